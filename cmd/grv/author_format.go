@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// AuthorDisplay describes how commit authors are displayed
+type AuthorDisplay int
+
+// The set of author display modes
+const (
+	AdName AuthorDisplay = iota
+	AdEmail
+	AdNameEmail
+	AdInitials
+)
+
+var authorDisplayNames = map[string]AuthorDisplay{
+	"name":      AdName,
+	"email":     AdEmail,
+	"nameemail": AdNameEmail,
+	"initials":  AdInitials,
+}
+
+// ParseAuthorDisplay returns the author display mode with the provided name
+func ParseAuthorDisplay(name string) (authorDisplay AuthorDisplay, err error) {
+	authorDisplay, ok := authorDisplayNames[strings.ToLower(name)]
+	if !ok {
+		err = fmt.Errorf("Invalid author display %v. Valid values are name, email, nameemail and initials", name)
+	}
+
+	return
+}
+
+// FormatAuthor returns the representation of the author specified by the display mode
+func FormatAuthor(name, email string, authorDisplay AuthorDisplay) string {
+	switch authorDisplay {
+	case AdEmail:
+		return email
+	case AdNameEmail:
+		return fmt.Sprintf("%v <%v>", name, email)
+	case AdInitials:
+		return authorInitials(name, email)
+	}
+
+	return name
+}
+
+func authorInitials(name, email string) string {
+	words := strings.Fields(name)
+	if len(words) == 0 {
+		words = strings.Fields(strings.SplitN(email, "@", 2)[0])
+	}
+
+	var initials bytes.Buffer
+
+	for _, word := range words {
+		if initial, _ := utf8.DecodeRuneInString(word); unicode.IsLetter(initial) {
+			initials.WriteRune(unicode.ToUpper(initial))
+		}
+	}
+
+	return initials.String()
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestFormatAuthor(t *testing.T) {
+	var formatAuthorTests = []struct {
+		name           string
+		email          string
+		authorDisplay  AuthorDisplay
+		expectedResult string
+	}{
+		{
+			name:           "Richard Burke",
+			email:          "rburke@example.com",
+			authorDisplay:  AdName,
+			expectedResult: "Richard Burke",
+		},
+		{
+			name:           "Richard Burke",
+			email:          "rburke@example.com",
+			authorDisplay:  AdEmail,
+			expectedResult: "rburke@example.com",
+		},
+		{
+			name:           "Richard Burke",
+			email:          "rburke@example.com",
+			authorDisplay:  AdNameEmail,
+			expectedResult: "Richard Burke <rburke@example.com>",
+		},
+		{
+			name:           "richard van burke (bot)",
+			email:          "rburke@example.com",
+			authorDisplay:  AdInitials,
+			expectedResult: "RVB",
+		},
+		{
+			name:           "",
+			email:          "rburke@example.com",
+			authorDisplay:  AdInitials,
+			expectedResult: "R",
+		},
+	}
+
+	for _, formatAuthorTest := range formatAuthorTests {
+		actualResult := FormatAuthor(formatAuthorTest.name, formatAuthorTest.email, formatAuthorTest.authorDisplay)
+
+		if actualResult != formatAuthorTest.expectedResult {
+			t.Errorf("FormatAuthor return value does not match expected value. Expected: %v, Actual: %v", formatAuthorTest.expectedResult, actualResult)
+		}
+	}
+}
+
+func TestParseAuthorDisplay(t *testing.T) {
+	if authorDisplay, err := ParseAuthorDisplay("Initials"); err != nil || authorDisplay != AdInitials {
+		t.Errorf("Expected initials author display but got: %v, %v", authorDisplay, err)
+	}
+
+	if _, err := ParseAuthorDisplay("invalid"); err == nil {
+		t.Errorf("Expected error for invalid author display")
+	}
+}
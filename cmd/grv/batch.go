@@ -0,0 +1,249 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	btPollInterval = time.Millisecond * 50
+	btSettleTime   = time.Millisecond * 250
+)
+
+// LoadingView is implemented by views which load their content asynchronously
+type LoadingView interface {
+	Loading() bool
+}
+
+// BatchRenderer renders the content of a view as plain text without a UI
+type BatchRenderer struct {
+	repoData          *RepositoryData
+	channels          gRVChannels
+	config            *Configuration
+	windowViewFactory *WindowViewFactory
+	errors            []error
+	lock              sync.Mutex
+}
+
+// NewBatchRenderer creates a new instance
+func NewBatchRenderer() *BatchRenderer {
+	grvChannels := gRVChannels{
+		exitCh:    make(chan bool),
+		actionCh:  make(chan Action, grvActionBufferSize),
+		eventCh:   make(chan Event, grvEventBufferSize),
+		displayCh: make(chan bool, grvDisplayBufferSize),
+		errorCh:   make(chan error, grvErrorBufferSize),
+	}
+
+	channels := grvChannels.Channels()
+
+	repoDataLoader := NewRepoDataLoader(channels)
+	repoData := NewRepositoryData(repoDataLoader, channels)
+	keyBindings := NewKeyBindingManager()
+	plugins := NewPluginManager(repoData, keyBindings, channels)
+	config := NewConfiguration(keyBindings, channels, plugins, NewExternalCommandManager(keyBindings), NewCommandCompletion(repoData))
+	repoData.SetConfig(config)
+
+	return &BatchRenderer{
+		repoData:          repoData,
+		channels:          grvChannels,
+		config:            config,
+		windowViewFactory: NewWindowViewFactory(repoData, channels, config, plugins),
+	}
+}
+
+// Initialise loads the repository and its refs
+func (batchRenderer *BatchRenderer) Initialise(repoPath string) (err error) {
+	log.Info("Initialising BatchRenderer")
+
+	go batchRenderer.discardActionsAndEvents()
+
+	if err = batchRenderer.repoData.Initialise(repoPath); err != nil {
+		return
+	}
+
+	if err = batchRenderer.repoData.LoadHead(); err != nil {
+		return
+	}
+
+	refsLoadedCh := make(chan bool)
+
+	batchRenderer.repoData.LoadRefs(func(refs []Ref) error {
+		close(refsLoadedCh)
+		return nil
+	})
+
+	<-refsLoadedCh
+
+	return
+}
+
+// LoadConfig loads the grvrc file so saved filters and display settings
+// such as date formats and column widths apply to the rendered output
+func (batchRenderer *BatchRenderer) LoadConfig() []error {
+	configErrors := batchRenderer.config.Initialise()
+
+	for _, configError := range configErrors {
+		log.Errorf("Error loading config: %v", configError)
+	}
+
+	return configErrors
+}
+
+// Free releases any resources used
+func (batchRenderer *BatchRenderer) Free() {
+	log.Info("Freeing BatchRenderer")
+
+	close(batchRenderer.channels.exitCh)
+	batchRenderer.repoData.Free()
+}
+
+// discardActionsAndEvents consumes messages views send to the UI so they never block.
+// Errors are stored so they can be returned once rendering is complete
+func (batchRenderer *BatchRenderer) discardActionsAndEvents() {
+	defer RecoverPanic()
+
+	channels := batchRenderer.channels
+
+	for {
+		select {
+		case action := <-channels.actionCh:
+			log.Debugf("Discarding action %v", action)
+		case event := <-channels.eventCh:
+			log.Debugf("Discarding event %v", event)
+		case <-channels.displayCh:
+		case err := <-channels.errorCh:
+			log.Errorf("Error channel received error: %v", err)
+			batchRenderer.lock.Lock()
+			batchRenderer.errors = append(batchRenderer.errors, err)
+			batchRenderer.lock.Unlock()
+		case _, ok := <-channels.exitCh:
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+// Render creates the view described by viewSpec (e.g. "CommitView master"),
+// waits for it to load and writes each of its lines to the provided writer
+func (batchRenderer *BatchRenderer) Render(viewSpec string, writer io.Writer) (err error) {
+	viewID, viewArgs, err := parseBatchViewSpec(viewSpec)
+	if err != nil {
+		return
+	}
+
+	if viewID == ViewCommit && len(viewArgs) == 0 {
+		return fmt.Errorf("CommitView requires a ref or oid argument")
+	}
+
+	windowView, err := batchRenderer.windowViewFactory.CreateWindowViewWithArgs(viewID, viewArgs)
+	if err != nil {
+		return
+	}
+
+	if err = windowView.Initialise(); err != nil {
+		return
+	}
+
+	searchInputProvidor, ok := windowView.(SearchInputProvidor)
+	if !ok {
+		return fmt.Errorf("View %v cannot be rendered as text", viewSpec)
+	}
+
+	lineNumber := waitForBatchViewToLoad(windowView, searchInputProvidor)
+
+	bufferedWriter := bufio.NewWriter(writer)
+
+	for lineIndex := uint(0); lineIndex < lineNumber; lineIndex++ {
+		line := strings.TrimRight(searchInputProvidor.Line(lineIndex), " ")
+
+		if _, err = fmt.Fprintln(bufferedWriter, line); err != nil {
+			return
+		}
+	}
+
+	if err = bufferedWriter.Flush(); err != nil {
+		return
+	}
+
+	batchRenderer.lock.Lock()
+	defer batchRenderer.lock.Unlock()
+
+	if len(batchRenderer.errors) > 0 {
+		err = batchRenderer.errors[0]
+	}
+
+	return
+}
+
+// waitForBatchViewToLoad blocks until the view has finished loading and
+// its line count has remained unchanged for the settle time
+func waitForBatchViewToLoad(windowView WindowView, searchInputProvidor SearchInputProvidor) (lineNumber uint) {
+	loadingView, isLoadingView := windowView.(LoadingView)
+	lineNumber = searchInputProvidor.LineNumber()
+	settled := time.Duration(0)
+
+	for settled < btSettleTime {
+		time.Sleep(btPollInterval)
+
+		currentLineNumber := searchInputProvidor.LineNumber()
+
+		if (isLoadingView && loadingView.Loading()) || currentLineNumber != lineNumber {
+			settled = 0
+		} else {
+			settled += btPollInterval
+		}
+
+		lineNumber = currentLineNumber
+	}
+
+	return
+}
+
+func parseBatchViewSpec(viewSpec string) (viewID ViewID, viewArgs []interface{}, err error) {
+	if strings.TrimSpace(viewSpec) == "" {
+		err = fmt.Errorf("No view specified")
+		return
+	}
+
+	parser := NewConfigParser(strings.NewReader(addviewCommand+" "+viewSpec), "")
+
+	command, _, err := parser.Parse()
+	if err != nil {
+		return
+	}
+
+	addViewCommand, ok := command.(*AddViewCommand)
+	if !ok {
+		err = fmt.Errorf("Invalid view: %v", viewSpec)
+		return
+	}
+
+	viewFound := false
+
+	for viewName, id := range viewIDNames {
+		if strings.EqualFold(viewName, addViewCommand.view.value) {
+			viewID = id
+			viewFound = true
+			break
+		}
+	}
+
+	if !viewFound {
+		err = fmt.Errorf("Invalid view: %v", addViewCommand.view.value)
+		return
+	}
+
+	for _, token := range addViewCommand.args {
+		viewArgs = append(viewArgs, token.value)
+	}
+
+	return
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBatchViewSpecIsParsed(t *testing.T) {
+	var viewSpecTests = []struct {
+		viewSpec         string
+		expectedViewID   ViewID
+		expectedViewArgs []interface{}
+	}{
+		{
+			viewSpec:         "CommitView master",
+			expectedViewID:   ViewCommit,
+			expectedViewArgs: []interface{}{"master"},
+		},
+		{
+			viewSpec:         "commitview origin/master",
+			expectedViewID:   ViewCommit,
+			expectedViewArgs: []interface{}{"origin/master"},
+		},
+		{
+			viewSpec:       "RefView",
+			expectedViewID: ViewRef,
+		},
+		{
+			viewSpec:         "CommandOutputView \"git log --oneline\"",
+			expectedViewID:   ViewCommandOutput,
+			expectedViewArgs: []interface{}{"git log --oneline"},
+		},
+	}
+
+	for _, viewSpecTest := range viewSpecTests {
+		viewID, viewArgs, err := parseBatchViewSpec(viewSpecTest.viewSpec)
+
+		if err != nil {
+			t.Errorf("parseBatchViewSpec failed with error %v", err)
+			continue
+		}
+
+		if viewID != viewSpecTest.expectedViewID {
+			t.Errorf("ViewID does not match expected value. Expected %v, Actual %v", viewSpecTest.expectedViewID, viewID)
+		}
+
+		if len(viewArgs) != len(viewSpecTest.expectedViewArgs) {
+			t.Errorf("View args do not match expected value. Expected %v, Actual %v", viewSpecTest.expectedViewArgs, viewArgs)
+			continue
+		}
+
+		for argIndex, viewArg := range viewArgs {
+			if viewArg != viewSpecTest.expectedViewArgs[argIndex] {
+				t.Errorf("View args do not match expected value. Expected %v, Actual %v", viewSpecTest.expectedViewArgs, viewArgs)
+			}
+		}
+	}
+}
+
+func TestErrorIsReturnedForInvalidBatchViewSpec(t *testing.T) {
+	var invalidViewSpecs = []string{
+		"",
+		"NoSuchView",
+		"\"CommitView",
+	}
+
+	for _, invalidViewSpec := range invalidViewSpecs {
+		if _, _, err := parseBatchViewSpec(invalidViewSpec); err == nil {
+			t.Errorf("Expected parseBatchViewSpec to return an error for view spec \"%v\"", invalidViewSpec)
+		}
+	}
+}
+
+func TestErrorIsReturnedForInvalidJSONSpec(t *testing.T) {
+	var invalidJSONSpecs = []string{
+		"",
+		"branches",
+		"refs master",
+		"commits master develop",
+	}
+
+	batchRenderer := &BatchRenderer{}
+
+	for _, invalidJSONSpec := range invalidJSONSpecs {
+		if err := batchRenderer.RenderJSON(invalidJSONSpec, "", &bytes.Buffer{}); err == nil {
+			t.Errorf("Expected RenderJSON to return an error for JSON spec \"%v\"", invalidJSONSpec)
+		}
+	}
+}
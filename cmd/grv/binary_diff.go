@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	bdBinaryFilesPrefix = "Binary files "
+	bdSizeUnits         = "KMGT"
+)
+
+// binaryDiffSummary describes the change in size of a binary file
+func binaryDiffSummary(oldSize, newSize int, added, deleted bool) string {
+	switch {
+	case added:
+		return fmt.Sprintf("Binary file added: %v", formatFileSize(newSize))
+	case deleted:
+		return fmt.Sprintf("Binary file deleted: %v", formatFileSize(oldSize))
+	case oldSize == newSize:
+		return fmt.Sprintf("Binary file changed: %v (size unchanged)", formatFileSize(newSize))
+	}
+
+	sign := "+"
+	sizeChange := newSize - oldSize
+
+	if sizeChange < 0 {
+		sign = "-"
+		sizeChange = -sizeChange
+	}
+
+	return fmt.Sprintf("Binary file changed: %v -> %v (%v%v)", formatFileSize(oldSize), formatFileSize(newSize), sign, formatFileSize(sizeChange))
+}
+
+// formatFileSize formats the size in bytes using binary unit prefixes
+func formatFileSize(size int) string {
+	if size < 1024 {
+		return fmt.Sprintf("%v B", size)
+	}
+
+	value := float64(size)
+	unitIndex := -1
+
+	for value >= 1024 && unitIndex < len(bdSizeUnits)-1 {
+		value /= 1024
+		unitIndex++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", value, bdSizeUnits[unitIndex])
+}
+
+// replaceBinaryFilesLine replaces the "Binary files ... differ" line of a patch with the provided summary
+func replaceBinaryFilesLine(patch, summary string) string {
+	lines := strings.SplitAfter(patch, "\n")
+
+	for lineIndex, line := range lines {
+		if strings.HasPrefix(line, bdBinaryFilesPrefix) {
+			lines[lineIndex] = summary + "\n"
+		}
+	}
+
+	return strings.Join(lines, "")
+}
+
+// diffHeaderPath returns the new path of the file from a git or combined diff header line
+func diffHeaderPath(line string) (path string) {
+	switch {
+	case strings.HasPrefix(line, dvCombinedDiffHeaderPrefix):
+		return strings.TrimPrefix(line, dvCombinedDiffHeaderPrefix)
+	case strings.HasPrefix(line, dvGitDiffHeaderPrefix):
+		if index := strings.LastIndex(line, " b/"); index != -1 {
+			return line[index+len(" b/"):]
+		}
+	}
+
+	return
+}
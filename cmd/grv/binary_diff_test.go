@@ -0,0 +1,51 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestBinaryDiffSummaryDescribesSizeChange(t *testing.T) {
+	tests := []struct {
+		oldSize         int
+		newSize         int
+		added           bool
+		deleted         bool
+		expectedSummary string
+	}{
+		{oldSize: 0, newSize: 512, added: true, expectedSummary: "Binary file added: 512 B"},
+		{oldSize: 2048, newSize: 0, deleted: true, expectedSummary: "Binary file deleted: 2.0 KiB"},
+		{oldSize: 1024, newSize: 3584, expectedSummary: "Binary file changed: 1.0 KiB -> 3.5 KiB (+2.5 KiB)"},
+		{oldSize: 3 * 1024 * 1024, newSize: 1024 * 1024, expectedSummary: "Binary file changed: 3.0 MiB -> 1.0 MiB (-2.0 MiB)"},
+		{oldSize: 100, newSize: 100, expectedSummary: "Binary file changed: 100 B (size unchanged)"},
+	}
+
+	for _, test := range tests {
+		if summary := binaryDiffSummary(test.oldSize, test.newSize, test.added, test.deleted); summary != test.expectedSummary {
+			t.Errorf("Summary does not match expected value. Expected: %q, Actual: %q", test.expectedSummary, summary)
+		}
+	}
+}
+
+func TestBinaryFilesLineIsReplacedWithSummary(t *testing.T) {
+	patch := "diff --git a/image.png b/image.png\nindex 1234567..89abcde 100644\nBinary files a/image.png and b/image.png differ\n"
+	expectedPatch := "diff --git a/image.png b/image.png\nindex 1234567..89abcde 100644\nBinary file changed: 1.0 KiB -> 2.0 KiB (+1.0 KiB)\n"
+
+	if replacedPatch := replaceBinaryFilesLine(patch, "Binary file changed: 1.0 KiB -> 2.0 KiB (+1.0 KiB)"); replacedPatch != expectedPatch {
+		t.Errorf("Patch does not match expected value. Expected: %q, Actual: %q", expectedPatch, replacedPatch)
+	}
+}
+
+func TestDiffHeaderPathReturnsNewPath(t *testing.T) {
+	tests := map[string]string{
+		"diff --git a/old.png b/new.png":         "new.png",
+		"diff --git a/dir/a b.png b/dir/a b.png": "dir/a b.png",
+		"diff --cc merged.png":                   "merged.png",
+		"index 1234567..89abcde":                 "",
+	}
+
+	for line, expectedPath := range tests {
+		if path := diffHeaderPath(line); path != expectedPath {
+			t.Errorf("Path does not match expected value for %q. Expected: %q, Actual: %q", line, expectedPath, path)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// BisectTerm is the verdict given to a commit during a bisect
+type BisectTerm int
+
+// The set of bisect terms
+const (
+	BtGood BisectTerm = iota
+	BtBad
+	BtSkip
+)
+
+var bisectTermNames = map[BisectTerm]string{
+	BtGood: "good",
+	BtBad:  "bad",
+	BtSkip: "skip",
+}
+
+// String returns the git bisect subcommand for the term
+func (bisectTerm BisectTerm) String() string {
+	return bisectTermNames[bisectTerm]
+}
+
+var bisectProgressRegex = regexp.MustCompile(`Bisecting: ([0-9]+) revisions? left to test after this \(roughly ([0-9]+) steps?\)`)
+var bisectCurrentCommitRegex = regexp.MustCompile(`(?m)^\[([0-9a-f]{40})\]`)
+var bisectCulpritRegex = regexp.MustCompile(`(?m)^([0-9a-f]{40}) is the first bad commit`)
+var bisectWaitingRegex = regexp.MustCompile(`(?m)^status: (waiting for .*)$`)
+
+// BisectStatus is the progress of a bisect as reported by git bisect
+type BisectStatus struct {
+	revisionsLeft uint
+	steps         uint
+	currentOid    string
+	culpritOid    string
+	waiting       string
+}
+
+// parseBisectOutput extracts the progress of a bisect from the output of git bisect
+func parseBisectOutput(output string) *BisectStatus {
+	bisectStatus := &BisectStatus{}
+
+	if matches := bisectCulpritRegex.FindStringSubmatch(output); matches != nil {
+		bisectStatus.culpritOid = matches[1]
+		return bisectStatus
+	}
+
+	if matches := bisectProgressRegex.FindStringSubmatch(output); matches != nil {
+		revisionsLeft, _ := strconv.ParseUint(matches[1], 10, 32)
+		steps, _ := strconv.ParseUint(matches[2], 10, 32)
+		bisectStatus.revisionsLeft = uint(revisionsLeft)
+		bisectStatus.steps = uint(steps)
+	}
+
+	if matches := bisectCurrentCommitRegex.FindStringSubmatch(output); matches != nil {
+		bisectStatus.currentOid = matches[1]
+	}
+
+	if matches := bisectWaitingRegex.FindStringSubmatch(output); matches != nil {
+		bisectStatus.waiting = strings.TrimSpace(matches[1])
+	}
+
+	return bisectStatus
+}
+
+// Complete returns true if the first bad commit has been found
+func (bisectStatus *BisectStatus) Complete() bool {
+	return bisectStatus.culpritOid != ""
+}
+
+// SelectedOid returns the commit which should be selected after the bisect step,
+// i.e. the first bad commit if it has been found, otherwise the commit to test next
+func (bisectStatus *BisectStatus) SelectedOid() string {
+	if bisectStatus.Complete() {
+		return bisectStatus.culpritOid
+	}
+
+	return bisectStatus.currentOid
+}
+
+// String returns a description of the bisect progress
+func (bisectStatus *BisectStatus) String() string {
+	switch {
+	case bisectStatus.Complete():
+		return fmt.Sprintf("Bisect complete: %v is the first bad commit", abbreviateOid(bisectStatus.culpritOid))
+	case bisectStatus.currentOid != "":
+		return fmt.Sprintf("Bisecting: testing %v, %v revisions left after this (roughly %v steps)",
+			abbreviateOid(bisectStatus.currentOid), bisectStatus.revisionsLeft, bisectStatus.steps)
+	case bisectStatus.waiting != "":
+		return fmt.Sprintf("Bisecting: %v", bisectStatus.waiting)
+	}
+
+	return "Bisecting"
+}
+
+// SegmentText returns the summary of the bisect progress displayed in the status bar
+func (bisectStatus *BisectStatus) SegmentText() string {
+	switch {
+	case bisectStatus.Complete():
+		return fmt.Sprintf("Bisect: found %v", abbreviateOid(bisectStatus.culpritOid))
+	case bisectStatus.currentOid != "":
+		return fmt.Sprintf("Bisect: ~%v steps left", bisectStatus.steps)
+	}
+
+	return "Bisect"
+}
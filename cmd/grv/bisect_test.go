@@ -0,0 +1,52 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestBisectProgressIsParsedFromOutput(t *testing.T) {
+	output := "Bisecting: 6 revisions left to test after this (roughly 3 steps)\n" +
+		"[4882ca9044661b49a26ae03ceb1be3a70d00c6a2] Add changelog command\n"
+
+	bisectStatus := parseBisectOutput(output)
+
+	if bisectStatus.Complete() {
+		t.Errorf("Expected bisect to be in progress")
+	}
+
+	if bisectStatus.revisionsLeft != 6 || bisectStatus.steps != 3 {
+		t.Errorf("Expected 6 revisions and 3 steps left but got %v revisions and %v steps", bisectStatus.revisionsLeft, bisectStatus.steps)
+	}
+
+	if expectedOid := "4882ca9044661b49a26ae03ceb1be3a70d00c6a2"; bisectStatus.SelectedOid() != expectedOid {
+		t.Errorf("Expected selected oid %v but got %v", expectedOid, bisectStatus.SelectedOid())
+	}
+}
+
+func TestBisectCulpritIsParsedFromOutput(t *testing.T) {
+	output := "c5f1a8e1b4b1c0d5e4b7a2a8f9e0d1c2b3a4f5e6 is the first bad commit\n" +
+		"commit c5f1a8e1b4b1c0d5e4b7a2a8f9e0d1c2b3a4f5e6\n" +
+		"Author: A U Thor <author@example.com>\n"
+
+	bisectStatus := parseBisectOutput(output)
+
+	if !bisectStatus.Complete() {
+		t.Errorf("Expected bisect to be complete")
+	}
+
+	if expectedOid := "c5f1a8e1b4b1c0d5e4b7a2a8f9e0d1c2b3a4f5e6"; bisectStatus.SelectedOid() != expectedOid {
+		t.Errorf("Expected selected oid %v but got %v", expectedOid, bisectStatus.SelectedOid())
+	}
+}
+
+func TestBisectWaitingStatusIsParsedFromOutput(t *testing.T) {
+	bisectStatus := parseBisectOutput("status: waiting for good commit(s), bad commit known\n")
+
+	if bisectStatus.SelectedOid() != "" {
+		t.Errorf("Expected no commit to be selected but got %v", bisectStatus.SelectedOid())
+	}
+
+	if expected := "Bisecting: waiting for good commit(s), bad commit known"; bisectStatus.String() != expected {
+		t.Errorf("Expected status %q but got %q", expected, bisectStatus.String())
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	blAuthor     = "author "
+	blAuthorTime = "author-time "
+	blSummary    = "summary "
+	blPrevious   = "previous "
+	blFilename   = "filename "
+	blBoundary   = "boundary"
+)
+
+// BlameLine is a line of a file and the commit which last modified it
+type BlameLine struct {
+	oid          string
+	path         string
+	originalLine int
+	finalLine    int
+	author       string
+	authorTime   time.Time
+	summary      string
+	previousOid  string
+	previousPath string
+	boundary     bool
+	content      string
+}
+
+// ShortOid returns the abbreviated oid of the commit which last modified the line
+func (blameLine *BlameLine) ShortOid() string {
+	return abbreviateOid(blameLine.oid)
+}
+
+// ParentRevision returns the parent of the commit which last modified the line and
+// the path of the file in the parent. exists is false if the line was added in a
+// commit without a parent or in a boundary commit
+func (blameLine *BlameLine) ParentRevision() (oid, path string, exists bool) {
+	if blameLine.previousOid == "" {
+		return
+	}
+
+	return blameLine.previousOid, blameLine.previousPath, true
+}
+
+// parseBlame parses the output of git blame --line-porcelain
+func parseBlame(output []byte) (blameLines []*BlameLine, err error) {
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var blameLine *BlameLine
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if blameLine == nil {
+			if blameLine, err = parseBlameHeader(line); err != nil {
+				return
+			}
+
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "\t"):
+			blameLine.content = line[1:]
+			blameLines = append(blameLines, blameLine)
+			blameLine = nil
+		case strings.HasPrefix(line, blAuthorTime):
+			var seconds int64
+			if seconds, err = strconv.ParseInt(strings.TrimPrefix(line, blAuthorTime), 10, 64); err != nil {
+				err = fmt.Errorf("Invalid blame author time: %v", line)
+				return
+			}
+
+			blameLine.authorTime = time.Unix(seconds, 0)
+		case strings.HasPrefix(line, blAuthor):
+			blameLine.author = strings.TrimPrefix(line, blAuthor)
+		case strings.HasPrefix(line, blSummary):
+			blameLine.summary = strings.TrimPrefix(line, blSummary)
+		case strings.HasPrefix(line, blPrevious):
+			fields := strings.SplitN(strings.TrimPrefix(line, blPrevious), " ", 2)
+			if len(fields) != 2 {
+				err = fmt.Errorf("Invalid blame previous commit: %v", line)
+				return
+			}
+
+			blameLine.previousOid, blameLine.previousPath = fields[0], fields[1]
+		case strings.HasPrefix(line, blFilename):
+			blameLine.path = strings.TrimPrefix(line, blFilename)
+		case line == blBoundary:
+			blameLine.boundary = true
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		return
+	}
+
+	if blameLine != nil {
+		err = fmt.Errorf("Incomplete blame output for line %v", blameLine.finalLine)
+	}
+
+	return
+}
+
+func parseBlameHeader(line string) (blameLine *BlameLine, err error) {
+	fields := strings.Fields(line)
+	if len(fields) < 3 || !hexRegexp.MatchString(fields[0]) {
+		return nil, fmt.Errorf("Invalid blame header: %v", line)
+	}
+
+	blameLine = &BlameLine{oid: fields[0]}
+
+	if blameLine.originalLine, err = strconv.Atoi(fields[1]); err != nil {
+		return nil, fmt.Errorf("Invalid blame header: %v", line)
+	}
+
+	if blameLine.finalLine, err = strconv.Atoi(fields[2]); err != nil {
+		return nil, fmt.Errorf("Invalid blame header: %v", line)
+	}
+
+	return
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+const testBlameOutput = `3b18e512dba79e4c8300dd08aeb37f8e728b8dad 1 1 2
+author Jane Doe
+author-mail <jane@example.com>
+author-time 1500000000
+author-tz +0000
+committer Jane Doe
+committer-mail <jane@example.com>
+committer-time 1500000000
+committer-tz +0000
+summary Reformat main.go
+previous 8f1a4ee0f8a3a1c3d2a0f2d5c7b5f0e3b2a1c4d5 src/main.go
+filename main.go
+	package main
+3b18e512dba79e4c8300dd08aeb37f8e728b8dad 2 2
+author Jane Doe
+author-mail <jane@example.com>
+author-time 1500000000
+author-tz +0000
+committer Jane Doe
+committer-mail <jane@example.com>
+committer-time 1500000000
+committer-tz +0000
+summary Reformat main.go
+previous 8f1a4ee0f8a3a1c3d2a0f2d5c7b5f0e3b2a1c4d5 src/main.go
+filename main.go
+	
+c6f2b9a2d8e4f1a0b3c5d7e9f1a2b4c6d8e0f2a4 1 3 1
+author John Smith
+author-mail <john@example.com>
+author-time 1400000000
+author-tz +0000
+committer John Smith
+committer-mail <john@example.com>
+committer-time 1400000000
+committer-tz +0000
+summary Initial commit
+boundary
+filename main.go
+	func main() {}
+`
+
+func TestBlameOutputIsParsed(t *testing.T) {
+	blameLines, err := parseBlame([]byte(testBlameOutput))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(blameLines) != 3 {
+		t.Fatalf("Blame line count does not match expected value. Expected: 3, Actual: %v", len(blameLines))
+	}
+
+	blameLine := blameLines[0]
+
+	if blameLine.ShortOid() != "3b18e51" || blameLine.author != "Jane Doe" || blameLine.summary != "Reformat main.go" ||
+		blameLine.content != "package main" || blameLine.path != "main.go" || blameLine.finalLine != 1 {
+		t.Errorf("Blame line does not match expected value: %+v", blameLine)
+	}
+
+	if !blameLine.authorTime.Equal(time.Unix(1500000000, 0)) {
+		t.Errorf("Blame author time does not match expected value. Expected: %v, Actual: %v", time.Unix(1500000000, 0), blameLine.authorTime)
+	}
+
+	if blameLines[1].content != "" {
+		t.Errorf("Expected empty line content but found: %v", blameLines[1].content)
+	}
+
+	if blameLine = blameLines[2]; blameLine.originalLine != 1 || blameLine.finalLine != 3 || !blameLine.boundary {
+		t.Errorf("Blame line does not match expected value: %+v", blameLine)
+	}
+}
+
+func TestBlameParentRevisionIsTheRevisionBeforeTheLineWasModified(t *testing.T) {
+	blameLines, err := parseBlame([]byte(testBlameOutput))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	oid, path, exists := blameLines[0].ParentRevision()
+	if !exists || oid != "8f1a4ee0f8a3a1c3d2a0f2d5c7b5f0e3b2a1c4d5" || path != "src/main.go" {
+		t.Errorf("Parent revision does not match expected value. Actual: %v %v %v", oid, path, exists)
+	}
+
+	if _, _, exists = blameLines[2].ParentRevision(); exists {
+		t.Errorf("Expected no parent revision for line added in a boundary commit")
+	}
+}
+
+func TestIncompleteBlameOutputReturnsAnError(t *testing.T) {
+	if _, err := parseBlame([]byte("3b18e512dba79e4c8300dd08aeb37f8e728b8dad 1 1 1\nauthor Jane Doe\n")); err == nil {
+		t.Errorf("Expected error for incomplete blame output")
+	}
+
+	if _, err := parseBlame([]byte("not a header\n")); err == nil {
+		t.Errorf("Expected error for invalid blame header")
+	}
+}
@@ -0,0 +1,344 @@
+package main
+
+import (
+	"fmt"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	bvColumnNum    = 5
+	bvAuthorColumn = 2
+	bvOidLength    = 40
+)
+
+type blameViewHandler func(*BlameView, Action) error
+
+// BlameView displays the commit which last modified each line of a file at a revision
+type BlameView struct {
+	*ListView
+	repoData       RepoData
+	config         Config
+	revision       string
+	path           string
+	selectLine     int
+	blameLines     []*BlameLine
+	loading        bool
+	loadErr        error
+	active         bool
+	tableFormatter *TableFormatter
+	handlers       map[ActionType]blameViewHandler
+}
+
+// NewBlameView creates a new instance which blames the file at the provided path and revision.
+// The provided line number is selected once the blame has loaded
+func NewBlameView(revision, path string, lineNumber int, repoData RepoData, channels *Channels, config Config) *BlameView {
+	blameView := &BlameView{
+		ListView:       NewListView(channels),
+		repoData:       repoData,
+		config:         config,
+		revision:       revision,
+		path:           path,
+		selectLine:     lineNumber,
+		tableFormatter: NewTableFormatter(bvColumnNum),
+		handlers: map[ActionType]blameViewHandler{
+			ActionReblameParent: reblameParent,
+			ActionLineHistory:   showBlameLineHistory,
+		},
+	}
+
+	blameView.viewSearch = NewViewSearch(blameView, channels)
+
+	return blameView
+}
+
+// Initialise loads the blame for the file
+func (blameView *BlameView) Initialise() (err error) {
+	log.Debugf("Initialising BlameView for %v at %v", blameView.path, blameView.revision)
+
+	blameView.lock.Lock()
+	defer blameView.lock.Unlock()
+
+	blameView.loadBlame()
+
+	return
+}
+
+func (blameView *BlameView) loadBlame() {
+	if blameView.loading {
+		return
+	}
+
+	blameView.loading = true
+	revision, path := blameView.revision, blameView.path
+
+	go func() {
+		defer RecoverPanic()
+
+		blameLines, err := blameView.repoData.Blame(revision, path)
+
+		blameView.lock.Lock()
+		blameView.setBlameLines(blameLines)
+		blameView.loadErr = err
+		blameView.loading = false
+		blameView.lock.Unlock()
+
+		if err != nil {
+			blameView.channels.ReportError(err)
+		}
+
+		blameView.channels.UpdateDisplay()
+	}()
+}
+
+func (blameView *BlameView) setBlameLines(blameLines []*BlameLine) {
+	blameView.blameLines = blameLines
+
+	lineNumber := uint(len(blameView.blameLines))
+	viewPos := blameView.viewPos
+
+	if lineNumber == 0 {
+		viewPos.SetActiveRowIndex(0)
+		return
+	}
+
+	if blameView.selectLine > 0 {
+		viewPos.SetActiveRowIndex(MinUint(uint(blameView.selectLine-1), lineNumber-1))
+		blameView.selectLine = 0
+
+		viewPos.CenterActiveRow(blameView.pageRows())
+	} else if viewPos.ActiveRowIndex() >= lineNumber {
+		viewPos.SetActiveRowIndex(lineNumber - 1)
+	}
+}
+
+// Render generates and writes the blame view to the provided window
+func (blameView *BlameView) Render(win RenderWindow) (err error) {
+	blameView.lock.Lock()
+	defer blameView.lock.Unlock()
+
+	log.Debugf("Rendering BlameView for %v at %v", blameView.path, blameView.revision)
+
+	blameView.viewDimension = win.ViewDimensions()
+
+	lineNumber := uint(len(blameView.blameLines))
+	rows := win.Rows() - 2
+
+	viewPos := blameView.viewPos
+	viewPos.DetermineViewStartRow(rows, lineNumber, uint(blameView.config.GetInt(CfScrollOff)))
+	lineIndex := viewPos.ViewStartRowIndex()
+
+	tableFormatter := blameView.tableFormatter
+	tableFormatter.Resize(MinUint(rows, lineNumber-lineIndex))
+	tableFormatter.Clear()
+	tableFormatter.SetColWidthLimit(bvAuthorColumn, uint(blameView.config.GetInt(CfCommitAuthorWidth)))
+
+	dateFormatter := NewDateFormatter(blameView.config)
+
+	for rowIndex := uint(0); rowIndex < rows && lineIndex < lineNumber; rowIndex++ {
+		if err = blameView.renderBlameLine(tableFormatter, dateFormatter, rowIndex, blameView.blameLines[lineIndex]); err != nil {
+			return
+		}
+
+		lineIndex++
+	}
+
+	if err = tableFormatter.Render(win, viewPos.ViewStartColumn(), true); err != nil {
+		return
+	}
+
+	if lineNumber > 0 {
+		if err = win.SetSelectedRow(viewPos.SelectedRowIndex()+1, blameView.active); err != nil {
+			return
+		}
+	}
+
+	win.DrawBorder()
+
+	if err = win.SetTitle(CmpBlameviewTitle, "Blame %v at %v", blameView.path, blameView.revisionDescription()); err != nil {
+		return
+	}
+
+	switch {
+	case blameView.loading:
+		err = win.SetFooter(CmpBlameviewFooter, "Loading...")
+	case blameView.loadErr != nil:
+		err = win.SetFooter(CmpBlameviewFooter, "%v", blameView.loadErr)
+	case lineNumber > 0:
+		err = win.SetFooter(CmpBlameviewFooter, "Line %v of %v", viewPos.ActiveRowIndex()+1, lineNumber)
+	}
+
+	if err != nil {
+		return
+	}
+
+	err = blameView.RenderSearchHighlight(win)
+
+	return
+}
+
+func (blameView *BlameView) renderBlameLine(tableFormatter *TableFormatter, dateFormatter *DateFormatter, rowIndex uint, blameLine *BlameLine) (err error) {
+	if err = tableFormatter.SetCellWithStyle(rowIndex, 0, CmpBlameviewShortOid, "%v", blameLine.ShortOid()); err != nil {
+		return
+	}
+
+	if err = tableFormatter.SetCellWithStyle(rowIndex, 1, CmpBlameviewDate, "%v", dateFormatter.Format(blameLine.authorTime)); err != nil {
+		return
+	}
+
+	if err = tableFormatter.SetCellWithStyle(rowIndex, bvAuthorColumn, CmpBlameviewAuthor, "%v", blameLine.author); err != nil {
+		return
+	}
+
+	if err = tableFormatter.SetCellWithStyle(rowIndex, 3, CmpBlameviewLineNumber, "%v", blameLine.finalLine); err != nil {
+		return
+	}
+
+	return tableFormatter.SetCell(rowIndex, 4, "%v", blameLine.content)
+}
+
+func (blameView *BlameView) revisionDescription() string {
+	if len(blameView.revision) == bvOidLength && hexRegexp.MatchString(blameView.revision) {
+		return abbreviateOid(blameView.revision)
+	}
+
+	return blameView.revision
+}
+
+// RenderHelpBar renders key binding help for the blame view
+func (blameView *BlameView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
+	RenderKeyBindingHelp(blameView.ViewID(), lineBuilder, []ActionMessage{
+		{action: ActionReblameParent, message: "Blame parent"},
+		{action: ActionLineHistory, message: "Line history"},
+	})
+
+	return
+}
+
+// HandleEvent does nothing
+func (blameView *BlameView) HandleEvent(event Event) (err error) {
+	return
+}
+
+// OnActiveChange updates whether this view is currently active
+func (blameView *BlameView) OnActiveChange(active bool) {
+	log.Debugf("BlameView active: %v", active)
+	blameView.lock.Lock()
+	defer blameView.lock.Unlock()
+
+	blameView.active = active
+}
+
+// ViewID returns the ViewID for the blame view
+func (blameView *BlameView) ViewID() ViewID {
+	return ViewBlame
+}
+
+// Line returns the rendered line at the specified index
+func (blameView *BlameView) Line(lineIndex uint) (line string) {
+	blameView.lock.Lock()
+	defer blameView.lock.Unlock()
+
+	lineNumber := uint(len(blameView.blameLines))
+	if lineIndex >= lineNumber {
+		log.Errorf("Invalid lineIndex: %v >= %v", lineIndex, lineNumber)
+		return
+	}
+
+	blameLine := blameView.blameLines[lineIndex]
+
+	return fmt.Sprintf("%v %v %v %v", blameLine.ShortOid(), blameLine.author, blameLine.finalLine, blameLine.content)
+}
+
+// LineNumber returns the number of lines in the view
+func (blameView *BlameView) LineNumber() (lineNumber uint) {
+	blameView.lock.Lock()
+	defer blameView.lock.Unlock()
+
+	return uint(len(blameView.blameLines))
+}
+
+// HandleAction checks if the blame view supports this action and if it does executes it
+func (blameView *BlameView) HandleAction(action Action) (err error) {
+	blameView.lock.Lock()
+	defer blameView.lock.Unlock()
+
+	if handler, ok := blameView.handlers[action.ActionType]; ok {
+		log.Debugf("BlameView handling action %v", action)
+		err = handler(blameView, action)
+	} else {
+		_, err = blameView.HandleListAction(action, uint(len(blameView.blameLines)))
+	}
+
+	return
+}
+
+func (blameView *BlameView) selectedBlameLine() (blameLine *BlameLine, exists bool) {
+	activeRowIndex := blameView.viewPos.ActiveRowIndex()
+
+	if activeRowIndex >= uint(len(blameView.blameLines)) {
+		return
+	}
+
+	return blameView.blameLines[activeRowIndex], true
+}
+
+// reblameParent blames the file at the parent of the commit which last modified the selected line.
+// This allows the history of a line to be followed past commits which only reformatted it
+func reblameParent(blameView *BlameView, action Action) (err error) {
+	if blameView.loading {
+		blameView.channels.ReportStatus("Blame is loading")
+		return
+	}
+
+	blameLine, exists := blameView.selectedBlameLine()
+	if !exists {
+		return
+	}
+
+	parentOid, parentPath, exists := blameLine.ParentRevision()
+	if !exists {
+		blameView.channels.ReportWarning("Commit %v has no parent to blame", blameLine.ShortOid())
+		return
+	}
+
+	log.Debugf("Blaming %v at %v, the parent of %v", parentPath, parentOid, blameLine.oid)
+
+	blameView.revision = parentOid
+	blameView.path = parentPath
+	blameView.selectLine = blameLine.originalLine
+	blameView.loadBlame()
+	blameView.channels.UpdateDisplay()
+
+	return
+}
+
+// showBlameLineHistory shows the history of the selected line starting at the commit which last modified it
+func showBlameLineHistory(blameView *BlameView, action Action) (err error) {
+	blameLine, exists := blameView.selectedBlameLine()
+	if !exists {
+		return
+	}
+
+	lineRange := LineRange{
+		path:     blameLine.path,
+		start:    blameLine.originalLine,
+		end:      blameLine.originalLine,
+		revision: blameLine.oid,
+	}
+
+	blameView.channels.DoAction(Action{
+		ActionType: ActionSplitView,
+		Args: []interface{}{
+			ActionSplitViewArgs{
+				CreateViewArgs: CreateViewArgs{
+					viewID:   ViewDiff,
+					viewArgs: []interface{}{lineRange},
+				},
+				orientation: CoDynamic,
+			},
+		},
+	})
+
+	return
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+var browserCommands = []string{"xdg-open", "open", "wslview"}
+
+// OpenInBrowser opens the URL using the command specified by the BROWSER environment variable,
+// falling back to the first available system URL opener
+func OpenInBrowser(url string) error {
+	browserCommand := os.Getenv("BROWSER")
+
+	if browserCommand == "" {
+		for _, command := range browserCommands {
+			if _, err := exec.LookPath(command); err == nil {
+				browserCommand = command
+				break
+			}
+		}
+	}
+
+	if browserCommand == "" {
+		return fmt.Errorf("No browser found. Set the BROWSER environment variable")
+	}
+
+	log.Debugf("Opening %v using %v", url, browserCommand)
+
+	if err := exec.Command(browserCommand, url).Start(); err != nil {
+		return fmt.Errorf("Unable to open %v using %v: %v", url, browserCommand, err)
+	}
+
+	return nil
+}
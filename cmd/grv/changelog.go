@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	slice "github.com/bradfitz/slice"
+)
+
+const (
+	clBreakingChangesTitle = "Breaking Changes"
+	clOtherChangesTitle    = "Other Changes"
+)
+
+var changelogBreakingChangeKeys = []string{"BREAKING CHANGE:", "BREAKING-CHANGE:"}
+
+var changelogCommitTypes = []struct {
+	commitType string
+	title      string
+}{
+	{commitType: "feat", title: "Features"},
+	{commitType: "fix", title: "Bug Fixes"},
+	{commitType: "perf", title: "Performance Improvements"},
+	{commitType: "refactor", title: "Code Refactoring"},
+	{commitType: "revert", title: "Reverts"},
+	{commitType: "docs", title: "Documentation"},
+	{commitType: "style", title: "Styles"},
+	{commitType: "test", title: "Tests"},
+	{commitType: "build", title: "Build System"},
+	{commitType: "ci", title: "Continuous Integration"},
+	{commitType: "chore", title: "Chores"},
+}
+
+// ChangelogEntry is a commit included in a changelog
+type ChangelogEntry struct {
+	shortID string
+	message string
+}
+
+type changelogSection struct {
+	title   string
+	order   int
+	entries []string
+}
+
+// Changelog groups the commits between two refs either by conventional commit type
+// or, if a trailer key is provided, by the value of that trailer
+type Changelog struct {
+	from       string
+	to         string
+	trailerKey string
+	entries    []*ChangelogEntry
+}
+
+// NewChangelog creates a new instance for the commits reachable from to but not from
+func NewChangelog(from, to, trailerKey string) *Changelog {
+	return &Changelog{
+		from:       from,
+		to:         to,
+		trailerKey: trailerKey,
+	}
+}
+
+// Range returns the commit range the changelog is generated for
+func (changelog *Changelog) Range() string {
+	return changelog.from + ".." + changelog.to
+}
+
+// AddCommit adds the commit to the changelog. Merge commits are ignored
+func (changelog *Changelog) AddCommit(commit *Commit) {
+	if commit.commit.ParentCount() > 1 {
+		return
+	}
+
+	changelog.entries = append(changelog.entries, &ChangelogEntry{
+		shortID: commit.oid.ShortID(),
+		message: commit.commit.Message(),
+	})
+}
+
+// Lines returns the changelog as markdown
+func (changelog *Changelog) Lines() (lines []string) {
+	lines = append(lines, fmt.Sprintf("# Changelog %v", changelog.Range()))
+
+	if len(changelog.entries) == 0 {
+		return append(lines, "", "No changes")
+	}
+
+	for _, section := range changelog.sections() {
+		lines = append(lines, "", "## "+section.title, "")
+		lines = append(lines, section.entries...)
+	}
+
+	return
+}
+
+func (changelog *Changelog) sections() []*changelogSection {
+	sections := make(map[string]*changelogSection)
+
+	for _, entry := range changelog.entries {
+		var title, description string
+		var order int
+
+		if changelog.trailerKey != "" {
+			title, order, description = changelog.trailerSection(entry)
+		} else {
+			title, order, description = changelog.commitTypeSection(entry)
+		}
+
+		section, exists := sections[title]
+		if !exists {
+			section = &changelogSection{title: title, order: order}
+			sections[title] = section
+		}
+
+		section.entries = append(section.entries, fmt.Sprintf("- %v (%v)", description, entry.shortID))
+	}
+
+	var orderedSections []*changelogSection
+	for _, section := range sections {
+		orderedSections = append(orderedSections, section)
+	}
+
+	slice.Sort(orderedSections, func(i, j int) bool {
+		if orderedSections[i].order != orderedSections[j].order {
+			return orderedSections[i].order < orderedSections[j].order
+		}
+
+		return orderedSections[i].title < orderedSections[j].title
+	})
+
+	return orderedSections
+}
+
+// commitTypeSection groups breaking changes first, followed by the known commit types,
+// then any other commit types and finally commits which are not conventional commits
+func (changelog *Changelog) commitTypeSection(entry *ChangelogEntry) (title string, order int, description string) {
+	summary := strings.TrimSpace(strings.SplitN(entry.message, "\n", 2)[0])
+
+	conventionalCommit, ok := parseConventionalCommit(summary)
+	if !ok {
+		return clOtherChangesTitle, len(changelogCommitTypes) + 2, summary
+	}
+
+	description = strings.TrimSpace(strings.TrimPrefix(summary, conventionalCommit.prefix))
+	if conventionalCommit.scope != "" {
+		description = conventionalCommit.scope + ": " + description
+	}
+
+	if conventionalCommit.breaking || isBreakingChange(entry.message) {
+		return clBreakingChangesTitle, 0, description
+	}
+
+	for index, changelogCommitType := range changelogCommitTypes {
+		if changelogCommitType.commitType == conventionalCommit.commitType {
+			return changelogCommitType.title, index + 1, description
+		}
+	}
+
+	return conventionalCommit.commitType, len(changelogCommitTypes) + 1, description
+}
+
+// isBreakingChange returns true if the commit message contains a BREAKING CHANGE footer
+func isBreakingChange(message string) bool {
+	for _, line := range strings.Split(message, "\n")[1:] {
+		for _, breakingChangeKey := range changelogBreakingChangeKeys {
+			if strings.HasPrefix(line, breakingChangeKey) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// trailerSection groups commits by the first value of the configured trailer.
+// Commits without the trailer are grouped last
+func (changelog *Changelog) trailerSection(entry *ChangelogEntry) (title string, order int, description string) {
+	description = strings.TrimSpace(strings.SplitN(entry.message, "\n", 2)[0])
+
+	values := commitTrailerValues(entry.message, changelog.trailerKey)
+	if values == "" {
+		return clOtherChangesTitle, 1, description
+	}
+
+	return strings.SplitN(values, "\n", 2)[0], 0, description
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChangelogIsGroupedByConventionalCommitType(t *testing.T) {
+	changelog := NewChangelog("v1.0.0", "v1.1.0", "")
+	changelog.entries = []*ChangelogEntry{
+		{shortID: "1111111", message: "fix(parser): Handle empty input"},
+		{shortID: "2222222", message: "Update README"},
+		{shortID: "3333333", message: "feat: Add changelog command"},
+		{shortID: "4444444", message: "feat(api)!: Remove deprecated endpoints"},
+		{shortID: "5555555", message: "chore: Bump version"},
+		{shortID: "6666666", message: "fix: Rework config loading\n\nBREAKING CHANGE: config files are no longer merged"},
+		{shortID: "7777777", message: "wip: Experiment"},
+	}
+
+	expectedLines := []string{
+		"# Changelog v1.0.0..v1.1.0",
+		"",
+		"## Breaking Changes",
+		"",
+		"- api: Remove deprecated endpoints (4444444)",
+		"- Rework config loading (6666666)",
+		"",
+		"## Features",
+		"",
+		"- Add changelog command (3333333)",
+		"",
+		"## Bug Fixes",
+		"",
+		"- parser: Handle empty input (1111111)",
+		"",
+		"## Chores",
+		"",
+		"- Bump version (5555555)",
+		"",
+		"## wip",
+		"",
+		"- Experiment (7777777)",
+		"",
+		"## Other Changes",
+		"",
+		"- Update README (2222222)",
+	}
+
+	if lines := changelog.Lines(); !reflect.DeepEqual(expectedLines, lines) {
+		t.Errorf("Changelog does not match expected value.\nExpected: %q\nActual:   %q", expectedLines, lines)
+	}
+}
+
+func TestChangelogIsGroupedByTrailerValue(t *testing.T) {
+	changelog := NewChangelog("v1.0.0", "HEAD", "changelog")
+	changelog.entries = []*ChangelogEntry{
+		{shortID: "1111111", message: "Fix crash on startup\n\nChangelog: Fixed"},
+		{shortID: "2222222", message: "Refactor loader"},
+		{shortID: "3333333", message: "Add tag sorting\n\nChangelog: Added\nSigned-off-by: A U Thor <author@example.com>"},
+	}
+
+	expectedLines := []string{
+		"# Changelog v1.0.0..HEAD",
+		"",
+		"## Added",
+		"",
+		"- Add tag sorting (3333333)",
+		"",
+		"## Fixed",
+		"",
+		"- Fix crash on startup (1111111)",
+		"",
+		"## Other Changes",
+		"",
+		"- Refactor loader (2222222)",
+	}
+
+	if lines := changelog.Lines(); !reflect.DeepEqual(expectedLines, lines) {
+		t.Errorf("Changelog does not match expected value.\nExpected: %q\nActual:   %q", expectedLines, lines)
+	}
+}
+
+func TestEmptyChangelogReportsNoChanges(t *testing.T) {
+	changelog := NewChangelog("v1.0.0", "v1.0.1", "")
+	expectedLines := []string{"# Changelog v1.0.0..v1.0.1", "", "No changes"}
+
+	if lines := changelog.Lines(); !reflect.DeepEqual(expectedLines, lines) {
+		t.Errorf("Changelog does not match expected value.\nExpected: %q\nActual:   %q", expectedLines, lines)
+	}
+}
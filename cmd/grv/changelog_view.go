@@ -0,0 +1,259 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+type changelogViewHandler func(*ChangelogView, Action) error
+
+// ChangelogView displays a changelog generated from the commits between two refs
+type ChangelogView struct {
+	*ListView
+	config     Config
+	repoData   RepoData
+	from       string
+	to         string
+	trailerKey string
+	outputFile string
+	lines      []string
+	loading    bool
+	loadErr    error
+	active     bool
+	handlers   map[ActionType]changelogViewHandler
+}
+
+// NewChangelogView creates a new instance which generates a changelog for the commits reachable from to but not from.
+// Commits are grouped by the provided trailer key if one is set. If an output file is provided the changelog is written to it
+func NewChangelogView(from, to, trailerKey, outputFile string, repoData RepoData, channels *Channels, config Config) *ChangelogView {
+	changelogView := &ChangelogView{
+		ListView:   NewListView(channels),
+		config:     config,
+		repoData:   repoData,
+		from:       from,
+		to:         to,
+		trailerKey: trailerKey,
+		outputFile: outputFile,
+		handlers: map[ActionType]changelogViewHandler{
+			ActionSelect: regenerateChangelogView,
+		},
+	}
+
+	changelogView.viewSearch = NewViewSearch(changelogView, channels)
+
+	return changelogView
+}
+
+// Initialise generates the changelog
+func (changelogView *ChangelogView) Initialise() (err error) {
+	log.Debugf("Initialising ChangelogView for range %v..%v", changelogView.from, changelogView.to)
+
+	changelogView.lock.Lock()
+	defer changelogView.lock.Unlock()
+
+	changelogView.generateChangelog()
+
+	return
+}
+
+func (changelogView *ChangelogView) generateChangelog() {
+	if changelogView.loading {
+		return
+	}
+
+	changelogView.loading = true
+	changelog := NewChangelog(changelogView.from, changelogView.to, changelogView.trailerKey)
+
+	go func() {
+		defer RecoverPanic()
+
+		lines, err := changelogView.loadChangelog(changelog)
+
+		changelogView.lock.Lock()
+		changelogView.setLines(lines)
+		changelogView.loadErr = err
+		changelogView.loading = false
+		changelogView.lock.Unlock()
+
+		if err != nil {
+			changelogView.channels.ReportError(err)
+		} else if changelogView.outputFile != "" {
+			changelogView.channels.ReportSuccess("Changelog written to %v", changelogView.outputFile)
+		}
+
+		changelogView.channels.UpdateDisplay()
+	}()
+}
+
+func (changelogView *ChangelogView) loadChangelog(changelog *Changelog) (lines []string, err error) {
+	commitCh, err := changelogView.repoData.CommitRange(changelog.Range())
+	if err != nil {
+		err = fmt.Errorf("Unable to load commits for range %v: %v", changelog.Range(), err)
+		return
+	}
+
+	for commit := range commitCh {
+		changelog.AddCommit(commit)
+	}
+
+	lines = changelog.Lines()
+
+	if changelogView.outputFile != "" {
+		if err = ioutil.WriteFile(changelogView.outputFile, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+			err = fmt.Errorf("Unable to write changelog to %v: %v", changelogView.outputFile, err)
+		}
+	}
+
+	return
+}
+
+func (changelogView *ChangelogView) setLines(lines []string) {
+	changelogView.lines = lines
+
+	lineNumber := uint(len(changelogView.lines))
+	viewPos := changelogView.viewPos
+
+	if lineNumber == 0 {
+		viewPos.SetActiveRowIndex(0)
+	} else if viewPos.ActiveRowIndex() >= lineNumber {
+		viewPos.SetActiveRowIndex(lineNumber - 1)
+	}
+}
+
+// Render generates and writes the changelog view to the provided window
+func (changelogView *ChangelogView) Render(win RenderWindow) (err error) {
+	changelogView.lock.Lock()
+	defer changelogView.lock.Unlock()
+
+	log.Debugf("Rendering ChangelogView for range %v..%v", changelogView.from, changelogView.to)
+
+	changelogView.viewDimension = win.ViewDimensions()
+
+	lineNumber := uint(len(changelogView.lines))
+	rows := win.Rows() - 2
+
+	viewPos := changelogView.viewPos
+	viewPos.DetermineViewStartRow(rows, lineNumber, uint(changelogView.config.GetInt(CfScrollOff)))
+	lineIndex := viewPos.ViewStartRowIndex()
+	startColumn := viewPos.ViewStartColumn()
+
+	for rowIndex := uint(0); rowIndex < rows && lineIndex < lineNumber; rowIndex++ {
+		line := changelogView.lines[lineIndex]
+		themeComponentID := CmpNone
+
+		if strings.HasPrefix(line, "#") {
+			themeComponentID = CmpChangelogviewHeading
+		}
+
+		if err = win.SetRow(rowIndex+1, startColumn, themeComponentID, " %v", line); err != nil {
+			return
+		}
+
+		lineIndex++
+	}
+
+	if lineNumber > 0 {
+		if err = win.SetSelectedRow(viewPos.SelectedRowIndex()+1, changelogView.active); err != nil {
+			return
+		}
+	}
+
+	win.DrawBorder()
+
+	if err = win.SetTitle(CmpChangelogviewTitle, "Changelog %v..%v", changelogView.from, changelogView.to); err != nil {
+		return
+	}
+
+	switch {
+	case changelogView.loading:
+		err = win.SetFooter(CmpChangelogviewFooter, "Loading...")
+	case changelogView.loadErr != nil:
+		err = win.SetFooter(CmpChangelogviewFooter, "%v", changelogView.loadErr)
+	case lineNumber > 0:
+		err = win.SetFooter(CmpChangelogviewFooter, "Line %v of %v", viewPos.ActiveRowIndex()+1, lineNumber)
+	}
+
+	if err != nil {
+		return
+	}
+
+	err = changelogView.RenderSearchHighlight(win)
+
+	return
+}
+
+// RenderHelpBar renders key binding help for the changelog view
+func (changelogView *ChangelogView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
+	RenderKeyBindingHelp(changelogView.ViewID(), lineBuilder, []ActionMessage{
+		{action: ActionSelect, message: "Regenerate"},
+	})
+
+	return
+}
+
+// HandleEvent does nothing
+func (changelogView *ChangelogView) HandleEvent(event Event) (err error) {
+	return
+}
+
+// OnActiveChange updates whether this view is currently active
+func (changelogView *ChangelogView) OnActiveChange(active bool) {
+	log.Debugf("ChangelogView active: %v", active)
+	changelogView.lock.Lock()
+	defer changelogView.lock.Unlock()
+
+	changelogView.active = active
+}
+
+// ViewID returns the ViewID for the changelog view
+func (changelogView *ChangelogView) ViewID() ViewID {
+	return ViewChangelog
+}
+
+// Line returns the line at the specified index
+func (changelogView *ChangelogView) Line(lineIndex uint) (line string) {
+	changelogView.lock.Lock()
+	defer changelogView.lock.Unlock()
+
+	lineNumber := uint(len(changelogView.lines))
+	if lineIndex >= lineNumber {
+		log.Errorf("Invalid lineIndex: %v >= %v", lineIndex, lineNumber)
+		return
+	}
+
+	return changelogView.lines[lineIndex]
+}
+
+// LineNumber returns the number of lines in the view
+func (changelogView *ChangelogView) LineNumber() (lineNumber uint) {
+	changelogView.lock.Lock()
+	defer changelogView.lock.Unlock()
+
+	return uint(len(changelogView.lines))
+}
+
+// HandleAction checks if the changelog view supports this action and if it does executes it
+func (changelogView *ChangelogView) HandleAction(action Action) (err error) {
+	changelogView.lock.Lock()
+	defer changelogView.lock.Unlock()
+
+	if handler, ok := changelogView.handlers[action.ActionType]; ok {
+		log.Debugf("ChangelogView handling action %v", action)
+		err = handler(changelogView, action)
+	} else {
+		_, err = changelogView.HandleListAction(action, uint(len(changelogView.lines)))
+	}
+
+	return
+}
+
+func regenerateChangelogView(changelogView *ChangelogView, action Action) (err error) {
+	log.Debugf("Regenerating changelog for range %v..%v", changelogView.from, changelogView.to)
+	changelogView.generateChangelog()
+	changelogView.channels.UpdateDisplay()
+
+	return
+}
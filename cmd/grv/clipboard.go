@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	osc52Terminal = "/dev/tty"
+)
+
+// clipboardCommand is a command which copies its standard input to the system clipboard.
+// The command is only used when the environment variable it depends on, if any, is set
+type clipboardCommand struct {
+	args        []string
+	requiredEnv string
+}
+
+var tmuxClipboardCommand = clipboardCommand{args: []string{"tmux", "load-buffer", "-"}}
+
+var clipboardCommands = []clipboardCommand{
+	{args: []string{"pbcopy"}},
+	{args: []string{"wl-copy"}, requiredEnv: "WAYLAND_DISPLAY"},
+	{args: []string{"xclip", "-selection", "clipboard"}, requiredEnv: "DISPLAY"},
+	{args: []string{"xsel", "--clipboard", "--input"}, requiredEnv: "DISPLAY"},
+	{args: []string{"clip.exe"}},
+}
+
+// CopyToClipboard copies the text to the system clipboard using the first available clipboard command.
+// When running inside tmux and the tmuxclipboard config variable is enabled the text is copied into
+// the tmux paste buffer instead. If no clipboard command is available and the osc52 config variable
+// is enabled then the text is copied using an OSC 52 escape sequence
+func CopyToClipboard(text string, config Config) error {
+	if insideTmux() && config.GetBool(CfTmuxClipboard) {
+		return tmuxClipboardCommand.copy(text)
+	}
+
+	for _, clipboardCommand := range clipboardCommands {
+		if clipboardCommand.requiredEnv != "" && os.Getenv(clipboardCommand.requiredEnv) == "" {
+			continue
+		}
+
+		if _, err := exec.LookPath(clipboardCommand.args[0]); err != nil {
+			continue
+		}
+
+		return clipboardCommand.copy(text)
+	}
+
+	if config.GetBool(CfOSC52) {
+		return copyToClipboardUsingOSC52(text, config.GetInt(CfOSC52MaxSize))
+	}
+
+	return fmt.Errorf("No clipboard command found. Install one of pbcopy, wl-copy, xclip or xsel or set %v to true", CfOSC52)
+}
+
+func (clipboardCommand clipboardCommand) copy(text string) error {
+	log.Debugf("Copying %v bytes to clipboard using %v", len(text), clipboardCommand.args[0])
+
+	cmd := exec.Command(clipboardCommand.args[0], clipboardCommand.args[1:]...)
+	cmd.Stdin = strings.NewReader(text)
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("Unable to copy to clipboard using %v: %v", clipboardCommand.args[0], err)
+	}
+
+	return nil
+}
+
+func copyToClipboardUsingOSC52(text string, maxSize int) (err error) {
+	if maxSize > 0 && len(text) > maxSize {
+		return fmt.Errorf("Unable to copy %v bytes to clipboard using OSC 52: maximum size is %v bytes", len(text), maxSize)
+	}
+
+	terminal, err := os.OpenFile(osc52Terminal, os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("Unable to open terminal to copy to clipboard using OSC 52: %v", err)
+	}
+	defer terminal.Close()
+
+	log.Debugf("Copying %v bytes to clipboard using OSC 52", len(text))
+
+	if _, err = terminal.WriteString(osc52Sequence(text, insideTmux(), strings.HasPrefix(os.Getenv("TERM"), "screen"))); err != nil {
+		err = fmt.Errorf("Unable to copy to clipboard using OSC 52: %v", err)
+	}
+
+	return
+}
+
+// osc52Sequence generates the escape sequence which sets the clipboard to the provided text.
+// When running inside tmux or screen the sequence is wrapped so that it is passed through to
+// the outer terminal
+func osc52Sequence(text string, tmux, screen bool) string {
+	sequence := fmt.Sprintf("\x1b]52;c;%v\a", base64.StdEncoding.EncodeToString([]byte(text)))
+
+	switch {
+	case tmux:
+		sequence = "\x1bPtmux;" + strings.Replace(sequence, "\x1b", "\x1b\x1b", -1) + "\x1b\\"
+	case screen:
+		sequence = "\x1bP" + sequence + "\x1b\\"
+	}
+
+	return sequence
+}
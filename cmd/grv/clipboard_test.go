@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestOSC52SequenceEncodesText(t *testing.T) {
+	tests := []struct {
+		tmux             bool
+		screen           bool
+		expectedSequence string
+	}{
+		{expectedSequence: "\x1b]52;c;aGVsbG8=\a"},
+		{tmux: true, expectedSequence: "\x1bPtmux;\x1b\x1b]52;c;aGVsbG8=\a\x1b\\"},
+		{screen: true, expectedSequence: "\x1bP\x1b]52;c;aGVsbG8=\a\x1b\\"},
+	}
+
+	for _, test := range tests {
+		if sequence := osc52Sequence("hello", test.tmux, test.screen); sequence != test.expectedSequence {
+			t.Errorf("Sequence does not match expected value. Expected: %q, Actual: %q", test.expectedSequence, sequence)
+		}
+	}
+}
+
+func TestOSC52CopyFailsWhenTextExceedsMaxSize(t *testing.T) {
+	if err := copyToClipboardUsingOSC52("hello", 4); err == nil {
+		t.Errorf("Expected error when text exceeds maximum size")
+	}
+}
@@ -0,0 +1,346 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+type commandOutputViewHandler func(*CommandOutputView, Action) error
+
+// CommandOutputView displays the output of a shell command.
+// When a refresh interval is set the command is rerun at that interval while
+// the view is displayed and lines which have changed are highlighted
+type CommandOutputView struct {
+	*ListView
+	config           Config
+	viewID           ViewID
+	title            string
+	command          string
+	workdir          string
+	env              []string
+	lines            []string
+	changedLines     []bool
+	running          bool
+	completedRun     bool
+	exitErr          error
+	refreshInterval  time.Duration
+	refreshScheduled bool
+	rendered         bool
+	active           bool
+	handlers         map[ActionType]commandOutputViewHandler
+}
+
+// NewCommandOutputView creates a new instance which will run the provided command in workdir.
+// The provided environment variables are added to the environment of the command
+func NewCommandOutputView(command, workdir string, env []string, channels *Channels, config Config) *CommandOutputView {
+	commandOutputView := &CommandOutputView{
+		ListView: NewListView(channels),
+		config:   config,
+		viewID:   ViewCommandOutput,
+		title:    command,
+		command:  command,
+		workdir:  workdir,
+		env:      env,
+		handlers: map[ActionType]commandOutputViewHandler{
+			ActionSelect: rerunCommandOutputView,
+		},
+	}
+
+	commandOutputView.viewSearch = NewViewSearch(commandOutputView, channels)
+
+	return commandOutputView
+}
+
+// NewCommandView creates a new instance for the command view with the provided name.
+// The name is displayed as the title of the view instead of the command.
+// If refreshInterval is greater than 0 the command is rerun at that interval
+func NewCommandView(name, command, workdir string, env []string, refreshInterval time.Duration, channels *Channels, config Config) *CommandOutputView {
+	commandOutputView := NewCommandOutputView(command, workdir, env, channels, config)
+	commandOutputView.viewID = ViewCommand
+	commandOutputView.title = name
+	commandOutputView.refreshInterval = refreshInterval
+
+	if refreshInterval > 0 {
+		commandOutputView.title = fmt.Sprintf("Every %v: %v", refreshInterval, name)
+	}
+
+	return commandOutputView
+}
+
+// Initialise runs the command
+func (commandOutputView *CommandOutputView) Initialise() (err error) {
+	log.Debugf("Initialising CommandOutputView for command: %v", commandOutputView.command)
+
+	commandOutputView.lock.Lock()
+	defer commandOutputView.lock.Unlock()
+
+	commandOutputView.runCommand()
+
+	return
+}
+
+func (commandOutputView *CommandOutputView) runCommand() {
+	if commandOutputView.running {
+		return
+	}
+
+	commandOutputView.running = true
+
+	go func() {
+		defer RecoverPanic()
+
+		lines, err := runCommandAndCaptureOutput(commandOutputView.command, commandOutputView.workdir, commandOutputView.env)
+
+		commandOutputView.lock.Lock()
+		if commandOutputView.refreshInterval > 0 && commandOutputView.completedRun {
+			commandOutputView.changedLines = changedCommandOutputLines(commandOutputView.lines, lines)
+		}
+
+		commandOutputView.setLines(lines)
+		commandOutputView.exitErr = err
+		commandOutputView.running = false
+		commandOutputView.completedRun = true
+		commandOutputView.scheduleRefresh()
+		commandOutputView.lock.Unlock()
+
+		if err != nil {
+			commandOutputView.channels.ReportFailure("Command exited with error: %v", err)
+		}
+
+		commandOutputView.channels.UpdateDisplay()
+	}()
+}
+
+// scheduleRefresh reruns the command once the refresh interval has elapsed
+func (commandOutputView *CommandOutputView) scheduleRefresh() {
+	if commandOutputView.refreshInterval <= 0 || commandOutputView.refreshScheduled || commandOutputView.running {
+		return
+	}
+
+	commandOutputView.refreshScheduled = true
+	commandOutputView.rendered = false
+
+	time.AfterFunc(commandOutputView.refreshInterval, commandOutputView.refresh)
+}
+
+// refresh reruns the command if the view has been rendered since the refresh was scheduled.
+// Otherwise the view is no longer displayed and refreshing resumes when it is next rendered
+func (commandOutputView *CommandOutputView) refresh() {
+	defer RecoverPanic()
+
+	commandOutputView.lock.Lock()
+	defer commandOutputView.lock.Unlock()
+
+	commandOutputView.refreshScheduled = false
+
+	if !commandOutputView.rendered {
+		log.Debugf("Pausing refresh of command: %v", commandOutputView.command)
+		return
+	}
+
+	log.Debugf("Refreshing command: %v", commandOutputView.command)
+	commandOutputView.runCommand()
+	commandOutputView.channels.UpdateDisplay()
+}
+
+// changedCommandOutputLines returns whether each line of the output was absent from the previous output.
+// A line is also considered changed if it occurs more times than it did in the previous output
+func changedCommandOutputLines(previousLines, lines []string) (changedLines []bool) {
+	previousLineCounts := make(map[string]int)
+	for _, line := range previousLines {
+		previousLineCounts[line]++
+	}
+
+	changedLines = make([]bool, len(lines))
+
+	for lineIndex, line := range lines {
+		if previousLineCounts[line] > 0 {
+			previousLineCounts[line]--
+		} else {
+			changedLines[lineIndex] = true
+		}
+	}
+
+	return
+}
+
+func runCommandAndCaptureOutput(command, workdir string, env []string) (lines []string, err error) {
+	var output bytes.Buffer
+
+	cmd := exec.Command(ecShell, "-c", command)
+	cmd.Dir = workdir
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	err = cmd.Run()
+
+	scanner := bufio.NewScanner(&output)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	if scanErr := scanner.Err(); scanErr != nil && err == nil {
+		err = fmt.Errorf("Unable to read command output: %v", scanErr)
+	}
+
+	return
+}
+
+func (commandOutputView *CommandOutputView) setLines(lines []string) {
+	commandOutputView.lines = lines
+
+	lineNumber := uint(len(commandOutputView.lines))
+	viewPos := commandOutputView.viewPos
+
+	if lineNumber == 0 {
+		viewPos.SetActiveRowIndex(0)
+	} else if viewPos.ActiveRowIndex() >= lineNumber {
+		viewPos.SetActiveRowIndex(lineNumber - 1)
+	}
+}
+
+// Render generates and writes the command output view to the provided window
+func (commandOutputView *CommandOutputView) Render(win RenderWindow) (err error) {
+	commandOutputView.lock.Lock()
+	defer commandOutputView.lock.Unlock()
+
+	log.Debugf("Rendering CommandOutputView for command: %v", commandOutputView.command)
+
+	commandOutputView.viewDimension = win.ViewDimensions()
+
+	if commandOutputView.completedRun {
+		commandOutputView.scheduleRefresh()
+	}
+
+	commandOutputView.rendered = true
+
+	lineNumber := uint(len(commandOutputView.lines))
+	rows := win.Rows() - 2
+
+	viewPos := commandOutputView.viewPos
+	viewPos.DetermineViewStartRow(rows, lineNumber, uint(commandOutputView.config.GetInt(CfScrollOff)))
+	lineIndex := viewPos.ViewStartRowIndex()
+	startColumn := viewPos.ViewStartColumn()
+
+	for rowIndex := uint(0); rowIndex < rows && lineIndex < lineNumber; rowIndex++ {
+		themeComponentID := CmpNone
+		if lineIndex < uint(len(commandOutputView.changedLines)) && commandOutputView.changedLines[lineIndex] {
+			themeComponentID = CmpCommandOutputViewChangedLine
+		}
+
+		if err = win.SetRow(rowIndex+1, startColumn, themeComponentID, " %v", commandOutputView.lines[lineIndex]); err != nil {
+			return
+		}
+
+		lineIndex++
+	}
+
+	if lineNumber > 0 {
+		if err = win.SetSelectedRow(viewPos.SelectedRowIndex()+1, commandOutputView.active); err != nil {
+			return
+		}
+	}
+
+	win.DrawBorder()
+
+	if err = win.SetTitle(CmpCommandOutputViewTitle, "%v", commandOutputView.title); err != nil {
+		return
+	}
+
+	switch {
+	case commandOutputView.running:
+		err = win.SetFooter(CmpCommandOutputViewFooter, "Running...")
+	case commandOutputView.exitErr != nil:
+		err = win.SetFooter(CmpCommandOutputViewFooter, "%v", commandOutputView.exitErr)
+	case lineNumber > 0:
+		err = win.SetFooter(CmpCommandOutputViewFooter, "Line %v of %v", viewPos.ActiveRowIndex()+1, lineNumber)
+	}
+
+	if err != nil {
+		return
+	}
+
+	err = commandOutputView.RenderSearchHighlight(win)
+
+	return
+}
+
+// RenderHelpBar renders key binding help for the command output view
+func (commandOutputView *CommandOutputView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
+	RenderKeyBindingHelp(commandOutputView.ViewID(), lineBuilder, []ActionMessage{
+		{action: ActionSelect, message: "Rerun"},
+	})
+
+	return
+}
+
+// HandleEvent does nothing
+func (commandOutputView *CommandOutputView) HandleEvent(event Event) (err error) {
+	return
+}
+
+// OnActiveChange updates whether this view is currently active
+func (commandOutputView *CommandOutputView) OnActiveChange(active bool) {
+	log.Debugf("CommandOutputView active: %v", active)
+	commandOutputView.lock.Lock()
+	defer commandOutputView.lock.Unlock()
+
+	commandOutputView.active = active
+}
+
+// ViewID returns the ViewID for the command output view
+func (commandOutputView *CommandOutputView) ViewID() ViewID {
+	return commandOutputView.viewID
+}
+
+// Line returns the line at the specified index
+func (commandOutputView *CommandOutputView) Line(lineIndex uint) (line string) {
+	commandOutputView.lock.Lock()
+	defer commandOutputView.lock.Unlock()
+
+	lineNumber := uint(len(commandOutputView.lines))
+	if lineIndex >= lineNumber {
+		log.Errorf("Invalid lineIndex: %v >= %v", lineIndex, lineNumber)
+		return
+	}
+
+	return commandOutputView.lines[lineIndex]
+}
+
+// LineNumber returns the number of lines in the view
+func (commandOutputView *CommandOutputView) LineNumber() (lineNumber uint) {
+	commandOutputView.lock.Lock()
+	defer commandOutputView.lock.Unlock()
+
+	return uint(len(commandOutputView.lines))
+}
+
+// HandleAction checks if the command output view supports this action and if it does executes it
+func (commandOutputView *CommandOutputView) HandleAction(action Action) (err error) {
+	commandOutputView.lock.Lock()
+	defer commandOutputView.lock.Unlock()
+
+	if handler, ok := commandOutputView.handlers[action.ActionType]; ok {
+		log.Debugf("CommandOutputView handling action %v", action)
+		err = handler(commandOutputView, action)
+	} else {
+		_, err = commandOutputView.HandleListAction(action, uint(len(commandOutputView.lines)))
+	}
+
+	return
+}
+
+func rerunCommandOutputView(commandOutputView *CommandOutputView, action Action) (err error) {
+	log.Debugf("Rerunning command: %v", commandOutputView.command)
+	commandOutputView.runCommand()
+	commandOutputView.channels.UpdateDisplay()
+
+	return
+}
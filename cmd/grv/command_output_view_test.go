@@ -0,0 +1,49 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChangedCommandOutputLinesAreDetermined(t *testing.T) {
+	var changedLinesTests = []struct {
+		previousLines        []string
+		lines                []string
+		expectedChangedLines []bool
+	}{
+		{
+			previousLines:        []string{"build: passed", "test: running"},
+			lines:                []string{"build: passed", "test: passed"},
+			expectedChangedLines: []bool{false, true},
+		},
+		{
+			previousLines:        []string{"one", "two"},
+			lines:                []string{"zero", "one", "two"},
+			expectedChangedLines: []bool{true, false, false},
+		},
+		{
+			previousLines:        []string{"ok"},
+			lines:                []string{"ok", "ok"},
+			expectedChangedLines: []bool{false, true},
+		},
+		{
+			previousLines:        nil,
+			lines:                []string{"first"},
+			expectedChangedLines: []bool{true},
+		},
+		{
+			previousLines:        []string{"removed"},
+			lines:                nil,
+			expectedChangedLines: []bool{},
+		},
+	}
+
+	for _, changedLinesTest := range changedLinesTests {
+		changedLines := changedCommandOutputLines(changedLinesTest.previousLines, changedLinesTest.lines)
+
+		if !reflect.DeepEqual(changedLines, changedLinesTest.expectedChangedLines) {
+			t.Errorf("Changed lines do not match expected value for previous lines %q and lines %q. Expected: %v, Actual: %v",
+				changedLinesTest.previousLines, changedLinesTest.lines, changedLinesTest.expectedChangedLines, changedLines)
+		}
+	}
+}
@@ -0,0 +1,418 @@
+package main
+
+import (
+	"encoding/gob"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	git "gopkg.in/libgit2/git2go.v25"
+)
+
+const (
+	ccVersion     = 1
+	ccCacheDir    = "grv"
+	ccCacheFile   = "commit_cache"
+	ccMaxWalks    = 10
+	ccTempFileExt = ".tmp"
+)
+
+// RawCommit exposes the commit data used by grv.
+// It is implemented by git commits and by commits loaded from the commit cache
+type RawCommit interface {
+	Id() *git.Oid
+	Author() *git.Signature
+	Committer() *git.Signature
+	Summary() string
+	Message() string
+	ParentCount() uint
+	ParentId(n uint) *git.Oid
+	Parent(n uint) *git.Commit
+	Tree() (*git.Tree, error)
+}
+
+type commitRecord struct {
+	Oid            string
+	Parents        []string
+	AuthorName     string
+	AuthorEmail    string
+	AuthorWhen     time.Time
+	CommitterName  string
+	CommitterEmail string
+	CommitterWhen  time.Time
+	Summary        string
+}
+
+// commitWalk is the ordered list of commits reachable from a tip commit.
+// Commits are stored as indexes into the record list
+type commitWalk struct {
+	Tip     string
+	Commits []uint32
+}
+
+type commitCacheData struct {
+	Version int
+	Records []*commitRecord
+	Walks   []*commitWalk
+}
+
+// CommitCache persists the metadata of walked commits in the repository directory
+// so commits do not have to be walked and parsed again when grv is restarted
+type CommitCache struct {
+	filePath    string
+	records     []*commitRecord
+	recordIndex map[string]uint32
+	walks       []*commitWalk
+	modified    bool
+	lock        sync.Mutex
+}
+
+// NewCommitCache creates a new instance which is stored in the provided repository directory
+func NewCommitCache(repoPath string) *CommitCache {
+	return &CommitCache{
+		filePath:    filepath.Join(repoPath, ccCacheDir, ccCacheFile),
+		recordIndex: make(map[string]uint32),
+	}
+}
+
+// Load reads the commit cache from disk if it exists
+func (commitCache *CommitCache) Load() (err error) {
+	commitCache.lock.Lock()
+	defer commitCache.lock.Unlock()
+
+	file, err := os.Open(commitCache.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+
+		return
+	}
+	defer file.Close()
+
+	var data commitCacheData
+	if err = gob.NewDecoder(file).Decode(&data); err != nil {
+		return fmt.Errorf("Unable to read commit cache %v: %v", commitCache.filePath, err)
+	}
+
+	if data.Version != ccVersion {
+		log.Infof("Ignoring commit cache with version %v", data.Version)
+		return
+	}
+
+	for _, walk := range data.Walks {
+		for _, recordIndex := range walk.Commits {
+			if int(recordIndex) >= len(data.Records) {
+				return fmt.Errorf("Commit cache %v is corrupt", commitCache.filePath)
+			}
+		}
+	}
+
+	commitCache.records = data.Records
+	commitCache.walks = data.Walks
+
+	for index, record := range commitCache.records {
+		commitCache.recordIndex[record.Oid] = uint32(index)
+	}
+
+	log.Infof("Loaded %v commits and %v walks from commit cache", len(commitCache.records), len(commitCache.walks))
+
+	return
+}
+
+// Save writes the commit cache to disk if walks have been added since it was loaded or last saved.
+// Records not referenced by a walk are discarded
+func (commitCache *CommitCache) Save() (err error) {
+	commitCache.lock.Lock()
+	defer commitCache.lock.Unlock()
+
+	if !commitCache.modified {
+		return
+	}
+
+	data := commitCacheData{
+		Version: ccVersion,
+	}
+
+	newRecordIndex := make(map[uint32]uint32)
+
+	for _, walk := range commitCache.walks {
+		savedWalk := &commitWalk{
+			Tip:     walk.Tip,
+			Commits: make([]uint32, 0, len(walk.Commits)),
+		}
+
+		for _, recordIndex := range walk.Commits {
+			newIndex, exists := newRecordIndex[recordIndex]
+			if !exists {
+				newIndex = uint32(len(data.Records))
+				newRecordIndex[recordIndex] = newIndex
+				data.Records = append(data.Records, commitCache.records[recordIndex])
+			}
+
+			savedWalk.Commits = append(savedWalk.Commits, newIndex)
+		}
+
+		data.Walks = append(data.Walks, savedWalk)
+	}
+
+	if err = os.MkdirAll(filepath.Dir(commitCache.filePath), 0755); err != nil {
+		return
+	}
+
+	tempFilePath := commitCache.filePath + ccTempFileExt
+
+	file, err := os.Create(tempFilePath)
+	if err != nil {
+		return
+	}
+
+	if err = gob.NewEncoder(file).Encode(&data); err != nil {
+		file.Close()
+		os.Remove(tempFilePath)
+		return
+	}
+
+	if err = file.Close(); err != nil {
+		os.Remove(tempFilePath)
+		return
+	}
+
+	if err = os.Rename(tempFilePath, commitCache.filePath); err != nil {
+		return
+	}
+
+	commitCache.modified = false
+	log.Debugf("Saved %v commits and %v walks to commit cache", len(data.Records), len(data.Walks))
+
+	return
+}
+
+// Walk returns the cached commits reachable from the provided tip in the order they were walked
+func (commitCache *CommitCache) Walk(tip string) (records []*commitRecord, exists bool) {
+	commitCache.lock.Lock()
+	defer commitCache.lock.Unlock()
+
+	walkIndex, exists := commitCache.walkIndex(tip)
+	if !exists {
+		return
+	}
+
+	walk := commitCache.walks[walkIndex]
+	commitCache.markWalkUsed(walkIndex)
+
+	records = make([]*commitRecord, 0, len(walk.Commits))
+	for _, recordIndex := range walk.Commits {
+		records = append(records, commitCache.records[recordIndex])
+	}
+
+	return
+}
+
+// Tips returns the tip of each cached walk, most recently used first
+func (commitCache *CommitCache) Tips() (tips []string) {
+	commitCache.lock.Lock()
+	defer commitCache.lock.Unlock()
+
+	for index := len(commitCache.walks) - 1; index >= 0; index-- {
+		tips = append(tips, commitCache.walks[index].Tip)
+	}
+
+	return
+}
+
+// AddWalk stores the commits reachable from the provided tip in the order they were walked.
+// The least recently used walk is removed when the maximum number of walks is exceeded
+func (commitCache *CommitCache) AddWalk(tip string, rawCommits []RawCommit) {
+	commitCache.lock.Lock()
+	defer commitCache.lock.Unlock()
+
+	walk := &commitWalk{
+		Tip:     tip,
+		Commits: make([]uint32, 0, len(rawCommits)),
+	}
+
+	for _, rawCommit := range rawCommits {
+		oid := rawCommit.Id().String()
+
+		recordIndex, exists := commitCache.recordIndex[oid]
+		if !exists {
+			recordIndex = uint32(len(commitCache.records))
+			commitCache.records = append(commitCache.records, newCommitRecord(rawCommit))
+			commitCache.recordIndex[oid] = recordIndex
+		}
+
+		walk.Commits = append(walk.Commits, recordIndex)
+	}
+
+	if walkIndex, exists := commitCache.walkIndex(tip); exists {
+		commitCache.walks = append(commitCache.walks[:walkIndex], commitCache.walks[walkIndex+1:]...)
+	}
+
+	commitCache.walks = append(commitCache.walks, walk)
+
+	if len(commitCache.walks) > ccMaxWalks {
+		commitCache.walks = commitCache.walks[len(commitCache.walks)-ccMaxWalks:]
+	}
+
+	commitCache.modified = true
+}
+
+func (commitCache *CommitCache) walkIndex(tip string) (walkIndex int, exists bool) {
+	for index, walk := range commitCache.walks {
+		if walk.Tip == tip {
+			return index, true
+		}
+	}
+
+	return
+}
+
+func (commitCache *CommitCache) markWalkUsed(walkIndex int) {
+	walk := commitCache.walks[walkIndex]
+	commitCache.walks = append(commitCache.walks[:walkIndex], commitCache.walks[walkIndex+1:]...)
+	commitCache.walks = append(commitCache.walks, walk)
+}
+
+func newCommitRecord(rawCommit RawCommit) *commitRecord {
+	author := rawCommit.Author()
+	committer := rawCommit.Committer()
+
+	record := &commitRecord{
+		Oid:            rawCommit.Id().String(),
+		AuthorName:     author.Name,
+		AuthorEmail:    author.Email,
+		AuthorWhen:     author.When,
+		CommitterName:  committer.Name,
+		CommitterEmail: committer.Email,
+		CommitterWhen:  committer.When,
+		Summary:        rawCommit.Summary(),
+	}
+
+	for parentIndex := uint(0); parentIndex < rawCommit.ParentCount(); parentIndex++ {
+		record.Parents = append(record.Parents, rawCommit.ParentId(parentIndex).String())
+	}
+
+	return record
+}
+
+// cachedCommit provides the commit metadata stored in the commit cache.
+// The git commit is only loaded when data not stored in the cache is required
+type cachedCommit struct {
+	id        *git.Oid
+	record    *commitRecord
+	repo      *git.Repository
+	rawCommit *git.Commit
+	lock      sync.Mutex
+}
+
+func newCachedCommit(record *commitRecord, repo *git.Repository) (*cachedCommit, error) {
+	id, err := git.NewOid(record.Oid)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cachedCommit{
+		id:     id,
+		record: record,
+		repo:   repo,
+	}, nil
+}
+
+func (cachedCommit *cachedCommit) loadRawCommit() (rawCommit *git.Commit, err error) {
+	cachedCommit.lock.Lock()
+	defer cachedCommit.lock.Unlock()
+
+	if cachedCommit.rawCommit == nil {
+		log.Debugf("Loading commit %v not stored in commit cache", cachedCommit.record.Oid)
+
+		if cachedCommit.rawCommit, err = cachedCommit.repo.LookupCommit(cachedCommit.id); err != nil {
+			return
+		}
+	}
+
+	return cachedCommit.rawCommit, nil
+}
+
+// Id returns the oid of the commit
+func (cachedCommit *cachedCommit) Id() *git.Oid {
+	return cachedCommit.id
+}
+
+// Author returns the author signature of the commit
+func (cachedCommit *cachedCommit) Author() *git.Signature {
+	return &git.Signature{
+		Name:  cachedCommit.record.AuthorName,
+		Email: cachedCommit.record.AuthorEmail,
+		When:  cachedCommit.record.AuthorWhen,
+	}
+}
+
+// Committer returns the committer signature of the commit
+func (cachedCommit *cachedCommit) Committer() *git.Signature {
+	return &git.Signature{
+		Name:  cachedCommit.record.CommitterName,
+		Email: cachedCommit.record.CommitterEmail,
+		When:  cachedCommit.record.CommitterWhen,
+	}
+}
+
+// Summary returns the first line of the commit message
+func (cachedCommit *cachedCommit) Summary() string {
+	return cachedCommit.record.Summary
+}
+
+// Message returns the full commit message
+func (cachedCommit *cachedCommit) Message() string {
+	rawCommit, err := cachedCommit.loadRawCommit()
+	if err != nil {
+		log.Errorf("Unable to load message for commit %v: %v", cachedCommit.record.Oid, err)
+		return cachedCommit.record.Summary
+	}
+
+	return rawCommit.Message()
+}
+
+// ParentCount returns the number of parents of the commit
+func (cachedCommit *cachedCommit) ParentCount() uint {
+	return uint(len(cachedCommit.record.Parents))
+}
+
+// ParentId returns the oid of the parent at the specified index
+func (cachedCommit *cachedCommit) ParentId(n uint) *git.Oid {
+	if n >= cachedCommit.ParentCount() {
+		return nil
+	}
+
+	oid, err := git.NewOid(cachedCommit.record.Parents[n])
+	if err != nil {
+		log.Errorf("Invalid parent oid %v for commit %v: %v", cachedCommit.record.Parents[n], cachedCommit.record.Oid, err)
+		return nil
+	}
+
+	return oid
+}
+
+// Parent loads the parent commit at the specified index
+func (cachedCommit *cachedCommit) Parent(n uint) *git.Commit {
+	rawCommit, err := cachedCommit.loadRawCommit()
+	if err != nil {
+		log.Errorf("Unable to load parent of commit %v: %v", cachedCommit.record.Oid, err)
+		return nil
+	}
+
+	return rawCommit.Parent(n)
+}
+
+// Tree loads the tree of the commit
+func (cachedCommit *cachedCommit) Tree() (*git.Tree, error) {
+	rawCommit, err := cachedCommit.loadRawCommit()
+	if err != nil {
+		return nil, err
+	}
+
+	return rawCommit.Tree()
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	git "gopkg.in/libgit2/git2go.v25"
+)
+
+type testRawCommit struct {
+	id      *git.Oid
+	parents []*git.Oid
+	summary string
+}
+
+func newTestRawCommit(t *testing.T, id string, summary string, parentIDs ...string) *testRawCommit {
+	oid, err := git.NewOid(id)
+	if err != nil {
+		t.Fatalf("Invalid oid %v: %v", id, err)
+	}
+
+	rawCommit := &testRawCommit{
+		id:      oid,
+		summary: summary,
+	}
+
+	for _, parentID := range parentIDs {
+		parentOid, err := git.NewOid(parentID)
+		if err != nil {
+			t.Fatalf("Invalid oid %v: %v", parentID, err)
+		}
+
+		rawCommit.parents = append(rawCommit.parents, parentOid)
+	}
+
+	return rawCommit
+}
+
+func (rawCommit *testRawCommit) Id() *git.Oid {
+	return rawCommit.id
+}
+
+func (rawCommit *testRawCommit) Author() *git.Signature {
+	return &git.Signature{
+		Name:  "Author",
+		Email: "author@example.com",
+		When:  time.Unix(1500000000, 0).UTC(),
+	}
+}
+
+func (rawCommit *testRawCommit) Committer() *git.Signature {
+	return &git.Signature{
+		Name:  "Committer",
+		Email: "committer@example.com",
+		When:  time.Unix(1500000100, 0).UTC(),
+	}
+}
+
+func (rawCommit *testRawCommit) Summary() string {
+	return rawCommit.summary
+}
+
+func (rawCommit *testRawCommit) Message() string {
+	return rawCommit.summary + "\n"
+}
+
+func (rawCommit *testRawCommit) ParentCount() uint {
+	return uint(len(rawCommit.parents))
+}
+
+func (rawCommit *testRawCommit) ParentId(n uint) *git.Oid {
+	return rawCommit.parents[n]
+}
+
+func (rawCommit *testRawCommit) Parent(n uint) *git.Commit {
+	return nil
+}
+
+func (rawCommit *testRawCommit) Tree() (*git.Tree, error) {
+	return nil, nil
+}
+
+const (
+	testCommitID1 = "1111111111111111111111111111111111111111"
+	testCommitID2 = "2222222222222222222222222222222222222222"
+	testCommitID3 = "3333333333333333333333333333333333333333"
+)
+
+func checkCachedWalk(t *testing.T, commitCache *CommitCache, tip string, expectedOids []string) {
+	records, exists := commitCache.Walk(tip)
+	if !exists {
+		t.Fatalf("Expected walk to exist for tip %v", tip)
+	}
+
+	if len(records) != len(expectedOids) {
+		t.Fatalf("Walk length does not match expected value. Expected: %v, Actual: %v", len(expectedOids), len(records))
+	}
+
+	for index, record := range records {
+		if record.Oid != expectedOids[index] {
+			t.Errorf("Walk commit does not match expected value. Expected: %v, Actual: %v", expectedOids[index], record.Oid)
+		}
+	}
+}
+
+func TestCommitCacheReturnsWalkInOrderItWasAdded(t *testing.T) {
+	commitCache := NewCommitCache("")
+	commitCache.AddWalk(testCommitID2, []RawCommit{
+		newTestRawCommit(t, testCommitID2, "Second", testCommitID1),
+		newTestRawCommit(t, testCommitID1, "First"),
+	})
+
+	checkCachedWalk(t, commitCache, testCommitID2, []string{testCommitID2, testCommitID1})
+
+	if _, exists := commitCache.Walk(testCommitID1); exists {
+		t.Errorf("Expected no walk to exist for tip %v", testCommitID1)
+	}
+}
+
+func TestCommitCacheTipsAreOrderedByMostRecentlyUsed(t *testing.T) {
+	commitCache := NewCommitCache("")
+	commitCache.AddWalk(testCommitID1, []RawCommit{newTestRawCommit(t, testCommitID1, "First")})
+	commitCache.AddWalk(testCommitID2, []RawCommit{newTestRawCommit(t, testCommitID2, "Second")})
+	commitCache.Walk(testCommitID1)
+
+	tips := commitCache.Tips()
+
+	if len(tips) != 2 || tips[0] != testCommitID1 || tips[1] != testCommitID2 {
+		t.Errorf("Tips do not match expected value. Expected: [%v %v], Actual: %v", testCommitID1, testCommitID2, tips)
+	}
+}
+
+func TestCommitCacheIsPersistedAcrossInstances(t *testing.T) {
+	repoPath, err := ioutil.TempDir("", "grv-commit-cache")
+	if err != nil {
+		t.Fatalf("Unable to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(repoPath)
+
+	commitCache := NewCommitCache(repoPath)
+	commitCache.AddWalk(testCommitID3, []RawCommit{
+		newTestRawCommit(t, testCommitID3, "Third", testCommitID2),
+		newTestRawCommit(t, testCommitID2, "Second", testCommitID1),
+		newTestRawCommit(t, testCommitID1, "First"),
+	})
+
+	if err = commitCache.Save(); err != nil {
+		t.Fatalf("Unable to save commit cache: %v", err)
+	}
+
+	loadedCommitCache := NewCommitCache(repoPath)
+	if err = loadedCommitCache.Load(); err != nil {
+		t.Fatalf("Unable to load commit cache: %v", err)
+	}
+
+	checkCachedWalk(t, loadedCommitCache, testCommitID3, []string{testCommitID3, testCommitID2, testCommitID1})
+
+	records, _ := loadedCommitCache.Walk(testCommitID3)
+	record := records[1]
+
+	if record.Summary != "Second" || len(record.Parents) != 1 || record.Parents[0] != testCommitID1 {
+		t.Errorf("Cached commit does not match expected value: %v", record)
+	}
+
+	if !record.AuthorWhen.Equal(time.Unix(1500000000, 0)) {
+		t.Errorf("Cached author date does not match expected value. Expected: %v, Actual: %v", time.Unix(1500000000, 0), record.AuthorWhen)
+	}
+}
+
+func TestCommitCacheLoadIgnoresMissingFile(t *testing.T) {
+	commitCache := NewCommitCache("/non/existent/path")
+
+	if err := commitCache.Load(); err != nil {
+		t.Errorf("Expected no error but received: %v", err)
+	}
+}
+
+func TestCommitCacheIsOnlySavedWhenModified(t *testing.T) {
+	repoPath, err := ioutil.TempDir("", "grv-commit-cache")
+	if err != nil {
+		t.Fatalf("Unable to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(repoPath)
+
+	commitCache := NewCommitCache(repoPath)
+
+	if err = commitCache.Save(); err != nil {
+		t.Fatalf("Unable to save commit cache: %v", err)
+	}
+
+	if _, err = os.Stat(commitCache.filePath); !os.IsNotExist(err) {
+		t.Errorf("Expected unmodified commit cache not to be written")
+	}
+
+	commitCache.AddWalk(testCommitID1, []RawCommit{newTestRawCommit(t, testCommitID1, "First")})
+
+	if err = commitCache.Save(); err != nil {
+		t.Fatalf("Unable to save commit cache: %v", err)
+	}
+
+	if _, err = os.Stat(commitCache.filePath); err != nil {
+		t.Errorf("Expected modified commit cache to be written: %v", err)
+	}
+}
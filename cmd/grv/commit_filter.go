@@ -118,4 +118,50 @@ var commitFields = map[string]CommitField{
 			return float64(commit.commit.ParentCount())
 		},
 	},
+	"committype": {
+		fieldType: FtString,
+		value: func(commit *Commit) interface{} {
+			return conventionalCommitField(commit.commit.Summary(), func(conventionalCommit *ConventionalCommit) string {
+				return conventionalCommit.commitType
+			})
+		},
+	},
+	"commitscope": {
+		fieldType: FtString,
+		value: func(commit *Commit) interface{} {
+			return conventionalCommitField(commit.commit.Summary(), func(conventionalCommit *ConventionalCommit) string {
+				return conventionalCommit.scope
+			})
+		},
+	},
+	"trailers": {
+		fieldType: FtString,
+		value: func(commit *Commit) interface{} {
+			return commitTrailerValues(commit.commit.Message(), "")
+		},
+	},
+	"signedoffby": {
+		fieldType: FtString,
+		value: func(commit *Commit) interface{} {
+			return commitTrailerValues(commit.commit.Message(), "Signed-off-by")
+		},
+	},
+	"reviewedby": {
+		fieldType: FtString,
+		value: func(commit *Commit) interface{} {
+			return commitTrailerValues(commit.commit.Message(), "Reviewed-by")
+		},
+	},
+	"coauthoredby": {
+		fieldType: FtString,
+		value: func(commit *Commit) interface{} {
+			return commitTrailerValues(commit.commit.Message(), "Co-authored-by")
+		},
+	},
+	"fixes": {
+		fieldType: FtString,
+		value: func(commit *Commit) interface{} {
+			return commitTrailerValues(commit.commit.Message(), "Fixes")
+		},
+	},
 }
@@ -0,0 +1,451 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"container/heap"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	cgSignature            = "CGPH"
+	cgVersion              = 1
+	cgHashVersionSHA1      = 1
+	cgHeaderSize           = 8
+	cgChunkLookupEntrySize = 12
+	cgFanoutSize           = 256 * 4
+	cgOidSize              = 20
+	cgCommitDataSize       = cgOidSize + 16
+	cgParentNone           = 0x70000000
+	cgExtraEdgesNeeded     = 0x80000000
+	cgLastEdge             = 0x80000000
+	cgGenerationShift      = 2
+
+	cgChunkOidFanout  = 0x4f494446
+	cgChunkOidLookup  = 0x4f49444c
+	cgChunkCommitData = 0x43444154
+	cgChunkExtraEdges = 0x45444745
+)
+
+var (
+	cgGraphFile     = filepath.Join("objects", "info", "commit-graph")
+	cgChainDir      = filepath.Join("objects", "info", "commit-graphs")
+	cgChainFile     = "commit-graph-chain"
+	cgChainLayerFmt = "graph-%v.graph"
+)
+
+type commitGraphLayer struct {
+	baseCount  uint32
+	commitNum  uint32
+	fanout     []byte
+	oids       []byte
+	commitData []byte
+	extraEdges []byte
+}
+
+// CommitGraph provides access to the commit-graph files written by git.
+// It allows parents and generation numbers of commits to be determined
+// without loading commit objects
+type CommitGraph struct {
+	layers    []*commitGraphLayer
+	commitNum uint32
+}
+
+// LoadCommitGraph loads the commit-graph for the repository at the provided path.
+// If the repository has no commit-graph then nil is returned
+func LoadCommitGraph(repoPath string) (commitGraph *CommitGraph, err error) {
+	var layerFiles []string
+
+	chainFilePath := filepath.Join(repoPath, cgChainDir, cgChainFile)
+	graphFilePath := filepath.Join(repoPath, cgGraphFile)
+
+	if chainFile, openErr := os.Open(chainFilePath); openErr == nil {
+		defer chainFile.Close()
+
+		scanner := bufio.NewScanner(chainFile)
+		for scanner.Scan() {
+			if hash := strings.TrimSpace(scanner.Text()); hash != "" {
+				layerFiles = append(layerFiles, filepath.Join(repoPath, cgChainDir, fmt.Sprintf(cgChainLayerFmt, hash)))
+			}
+		}
+
+		if err = scanner.Err(); err != nil {
+			return
+		}
+	} else if _, statErr := os.Stat(graphFilePath); statErr == nil {
+		layerFiles = append(layerFiles, graphFilePath)
+	}
+
+	if len(layerFiles) == 0 {
+		return
+	}
+
+	commitGraph = &CommitGraph{}
+
+	for _, layerFile := range layerFiles {
+		var data []byte
+		if data, err = ioutil.ReadFile(layerFile); err != nil {
+			return nil, err
+		}
+
+		if err = commitGraph.addLayer(data); err != nil {
+			return nil, fmt.Errorf("Invalid commit-graph file %v: %v", layerFile, err)
+		}
+	}
+
+	return
+}
+
+// NewCommitGraph creates a commit graph from the contents of a single commit-graph file
+func NewCommitGraph(data []byte) (commitGraph *CommitGraph, err error) {
+	commitGraph = &CommitGraph{}
+
+	if err = commitGraph.addLayer(data); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (commitGraph *CommitGraph) addLayer(data []byte) (err error) {
+	if len(data) < cgHeaderSize || string(data[0:4]) != cgSignature {
+		return fmt.Errorf("Invalid signature")
+	}
+
+	if data[4] != cgVersion {
+		return fmt.Errorf("Unsupported version %v", data[4])
+	}
+
+	if data[5] != cgHashVersionSHA1 {
+		return fmt.Errorf("Unsupported hash version %v", data[5])
+	}
+
+	chunkNum := int(data[6])
+	layer := &commitGraphLayer{
+		baseCount: commitGraph.commitNum,
+	}
+
+	chunkLookupEnd := cgHeaderSize + (chunkNum+1)*cgChunkLookupEntrySize
+	if len(data) < chunkLookupEnd {
+		return fmt.Errorf("Truncated chunk lookup table")
+	}
+
+	for chunkIndex := 0; chunkIndex < chunkNum; chunkIndex++ {
+		entry := data[cgHeaderSize+chunkIndex*cgChunkLookupEntrySize:]
+		chunkID := binary.BigEndian.Uint32(entry[0:4])
+		chunkStart := binary.BigEndian.Uint64(entry[4:12])
+		chunkEnd := binary.BigEndian.Uint64(entry[cgChunkLookupEntrySize+4 : cgChunkLookupEntrySize+12])
+
+		if chunkStart > chunkEnd || chunkEnd > uint64(len(data)) {
+			return fmt.Errorf("Invalid offset for chunk %x", chunkID)
+		}
+
+		chunk := data[chunkStart:chunkEnd]
+
+		switch chunkID {
+		case cgChunkOidFanout:
+			layer.fanout = chunk
+		case cgChunkOidLookup:
+			layer.oids = chunk
+		case cgChunkCommitData:
+			layer.commitData = chunk
+		case cgChunkExtraEdges:
+			layer.extraEdges = chunk
+		}
+	}
+
+	if len(layer.fanout) != cgFanoutSize || layer.oids == nil || layer.commitData == nil {
+		return fmt.Errorf("Missing required chunk")
+	}
+
+	layer.commitNum = binary.BigEndian.Uint32(layer.fanout[cgFanoutSize-4:])
+
+	if uint64(len(layer.oids)) < uint64(layer.commitNum)*cgOidSize ||
+		uint64(len(layer.commitData)) < uint64(layer.commitNum)*cgCommitDataSize {
+		return fmt.Errorf("Truncated commit data")
+	}
+
+	commitGraph.layers = append(commitGraph.layers, layer)
+	commitGraph.commitNum += layer.commitNum
+
+	return
+}
+
+// CommitNum returns the number of commits in the commit graph
+func (commitGraph *CommitGraph) CommitNum() uint32 {
+	return commitGraph.commitNum
+}
+
+func (commitGraph *CommitGraph) position(oid string) (position uint32, found bool) {
+	rawOid, err := hex.DecodeString(oid)
+	if err != nil || len(rawOid) != cgOidSize {
+		return
+	}
+
+	for _, layer := range commitGraph.layers {
+		var start uint32
+		if rawOid[0] > 0 {
+			start = binary.BigEndian.Uint32(layer.fanout[(int(rawOid[0])-1)*4:])
+		}
+		end := binary.BigEndian.Uint32(layer.fanout[int(rawOid[0])*4:])
+
+		for start < end {
+			middle := start + (end-start)/2
+			cmp := bytes.Compare(layer.oids[middle*cgOidSize:(middle+1)*cgOidSize], rawOid)
+
+			if cmp == 0 {
+				return layer.baseCount + middle, true
+			} else if cmp < 0 {
+				start = middle + 1
+			} else {
+				end = middle
+			}
+		}
+	}
+
+	return
+}
+
+func (commitGraph *CommitGraph) layer(position uint32) *commitGraphLayer {
+	for _, layer := range commitGraph.layers {
+		if position >= layer.baseCount && position < layer.baseCount+layer.commitNum {
+			return layer
+		}
+	}
+
+	return nil
+}
+
+func (commitGraph *CommitGraph) commitData(position uint32) (layer *commitGraphLayer, commitData []byte) {
+	if layer = commitGraph.layer(position); layer == nil {
+		return
+	}
+
+	localPosition := position - layer.baseCount
+
+	return layer, layer.commitData[localPosition*cgCommitDataSize : (localPosition+1)*cgCommitDataSize]
+}
+
+func (commitGraph *CommitGraph) oid(position uint32) string {
+	layer := commitGraph.layer(position)
+	if layer == nil {
+		return ""
+	}
+
+	localPosition := position - layer.baseCount
+
+	return hex.EncodeToString(layer.oids[localPosition*cgOidSize : (localPosition+1)*cgOidSize])
+}
+
+func (commitGraph *CommitGraph) parents(position uint32) (parents []uint32) {
+	layer, commitData := commitGraph.commitData(position)
+	if commitData == nil {
+		return
+	}
+
+	firstParent := binary.BigEndian.Uint32(commitData[cgOidSize:])
+	secondParent := binary.BigEndian.Uint32(commitData[cgOidSize+4:])
+
+	if firstParent == cgParentNone {
+		return
+	}
+
+	parents = append(parents, firstParent)
+
+	if secondParent == cgParentNone {
+		return
+	}
+
+	if secondParent&cgExtraEdgesNeeded == 0 {
+		return append(parents, secondParent)
+	}
+
+	for edgeIndex := secondParent &^ cgExtraEdgesNeeded; uint64(edgeIndex+1)*4 <= uint64(len(layer.extraEdges)); edgeIndex++ {
+		edge := binary.BigEndian.Uint32(layer.extraEdges[edgeIndex*4:])
+		parents = append(parents, edge&^cgLastEdge)
+
+		if edge&cgLastEdge != 0 {
+			break
+		}
+	}
+
+	return
+}
+
+func (commitGraph *CommitGraph) generation(position uint32) uint32 {
+	_, commitData := commitGraph.commitData(position)
+	if commitData == nil {
+		return 0
+	}
+
+	return binary.BigEndian.Uint32(commitData[cgOidSize+8:]) >> cgGenerationShift
+}
+
+// Parents returns the parent oids of the commit with the provided oid
+func (commitGraph *CommitGraph) Parents(oid string) (parents []string, found bool) {
+	position, found := commitGraph.position(oid)
+	if !found {
+		return
+	}
+
+	for _, parent := range commitGraph.parents(position) {
+		parents = append(parents, commitGraph.oid(parent))
+	}
+
+	return
+}
+
+// IsDescendant returns true if the commit with oid ancestor is reachable from the commit with oid commit.
+// found is false if either commit is not present in the commit graph
+func (commitGraph *CommitGraph) IsDescendant(commit, ancestor string) (isDescendant, found bool) {
+	commitPosition, commitFound := commitGraph.position(commit)
+	ancestorPosition, ancestorFound := commitGraph.position(ancestor)
+
+	if !commitFound || !ancestorFound {
+		return
+	}
+
+	found = true
+	ancestorGeneration := commitGraph.generation(ancestorPosition)
+	visited := map[uint32]bool{commitPosition: true}
+	stack := []uint32{commitPosition}
+
+	for len(stack) > 0 {
+		position := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if position == ancestorPosition {
+			isDescendant = true
+			return
+		}
+
+		for _, parent := range commitGraph.parents(position) {
+			if visited[parent] {
+				continue
+			}
+
+			visited[parent] = true
+
+			if generation := commitGraph.generation(parent); ancestorGeneration != 0 && generation != 0 && generation < ancestorGeneration {
+				continue
+			}
+
+			stack = append(stack, parent)
+		}
+	}
+
+	return
+}
+
+const (
+	cgFlagLocal = 1 << iota
+	cgFlagUpstream
+	cgFlagBoth = cgFlagLocal | cgFlagUpstream
+)
+
+type commitGraphQueue struct {
+	commitGraph *CommitGraph
+	positions   []uint32
+}
+
+// Len returns the number of queued commits
+func (queue *commitGraphQueue) Len() int {
+	return len(queue.positions)
+}
+
+// Less orders commits with the highest generation number first
+func (queue *commitGraphQueue) Less(i, j int) bool {
+	return queue.commitGraph.generation(queue.positions[i]) > queue.commitGraph.generation(queue.positions[j])
+}
+
+// Swap swaps the queued commits at the provided indexes
+func (queue *commitGraphQueue) Swap(i, j int) {
+	queue.positions[i], queue.positions[j] = queue.positions[j], queue.positions[i]
+}
+
+// Push adds a commit to the queue
+func (queue *commitGraphQueue) Push(position interface{}) {
+	queue.positions = append(queue.positions, position.(uint32))
+}
+
+// Pop removes the last commit from the queue
+func (queue *commitGraphQueue) Pop() interface{} {
+	position := queue.positions[len(queue.positions)-1]
+	queue.positions = queue.positions[:len(queue.positions)-1]
+
+	return position
+}
+
+// AheadBehind returns the number of commits reachable from local but not upstream (ahead)
+// and the number of commits reachable from upstream but not local (behind).
+// Commits are processed in generation number order so the walk can stop as soon as
+// only commits reachable from both remain. found is false if either commit is not
+// present in the commit graph or generation numbers have not been computed
+func (commitGraph *CommitGraph) AheadBehind(local, upstream string) (ahead, behind int, found bool) {
+	localPosition, localFound := commitGraph.position(local)
+	upstreamPosition, upstreamFound := commitGraph.position(upstream)
+
+	if !localFound || !upstreamFound ||
+		commitGraph.generation(localPosition) == 0 || commitGraph.generation(upstreamPosition) == 0 {
+		return
+	}
+
+	found = true
+
+	if localPosition == upstreamPosition {
+		return
+	}
+
+	flags := map[uint32]int{
+		localPosition:    cgFlagLocal,
+		upstreamPosition: cgFlagUpstream,
+	}
+
+	queue := &commitGraphQueue{
+		commitGraph: commitGraph,
+		positions:   []uint32{localPosition, upstreamPosition},
+	}
+	heap.Init(queue)
+
+	uncommonQueued := 2
+
+	for uncommonQueued > 0 {
+		position := heap.Pop(queue).(uint32)
+		positionFlags := flags[position]
+
+		switch positionFlags {
+		case cgFlagLocal:
+			ahead++
+		case cgFlagUpstream:
+			behind++
+		}
+
+		if positionFlags != cgFlagBoth {
+			uncommonQueued--
+		}
+
+		for _, parent := range commitGraph.parents(position) {
+			parentFlags, queued := flags[parent]
+			newParentFlags := parentFlags | positionFlags
+			flags[parent] = newParentFlags
+
+			if !queued {
+				heap.Push(queue, parent)
+
+				if newParentFlags != cgFlagBoth {
+					uncommonQueued++
+				}
+			} else if parentFlags != cgFlagBoth && newParentFlags == cgFlagBoth {
+				uncommonQueued--
+			}
+		}
+	}
+
+	return
+}
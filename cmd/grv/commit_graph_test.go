@@ -0,0 +1,268 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+type testGraphCommit struct {
+	oid     string
+	parents []string
+}
+
+func testGraphOid(name string) string {
+	return strings.Repeat(name, cgOidSize*2/len(name))
+}
+
+type testGraphCommits []testGraphCommit
+
+func (commits testGraphCommits) Len() int {
+	return len(commits)
+}
+
+func (commits testGraphCommits) Less(i, j int) bool {
+	return commits[i].oid < commits[j].oid
+}
+
+func (commits testGraphCommits) Swap(i, j int) {
+	commits[i], commits[j] = commits[j], commits[i]
+}
+
+// buildTestCommitGraph generates a commit-graph file containing the provided commits
+func buildTestCommitGraph(t *testing.T, commits []testGraphCommit) []byte {
+	sort.Sort(testGraphCommits(commits))
+
+	positions := make(map[string]uint32)
+	for index, commit := range commits {
+		positions[commit.oid] = uint32(index)
+	}
+
+	generations := make(map[string]uint32)
+	var generation func(oid string) uint32
+	generation = func(oid string) uint32 {
+		if value, exists := generations[oid]; exists {
+			return value
+		}
+
+		value := uint32(1)
+		for _, commit := range commits {
+			if commit.oid == oid {
+				for _, parent := range commit.parents {
+					if parentGeneration := generation(parent) + 1; parentGeneration > value {
+						value = parentGeneration
+					}
+				}
+			}
+		}
+
+		generations[oid] = value
+		return value
+	}
+
+	var fanout, oids, commitData, extraEdges bytes.Buffer
+
+	for firstByte := 0; firstByte < 256; firstByte++ {
+		count := uint32(0)
+		for _, commit := range commits {
+			if rawOid, _ := hex.DecodeString(commit.oid); int(rawOid[0]) <= firstByte {
+				count++
+			}
+		}
+
+		binary.Write(&fanout, binary.BigEndian, count)
+	}
+
+	for _, commit := range commits {
+		rawOid, err := hex.DecodeString(commit.oid)
+		if err != nil {
+			t.Fatalf("Invalid oid %v", commit.oid)
+		}
+
+		oids.Write(rawOid)
+		commitData.Write(make([]byte, cgOidSize))
+
+		parentPositions := []uint32{cgParentNone, cgParentNone}
+		for index, parent := range commit.parents {
+			if index < 2 {
+				parentPositions[index] = positions[parent]
+			}
+		}
+
+		if len(commit.parents) > 2 {
+			parentPositions[1] = cgExtraEdgesNeeded | uint32(extraEdges.Len()/4)
+
+			for index, parent := range commit.parents[1:] {
+				edge := positions[parent]
+				if index == len(commit.parents)-2 {
+					edge |= cgLastEdge
+				}
+
+				binary.Write(&extraEdges, binary.BigEndian, edge)
+			}
+		}
+
+		binary.Write(&commitData, binary.BigEndian, parentPositions)
+		binary.Write(&commitData, binary.BigEndian, generation(commit.oid)<<cgGenerationShift)
+		binary.Write(&commitData, binary.BigEndian, uint32(0))
+	}
+
+	chunks := []struct {
+		id   uint32
+		data []byte
+	}{
+		{id: cgChunkOidFanout, data: fanout.Bytes()},
+		{id: cgChunkOidLookup, data: oids.Bytes()},
+		{id: cgChunkCommitData, data: commitData.Bytes()},
+		{id: cgChunkExtraEdges, data: extraEdges.Bytes()},
+	}
+
+	var graph bytes.Buffer
+	graph.WriteString(cgSignature)
+	graph.Write([]byte{cgVersion, cgHashVersionSHA1, byte(len(chunks)), 0})
+
+	offset := uint64(cgHeaderSize + (len(chunks)+1)*cgChunkLookupEntrySize)
+	for _, chunk := range chunks {
+		binary.Write(&graph, binary.BigEndian, chunk.id)
+		binary.Write(&graph, binary.BigEndian, offset)
+		offset += uint64(len(chunk.data))
+	}
+
+	binary.Write(&graph, binary.BigEndian, uint32(0))
+	binary.Write(&graph, binary.BigEndian, offset)
+
+	for _, chunk := range chunks {
+		graph.Write(chunk.data)
+	}
+
+	return graph.Bytes()
+}
+
+// newTestCommitGraph creates a history where B follows the root commit A,
+// C and D both follow B, F follows D and E is an octopus merge of C, D and F.
+// 0 is an unrelated root commit
+func newTestCommitGraph(t *testing.T) *CommitGraph {
+	data := buildTestCommitGraph(t, []testGraphCommit{
+		{oid: testGraphOid("a")},
+		{oid: testGraphOid("b"), parents: []string{testGraphOid("a")}},
+		{oid: testGraphOid("c"), parents: []string{testGraphOid("b")}},
+		{oid: testGraphOid("d"), parents: []string{testGraphOid("b")}},
+		{oid: testGraphOid("f"), parents: []string{testGraphOid("d")}},
+		{oid: testGraphOid("e"), parents: []string{testGraphOid("c"), testGraphOid("d"), testGraphOid("f")}},
+		{oid: testGraphOid("0")},
+	})
+
+	commitGraph, err := NewCommitGraph(data)
+	if err != nil {
+		t.Fatalf("Unable to create commit graph: %v", err)
+	}
+
+	return commitGraph
+}
+
+func TestCommitGraphRejectsInvalidData(t *testing.T) {
+	if _, err := NewCommitGraph([]byte("CGPX")); err == nil {
+		t.Errorf("Expected error for invalid commit graph data")
+	}
+}
+
+func TestCommitGraphReturnsParents(t *testing.T) {
+	commitGraph := newTestCommitGraph(t)
+
+	if commitNum := commitGraph.CommitNum(); commitNum != 7 {
+		t.Errorf("Commit number does not match expected value. Expected: 7, Actual: %v", commitNum)
+	}
+
+	var parentsTests = []struct {
+		oid             string
+		expectedParents []string
+		expectedFound   bool
+	}{
+		{
+			oid:           testGraphOid("a"),
+			expectedFound: true,
+		},
+		{
+			oid:             testGraphOid("c"),
+			expectedParents: []string{testGraphOid("b")},
+			expectedFound:   true,
+		},
+		{
+			oid:             testGraphOid("e"),
+			expectedParents: []string{testGraphOid("c"), testGraphOid("d"), testGraphOid("f")},
+			expectedFound:   true,
+		},
+		{
+			oid:           testGraphOid("9"),
+			expectedFound: false,
+		},
+	}
+
+	for _, parentsTest := range parentsTests {
+		parents, found := commitGraph.Parents(parentsTest.oid)
+
+		if found != parentsTest.expectedFound || !reflect.DeepEqual(parents, parentsTest.expectedParents) {
+			t.Errorf("Parents do not match expected value for %v. Expected: %v, %v. Actual: %v, %v",
+				parentsTest.oid, parentsTest.expectedParents, parentsTest.expectedFound, parents, found)
+		}
+	}
+}
+
+func TestCommitGraphIsDescendant(t *testing.T) {
+	commitGraph := newTestCommitGraph(t)
+
+	var isDescendantTests = []struct {
+		commit               string
+		ancestor             string
+		expectedIsDescendant bool
+	}{
+		{commit: "e", ancestor: "a", expectedIsDescendant: true},
+		{commit: "e", ancestor: "f", expectedIsDescendant: true},
+		{commit: "c", ancestor: "d", expectedIsDescendant: false},
+		{commit: "a", ancestor: "e", expectedIsDescendant: false},
+		{commit: "e", ancestor: "0", expectedIsDescendant: false},
+	}
+
+	for _, isDescendantTest := range isDescendantTests {
+		isDescendant, found := commitGraph.IsDescendant(testGraphOid(isDescendantTest.commit), testGraphOid(isDescendantTest.ancestor))
+
+		if !found || isDescendant != isDescendantTest.expectedIsDescendant {
+			t.Errorf("IsDescendant does not match expected value for %v -> %v. Expected: %v, Actual: %v (found: %v)",
+				isDescendantTest.commit, isDescendantTest.ancestor, isDescendantTest.expectedIsDescendant, isDescendant, found)
+		}
+	}
+}
+
+func TestCommitGraphAheadBehind(t *testing.T) {
+	commitGraph := newTestCommitGraph(t)
+
+	var aheadBehindTests = []struct {
+		local          string
+		upstream       string
+		expectedAhead  int
+		expectedBehind int
+	}{
+		{local: "c", upstream: "f", expectedAhead: 1, expectedBehind: 2},
+		{local: "e", upstream: "b", expectedAhead: 4, expectedBehind: 0},
+		{local: "a", upstream: "e", expectedAhead: 0, expectedBehind: 5},
+		{local: "c", upstream: "c", expectedAhead: 0, expectedBehind: 0},
+		{local: "c", upstream: "0", expectedAhead: 3, expectedBehind: 1},
+	}
+
+	for _, aheadBehindTest := range aheadBehindTests {
+		ahead, behind, found := commitGraph.AheadBehind(testGraphOid(aheadBehindTest.local), testGraphOid(aheadBehindTest.upstream))
+
+		if !found || ahead != aheadBehindTest.expectedAhead || behind != aheadBehindTest.expectedBehind {
+			t.Errorf("AheadBehind does not match expected value for %v..%v. Expected: %v, %v. Actual: %v, %v (found: %v)",
+				aheadBehindTest.local, aheadBehindTest.upstream, aheadBehindTest.expectedAhead, aheadBehindTest.expectedBehind, ahead, behind, found)
+		}
+	}
+
+	if _, _, found := commitGraph.AheadBehind(testGraphOid("a"), testGraphOid("9")); found {
+		t.Errorf("Expected commit not in commit graph to not be found")
+	}
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"container/list"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+// CommitLoader loads the commit with the provided oid
+type CommitLoader func(oid *Oid) (*Commit, error)
+
+// commitList stores an ordered list of commits.
+// When a limit is set only the most recently accessed commits are kept in memory.
+// Evicted commits are reloaded using their oid the next time they are accessed
+type commitList struct {
+	oids         []*Oid
+	commits      []*Commit
+	limit        uint
+	loader       CommitLoader
+	recentlyUsed *list.List
+	elements     map[uint]*list.Element
+}
+
+func newCommitList(limit uint, loader CommitLoader) *commitList {
+	return &commitList{
+		limit:        limit,
+		loader:       loader,
+		recentlyUsed: list.New(),
+		elements:     make(map[uint]*list.Element),
+	}
+}
+
+// Len returns the number of commits in the list, including evicted commits
+func (commitList *commitList) Len() uint {
+	return uint(len(commitList.oids))
+}
+
+// LoadedNum returns the number of commits currently held in memory
+func (commitList *commitList) LoadedNum() uint {
+	if commitList.limit == 0 {
+		return commitList.Len()
+	}
+
+	return uint(commitList.recentlyUsed.Len())
+}
+
+// Append adds a commit to the end of the list
+func (commitList *commitList) Append(commit *Commit) {
+	index := uint(len(commitList.oids))
+
+	commitList.oids = append(commitList.oids, commit.oid)
+	commitList.commits = append(commitList.commits, commit)
+
+	commitList.markUsed(index)
+	commitList.evict()
+}
+
+// Commit returns the commit at the specified index, reloading it if it was evicted
+func (commitList *commitList) Commit(index uint) (commit *Commit) {
+	if index >= commitList.Len() {
+		return
+	}
+
+	if commit = commitList.commits[index]; commit == nil {
+		var err error
+		oid := commitList.oids[index]
+
+		if commit, err = commitList.loader(oid); err != nil {
+			log.Errorf("Unable to reload evicted commit %v: %v", oid, err)
+			return nil
+		}
+
+		commitList.commits[index] = commit
+	}
+
+	commitList.markUsed(index)
+	commitList.evict()
+
+	return
+}
+
+// Set replaces the contents of the list with the provided commits
+func (commitList *commitList) Set(commits []*Commit) {
+	commitList.oids = nil
+	commitList.commits = nil
+	commitList.recentlyUsed.Init()
+	commitList.elements = make(map[uint]*list.Element)
+
+	for _, commit := range commits {
+		commitList.Append(commit)
+	}
+}
+
+// SetLimit updates the maximum number of commits held in memory.
+// A limit of 0 means no commits will be evicted
+func (commitList *commitList) SetLimit(limit uint) {
+	if limit == commitList.limit {
+		return
+	}
+
+	previousLimit := commitList.limit
+	commitList.limit = limit
+
+	if limit == 0 {
+		commitList.recentlyUsed.Init()
+		commitList.elements = make(map[uint]*list.Element)
+		return
+	}
+
+	if previousLimit == 0 {
+		for index := len(commitList.commits) - 1; index >= 0; index-- {
+			if commitList.commits[index] != nil {
+				commitList.markUsed(uint(index))
+			}
+		}
+	}
+
+	commitList.evict()
+}
+
+// Clone creates a copy of the list which shares the same commit instances
+func (commitList *commitList) Clone() *commitList {
+	clone := newCommitList(commitList.limit, commitList.loader)
+	clone.oids = append([]*Oid(nil), commitList.oids...)
+	clone.commits = append([]*Commit(nil), commitList.commits...)
+
+	for element := commitList.recentlyUsed.Back(); element != nil; element = element.Prev() {
+		index := element.Value.(uint)
+		clone.elements[index] = clone.recentlyUsed.PushFront(index)
+	}
+
+	return clone
+}
+
+func (commitList *commitList) markUsed(index uint) {
+	if commitList.limit == 0 {
+		return
+	}
+
+	if element, exists := commitList.elements[index]; exists {
+		commitList.recentlyUsed.MoveToFront(element)
+	} else {
+		commitList.elements[index] = commitList.recentlyUsed.PushFront(index)
+	}
+}
+
+func (commitList *commitList) evict() {
+	if commitList.limit == 0 {
+		return
+	}
+
+	for uint(commitList.recentlyUsed.Len()) > commitList.limit {
+		element := commitList.recentlyUsed.Back()
+		index := element.Value.(uint)
+
+		commitList.recentlyUsed.Remove(element)
+		delete(commitList.elements, index)
+		commitList.commits[index] = nil
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+type testCommitLoader struct {
+	commits   map[*Oid]*Commit
+	loadCount int
+}
+
+func newTestCommitLoader(commitNum int) (*testCommitLoader, []*Commit) {
+	commitLoader := &testCommitLoader{
+		commits: make(map[*Oid]*Commit),
+	}
+
+	var commits []*Commit
+	for index := 0; index < commitNum; index++ {
+		commit := &Commit{oid: &Oid{}}
+		commitLoader.commits[commit.oid] = commit
+		commits = append(commits, commit)
+	}
+
+	return commitLoader, commits
+}
+
+func (commitLoader *testCommitLoader) load(oid *Oid) (*Commit, error) {
+	commitLoader.loadCount++
+
+	if commit, exists := commitLoader.commits[oid]; exists {
+		return &Commit{oid: commit.oid}, nil
+	}
+
+	return nil, fmt.Errorf("No commit exists")
+}
+
+func TestCommitListWithNoLimitRetainsAllCommits(t *testing.T) {
+	commitLoader, commits := newTestCommitLoader(10)
+	commitList := newCommitList(0, commitLoader.load)
+
+	for _, commit := range commits {
+		commitList.Append(commit)
+	}
+
+	for index, commit := range commits {
+		if actual := commitList.Commit(uint(index)); actual != commit {
+			t.Errorf("Commit at index %v does not match expected value", index)
+		}
+	}
+
+	if commitLoader.loadCount != 0 {
+		t.Errorf("Expected no commits to be reloaded but %v were", commitLoader.loadCount)
+	}
+}
+
+func TestCommitListEvictsLeastRecentlyUsedCommits(t *testing.T) {
+	commitLoader, commits := newTestCommitLoader(5)
+	commitList := newCommitList(3, commitLoader.load)
+
+	commitList.Append(commits[0])
+	commitList.Append(commits[1])
+	commitList.Commit(0)
+	commitList.Append(commits[2])
+	commitList.Append(commits[3])
+	commitList.Append(commits[4])
+
+	if commitList.Len() != 5 {
+		t.Errorf("Commit list length does not match expected value. Expected: 5, Actual: %v", commitList.Len())
+	}
+
+	if commitList.LoadedNum() != 3 {
+		t.Errorf("Loaded commit number does not match expected value. Expected: 3, Actual: %v", commitList.LoadedNum())
+	}
+
+	for _, index := range []uint{2, 3, 4} {
+		if commitList.Commit(index) != commits[index] {
+			t.Errorf("Expected commit at index %v to be retained", index)
+		}
+	}
+
+	if commitLoader.loadCount != 0 {
+		t.Errorf("Expected no commits to be reloaded but %v were", commitLoader.loadCount)
+	}
+}
+
+func TestCommitListReloadsEvictedCommits(t *testing.T) {
+	commitLoader, commits := newTestCommitLoader(4)
+	commitList := newCommitList(2, commitLoader.load)
+
+	for _, commit := range commits {
+		commitList.Append(commit)
+	}
+
+	commit := commitList.Commit(0)
+	if commit == nil || commit.oid != commits[0].oid {
+		t.Fatalf("Expected evicted commit to be reloaded")
+	}
+
+	if commitLoader.loadCount != 1 {
+		t.Errorf("Load count does not match expected value. Expected: 1, Actual: %v", commitLoader.loadCount)
+	}
+
+	if commitList.Commit(0) != commit {
+		t.Errorf("Expected reloaded commit to be retained")
+	}
+
+	if commitList.LoadedNum() != 2 {
+		t.Errorf("Loaded commit number does not match expected value. Expected: 2, Actual: %v", commitList.LoadedNum())
+	}
+}
+
+func TestCommitListSetLimitEvictsExcessCommits(t *testing.T) {
+	commitLoader, commits := newTestCommitLoader(6)
+	commitList := newCommitList(0, commitLoader.load)
+
+	for _, commit := range commits {
+		commitList.Append(commit)
+	}
+
+	commitList.SetLimit(2)
+
+	if commitList.LoadedNum() != 2 {
+		t.Errorf("Loaded commit number does not match expected value. Expected: 2, Actual: %v", commitList.LoadedNum())
+	}
+
+	for index := range commits {
+		if commit := commitList.Commit(uint(index)); commit == nil || commit.oid != commits[index].oid {
+			t.Errorf("Commit at index %v does not match expected value", index)
+		}
+	}
+}
+
+func TestCommitListCloneSharesRecentlyUsedOrder(t *testing.T) {
+	commitLoader, commits := newTestCommitLoader(3)
+	commitList := newCommitList(3, commitLoader.load)
+
+	for _, commit := range commits {
+		commitList.Append(commit)
+	}
+
+	commitList.Commit(0)
+	clone := commitList.Clone()
+	clone.SetLimit(1)
+
+	if clone.Commit(0) != commits[0] {
+		t.Errorf("Expected most recently used commit to be retained in clone")
+	}
+
+	if commitList.LoadedNum() != 3 {
+		t.Errorf("Expected original commit list to be unaffected by clone")
+	}
+}
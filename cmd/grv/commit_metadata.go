@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+// SignatureStatus describes the result of verifying the signature of a commit
+type SignatureStatus int
+
+// The set of supported signature statuses
+const (
+	SignatureNone SignatureStatus = iota
+	SignatureGood
+	SignatureBad
+	SignatureUnverified
+)
+
+var signatureStatusIndicators = map[SignatureStatus]string{
+	SignatureGood:       "G",
+	SignatureBad:        "B",
+	SignatureUnverified: "U",
+}
+
+// CommitStats contains the number of files and lines changed by a commit
+type CommitStats struct {
+	filesChanged int
+	insertions   int
+	deletions    int
+}
+
+// String returns the stats in the form +insertions -deletions
+func (commitStats CommitStats) String() string {
+	return fmt.Sprintf("+%v -%v", commitStats.insertions, commitStats.deletions)
+}
+
+// CommitMetadata contains data derived from a commit which is expensive to compute
+type CommitMetadata struct {
+	stats           CommitStats
+	signatureStatus SignatureStatus
+}
+
+// SignatureIndicator returns a single character summary of the signature status.
+// An empty string is returned for unsigned commits
+func (commitMetadata *CommitMetadata) SignatureIndicator() string {
+	return signatureStatusIndicators[commitMetadata.signatureStatus]
+}
+
+// CommitMetadataGenerator computes metadata for a commit
+type CommitMetadataGenerator interface {
+	CommitStats(commit *Commit) (CommitStats, error)
+	CommitSignatureStatus(commit *Commit) (SignatureStatus, error)
+}
+
+// CommitMetadataCache computes commit metadata on a worker pool and caches the results
+type CommitMetadataCache struct {
+	generator  CommitMetadataGenerator
+	workerPool *WorkerPool
+	channels   *Channels
+	metadata   map[string]*CommitMetadata
+	lock       sync.Mutex
+}
+
+// NewCommitMetadataCache creates a new instance which computes metadata using the provided worker pool
+func NewCommitMetadataCache(generator CommitMetadataGenerator, workerPool *WorkerPool, channels *Channels) *CommitMetadataCache {
+	return &CommitMetadataCache{
+		generator:  generator,
+		workerPool: workerPool,
+		channels:   channels,
+		metadata:   make(map[string]*CommitMetadata),
+	}
+}
+
+// Metadata returns the cached metadata for the provided commit if it has been computed
+func (commitMetadataCache *CommitMetadataCache) Metadata(commit *Commit) (commitMetadata *CommitMetadata, exists bool) {
+	commitMetadataCache.lock.Lock()
+	defer commitMetadataCache.lock.Unlock()
+
+	commitMetadata, exists = commitMetadataCache.metadata[commit.oid.String()]
+	return
+}
+
+// Load queues metadata computation for each of the provided commits which has no cached metadata.
+// The display is updated as the metadata for each commit becomes available
+func (commitMetadataCache *CommitMetadataCache) Load(commits []*Commit) {
+	for _, commit := range commits {
+		if _, exists := commitMetadataCache.Metadata(commit); exists {
+			continue
+		}
+
+		commit := commit
+		commitMetadataCache.workerPool.Submit(commit.oid.String(), func() {
+			commitMetadataCache.generateMetadata(commit)
+		})
+	}
+}
+
+func (commitMetadataCache *CommitMetadataCache) generateMetadata(commit *Commit) {
+	commitMetadata := &CommitMetadata{}
+	var err error
+
+	if commitMetadata.stats, err = commitMetadataCache.generator.CommitStats(commit); err != nil {
+		log.Errorf("Unable to generate stats for commit %v: %v", commit.oid, err)
+	}
+
+	if commitMetadata.signatureStatus, err = commitMetadataCache.generator.CommitSignatureStatus(commit); err != nil {
+		log.Errorf("Unable to verify signature for commit %v: %v", commit.oid, err)
+		commitMetadata.signatureStatus = SignatureUnverified
+	}
+
+	commitMetadataCache.lock.Lock()
+	commitMetadataCache.metadata[commit.oid.String()] = commitMetadata
+	commitMetadataCache.lock.Unlock()
+
+	commitMetadataCache.channels.UpdateDisplay()
+}
+
+// isSignedCommitObject returns true if the raw commit object contains a signature header
+func isSignedCommitObject(data []byte) bool {
+	for _, line := range strings.Split(string(data), "\n") {
+		if line == "" {
+			return false
+		} else if strings.HasPrefix(line, "gpgsig ") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseVerifyCommitOutput determines the signature status from the gpg status lines
+// output by git verify-commit --raw
+func parseVerifyCommitOutput(output string) SignatureStatus {
+	switch {
+	case strings.Contains(output, "[GNUPG:] BADSIG"):
+		return SignatureBad
+	case strings.Contains(output, "[GNUPG:] GOODSIG"):
+		return SignatureGood
+	default:
+		return SignatureUnverified
+	}
+}
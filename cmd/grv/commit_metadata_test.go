@@ -0,0 +1,90 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+type testCommitMetadataGenerator struct {
+	stats           CommitStats
+	signatureStatus SignatureStatus
+}
+
+func (generator *testCommitMetadataGenerator) CommitStats(commit *Commit) (CommitStats, error) {
+	return generator.stats, nil
+}
+
+func (generator *testCommitMetadataGenerator) CommitSignatureStatus(commit *Commit) (SignatureStatus, error) {
+	return generator.signatureStatus, nil
+}
+
+func TestCommitMetadataIsCachedOnceLoaded(t *testing.T) {
+	generator := &testCommitMetadataGenerator{
+		stats:           CommitStats{filesChanged: 2, insertions: 10, deletions: 3},
+		signatureStatus: SignatureGood,
+	}
+
+	workerPool := NewWorkerPool(2)
+	workerPool.Start()
+
+	commitMetadataCache := NewCommitMetadataCache(generator, workerPool, &Channels{})
+	commit := &Commit{oid: &Oid{}}
+
+	if _, exists := commitMetadataCache.Metadata(commit); exists {
+		t.Errorf("Expected no metadata before load")
+	}
+
+	commitMetadataCache.Load([]*Commit{commit})
+
+	for workerPool.Pending(commit.oid.String()) {
+		time.Sleep(time.Millisecond)
+	}
+
+	workerPool.Stop()
+
+	commitMetadata, exists := commitMetadataCache.Metadata(commit)
+	if !exists {
+		t.Fatalf("Expected metadata to exist after load")
+	}
+
+	if commitMetadata.stats != generator.stats || commitMetadata.signatureStatus != SignatureGood {
+		t.Errorf("Metadata does not match expected value: %v", commitMetadata)
+	}
+
+	if commitMetadata.stats.String() != "+10 -3" || commitMetadata.SignatureIndicator() != "G" {
+		t.Errorf("Metadata display values do not match expected value: %v %v", commitMetadata.stats, commitMetadata.SignatureIndicator())
+	}
+}
+
+func TestIsSignedCommitObject(t *testing.T) {
+	signed := "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+		"author A <a@example.com> 1500000000 +0000\n" +
+		"gpgsig -----BEGIN PGP SIGNATURE-----\n -----END PGP SIGNATURE-----\n" +
+		"\nMessage\n"
+	unsigned := "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n\ngpgsig in message\n"
+
+	if !isSignedCommitObject([]byte(signed)) {
+		t.Errorf("Expected commit to be signed")
+	}
+
+	if isSignedCommitObject([]byte(unsigned)) {
+		t.Errorf("Expected commit to be unsigned")
+	}
+}
+
+func TestParseVerifyCommitOutput(t *testing.T) {
+	var verifyCommitTests = []struct {
+		output                  string
+		expectedSignatureStatus SignatureStatus
+	}{
+		{output: "[GNUPG:] NEWSIG\n[GNUPG:] GOODSIG 1234 A <a@example.com>\n", expectedSignatureStatus: SignatureGood},
+		{output: "[GNUPG:] BADSIG 1234 A <a@example.com>\n", expectedSignatureStatus: SignatureBad},
+		{output: "[GNUPG:] ERRSIG 1234 1 8 00 1500000000 9\n[GNUPG:] NO_PUBKEY 1234\n", expectedSignatureStatus: SignatureUnverified},
+	}
+
+	for _, verifyCommitTest := range verifyCommitTests {
+		if signatureStatus := parseVerifyCommitOutput(verifyCommitTest.output); signatureStatus != verifyCommitTest.expectedSignatureStatus {
+			t.Errorf("Signature status does not match expected value. Expected: %v, Actual: %v", verifyCommitTest.expectedSignatureStatus, signatureStatus)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var commitTrailerRegex = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9-]*):\s*(.*)$`)
+
+// CommitTrailer is a key value pair in the trailer block at the end of a commit message,
+// e.g. Signed-off-by: John Smith <john@example.com>
+type CommitTrailer struct {
+	key   string
+	value string
+}
+
+// parseCommitTrailers returns the trailers in the last paragraph of the commit message lines
+// and the index of the first line of that paragraph. The last paragraph is only considered
+// a trailer block if it is not the first paragraph and every line in it is a trailer or a
+// continuation of a trailer. If no trailer block exists the index returned is the number of lines
+func parseCommitTrailers(lines []string) (trailers []*CommitTrailer, startIndex int) {
+	endIndex := len(lines)
+	for endIndex > 0 && strings.TrimSpace(lines[endIndex-1]) == "" {
+		endIndex--
+	}
+
+	startIndex = endIndex
+	for startIndex > 0 && strings.TrimSpace(lines[startIndex-1]) != "" {
+		startIndex--
+	}
+
+	if startIndex == 0 || startIndex == endIndex {
+		return nil, len(lines)
+	}
+
+	for _, line := range lines[startIndex:endIndex] {
+		if matches := commitTrailerRegex.FindStringSubmatch(line); matches != nil {
+			trailers = append(trailers, &CommitTrailer{
+				key:   matches[1],
+				value: strings.TrimSpace(matches[2]),
+			})
+		} else if len(trailers) > 0 && (line[0] == ' ' || line[0] == '\t') {
+			trailer := trailers[len(trailers)-1]
+			trailer.value = strings.TrimSpace(trailer.value + " " + strings.TrimSpace(line))
+		} else {
+			return nil, len(lines)
+		}
+	}
+
+	return
+}
+
+// commitMessageTrailers returns the trailers in the commit message
+func commitMessageTrailers(message string) []*CommitTrailer {
+	trailers, _ := parseCommitTrailers(strings.Split(message, "\n"))
+	return trailers
+}
+
+// commitTrailerValues returns the values of the trailers in the commit message with the
+// provided key, separated by newlines. Keys are compared case-insensitively
+func commitTrailerValues(message, key string) string {
+	var values []string
+
+	for _, trailer := range commitMessageTrailers(message) {
+		if key == "" {
+			values = append(values, trailer.key+": "+trailer.value)
+		} else if strings.EqualFold(trailer.key, key) {
+			values = append(values, trailer.value)
+		}
+	}
+
+	return strings.Join(values, "\n")
+}
+
+// formatCommitTrailers formats the trailers with their values aligned
+func formatCommitTrailers(trailers []*CommitTrailer) (lines []string) {
+	keyWidth := 0
+	for _, trailer := range trailers {
+		keyWidth = MaxInt(keyWidth, len(trailer.key)+1)
+	}
+
+	for _, trailer := range trailers {
+		lines = append(lines, fmt.Sprintf("%-*v %v", keyWidth, trailer.key+":", trailer.value))
+	}
+
+	return
+}
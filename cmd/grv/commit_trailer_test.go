@@ -0,0 +1,68 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCommitTrailersAreParsedFromLastParagraph(t *testing.T) {
+	lines := strings.Split("Fix the thing\n\nLonger description\n\nSigned-off-by: John Smith <john@example.com>\nReviewed-by: Jane Roe\n <jane@example.com>\nFixes: abc1234\n", "\n")
+
+	trailers, startIndex := parseCommitTrailers(lines)
+	expectedTrailers := []*CommitTrailer{
+		{key: "Signed-off-by", value: "John Smith <john@example.com>"},
+		{key: "Reviewed-by", value: "Jane Roe <jane@example.com>"},
+		{key: "Fixes", value: "abc1234"},
+	}
+
+	if startIndex != 4 {
+		t.Errorf("Trailer start index does not match expected value. Expected: 4, Actual: %v", startIndex)
+	}
+
+	if !reflect.DeepEqual(trailers, expectedTrailers) {
+		t.Errorf("Trailers do not match expected value. Expected: %v, Actual: %v", expectedTrailers, trailers)
+	}
+}
+
+func TestParagraphsContainingNonTrailerLinesAreNotTrailers(t *testing.T) {
+	messages := []string{
+		"Signed-off-by: John Smith <john@example.com>\n",
+		"Fix the thing\n\nThis fixes the thing\nSigned-off-by: John Smith <john@example.com>\n",
+	}
+
+	for _, message := range messages {
+		lines := strings.Split(message, "\n")
+
+		if trailers, startIndex := parseCommitTrailers(lines); trailers != nil || startIndex != len(lines) {
+			t.Errorf("Expected no trailers in message %q but found %v", message, trailers)
+		}
+	}
+}
+
+func TestCommitTrailerValuesAreMatchedByKey(t *testing.T) {
+	message := "Fix the thing\n\nReviewed-by: Jane Roe\nreviewed-by: John Smith\nFixes: abc1234\n"
+
+	if values := commitTrailerValues(message, "Reviewed-by"); values != "Jane Roe\nJohn Smith" {
+		t.Errorf("Values do not match expected value. Actual: %q", values)
+	}
+
+	if values := commitTrailerValues(message, ""); values != "Reviewed-by: Jane Roe\nreviewed-by: John Smith\nFixes: abc1234" {
+		t.Errorf("Values do not match expected value. Actual: %q", values)
+	}
+}
+
+func TestCommitTrailersAreFormattedWithAlignedValues(t *testing.T) {
+	lines := formatCommitTrailers([]*CommitTrailer{
+		{key: "Signed-off-by", value: "John Smith"},
+		{key: "Fixes", value: "abc1234"},
+	})
+	expectedLines := []string{
+		"Signed-off-by: John Smith",
+		"Fixes:         abc1234",
+	}
+
+	if !reflect.DeepEqual(lines, expectedLines) {
+		t.Errorf("Lines do not match expected value. Expected: %q, Actual: %q", expectedLines, lines)
+	}
+}
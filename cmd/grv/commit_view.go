@@ -3,6 +3,7 @@ package main
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -11,10 +12,13 @@ import (
 
 const (
 	cvLoadRefreshMs = 500
-	cvColumnNum     = 4
-	cvDateFormat    = "2006-01-02 15:04"
+	cvColumnNum     = 5
+	cvAuthorColumn  = 2
+	cvSummaryColumn = cvColumnNum - 1
 )
 
+var cvLoadingSpinner = []rune{'|', '/', '-', '\\'}
+
 type commitViewHandler func(*CommitView, Action) error
 
 type loadingCommitsRefreshTask struct {
@@ -25,8 +29,12 @@ type loadingCommitsRefreshTask struct {
 }
 
 type referenceViewData struct {
-	viewPos        ViewPos
-	tableFormatter *TableFormatter
+	viewPos          ViewPos
+	tableFormatter   *TableFormatter
+	jumpList         *JumpList
+	filterQueries    []string
+	filterNames      []string
+	selectedCommitID string
 }
 
 // CommitViewListener is notified when a commit is selected
@@ -37,6 +45,7 @@ type CommitViewListener interface {
 // CommitView is the overall instance representing the commit view
 type CommitView struct {
 	channels            *Channels
+	config              Config
 	repoData            RepoData
 	activeRef           Ref
 	active              bool
@@ -46,30 +55,64 @@ type CommitView struct {
 	commitViewListeners []CommitViewListener
 	viewDimension       ViewDimension
 	viewSearch          *ViewSearch
+	pendingCommitID     string
+	sessionStore        *SessionStore
+	sessionState        *SessionState
+	defaultFilter       string
+	rebaseTodo          *RebaseTodo
 	lock                sync.Mutex
 }
 
 // NewCommitView creates a new instance of the commit view
-func NewCommitView(repoData RepoData, channels *Channels) *CommitView {
+func NewCommitView(repoData RepoData, channels *Channels, config Config) *CommitView {
 	commitView := &CommitView{
 		channels:    channels,
+		config:      config,
 		repoData:    repoData,
 		refViewData: make(map[string]*referenceViewData),
+		rebaseTodo:  NewRebaseTodo(),
 		handlers: map[ActionType]commitViewHandler{
-			ActionPrevLine:     moveUpCommit,
-			ActionNextLine:     moveDownCommit,
-			ActionPrevPage:     moveUpCommitPage,
-			ActionNextPage:     moveDownCommitPage,
-			ActionPrevHalfPage: moveUpCommitHalfPage,
-			ActionNextHalfPage: moveDownCommitHalfPage,
-			ActionScrollRight:  scrollCommitViewRight,
-			ActionScrollLeft:   scrollCommitViewLeft,
-			ActionFirstLine:    moveToFirstCommit,
-			ActionLastLine:     moveToLastCommit,
-			ActionAddFilter:    addCommitFilter,
-			ActionRemoveFilter: removeCommitFilter,
-			ActionCenterView:   centerCommitView,
-			ActionSelect:       selectCommit,
+			ActionPrevLine:                moveUpCommit,
+			ActionNextLine:                moveDownCommit,
+			ActionPrevPage:                moveUpCommitPage,
+			ActionNextPage:                moveDownCommitPage,
+			ActionPrevHalfPage:            moveUpCommitHalfPage,
+			ActionNextHalfPage:            moveDownCommitHalfPage,
+			ActionScrollRight:             scrollCommitViewRight,
+			ActionScrollLeft:              scrollCommitViewLeft,
+			ActionFirstLine:               moveToFirstCommit,
+			ActionLastLine:                moveToLastCommit,
+			ActionAddFilter:               addCommitFilter,
+			ActionRemoveFilter:            removeCommitFilter,
+			ActionCenterView:              centerCommitView,
+			ActionScrollCursorTop:         scrollCommitViewCursorTop,
+			ActionScrollCursorBottom:      scrollCommitViewCursorBottom,
+			ActionSelect:                  selectCommit,
+			ActionSelectCommit:            selectCommitByID,
+			ActionSelectParentCommit:      selectParentCommit,
+			ActionSelectMergeParentCommit: selectMergeParentCommit,
+			ActionSelectChildCommit:       selectChildCommit,
+			ActionJumpBack:                jumpBackCommit,
+			ActionJumpForward:             jumpForwardCommit,
+			ActionSetMark:                 setCommitMark,
+			ActionJumpToMark:              jumpToCommitMark,
+			ActionYank:                    yankCommitID,
+			ActionYankSummary:             yankCommitSummary,
+			ActionYankMessage:             yankCommitMessage,
+			ActionYankReference:           yankCommitReference,
+			ActionOpenPullRequest:         openCommitPullRequest,
+			ActionOpenIssue:               openCommitIssue,
+			ActionBisectStart:             startBisect,
+			ActionBisectGood:              markBisectCommitGood,
+			ActionBisectBad:               markBisectCommitBad,
+			ActionBisectSkip:              markBisectCommitSkipped,
+			ActionBisectReset:             resetBisect,
+			ActionRebasePick:              assignRebasePick,
+			ActionRebaseSquash:            assignRebaseSquash,
+			ActionRebaseFixup:             assignRebaseFixup,
+			ActionRebaseDrop:              assignRebaseDrop,
+			ActionRebaseClear:             clearRebaseTodo,
+			ActionInteractiveRebase:       startInteractiveRebase,
 		},
 	}
 
@@ -84,6 +127,16 @@ func (commitView *CommitView) Initialise() (err error) {
 
 	commitView.repoData.RegisterCommitSetListener(commitView)
 
+	if commitView.sessionStore != nil {
+		if sessionState := commitView.sessionStore.State(); sessionState.refName != "" {
+			commitView.sessionState = sessionState
+		} else {
+			commitView.defaultFilter = commitView.config.GetString(CfDefaultFilter)
+		}
+
+		commitView.sessionStore.RegisterSessionStateProvider(commitView)
+	}
+
 	return
 }
 
@@ -109,9 +162,14 @@ func (commitView *CommitView) Render(win RenderWindow) (err error) {
 
 	viewPos := refViewData.viewPos
 	rows := win.Rows() - 2
-	viewPos.DetermineViewStartRow(rows, commitNum)
-
 	commitDisplayNum := rows
+
+	if commitSetState.loading && commitDisplayNum > 1 {
+		commitDisplayNum--
+	}
+
+	viewPos.DetermineViewStartRow(commitDisplayNum, commitNum, uint(commitView.config.GetInt(CfScrollOff)))
+
 	startCommitIndex := viewPos.ViewStartRowIndex()
 
 	commitCh, err := commitView.repoData.Commits(commitView.activeRef, startCommitIndex, commitDisplayNum)
@@ -122,21 +180,35 @@ func (commitView *CommitView) Render(win RenderWindow) (err error) {
 	tableFormatter := refViewData.tableFormatter
 	tableFormatter.Resize(rows)
 	tableFormatter.Clear()
+	tableFormatter.SetColWidthLimit(cvAuthorColumn, uint(commitView.config.GetInt(CfCommitAuthorWidth)))
+	tableFormatter.SetColWidthLimit(cvSummaryColumn, uint(commitView.config.GetInt(CfCommitSummaryWidth)))
 
+	dateFormatter := NewDateFormatter(commitView.config)
+	authorDisplay := commitView.authorDisplay()
 	rowIndex := uint(0)
+	var visibleCommits []*Commit
 
 	for commit := range commitCh {
-		if err = commitView.renderCommit(tableFormatter, rowIndex, commit); err != nil {
+		if err = commitView.renderCommit(tableFormatter, dateFormatter, authorDisplay, rowIndex, commit); err != nil {
 			return
 		}
 
+		visibleCommits = append(visibleCommits, commit)
 		rowIndex++
 	}
 
+	commitView.repoData.LoadCommitMetadata(visibleCommits)
+
 	if err = tableFormatter.Render(win, viewPos.ViewStartColumn(), true); err != nil {
 		return
 	}
 
+	if commitSetState.loading && rowIndex < rows {
+		if err = commitView.renderLoadingRow(win, rowIndex+1, viewPos.ViewStartColumn(), commitNum); err != nil {
+			return
+		}
+	}
+
 	if commitSetState.commitNum > 0 {
 		if err = win.SetSelectedRow(viewPos.SelectedRowIndex()+1, commitView.active); err != nil {
 			return
@@ -145,7 +217,12 @@ func (commitView *CommitView) Render(win RenderWindow) (err error) {
 
 	win.DrawBorder()
 
-	if err = win.SetTitle(CmpCommitviewTitle, "Commits for %v", commitView.activeRef.Shorthand()); err != nil {
+	title := fmt.Sprintf("Commits for %v", commitView.activeRef.Shorthand())
+	if filterNames := commitView.appliedFilterNames(); len(filterNames) > 0 {
+		title = fmt.Sprintf("%v [%v]", title, strings.Join(filterNames, ", "))
+	}
+
+	if err = win.SetTitle(CmpCommitviewTitle, "%v", title); err != nil {
 		return
 	}
 
@@ -194,7 +271,20 @@ func (commitView *CommitView) renderEmptyView(win RenderWindow) (err error) {
 	return
 }
 
-func (commitView *CommitView) renderCommit(tableFormatter *TableFormatter, rowIndex uint, commit *Commit) (err error) {
+// renderLoadingRow displays the number of commits loaded so far below the last loaded commit.
+// The spinner advances each time the loading refresh task updates the display
+func (commitView *CommitView) renderLoadingRow(win RenderWindow, rowIndex, startColumn, commitNum uint) (err error) {
+	frame := (time.Now().UnixNano() / int64(time.Millisecond*cvLoadRefreshMs)) % int64(len(cvLoadingSpinner))
+
+	return win.SetRow(rowIndex, startColumn, CmpNone, "   %c Loading... %v commits", cvLoadingSpinner[frame], FormatNumber(commitNum))
+}
+
+func (commitView *CommitView) authorDisplay() AuthorDisplay {
+	authorDisplay, _ := ParseAuthorDisplay(commitView.config.GetString(CfAuthorDisplay))
+	return authorDisplay
+}
+
+func (commitView *CommitView) renderCommit(tableFormatter *TableFormatter, dateFormatter *DateFormatter, authorDisplay AuthorDisplay, rowIndex uint, commit *Commit) (err error) {
 	author := commit.commit.Author()
 	commitRefs := commitView.repoData.RefsForCommit(commit)
 	colIndex := uint(0)
@@ -204,15 +294,30 @@ func (commitView *CommitView) renderCommit(tableFormatter *TableFormatter, rowIn
 	}
 
 	colIndex++
-	if err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpCommitviewDate, "%v", author.When.Format(cvDateFormat)); err != nil {
+	if err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpCommitviewDate, "%v", dateFormatter.Format(author.When)); err != nil {
 		return
 	}
 
 	colIndex++
-	if err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpCommitviewAuthor, "%v", author.Name); err != nil {
+	if err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpCommitviewAuthor, "%v", FormatAuthor(author.Name, author.Email, authorDisplay)); err != nil {
 		return
 	}
 
+	colIndex++
+	if commitMetadata, loaded := commitView.repoData.CommitMetadata(commit); loaded {
+		if signatureIndicator := commitMetadata.SignatureIndicator(); signatureIndicator != "" {
+			if err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewSignature, "%v ", signatureIndicator); err != nil {
+				return
+			}
+		}
+
+		if commitMetadata.stats.filesChanged > 0 {
+			if err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewStats, "%v", commitMetadata.stats); err != nil {
+				return
+			}
+		}
+	}
+
 	colIndex++
 	if len(commitRefs.tags) > 0 {
 		for _, tag := range commitRefs.tags {
@@ -244,7 +349,23 @@ func (commitView *CommitView) renderCommit(tableFormatter *TableFormatter, rowIn
 		}
 	}
 
-	if err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewSummary, "%v", commit.commit.Summary()); err != nil {
+	if rebaseCommand, assigned := commitView.rebaseTodo.Command(commit.oid.String()); assigned {
+		if err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewRebaseCommand, "%v ", rebaseCommand); err != nil {
+			return
+		}
+	}
+
+	summary := commit.commit.Summary()
+
+	if conventionalCommit, ok := parseConventionalCommit(summary); ok {
+		if err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, conventionalCommit.ThemeComponentID(), "%v", conventionalCommit.prefix); err != nil {
+			return
+		}
+
+		summary = strings.TrimPrefix(summary, conventionalCommit.prefix)
+	}
+
+	if err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewSummary, "%v", summary); err != nil {
 		return
 	}
 
@@ -256,11 +377,43 @@ func (commitView *CommitView) RenderHelpBar(lineBuilder *LineBuilder) (err error
 	RenderKeyBindingHelp(commitView.ViewID(), lineBuilder, []ActionMessage{
 		{action: ActionFilterPrompt, message: "Add Filter"},
 		{action: ActionRemoveFilter, message: "Remove Filter"},
+		{action: ActionPickaxePrompt, message: "Pickaxe"},
+		{action: ActionSelectCommitPrompt, message: "Go To Commit"},
+		{action: ActionSetMarkPrompt, message: "Set Mark"},
+		{action: ActionJumpToMarkPrompt, message: "Go To Mark"},
 	})
 
 	return
 }
 
+// RenderStatusBar adds the number of applied filters and the position of the selected commit to the status bar
+func (commitView *CommitView) RenderStatusBar(statusBarSegments *StatusBarSegments) (err error) {
+	commitView.lock.Lock()
+	defer commitView.lock.Unlock()
+
+	statusBarSegments.Add(SegmentMode, "Commits")
+
+	if commitView.activeRef == nil {
+		return
+	}
+
+	commitSetState := commitView.repoData.CommitSetState(commitView.activeRef)
+
+	if commitSetState.filterState != nil {
+		statusBarSegments.Add(SegmentFilter, "%v", filterSegmentText(commitSetState.filterState.filtersApplied))
+	}
+
+	if refViewData, ok := commitView.refViewData[commitView.activeRef.Name()]; ok {
+		statusBarSegments.AddPosition(refViewData.viewPos.ActiveRowIndex(), commitSetState.commitNum)
+	}
+
+	if bisectStatus := commitView.repoData.BisectStatus(); bisectStatus != nil {
+		statusBarSegments.Add(SegmentBisect, "%v", bisectStatus.SegmentText())
+	}
+
+	return
+}
+
 func newLoadingCommitsRefreshTask(refreshRate time.Duration, channels *Channels) *loadingCommitsRefreshTask {
 	return &loadingCommitsRefreshTask{
 		refreshRate: refreshRate,
@@ -276,6 +429,8 @@ func (refreshTask *loadingCommitsRefreshTask) start() {
 	refreshTask.cancelCh = cancelCh
 
 	go func(cancelCh <-chan bool) {
+		defer RecoverPanic()
+
 		for {
 			select {
 			case <-refreshTask.ticker.C:
@@ -324,6 +479,7 @@ func (commitView *CommitView) OnRefSelect(ref Ref) (err error) {
 		refViewData = &referenceViewData{
 			viewPos:        NewViewPosition(),
 			tableFormatter: NewTableFormatter(cvColumnNum),
+			jumpList:       NewJumpList(),
 		}
 
 		commitView.refViewData[ref.Name()] = refViewData
@@ -353,9 +509,87 @@ func (commitView *CommitView) OnRefSelect(ref Ref) (err error) {
 
 	commitView.notifyCommitViewListeners(commit)
 
+	if commitView.sessionState != nil && isRefNamed(ref, commitView.sessionState.refName) {
+		commitView.restoreSessionState(refViewData)
+	} else if commitView.defaultFilter != "" {
+		query := commitView.defaultFilter
+		commitView.defaultFilter = ""
+
+		if err := commitView.applyFilterQuery(refViewData, query); err != nil {
+			commitView.channels.ReportError(fmt.Errorf("Unable to apply default filter: %v", err))
+		}
+	}
+
+	return
+}
+
+// SetSessionStore sets the store the state of this view is restored from and saved to
+func (commitView *CommitView) SetSessionStore(sessionStore *SessionStore) {
+	commitView.sessionStore = sessionStore
+}
+
+// restoreSessionState reapplies the filters and selects the commit saved in the previous session
+func (commitView *CommitView) restoreSessionState(refViewData *referenceViewData) {
+	sessionState := commitView.sessionState
+	commitView.sessionState = nil
+
+	log.Debugf("Restoring session state for ref %v", sessionState.refName)
+
+	for _, query := range sessionState.commitFilters {
+		if err := commitView.applyFilterQuery(refViewData, query); err != nil {
+			log.Warnf("Unable to restore commit filter %v: %v", query, err)
+		}
+	}
+
+	if sessionState.commitID == "" {
+		return
+	}
+
+	if commitView.repoData.CommitSetState(commitView.activeRef).loading {
+		commitView.pendingCommitID = sessionState.commitID
+	} else if err := commitView.selectCommitWithID(sessionState.commitID); err != nil {
+		log.Warnf("Unable to restore selected commit: %v", err)
+	}
+}
+
+func (commitView *CommitView) applyFilterQuery(refViewData *referenceViewData, query string) (err error) {
+	commitFilter, errors := commitView.createCommitFilter(query)
+	if len(errors) > 0 {
+		return errors[0]
+	}
+
+	if err = commitView.repoData.AddCommitFilter(commitView.activeRef, commitFilter); err != nil {
+		return
+	}
+
+	refViewData.filterQueries = append(refViewData.filterQueries, query)
+	refViewData.filterNames = append(refViewData.filterNames, "")
+
 	return
 }
 
+// SaveSessionState records the selected ref, commit and the filters applied to the selected ref
+func (commitView *CommitView) SaveSessionState(sessionState *SessionState) {
+	commitView.lock.Lock()
+	defer commitView.lock.Unlock()
+
+	if commitView.activeRef == nil {
+		return
+	}
+
+	refViewData, ok := commitView.refViewData[commitView.activeRef.Name()]
+	if !ok {
+		return
+	}
+
+	sessionState.refName = commitView.activeRef.Name()
+	sessionState.commitFilters = append(sessionState.commitFilters, refViewData.filterQueries...)
+
+	if commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, refViewData.viewPos.ActiveRowIndex()); err == nil {
+		sessionState.commitID = commit.oid.String()
+	}
+}
+
 // OnCommitsLoaded stops the refresh task if it's still running
 func (commitView *CommitView) OnCommitsLoaded(ref Ref) {
 	commitView.lock.Lock()
@@ -368,9 +602,19 @@ func (commitView *CommitView) OnCommitsLoaded(ref Ref) {
 
 	commitSetState := commitView.repoData.CommitSetState(ref)
 	commitView.channels.ReportStatus("Loaded %v commits for ref %v", commitSetState.commitNum, ref.Shorthand())
+
+	if commitView.pendingCommitID != "" && commitView.activeRef.Name() == ref.Name() {
+		commitID := commitView.pendingCommitID
+		commitView.pendingCommitID = ""
+
+		if err := commitView.selectCommitWithID(commitID); err != nil {
+			commitView.channels.ReportError(err)
+		}
+	}
 }
 
-// OnCommitsUpdated adjusts the active row index to take account of the newly loaded commits
+// OnCommitsUpdated adjusts the active row index to take account of the newly loaded commits.
+// The previously selected commit remains selected if it is still reachable from the ref
 func (commitView *CommitView) OnCommitsUpdated(ref Ref) {
 	commitView.lock.Lock()
 	defer commitView.lock.Unlock()
@@ -383,7 +627,11 @@ func (commitView *CommitView) OnCommitsUpdated(ref Ref) {
 		}
 
 		viewPos := commitView.ViewPos()
-		if viewPos.ActiveRowIndex() > commitSetState.commitNum {
+		selectedCommitID := commitView.refViewData[ref.Name()].selectedCommitID
+
+		if commitIndex, found, _ := commitView.findCommitIndex(selectedCommitID); selectedCommitID != "" && found {
+			viewPos.SetActiveRowIndex(commitIndex)
+		} else if viewPos.ActiveRowIndex() > commitSetState.commitNum {
 			viewPos.SetActiveRowIndex(uint(MaxInt(0, int(commitSetState.commitNum)-1)))
 		}
 
@@ -409,6 +657,49 @@ func (commitView *CommitView) ViewID() ViewID {
 	return ViewCommit
 }
 
+// Loading returns true if commits are still being loaded for the active ref
+func (commitView *CommitView) Loading() bool {
+	commitView.lock.Lock()
+	defer commitView.lock.Unlock()
+
+	if commitView.activeRef == nil {
+		return false
+	}
+
+	return commitView.repoData.CommitSetState(commitView.activeRef).loading
+}
+
+// ExternalCommandContext returns the selected commit and the branch commits are displayed for
+func (commitView *CommitView) ExternalCommandContext() map[string]string {
+	commitView.lock.Lock()
+	defer commitView.lock.Unlock()
+
+	context := map[string]string{}
+
+	if commitView.activeRef == nil {
+		return context
+	}
+
+	if _, isBranch := commitView.activeRef.(Branch); isBranch {
+		context[ecBranch] = commitView.activeRef.Shorthand()
+	}
+
+	commitSetState := commitView.repoData.CommitSetState(commitView.activeRef)
+	if commitSetState.commitNum == 0 {
+		return context
+	}
+
+	commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitView.ViewPos().ActiveRowIndex())
+	if err != nil {
+		log.Errorf("Unable to determine selected commit: %v", err)
+		return context
+	}
+
+	context[ecCommit] = commit.oid.String()
+
+	return context
+}
+
 // RegisterCommitViewListener accepts a listener to be notified when a commit is selected
 func (commitView *CommitView) RegisterCommitViewListener(commitViewListener CommitViewListener) {
 	if commitViewListener == nil {
@@ -427,6 +718,8 @@ func (commitView *CommitView) notifyCommitViewListeners(commit *Commit) {
 	log.Debugf("Notifying commit listeners of selected commit %v", commit.commit.Id().String())
 
 	go func() {
+		defer RecoverPanic()
+
 		for _, commitViewListener := range commitView.commitViewListeners {
 			if err := commitViewListener.OnCommitSelected(commit); err != nil {
 				commitView.channels.ReportError(err)
@@ -453,6 +746,7 @@ func (commitView *CommitView) selectCommit(lineIndex uint) (err error) {
 	}
 
 	commitView.ViewPos().SetActiveRowIndex(lineIndex)
+	commitView.refViewData[commitView.activeRef.Name()].selectedCommitID = selectedCommit.oid.String()
 	commitView.notifyCommitViewListeners(selectedCommit)
 
 	return
@@ -506,9 +800,28 @@ func (commitView *CommitView) OnSearchMatch(startPos ViewPos, matchLineIndex uin
 		return
 	}
 
+	commitView.recordJump()
 	commitView.selectCommit(matchLineIndex)
 }
 
+func (commitView *CommitView) recordJump() {
+	refViewData := commitView.refViewData[commitView.activeRef.Name()]
+	refViewData.jumpList.Record(commitView.jumpPosition(refViewData))
+}
+
+// jumpPosition identifies the selected commit by its id so that jumping
+// returns to the same commit if the rows of the view have changed
+func (commitView *CommitView) jumpPosition(refViewData *referenceViewData) JumpPosition {
+	rowIndex := refViewData.viewPos.ActiveRowIndex()
+	position := JumpPosition{RowIndex: rowIndex}
+
+	if commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, rowIndex); err == nil {
+		position.ID = commit.oid.String()
+	}
+
+	return position
+}
+
 // Line returns the rendered line at the index provided
 func (commitView *CommitView) Line(lineIndex uint) (line string) {
 	commitView.lock.Lock()
@@ -528,6 +841,10 @@ func (commitView *CommitView) Line(lineIndex uint) (line string) {
 	}
 
 	tableFormatter := refViewData.tableFormatter
+	if tableFormatter.Rows() == 0 {
+		tableFormatter.Resize(1)
+	}
+
 	tableFormatter.Clear()
 
 	commitIndex := lineIndex
@@ -537,7 +854,7 @@ func (commitView *CommitView) Line(lineIndex uint) (line string) {
 		return
 	}
 
-	if err = commitView.renderCommit(tableFormatter, 0, commit); err != nil {
+	if err = commitView.renderCommit(tableFormatter, NewDateFormatter(commitView.config), commitView.authorDisplay(), 0, commit); err != nil {
 		log.Errorf("Error when rendering commit: %v", err)
 		return
 	}
@@ -619,7 +936,7 @@ func (commitView *CommitView) HandleAction(action Action) (err error) {
 func moveUpCommit(commitView *CommitView, action Action) (err error) {
 	viewPos := commitView.ViewPos()
 
-	if viewPos.MoveLineUp() {
+	if viewPos.MoveLinesUp(action.MoveCount()) {
 		log.Debug("Moving up one commit")
 		if err = commitView.selectCommit(viewPos.ActiveRowIndex()); err != nil {
 			return
@@ -634,7 +951,7 @@ func moveDownCommit(commitView *CommitView, action Action) (err error) {
 	lineNumber := commitView.lineNumber()
 	viewPos := commitView.ViewPos()
 
-	if viewPos.MoveLineDown(lineNumber) {
+	if viewPos.MoveLinesDown(action.MoveCount(), lineNumber) {
 		log.Debug("Moving down one commit")
 		if err = commitView.selectCommit(viewPos.ActiveRowIndex()); err != nil {
 			return
@@ -648,7 +965,7 @@ func moveDownCommit(commitView *CommitView, action Action) (err error) {
 func moveUpCommitPage(commitView *CommitView, action Action) (err error) {
 	viewPos := commitView.ViewPos()
 
-	if viewPos.MovePageUp(commitView.viewDimension.rows - 2) {
+	if viewPos.MovePagesUp(action.MoveCount(), pageRows(commitView.viewDimension)) {
 		log.Debug("Moving up one page")
 		if err = commitView.selectCommit(viewPos.ActiveRowIndex()); err != nil {
 			return
@@ -663,7 +980,7 @@ func moveDownCommitPage(commitView *CommitView, action Action) (err error) {
 	lineNumber := commitView.lineNumber()
 	viewPos := commitView.ViewPos()
 
-	if viewPos.MovePageDown(commitView.viewDimension.rows-2, lineNumber) {
+	if viewPos.MovePagesDown(action.MoveCount(), pageRows(commitView.viewDimension), lineNumber) {
 		log.Debug("Moving down one page")
 		if err = commitView.selectCommit(viewPos.ActiveRowIndex()); err != nil {
 			return
@@ -677,7 +994,7 @@ func moveDownCommitPage(commitView *CommitView, action Action) (err error) {
 func moveUpCommitHalfPage(commitView *CommitView, action Action) (err error) {
 	viewPos := commitView.ViewPos()
 
-	if viewPos.MovePageUp(commitView.viewDimension.rows/2 - 2) {
+	if viewPos.MoveHalfPagesUp(action.MoveCount(), pageRows(commitView.viewDimension)) {
 		log.Debug("Moving up one half page")
 		if err = commitView.selectCommit(viewPos.ActiveRowIndex()); err != nil {
 			return
@@ -692,7 +1009,7 @@ func moveDownCommitHalfPage(commitView *CommitView, action Action) (err error) {
 	lineNumber := commitView.lineNumber()
 	viewPos := commitView.ViewPos()
 
-	if viewPos.MovePageDown(commitView.viewDimension.rows/2-2, lineNumber) {
+	if viewPos.MoveHalfPagesDown(action.MoveCount(), pageRows(commitView.viewDimension), lineNumber) {
 		log.Debug("Moving down one half page")
 		if err = commitView.selectCommit(viewPos.ActiveRowIndex()); err != nil {
 			return
@@ -725,6 +1042,7 @@ func scrollCommitViewLeft(commitView *CommitView, action Action) (err error) {
 
 func moveToFirstCommit(commitView *CommitView, action Action) (err error) {
 	viewPos := commitView.ViewPos()
+	commitView.recordJump()
 
 	if viewPos.MoveToFirstLine() {
 		log.Debug("Moving up to first commit")
@@ -740,6 +1058,7 @@ func moveToFirstCommit(commitView *CommitView, action Action) (err error) {
 func moveToLastCommit(commitView *CommitView, action Action) (err error) {
 	lineNumber := commitView.lineNumber()
 	viewPos := commitView.ViewPos()
+	commitView.recordJump()
 
 	if viewPos.MoveToLastLine(lineNumber) {
 		log.Debug("Moving to last commit")
@@ -762,7 +1081,7 @@ func addCommitFilter(commitView *CommitView, action Action) (err error) {
 		return fmt.Errorf("Expected filter query argument to have type string")
 	}
 
-	commitFilter, errors := CreateCommitFilter(query)
+	commitFilter, errors := commitView.createCommitFilter(query)
 	if len(errors) > 0 {
 		commitView.channels.ReportErrors(errors)
 		return
@@ -772,9 +1091,21 @@ func addCommitFilter(commitView *CommitView, action Action) (err error) {
 		return
 	}
 
-	commitView.ViewPos().SetActiveRowIndex(0)
+	refViewData := commitView.refViewData[commitView.activeRef.Name()]
+	filterName := ""
+	if len(action.Args) > 1 {
+		if filterName, ok = action.Args[1].(string); !ok {
+			log.Errorf("Expected filter name argument to have type string but found %T", action.Args[1])
+		}
+	}
+
+	refViewData.filterQueries = append(refViewData.filterQueries, query)
+	refViewData.filterNames = append(refViewData.filterNames, filterName)
+	refViewData.viewPos.SetActiveRowIndex(0)
 
 	go func() {
+		defer RecoverPanic()
+
 		// TODO: Works in practice, but there is no guarantee the filtered commit set will have
 		// been populated after 250ms. Need an event based mechanism to be notified when a filtered
 		// set has started to be populated so that the first commit can be selected
@@ -792,11 +1123,61 @@ func addCommitFilter(commitView *CommitView, action Action) (err error) {
 	return
 }
 
+// createCommitFilter creates a filter from either a filter query or a pickaxe query.
+// Pickaxe filters generate the diff of each commit and so are applied more slowly
+func (commitView *CommitView) createCommitFilter(query string) (commitFilter *CommitFilter, errors []error) {
+	pickaxe, isPickaxeQuery, err := ParsePickaxeQuery(query)
+	if err != nil {
+		return nil, []error{err}
+	} else if !isPickaxeQuery {
+		return CreateCommitFilter(query)
+	}
+
+	repoData := commitView.repoData
+
+	commitFilter = NewCommitFilter(func(inputValue interface{}) bool {
+		commit := inputValue.(*Commit)
+
+		matches, err := repoData.PickaxeMatches(commit, pickaxe)
+		if err != nil {
+			log.Errorf("Unable to apply pickaxe to commit %v: %v", commit.oid, err)
+		}
+
+		return matches
+	})
+
+	commitView.channels.ReportStatus("Searching commit diffs using %v", pickaxe.Query())
+
+	return
+}
+
+// appliedFilterNames returns the names of the saved filters applied to the active ref
+func (commitView *CommitView) appliedFilterNames() (filterNames []string) {
+	refViewData, ok := commitView.refViewData[commitView.activeRef.Name()]
+	if !ok {
+		return
+	}
+
+	for _, filterName := range refViewData.filterNames {
+		if filterName != "" {
+			filterNames = append(filterNames, filterName)
+		}
+	}
+
+	return
+}
+
 func removeCommitFilter(commitView *CommitView, action Action) (err error) {
 	if err = commitView.repoData.RemoveCommitFilter(commitView.activeRef); err != nil {
 		return
 	}
 
+	refViewData := commitView.refViewData[commitView.activeRef.Name()]
+	if filterNum := len(refViewData.filterQueries); filterNum > 0 {
+		refViewData.filterQueries = refViewData.filterQueries[:filterNum-1]
+		refViewData.filterNames = refViewData.filterNames[:filterNum-1]
+	}
+
 	if err = commitView.selectCommit(0); err != nil {
 		return
 	}
@@ -809,7 +1190,7 @@ func removeCommitFilter(commitView *CommitView, action Action) (err error) {
 func centerCommitView(commitView *CommitView, action Action) (err error) {
 	viewPos := commitView.ViewPos()
 
-	if viewPos.CenterActiveRow(commitView.viewDimension.rows - 2) {
+	if viewPos.CenterActiveRow(pageRows(commitView.viewDimension)) {
 		log.Debug("Centering CommitView")
 		commitView.channels.UpdateDisplay()
 	}
@@ -817,6 +1198,28 @@ func centerCommitView(commitView *CommitView, action Action) (err error) {
 	return
 }
 
+func scrollCommitViewCursorTop(commitView *CommitView, action Action) (err error) {
+	viewPos := commitView.ViewPos()
+
+	if viewPos.ScrollActiveRowTop() {
+		log.Debug("Scrolling CommitView so selected row is at the top")
+		commitView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func scrollCommitViewCursorBottom(commitView *CommitView, action Action) (err error) {
+	viewPos := commitView.ViewPos()
+
+	if viewPos.ScrollActiveRowBottom(pageRows(commitView.viewDimension)) {
+		log.Debug("Scrolling CommitView so selected row is at the bottom")
+		commitView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
 func selectCommit(commitView *CommitView, action Action) (err error) {
 	viewPos := commitView.ViewPos()
 
@@ -831,3 +1234,573 @@ func selectCommit(commitView *CommitView, action Action) (err error) {
 
 	return commitView.selectCommit(viewPos.ActiveRowIndex())
 }
+
+func selectCommitByID(commitView *CommitView, action Action) (err error) {
+	if !(len(action.Args) > 0) {
+		return fmt.Errorf("Expected commit id argument")
+	}
+
+	commitID, ok := action.Args[0].(string)
+	if !ok {
+		return fmt.Errorf("Expected commit id argument to have type string but has type: %T", action.Args[0])
+	}
+
+	commitID = strings.ToLower(strings.TrimSpace(commitID))
+	if !hexRegexp.MatchString(commitID) {
+		return fmt.Errorf("Invalid commit id: %v", commitID)
+	}
+
+	commitView.pendingCommitID = ""
+
+	if commitView.repoData.CommitSetState(commitView.activeRef).loading {
+		var found bool
+		if _, found, err = commitView.findCommitIndex(commitID); err != nil {
+			return
+		} else if !found {
+			log.Debugf("Commit %v not loaded yet. Will select once loading is complete", commitID)
+			commitView.pendingCommitID = commitID
+			commitView.channels.ReportStatus("Waiting for commit %v to load", commitID)
+			return
+		}
+	}
+
+	return commitView.selectCommitWithID(commitID)
+}
+
+func (commitView *CommitView) selectCommitWithID(commitID string) (err error) {
+	commitIndex, found, err := commitView.findCommitIndex(commitID)
+	if err != nil {
+		return
+	} else if !found {
+		return fmt.Errorf("Commit %v not found for ref %v", commitID, commitView.activeRef.Shorthand())
+	}
+
+	commitView.recordJump()
+
+	if err = commitView.selectCommit(commitIndex); err != nil {
+		return
+	}
+
+	commitView.ViewPos().CenterActiveRow(pageRows(commitView.viewDimension))
+	commitView.channels.UpdateDisplay()
+
+	return
+}
+
+// findCommitIndex returns the index of the loaded commit with an id starting with the provided commit id.
+// Only commits loaded for the active ref are searched, so history is not loaded to find a commit.
+// An error is returned if an abbreviated commit id matches more than one loaded commit
+func (commitView *CommitView) findCommitIndex(commitID string) (commitIndex uint, found bool, err error) {
+	commitNum := commitView.repoData.CommitSetState(commitView.activeRef).commitNum
+	var matchedOid string
+
+	for index := uint(0); index < commitNum; index++ {
+		commit, commitErr := commitView.repoData.CommitByIndex(commitView.activeRef, index)
+		if commitErr != nil {
+			break
+		}
+
+		oid := commit.oid.String()
+		if !strings.HasPrefix(oid, commitID) {
+			continue
+		}
+
+		if found {
+			err = fmt.Errorf("Commit id %v is ambiguous. It matches commits %v and %v", commitID, matchedOid, oid)
+			return 0, false, err
+		}
+
+		commitIndex, found, matchedOid = index, true, oid
+
+		// A full commit id cannot match another commit
+		if len(commitID) == len(oid) {
+			break
+		}
+	}
+
+	return
+}
+
+func selectParentCommit(commitView *CommitView, action Action) (err error) {
+	return commitView.selectParent(0, action.MoveCount())
+}
+
+func selectMergeParentCommit(commitView *CommitView, action Action) (err error) {
+	return commitView.selectParent(1, 1)
+}
+
+// selectParent selects the parent with the provided index of the selected commit.
+// When more than one generation is requested the first parent of each ancestor is followed
+func (commitView *CommitView) selectParent(parentIndex, generations uint) (err error) {
+	commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitView.ViewPos().ActiveRowIndex())
+	if err != nil {
+		return
+	}
+
+	parentCount := commit.commit.ParentCount()
+
+	if parentCount == 0 {
+		commitView.channels.ReportWarning("Commit %v has no parents", commit.oid.ShortID())
+		return
+	} else if parentIndex >= parentCount {
+		commitView.channels.ReportWarning("Commit %v is not a merge commit", commit.oid.ShortID())
+		return
+	}
+
+	parentID := commit.commit.ParentId(parentIndex).String()
+
+	for generation := uint(1); generation < generations; generation++ {
+		parentRowIndex, found, _ := commitView.findCommitIndex(parentID)
+		if !found {
+			break
+		}
+
+		if commit, err = commitView.repoData.CommitByIndex(commitView.activeRef, parentRowIndex); err != nil {
+			return
+		} else if commit.commit.ParentCount() == 0 {
+			break
+		}
+
+		parentID = commit.commit.ParentId(0).String()
+	}
+
+	if _, found, _ := commitView.findCommitIndex(parentID); !found {
+		return fmt.Errorf("Parent commit %v is not loaded for ref %v", parentID[:7], commitView.activeRef.Shorthand())
+	}
+
+	return commitView.selectCommitWithID(parentID)
+}
+
+func selectChildCommit(commitView *CommitView, action Action) (err error) {
+	activeRowIndex := commitView.ViewPos().ActiveRowIndex()
+	childRowIndex := activeRowIndex
+
+	for generation := action.MoveCount(); generation > 0; generation-- {
+		var rowIndex uint
+		var found bool
+
+		if rowIndex, found, err = commitView.findChildIndex(childRowIndex); err != nil {
+			return
+		} else if !found {
+			break
+		}
+
+		childRowIndex = rowIndex
+	}
+
+	if childRowIndex == activeRowIndex {
+		var commit *Commit
+		if commit, err = commitView.repoData.CommitByIndex(commitView.activeRef, activeRowIndex); err != nil {
+			return
+		}
+
+		commitView.channels.ReportStatus("No child of commit %v is loaded", commit.oid.ShortID())
+		return
+	}
+
+	child, err := commitView.repoData.CommitByIndex(commitView.activeRef, childRowIndex)
+	if err != nil {
+		return
+	}
+
+	return commitView.selectCommitWithID(child.oid.String())
+}
+
+// findChildIndex returns the index of the closest loaded child of the commit at the provided index
+func (commitView *CommitView) findChildIndex(commitIndex uint) (childIndex uint, found bool, err error) {
+	commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitIndex)
+	if err != nil {
+		return
+	}
+
+	for childIndex = commitIndex; childIndex > 0; childIndex-- {
+		var child *Commit
+		if child, err = commitView.repoData.CommitByIndex(commitView.activeRef, childIndex-1); err != nil {
+			return
+		}
+
+		for parentIndex := uint(0); parentIndex < child.commit.ParentCount(); parentIndex++ {
+			if child.commit.ParentId(parentIndex).Equal(commit.oid.oid) {
+				return childIndex - 1, true, nil
+			}
+		}
+	}
+
+	return
+}
+
+func jumpBackCommit(commitView *CommitView, action Action) (err error) {
+	refViewData := commitView.refViewData[commitView.activeRef.Name()]
+
+	if position, exists := refViewData.jumpList.Back(commitView.jumpPosition(refViewData)); exists {
+		err = commitView.jumpToCommit(position)
+	}
+
+	return
+}
+
+func jumpForwardCommit(commitView *CommitView, action Action) (err error) {
+	refViewData := commitView.refViewData[commitView.activeRef.Name()]
+
+	if position, exists := refViewData.jumpList.Forward(); exists {
+		err = commitView.jumpToCommit(position)
+	}
+
+	return
+}
+
+func (commitView *CommitView) jumpToCommit(position JumpPosition) (err error) {
+	lineNumber := commitView.lineNumber()
+	if lineNumber == 0 {
+		return
+	}
+
+	rowIndex := MinUint(position.RowIndex, lineNumber-1)
+
+	if position.ID != "" {
+		commitIndex, found, _ := commitView.findCommitIndex(position.ID)
+		if !found {
+			commitView.channels.ReportStatus("Commit %v is no longer loaded", position.ID[0:7])
+			return
+		}
+
+		rowIndex = commitIndex
+	}
+
+	log.Debugf("Jumping to commit at index %v", rowIndex)
+
+	if err = commitView.selectCommit(rowIndex); err != nil {
+		return
+	}
+
+	commitView.ViewPos().CenterActiveRow(pageRows(commitView.viewDimension))
+	commitView.channels.UpdateDisplay()
+
+	return
+}
+
+func setCommitMark(commitView *CommitView, action Action) (err error) {
+	markName, err := markNameArg(action)
+	if err != nil {
+		return
+	}
+
+	commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitView.ViewPos().ActiveRowIndex())
+	if err != nil {
+		return
+	}
+
+	if err = commitView.repoData.Marks().SetMark(markName, commit.oid.String()); err != nil {
+		return
+	}
+
+	commitView.channels.ReportSuccess("Set mark %v on commit %v", markName, commit.oid.ShortID())
+
+	return
+}
+
+func yankCommitID(commitView *CommitView, action Action) (err error) {
+	return commitView.yankCommit("id", func(commit *Commit) string {
+		return commit.oid.String()
+	})
+}
+
+func yankCommitSummary(commitView *CommitView, action Action) (err error) {
+	return commitView.yankCommit("summary", func(commit *Commit) string {
+		return commit.commit.Summary()
+	})
+}
+
+func yankCommitMessage(commitView *CommitView, action Action) (err error) {
+	return commitView.yankCommit("message", func(commit *Commit) string {
+		return commit.commit.Message()
+	})
+}
+
+func yankCommitReference(commitView *CommitView, action Action) (err error) {
+	return commitView.yankCommit("reference", func(commit *Commit) string {
+		return formatCommitReference(commit.oid.ShortID(), commit.commit.Summary())
+	})
+}
+
+// formatCommitReference formats a reference to a commit suitable for including in other commit messages
+func formatCommitReference(shortID, summary string) string {
+	return fmt.Sprintf("%v (\"%v\")", shortID, summary)
+}
+
+// yankCommit copies the text generated for the selected commit to the clipboard
+func (commitView *CommitView) yankCommit(description string, commitText func(*Commit) string) (err error) {
+	commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitView.ViewPos().ActiveRowIndex())
+	if err != nil {
+		return
+	}
+
+	if err = CopyToClipboard(commitText(commit), commitView.config); err != nil {
+		return
+	}
+
+	commitView.channels.ReportSuccess("Copied commit %v %v to the clipboard", commit.oid.ShortID(), description)
+
+	return
+}
+
+func openCommitPullRequest(commitView *CommitView, action Action) (err error) {
+	commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitView.ViewPos().ActiveRowIndex())
+	if err != nil {
+		return
+	}
+
+	if pullRequestLookup, _ := ParsePullRequestLookup(commitView.config.GetString(CfPullRequests)); pullRequestLookup == PrlOff {
+		return fmt.Errorf("Pull request lookup is disabled. Set %v to message or api", CfPullRequests)
+	}
+
+	go func() {
+		defer RecoverPanic()
+
+		pullRequest, err := commitView.repoData.PullRequest(commit)
+
+		switch {
+		case err != nil:
+			commitView.channels.ReportError(err)
+		case pullRequest == nil:
+			commitView.channels.ReportError(fmt.Errorf("No pull request found for commit %v", commit.oid.ShortID()))
+		case pullRequest.url == "":
+			commitView.channels.ReportError(fmt.Errorf("Unable to determine the URL of pull request #%v as origin is not a GitHub repository", pullRequest.number))
+		default:
+			if err = OpenInBrowser(pullRequest.url); err != nil {
+				commitView.channels.ReportError(err)
+			} else {
+				commitView.channels.ReportSuccess("Opened pull request #%v", pullRequest.number)
+			}
+		}
+	}()
+
+	return
+}
+
+func openCommitIssue(commitView *CommitView, action Action) (err error) {
+	commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitView.ViewPos().ActiveRowIndex())
+	if err != nil {
+		return
+	}
+
+	references := commitView.config.IssueReferences(commit.commit.Message())
+	if len(references) == 0 {
+		return fmt.Errorf("No issue reference found in the message of commit %v", commit.oid.ShortID())
+	}
+
+	return openIssueReference(references[0], commitView.channels)
+}
+
+func jumpToCommitMark(commitView *CommitView, action Action) (err error) {
+	markName, err := markNameArg(action)
+	if err != nil {
+		return
+	}
+
+	oid, exists := commitView.repoData.Marks().Mark(markName)
+	if !exists {
+		return fmt.Errorf("No mark set with name %v", markName)
+	}
+
+	log.Debugf("Jumping to mark %v on commit %v", markName, oid)
+
+	return selectCommitByID(commitView, Action{
+		ActionType: ActionSelectCommit,
+		Args:       []interface{}{oid},
+	})
+}
+
+func markNameArg(action Action) (markName string, err error) {
+	if !(len(action.Args) > 0) {
+		err = fmt.Errorf("Expected mark name argument")
+		return
+	}
+
+	markName, ok := action.Args[0].(string)
+	if !ok {
+		err = fmt.Errorf("Expected mark name argument to have type string but has type: %T", action.Args[0])
+	}
+
+	return
+}
+
+func startBisect(commitView *CommitView, action Action) (err error) {
+	if commitView.repoData.BisectStatus() != nil {
+		return fmt.Errorf("A bisect is already in progress")
+	}
+
+	if err = commitView.repoData.StartBisect(); err != nil {
+		return
+	}
+
+	commitView.channels.ReportStatus("Started bisect. Mark a good and a bad commit to begin")
+
+	return
+}
+
+func markBisectCommitGood(commitView *CommitView, action Action) (err error) {
+	return commitView.markBisectCommit(BtGood)
+}
+
+func markBisectCommitBad(commitView *CommitView, action Action) (err error) {
+	return commitView.markBisectCommit(BtBad)
+}
+
+func markBisectCommitSkipped(commitView *CommitView, action Action) (err error) {
+	return commitView.markBisectCommit(BtSkip)
+}
+
+// markBisectCommit marks the selected commit and selects the commit git bisect checks out to be tested next.
+// Once the first bad commit has been found it is selected instead
+func (commitView *CommitView) markBisectCommit(bisectTerm BisectTerm) (err error) {
+	if commitView.repoData.BisectStatus() == nil {
+		return fmt.Errorf("No bisect in progress")
+	}
+
+	commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitView.ViewPos().ActiveRowIndex())
+	if err != nil {
+		return
+	}
+
+	bisectStatus, err := commitView.repoData.MarkBisectCommit(commit, bisectTerm)
+	if err != nil {
+		return
+	}
+
+	if selectedOid := bisectStatus.SelectedOid(); selectedOid != "" {
+		if _, found, _ := commitView.findCommitIndex(selectedOid); found {
+			if err = commitView.selectCommitWithID(selectedOid); err != nil {
+				return
+			}
+		}
+	}
+
+	commitView.channels.ReportStatus("%v", bisectStatus)
+	commitView.channels.UpdateDisplay()
+
+	return
+}
+
+func resetBisect(commitView *CommitView, action Action) (err error) {
+	if commitView.repoData.BisectStatus() == nil {
+		return fmt.Errorf("No bisect in progress")
+	}
+
+	if len(action.Args) == 0 {
+		commitView.channels.Confirm("End bisect and checkout the original branch", Action{
+			ActionType: ActionBisectReset,
+			Args:       []interface{}{true},
+		})
+
+		return
+	}
+
+	if err = commitView.repoData.ResetBisect(); err != nil {
+		return
+	}
+
+	commitView.channels.ReportStatus("Ended bisect")
+	commitView.channels.UpdateDisplay()
+
+	return
+}
+
+func assignRebasePick(commitView *CommitView, action Action) (err error) {
+	return commitView.assignRebaseCommand(RcPick)
+}
+
+func assignRebaseSquash(commitView *CommitView, action Action) (err error) {
+	return commitView.assignRebaseCommand(RcSquash)
+}
+
+func assignRebaseFixup(commitView *CommitView, action Action) (err error) {
+	return commitView.assignRebaseCommand(RcFixup)
+}
+
+func assignRebaseDrop(commitView *CommitView, action Action) (err error) {
+	return commitView.assignRebaseCommand(RcDrop)
+}
+
+func (commitView *CommitView) assignRebaseCommand(rebaseCommand RebaseCommand) (err error) {
+	commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitView.ViewPos().ActiveRowIndex())
+	if err != nil {
+		return
+	}
+
+	commitView.rebaseTodo.Set(commit.oid.String(), rebaseCommand)
+	commitView.channels.ReportStatus("Commit %v will be %v", commit.oid.ShortID(), rebaseCommandDescriptions[rebaseCommand])
+	commitView.channels.UpdateDisplay()
+
+	return
+}
+
+var rebaseCommandDescriptions = map[RebaseCommand]string{
+	RcPick:   "picked",
+	RcSquash: "squashed",
+	RcFixup:  "fixed up",
+	RcDrop:   "dropped",
+}
+
+func clearRebaseTodo(commitView *CommitView, action Action) (err error) {
+	commitView.rebaseTodo.Clear()
+	commitView.channels.ReportStatus("Cleared rebase commands")
+	commitView.channels.UpdateDisplay()
+
+	return
+}
+
+// startInteractiveRebase generates the todo list from the rebase commands assigned to commits
+// and requests the interactive rebase is run. Rebase commands can only be assigned to commits of HEAD
+func startInteractiveRebase(commitView *CommitView, action Action) (err error) {
+	if repositoryState := commitView.repoData.RepositoryState(); repositoryState != "" {
+		return fmt.Errorf("Unable to start a rebase while a %v is in progress", repositoryState)
+	}
+
+	head := commitView.repoData.Head()
+	if head == nil || commitView.activeRef == nil || !head.Oid().Equal(commitView.activeRef.Oid()) {
+		return fmt.Errorf("An interactive rebase can only be started from the commits of HEAD")
+	}
+
+	commitNum := commitView.repoData.CommitSetState(commitView.activeRef).commitNum
+
+	base, todo, err := commitView.rebaseTodo.Generate(func(index uint) (rebaseTodoCommit RebaseTodoCommit, exists bool) {
+		if index >= commitNum {
+			return
+		}
+
+		commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, index)
+		if err != nil {
+			return
+		}
+
+		rebaseTodoCommit = RebaseTodoCommit{
+			oid:         commit.oid.String(),
+			summary:     commit.commit.Summary(),
+			parentCount: commit.commit.ParentCount(),
+		}
+
+		if rebaseTodoCommit.parentCount > 0 {
+			rebaseTodoCommit.parentOid = commit.commit.ParentId(0).String()
+		}
+
+		return rebaseTodoCommit, true
+	})
+	if err != nil {
+		return
+	}
+
+	commitView.rebaseTodo.Clear()
+
+	commitView.channels.DoAction(Action{
+		ActionType: ActionRunInteractiveRebase,
+		Args: []interface{}{
+			ActionRunInteractiveRebaseArgs{
+				base: base,
+				todo: todo,
+			},
+		},
+	})
+
+	return
+}
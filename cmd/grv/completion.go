@@ -0,0 +1,246 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Completer provides completion candidates for a command argument.
+// args contains the arguments preceding the argument being completed
+// and prefix is the text of the argument entered so far
+type Completer interface {
+	Complete(args []string, prefix string) []string
+}
+
+// CompleterFunc allows a function to be used as a Completer
+type CompleterFunc func(args []string, prefix string) []string
+
+// Complete calls the underlying function
+func (completerFunc CompleterFunc) Complete(args []string, prefix string) []string {
+	return completerFunc(args, prefix)
+}
+
+// CommandCompletion completes command prompt input.
+// Commands register a completer to provide completion for their arguments
+type CommandCompletion struct {
+	repoData           RepoData
+	commandCompleters  map[string]Completer
+	viewArgsCompleters map[string]Completer
+	lock               sync.Mutex
+}
+
+// NewCommandCompletion creates a new instance
+func NewCommandCompletion(repoData RepoData) *CommandCompletion {
+	commandCompletion := &CommandCompletion{
+		repoData:           repoData,
+		commandCompleters:  make(map[string]Completer),
+		viewArgsCompleters: make(map[string]Completer),
+	}
+
+	commandCompletion.RegisterViewArgsCompleter(cfCommitView, CompleterFunc(commandCompletion.completeRefs))
+	commandCompletion.RegisterViewArgsCompleter(cfDiffView, CompleterFunc(commandCompletion.completeRefs))
+
+	return commandCompletion
+}
+
+// RegisterCommandCompleter registers a completer for the arguments of the provided command.
+// Commands without arguments can register a nil completer so their name is completed
+func (commandCompletion *CommandCompletion) RegisterCommandCompleter(command string, completer Completer) {
+	commandCompletion.lock.Lock()
+	defer commandCompletion.lock.Unlock()
+
+	commandCompletion.commandCompleters[command] = completer
+}
+
+// RegisterViewArgsCompleter registers a completer for the arguments of a view
+// when it is created using a command such as addview or split
+func (commandCompletion *CommandCompletion) RegisterViewArgsCompleter(view string, completer Completer) {
+	commandCompletion.lock.Lock()
+	defer commandCompletion.lock.Unlock()
+
+	commandCompletion.viewArgsCompleters[view] = completer
+}
+
+// Complete returns the sorted candidates for the last word of the provided input
+func (commandCompletion *CommandCompletion) Complete(input string) (candidates []string) {
+	words := strings.Fields(input)
+	prefix := ""
+
+	if len(words) > 0 && !strings.HasSuffix(input, " ") && !strings.HasSuffix(input, "\t") {
+		prefix = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+
+	if len(words) == 0 {
+		candidates = commandCompletion.commands()
+	} else if completer := commandCompletion.commandCompleter(words[0]); completer != nil {
+		candidates = completer.Complete(words[1:], prefix)
+	}
+
+	return filterCompletionCandidates(candidates, prefix)
+}
+
+// CompleteView completes a view name followed by the arguments of that view
+func (commandCompletion *CommandCompletion) CompleteView(args []string, prefix string) []string {
+	if len(args) == 0 {
+		return viewNames()
+	}
+
+	commandCompletion.lock.Lock()
+	completer := commandCompletion.viewArgsCompleters[args[0]]
+	commandCompletion.lock.Unlock()
+
+	if completer == nil {
+		return nil
+	}
+
+	return completer.Complete(args[1:], prefix)
+}
+
+func (commandCompletion *CommandCompletion) commands() (commands []string) {
+	commandCompletion.lock.Lock()
+	defer commandCompletion.lock.Unlock()
+
+	for command := range commandCompletion.commandCompleters {
+		commands = append(commands, command)
+	}
+
+	return
+}
+
+func (commandCompletion *CommandCompletion) commandCompleter(command string) Completer {
+	commandCompletion.lock.Lock()
+	defer commandCompletion.lock.Unlock()
+
+	return commandCompletion.commandCompleters[command]
+}
+
+func (commandCompletion *CommandCompletion) completeRefs(args []string, prefix string) (candidates []string) {
+	if len(args) > 0 {
+		return
+	}
+
+	localBranches, remoteBranches, _ := commandCompletion.repoData.Branches()
+
+	for _, branches := range [][]Branch{localBranches, remoteBranches} {
+		for _, branch := range branches {
+			candidates = append(candidates, branch.Shorthand())
+		}
+	}
+
+	tags, _ := commandCompletion.repoData.Tags()
+
+	for _, tag := range tags {
+		candidates = append(candidates, tag.Shorthand())
+	}
+
+	return
+}
+
+// CompleteRemoteBranches returns the names of the remote branches which start with prefix
+func (commandCompletion *CommandCompletion) CompleteRemoteBranches(prefix string) []string {
+	_, remoteBranches, _ := commandCompletion.repoData.Branches()
+	var candidates []string
+
+	for _, remoteBranch := range remoteBranches {
+		candidates = append(candidates, remoteBranch.Shorthand())
+	}
+
+	return filterCompletionCandidates(candidates, prefix)
+}
+
+// CompleteFilePath returns the paths of the files and directories which start with prefix.
+// Directories are suffixed with a path separator
+func CompleteFilePath(prefix string) (candidates []string) {
+	dir, filePrefix := filepath.Split(prefix)
+
+	searchDir := dir
+	if searchDir == "" {
+		searchDir = "."
+	} else if strings.HasPrefix(searchDir, "~/") {
+		if home, ok := os.LookupEnv("HOME"); ok {
+			searchDir = filepath.Join(home, searchDir[2:])
+		}
+	}
+
+	files, err := ioutil.ReadDir(searchDir)
+	if err != nil {
+		return
+	}
+
+	for _, file := range files {
+		name := file.Name()
+
+		if !strings.HasPrefix(name, filePrefix) || (strings.HasPrefix(name, ".") && !strings.HasPrefix(filePrefix, ".")) {
+			continue
+		}
+
+		candidate := dir + name
+		if file.IsDir() {
+			candidate += string(filepath.Separator)
+		}
+
+		candidates = append(candidates, candidate)
+	}
+
+	return
+}
+
+// StaticCompleter completes each argument position using the candidates provided for that position
+func StaticCompleter(argCandidates ...[]string) Completer {
+	return CompleterFunc(func(args []string, prefix string) []string {
+		if len(args) < len(argCandidates) {
+			return argCandidates[len(args)]
+		}
+
+		return nil
+	})
+}
+
+// OptionCompleter completes option names and the values of the most recently entered option
+func OptionCompleter(optionValueCompleters map[string]Completer) Completer {
+	return CompleterFunc(func(args []string, prefix string) (candidates []string) {
+		if len(args) > 0 {
+			if completer, isOption := optionValueCompleters[args[len(args)-1]]; isOption {
+				if completer != nil {
+					candidates = completer.Complete(nil, prefix)
+				}
+
+				return
+			}
+		}
+
+		for option := range optionValueCompleters {
+			candidates = append(candidates, option)
+		}
+
+		return
+	})
+}
+
+func viewNames() (names []string) {
+	for viewName := range viewIDNames {
+		names = append(names, viewName)
+	}
+
+	return
+}
+
+func filterCompletionCandidates(candidates []string, prefix string) (filteredCandidates []string) {
+	seen := make(map[string]bool)
+
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, prefix) && !seen[candidate] {
+			seen[candidate] = true
+			filteredCandidates = append(filteredCandidates, candidate)
+		}
+	}
+
+	sort.Strings(filteredCandidates)
+
+	return
+}
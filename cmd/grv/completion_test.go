@@ -0,0 +1,72 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCommandNamesAreCompleted(t *testing.T) {
+	commandCompletion := &CommandCompletion{
+		commandCompleters: map[string]Completer{
+			"addtab":  nil,
+			"addview": StaticCompleter([]string{"CommitView"}),
+			"q":       nil,
+		},
+	}
+
+	expected := []string{"addtab", "addview"}
+	if candidates := commandCompletion.Complete("add"); !reflect.DeepEqual(candidates, expected) {
+		t.Errorf("Candidates do not match expected value. Expected: %v, Actual: %v", expected, candidates)
+	}
+}
+
+func TestCommandArgumentsAreCompletedUsingRegisteredCompleter(t *testing.T) {
+	commandCompletion := &CommandCompletion{
+		commandCompleters: map[string]Completer{
+			"map": StaticCompleter([]string{"RefView", "CommitView", "DiffView"}, []string{"<Enter>"}),
+		},
+	}
+
+	completionTests := []struct {
+		input    string
+		expected []string
+	}{
+		{input: "map ", expected: []string{"CommitView", "DiffView", "RefView"}},
+		{input: "map  D", expected: []string{"DiffView"}},
+		{input: "map RefView ", expected: []string{"<Enter>"}},
+		{input: "map RefView <Enter> ", expected: nil},
+		{input: "unknown ", expected: nil},
+	}
+
+	for _, completionTest := range completionTests {
+		if candidates := commandCompletion.Complete(completionTest.input); !reflect.DeepEqual(candidates, completionTest.expected) {
+			t.Errorf("Candidates do not match expected value for input \"%v\". Expected: %v, Actual: %v",
+				completionTest.input, completionTest.expected, candidates)
+		}
+	}
+}
+
+func TestOptionValuesAreCompletedForPrecedingOption(t *testing.T) {
+	completer := OptionCompleter(map[string]Completer{
+		"--name":    StaticCompleter([]string{"solarized", "cold"}),
+		"--bgcolor": nil,
+	})
+
+	completionTests := []struct {
+		args     []string
+		expected []string
+	}{
+		{args: nil, expected: []string{"--bgcolor", "--name"}},
+		{args: []string{"--name"}, expected: []string{"cold", "solarized"}},
+		{args: []string{"--bgcolor"}, expected: nil},
+		{args: []string{"--name", "cold"}, expected: []string{"--bgcolor", "--name"}},
+	}
+
+	for _, completionTest := range completionTests {
+		candidates := filterCompletionCandidates(completer.Complete(completionTest.args, ""), "")
+		if !reflect.DeepEqual(candidates, completionTest.expected) {
+			t.Errorf("Candidates do not match expected value for args %v. Expected: %v, Actual: %v",
+				completionTest.args, completionTest.expected, candidates)
+		}
+	}
+}
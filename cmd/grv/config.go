@@ -4,36 +4,82 @@ import (
 	"encoding/hex"
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 )
 
 const (
-	cfDefaultConfigHomeDir = "/.config"
-	cfGrvConfigDir         = "/grv"
-	cfGrvrcFile            = "/grvrc"
-	cfTabWidthMinValue     = 1
-	cfTabWidthDefaultValue = 8
-	cfClassicThemeName     = "classic"
-	cfColdThemeName        = "cold"
-	cfSolarizedThemeName   = "solarized"
-
-	cfAllView       = "All"
-	cfMainView      = "MainView"
-	cfHistoryView   = "HistoryView"
-	cfStatusView    = "StatusView"
-	cfGRVStatusView = "GRVStatusView"
-	cfRefView       = "RefView"
-	cfCommitView    = "CommitView"
-	cfDiffView      = "DiffView"
-	cfStatusBarView = "StatusBarView"
-	cfHelpBarView   = "HelpBarView"
-	cfErrorView     = "ErrorView"
-	cfGitStatusView = "GitStatusView"
+	cfDefaultConfigHomeDir          = "/.config"
+	cfGrvConfigDir                  = "/grv"
+	cfGrvrcFile                     = "/grvrc"
+	cfRepoConfigDir                 = "grv"
+	cfRepoConfigFile                = "config"
+	cfTabWidthMinValue              = 1
+	cfTabWidthDefaultValue          = 8
+	cfScrollOffDefaultValue         = 0
+	cfCommitLimitDefaultValue       = 0
+	cfLayoutDefaultValue            = ""
+	cfDefaultBranchDefaultValue     = ""
+	cfDefaultFilterDefaultValue     = ""
+	cfAutoRefreshDefaultValue       = true
+	cfPollIntervalDefaultValue      = 0
+	cfSearchCaseDefaultValue        = "sensitive"
+	cfHistorySizeDefaultValue       = 1000
+	cfCommitAuthorWidthDefaultValue = 20
+	cfColumnWidthDefaultValue       = 0
+	cfDateFormatDefaultValue        = "short"
+	cfTimeZoneDefaultValue          = "author"
+	cfAuthorDisplayDefaultValue     = "name"
+	cfAbbrevDefaultValue            = 0
+	cfDiffWhitespaceDefaultValue    = "none"
+	cfBinaryPreviewDefaultValue     = ""
+	cfRenameThresholdDefaultValue   = 50
+	cfDiffAlgorithmDefaultValue     = "myers"
+	cfOSC52DefaultValue             = true
+	cfOSC52MaxSizeDefaultValue      = 74994
+	cfTmuxClipboardDefaultValue     = false
+	cfTmuxCommandsDefaultValue      = "suspend"
+	cfPullRequestsDefaultValue      = "off"
+	cfTagFilterDefaultValue         = ""
+	cfTagSortDefaultValue           = "name"
+	cfDiscardBackupDefaultValue     = true
+	cfNoConfirmDefaultValue         = ""
+	cfStatusTimeoutDefaultValue     = 5
+	cfKeyTimeoutDefaultValue        = 1000
+	cfKeyHintsDefaultValue          = true
+	cfClassicThemeName              = "classic"
+	cfColdThemeName                 = "cold"
+	cfSolarizedThemeName            = "solarized"
+
+	cfAllView           = "All"
+	cfMainView          = "MainView"
+	cfHistoryView       = "HistoryView"
+	cfStatusView        = "StatusView"
+	cfGRVStatusView     = "GRVStatusView"
+	cfRefView           = "RefView"
+	cfCommitView        = "CommitView"
+	cfDiffView          = "DiffView"
+	cfStatusBarView     = "StatusBarView"
+	cfHelpBarView       = "HelpBarView"
+	cfErrorView         = "ErrorView"
+	cfGitStatusView     = "GitStatusView"
+	cfPluginView        = "PluginView"
+	cfCommandOutputView = "CommandOutputView"
+	cfMarkView          = "MarkView"
+	cfDebugView         = "DebugView"
+	cfStashView         = "StashView"
+	cfConflictView      = "ConflictView"
+	cfBlameView         = "BlameView"
+	cfChangelogView     = "ChangelogView"
+	cfLogView           = "LogView"
+	cfCommandView       = "CommandView"
+	cfKeyHintView       = "KeyHintView"
 )
 
 // ConfigVariable stores a config variable name
@@ -44,8 +90,96 @@ const (
 	CfTabWidth ConfigVariable = "tabwidth"
 	// CfTheme stores the theme variable name
 	CfTheme ConfigVariable = "theme"
+	// CfScrollOff stores the scroll off variable name
+	CfScrollOff ConfigVariable = "scrolloff"
+	// CfCommitLimit stores the commit limit variable name
+	CfCommitLimit ConfigVariable = "commitlimit"
+	// CfLayout stores the layout variable name
+	CfLayout ConfigVariable = "layout"
+	// CfDefaultBranch stores the default branch variable name
+	CfDefaultBranch ConfigVariable = "defaultbranch"
+	// CfDefaultFilter stores the default filter variable name
+	CfDefaultFilter ConfigVariable = "defaultfilter"
+	// CfAutoRefresh stores the auto refresh variable name
+	CfAutoRefresh ConfigVariable = "autorefresh"
+	// CfPollInterval stores the poll interval variable name
+	CfPollInterval ConfigVariable = "pollinterval"
+	// CfSearchCase stores the search case variable name
+	CfSearchCase ConfigVariable = "searchcase"
+	// CfHistorySize stores the history size variable name
+	CfHistorySize ConfigVariable = "historysize"
+	// CfCommitAuthorWidth stores the commit author column width variable name
+	CfCommitAuthorWidth ConfigVariable = "commitauthorwidth"
+	// CfCommitSummaryWidth stores the commit summary column width variable name
+	CfCommitSummaryWidth ConfigVariable = "commitsummarywidth"
+	// CfMarkSummaryWidth stores the mark summary column width variable name
+	CfMarkSummaryWidth ConfigVariable = "marksummarywidth"
+	// CfStashMessageWidth stores the stash message column width variable name
+	CfStashMessageWidth ConfigVariable = "stashmessagewidth"
+	// CfDateFormat stores the date format variable name
+	CfDateFormat ConfigVariable = "dateformat"
+	// CfTimeZone stores the time zone variable name
+	CfTimeZone ConfigVariable = "timezone"
+	// CfAuthorDisplay stores the author display variable name
+	CfAuthorDisplay ConfigVariable = "authordisplay"
+	// CfAbbrev stores the abbreviated commit id length variable name
+	CfAbbrev ConfigVariable = "abbrev"
+	// CfDiffWhitespace stores the diff whitespace variable name
+	CfDiffWhitespace ConfigVariable = "diffwhitespace"
+	// CfBinaryPreview stores the binary file preview command variable name
+	CfBinaryPreview ConfigVariable = "binarypreview"
+	// CfRenameThreshold stores the rename and copy detection similarity threshold variable name
+	CfRenameThreshold ConfigVariable = "renamethreshold"
+	// CfDiffAlgorithm stores the diff algorithm variable name
+	CfDiffAlgorithm ConfigVariable = "diffalgorithm"
+	// CfOSC52 stores the OSC 52 clipboard fallback variable name
+	CfOSC52 ConfigVariable = "osc52"
+	// CfOSC52MaxSize stores the maximum size of text copied using OSC 52 variable name
+	CfOSC52MaxSize ConfigVariable = "osc52maxsize"
+	// CfTmuxClipboard stores the tmux paste buffer clipboard variable name
+	CfTmuxClipboard ConfigVariable = "tmuxclipboard"
+	// CfTmuxCommands stores the tmux external command mode variable name
+	CfTmuxCommands ConfigVariable = "tmuxcommands"
+	// CfPullRequests stores the GitHub pull request lookup variable name
+	CfPullRequests ConfigVariable = "pullrequests"
+	// CfTagFilter stores the Ref View tag filter variable name
+	CfTagFilter ConfigVariable = "tagfilter"
+	// CfTagSort stores the Ref View tag sort order variable name
+	CfTagSort ConfigVariable = "tagsort"
+	// CfDiscardBackup stores the backup stash before discarding changes variable name
+	CfDiscardBackup ConfigVariable = "discardbackup"
+	// CfNoConfirm stores the actions performed without confirmation variable name
+	CfNoConfirm ConfigVariable = "noconfirm"
+	// CfStatusTimeout stores the status message timeout variable name
+	CfStatusTimeout ConfigVariable = "statustimeout"
+	// CfLogLevel stores the log level variable name
+	CfLogLevel ConfigVariable = "loglevel"
+	// CfLogFile stores the log file variable name
+	CfLogFile ConfigVariable = "logfile"
+	// CfKeyTimeout stores the key sequence timeout variable name
+	CfKeyTimeout ConfigVariable = "keytimeout"
+	// CfKeyHints stores the key hints variable name
+	CfKeyHints ConfigVariable = "keyhints"
 )
 
+// repositoryConfigVariables are the variables which can be set in a repository config file.
+// Variables which run commands, write files or skip confirmation are excluded
+var repositoryConfigVariables = map[ConfigVariable]bool{
+	CfDefaultBranch:      true,
+	CfDefaultFilter:      true,
+	CfLayout:             true,
+	CfTabWidth:           true,
+	CfCommitLimit:        true,
+	CfCommitAuthorWidth:  true,
+	CfCommitSummaryWidth: true,
+	CfAbbrev:             true,
+	CfDiffWhitespace:     true,
+	CfDiffAlgorithm:      true,
+	CfRenameThreshold:    true,
+	CfTagFilter:          true,
+	CfTagSort:            true,
+}
+
 var systemColorValues = map[string]SystemColorValue{
 	"None":    ColorNone,
 	"Black":   ColorBlack,
@@ -59,18 +193,29 @@ var systemColorValues = map[string]SystemColorValue{
 }
 
 var viewIDNames = map[string]ViewID{
-	cfAllView:       ViewAll,
-	cfMainView:      ViewMain,
-	cfHistoryView:   ViewHistory,
-	cfStatusView:    ViewStatus,
-	cfGRVStatusView: ViewGRVStatus,
-	cfRefView:       ViewRef,
-	cfCommitView:    ViewCommit,
-	cfDiffView:      ViewDiff,
-	cfStatusBarView: ViewStatusBar,
-	cfHelpBarView:   ViewHelpBar,
-	cfErrorView:     ViewError,
-	cfGitStatusView: ViewGitStatus,
+	cfAllView:           ViewAll,
+	cfMainView:          ViewMain,
+	cfHistoryView:       ViewHistory,
+	cfStatusView:        ViewStatus,
+	cfGRVStatusView:     ViewGRVStatus,
+	cfRefView:           ViewRef,
+	cfCommitView:        ViewCommit,
+	cfDiffView:          ViewDiff,
+	cfStatusBarView:     ViewStatusBar,
+	cfHelpBarView:       ViewHelpBar,
+	cfErrorView:         ViewError,
+	cfGitStatusView:     ViewGitStatus,
+	cfPluginView:        ViewPlugin,
+	cfCommandOutputView: ViewCommandOutput,
+	cfMarkView:          ViewMark,
+	cfDebugView:         ViewDebug,
+	cfStashView:         ViewStash,
+	cfConflictView:      ViewConflict,
+	cfBlameView:         ViewBlame,
+	cfChangelogView:     ViewChangelog,
+	cfLogView:           ViewLog,
+	cfCommandView:       ViewCommand,
+	cfKeyHintView:       ViewKeyHint,
 }
 
 var themeComponents = map[string]ThemeComponentID{
@@ -92,15 +237,21 @@ var themeComponents = map[string]ThemeComponentID{
 	cfRefView + ".TagsHeader":           CmpRefviewTagsHeader,
 	cfRefView + ".Tag":                  CmpRefviewTag,
 
-	cfCommitView + ".Title":        CmpCommitviewTitle,
-	cfCommitView + ".Footer":       CmpCommitviewFooter,
-	cfCommitView + ".ShortOid":     CmpCommitviewShortOid,
-	cfCommitView + ".Date":         CmpCommitviewDate,
-	cfCommitView + ".Author":       CmpCommitviewAuthor,
-	cfCommitView + ".Summary":      CmpCommitviewSummary,
-	cfCommitView + ".Tag":          CmpCommitviewTag,
-	cfCommitView + ".LocalBranch":  CmpCommitviewLocalBranch,
-	cfCommitView + ".RemoteBranch": CmpCommitviewRemoteBranch,
+	cfCommitView + ".Title":          CmpCommitviewTitle,
+	cfCommitView + ".Footer":         CmpCommitviewFooter,
+	cfCommitView + ".ShortOid":       CmpCommitviewShortOid,
+	cfCommitView + ".Date":           CmpCommitviewDate,
+	cfCommitView + ".Author":         CmpCommitviewAuthor,
+	cfCommitView + ".Stats":          CmpCommitviewStats,
+	cfCommitView + ".Signature":      CmpCommitviewSignature,
+	cfCommitView + ".Summary":        CmpCommitviewSummary,
+	cfCommitView + ".CommitType":     CmpCommitviewCommitType,
+	cfCommitView + ".FeatCommitType": CmpCommitviewFeatCommitType,
+	cfCommitView + ".FixCommitType":  CmpCommitviewFixCommitType,
+	cfCommitView + ".RebaseCommand":  CmpCommitviewRebaseCommand,
+	cfCommitView + ".Tag":            CmpCommitviewTag,
+	cfCommitView + ".LocalBranch":    CmpCommitviewLocalBranch,
+	cfCommitView + ".RemoteBranch":   CmpCommitviewRemoteBranch,
 
 	cfDiffView + ".Title":                 CmpDiffviewTitle,
 	cfDiffView + ".Footer":                CmpDiffviewFooter,
@@ -110,6 +261,7 @@ var themeComponents = map[string]ThemeComponentID{
 	cfDiffView + ".CommitCommitter":       CmpDiffviewDifflineDiffCommitCommitter,
 	cfDiffView + ".CommitCommitterDate":   CmpDiffviewDifflineDiffCommitCommitterDate,
 	cfDiffView + ".CommitMessage":         CmpDiffviewDifflineDiffCommitMessage,
+	cfDiffView + ".CommitTrailer":         CmpDiffviewDifflineDiffCommitTrailer,
 	cfDiffView + ".StatsFile":             CmpDiffviewDifflineDiffStatsFile,
 	cfDiffView + ".GitDiffHeader":         CmpDiffviewDifflineGitDiffHeader,
 	cfDiffView + ".GitDiffExtendedHeader": CmpDiffviewDifflineGitDiffExtendedHeader,
@@ -118,6 +270,7 @@ var themeComponents = map[string]ThemeComponentID{
 	cfDiffView + ".HunkHeader":            CmpDiffviewDifflineHunkHeader,
 	cfDiffView + ".AddedLine":             CmpDiffviewDifflineLineAdded,
 	cfDiffView + ".RemovedLine":           CmpDiffviewDifflineLineRemoved,
+	cfDiffView + ".LineNumber":            CmpDiffviewDifflineLineNumber,
 
 	cfGitStatusView + ".StagedTitle":     CmpGitStatusStagedTitle,
 	cfGitStatusView + ".UnstagedTitle":   CmpGitStatusUnstagedTitle,
@@ -128,7 +281,15 @@ var themeComponents = map[string]ThemeComponentID{
 	cfGitStatusView + ".UntrackedFile":   CmpGitStatusUntrackedFile,
 	cfGitStatusView + ".ConflictedFile":  CmpGitStatusConflictedFile,
 
-	cfStatusBarView + ".Normal": CmpStatusbarviewNormal,
+	cfStatusBarView + ".Normal":   CmpStatusbarviewNormal,
+	cfStatusBarView + ".Mode":     CmpStatusbarviewMode,
+	cfStatusBarView + ".Filter":   CmpStatusbarviewFilter,
+	cfStatusBarView + ".Position": CmpStatusbarviewPosition,
+	cfStatusBarView + ".Dirty":    CmpStatusbarviewDirty,
+	cfStatusBarView + ".Bisect":   CmpStatusbarviewBisect,
+	cfStatusBarView + ".Success":  CmpStatusbarviewSuccess,
+	cfStatusBarView + ".Warning":  CmpStatusbarviewWarning,
+	cfStatusBarView + ".Error":    CmpStatusbarviewError,
 
 	cfHelpBarView + ".Special": CmpHelpbarviewSpecial,
 	cfHelpBarView + ".Normal":  CmpHelpbarviewNormal,
@@ -136,6 +297,47 @@ var themeComponents = map[string]ThemeComponentID{
 	cfErrorView + ".Title":  CmpErrorViewTitle,
 	cfErrorView + ".Footer": CmpErrorViewFooter,
 	cfErrorView + ".Errors": CmpErrorViewErrors,
+
+	cfKeyHintView + ".Title":  CmpKeyHintViewTitle,
+	cfKeyHintView + ".Footer": CmpKeyHintViewFooter,
+	cfKeyHintView + ".Keys":   CmpKeyHintViewKeys,
+
+	cfPluginView + ".Title":  CmpPluginviewTitle,
+	cfPluginView + ".Footer": CmpPluginviewFooter,
+
+	cfCommandOutputView + ".Title":       CmpCommandOutputViewTitle,
+	cfCommandOutputView + ".Footer":      CmpCommandOutputViewFooter,
+	cfCommandOutputView + ".ChangedLine": CmpCommandOutputViewChangedLine,
+
+	cfMarkView + ".Title":    CmpMarkviewTitle,
+	cfMarkView + ".Footer":   CmpMarkviewFooter,
+	cfMarkView + ".Name":     CmpMarkviewName,
+	cfMarkView + ".ShortOid": CmpMarkviewShortOid,
+	cfMarkView + ".Summary":  CmpMarkviewSummary,
+
+	cfStashView + ".Title":   CmpStashviewTitle,
+	cfStashView + ".Footer":  CmpStashviewFooter,
+	cfStashView + ".Name":    CmpStashviewName,
+	cfStashView + ".Message": CmpStashviewMessage,
+
+	cfConflictView + ".Title":  CmpConflictviewTitle,
+	cfConflictView + ".Footer": CmpConflictviewFooter,
+	cfConflictView + ".File":   CmpConflictviewFile,
+	cfConflictView + ".Marker": CmpConflictviewMarker,
+	cfConflictView + ".Ours":   CmpConflictviewOurs,
+	cfConflictView + ".Base":   CmpConflictviewBase,
+	cfConflictView + ".Theirs": CmpConflictviewTheirs,
+
+	cfBlameView + ".Title":      CmpBlameviewTitle,
+	cfBlameView + ".Footer":     CmpBlameviewFooter,
+	cfBlameView + ".ShortOid":   CmpBlameviewShortOid,
+	cfBlameView + ".Date":       CmpBlameviewDate,
+	cfBlameView + ".Author":     CmpBlameviewAuthor,
+	cfBlameView + ".LineNumber": CmpBlameviewLineNumber,
+
+	cfChangelogView + ".Title":   CmpChangelogviewTitle,
+	cfChangelogView + ".Footer":  CmpChangelogviewFooter,
+	cfChangelogView + ".Heading": CmpChangelogviewHeading,
 }
 
 var colorNumberPattern = regexp.MustCompile(`[0-9]{1,3}`)
@@ -151,6 +353,7 @@ type Config interface {
 	GetTheme() Theme
 	AddOnChangeListener(ConfigVariable, ConfigVariableOnChangeListener)
 	ConfigDir() string
+	IssueReferences(text string) []*IssueReference
 }
 
 // ConfigSetter extends the config interface and exposes the ability to set config values
@@ -183,12 +386,23 @@ type Configuration struct {
 	keyBindings  KeyBindings
 	grvConfigDir string
 	channels     *Channels
+	plugins      *PluginManager
+	commands     *ExternalCommandManager
+	completion   *CommandCompletion
+	namedFilters *NamedFilters
+	issues       *IssuePatterns
 }
 
 // NewConfiguration creates a Configuration instance with default values
-func NewConfiguration(keyBindings KeyBindings, channels *Channels) *Configuration {
+func NewConfiguration(keyBindings KeyBindings, channels *Channels, plugins *PluginManager, commands *ExternalCommandManager,
+	completion *CommandCompletion) *Configuration {
 	config := &Configuration{
-		keyBindings: keyBindings,
+		keyBindings:  keyBindings,
+		plugins:      plugins,
+		commands:     commands,
+		completion:   completion,
+		namedFilters: NewNamedFilters(keyBindings),
+		issues:       NewIssuePatterns(),
 		themes: map[string]MutableTheme{
 			cfClassicThemeName:   NewClassicTheme(),
 			cfColdThemeName:      NewColdTheme(),
@@ -208,11 +422,328 @@ func NewConfiguration(keyBindings KeyBindings, channels *Channels) *Configuratio
 				config: config,
 			},
 		},
+		CfScrollOff: {
+			value:     cfScrollOffDefaultValue,
+			validator: scrollOffValidator{},
+		},
+		CfCommitLimit: {
+			value:     cfCommitLimitDefaultValue,
+			validator: commitLimitValidator{},
+		},
+		CfLayout: {
+			value:     cfLayoutDefaultValue,
+			validator: layoutValidator{},
+		},
+		CfDefaultBranch: {
+			value: cfDefaultBranchDefaultValue,
+		},
+		CfDefaultFilter: {
+			value:     cfDefaultFilterDefaultValue,
+			validator: commitFilterValidator{},
+		},
+		CfAutoRefresh: {
+			value: cfAutoRefreshDefaultValue,
+			validator: booleanValidator{
+				variable: CfAutoRefresh,
+			},
+		},
+		CfPollInterval: {
+			value:     cfPollIntervalDefaultValue,
+			validator: pollIntervalValidator{},
+		},
+		CfSearchCase: {
+			value:     cfSearchCaseDefaultValue,
+			validator: searchCaseValidator{},
+		},
+		CfHistorySize: {
+			value:     cfHistorySizeDefaultValue,
+			validator: historySizeValidator{},
+		},
+		CfCommitAuthorWidth: {
+			value: cfCommitAuthorWidthDefaultValue,
+			validator: columnWidthValidator{
+				variable: CfCommitAuthorWidth,
+			},
+		},
+		CfCommitSummaryWidth: {
+			value: cfColumnWidthDefaultValue,
+			validator: columnWidthValidator{
+				variable: CfCommitSummaryWidth,
+			},
+		},
+		CfMarkSummaryWidth: {
+			value: cfColumnWidthDefaultValue,
+			validator: columnWidthValidator{
+				variable: CfMarkSummaryWidth,
+			},
+		},
+		CfStashMessageWidth: {
+			value: cfColumnWidthDefaultValue,
+			validator: columnWidthValidator{
+				variable: CfStashMessageWidth,
+			},
+		},
+		CfDateFormat: {
+			value:     cfDateFormatDefaultValue,
+			validator: dateFormatValidator{},
+		},
+		CfTimeZone: {
+			value:     cfTimeZoneDefaultValue,
+			validator: timeZoneValidator{},
+		},
+		CfAuthorDisplay: {
+			value:     cfAuthorDisplayDefaultValue,
+			validator: authorDisplayValidator{},
+		},
+		CfAbbrev: {
+			value:     cfAbbrevDefaultValue,
+			validator: abbrevValidator{},
+		},
+		CfDiffWhitespace: {
+			value:     cfDiffWhitespaceDefaultValue,
+			validator: diffWhitespaceValidator{},
+		},
+		CfBinaryPreview: {
+			value:     cfBinaryPreviewDefaultValue,
+			validator: binaryPreviewValidator{},
+		},
+		CfRenameThreshold: {
+			value:     cfRenameThresholdDefaultValue,
+			validator: renameThresholdValidator{},
+		},
+		CfDiffAlgorithm: {
+			value:     cfDiffAlgorithmDefaultValue,
+			validator: diffAlgorithmValidator{},
+		},
+		CfOSC52: {
+			value: cfOSC52DefaultValue,
+			validator: booleanValidator{
+				variable: CfOSC52,
+			},
+		},
+		CfOSC52MaxSize: {
+			value:     cfOSC52MaxSizeDefaultValue,
+			validator: osc52MaxSizeValidator{},
+		},
+		CfTmuxClipboard: {
+			value: cfTmuxClipboardDefaultValue,
+			validator: booleanValidator{
+				variable: CfTmuxClipboard,
+			},
+		},
+		CfTmuxCommands: {
+			value:     cfTmuxCommandsDefaultValue,
+			validator: tmuxCommandsValidator{},
+		},
+		CfPullRequests: {
+			value:     cfPullRequestsDefaultValue,
+			validator: pullRequestsValidator{},
+		},
+		CfTagFilter: {
+			value:     cfTagFilterDefaultValue,
+			validator: tagFilterValidator{},
+		},
+		CfTagSort: {
+			value:     cfTagSortDefaultValue,
+			validator: tagSortValidator{},
+		},
+		CfDiscardBackup: {
+			value: cfDiscardBackupDefaultValue,
+			validator: booleanValidator{
+				variable: CfDiscardBackup,
+			},
+		},
+		CfNoConfirm: {
+			value:     cfNoConfirmDefaultValue,
+			validator: noConfirmValidator{},
+		},
+		CfStatusTimeout: {
+			value:     cfStatusTimeoutDefaultValue,
+			validator: statusTimeoutValidator{},
+		},
+		CfLogLevel: {
+			value:     LogLevel(),
+			validator: logLevelValidator{},
+		},
+		CfLogFile: {
+			value:     LogFilePath(),
+			validator: logFileValidator{},
+		},
+		CfKeyTimeout: {
+			value:     cfKeyTimeoutDefaultValue,
+			validator: keyTimeoutValidator{},
+		},
+		CfKeyHints: {
+			value: cfKeyHintsDefaultValue,
+			validator: booleanValidator{
+				variable: CfKeyHints,
+			},
+		},
 	}
 
+	config.registerCompleters()
+
 	return config
 }
 
+func (config *Configuration) registerCompleters() {
+	viewCompleter := CompleterFunc(config.completion.CompleteView)
+	pluginCompleter := CompleterFunc(func(args []string, prefix string) []string {
+		if len(args) == 0 {
+			return CompleteFilePath(prefix)
+		}
+
+		return nil
+	})
+
+	completers := map[string]Completer{
+		setCommand:        CompleterFunc(config.completeSetCommand),
+		themeCommand:      CompleterFunc(config.completeThemeCommand),
+		mapCommand:        StaticCompleter(viewNames()),
+		quitCommand:       nil,
+		addtabCommand:     nil,
+		removetabCommand:  nil,
+		addviewCommand:    viewCompleter,
+		vsplitCommand:     viewCompleter,
+		hsplitCommand:     viewCompleter,
+		splitCommand:      viewCompleter,
+		pluginCommand:     pluginCompleter,
+		shellCommand:      CompleterFunc(completeShellCommand),
+		toggleviewCommand: StaticCompleter(viewNames()),
+		filterCommand:     CompleterFunc(config.completeFilterCommand),
+		issueCommand:      nil,
+		changelogCommand:  CompleterFunc(config.completeChangelogCommand),
+		cmdviewCommand:    nil,
+	}
+
+	for command, completer := range completers {
+		config.completion.RegisterCommandCompleter(command, completer)
+	}
+}
+
+func (config *Configuration) completeSetCommand(args []string, prefix string) (candidates []string) {
+	switch len(args) {
+	case 0:
+		for variable := range config.variables {
+			candidates = append(candidates, string(variable))
+		}
+	case 1:
+		switch ConfigVariable(args[0]) {
+		case CfTheme:
+			candidates = config.themeNames()
+		case CfSearchCase:
+			for searchCaseName := range searchCaseNames {
+				candidates = append(candidates, searchCaseName)
+			}
+		case CfDateFormat:
+			for dateFormatName := range dateFormatNames {
+				candidates = append(candidates, dateFormatName)
+			}
+		case CfTimeZone:
+			for dateTimeZoneName := range dateTimeZoneNames {
+				candidates = append(candidates, dateTimeZoneName)
+			}
+		case CfAuthorDisplay:
+			for authorDisplayName := range authorDisplayNames {
+				candidates = append(candidates, authorDisplayName)
+			}
+		case CfDiffWhitespace:
+			for diffWhitespaceName := range diffWhitespaceNames {
+				candidates = append(candidates, diffWhitespaceName)
+			}
+		case CfDiffAlgorithm:
+			for diffAlgorithmName := range diffAlgorithmNames {
+				candidates = append(candidates, diffAlgorithmName)
+			}
+		case CfPullRequests:
+			for pullRequestLookupName := range pullRequestLookupNames {
+				candidates = append(candidates, pullRequestLookupName)
+			}
+		case CfTmuxCommands:
+			for tmuxCommandModeName := range tmuxCommandModeNames {
+				candidates = append(candidates, tmuxCommandModeName)
+			}
+		case CfTagSort:
+			for tagSortName := range tagSortNames {
+				candidates = append(candidates, tagSortName)
+			}
+		case CfLogLevel:
+			candidates = append(candidates, strings.ToLower(MnLogLevelDefault))
+			for logLevelName := range logLevels {
+				candidates = append(candidates, strings.ToLower(logLevelName))
+			}
+		default:
+			if variable, ok := config.variables[ConfigVariable(args[0])]; ok {
+				if _, isBool := variable.value.(bool); isBool {
+					candidates = []string{"true", "false"}
+				}
+			}
+		}
+	}
+
+	return
+}
+
+func (config *Configuration) completeThemeCommand(args []string, prefix string) []string {
+	return OptionCompleter(map[string]Completer{
+		"--name": CompleterFunc(func([]string, string) []string {
+			return config.themeNames()
+		}),
+		"--component": CompleterFunc(func([]string, string) (components []string) {
+			for component := range themeComponents {
+				components = append(components, component)
+			}
+
+			return
+		}),
+		"--bgcolor": nil,
+		"--fgcolor": nil,
+	}).Complete(args, prefix)
+}
+
+func (config *Configuration) themeNames() (themeNames []string) {
+	for themeName := range config.themes {
+		themeNames = append(themeNames, themeName)
+	}
+
+	return
+}
+
+func (config *Configuration) completeFilterCommand(args []string, prefix string) []string {
+	switch {
+	case len(args) == 0:
+		return []string{filterSaveSubcommand, filterApplySubcommand}
+	case len(args) == 1 && args[0] == filterApplySubcommand:
+		return config.namedFilters.Names()
+	}
+
+	return nil
+}
+
+func (config *Configuration) completeChangelogCommand(args []string, prefix string) []string {
+	if len(args) > 0 {
+		if lastArg := args[len(args)-1]; lastArg == changelogTrailerOption || lastArg == changelogOutputOption {
+			return nil
+		}
+	}
+
+	return append([]string{changelogTrailerOption, changelogOutputOption}, config.completion.completeRefs(nil, prefix)...)
+}
+
+func completeShellCommand(args []string, prefix string) []string {
+	if len(args) == 0 {
+		return append(viewNames(), "--capture")
+	} else if args[0] == "--capture" {
+		args = args[1:]
+	}
+
+	if len(args) == 0 {
+		return viewNames()
+	}
+
+	return nil
+}
+
 // Initialise loads the grvrc config file (if it exists)
 func (config *Configuration) Initialise() []error {
 	configHomeDir, configHomeDirSet := os.LookupEnv("XDG_CONFIG_HOME")
@@ -257,6 +788,37 @@ func (config *Configuration) Initialise() []error {
 	return errors
 }
 
+// LoadRepositoryConfig loads the config file in the grv directory of the provided
+// git directory (if it exists). As this is loaded after the grvrc file
+// any settings it contains override the global settings for this repository only.
+// The file is stored in the git directory so that it is not shared by cloning the repository
+// and only set commands for variables in repositoryConfigVariables are processed
+func (config *Configuration) LoadRepositoryConfig(gitDir string) []error {
+	repoConfig := filepath.Join(gitDir, cfRepoConfigDir, cfRepoConfigFile)
+
+	file, err := os.Open(repoConfig)
+	if err != nil {
+		if os.IsNotExist(err) {
+			log.Infof("No repository config file found at: %v", repoConfig)
+			return nil
+		}
+
+		log.Errorf("Unable to open repository config file %v for reading: %v", repoConfig, err)
+		return []error{err}
+	}
+	defer file.Close()
+
+	log.Infof("Loading repository config file %v", repoConfig)
+
+	errors := config.processRepositoryCommands(NewConfigParser(file, repoConfig))
+
+	if len(errors) > 0 {
+		log.Infof("Encountered %v error(s) when loading repository config file", len(errors))
+	}
+
+	return errors
+}
+
 // ConfigDir returns the directory grv looks for config in
 func (config *Configuration) ConfigDir() string {
 	return config.grvConfigDir
@@ -311,6 +873,39 @@ OuterLoop:
 	return configErrors
 }
 
+func (config *Configuration) processRepositoryCommands(parser *ConfigParser) []error {
+	var configErrors []error
+
+	for {
+		command, eof, err := parser.Parse()
+
+		if err != nil {
+			configErrors = append(configErrors, err)
+			continue
+		} else if eof {
+			break
+		}
+
+		setCommand, isSetCommand := command.(*SetCommand)
+
+		switch {
+		case !isSetCommand:
+			err = fmt.Errorf("%v: Only set commands are permitted in a repository config file", parser.InputSource())
+		case !repositoryConfigVariables[ConfigVariable(setCommand.variable.value)]:
+			err = generateConfigError(parser.InputSource(), setCommand.variable,
+				"Variable %v cannot be set in a repository config file", setCommand.variable.value)
+		default:
+			err = config.processSetCommand(setCommand, parser.InputSource())
+		}
+
+		if err != nil {
+			configErrors = append(configErrors, err)
+		}
+	}
+
+	return configErrors
+}
+
 func (config *Configuration) processCommand(command ConfigCommand, inputSource string) (err error) {
 	switch command := command.(type) {
 	case *SetCommand:
@@ -329,6 +924,20 @@ func (config *Configuration) processCommand(command ConfigCommand, inputSource s
 		err = config.processAddViewCommand(command, inputSource)
 	case *SplitViewCommand:
 		err = config.processSplitViewCommand(command, inputSource)
+	case *PluginCommand:
+		err = config.processPluginCommand(command, inputSource)
+	case *ShellCommand:
+		err = config.processShellCommand(command, inputSource)
+	case *ToggleViewCommand:
+		err = config.processToggleViewCommand(command, inputSource)
+	case *FilterCommand:
+		err = config.processFilterCommand(command, inputSource)
+	case *IssueCommand:
+		err = config.processIssueCommand(command, inputSource)
+	case *ChangelogCommand:
+		err = config.processChangelogCommand(command, inputSource)
+	case *CommandViewCommand:
+		err = config.processCommandViewCommand(command, inputSource)
 	default:
 		log.Errorf("Unknown command type %T", command)
 	}
@@ -504,6 +1113,103 @@ func (config *Configuration) processRemoveTabCommand() (err error) {
 	return
 }
 
+func (config *Configuration) processToggleViewCommand(toggleViewCommand *ToggleViewCommand, inputSource string) (err error) {
+	viewID, ok := viewIDNames[toggleViewCommand.view.value]
+	if !ok {
+		return generateConfigError(inputSource, toggleViewCommand.view, "Invalid view: %v", toggleViewCommand.view.value)
+	}
+
+	log.Infof("Processed toggle view command for view %v", toggleViewCommand.view.value)
+
+	config.channels.DoAction(Action{
+		ActionType: ActionToggleViewVisibility,
+		Args:       []interface{}{viewID},
+	})
+
+	return
+}
+
+func (config *Configuration) processIssueCommand(issueCommand *IssueCommand, inputSource string) (err error) {
+	if err = config.issues.Add(issueCommand.pattern.value, issueCommand.urlTemplate.value); err != nil {
+		return generateConfigError(inputSource, issueCommand.pattern, "%v", err)
+	}
+
+	return
+}
+
+// IssueReferences returns the issue references in the text matched by the patterns defined using the issue command
+func (config *Configuration) IssueReferences(text string) []*IssueReference {
+	return config.issues.References(text)
+}
+
+func (config *Configuration) processChangelogCommand(changelogCommand *ChangelogCommand, inputSource string) (err error) {
+	viewArgs := []interface{}{changelogCommand.from.value, changelogCommand.to.value, "", ""}
+
+	if changelogCommand.trailerKey != nil {
+		viewArgs[2] = changelogCommand.trailerKey.value
+	}
+
+	if changelogCommand.outputFile != nil {
+		viewArgs[3] = changelogCommand.outputFile.value
+	}
+
+	log.Infof("Processing changelog command: %v", viewArgs)
+
+	config.channels.DoAction(Action{
+		ActionType: ActionSplitView,
+		Args: []interface{}{
+			ActionSplitViewArgs{
+				CreateViewArgs: CreateViewArgs{
+					viewID:   ViewChangelog,
+					viewArgs: viewArgs,
+				},
+				orientation: CoDynamic,
+			},
+		},
+	})
+
+	return
+}
+
+func (config *Configuration) processFilterCommand(filterCommand *FilterCommand, inputSource string) (err error) {
+	name := filterCommand.name.value
+
+	if filterCommand.subcommand.value == filterSaveSubcommand {
+		query := filterCommand.query.value
+
+		if _, isPickaxeQuery, err := ParsePickaxeQuery(query); err != nil {
+			return generateConfigError(inputSource, filterCommand.query, "Invalid filter query: %v", err)
+		} else if !isPickaxeQuery {
+			if _, errors := CreateCommitFilter(query); len(errors) > 0 {
+				return generateConfigError(inputSource, filterCommand.query, "Invalid filter query: %v", errors[0])
+			}
+		}
+
+		config.namedFilters.Save(name, query)
+
+		if filterCommand.keys != nil {
+			config.namedFilters.Bind(name, filterCommand.keys.value)
+		}
+
+		return
+	}
+
+	action, exists := config.namedFilters.ApplyAction(name)
+	if !exists {
+		return generateConfigError(inputSource, filterCommand.name, "No filter saved with name: %v", name)
+	}
+
+	log.Infof("Applying filter %v", name)
+	config.channels.DoAction(action)
+
+	return
+}
+
+// NamedFilterAction returns the action which applies the saved filter bound to the provided action type
+func (config *Configuration) NamedFilterAction(actionType ActionType) (Action, bool) {
+	return config.namedFilters.BoundApplyAction(actionType)
+}
+
 func (config *Configuration) generateViewArgs(view *ConfigToken, args []*ConfigToken, inputSource string) (createViewArgs CreateViewArgs, err error) {
 	viewID, ok := viewIDNames[view.value]
 	if !ok {
@@ -564,6 +1270,60 @@ func (config *Configuration) processSplitViewCommand(splitViewCommand *SplitView
 	return
 }
 
+func (config *Configuration) processPluginCommand(pluginCommand *PluginCommand, inputSource string) (err error) {
+	if pluginCommand.path.value == "" {
+		return generateConfigError(inputSource, pluginCommand.path, "plugin path cannot be empty")
+	}
+
+	log.Infof("Processing plugin command: %v", pluginCommand.path.value)
+
+	if err = config.plugins.Load(pluginCommand.path.value); err != nil {
+		return generateConfigError(inputSource, pluginCommand.path, "%v", err.Error())
+	}
+
+	return
+}
+
+func (config *Configuration) processShellCommand(shellCommand *ShellCommand, inputSource string) (err error) {
+	viewID, ok := viewIDNames[shellCommand.view.value]
+	if !ok {
+		return generateConfigError(inputSource, shellCommand.view, "Invalid view: %v", shellCommand.view.value)
+	}
+
+	if shellCommand.keys.value == "" {
+		return generateConfigError(inputSource, shellCommand.keys, "keystring cannot be empty")
+	}
+
+	if err = config.commands.RegisterCommand(viewID, shellCommand.keys.value, shellCommand.command.value, shellCommand.captureOutput); err != nil {
+		return generateConfigError(inputSource, shellCommand.command, "%v", err.Error())
+	}
+
+	log.Infof("Processed shell command for keys \"%v\" in view %v", shellCommand.keys.value, shellCommand.view.value)
+
+	return
+}
+
+func (config *Configuration) processCommandViewCommand(commandViewCommand *CommandViewCommand, inputSource string) (err error) {
+	var refreshInterval time.Duration
+
+	if commandViewCommand.interval != nil {
+		interval, parseErr := strconv.Atoi(commandViewCommand.interval.value)
+		if parseErr != nil || interval <= 0 {
+			return generateConfigError(inputSource, commandViewCommand.interval, "Interval must be an integer number of seconds greater than 0")
+		}
+
+		refreshInterval = time.Duration(interval) * time.Second
+	}
+
+	if err = config.commands.RegisterView(commandViewCommand.name.value, commandViewCommand.command.value, refreshInterval); err != nil {
+		return generateConfigError(inputSource, commandViewCommand.command, "%v", err.Error())
+	}
+
+	log.Infof("Processed command view \"%v\"", commandViewCommand.name.value)
+
+	return
+}
+
 // AddOnChangeListener adds a listener to be notified when a configuration variable changes value
 func (config *Configuration) AddOnChangeListener(configVariable ConfigVariable, listener ConfigVariableOnChangeListener) {
 	variable := config.getVariable(configVariable)
@@ -638,6 +1398,320 @@ func (tabwidthValidator tabWidithValidator) validate(value string) (processedVal
 	return
 }
 
+type scrollOffValidator struct{}
+
+func (scrollOffValidator scrollOffValidator) validate(value string) (processedValue interface{}, err error) {
+	var scrollOff int
+
+	if scrollOff, err = strconv.Atoi(value); err != nil || scrollOff < 0 {
+		err = fmt.Errorf("%v must be an integer value greater than or equal to 0", CfScrollOff)
+	} else {
+		processedValue = scrollOff
+	}
+
+	return
+}
+
+type commitLimitValidator struct{}
+
+func (commitLimitValidator commitLimitValidator) validate(value string) (processedValue interface{}, err error) {
+	var commitLimit int
+
+	if commitLimit, err = strconv.Atoi(value); err != nil || commitLimit < 0 {
+		err = fmt.Errorf("%v must be an integer value greater than or equal to 0", CfCommitLimit)
+	} else {
+		processedValue = commitLimit
+	}
+
+	return
+}
+
+type pollIntervalValidator struct{}
+
+func (pollIntervalValidator pollIntervalValidator) validate(value string) (processedValue interface{}, err error) {
+	var pollInterval int
+
+	if pollInterval, err = strconv.Atoi(value); err != nil || pollInterval < 0 {
+		err = fmt.Errorf("%v must be an integer value greater than or equal to 0", CfPollInterval)
+	} else {
+		processedValue = pollInterval
+	}
+
+	return
+}
+
+type statusTimeoutValidator struct{}
+
+func (statusTimeoutValidator statusTimeoutValidator) validate(value string) (processedValue interface{}, err error) {
+	var statusTimeout int
+
+	if statusTimeout, err = strconv.Atoi(value); err != nil || statusTimeout < 0 {
+		err = fmt.Errorf("%v must be an integer value greater than or equal to 0", CfStatusTimeout)
+	} else {
+		processedValue = statusTimeout
+	}
+
+	return
+}
+
+type keyTimeoutValidator struct{}
+
+func (keyTimeoutValidator keyTimeoutValidator) validate(value string) (processedValue interface{}, err error) {
+	var keyTimeout int
+
+	if keyTimeout, err = strconv.Atoi(value); err != nil || keyTimeout < 0 {
+		err = fmt.Errorf("%v must be an integer value greater than or equal to 0", CfKeyTimeout)
+	} else {
+		processedValue = keyTimeout
+	}
+
+	return
+}
+
+type logLevelValidator struct{}
+
+func (logLevelValidator logLevelValidator) validate(value string) (processedValue interface{}, err error) {
+	if _, _, err = ParseLogLevel(value); err == nil {
+		processedValue = strings.ToLower(value)
+	}
+
+	return
+}
+
+type logFileValidator struct{}
+
+func (logFileValidator logFileValidator) validate(value string) (processedValue interface{}, err error) {
+	if fileInfo, statErr := os.Stat(value); value != "" && statErr == nil && fileInfo.IsDir() {
+		err = fmt.Errorf("%v must be the path of a file but %v is a directory", CfLogFile, value)
+	} else {
+		processedValue = value
+	}
+
+	return
+}
+
+type layoutValidator struct{}
+
+func (layoutValidator layoutValidator) validate(value string) (processedValue interface{}, err error) {
+	if _, err = ParseLayout(value); err == nil {
+		processedValue = value
+	}
+
+	return
+}
+
+type commitFilterValidator struct{}
+
+func (commitFilterValidator commitFilterValidator) validate(value string) (processedValue interface{}, err error) {
+	if value != "" {
+		if _, errors := CreateCommitFilter(value); len(errors) > 0 {
+			return nil, errors[0]
+		}
+	}
+
+	return value, nil
+}
+
+type searchCaseValidator struct{}
+
+func (searchCaseValidator searchCaseValidator) validate(value string) (processedValue interface{}, err error) {
+	if _, err = ParseSearchCase(value); err == nil {
+		processedValue = strings.ToLower(value)
+	}
+
+	return
+}
+
+type historySizeValidator struct{}
+
+func (historySizeValidator historySizeValidator) validate(value string) (processedValue interface{}, err error) {
+	var historySize int
+
+	if historySize, err = strconv.Atoi(value); err != nil || historySize < 0 {
+		err = fmt.Errorf("%v must be an integer value greater than or equal to 0", CfHistorySize)
+	} else {
+		processedValue = historySize
+	}
+
+	return
+}
+
+type columnWidthValidator struct {
+	variable ConfigVariable
+}
+
+func (columnWidthValidator columnWidthValidator) validate(value string) (processedValue interface{}, err error) {
+	var width int
+
+	if width, err = strconv.Atoi(value); err != nil || width < 0 {
+		err = fmt.Errorf("%v must be an integer value greater than or equal to 0", columnWidthValidator.variable)
+	} else {
+		processedValue = width
+	}
+
+	return
+}
+
+type dateFormatValidator struct{}
+
+func (dateFormatValidator dateFormatValidator) validate(value string) (processedValue interface{}, err error) {
+	var dateFormat DateFormat
+
+	if dateFormat, _, err = ParseDateFormat(value); err == nil {
+		if dateFormat == DfCustom {
+			processedValue = value
+		} else {
+			processedValue = strings.ToLower(value)
+		}
+	}
+
+	return
+}
+
+type timeZoneValidator struct{}
+
+func (timeZoneValidator timeZoneValidator) validate(value string) (processedValue interface{}, err error) {
+	if _, err = ParseDateTimeZone(value); err == nil {
+		processedValue = strings.ToLower(value)
+	}
+
+	return
+}
+
+type authorDisplayValidator struct{}
+
+func (authorDisplayValidator authorDisplayValidator) validate(value string) (processedValue interface{}, err error) {
+	if _, err = ParseAuthorDisplay(value); err == nil {
+		processedValue = strings.ToLower(value)
+	}
+
+	return
+}
+
+type abbrevValidator struct{}
+
+func (abbrevValidator abbrevValidator) validate(value string) (processedValue interface{}, err error) {
+	var abbrev int
+
+	if abbrev, err = strconv.Atoi(value); err != nil || (abbrev != 0 && (abbrev < rdlMinShortOidLen || abbrev > rdlMaxShortOidLen)) {
+		err = fmt.Errorf("%v must be 0 or an integer value between %v and %v", CfAbbrev, rdlMinShortOidLen, rdlMaxShortOidLen)
+	} else {
+		processedValue = abbrev
+	}
+
+	return
+}
+
+type diffWhitespaceValidator struct{}
+
+func (diffWhitespaceValidator diffWhitespaceValidator) validate(value string) (processedValue interface{}, err error) {
+	if _, err = ParseDiffWhitespace(value); err == nil {
+		processedValue = strings.ToLower(value)
+	}
+
+	return
+}
+
+type diffAlgorithmValidator struct{}
+
+func (diffAlgorithmValidator diffAlgorithmValidator) validate(value string) (processedValue interface{}, err error) {
+	if _, err = ParseDiffAlgorithm(value); err == nil {
+		processedValue = strings.ToLower(value)
+	}
+
+	return
+}
+
+type renameThresholdValidator struct{}
+
+func (renameThresholdValidator renameThresholdValidator) validate(value string) (processedValue interface{}, err error) {
+	var threshold int
+
+	if threshold, err = strconv.Atoi(value); err != nil || threshold < 0 || threshold > rdlMaxSimilarity {
+		err = fmt.Errorf("%v must be an integer value between 0 and %v", CfRenameThreshold, rdlMaxSimilarity)
+	} else {
+		processedValue = threshold
+	}
+
+	return
+}
+
+type tagFilterValidator struct{}
+
+func (tagFilterValidator tagFilterValidator) validate(value string) (processedValue interface{}, err error) {
+	if _, err = ParseTagFilter(value); err == nil {
+		processedValue = value
+	}
+
+	return
+}
+
+type noConfirmValidator struct{}
+
+func (noConfirmValidator noConfirmValidator) validate(value string) (processedValue interface{}, err error) {
+	if _, err = ParseNoConfirmActions(value); err == nil {
+		processedValue = value
+	}
+
+	return
+}
+
+type tagSortValidator struct{}
+
+func (tagSortValidator tagSortValidator) validate(value string) (processedValue interface{}, err error) {
+	if _, err = ParseTagSort(value); err == nil {
+		processedValue = strings.ToLower(value)
+	}
+
+	return
+}
+
+type pullRequestsValidator struct{}
+
+func (pullRequestsValidator pullRequestsValidator) validate(value string) (processedValue interface{}, err error) {
+	if _, err = ParsePullRequestLookup(value); err == nil {
+		processedValue = strings.ToLower(value)
+	}
+
+	return
+}
+
+type tmuxCommandsValidator struct{}
+
+func (tmuxCommandsValidator tmuxCommandsValidator) validate(value string) (processedValue interface{}, err error) {
+	if _, err = ParseTmuxCommandMode(value); err == nil {
+		processedValue = strings.ToLower(value)
+	}
+
+	return
+}
+
+type osc52MaxSizeValidator struct{}
+
+func (osc52MaxSizeValidator osc52MaxSizeValidator) validate(value string) (processedValue interface{}, err error) {
+	var maxSize int
+
+	if maxSize, err = strconv.Atoi(value); err != nil || maxSize < 0 {
+		err = fmt.Errorf("%v must be an integer value greater than or equal to 0", CfOSC52MaxSize)
+	} else {
+		processedValue = maxSize
+	}
+
+	return
+}
+
+type binaryPreviewValidator struct{}
+
+func (binaryPreviewValidator binaryPreviewValidator) validate(value string) (processedValue interface{}, err error) {
+	if value != "" {
+		if err = validateExternalCommandTemplate(value); err != nil {
+			return
+		}
+	}
+
+	return value, nil
+}
+
 type themeValidator struct {
 	config *Configuration
 }
@@ -651,3 +1725,19 @@ func (themeValidator themeValidator) validate(value string) (processedValue inte
 
 	return
 }
+
+type booleanValidator struct {
+	variable ConfigVariable
+}
+
+func (booleanValidator booleanValidator) validate(value string) (processedValue interface{}, err error) {
+	var boolValue bool
+
+	if boolValue, err = strconv.ParseBool(value); err != nil {
+		err = fmt.Errorf("%v must be either true or false", booleanValidator.variable)
+	} else {
+		processedValue = boolValue
+	}
+
+	return
+}
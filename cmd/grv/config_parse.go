@@ -8,16 +8,31 @@ import (
 )
 
 const (
-	setCommand       = "set"
-	themeCommand     = "theme"
-	mapCommand       = "map"
-	quitCommand      = "q"
-	addtabCommand    = "addtab"
-	removetabCommand = "rmtab"
-	addviewCommand   = "addview"
-	vsplitCommand    = "vsplit"
-	hsplitCommand    = "hsplit"
-	splitCommand     = "split"
+	setCommand        = "set"
+	themeCommand      = "theme"
+	mapCommand        = "map"
+	quitCommand       = "q"
+	addtabCommand     = "addtab"
+	removetabCommand  = "rmtab"
+	addviewCommand    = "addview"
+	vsplitCommand     = "vsplit"
+	hsplitCommand     = "hsplit"
+	splitCommand      = "split"
+	pluginCommand     = "plugin"
+	shellCommand      = "shell"
+	toggleviewCommand = "toggleview"
+	filterCommand     = "filter"
+	issueCommand      = "issue"
+	changelogCommand  = "changelog"
+	cmdviewCommand    = "cmdview"
+
+	filterSaveSubcommand  = "save"
+	filterApplySubcommand = "apply"
+
+	changelogTrailerOption = "--trailer"
+	changelogOutputOption  = "--output"
+
+	cmdviewIntervalOption = "--interval"
 )
 
 type commandConstructor func(parser *ConfigParser, commandToken *ConfigToken, tokens []*ConfigToken) (ConfigCommand, error)
@@ -71,6 +86,13 @@ type RemoveTabCommand struct{}
 
 func (removeTabCommand *RemoveTabCommand) configCommand() {}
 
+// ToggleViewCommand represents the command to hide or show a view in the currently active tab
+type ToggleViewCommand struct {
+	view *ConfigToken
+}
+
+func (toggleViewCommand *ToggleViewCommand) configCommand() {}
+
 // AddViewCommand represents the command to add a new view
 // to the currently active view
 type AddViewCommand struct {
@@ -90,6 +112,65 @@ type SplitViewCommand struct {
 
 func (splitViewCommand *SplitViewCommand) configCommand() {}
 
+// PluginCommand represents the command to load a plugin
+type PluginCommand struct {
+	path *ConfigToken
+}
+
+func (pluginCommand *PluginCommand) configCommand() {}
+
+// ShellCommand represents the command to bind a key sequence
+// to an external shell command
+type ShellCommand struct {
+	captureOutput bool
+	view          *ConfigToken
+	keys          *ConfigToken
+	command       *ConfigToken
+}
+
+func (shellCommand *ShellCommand) configCommand() {}
+
+// FilterCommand represents the command to save a commit filter query
+// under a name, optionally bound to a key sequence, or to apply a previously saved filter
+type FilterCommand struct {
+	subcommand *ConfigToken
+	name       *ConfigToken
+	query      *ConfigToken
+	keys       *ConfigToken
+}
+
+func (filterCommand *FilterCommand) configCommand() {}
+
+// IssueCommand represents the command to define a pattern matching
+// issue references in commit messages and the URL of each referenced issue
+type IssueCommand struct {
+	pattern     *ConfigToken
+	urlTemplate *ConfigToken
+}
+
+func (issueCommand *IssueCommand) configCommand() {}
+
+// ChangelogCommand represents the command to generate a changelog for the commits
+// between two refs, optionally grouped by a trailer and written to a file
+type ChangelogCommand struct {
+	from       *ConfigToken
+	to         *ConfigToken
+	trailerKey *ConfigToken
+	outputFile *ConfigToken
+}
+
+func (changelogCommand *ChangelogCommand) configCommand() {}
+
+// CommandViewCommand represents the command to define a named view
+// which displays the output of an external command, optionally rerun at an interval
+type CommandViewCommand struct {
+	interval *ConfigToken
+	name     *ConfigToken
+	command  *ConfigToken
+}
+
+func (commandViewCommand *CommandViewCommand) configCommand() {}
+
 type commandDescriptor struct {
 	tokenTypes  []ConfigTokenType
 	varArgs     bool
@@ -135,6 +216,34 @@ var commandDescriptors = map[string]*commandDescriptor{
 		varArgs:     true,
 		constructor: splitViewCommandConstructor,
 	},
+	pluginCommand: {
+		tokenTypes:  []ConfigTokenType{CtkWord},
+		constructor: pluginCommandConstructor,
+	},
+	shellCommand: {
+		varArgs:     true,
+		constructor: shellCommandConstructor,
+	},
+	toggleviewCommand: {
+		tokenTypes:  []ConfigTokenType{CtkWord},
+		constructor: toggleViewCommandConstructor,
+	},
+	filterCommand: {
+		varArgs:     true,
+		constructor: filterCommandConstructor,
+	},
+	issueCommand: {
+		tokenTypes:  []ConfigTokenType{CtkWord, CtkWord},
+		constructor: issueCommandConstructor,
+	},
+	changelogCommand: {
+		varArgs:     true,
+		constructor: changelogCommandConstructor,
+	},
+	cmdviewCommand: {
+		varArgs:     true,
+		constructor: commandViewCommandConstructor,
+	},
 }
 
 // ConfigParser is a component capable of parsing config into commands
@@ -365,6 +474,12 @@ func newRemoveTabCommandConstructor(parser *ConfigParser, commandToken *ConfigTo
 	return &RemoveTabCommand{}, nil
 }
 
+func toggleViewCommandConstructor(parser *ConfigParser, commandToken *ConfigToken, tokens []*ConfigToken) (ConfigCommand, error) {
+	return &ToggleViewCommand{
+		view: tokens[0],
+	}, nil
+}
+
 func addViewCommandConstructor(parser *ConfigParser, commandToken *ConfigToken, tokens []*ConfigToken) (ConfigCommand, error) {
 	if len(tokens) < 1 {
 		addViewCommand := commandToken.value
@@ -403,3 +518,150 @@ func splitViewCommandConstructor(parser *ConfigParser, commandToken *ConfigToken
 		args:        tokens[1:],
 	}, nil
 }
+
+func pluginCommandConstructor(parser *ConfigParser, commandToken *ConfigToken, tokens []*ConfigToken) (ConfigCommand, error) {
+	return &PluginCommand{
+		path: tokens[0],
+	}, nil
+}
+
+func shellCommandConstructor(parser *ConfigParser, commandToken *ConfigToken, tokens []*ConfigToken) (ConfigCommand, error) {
+	shellCommand := &ShellCommand{}
+
+	if len(tokens) > 0 && tokens[0].tokenType == CtkOption {
+		if tokens[0].value != "--capture" {
+			return nil, parser.generateParseError(tokens[0], "Invalid option for shell command: \"%v\"", tokens[0].value)
+		}
+
+		shellCommand.captureOutput = true
+		tokens = tokens[1:]
+	}
+
+	if len(tokens) != 3 {
+		return nil, parser.generateParseError(commandToken, "Invalid %[1]v command. Usage: %[1]v [--capture] VIEW KEYS COMMAND", commandToken.value)
+	}
+
+	for _, token := range tokens {
+		if token.tokenType != CtkWord {
+			return nil, parser.generateParseError(token, "Expected %v but got %v: \"%v\"",
+				ConfigTokenName(CtkWord), ConfigTokenName(token.tokenType), token.value)
+		}
+	}
+
+	shellCommand.view = tokens[0]
+	shellCommand.keys = tokens[1]
+	shellCommand.command = tokens[2]
+
+	return shellCommand, nil
+}
+
+func filterCommandConstructor(parser *ConfigParser, commandToken *ConfigToken, tokens []*ConfigToken) (ConfigCommand, error) {
+	for _, token := range tokens {
+		if token.tokenType != CtkWord {
+			return nil, parser.generateParseError(token, "Expected %v but got %v: \"%v\"",
+				ConfigTokenName(CtkWord), ConfigTokenName(token.tokenType), token.value)
+		}
+	}
+
+	switch {
+	case (len(tokens) == 3 || len(tokens) == 4) && tokens[0].value == filterSaveSubcommand:
+		filterCommand := &FilterCommand{
+			subcommand: tokens[0],
+			name:       tokens[1],
+			query:      tokens[2],
+		}
+
+		if len(tokens) == 4 {
+			filterCommand.keys = tokens[3]
+		}
+
+		return filterCommand, nil
+	case len(tokens) == 2 && tokens[0].value == filterApplySubcommand:
+		return &FilterCommand{
+			subcommand: tokens[0],
+			name:       tokens[1],
+		}, nil
+	}
+
+	return nil, parser.generateParseError(commandToken, "Invalid %[1]v command. Usage: %[1]v save NAME QUERY [KEYS] or %[1]v apply NAME", commandToken.value)
+}
+
+func issueCommandConstructor(parser *ConfigParser, commandToken *ConfigToken, tokens []*ConfigToken) (ConfigCommand, error) {
+	return &IssueCommand{
+		pattern:     tokens[0],
+		urlTemplate: tokens[1],
+	}, nil
+}
+
+func changelogCommandConstructor(parser *ConfigParser, commandToken *ConfigToken, tokens []*ConfigToken) (ConfigCommand, error) {
+	changelogCommand := &ChangelogCommand{}
+	var refs []*ConfigToken
+
+	for tokenIndex := 0; tokenIndex < len(tokens); tokenIndex++ {
+		token := tokens[tokenIndex]
+
+		if token.tokenType == CtkOption {
+			if tokenIndex+1 >= len(tokens) || tokens[tokenIndex+1].tokenType != CtkWord {
+				return nil, parser.generateParseError(token, "Expected value for option \"%v\"", token.value)
+			}
+
+			switch token.value {
+			case changelogTrailerOption:
+				changelogCommand.trailerKey = tokens[tokenIndex+1]
+			case changelogOutputOption:
+				changelogCommand.outputFile = tokens[tokenIndex+1]
+			default:
+				return nil, parser.generateParseError(token, "Invalid option for %v command: \"%v\"", commandToken.value, token.value)
+			}
+
+			tokenIndex++
+		} else if token.tokenType == CtkWord {
+			refs = append(refs, token)
+		} else {
+			return nil, parser.generateParseError(token, "Expected %v but got %v: \"%v\"",
+				ConfigTokenName(CtkWord), ConfigTokenName(token.tokenType), token.value)
+		}
+	}
+
+	if len(refs) != 2 {
+		return nil, parser.generateParseError(commandToken, "Invalid %[1]v command. Usage: %[1]v [--trailer KEY] [--output FILE] FROM TO", commandToken.value)
+	}
+
+	changelogCommand.from = refs[0]
+	changelogCommand.to = refs[1]
+
+	return changelogCommand, nil
+}
+
+func commandViewCommandConstructor(parser *ConfigParser, commandToken *ConfigToken, tokens []*ConfigToken) (ConfigCommand, error) {
+	commandViewCommand := &CommandViewCommand{}
+
+	if len(tokens) > 0 && tokens[0].tokenType == CtkOption {
+		if tokens[0].value != cmdviewIntervalOption {
+			return nil, parser.generateParseError(tokens[0], "Invalid option for %v command: \"%v\"", commandToken.value, tokens[0].value)
+		}
+
+		if len(tokens) < 2 || tokens[1].tokenType != CtkWord {
+			return nil, parser.generateParseError(tokens[0], "Expected value for option \"%v\"", tokens[0].value)
+		}
+
+		commandViewCommand.interval = tokens[1]
+		tokens = tokens[2:]
+	}
+
+	if len(tokens) != 2 {
+		return nil, parser.generateParseError(commandToken, "Invalid %[1]v command. Usage: %[1]v [--interval SECONDS] NAME COMMAND", commandToken.value)
+	}
+
+	for _, token := range tokens {
+		if token.tokenType != CtkWord {
+			return nil, parser.generateParseError(token, "Expected %v but got %v: \"%v\"",
+				ConfigTokenName(CtkWord), ConfigTokenName(token.tokenType), token.value)
+		}
+	}
+
+	commandViewCommand.name = tokens[0]
+	commandViewCommand.command = tokens[1]
+
+	return commandViewCommand, nil
+}
@@ -96,6 +96,27 @@ func (removeTabCommandValues *RemoveTabCommandValues) Equal(command ConfigComman
 	return ok
 }
 
+type ToggleViewCommandValues struct {
+	view string
+}
+
+func (toggleViewCommandValues *ToggleViewCommandValues) Equal(command ConfigCommand) bool {
+	if command == nil {
+		return false
+	}
+
+	other, ok := command.(*ToggleViewCommand)
+	if !ok {
+		return false
+	}
+
+	if other.view == nil {
+		return false
+	}
+
+	return toggleViewCommandValues.view == other.view.value
+}
+
 type AddViewCommandValues struct {
 	view string
 	args []string
@@ -154,6 +175,179 @@ func (splitViewCommandValues *SplitViewCommandValues) Equal(command ConfigComman
 		reflect.DeepEqual(splitViewCommandValues.args, otherArgs)
 }
 
+type PluginCommandValues struct {
+	path string
+}
+
+func (pluginCommandValues *PluginCommandValues) Equal(command ConfigCommand) bool {
+	if command == nil {
+		return false
+	}
+
+	other, ok := command.(*PluginCommand)
+	if !ok {
+		return false
+	}
+
+	if other.path == nil {
+		return false
+	}
+
+	return pluginCommandValues.path == other.path.value
+}
+
+type ShellCommandValues struct {
+	captureOutput bool
+	view          string
+	keys          string
+	command       string
+}
+
+func (shellCommandValues *ShellCommandValues) Equal(command ConfigCommand) bool {
+	if command == nil {
+		return false
+	}
+
+	other, ok := command.(*ShellCommand)
+	if !ok {
+		return false
+	}
+
+	if other.view == nil || other.keys == nil || other.command == nil {
+		return false
+	}
+
+	return shellCommandValues.captureOutput == other.captureOutput &&
+		shellCommandValues.view == other.view.value &&
+		shellCommandValues.keys == other.keys.value &&
+		shellCommandValues.command == other.command.value
+}
+
+type FilterCommandValues struct {
+	subcommand string
+	name       string
+	query      string
+	keys       string
+}
+
+func (filterCommandValues *FilterCommandValues) Equal(command ConfigCommand) bool {
+	if command == nil {
+		return false
+	}
+
+	other, ok := command.(*FilterCommand)
+	if !ok {
+		return false
+	}
+
+	if other.subcommand == nil || other.name == nil {
+		return false
+	}
+
+	query, keys := "", ""
+	if other.query != nil {
+		query = other.query.value
+	}
+	if other.keys != nil {
+		keys = other.keys.value
+	}
+
+	return filterCommandValues.subcommand == other.subcommand.value &&
+		filterCommandValues.name == other.name.value &&
+		filterCommandValues.query == query &&
+		filterCommandValues.keys == keys
+}
+
+type IssueCommandValues struct {
+	pattern     string
+	urlTemplate string
+}
+
+func (issueCommandValues *IssueCommandValues) Equal(command ConfigCommand) bool {
+	if command == nil {
+		return false
+	}
+
+	other, ok := command.(*IssueCommand)
+	if !ok {
+		return false
+	}
+
+	if other.pattern == nil || other.urlTemplate == nil {
+		return false
+	}
+
+	return issueCommandValues.pattern == other.pattern.value &&
+		issueCommandValues.urlTemplate == other.urlTemplate.value
+}
+
+type CommandViewCommandValues struct {
+	interval string
+	name     string
+	command  string
+}
+
+func (commandViewCommandValues *CommandViewCommandValues) Equal(command ConfigCommand) bool {
+	if command == nil {
+		return false
+	}
+
+	other, ok := command.(*CommandViewCommand)
+	if !ok {
+		return false
+	}
+
+	if other.name == nil || other.command == nil {
+		return false
+	}
+
+	interval := ""
+	if other.interval != nil {
+		interval = other.interval.value
+	}
+
+	return commandViewCommandValues.interval == interval &&
+		commandViewCommandValues.name == other.name.value &&
+		commandViewCommandValues.command == other.command.value
+}
+
+type ChangelogCommandValues struct {
+	from       string
+	to         string
+	trailerKey string
+	outputFile string
+}
+
+func (changelogCommandValues *ChangelogCommandValues) Equal(command ConfigCommand) bool {
+	if command == nil {
+		return false
+	}
+
+	other, ok := command.(*ChangelogCommand)
+	if !ok {
+		return false
+	}
+
+	if other.from == nil || other.to == nil {
+		return false
+	}
+
+	var trailerKey, outputFile string
+
+	if other.trailerKey != nil {
+		trailerKey = other.trailerKey.value
+	}
+
+	if other.outputFile != nil {
+		outputFile = other.outputFile.value
+	}
+
+	return changelogCommandValues.from == other.from.value &&
+		changelogCommandValues.to == other.to.value &&
+		changelogCommandValues.trailerKey == trailerKey &&
+		changelogCommandValues.outputFile == outputFile
+}
+
 func TestParseSingleCommand(t *testing.T) {
 	var singleCommandTests = []struct {
 		input           string
@@ -185,6 +379,12 @@ func TestParseSingleCommand(t *testing.T) {
 			input:           "rmtab",
 			expectedCommand: &RemoveTabCommandValues{},
 		},
+		{
+			input: "toggleview RefView",
+			expectedCommand: &ToggleViewCommandValues{
+				view: "RefView",
+			},
+		},
 		{
 			input: "addview RefView",
 			expectedCommand: &AddViewCommandValues{
@@ -220,6 +420,91 @@ func TestParseSingleCommand(t *testing.T) {
 				view:        "GitStatusView",
 			},
 		},
+		{
+			input: "plugin /usr/lib/grv/myplugin.so",
+			expectedCommand: &PluginCommandValues{
+				path: "/usr/lib/grv/myplugin.so",
+			},
+		},
+		{
+			input: "shell CommitView C \"git checkout %(commit)\"",
+			expectedCommand: &ShellCommandValues{
+				view:    "CommitView",
+				keys:    "C",
+				command: "git checkout %(commit)",
+			},
+		},
+		{
+			input: "shell --capture GitStatusView L \"git log -- %(file)\"",
+			expectedCommand: &ShellCommandValues{
+				captureOutput: true,
+				view:          "GitStatusView",
+				keys:          "L",
+				command:       "git log -- %(file)",
+			},
+		},
+		{
+			input: "filter save release \"authorname = \\\"Release Bot\\\"\"",
+			expectedCommand: &FilterCommandValues{
+				subcommand: "save",
+				name:       "release",
+				query:      "authorname = \"Release Bot\"",
+			},
+		},
+		{
+			input: "filter save mine \"authorname = me\" <C-f>",
+			expectedCommand: &FilterCommandValues{
+				subcommand: "save",
+				name:       "mine",
+				query:      "authorname = me",
+				keys:       "<C-f>",
+			},
+		},
+		{
+			input: "filter apply release",
+			expectedCommand: &FilterCommandValues{
+				subcommand: "apply",
+				name:       "release",
+			},
+		},
+		{
+			input: "issue \"[A-Z]+-[0-9]+\" https://jira.example.com/browse/$0",
+			expectedCommand: &IssueCommandValues{
+				pattern:     "[A-Z]+-[0-9]+",
+				urlTemplate: "https://jira.example.com/browse/$0",
+			},
+		},
+		{
+			input: "changelog v1.0.0 v1.1.0",
+			expectedCommand: &ChangelogCommandValues{
+				from: "v1.0.0",
+				to:   "v1.1.0",
+			},
+		},
+		{
+			input: "changelog --trailer Changelog v1.0.0 --output CHANGELOG.md v1.1.0",
+			expectedCommand: &ChangelogCommandValues{
+				from:       "v1.0.0",
+				to:         "v1.1.0",
+				trailerKey: "Changelog",
+				outputFile: "CHANGELOG.md",
+			},
+		},
+		{
+			input: "cmdview ci-status \"ci-status --commit %(commit)\"",
+			expectedCommand: &CommandViewCommandValues{
+				name:    "ci-status",
+				command: "ci-status --commit %(commit)",
+			},
+		},
+		{
+			input: "cmdview --interval 30 ci-status \"ci-status --commit %(commit)\"",
+			expectedCommand: &CommandViewCommandValues{
+				interval: "30",
+				name:     "ci-status",
+				command:  "ci-status --commit %(commit)",
+			},
+		},
 	}
 
 	for _, singleCommandTest := range singleCommandTests {
@@ -356,6 +641,34 @@ func TestErrorsAreReceivedForInvalidConfigTokenSequences(t *testing.T) {
 			input:                "addtab",
 			expectedErrorMessage: ConfigFile + ":1:6 Unexpected EOF",
 		},
+		{
+			input:                "shell --output CommitView C ls",
+			expectedErrorMessage: ConfigFile + ":1:7 Invalid option for shell command: \"--output\"",
+		},
+		{
+			input:                "shell CommitView C",
+			expectedErrorMessage: ConfigFile + ":1:1 Invalid shell command. Usage: shell [--capture] VIEW KEYS COMMAND",
+		},
+		{
+			input:                "filter apply",
+			expectedErrorMessage: ConfigFile + ":1:1 Invalid filter command. Usage: filter save NAME QUERY [KEYS] or filter apply NAME",
+		},
+		{
+			input:                "changelog v1.0.0",
+			expectedErrorMessage: ConfigFile + ":1:1 Invalid changelog command. Usage: changelog [--trailer KEY] [--output FILE] FROM TO",
+		},
+		{
+			input:                "changelog --group type v1.0.0 v1.1.0",
+			expectedErrorMessage: ConfigFile + ":1:11 Invalid option for changelog command: \"--group\"",
+		},
+		{
+			input:                "cmdview ci-status",
+			expectedErrorMessage: ConfigFile + ":1:1 Invalid cmdview command. Usage: cmdview [--interval SECONDS] NAME COMMAND",
+		},
+		{
+			input:                "cmdview --every 30 ci-status ci-status",
+			expectedErrorMessage: ConfigFile + ":1:9 Invalid option for cmdview command: \"--every\"",
+		},
 	}
 
 	for _, errorTest := range errorTests {
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	cfActionNamePrefix = "<grv-"
+	cfActionNameSuffix = ">"
+)
+
+// Confirmation is a question asked before an action is performed.
+// When typedResponse is set it must be entered to confirm, otherwise y or yes confirms
+type Confirmation struct {
+	question      string
+	action        Action
+	typedResponse string
+}
+
+// Prompt returns the text displayed when asking for confirmation
+func (confirmation Confirmation) Prompt() string {
+	if confirmation.typedResponse != "" {
+		return fmt.Sprintf("%v? Type %v to confirm: ", confirmation.question, confirmation.typedResponse)
+	}
+
+	return fmt.Sprintf("%v? (y/n): ", confirmation.question)
+}
+
+// IsConfirmedBy returns true if the provided input confirms the action
+func (confirmation Confirmation) IsConfirmedBy(input string) bool {
+	if confirmation.typedResponse != "" {
+		return strings.TrimSpace(input) == confirmation.typedResponse
+	}
+
+	return isConfirmation(input)
+}
+
+func isConfirmation(input string) bool {
+	return strings.EqualFold(input, "y") || strings.EqualFold(input, "yes")
+}
+
+// ParseNoConfirmActions parses a comma or space separated list of action names and returns the action types.
+// Action names can be provided with or without the surrounding <grv- and >, e.g. drop-stash
+func ParseNoConfirmActions(value string) (actionTypes map[ActionType]bool, err error) {
+	actionTypes = make(map[ActionType]bool)
+
+	names := strings.FieldsFunc(value, func(char rune) bool {
+		return char == ',' || char == ' ' || char == '\t'
+	})
+
+	for _, name := range names {
+		if !strings.HasPrefix(name, cfActionNamePrefix) {
+			name = cfActionNamePrefix + name + cfActionNameSuffix
+		}
+
+		actionType, ok := actionKeys[name]
+		if !ok {
+			return nil, fmt.Errorf("Invalid action %v", name)
+		}
+
+		actionTypes[actionType] = true
+	}
+
+	return
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestConfirmationIsConfirmedByYesOrTypedResponse(t *testing.T) {
+	confirmationTests := []struct {
+		confirmation      Confirmation
+		input             string
+		expectedConfirmed bool
+	}{
+		{confirmation: Confirmation{question: "Drop"}, input: "y", expectedConfirmed: true},
+		{confirmation: Confirmation{question: "Drop"}, input: "YES", expectedConfirmed: true},
+		{confirmation: Confirmation{question: "Drop"}, input: "n", expectedConfirmed: false},
+		{confirmation: Confirmation{question: "Drop"}, input: "", expectedConfirmed: false},
+		{confirmation: Confirmation{question: "Discard", typedResponse: "discard"}, input: "discard", expectedConfirmed: true},
+		{confirmation: Confirmation{question: "Discard", typedResponse: "discard"}, input: " discard ", expectedConfirmed: true},
+		{confirmation: Confirmation{question: "Discard", typedResponse: "discard"}, input: "y", expectedConfirmed: false},
+		{confirmation: Confirmation{question: "Discard", typedResponse: "discard"}, input: "Discard", expectedConfirmed: false},
+	}
+
+	for _, confirmationTest := range confirmationTests {
+		if confirmed := confirmationTest.confirmation.IsConfirmedBy(confirmationTest.input); confirmed != confirmationTest.expectedConfirmed {
+			t.Errorf("Unexpected result for input %q with typed response %q. Expected: %v, Actual: %v",
+				confirmationTest.input, confirmationTest.confirmation.typedResponse, confirmationTest.expectedConfirmed, confirmed)
+		}
+	}
+}
+
+func TestConfirmationPromptDescribesResponse(t *testing.T) {
+	if prompt := (Confirmation{question: "Drop stash@{0}"}).Prompt(); prompt != "Drop stash@{0}? (y/n): " {
+		t.Errorf("Unexpected prompt: %q", prompt)
+	}
+
+	if prompt := (Confirmation{question: "Discard changes", typedResponse: "discard"}).Prompt(); prompt != "Discard changes? Type discard to confirm: " {
+		t.Errorf("Unexpected prompt: %q", prompt)
+	}
+}
+
+func TestNoConfirmActionsAreParsed(t *testing.T) {
+	actionTypes, err := ParseNoConfirmActions("drop-stash, <grv-discard-changes>  bisect-reset")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedActionTypes := []ActionType{ActionDropStash, ActionDiscardChanges, ActionBisectReset}
+
+	if len(actionTypes) != len(expectedActionTypes) {
+		t.Errorf("Expected %v action types but found %v", len(expectedActionTypes), len(actionTypes))
+	}
+
+	for _, actionType := range expectedActionTypes {
+		if !actionTypes[actionType] {
+			t.Errorf("Expected action type %v to be parsed", actionType)
+		}
+	}
+
+	if actionTypes, err = ParseNoConfirmActions(""); err != nil || len(actionTypes) != 0 {
+		t.Errorf("Expected no action types for empty value but found %v, error: %v", actionTypes, err)
+	}
+
+	if _, err = ParseNoConfirmActions("drop-stash,not-an-action"); err == nil {
+		t.Errorf("Expected error for invalid action name")
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"strings"
+)
+
+const (
+	cnOursMarker   = "<<<<<<<"
+	cnBaseMarker   = "|||||||"
+	cnSplitMarker  = "======="
+	cnTheirsMarker = ">>>>>>>"
+)
+
+// ConflictLineType identifies the section of a three-way conflict a line belongs to
+type ConflictLineType int
+
+// The set of conflict line types
+const (
+	CltCommon ConflictLineType = iota
+	CltMarker
+	CltOurs
+	CltBase
+	CltTheirs
+)
+
+// ConflictResolution identifies the side of a conflict used to resolve it
+type ConflictResolution int
+
+// The set of conflict resolutions
+const (
+	CrOurs ConflictResolution = iota
+	CrTheirs
+)
+
+var conflictResolutionNames = map[ConflictResolution]string{
+	CrOurs:   "ours",
+	CrTheirs: "theirs",
+}
+
+func (resolution ConflictResolution) String() string {
+	return conflictResolutionNames[resolution]
+}
+
+// ConflictLine is a line of merged file content
+type ConflictLine struct {
+	line     string
+	lineType ConflictLineType
+}
+
+// Conflict is a file which has conflicting changes in the index.
+// The oid of a stage is nil if the file does not exist in that stage
+type Conflict struct {
+	path        string
+	ancestorOid *Oid
+	ourOid      *Oid
+	theirOid    *Oid
+}
+
+// Description describes how the file was changed on each side of the conflict
+func (conflict *Conflict) Description() string {
+	switch {
+	case conflict.ourOid != nil && conflict.theirOid != nil && conflict.ancestorOid == nil:
+		return "both added"
+	case conflict.ourOid != nil && conflict.theirOid != nil:
+		return "both modified"
+	case conflict.ourOid != nil:
+		if conflict.ancestorOid == nil {
+			return "added by us"
+		}
+
+		return "deleted by them"
+	case conflict.theirOid != nil:
+		if conflict.ancestorOid == nil {
+			return "added by them"
+		}
+
+		return "deleted by us"
+	}
+
+	return "both deleted"
+}
+
+// ParseConflictContent determines which section of a conflict each line of the
+// provided content belongs to. The content is expected to use diff3 style conflict
+// markers, where the base section is optional
+func ParseConflictContent(content string) (conflictLines []ConflictLine) {
+	content = strings.TrimSuffix(content, "\n")
+	if content == "" {
+		return
+	}
+
+	lineType := CltCommon
+
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		markerLine := true
+
+		switch {
+		case lineType == CltCommon && strings.HasPrefix(line, cnOursMarker):
+			lineType = CltOurs
+		case lineType == CltOurs && strings.HasPrefix(line, cnBaseMarker):
+			lineType = CltBase
+		case (lineType == CltOurs || lineType == CltBase) && strings.HasPrefix(line, cnSplitMarker):
+			lineType = CltTheirs
+		case lineType == CltTheirs && strings.HasPrefix(line, cnTheirsMarker):
+			lineType = CltCommon
+		default:
+			markerLine = false
+		}
+
+		conflictLine := ConflictLine{
+			line:     line,
+			lineType: lineType,
+		}
+
+		if markerLine {
+			conflictLine.lineType = CltMarker
+		}
+
+		conflictLines = append(conflictLines, conflictLine)
+	}
+
+	return
+}
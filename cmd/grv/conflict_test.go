@@ -0,0 +1,72 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConflictContentLinesAreClassifiedBySection(t *testing.T) {
+	content := "common\n" +
+		"<<<<<<< ours\n" +
+		"our change\n" +
+		"||||||| base\n" +
+		"original\n" +
+		"=======\n" +
+		"their change\n" +
+		">>>>>>> theirs\n" +
+		"=======\n"
+
+	expected := []ConflictLine{
+		{line: "common", lineType: CltCommon},
+		{line: "<<<<<<< ours", lineType: CltMarker},
+		{line: "our change", lineType: CltOurs},
+		{line: "||||||| base", lineType: CltMarker},
+		{line: "original", lineType: CltBase},
+		{line: "=======", lineType: CltMarker},
+		{line: "their change", lineType: CltTheirs},
+		{line: ">>>>>>> theirs", lineType: CltMarker},
+		{line: "=======", lineType: CltCommon},
+	}
+
+	if actual := ParseConflictContent(content); !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Conflict lines do not match expected value. Expected: %v, Actual: %v", expected, actual)
+	}
+}
+
+func TestConflictContentWithoutBaseSection(t *testing.T) {
+	content := "<<<<<<< ours\r\nours\r\n=======\r\ntheirs\r\n>>>>>>> theirs"
+
+	expected := []ConflictLine{
+		{line: "<<<<<<< ours", lineType: CltMarker},
+		{line: "ours", lineType: CltOurs},
+		{line: "=======", lineType: CltMarker},
+		{line: "theirs", lineType: CltTheirs},
+		{line: ">>>>>>> theirs", lineType: CltMarker},
+	}
+
+	if actual := ParseConflictContent(content); !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Conflict lines do not match expected value. Expected: %v, Actual: %v", expected, actual)
+	}
+}
+
+func TestConflictDescriptionReflectsStages(t *testing.T) {
+	oid := &Oid{}
+
+	descriptionTests := []struct {
+		conflict *Conflict
+		expected string
+	}{
+		{conflict: &Conflict{ancestorOid: oid, ourOid: oid, theirOid: oid}, expected: "both modified"},
+		{conflict: &Conflict{ourOid: oid, theirOid: oid}, expected: "both added"},
+		{conflict: &Conflict{ancestorOid: oid, ourOid: oid}, expected: "deleted by them"},
+		{conflict: &Conflict{ancestorOid: oid, theirOid: oid}, expected: "deleted by us"},
+		{conflict: &Conflict{ourOid: oid}, expected: "added by us"},
+		{conflict: &Conflict{theirOid: oid}, expected: "added by them"},
+	}
+
+	for _, descriptionTest := range descriptionTests {
+		if actual := descriptionTest.conflict.Description(); actual != descriptionTest.expected {
+			t.Errorf("Description does not match expected value. Expected: %v, Actual: %v", descriptionTest.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,386 @@
+package main
+
+import (
+	"fmt"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+var conflictLineThemeComponents = map[ConflictLineType]ThemeComponentID{
+	CltCommon: CmpNone,
+	CltMarker: CmpConflictviewMarker,
+	CltOurs:   CmpConflictviewOurs,
+	CltBase:   CmpConflictviewBase,
+	CltTheirs: CmpConflictviewTheirs,
+}
+
+type conflictViewHandler func(*ConflictView, Action) error
+
+// renderedConflictRow is either the header of a conflicted file or a line of its content
+type renderedConflictRow struct {
+	conflictIndex int
+	conflictLine  *ConflictLine
+}
+
+// ConflictView lists the files with conflicting changes during a merge, rebase or similar operation.
+// Expanding a file displays its three-way merged content with the conflicting sections highlighted
+type ConflictView struct {
+	*ListView
+	repoData      RepoData
+	config        Config
+	repoState     string
+	conflicts     []*Conflict
+	expanded      map[string]bool
+	conflictLines map[string][]ConflictLine
+	renderedRows  []renderedConflictRow
+	active        bool
+	handlers      map[ActionType]conflictViewHandler
+}
+
+// NewConflictView creates a new instance
+func NewConflictView(repoData RepoData, channels *Channels, config Config) *ConflictView {
+	conflictView := &ConflictView{
+		ListView:      NewListView(channels),
+		repoData:      repoData,
+		config:        config,
+		expanded:      make(map[string]bool),
+		conflictLines: make(map[string][]ConflictLine),
+		handlers: map[ActionType]conflictViewHandler{
+			ActionSelect:        toggleConflictExpanded,
+			ActionResolveOurs:   resolveConflictUsingOurs,
+			ActionResolveTheirs: resolveConflictUsingTheirs,
+		},
+	}
+
+	conflictView.viewSearch = NewViewSearch(conflictView, channels)
+	repoData.RegisterStatusListener(conflictView)
+
+	return conflictView
+}
+
+// Initialise loads the conflicted files and expands the first one
+func (conflictView *ConflictView) Initialise() (err error) {
+	log.Info("Initialising ConflictView")
+
+	conflictView.lock.Lock()
+	defer conflictView.lock.Unlock()
+
+	if err = conflictView.loadConflicts(); err != nil {
+		return
+	}
+
+	if len(conflictView.conflicts) > 0 {
+		conflictView.expanded[conflictView.conflicts[0].path] = true
+		conflictView.generateRenderedRows()
+	}
+
+	return
+}
+
+// OnStatusChanged reloads the conflicted files as resolving a conflict changes the status
+func (conflictView *ConflictView) OnStatusChanged(status *Status) {
+	conflictView.lock.Lock()
+	defer conflictView.lock.Unlock()
+
+	if err := conflictView.loadConflicts(); err != nil {
+		conflictView.channels.ReportError(err)
+		return
+	}
+
+	conflictView.channels.UpdateDisplay()
+}
+
+func (conflictView *ConflictView) loadConflicts() (err error) {
+	conflicts, err := conflictView.repoData.Conflicts()
+	if err != nil {
+		return
+	}
+
+	selectedConflict, selectedExists := conflictView.selectedConflict()
+
+	conflictView.repoState = conflictView.repoData.RepositoryState()
+	conflictView.conflicts = conflicts
+	conflictView.conflictLines = make(map[string][]ConflictLine)
+	conflictView.generateRenderedRows()
+
+	if conflict, exists := conflictView.selectedConflict(); selectedExists && (!exists || conflict.path != selectedConflict.path) {
+		conflictView.selectConflictHeader(selectedConflict.path)
+	}
+
+	return
+}
+
+func (conflictView *ConflictView) generateRenderedRows() {
+	var renderedRows []renderedConflictRow
+
+	for conflictIndex, conflict := range conflictView.conflicts {
+		renderedRows = append(renderedRows, renderedConflictRow{conflictIndex: conflictIndex})
+
+		if !conflictView.expanded[conflict.path] {
+			continue
+		}
+
+		conflictLines, loaded := conflictView.conflictLines[conflict.path]
+		if !loaded {
+			var err error
+			if conflictLines, err = conflictView.repoData.ConflictContent(conflict); err != nil {
+				conflictView.channels.ReportError(fmt.Errorf("Unable to generate conflict content for %v: %v", conflict.path, err))
+			}
+
+			conflictView.conflictLines[conflict.path] = conflictLines
+		}
+
+		for lineIndex := range conflictLines {
+			renderedRows = append(renderedRows, renderedConflictRow{
+				conflictIndex: conflictIndex,
+				conflictLine:  &conflictLines[lineIndex],
+			})
+		}
+	}
+
+	conflictView.renderedRows = renderedRows
+
+	rowNum := uint(len(renderedRows))
+	viewPos := conflictView.viewPos
+
+	if rowNum == 0 {
+		viewPos.SetActiveRowIndex(0)
+	} else if viewPos.ActiveRowIndex() >= rowNum {
+		viewPos.SetActiveRowIndex(rowNum - 1)
+	}
+}
+
+// Render generates and writes the conflict view to the provided window
+func (conflictView *ConflictView) Render(win RenderWindow) (err error) {
+	conflictView.lock.Lock()
+	defer conflictView.lock.Unlock()
+
+	log.Debug("Rendering ConflictView")
+
+	conflictView.viewDimension = win.ViewDimensions()
+
+	rowNum := uint(len(conflictView.renderedRows))
+	rows := win.Rows() - 2
+
+	viewPos := conflictView.viewPos
+	viewPos.DetermineViewStartRow(rows, rowNum, uint(conflictView.config.GetInt(CfScrollOff)))
+	renderedRowIndex := viewPos.ViewStartRowIndex()
+	startColumn := viewPos.ViewStartColumn()
+
+	for rowIndex := uint(0); rowIndex < rows && renderedRowIndex < rowNum; rowIndex++ {
+		renderedRow := conflictView.renderedRows[renderedRowIndex]
+		themeComponentID := CmpConflictviewFile
+
+		if renderedRow.conflictLine != nil {
+			themeComponentID = conflictLineThemeComponents[renderedRow.conflictLine.lineType]
+		}
+
+		if err = win.SetRow(rowIndex+1, startColumn, themeComponentID, "%v", conflictView.rowText(renderedRow)); err != nil {
+			return
+		}
+
+		renderedRowIndex++
+	}
+
+	if rowNum == 0 {
+		if err = win.SetRow(2, startColumn, CmpNone, "   No conflicts"); err != nil {
+			return
+		}
+	} else if err = win.SetSelectedRow(viewPos.SelectedRowIndex()+1, conflictView.active); err != nil {
+		return
+	}
+
+	win.DrawBorder()
+
+	title := "Conflicts"
+	if conflictView.repoState != "" {
+		title = fmt.Sprintf("Conflicts (%v)", conflictView.repoState)
+	}
+
+	if err = win.SetTitle(CmpConflictviewTitle, "%v", title); err != nil {
+		return
+	}
+
+	if rowNum > 0 {
+		conflictIndex := conflictView.renderedRows[viewPos.ActiveRowIndex()].conflictIndex
+
+		if err = win.SetFooter(CmpConflictviewFooter, "Conflict %v of %v", conflictIndex+1, len(conflictView.conflicts)); err != nil {
+			return
+		}
+	}
+
+	err = conflictView.RenderSearchHighlight(win)
+
+	return
+}
+
+func (conflictView *ConflictView) rowText(renderedRow renderedConflictRow) string {
+	if renderedRow.conflictLine != nil {
+		return fmt.Sprintf("     %v", renderedRow.conflictLine.line)
+	}
+
+	conflict := conflictView.conflicts[renderedRow.conflictIndex]
+	expandChar := "+"
+	if conflictView.expanded[conflict.path] {
+		expandChar = "-"
+	}
+
+	return fmt.Sprintf("  [%v] %v (%v)", expandChar, conflict.path, conflict.Description())
+}
+
+// RenderHelpBar renders key binding help for the conflict view
+func (conflictView *ConflictView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
+	RenderKeyBindingHelp(conflictView.ViewID(), lineBuilder, []ActionMessage{
+		{action: ActionSelect, message: "Expand/Collapse"},
+		{action: ActionResolveOurs, message: "Use ours"},
+		{action: ActionResolveTheirs, message: "Use theirs"},
+		{action: ActionMergeTool, message: "Merge tool"},
+	})
+
+	return
+}
+
+// ExternalCommandContext returns the path of the selected conflicted file
+func (conflictView *ConflictView) ExternalCommandContext() map[string]string {
+	conflictView.lock.Lock()
+	defer conflictView.lock.Unlock()
+
+	context := map[string]string{}
+
+	if conflict, exists := conflictView.selectedConflict(); exists {
+		context[ecFile] = conflict.path
+	}
+
+	return context
+}
+
+// HandleEvent does nothing
+func (conflictView *ConflictView) HandleEvent(event Event) (err error) {
+	return
+}
+
+// OnActiveChange updates whether this view is currently active
+func (conflictView *ConflictView) OnActiveChange(active bool) {
+	log.Debugf("ConflictView active: %v", active)
+	conflictView.lock.Lock()
+	defer conflictView.lock.Unlock()
+
+	conflictView.active = active
+}
+
+// ViewID returns the ViewID for the conflict view
+func (conflictView *ConflictView) ViewID() ViewID {
+	return ViewConflict
+}
+
+// Line returns the rendered line at the specified index
+func (conflictView *ConflictView) Line(lineIndex uint) (line string) {
+	conflictView.lock.Lock()
+	defer conflictView.lock.Unlock()
+
+	rowNum := uint(len(conflictView.renderedRows))
+	if lineIndex >= rowNum {
+		log.Errorf("Invalid lineIndex: %v >= %v", lineIndex, rowNum)
+		return
+	}
+
+	return conflictView.rowText(conflictView.renderedRows[lineIndex])
+}
+
+// LineNumber returns the number of rendered rows in the view
+func (conflictView *ConflictView) LineNumber() (lineNumber uint) {
+	conflictView.lock.Lock()
+	defer conflictView.lock.Unlock()
+
+	return uint(len(conflictView.renderedRows))
+}
+
+// HandleAction checks if the conflict view supports this action and if it does executes it
+func (conflictView *ConflictView) HandleAction(action Action) (err error) {
+	conflictView.lock.Lock()
+	defer conflictView.lock.Unlock()
+
+	if handler, ok := conflictView.handlers[action.ActionType]; ok {
+		log.Debugf("ConflictView handling action %v", action)
+		err = handler(conflictView, action)
+	} else {
+		_, err = conflictView.HandleListAction(action, uint(len(conflictView.renderedRows)))
+	}
+
+	return
+}
+
+func (conflictView *ConflictView) selectedConflict() (conflict *Conflict, exists bool) {
+	activeRowIndex := conflictView.viewPos.ActiveRowIndex()
+
+	if activeRowIndex >= uint(len(conflictView.renderedRows)) {
+		return
+	}
+
+	return conflictView.conflicts[conflictView.renderedRows[activeRowIndex].conflictIndex], true
+}
+
+func toggleConflictExpanded(conflictView *ConflictView, action Action) (err error) {
+	conflict, exists := conflictView.selectedConflict()
+	if !exists {
+		return
+	}
+
+	conflictView.expanded[conflict.path] = !conflictView.expanded[conflict.path]
+	log.Debugf("Setting conflict %v to expanded %v", conflict.path, conflictView.expanded[conflict.path])
+
+	conflictView.selectConflictHeader(conflict.path)
+	conflictView.generateRenderedRows()
+	conflictView.channels.UpdateDisplay()
+
+	return
+}
+
+// selectConflictHeader selects the header row of the conflicted file with the provided path if it exists
+func (conflictView *ConflictView) selectConflictHeader(path string) {
+	for rowIndex, renderedRow := range conflictView.renderedRows {
+		if renderedRow.conflictLine == nil && conflictView.conflicts[renderedRow.conflictIndex].path == path {
+			conflictView.viewPos.SetActiveRowIndex(uint(rowIndex))
+			return
+		}
+	}
+}
+
+func resolveConflictUsingOurs(conflictView *ConflictView, action Action) error {
+	return conflictView.resolveConflict(action, CrOurs)
+}
+
+func resolveConflictUsingTheirs(conflictView *ConflictView, action Action) error {
+	return conflictView.resolveConflict(action, CrTheirs)
+}
+
+// resolveConflict requests confirmation before resolving the selected conflicted file using the chosen side.
+// The confirmed action contains the conflict to resolve as its argument
+func (conflictView *ConflictView) resolveConflict(action Action, resolution ConflictResolution) (err error) {
+	if len(action.Args) == 0 {
+		conflict, exists := conflictView.selectedConflict()
+		if !exists {
+			return
+		}
+
+		conflictView.channels.Confirm(fmt.Sprintf("Resolve %v using %v", conflict.path, resolution), Action{
+			ActionType: action.ActionType,
+			Args:       []interface{}{conflict},
+		})
+
+		return
+	}
+
+	conflict, ok := action.Args[0].(*Conflict)
+	if !ok {
+		return fmt.Errorf("Expected conflict argument to have type *Conflict but got %T", action.Args[0])
+	}
+
+	if err = conflictView.repoData.ResolveConflict(conflict, resolution); err != nil {
+		return
+	}
+
+	conflictView.channels.ReportSuccess("Resolved %v using %v", conflict.path, resolution)
+
+	return
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 )
@@ -35,6 +36,7 @@ type ViewLayoutData struct {
 	orientation     ContainerOrientation
 	activeViewIndex uint
 	childViewNum    uint
+	childViewIDs    []ViewID
 }
 
 // ChildViewPositionCalculator calculates the child layout data for the view
@@ -44,6 +46,23 @@ type ChildViewPositionCalculator interface {
 
 type containerViewHandler func(*ContainerView, Action) error
 
+var focusDirections = map[ActionType]FocusDirection{
+	ActionFocusLeftView:  FocusLeft,
+	ActionFocusRightView: FocusRight,
+	ActionFocusUpView:    FocusUp,
+	ActionFocusDownView:  FocusDown,
+}
+
+type focusCandidate struct {
+	rect FocusRect
+	path []uint
+}
+
+type hiddenChildView struct {
+	childView  AbstractView
+	childIndex int
+}
+
 // ContainerView is a container with no visual presence that manages the
 // layout of its child views
 type ContainerView struct {
@@ -59,6 +78,9 @@ type ContainerView struct {
 	childViewPositionCalculator ChildViewPositionCalculator
 	viewID                      ViewID
 	fullScreen                  bool
+	active                      bool
+	hiddenChildViews            []hiddenChildView
+	renderedChildViews          map[AbstractView]bool
 	lock                        sync.Mutex
 }
 
@@ -71,12 +93,18 @@ func NewContainerView(channels *Channels, config Config) *ContainerView {
 		viewID:      ViewContainer,
 		viewWins:    make(map[WindowView]*Window),
 		handlers: map[ActionType]containerViewHandler{
-			ActionNextView:         nextContainerChildView,
-			ActionPrevView:         prevContainerChildView,
-			ActionFullScreenView:   toggleFullScreenChildView,
-			ActionToggleViewLayout: toggleViewOrientation,
-			ActionSplitView:        splitView,
-			ActionRemoveView:       removeView,
+			ActionNextView:             nextContainerChildView,
+			ActionPrevView:             prevContainerChildView,
+			ActionFocusLeftView:        moveFocusInDirection,
+			ActionFocusRightView:       moveFocusInDirection,
+			ActionFocusUpView:          moveFocusInDirection,
+			ActionFocusDownView:        moveFocusInDirection,
+			ActionFullScreenView:       toggleFullScreenChildView,
+			ActionToggleViewLayout:     toggleViewOrientation,
+			ActionToggleViewVisibility: toggleChildViewVisibility,
+			ActionToggleRefView:        toggleChildViewVisibility,
+			ActionSplitView:            splitView,
+			ActionRemoveView:           removeView,
 		},
 	}
 
@@ -116,6 +144,24 @@ func (containerView *ContainerView) addChildView(newView AbstractView) {
 	}
 }
 
+// SetChildViews replaces the child views of this container with the provided views
+func (containerView *ContainerView) SetChildViews(newViews ...AbstractView) {
+	containerView.lock.Lock()
+	defer containerView.lock.Unlock()
+
+	containerView.childViews = nil
+	containerView.hiddenChildViews = nil
+	containerView.viewWins = make(map[WindowView]*Window)
+	containerView.activeViewIndex = 0
+	containerView.fullScreen = false
+
+	for _, newView := range newViews {
+		containerView.addChildView(newView)
+	}
+
+	containerView.onActiveChange(containerView.active)
+}
+
 // SetChildViewPositionCalculator sets the child layout calculator for this view
 func (containerView *ContainerView) SetChildViewPositionCalculator(childViewPositionCalculator ChildViewPositionCalculator) {
 	containerView.lock.Lock()
@@ -201,6 +247,8 @@ func (containerView *ContainerView) OnActiveChange(active bool) {
 }
 
 func (containerView *ContainerView) onActiveChange(active bool) {
+	containerView.active = active
+
 	for index, childView := range containerView.childViews {
 		if uint(index) == containerView.activeViewIndex {
 			childView.OnActiveChange(active)
@@ -244,11 +292,27 @@ func (containerView *ContainerView) RenderHelpBar(lineBuilder *LineBuilder) (err
 	return
 }
 
+// RenderStatusBar is proxied to the active child view
+func (containerView *ContainerView) RenderStatusBar(statusBarSegments *StatusBarSegments) (err error) {
+	containerView.lock.Lock()
+	if containerView.isEmpty() {
+		containerView.lock.Unlock()
+		return
+	}
+
+	childView := containerView.activeChildView()
+	containerView.lock.Unlock()
+
+	return RenderChildStatusBar(childView, statusBarSegments)
+}
+
 // Render determines the layout of all child views, renders them and returns the resulting windows
 func (containerView *ContainerView) Render(viewDimension ViewDimension) (wins []*Window, err error) {
 	containerView.lock.Lock()
 	defer containerView.lock.Unlock()
 
+	containerView.renderedChildViews = make(map[AbstractView]bool)
+
 	if containerView.isEmpty() {
 		wins = append(wins, containerView.renderEmptyView(viewDimension))
 		return
@@ -266,6 +330,10 @@ func (containerView *ContainerView) Render(viewDimension ViewDimension) (wins []
 		childViewNum:    uint(len(containerView.childViews)),
 	}
 
+	for _, childView := range containerView.childViews {
+		viewLayoutData.childViewIDs = append(viewLayoutData.childViewIDs, childView.ViewID())
+	}
+
 	childPositions := containerView.childViewPositionCalculator.CalculateChildViewPositions(&viewLayoutData)
 
 	for childViewIndex, childView := range containerView.childViews {
@@ -274,6 +342,8 @@ func (containerView *ContainerView) Render(viewDimension ViewDimension) (wins []
 			continue
 		}
 
+		containerView.renderedChildViews[childView] = true
+
 		switch view := childView.(type) {
 		case WindowView:
 			var win *Window
@@ -383,10 +453,14 @@ func (containerView *ContainerView) renderWindowView(childView WindowView, child
 	win.SetPosition(childPosition.startRow, childPosition.startCol)
 	win.Clear()
 
+	renderStartTime := time.Now()
+
 	if err := childView.Render(win); err != nil {
 		return nil, err
 	}
 
+	renderStats.Record(childView.ViewID(), time.Since(renderStartTime))
+
 	return win, nil
 }
 
@@ -580,22 +654,150 @@ func prevContainerChildView(containerView *ContainerView, action Action) (err er
 	return
 }
 
-func toggleFullScreenChildView(containerView *ContainerView, action Action) (err error) {
-	containerView.fullScreen = !containerView.fullScreen
+func moveFocusInDirection(containerView *ContainerView, action Action) (err error) {
+	direction, ok := focusDirections[action.ActionType]
+	if !ok {
+		return fmt.Errorf("Unsupported focus action: %v", action.ActionType)
+	}
 
-	for _, childView := range containerView.childViews {
-		if _, isContainerView := childView.(WindowViewCollection); isContainerView {
-			if err = childView.HandleAction(action); err != nil {
-				break
+	candidates := containerView.focusCandidates(nil)
+	activePath := containerView.activeChildPath()
+	currentIndex := -1
+
+	var rects []FocusRect
+	for candidateIndex, candidate := range candidates {
+		rects = append(rects, candidate.rect)
+
+		if equalChildPaths(candidate.path, activePath) {
+			currentIndex = candidateIndex
+		}
+	}
+
+	if currentIndex < 0 {
+		log.Debug("Unable to determine position of active view")
+		return
+	}
+
+	if targetIndex, found := FindFocusTarget(rects[currentIndex], rects, direction); found {
+		log.Debugf("Moving focus to view at path %v", candidates[targetIndex].path)
+		containerView.setActiveChildPath(candidates[targetIndex].path)
+		containerView.onActiveChange(true)
+		containerView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+// focusCandidates returns the position of each window view displayed when this container
+// was last rendered along with the path of child indexes leading to it
+func (containerView *ContainerView) focusCandidates(parentPath []uint) (candidates []focusCandidate) {
+	for childIndex, childView := range containerView.childViews {
+		if !containerView.renderedChildViews[childView] {
+			continue
+		}
+
+		path := append(append([]uint(nil), parentPath...), uint(childIndex))
+
+		switch view := childView.(type) {
+		case *ContainerView:
+			view.lock.Lock()
+			candidates = append(candidates, view.focusCandidates(path)...)
+			view.lock.Unlock()
+		case WindowView:
+			if win, ok := containerView.viewWins[view]; ok {
+				candidates = append(candidates, focusCandidate{
+					rect: FocusRect{
+						startRow: win.startRow,
+						startCol: win.startCol,
+						rows:     win.rows,
+						cols:     win.cols,
+					},
+					path: path,
+				})
 			}
 		}
 	}
 
+	return
+}
+
+func (containerView *ContainerView) activeChildPath() (path []uint) {
+	if containerView.isEmpty() {
+		return
+	}
+
+	path = []uint{containerView.activeViewIndex}
+
+	if childContainerView, isContainerView := containerView.activeChildView().(*ContainerView); isContainerView {
+		childContainerView.lock.Lock()
+		path = append(path, childContainerView.activeChildPath()...)
+		childContainerView.lock.Unlock()
+	}
+
+	return
+}
+
+func (containerView *ContainerView) setActiveChildPath(path []uint) {
+	if len(path) == 0 || path[0] >= uint(len(containerView.childViews)) {
+		return
+	}
+
+	containerView.activeViewIndex = path[0]
+
+	if childContainerView, isContainerView := containerView.activeChildView().(*ContainerView); isContainerView {
+		childContainerView.lock.Lock()
+		childContainerView.setActiveChildPath(path[1:])
+		childContainerView.lock.Unlock()
+	}
+}
+
+func equalChildPaths(path1, path2 []uint) bool {
+	if len(path1) != len(path2) {
+		return false
+	}
+
+	for index := range path1 {
+		if path1[index] != path2[index] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func toggleFullScreenChildView(containerView *ContainerView, action Action) (err error) {
+	fullScreen := !containerView.fullScreen
+	containerView.setFullScreen(fullScreen)
+
+	if fullScreen {
+		containerView.channels.ReportStatus("Showing active view full screen")
+	} else {
+		containerView.channels.ReportStatus("Restored view layout")
+	}
+
 	containerView.channels.UpdateDisplay()
 
 	return
 }
 
+// SetFullScreen sets whether this container and all child containers display only their active child view
+func (containerView *ContainerView) SetFullScreen(fullScreen bool) {
+	containerView.lock.Lock()
+	defer containerView.lock.Unlock()
+
+	containerView.setFullScreen(fullScreen)
+}
+
+func (containerView *ContainerView) setFullScreen(fullScreen bool) {
+	containerView.fullScreen = fullScreen
+
+	for _, childView := range containerView.childViews {
+		if childContainerView, isContainerView := childView.(*ContainerView); isContainerView {
+			childContainerView.SetFullScreen(fullScreen)
+		}
+	}
+}
+
 func toggleViewOrientation(containerView *ContainerView, action Action) (err error) {
 	if containerView.isEmpty() {
 		return
@@ -617,6 +819,115 @@ func toggleViewOrientation(containerView *ContainerView, action Action) (err err
 	return
 }
 
+func toggleChildViewVisibility(containerView *ContainerView, action Action) (err error) {
+	viewID := ViewRef
+
+	if action.ActionType == ActionToggleViewVisibility {
+		if len(action.Args) == 0 {
+			return fmt.Errorf("Expected view argument")
+		}
+
+		var ok bool
+		if viewID, ok = action.Args[0].(ViewID); !ok {
+			return fmt.Errorf("Expected view argument of type ViewID but got type %T", action.Args[0])
+		}
+	}
+
+	if containerView.toggleChildViewVisibility(viewID) {
+		containerView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+// toggleChildViewVisibility hides all visible child views with the provided id.
+// If there are none then any hidden child views with the provided id are shown.
+// Child containers are processed in the same way. Returns true if any view was hidden or shown
+func (containerView *ContainerView) toggleChildViewVisibility(viewID ViewID) (toggled bool) {
+	for _, childView := range containerView.childViews {
+		if childContainerView, isContainerView := childView.(*ContainerView); isContainerView {
+			childContainerView.lock.Lock()
+			toggled = childContainerView.toggleChildViewVisibility(viewID) || toggled
+			childContainerView.lock.Unlock()
+		}
+	}
+
+	hidden := false
+
+	for childIndex := len(containerView.childViews) - 1; childIndex >= 0; childIndex-- {
+		if containerView.childViews[childIndex].ViewID() != viewID {
+			continue
+		}
+
+		if len(containerView.childViews) == 1 {
+			containerView.channels.ReportError(fmt.Errorf("Unable to hide the only view in the container"))
+			break
+		}
+
+		containerView.hideChildView(childIndex)
+		hidden = true
+	}
+
+	if hidden {
+		containerView.onActiveChange(containerView.active)
+		return true
+	}
+
+	shown := false
+	var hiddenChildViews []hiddenChildView
+
+	for _, hiddenView := range containerView.hiddenChildViews {
+		if hiddenView.childView.ViewID() == viewID {
+			containerView.showChildView(hiddenView)
+			shown = true
+		} else {
+			hiddenChildViews = append(hiddenChildViews, hiddenView)
+		}
+	}
+
+	containerView.hiddenChildViews = hiddenChildViews
+
+	if shown {
+		containerView.onActiveChange(containerView.active)
+	}
+
+	return toggled || shown
+}
+
+func (containerView *ContainerView) hideChildView(childIndex int) {
+	childView := containerView.childViews[childIndex]
+	log.Debugf("Hiding child view %T at index %v", childView, childIndex)
+
+	containerView.hiddenChildViews = append(containerView.hiddenChildViews, hiddenChildView{
+		childView:  childView,
+		childIndex: childIndex,
+	})
+
+	containerView.childViews = append(containerView.childViews[:childIndex], containerView.childViews[childIndex+1:]...)
+
+	if activeViewIndex := int(containerView.activeViewIndex); activeViewIndex > childIndex ||
+		(activeViewIndex == childIndex && activeViewIndex >= len(containerView.childViews)) {
+		containerView.activeViewIndex--
+	}
+}
+
+func (containerView *ContainerView) showChildView(hiddenView hiddenChildView) {
+	childIndex := hiddenView.childIndex
+	if childIndex > len(containerView.childViews) {
+		childIndex = len(containerView.childViews)
+	}
+
+	log.Debugf("Showing child view %T at index %v", hiddenView.childView, childIndex)
+
+	containerView.childViews = append(containerView.childViews, nil)
+	copy(containerView.childViews[childIndex+1:], containerView.childViews[childIndex:])
+	containerView.childViews[childIndex] = hiddenView.childView
+
+	if int(containerView.activeViewIndex) >= childIndex {
+		containerView.activeViewIndex++
+	}
+}
+
 func splitView(containerView *ContainerView, action Action) (err error) {
 	args := action.Args
 
@@ -641,6 +952,11 @@ func splitView(containerView *ContainerView, action Action) (err error) {
 
 	switch childView := containerView.activeChildView().(type) {
 	case WindowView:
+		if containerView.fullScreen {
+			log.Debug("Restoring view layout before splitting view")
+			containerView.setFullScreen(false)
+		}
+
 		if len(containerView.childViews) < 2 {
 			containerView.addChildView(newView)
 			containerView.orientation = orientation
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	csSelectRef          = "select-ref"
+	csSelectCommit       = "select-commit"
+	csFileHistory        = "file-history"
+	csCommand            = "command"
+	csResponseOK         = "OK"
+	csFileHistoryCommand = "git log --follow --patch -- "
+)
+
+type controlSocketHandler func(*ControlSocket, string) error
+
+// ControlSocket listens on a unix socket for requests from external tools.
+// Each request is a single line containing a command name followed by its argument.
+// A response of either OK or ERROR followed by a description is returned for each request
+type ControlSocket struct {
+	path     string
+	listener net.Listener
+	repoData RepoData
+	channels *Channels
+	handlers map[string]controlSocketHandler
+	lock     sync.Mutex
+}
+
+// NewControlSocket creates a new instance
+func NewControlSocket(path string, repoData RepoData, channels *Channels) *ControlSocket {
+	return &ControlSocket{
+		path:     path,
+		repoData: repoData,
+		channels: channels,
+		handlers: map[string]controlSocketHandler{
+			csSelectRef:    selectControlSocketRef,
+			csSelectCommit: selectControlSocketCommit,
+			csFileHistory:  showControlSocketFileHistory,
+			csCommand:      evaluateControlSocketCommand,
+		},
+	}
+}
+
+// Initialise creates the unix socket
+func (controlSocket *ControlSocket) Initialise() (err error) {
+	log.Infof("Creating control socket %v", controlSocket.path)
+
+	if err = removeStaleControlSocket(controlSocket.path); err != nil {
+		return
+	}
+
+	if controlSocket.listener, err = net.Listen("unix", controlSocket.path); err != nil {
+		err = fmt.Errorf("Unable to create control socket %v: %v", controlSocket.path, err)
+	}
+
+	return
+}
+
+// removeStaleControlSocket removes a socket file left behind by a GRV process which did not exit cleanly.
+// A socket which still accepts connections is in use and is not removed
+func removeStaleControlSocket(path string) (err error) {
+	if _, err = os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+
+		return
+	}
+
+	if conn, dialErr := net.Dial("unix", path); dialErr == nil {
+		conn.Close()
+		return fmt.Errorf("Control socket %v is in use by another process", path)
+	}
+
+	log.Infof("Removing stale control socket %v", path)
+
+	if err = os.Remove(path); err != nil {
+		err = fmt.Errorf("Unable to remove stale control socket %v: %v", path, err)
+	}
+
+	return
+}
+
+// Serve accepts connections until the control socket is closed
+func (controlSocket *ControlSocket) Serve() {
+	defer RecoverPanic()
+
+	for {
+		conn, err := controlSocket.listener.Accept()
+		if err != nil {
+			log.Infof("Control socket no longer accepting connections: %v", err)
+			return
+		}
+
+		go controlSocket.handleConnection(conn)
+	}
+}
+
+// Close stops the control socket accepting connections and removes the socket file
+func (controlSocket *ControlSocket) Close() {
+	log.Infof("Closing control socket %v", controlSocket.path)
+
+	if err := controlSocket.listener.Close(); err != nil {
+		log.Errorf("Error when closing control socket: %v", err)
+	}
+
+	if err := os.Remove(controlSocket.path); err != nil && !os.IsNotExist(err) {
+		log.Errorf("Unable to remove control socket %v: %v", controlSocket.path, err)
+	}
+}
+
+func (controlSocket *ControlSocket) handleConnection(conn net.Conn) {
+	defer RecoverPanic()
+	defer conn.Close()
+
+	scanner := bufio.NewScanner(conn)
+
+	for scanner.Scan() {
+		request := scanner.Text()
+		log.Debugf("Control socket received request: %v", request)
+
+		response := csResponseOK
+		if err := controlSocket.processRequest(request); err != nil {
+			log.Infof("Control socket request \"%v\" failed: %v", request, err)
+			response = fmt.Sprintf("ERROR %v", err)
+		}
+
+		if _, err := fmt.Fprintln(conn, response); err != nil {
+			log.Errorf("Unable to write control socket response: %v", err)
+			return
+		}
+	}
+}
+
+func (controlSocket *ControlSocket) processRequest(request string) (err error) {
+	command, argument, err := parseControlSocketRequest(request)
+	if err != nil {
+		return
+	}
+
+	handler, ok := controlSocket.handlers[command]
+	if !ok {
+		return fmt.Errorf("Invalid command: %v", command)
+	}
+
+	controlSocket.lock.Lock()
+	defer controlSocket.lock.Unlock()
+
+	return handler(controlSocket, argument)
+}
+
+func parseControlSocketRequest(request string) (command, argument string, err error) {
+	request = strings.TrimSpace(request)
+	if request == "" {
+		err = fmt.Errorf("Empty request")
+		return
+	}
+
+	fields := strings.SplitN(request, " ", 2)
+	command = fields[0]
+
+	if len(fields) == 2 {
+		argument = strings.TrimSpace(fields[1])
+	}
+
+	if argument == "" {
+		err = fmt.Errorf("No argument provided for command %v", command)
+	}
+
+	return
+}
+
+func selectControlSocketRef(controlSocket *ControlSocket, refName string) (err error) {
+	ref, err := controlSocket.repoData.Ref(refName)
+	if err != nil {
+		return fmt.Errorf("Invalid ref: %v", refName)
+	}
+
+	controlSocket.addTab(ref.Shorthand(), ViewCommit, refName)
+
+	return
+}
+
+func selectControlSocketCommit(controlSocket *ControlSocket, oid string) (err error) {
+	if !hexRegexp.MatchString(oid) {
+		return fmt.Errorf("Invalid oid: %v", oid)
+	}
+
+	commit, err := controlSocket.repoData.CommitByOid(oid)
+	if err != nil {
+		return fmt.Errorf("Invalid oid: %v", oid)
+	}
+
+	controlSocket.addTab(commit.oid.ShortID(), ViewCommit, oid)
+
+	return
+}
+
+func showControlSocketFileHistory(controlSocket *ControlSocket, path string) (err error) {
+	controlSocket.addTab(path, ViewCommandOutput, csFileHistoryCommand+shellQuote(path))
+	return
+}
+
+// evaluateControlSocketCommand evaluates the configuration command on the action handler goroutine,
+// as configuration is not safe to modify concurrently, and waits for the result
+func evaluateControlSocketCommand(controlSocket *ControlSocket, command string) (err error) {
+	resultCh := make(chan []error, 1)
+
+	controlSocket.channels.DoAction(Action{
+		ActionType: ActionEvaluateConfigCommand,
+		Args: []interface{}{
+			ActionEvaluateConfigCommandArgs{
+				command:  command,
+				resultCh: resultCh,
+			},
+		},
+	})
+
+	if errors := <-resultCh; len(errors) > 0 {
+		err = errors[0]
+	}
+
+	return
+}
+
+func (controlSocket *ControlSocket) addTab(tabName string, viewID ViewID, viewArg string) {
+	channels := controlSocket.channels
+
+	channels.DoAction(Action{
+		ActionType: ActionNewTab,
+		Args:       []interface{}{tabName},
+	})
+
+	channels.DoAction(Action{
+		ActionType: ActionAddView,
+		Args: []interface{}{
+			ActionAddViewArgs{
+				CreateViewArgs: CreateViewArgs{
+					viewID:   viewID,
+					viewArgs: []interface{}{viewArg},
+				},
+			},
+		},
+	})
+}
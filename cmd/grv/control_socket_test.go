@@ -0,0 +1,90 @@
+package main
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestControlSocketRequestIsParsed(t *testing.T) {
+	var requestTests = []struct {
+		request          string
+		expectedCommand  string
+		expectedArgument string
+	}{
+		{
+			request:          "select-ref master",
+			expectedCommand:  csSelectRef,
+			expectedArgument: "master",
+		},
+		{
+			request:          "  file-history  path/to/file with spaces.go ",
+			expectedCommand:  csFileHistory,
+			expectedArgument: "path/to/file with spaces.go",
+		},
+		{
+			request:          "command addview CommitView master",
+			expectedCommand:  csCommand,
+			expectedArgument: "addview CommitView master",
+		},
+	}
+
+	for _, requestTest := range requestTests {
+		command, argument, err := parseControlSocketRequest(requestTest.request)
+
+		if err != nil {
+			t.Errorf("parseControlSocketRequest failed with error %v", err)
+		} else if command != requestTest.expectedCommand {
+			t.Errorf("Command does not match expected value. Expected: %v, Actual: %v", requestTest.expectedCommand, command)
+		} else if argument != requestTest.expectedArgument {
+			t.Errorf("Argument does not match expected value. Expected: %v, Actual: %v", requestTest.expectedArgument, argument)
+		}
+	}
+}
+
+func TestErrorIsReturnedForInvalidControlSocketRequest(t *testing.T) {
+	var requests = []string{
+		"",
+		"   ",
+		"select-ref",
+		"select-commit   ",
+	}
+
+	for _, request := range requests {
+		if _, _, err := parseControlSocketRequest(request); err == nil {
+			t.Errorf("Expected error for request \"%v\" but none was returned", request)
+		}
+	}
+}
+
+func TestStaleControlSocketIsRemovedAndActiveSocketIsKept(t *testing.T) {
+	dir, err := ioutil.TempDir("", "grv-control-socket")
+	if err != nil {
+		t.Fatalf("Unable to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "grv.sock")
+
+	listener, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	if err != nil {
+		t.Fatalf("Unable to create socket: %v", err)
+	}
+
+	if err = removeStaleControlSocket(path); err == nil {
+		t.Errorf("Expected error as socket is in use")
+	}
+
+	listener.SetUnlinkOnClose(false)
+	listener.Close()
+
+	if err = removeStaleControlSocket(path); err != nil {
+		t.Errorf("Unexpected error removing stale socket: %v", err)
+	}
+
+	if _, err = os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected stale socket to have been removed")
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+var conventionalCommitRegex = regexp.MustCompile(`^([a-zA-Z]+)(\(([^()]*)\))?(!)?:\s`)
+
+// ConventionalCommit contains the type and scope parsed from a conventional commit summary,
+// e.g. feat(parser)!: Add support for arrays
+type ConventionalCommit struct {
+	commitType string
+	scope      string
+	breaking   bool
+	prefix     string
+}
+
+// parseConventionalCommit parses the conventional commit prefix of the summary
+func parseConventionalCommit(summary string) (conventionalCommit *ConventionalCommit, ok bool) {
+	matches := conventionalCommitRegex.FindStringSubmatch(summary)
+	if matches == nil {
+		return
+	}
+
+	return &ConventionalCommit{
+		commitType: strings.ToLower(matches[1]),
+		scope:      matches[3],
+		breaking:   matches[4] != "",
+		prefix:     strings.TrimRightFunc(matches[0], func(char rune) bool { return char == ' ' || char == '\t' }),
+	}, true
+}
+
+// ThemeComponentID returns the theme component the commit type prefix is displayed with
+func (conventionalCommit *ConventionalCommit) ThemeComponentID() ThemeComponentID {
+	switch conventionalCommit.commitType {
+	case "feat":
+		return CmpCommitviewFeatCommitType
+	case "fix":
+		return CmpCommitviewFixCommitType
+	}
+
+	return CmpCommitviewCommitType
+}
+
+// conventionalCommitField returns the value of the conventional commit field of the summary
+func conventionalCommitField(summary string, field func(*ConventionalCommit) string) string {
+	if conventionalCommit, ok := parseConventionalCommit(summary); ok {
+		return field(conventionalCommit)
+	}
+
+	return ""
+}
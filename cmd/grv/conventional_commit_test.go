@@ -0,0 +1,51 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestConventionalCommitPrefixIsParsed(t *testing.T) {
+	tests := []struct {
+		summary            string
+		expectedCommitType string
+		expectedScope      string
+		expectedBreaking   bool
+		expectedPrefix     string
+	}{
+		{summary: "feat: Add the thing", expectedCommitType: "feat", expectedPrefix: "feat:"},
+		{summary: "fix(parser): Handle empty input", expectedCommitType: "fix", expectedScope: "parser", expectedPrefix: "fix(parser):"},
+		{summary: "Chore!: Drop support for old config", expectedCommitType: "chore", expectedBreaking: true, expectedPrefix: "Chore!:"},
+	}
+
+	for _, test := range tests {
+		conventionalCommit, ok := parseConventionalCommit(test.summary)
+
+		switch {
+		case !ok:
+			t.Errorf("Expected %q to be parsed as a conventional commit", test.summary)
+		case conventionalCommit.commitType != test.expectedCommitType:
+			t.Errorf("Commit type does not match expected value. Expected: %v, Actual: %v", test.expectedCommitType, conventionalCommit.commitType)
+		case conventionalCommit.scope != test.expectedScope:
+			t.Errorf("Scope does not match expected value. Expected: %v, Actual: %v", test.expectedScope, conventionalCommit.scope)
+		case conventionalCommit.breaking != test.expectedBreaking:
+			t.Errorf("Breaking does not match expected value. Expected: %v, Actual: %v", test.expectedBreaking, conventionalCommit.breaking)
+		case conventionalCommit.prefix != test.expectedPrefix:
+			t.Errorf("Prefix does not match expected value. Expected: %v, Actual: %v", test.expectedPrefix, conventionalCommit.prefix)
+		}
+	}
+}
+
+func TestSummariesWithoutConventionalCommitPrefixAreNotParsed(t *testing.T) {
+	summaries := []string{
+		"Fix the thing",
+		"Merge branch 'master'",
+		"Revert \"feat: Add the thing\"",
+		"fix:missing space",
+	}
+
+	for _, summary := range summaries {
+		if _, ok := parseConventionalCommit(summary); ok {
+			t.Errorf("Expected %q not to be parsed as a conventional commit", summary)
+		}
+	}
+}
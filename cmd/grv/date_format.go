@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	dfISOLayout   = "2006-01-02 15:04:05 -0700"
+	dfRFCLayout   = time.RFC1123Z
+	dfShortLayout = "2006-01-02 15:04"
+)
+
+// DateFormat describes how dates are displayed
+type DateFormat int
+
+// The set of date formats
+const (
+	DfShort DateFormat = iota
+	DfISO
+	DfRFC
+	DfRelative
+	DfCustom
+)
+
+var dateFormatNames = map[string]DateFormat{
+	"short":    DfShort,
+	"iso":      DfISO,
+	"rfc":      DfRFC,
+	"relative": DfRelative,
+}
+
+var dateFormatLayouts = map[DateFormat]string{
+	DfShort: dfShortLayout,
+	DfISO:   dfISOLayout,
+	DfRFC:   dfRFCLayout,
+}
+
+// DateTimeZone describes which time zone dates are displayed in
+type DateTimeZone int
+
+// The set of date time zones
+const (
+	DtzAuthor DateTimeZone = iota
+	DtzLocal
+	DtzUTC
+)
+
+var dateTimeZoneNames = map[string]DateTimeZone{
+	"author": DtzAuthor,
+	"local":  DtzLocal,
+	"utc":    DtzUTC,
+}
+
+// ParseDateFormat returns the date format with the provided name.
+// Any value which isn't the name of a date format is treated as a custom
+// layout and must be specified using the Go reference time Mon Jan 2 15:04:05 -0700 MST 2006
+func ParseDateFormat(value string) (dateFormat DateFormat, layout string, err error) {
+	if dateFormat, ok := dateFormatNames[strings.ToLower(value)]; ok {
+		return dateFormat, dateFormatLayouts[dateFormat], nil
+	}
+
+	if value == "" || time.Unix(0, 0).UTC().Format(value) == value {
+		err = fmt.Errorf("Invalid date format %v. Valid values are short, iso, rfc, relative "+
+			"or a custom layout using the reference time Mon Jan 2 15:04:05 -0700 MST 2006", value)
+		return
+	}
+
+	return DfCustom, value, nil
+}
+
+// ParseDateTimeZone returns the date time zone with the provided name
+func ParseDateTimeZone(name string) (dateTimeZone DateTimeZone, err error) {
+	dateTimeZone, ok := dateTimeZoneNames[strings.ToLower(name)]
+	if !ok {
+		err = fmt.Errorf("Invalid time zone %v. Valid values are author, local and utc", name)
+	}
+
+	return
+}
+
+// DateFormatter formats dates as specified by the dateformat and timezone config variables
+type DateFormatter struct {
+	dateFormat   DateFormat
+	layout       string
+	dateTimeZone DateTimeZone
+}
+
+// NewDateFormatter creates a new instance using the current config values
+func NewDateFormatter(config Config) *DateFormatter {
+	dateFormatter := &DateFormatter{
+		layout: dfShortLayout,
+	}
+
+	if dateFormat, layout, err := ParseDateFormat(config.GetString(CfDateFormat)); err == nil {
+		dateFormatter.dateFormat = dateFormat
+		dateFormatter.layout = layout
+	}
+
+	if dateTimeZone, err := ParseDateTimeZone(config.GetString(CfTimeZone)); err == nil {
+		dateFormatter.dateTimeZone = dateTimeZone
+	}
+
+	return dateFormatter
+}
+
+// Format returns the formatted representation of the provided date
+func (dateFormatter *DateFormatter) Format(dateTime time.Time) string {
+	if dateFormatter.dateFormat == DfRelative {
+		return formatRelativeDate(dateTime, time.Now())
+	}
+
+	switch dateFormatter.dateTimeZone {
+	case DtzLocal:
+		dateTime = dateTime.Local()
+	case DtzUTC:
+		dateTime = dateTime.UTC()
+	}
+
+	return dateTime.Format(dateFormatter.layout)
+}
+
+func formatRelativeDate(dateTime, now time.Time) string {
+	seconds := int64(now.Sub(dateTime) / time.Second)
+
+	if seconds < 0 {
+		return "in the future"
+	}
+
+	units := []struct {
+		name    string
+		seconds int64
+	}{
+		{name: "year", seconds: 365 * 24 * 60 * 60},
+		{name: "month", seconds: 30 * 24 * 60 * 60},
+		{name: "week", seconds: 7 * 24 * 60 * 60},
+		{name: "day", seconds: 24 * 60 * 60},
+		{name: "hour", seconds: 60 * 60},
+		{name: "minute", seconds: 60},
+		{name: "second", seconds: 1},
+	}
+
+	for _, unit := range units {
+		if count := seconds / unit.seconds; count > 0 {
+			if count == 1 {
+				return fmt.Sprintf("1 %v ago", unit.name)
+			}
+
+			return fmt.Sprintf("%v %vs ago", count, unit.name)
+		}
+	}
+
+	return "just now"
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDateFormat(t *testing.T) {
+	var dateFormatTests = []struct {
+		arg                string
+		expectedDateFormat DateFormat
+		expectedLayout     string
+		expectError        bool
+	}{
+		{
+			arg:                "short",
+			expectedDateFormat: DfShort,
+			expectedLayout:     dfShortLayout,
+		},
+		{
+			arg:                "ISO",
+			expectedDateFormat: DfISO,
+			expectedLayout:     dfISOLayout,
+		},
+		{
+			arg:                "relative",
+			expectedDateFormat: DfRelative,
+		},
+		{
+			arg:                "02/01/2006",
+			expectedDateFormat: DfCustom,
+			expectedLayout:     "02/01/2006",
+		},
+		{
+			arg:         "invalid",
+			expectError: true,
+		},
+		{
+			arg:         "",
+			expectError: true,
+		},
+	}
+
+	for _, dateFormatTest := range dateFormatTests {
+		dateFormat, layout, err := ParseDateFormat(dateFormatTest.arg)
+
+		if dateFormatTest.expectError {
+			if err == nil {
+				t.Errorf("Expected error for date format %q", dateFormatTest.arg)
+			}
+		} else if err != nil {
+			t.Errorf("Unexpected error for date format %q: %v", dateFormatTest.arg, err)
+		} else if dateFormat != dateFormatTest.expectedDateFormat || layout != dateFormatTest.expectedLayout {
+			t.Errorf("ParseDateFormat return value does not match expected value for %q. Expected: %v %q, Actual: %v %q",
+				dateFormatTest.arg, dateFormatTest.expectedDateFormat, dateFormatTest.expectedLayout, dateFormat, layout)
+		}
+	}
+}
+
+func TestDateFormatterTimeZone(t *testing.T) {
+	dateTime := time.Date(2018, time.March, 4, 23, 30, 0, 0, time.FixedZone("", 2*60*60))
+
+	var timeZoneTests = []struct {
+		dateTimeZone   DateTimeZone
+		expectedResult string
+	}{
+		{
+			dateTimeZone:   DtzAuthor,
+			expectedResult: "2018-03-04 23:30:00 +0200",
+		},
+		{
+			dateTimeZone:   DtzUTC,
+			expectedResult: "2018-03-04 21:30:00 +0000",
+		},
+	}
+
+	for _, timeZoneTest := range timeZoneTests {
+		dateFormatter := &DateFormatter{
+			dateFormat:   DfISO,
+			layout:       dfISOLayout,
+			dateTimeZone: timeZoneTest.dateTimeZone,
+		}
+
+		if actualResult := dateFormatter.Format(dateTime); actualResult != timeZoneTest.expectedResult {
+			t.Errorf("Formatted date does not match expected value. Expected: %v, Actual: %v", timeZoneTest.expectedResult, actualResult)
+		}
+	}
+}
+
+func TestFormatRelativeDate(t *testing.T) {
+	now := time.Date(2018, time.March, 4, 12, 0, 0, 0, time.UTC)
+
+	var relativeDateTests = []struct {
+		dateTime       time.Time
+		expectedResult string
+	}{
+		{
+			dateTime:       now,
+			expectedResult: "just now",
+		},
+		{
+			dateTime:       now.Add(-time.Minute),
+			expectedResult: "1 minute ago",
+		},
+		{
+			dateTime:       now.Add(-3 * time.Hour),
+			expectedResult: "3 hours ago",
+		},
+		{
+			dateTime:       now.AddDate(0, 0, -15),
+			expectedResult: "2 weeks ago",
+		},
+		{
+			dateTime:       now.AddDate(-2, 0, 0),
+			expectedResult: "2 years ago",
+		},
+		{
+			dateTime:       now.Add(time.Hour),
+			expectedResult: "in the future",
+		},
+	}
+
+	for _, relativeDateTest := range relativeDateTests {
+		if actualResult := formatRelativeDate(relativeDateTest.dateTime, now); actualResult != relativeDateTest.expectedResult {
+			t.Errorf("Relative date does not match expected value. Expected: %v, Actual: %v", relativeDateTest.expectedResult, actualResult)
+		}
+	}
+}
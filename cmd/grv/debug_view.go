@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	dvTitle = "Debug"
+)
+
+// DebugView displays diagnostic information useful when investigating performance problems.
+// The displayed values are refreshed each time the view is rendered
+type DebugView struct {
+	*PluginView
+	repoData RepoData
+	channels *Channels
+}
+
+// NewDebugView creates a new instance
+func NewDebugView(repoData RepoData, channels *Channels, config Config) *DebugView {
+	debugView := &DebugView{
+		repoData: repoData,
+		channels: channels,
+	}
+
+	debugView.PluginView = NewPluginView(dvTitle, debugView.generateLines, channels, config)
+
+	return debugView
+}
+
+// Render refreshes the diagnostic information and writes it to the provided window
+func (debugView *DebugView) Render(win RenderWindow) (err error) {
+	debugView.PluginView.lock.Lock()
+	debugView.PluginView.loadLines()
+	debugView.PluginView.lock.Unlock()
+
+	return debugView.PluginView.Render(win)
+}
+
+// ViewID returns the ViewID for the debug view
+func (debugView *DebugView) ViewID() ViewID {
+	return ViewDebug
+}
+
+func (debugView *DebugView) generateLines() (lines []string) {
+	log.Debug("Generating DebugView lines")
+
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+
+	lines = append(lines,
+		"Runtime",
+		fmt.Sprintf("  Goroutines: %v", runtime.NumGoroutine()),
+		fmt.Sprintf("  Heap allocated: %v KiB", memStats.HeapAlloc/1024),
+		fmt.Sprintf("  GC cycles: %v", memStats.NumGC),
+		"",
+		"Loaded commits",
+	)
+
+	lines = append(lines, debugView.commitSetLines()...)
+	lines = append(lines, "", "Render times")
+	lines = append(lines, renderTimeLines()...)
+	lines = append(lines, "", "Channel queue depths")
+
+	for _, queueDepth := range debugView.channels.QueueDepths() {
+		lines = append(lines, fmt.Sprintf("  %-8v %v/%v", queueDepth.name, queueDepth.depth, queueDepth.capacity))
+	}
+
+	return
+}
+
+func (debugView *DebugView) commitSetLines() (lines []string) {
+	commitSetStates := debugView.repoData.CommitSetStates()
+	if len(commitSetStates) == 0 {
+		return []string{"  None"}
+	}
+
+	var refNames []string
+	for refName := range commitSetStates {
+		refNames = append(refNames, refName)
+	}
+
+	sort.Strings(refNames)
+
+	for _, refName := range refNames {
+		commitSetState := commitSetStates[refName]
+		line := fmt.Sprintf("  %v: %v", refName, commitSetState.commitNum)
+
+		if commitSetState.loading {
+			line += " (loading)"
+		}
+
+		if commitSetState.filterState != nil {
+			line += fmt.Sprintf(" (%v filters)", commitSetState.filterState.filtersApplied)
+		}
+
+		lines = append(lines, line)
+	}
+
+	return
+}
+
+func renderTimeLines() (lines []string) {
+	stats := renderStats.Stats()
+	if len(stats) == 0 {
+		return []string{"  None"}
+	}
+
+	for _, viewRenderStats := range stats {
+		lines = append(lines, fmt.Sprintf("  %v: renders %v, last %v, avg %v, max %v",
+			viewName(viewRenderStats.viewID),
+			viewRenderStats.renderNum,
+			viewRenderStats.lastDuration,
+			viewRenderStats.AverageDuration(),
+			viewRenderStats.maxDuration,
+		))
+	}
+
+	return
+}
+
+func viewName(viewID ViewID) string {
+	for name, id := range viewIDNames {
+		if id == viewID {
+			return name
+		}
+	}
+
+	return fmt.Sprintf("View %v", viewID)
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DiffAlgorithm is the algorithm used to generate diffs
+type DiffAlgorithm int
+
+// The set of diff algorithms
+const (
+	DaMyers DiffAlgorithm = iota
+	DaPatience
+	DaHistogram
+	DaMinimal
+)
+
+var diffAlgorithmNames = map[string]DiffAlgorithm{
+	"myers":     DaMyers,
+	"patience":  DaPatience,
+	"histogram": DaHistogram,
+	"minimal":   DaMinimal,
+}
+
+// ParseDiffAlgorithm returns the diff algorithm with the provided name
+func ParseDiffAlgorithm(name string) (diffAlgorithm DiffAlgorithm, err error) {
+	diffAlgorithm, ok := diffAlgorithmNames[strings.ToLower(name)]
+	if !ok {
+		err = fmt.Errorf("Invalid diff algorithm %v. Valid values are myers, patience, histogram and minimal", name)
+	}
+
+	return
+}
+
+// String returns the name of the diff algorithm
+func (diffAlgorithm DiffAlgorithm) String() string {
+	for name, value := range diffAlgorithmNames {
+		if value == diffAlgorithm {
+			return name
+		}
+	}
+
+	return "myers"
+}
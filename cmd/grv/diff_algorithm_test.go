@@ -0,0 +1,19 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestDiffAlgorithmNamesCanBeParsed(t *testing.T) {
+	for _, diffAlgorithm := range []DiffAlgorithm{DaMyers, DaPatience, DaHistogram, DaMinimal} {
+		if parsedAlgorithm, err := ParseDiffAlgorithm(diffAlgorithm.String()); err != nil || parsedAlgorithm != diffAlgorithm {
+			t.Errorf("Unable to parse diff algorithm name %v: %v", diffAlgorithm, err)
+		}
+	}
+}
+
+func TestInvalidDiffAlgorithmReturnsError(t *testing.T) {
+	if _, err := ParseDiffAlgorithm("default"); err == nil {
+		t.Errorf("Expected error for invalid diff algorithm")
+	}
+}
@@ -0,0 +1,28 @@
+package main
+
+// DiffBase describes what the selected commit is compared against when generating its diff
+type DiffBase int
+
+// The set of diff bases
+const (
+	DbParent DiffBase = iota
+	DbWorkingTree
+	DbIndex
+	dbCount
+)
+
+var diffBaseDescriptions = map[DiffBase]string{
+	DbParent:      "against its parent",
+	DbWorkingTree: "against the working tree",
+	DbIndex:       "against the index",
+}
+
+// Description returns a description of what the commit is compared against
+func (diffBase DiffBase) Description() string {
+	return diffBaseDescriptions[diffBase]
+}
+
+// Next returns the base following this one, wrapping back round to the parent
+func (diffBase DiffBase) Next() DiffBase {
+	return (diffBase + 1) % dbCount
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// validateFilePattern returns an error if the file pattern is not a valid glob
+func validateFilePattern(pattern string) (err error) {
+	if _, err = path.Match(pattern, ""); err != nil {
+		err = fmt.Errorf("Invalid file pattern %v: %v", pattern, err)
+	}
+
+	return
+}
+
+// matchesFilePattern returns true if the file path matches the glob pattern.
+// As with gitignore patterns, a pattern without a slash is matched against the file name only
+func matchesFilePattern(pattern, filePath string) bool {
+	if !strings.Contains(pattern, "/") {
+		filePath = path.Base(filePath)
+	}
+
+	matched, _ := path.Match(pattern, filePath)
+	return matched
+}
+
+// diffFileFilter determines which lines of a diff are hidden by a file pattern.
+// The diff stats summary is replaced with one which only counts the files which remain visible
+type diffFileFilter struct {
+	hiddenLines  map[*diffLineData]bool
+	statsSummary *diffLineData
+	replacement  *diffLineData
+}
+
+// newDiffFileFilter hides the diff and diff stats lines of each file not matching the pattern
+func newDiffFileFilter(lines []*diffLineData, pattern string) *diffFileFilter {
+	filter := &diffFileFilter{
+		hiddenLines: make(map[*diffLineData]bool),
+	}
+
+	var files, insertions, deletions int
+	inFile, hidingFile := false, false
+	lastStatsIndex := -1
+
+	for lineIndex, diffLine := range lines {
+		diffLine.determineDiffLineType()
+
+		switch {
+		case diffLine.isFileHeader():
+			inFile = true
+			hidingFile = !matchesFilePattern(pattern, diffHeaderPath(diffLine.line))
+
+			if !hidingFile {
+				files++
+			}
+		case diffLine.lineType == dltGitDiffHeader:
+			inFile, hidingFile = false, false
+		case diffLine.lineType == dltDiffStatsFile:
+			lastStatsIndex = lineIndex
+		case !inFile || hidingFile:
+		case diffLine.lineType == dltLineAdded:
+			insertions++
+		case diffLine.lineType == dltLineRemoved:
+			deletions++
+		}
+
+		if hidingFile {
+			filter.hiddenLines[diffLine] = true
+		}
+	}
+
+	for lineIndex := 0; lineIndex <= lastStatsIndex; lineIndex++ {
+		if lines[lineIndex].lineType != dltDiffStatsFile {
+			continue
+		}
+
+		if fileDiffIndex, err := findStatsFileDiffIndex(lines, uint(lineIndex)); err == nil && filter.hiddenLines[lines[fileDiffIndex]] {
+			filter.hiddenLines[lines[lineIndex]] = true
+		}
+	}
+
+	if summaryIndex := lastStatsIndex + 1; lastStatsIndex != -1 && summaryIndex < len(lines) {
+		filter.statsSummary = lines[summaryIndex]
+		filter.replacement = &diffLineData{
+			line:     formatDiffStatsSummary(files, insertions, deletions),
+			lineType: dltNormal,
+		}
+	}
+
+	return filter
+}
+
+// displayedLine returns the line which should be displayed in place of the provided line, or nil if it is hidden
+func (filter *diffFileFilter) displayedLine(diffLine *diffLineData) *diffLineData {
+	switch {
+	case filter == nil:
+		return diffLine
+	case filter.hiddenLines[diffLine]:
+		return nil
+	case diffLine == filter.statsSummary:
+		return filter.replacement
+	}
+
+	return diffLine
+}
+
+// formatDiffStatsSummary formats the number of files and lines changed in the same way as the diff stats generated by libgit2
+func formatDiffStatsSummary(files, insertions, deletions int) string {
+	summary := fmt.Sprintf("%v file%v changed", files, pluralSuffix(files))
+
+	if insertions > 0 || deletions == 0 {
+		summary += fmt.Sprintf(", %v insertion%v(+)", insertions, pluralSuffix(insertions))
+	}
+
+	if deletions > 0 || insertions == 0 {
+		summary += fmt.Sprintf(", %v deletion%v(-)", deletions, pluralSuffix(deletions))
+	}
+
+	return summary
+}
+
+func pluralSuffix(count int) string {
+	if count == 1 {
+		return ""
+	}
+
+	return "s"
+}
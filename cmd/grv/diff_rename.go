@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+var renameLinePrefixes = []struct {
+	fromPrefix    string
+	toPrefix      string
+	summaryPrefix string
+}{
+	{fromPrefix: "rename from ", toPrefix: "rename to ", summaryPrefix: dvRenamedPrefix},
+	{fromPrefix: "copy from ", toPrefix: "copy to ", summaryPrefix: dvCopiedPrefix},
+}
+
+// summariseRenameLines replaces the "rename from" and "rename to" extended header lines of a patch
+// with a single "renamed: old -> new" line. Copies are summarised in the same way
+func summariseRenameLines(patch string) string {
+	lines := strings.SplitAfter(patch, "\n")
+	var summarisedLines []string
+
+	for lineIndex := 0; lineIndex < len(lines); lineIndex++ {
+		line := lines[lineIndex]
+
+		if strings.HasPrefix(line, "@@") {
+			summarisedLines = append(summarisedLines, lines[lineIndex:]...)
+			break
+		}
+
+		summarised := false
+
+		for _, prefixes := range renameLinePrefixes {
+			if lineIndex+1 < len(lines) && strings.HasPrefix(line, prefixes.fromPrefix) && strings.HasPrefix(lines[lineIndex+1], prefixes.toPrefix) {
+				fromPath := strings.TrimSuffix(strings.TrimPrefix(line, prefixes.fromPrefix), "\n")
+				toPath := strings.TrimPrefix(lines[lineIndex+1], prefixes.toPrefix)
+
+				summarisedLines = append(summarisedLines, fmt.Sprintf("%v%v -> %v", prefixes.summaryPrefix, fromPath, toPath))
+				lineIndex++
+				summarised = true
+				break
+			}
+		}
+
+		if !summarised {
+			summarisedLines = append(summarisedLines, line)
+		}
+	}
+
+	return strings.Join(summarisedLines, "")
+}
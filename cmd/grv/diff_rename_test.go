@@ -0,0 +1,41 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestRenameLinesAreSummarised(t *testing.T) {
+	patch := "diff --git a/old.go b/new.go\n" +
+		"similarity index 90%\n" +
+		"rename from old.go\n" +
+		"rename to new.go\n" +
+		"index 1234567..89abcde 100644\n" +
+		"--- a/old.go\n" +
+		"+++ b/new.go\n" +
+		"@@ -1 +1 @@\n" +
+		"-rename from a\n" +
+		"+rename to b\n"
+
+	expectedPatch := "diff --git a/old.go b/new.go\n" +
+		"similarity index 90%\n" +
+		"renamed: old.go -> new.go\n" +
+		"index 1234567..89abcde 100644\n" +
+		"--- a/old.go\n" +
+		"+++ b/new.go\n" +
+		"@@ -1 +1 @@\n" +
+		"-rename from a\n" +
+		"+rename to b\n"
+
+	if summarisedPatch := summariseRenameLines(patch); summarisedPatch != expectedPatch {
+		t.Errorf("Patch does not match expected value. Expected: %q, Actual: %q", expectedPatch, summarisedPatch)
+	}
+}
+
+func TestCopyLinesAreSummarised(t *testing.T) {
+	patch := "diff --git a/a.go b/b.go\nsimilarity index 100%\ncopy from a.go\ncopy to b.go\n"
+	expectedPatch := "diff --git a/a.go b/b.go\nsimilarity index 100%\ncopied: a.go -> b.go\n"
+
+	if summarisedPatch := summariseRenameLines(patch); summarisedPatch != expectedPatch {
+		t.Errorf("Patch does not match expected value. Expected: %q, Actual: %q", expectedPatch, summarisedPatch)
+	}
+}
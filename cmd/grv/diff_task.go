@@ -0,0 +1,76 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	dtProgressReportInterval = 250 * time.Millisecond
+)
+
+var errDiffCancelled = errors.New("Diff generation was cancelled")
+
+// DiffProgressHandler is notified of the number of files processed while a diff is generated
+type DiffProgressHandler func(processedFiles, totalFiles int)
+
+// DiffTask tracks the generation of a diff on a worker goroutine.
+// The task can be cancelled which causes diff generation to stop at the next file
+type DiffTask struct {
+	progressHandler  DiffProgressHandler
+	cancelled        bool
+	lastProgressTime time.Time
+	lock             sync.Mutex
+}
+
+// NewDiffTask creates a new task. Progress is reported to the provided handler
+// at most once per progress report interval
+func NewDiffTask(progressHandler DiffProgressHandler) *DiffTask {
+	return &DiffTask{
+		progressHandler:  progressHandler,
+		lastProgressTime: time.Now(),
+	}
+}
+
+// Cancel requests diff generation for this task to stop
+func (diffTask *DiffTask) Cancel() {
+	if diffTask == nil {
+		return
+	}
+
+	diffTask.lock.Lock()
+	defer diffTask.lock.Unlock()
+
+	diffTask.cancelled = true
+}
+
+// Cancelled returns true if this task has been cancelled
+func (diffTask *DiffTask) Cancelled() bool {
+	if diffTask == nil {
+		return false
+	}
+
+	diffTask.lock.Lock()
+	defer diffTask.lock.Unlock()
+
+	return diffTask.cancelled
+}
+
+func (diffTask *DiffTask) reportProgress(processedFiles, totalFiles int) {
+	if diffTask == nil || diffTask.progressHandler == nil {
+		return
+	}
+
+	diffTask.lock.Lock()
+	now := time.Now()
+	report := !diffTask.cancelled && now.Sub(diffTask.lastProgressTime) >= dtProgressReportInterval
+	if report {
+		diffTask.lastProgressTime = now
+	}
+	diffTask.lock.Unlock()
+
+	if report {
+		diffTask.progressHandler(processedFiles, totalFiles)
+	}
+}
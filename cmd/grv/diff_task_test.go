@@ -0,0 +1,56 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiffTaskIsCancelledAfterCancelIsCalled(t *testing.T) {
+	diffTask := NewDiffTask(nil)
+
+	if diffTask.Cancelled() {
+		t.Errorf("Expected new diff task to not be cancelled")
+	}
+
+	diffTask.Cancel()
+
+	if !diffTask.Cancelled() {
+		t.Errorf("Expected diff task to be cancelled")
+	}
+}
+
+func TestNilDiffTaskIsNeverCancelled(t *testing.T) {
+	var diffTask *DiffTask
+	diffTask.Cancel()
+
+	if diffTask.Cancelled() {
+		t.Errorf("Expected nil diff task to not be cancelled")
+	}
+
+	diffTask.reportProgress(1, 2)
+}
+
+func TestDiffTaskProgressIsReportedAtMostOncePerInterval(t *testing.T) {
+	reportNum := 0
+	diffTask := NewDiffTask(func(processedFiles, totalFiles int) {
+		reportNum++
+	})
+
+	diffTask.lastProgressTime = time.Now().Add(-dtProgressReportInterval)
+
+	for processedFiles := 1; processedFiles <= 10; processedFiles++ {
+		diffTask.reportProgress(processedFiles, 10)
+	}
+
+	if reportNum != 1 {
+		t.Errorf("Progress report number does not match expected value. Expected: 1, Actual: %v", reportNum)
+	}
+
+	diffTask.Cancel()
+	diffTask.lastProgressTime = time.Now().Add(-dtProgressReportInterval)
+	diffTask.reportProgress(10, 10)
+
+	if reportNum != 1 {
+		t.Errorf("Expected no progress to be reported after the diff task was cancelled")
+	}
+}
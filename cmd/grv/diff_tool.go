@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	dtCommand      = "git difftool --no-prompt"
+	dtEmptyTreeOid = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+)
+
+// DiffToolCommandProvider is implemented by views which can
+// open the diff they display in the configured git difftool
+type DiffToolCommandProvider interface {
+	DiffToolCommand() (string, error)
+}
+
+// commitDiffToolArgs returns the git difftool arguments which compare the same revisions as the commit diff.
+// The combined diff of a merge commit is compared against its first parent
+func commitDiffToolArgs(oid string, diffBase DiffBase, parentCount, mergeParent uint) []string {
+	switch {
+	case diffBase == DbWorkingTree:
+		return []string{oid}
+	case diffBase == DbIndex:
+		return []string{"--cached", oid}
+	case parentCount == 0:
+		return []string{dtEmptyTreeOid, oid}
+	case parentCount > 1 && mergeParent > 0:
+		return []string{fmt.Sprintf("%v^%v", oid, mergeParent), oid}
+	}
+
+	return []string{oid + "^", oid}
+}
+
+// stageDiffToolArgs returns the git difftool arguments which compare the files in the stage.
+// Untracked and conflicted files cannot be compared
+func stageDiffToolArgs(statusType StatusType) (args []string, supported bool) {
+	switch statusType {
+	case StStaged:
+		return []string{"--cached"}, true
+	case StUnstaged:
+		return []string{}, true
+	}
+
+	return
+}
+
+// diffToolCommand generates the git difftool command for the provided arguments.
+// When a path is provided only that file is compared
+func diffToolCommand(args []string, path string) string {
+	command := []string{dtCommand}
+
+	for _, arg := range args {
+		command = append(command, shellQuote(arg))
+	}
+
+	if path != "" {
+		command = append(command, "--", shellQuote(path))
+	}
+
+	return strings.Join(command, " ")
+}
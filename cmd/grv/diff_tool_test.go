@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestDiffToolCommandComparesSameRevisionsAsCommitDiff(t *testing.T) {
+	tests := []struct {
+		diffBase        DiffBase
+		parentCount     uint
+		mergeParent     uint
+		path            string
+		expectedCommand string
+	}{
+		{diffBase: DbParent, parentCount: 1, path: "a.go", expectedCommand: "git difftool --no-prompt 'abc^' 'abc' -- 'a.go'"},
+		{diffBase: DbParent, parentCount: 0, expectedCommand: "git difftool --no-prompt '" + dtEmptyTreeOid + "' 'abc'"},
+		{diffBase: DbParent, parentCount: 2, mergeParent: 2, expectedCommand: "git difftool --no-prompt 'abc^2' 'abc'"},
+		{diffBase: DbParent, parentCount: 2, expectedCommand: "git difftool --no-prompt 'abc^' 'abc'"},
+		{diffBase: DbWorkingTree, parentCount: 1, expectedCommand: "git difftool --no-prompt 'abc'"},
+		{diffBase: DbIndex, parentCount: 1, path: "it's.go", expectedCommand: `git difftool --no-prompt '--cached' 'abc' -- 'it'\''s.go'`},
+	}
+
+	for _, test := range tests {
+		args := commitDiffToolArgs("abc", test.diffBase, test.parentCount, test.mergeParent)
+
+		if command := diffToolCommand(args, test.path); command != test.expectedCommand {
+			t.Errorf("Command does not match expected value. Expected: %v, Actual: %v", test.expectedCommand, command)
+		}
+	}
+}
+
+func TestUntrackedFilesCannotBeOpenedInDiffTool(t *testing.T) {
+	if _, supported := stageDiffToolArgs(StUntracked); supported {
+		t.Errorf("Expected untracked files to be unsupported")
+	}
+
+	if args, supported := stageDiffToolArgs(StUnstaged); !supported || len(args) != 0 {
+		t.Errorf("Expected unstaged files to be compared without arguments but found %v", args)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
+	"io"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -22,6 +24,7 @@ const (
 	dltDiffCommitCommitter
 	dltDiffCommitCommitterDate
 	dltDiffCommitMessage
+	dltDiffCommitTrailer
 	dltDiffStatsFile
 	dltGitDiffHeader
 	dltGitDiffExtendedHeader
@@ -32,7 +35,16 @@ const (
 )
 
 const (
-	dvDateFormat = "Mon Jan 2 15:04:05 2006 -0700"
+	dvDateFormat         = "Mon Jan 2 15:04:05 2006 -0700"
+	dvContentStartColumn = 2
+
+	dvGitDiffHeaderPrefix      = "diff --git "
+	dvCombinedDiffHeaderPrefix = "diff --cc "
+	dvSimilarityPrefix         = "similarity index "
+	dvRenamedPrefix            = "renamed: "
+	dvCopiedPrefix             = "copied: "
+	dvStatsRenameSeparator     = " => "
+	dvStatsAbbreviationPrefix  = "..."
 )
 
 var diffLineThemeComponentID = map[diffLineType]ThemeComponentID{
@@ -42,6 +54,7 @@ var diffLineThemeComponentID = map[diffLineType]ThemeComponentID{
 	dltDiffCommitCommitter:     CmpDiffviewDifflineDiffCommitCommitter,
 	dltDiffCommitCommitterDate: CmpDiffviewDifflineDiffCommitCommitterDate,
 	dltDiffCommitMessage:       CmpDiffviewDifflineDiffCommitMessage,
+	dltDiffCommitTrailer:       CmpDiffviewDifflineDiffCommitTrailer,
 	dltDiffStatsFile:           CmpDiffviewDifflineDiffStatsFile,
 	dltGitDiffHeader:           CmpDiffviewDifflineGitDiffHeader,
 	dltGitDiffExtendedHeader:   CmpDiffviewDifflineGitDiffExtendedHeader,
@@ -52,8 +65,12 @@ var diffLineThemeComponentID = map[diffLineType]ThemeComponentID{
 }
 
 type diffLineData struct {
-	line     string
-	lineType diffLineType
+	line           string
+	lineType       diffLineType
+	oldLineNumber  uint
+	newLineNumber  uint
+	collapsed      bool
+	collapsedLines uint
 }
 
 func (diffLine *diffLineData) getThemeComponentID() ThemeComponentID {
@@ -61,6 +78,72 @@ func (diffLine *diffLineData) getThemeComponentID() ThemeComponentID {
 	return diffLineThemeComponentID[diffLine.lineType]
 }
 
+// lineParts splits the line into sections which are each displayed with a single style
+func (diffLine *diffLineData) lineParts() (lineParts []LinePart, err error) {
+	themeComponentID := diffLine.getThemeComponentID()
+
+	switch diffLine.lineType {
+	case dltHunkStart:
+		hunkParts := strings.SplitAfter(diffLine.line, hunkHeaderMarker(diffLine.line))
+
+		if len(hunkParts) != 3 {
+			return nil, fmt.Errorf("Unable to display hunk header line: %v", diffLine.line)
+		}
+
+		lineParts = []LinePart{
+			{themeComponentID: themeComponentID, text: strings.Join(hunkParts[:2], "")},
+			{themeComponentID: CmpDiffviewDifflineHunkHeader, text: hunkParts[2]},
+		}
+	case dltDiffStatsFile:
+		sepIndex := strings.LastIndex(diffLine.line, "|")
+
+		if sepIndex == -1 || sepIndex >= len(diffLine.line)-1 {
+			return nil, fmt.Errorf("Unable to display diff stats file line: %v", diffLine.line)
+		}
+
+		lineParts = []LinePart{
+			{themeComponentID: CmpDiffviewDifflineDiffStatsFile, text: diffLine.line[0:sepIndex] + " |"},
+		}
+
+		for _, char := range diffLine.line[sepIndex+1:] {
+			charThemeComponentID := CmpNone
+
+			switch char {
+			case '+':
+				charThemeComponentID = CmpDiffviewDifflineLineAdded
+			case '-':
+				charThemeComponentID = CmpDiffviewDifflineLineRemoved
+			}
+
+			if partNum := len(lineParts); lineParts[partNum-1].themeComponentID == charThemeComponentID {
+				lineParts[partNum-1].text += string(char)
+			} else {
+				lineParts = append(lineParts, LinePart{themeComponentID: charThemeComponentID, text: string(char)})
+			}
+		}
+	default:
+		lineParts = []LinePart{
+			{themeComponentID: themeComponentID, text: diffLine.line},
+		}
+
+		if diffLine.collapsed {
+			lineParts = append(lineParts, LinePart{
+				themeComponentID: CmpDiffviewDifflineHunkHeader,
+				text:             fmt.Sprintf(" [+%v lines]", diffLine.collapsedLines),
+			})
+		}
+	}
+
+	return
+}
+
+// isFileHeader returns true if the line is the git diff header of a file
+func (diffLine *diffLineData) isFileHeader() bool {
+	diffLine.determineDiffLineType()
+	return diffLine.lineType == dltGitDiffHeader &&
+		(strings.HasPrefix(diffLine.line, dvGitDiffHeaderPrefix) || strings.HasPrefix(diffLine.line, dvCombinedDiffHeaderPrefix))
+}
+
 func (diffLine *diffLineData) determineDiffLineType() {
 	if diffLine.lineType != dltUnset {
 		return
@@ -70,9 +153,10 @@ func (diffLine *diffLineData) determineDiffLineType() {
 	line := diffLine.line
 
 	switch {
-	case strings.HasPrefix(line, "diff --git"):
+	case strings.HasPrefix(line, dvGitDiffHeaderPrefix) || strings.HasPrefix(line, dvCombinedDiffHeaderPrefix):
 		lineType = dltGitDiffHeader
-	case strings.HasPrefix(line, "index"):
+	case strings.HasPrefix(line, "index") || strings.HasPrefix(line, dvSimilarityPrefix) ||
+		strings.HasPrefix(line, dvRenamedPrefix) || strings.HasPrefix(line, dvCopiedPrefix):
 		lineType = dltGitDiffExtendedHeader
 	case strings.HasPrefix(line, "---") || strings.HasPrefix(line, "+++"):
 		lineType = dltUnifiedDiffHeader
@@ -90,54 +174,367 @@ func (diffLine *diffLineData) determineDiffLineType() {
 }
 
 type diffLines struct {
-	lines   []*diffLineData
-	viewPos ViewPos
+	lines             []*diffLineData
+	allLines          []*diffLineData
+	viewPos           ViewPos
+	jumpList          *JumpList
+	oid               string
+	lineNumbersSet    bool
+	lineNumberWidth   uint
+	filePattern       string
+	diffToolArgs      []string
+	diffToolSupported bool
+	unstaged          bool
+	visualActive      bool
+	visualStart       uint
+	// regenerable is true for diffs generated from the repository,
+	// which are regenerated when a variable affecting diff generation is changed
+	regenerable bool
+}
+
+// lineNumberGutter returns the old and new line numbers of the provided line padded to the gutter width
+func (diffLines *diffLines) lineNumberGutter(diffLine *diffLineData) string {
+	return fmt.Sprintf("%*v %*v ", diffLines.lineNumberWidth, formatLineNumber(diffLine.oldLineNumber),
+		diffLines.lineNumberWidth, formatLineNumber(diffLine.newLineNumber))
+}
+
+func formatLineNumber(lineNumber uint) string {
+	if lineNumber == 0 {
+		return ""
+	}
+
+	return strconv.FormatUint(uint64(lineNumber), 10)
+}
+
+// fullLines returns all lines of the diff, including those hidden in collapsed files
+func (diffLines *diffLines) fullLines() []*diffLineData {
+	if diffLines.allLines != nil {
+		return diffLines.allLines
+	}
+
+	return diffLines.lines
+}
+
+// updateDisplayedLines regenerates the displayed lines omitting the content of collapsed files
+// and files which don't match the file pattern.
+// The cursor remains on the active line or moves to the header of the file it was collapsed into
+func (diffLines *diffLines) updateDisplayedLines() {
+	activeRowIndex := diffLines.viewPos.ActiveRowIndex()
+	var activeLine, activeFileHeader *diffLineData
+
+	if activeRowIndex < uint(len(diffLines.lines)) {
+		activeLine = diffLines.lines[activeRowIndex]
+
+		if headerIndex, found := findFileHeaderIndex(diffLines.lines, activeRowIndex); found {
+			activeFileHeader = diffLines.lines[headerIndex]
+		}
+	}
+
+	diffLines.allLines = diffLines.fullLines()
+	diffLines.visualActive = false
+	var lines []*diffLineData
+	var collapsedHeader *diffLineData
+	var fileFilter *diffFileFilter
+
+	if diffLines.filePattern != "" {
+		fileFilter = newDiffFileFilter(diffLines.allLines, diffLines.filePattern)
+	}
+
+	for _, diffLine := range diffLines.allLines {
+		if diffLine = fileFilter.displayedLine(diffLine); diffLine == nil {
+			continue
+		}
+
+		diffLine.determineDiffLineType()
+
+		if diffLine.lineType == dltGitDiffHeader {
+			collapsedHeader = nil
+
+			if diffLine.collapsed {
+				collapsedHeader = diffLine
+				collapsedHeader.collapsedLines = 0
+			}
+		} else if collapsedHeader != nil {
+			collapsedHeader.collapsedLines++
+			continue
+		}
+
+		lines = append(lines, diffLine)
+	}
+
+	diffLines.lines = lines
+
+	for lineIndex, diffLine := range lines {
+		if diffLine == activeLine || (diffLine == activeFileHeader && diffLine.collapsed) {
+			diffLines.viewPos.SetActiveRowIndex(uint(lineIndex))
+			break
+		}
+	}
+}
+
+// selectedLineRange returns the first and last index of the lines in the visual selection.
+// Only the active line is selected when there is no visual selection
+func (diffLines *diffLines) selectedLineRange(activeRowIndex uint) (startIndex, endIndex uint) {
+	if !diffLines.visualActive {
+		return activeRowIndex, activeRowIndex
+	}
+
+	return MinUint(diffLines.visualStart, activeRowIndex), MaxUint(diffLines.visualStart, activeRowIndex)
+}
+
+// selectedText returns the text of the lines in the visual selection, or of the active line.
+// When stripPrefix is true the +, - and space prefixes of lines within hunks are removed
+func (diffLines *diffLines) selectedText(activeRowIndex uint, stripPrefix bool) (text string, lineNum uint) {
+	startIndex, endIndex := diffLines.selectedLineRange(activeRowIndex)
+	endIndex = MinUint(endIndex, uint(len(diffLines.lines))-1)
+
+	if stripPrefix {
+		diffLines.setLineNumbers()
+	}
+
+	var lines []string
+
+	for lineIndex := startIndex; lineIndex <= endIndex; lineIndex++ {
+		diffLine := diffLines.lines[lineIndex]
+		line := diffLine.line
+
+		if stripPrefix && line != "" && (diffLine.oldLineNumber != 0 || diffLine.newLineNumber != 0) {
+			line = line[1:]
+		}
+
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n") + "\n", uint(len(lines))
+}
+
+// setCollapsed collapses or expands all files in the diff
+func (diffLines *diffLines) setCollapsed(collapsed bool) {
+	for _, diffLine := range diffLines.fullLines() {
+		if diffLine.isFileHeader() {
+			diffLine.collapsed = collapsed
+		}
+	}
+
+	diffLines.updateDisplayedLines()
+}
+
+// setFilePattern only displays the files in the diff which match the pattern.
+// All files are displayed when the pattern is empty
+func (diffLines *diffLines) setFilePattern(pattern string) {
+	if diffLines.filePattern == pattern {
+		return
+	}
+
+	diffLines.filePattern = pattern
+	diffLines.updateDisplayedLines()
+}
+
+// findFileHeaderIndex returns the index of the header of the file the line belongs to
+func findFileHeaderIndex(lines []*diffLineData, lineIndex uint) (headerIndex uint, found bool) {
+	for index := int(lineIndex); index >= 0 && index < len(lines); index-- {
+		if diffLine := lines[index]; diffLine.isFileHeader() {
+			return uint(index), true
+		} else if diffLine.lineType == dltGitDiffHeader {
+			break
+		}
+	}
+
+	return
+}
+
+// setLineNumbers determines the old and new file line numbers of each line within a hunk
+func (diffLines *diffLines) setLineNumbers() {
+	if diffLines.lineNumbersSet {
+		return
+	}
+
+	diffLines.lineNumbersSet = true
+	var oldLine, newLine, oldRemaining, newRemaining int
+	maxLineNumber := 0
+
+	for _, diffLine := range diffLines.fullLines() {
+		diffLine.determineDiffLineType()
+
+		if diffLine.lineType == dltHunkStart {
+			oldRemaining, newRemaining = 0, 0
+
+			if header, err := parseHunkHeader(diffLine.line); err == nil {
+				oldLine, newLine = header.oldStart, header.newStart
+				oldRemaining, newRemaining = header.oldLines, header.newLines
+			}
+
+			continue
+		} else if oldRemaining <= 0 && newRemaining <= 0 {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(diffLine.line, "+"):
+			diffLine.newLineNumber = uint(newLine)
+			newLine++
+			newRemaining--
+		case strings.HasPrefix(diffLine.line, "-"):
+			diffLine.oldLineNumber = uint(oldLine)
+			oldLine++
+			oldRemaining--
+		case strings.HasPrefix(diffLine.line, lhNoNewline):
+		default:
+			diffLine.oldLineNumber = uint(oldLine)
+			diffLine.newLineNumber = uint(newLine)
+			oldLine++
+			newLine++
+			oldRemaining--
+			newRemaining--
+		}
+
+		maxLineNumber = MaxInt(maxLineNumber, MaxInt(oldLine, newLine)-1)
+	}
+
+	diffLines.lineNumberWidth = uint(len(strconv.Itoa(maxLineNumber)))
 }
 
 type diffID string
 
 // DiffView contains all state for the diff view
 type DiffView struct {
-	channels      *Channels
-	repoData      RepoData
-	activeDiff    diffID
-	diffs         map[diffID]*diffLines
-	viewPos       ViewPos
-	viewDimension ViewDimension
-	handlers      map[ActionType]diffViewHandler
-	active        bool
-	viewSearch    *ViewSearch
-	lock          sync.Mutex
+	channels         *Channels
+	config           Config
+	repoData         RepoData
+	activeDiff       diffID
+	diffs            map[diffID]*diffLines
+	viewPos          ViewPos
+	viewDimension    ViewDimension
+	handlers         map[ActionType]diffViewHandler
+	active           bool
+	wrap             bool
+	lineNumbers      bool
+	diffBase         DiffBase
+	mergeParent      uint
+	mergeParentCount uint
+	filePattern      string
+	reloadDiff       func()
+	viewSearch       *ViewSearch
+	diffTask         *DiffTask
+	pendingDiff      diffID
+	diffProgress     diffProgress
+	lock             sync.Mutex
+}
+
+type diffProgress struct {
+	processedFiles int
+	totalFiles     int
 }
 
 // NewDiffView creates a new diff view instance
-func NewDiffView(repoData RepoData, channels *Channels) *DiffView {
+func NewDiffView(repoData RepoData, channels *Channels, config Config) *DiffView {
 	diffView := &DiffView{
 		repoData: repoData,
 		channels: channels,
+		config:   config,
 		viewPos:  NewViewPosition(),
 		diffs:    make(map[diffID]*diffLines),
 		handlers: map[ActionType]diffViewHandler{
-			ActionPrevLine:     moveUpDiffLine,
-			ActionNextLine:     moveDownDiffLine,
-			ActionPrevPage:     moveUpDiffPage,
-			ActionNextPage:     moveDownDiffPage,
-			ActionPrevHalfPage: moveUpDiffHalfPage,
-			ActionNextHalfPage: moveDownDiffHalfPage,
-			ActionScrollRight:  scrollDiffViewRight,
-			ActionScrollLeft:   scrollDiffViewLeft,
-			ActionFirstLine:    moveToFirstDiffLine,
-			ActionLastLine:     moveToLastDiffLine,
-			ActionCenterView:   centerDiffView,
-			ActionSelect:       selectDiffLine,
+			ActionPrevLine:           moveUpDiffLine,
+			ActionNextLine:           moveDownDiffLine,
+			ActionPrevPage:           moveUpDiffPage,
+			ActionNextPage:           moveDownDiffPage,
+			ActionPrevHalfPage:       moveUpDiffHalfPage,
+			ActionNextHalfPage:       moveDownDiffHalfPage,
+			ActionScrollRight:        scrollDiffViewRight,
+			ActionScrollLeft:         scrollDiffViewLeft,
+			ActionFirstLine:          moveToFirstDiffLine,
+			ActionLastLine:           moveToLastDiffLine,
+			ActionCenterView:         centerDiffView,
+			ActionScrollCursorTop:    scrollDiffViewCursorTop,
+			ActionScrollCursorBottom: scrollDiffViewCursorBottom,
+			ActionSelect:             selectDiffLine,
+			ActionJumpBack:           jumpBackDiffLine,
+			ActionJumpForward:        jumpForwardDiffLine,
+			ActionLineHistory:        showDiffLineHistory,
+			ActionBlame:              showDiffBlame,
+			ActionToggleWrap:         toggleDiffViewWrap,
+			ActionToggleLineNumbers:  toggleDiffViewLineNumbers,
+			ActionNextFile:           moveToNextDiffFile,
+			ActionPrevFile:           moveToPrevDiffFile,
+			ActionNextHunk:           moveToNextDiffHunk,
+			ActionPrevHunk:           moveToPrevDiffHunk,
+			ActionToggleFileCollapse: toggleDiffFileCollapse,
+			ActionCollapseAllFiles:   collapseAllDiffFiles,
+			ActionExpandAllFiles:     expandAllDiffFiles,
+			ActionCycleDiffBase:      cycleDiffViewDiffBase,
+			ActionCycleMergeParent:   cycleDiffViewMergeParent,
+			ActionAddFilter:          addDiffFileFilter,
+			ActionRemoveFilter:       removeDiffFileFilter,
+			ActionVisualSelect:       toggleDiffVisualSelect,
+			ActionYank:               yankDiffLines,
+			ActionYankWithoutPrefix:  yankDiffLinesWithoutPrefix,
+			ActionOpenIssue:          openDiffLineIssue,
+			ActionStageLines:         stageDiffLines,
 		},
 	}
 
 	diffView.viewSearch = NewViewSearch(diffView, channels)
+	config.AddOnChangeListener(CfDiffWhitespace, diffView)
+	config.AddOnChangeListener(CfRenameThreshold, diffView)
+	config.AddOnChangeListener(CfDiffAlgorithm, diffView)
+	config.AddOnChangeListener(CfPullRequests, diffView)
 
 	return diffView
 }
 
+// onConfigVariableChange regenerates the displayed diff when a variable affecting diff generation is changed
+func (diffView *DiffView) onConfigVariableChange(configVariable ConfigVariable) {
+	switch configVariable {
+	case CfDiffWhitespace, CfRenameThreshold, CfDiffAlgorithm, CfPullRequests:
+	default:
+		return
+	}
+
+	diffView.lock.Lock()
+
+	// Text displayed by DisplayText and line history can't be regenerated, so is kept
+	for diffID, diffLines := range diffView.diffs {
+		if diffLines.regenerable {
+			delete(diffView.diffs, diffID)
+		}
+	}
+
+	reloadDiff := diffView.reloadDiff
+	if reloadDiff != nil {
+		diffView.cancelDiffTask()
+	}
+
+	diffView.lock.Unlock()
+
+	if reloadDiff != nil {
+		reloadDiff()
+	}
+}
+
+func (diffView *DiffView) title() string {
+	var qualifiers []string
+
+	if description := diffView.diffWhitespace().Description(); description != "" && diffView.reloadDiff != nil {
+		qualifiers = append(qualifiers, description)
+	}
+
+	if diffView.filePattern != "" {
+		qualifiers = append(qualifiers, fmt.Sprintf("files matching %v", diffView.filePattern))
+	}
+
+	if len(qualifiers) > 0 {
+		return fmt.Sprintf("Diff for %v (%v)", diffView.activeDiff, strings.Join(qualifiers, ", "))
+	}
+
+	return fmt.Sprintf("Diff for %v", diffView.activeDiff)
+}
+
+func (diffView *DiffView) diffWhitespace() DiffWhitespace {
+	diffWhitespace, _ := ParseDiffWhitespace(diffView.config.GetString(CfDiffWhitespace))
+	return diffWhitespace
+}
+
 // Initialise does nothing
 func (diffView *DiffView) Initialise() (err error) {
 	log.Info("Initialising DiffView")
@@ -159,25 +556,60 @@ func (diffView *DiffView) Render(win RenderWindow) (err error) {
 	viewPos := diffView.viewPos
 	diffLines, ok := diffView.diffs[diffView.activeDiff]
 	if !ok {
+		if diffView.pendingDiff == diffView.activeDiff {
+			return diffView.renderLoadingView(win)
+		}
+
 		log.Errorf("No diff data found for %v", diffView.activeDiff)
 		return
 	}
 
 	lineNum := uint(len(diffLines.lines))
-	viewPos.DetermineViewStartRow(rows, lineNum)
+	viewPos.DetermineViewStartRow(rows, lineNum, uint(diffView.config.GetInt(CfScrollOff)))
 
-	lineIndex := viewPos.ViewStartRowIndex()
 	startColumn := viewPos.ViewStartColumn()
+	contentStartColumn := uint(dvContentStartColumn)
+	gutterWidth := uint(0)
+
+	if diffView.lineNumbers {
+		diffLines.setLineNumbers()
+		gutterWidth = 2*diffLines.lineNumberWidth + 2
+		contentStartColumn += gutterWidth
+	}
+
+	wrapWidth := uint(0)
 
-	for rowIndex := uint(0); rowIndex < rows && lineIndex < lineNum; rowIndex++ {
+	if diffView.wrap && lineNum > 0 {
+		startColumn = 1
+
+		if cols := win.Cols(); cols > contentStartColumn {
+			wrapWidth = cols - contentStartColumn
+		}
+
+		diffView.determineWrappedViewStartRow(diffLines, rows, contentStartColumn, wrapWidth)
+	}
+
+	lineIndex := viewPos.ViewStartRowIndex()
+	activeRowIndex := viewPos.ActiveRowIndex()
+	selectionStartIndex, selectionEndIndex := diffLines.selectedLineRange(activeRowIndex)
+
+	for rowIndex := uint(0); rowIndex < rows && lineIndex < lineNum; lineIndex++ {
 		diffLine := diffLines.lines[lineIndex]
 		themeComponentID := diffLine.getThemeComponentID()
 
-		if diffLine.lineType == dltHunkStart {
-			lineParts := strings.SplitAfter(diffLine.line, "@@")
+		var lineParts []LinePart
+		if lineParts, err = diffLine.lineParts(); err != nil {
+			return
+		}
+
+		lineRows := [][]LinePart{lineParts}
+		if diffView.wrap {
+			lineRows = WrapLineParts(lineParts, contentStartColumn, wrapWidth, diffView.config)
+		}
 
-			if len(lineParts) != 3 {
-				return fmt.Errorf("Unable to display hunk header line: %v", diffLine.line)
+		for lineRowIndex, lineRow := range lineRows {
+			if rowIndex >= rows {
+				break
 			}
 
 			var lineBuilder *LineBuilder
@@ -185,55 +617,43 @@ func (diffView *DiffView) Render(win RenderWindow) (err error) {
 				return
 			}
 
-			lineBuilder.
-				AppendWithStyle(themeComponentID, " %v", strings.Join(lineParts[:2], "")).
-				AppendWithStyle(CmpDiffviewDifflineHunkHeader, "%v", lineParts[2])
+			lineBuilder.AppendWithStyle(themeComponentID, " ")
 
-		} else if diffLine.lineType == dltDiffStatsFile {
-			sepIndex := strings.LastIndex(diffLine.line, "|")
-
-			if sepIndex == -1 || sepIndex >= len(diffLine.line)-1 {
-				return fmt.Errorf("Unable to display diff stats file line: %v", diffLine.line)
+			if diffView.lineNumbers {
+				if lineRowIndex == 0 {
+					lineBuilder.AppendWithStyle(CmpDiffviewDifflineLineNumber, "%v", diffLines.lineNumberGutter(diffLine))
+				} else {
+					lineBuilder.Append("%v", strings.Repeat(" ", int(gutterWidth)))
+				}
 			}
 
-			filePart := diffLine.line[0:sepIndex]
-			changePart := diffLine.line[sepIndex+1:]
-
-			var lineBuilder *LineBuilder
-			if lineBuilder, err = win.LineBuilder(rowIndex+1, startColumn); err != nil {
-				return
+			for _, linePart := range lineRow {
+				lineBuilder.AppendWithStyle(linePart.themeComponentID, "%v", linePart.text)
 			}
 
-			lineBuilder.AppendWithStyle(CmpDiffviewDifflineDiffStatsFile, " %v |", filePart)
-
-			for _, char := range changePart {
-				switch char {
-				case '+':
-					lineBuilder.AppendWithStyle(CmpDiffviewDifflineLineAdded, "%c", char)
-				case '-':
-					lineBuilder.AppendWithStyle(CmpDiffviewDifflineLineRemoved, "%c", char)
-				default:
-					lineBuilder.Append("%c", char)
+			if lineIndex >= selectionStartIndex && lineIndex <= selectionEndIndex {
+				if err = win.SetSelectedRow(rowIndex+1, diffView.active); err != nil {
+					return
 				}
 			}
-		} else if err = win.SetRow(rowIndex+1, startColumn, themeComponentID, " %v", diffLines.lines[lineIndex].line); err != nil {
-			return
-		}
-
-		lineIndex++
-	}
 
-	if err = win.SetSelectedRow(viewPos.SelectedRowIndex()+1, diffView.active); err != nil {
-		return
+			rowIndex++
+		}
 	}
 
 	win.DrawBorder()
 
-	if err = win.SetTitle(CmpDiffviewTitle, "Diff for %v", diffView.activeDiff); err != nil {
+	if err = win.SetTitle(CmpDiffviewTitle, "%v", diffView.title()); err != nil {
 		return
 	}
 
-	if err = win.SetFooter(CmpDiffviewFooter, "Line %v of %v", viewPos.ActiveRowIndex()+1, lineNum); err != nil {
+	if diffLines.visualActive {
+		err = win.SetFooter(CmpDiffviewFooter, "%v lines selected, Line %v of %v",
+			selectionEndIndex-selectionStartIndex+1, viewPos.ActiveRowIndex()+1, lineNum)
+	} else {
+		err = win.SetFooter(CmpDiffviewFooter, "Line %v of %v", viewPos.ActiveRowIndex()+1, lineNum)
+	}
+	if err != nil {
 		return
 	}
 
@@ -246,6 +666,34 @@ func (diffView *DiffView) Render(win RenderWindow) (err error) {
 	return
 }
 
+// determineWrappedViewStartRow moves the view start down until all rows of the wrapped active line are visible
+func (diffView *DiffView) determineWrappedViewStartRow(diffLines *diffLines, rows, contentStartColumn, wrapWidth uint) {
+	viewPos := diffView.viewPos
+	viewStartRowIndex := viewPos.ViewStartRowIndex()
+	lineIndex := viewPos.ActiveRowIndex()
+	wrappedRows := diffView.wrappedRowNum(diffLines.lines[lineIndex], contentStartColumn, wrapWidth)
+
+	for lineIndex > viewStartRowIndex {
+		lineRows := diffView.wrappedRowNum(diffLines.lines[lineIndex-1], contentStartColumn, wrapWidth)
+		if wrappedRows+lineRows > rows {
+			viewPos.SetViewStartRowIndex(lineIndex)
+			return
+		}
+
+		wrappedRows += lineRows
+		lineIndex--
+	}
+}
+
+func (diffView *DiffView) wrappedRowNum(diffLine *diffLineData, contentStartColumn, wrapWidth uint) uint {
+	lineParts, err := diffLine.lineParts()
+	if err != nil {
+		return 1
+	}
+
+	return uint(len(WrapLineParts(lineParts, contentStartColumn, wrapWidth, diffView.config)))
+}
+
 func (diffView *DiffView) renderEmptyView(win RenderWindow) (err error) {
 	viewPos := diffView.viewPos
 	startColumn := viewPos.ViewStartColumn()
@@ -259,6 +707,25 @@ func (diffView *DiffView) renderEmptyView(win RenderWindow) (err error) {
 	return
 }
 
+func (diffView *DiffView) renderLoadingView(win RenderWindow) (err error) {
+	startColumn := diffView.viewPos.ViewStartColumn()
+	progress := diffView.diffProgress
+
+	if progress.totalFiles > 0 {
+		err = win.SetRow(2, startColumn, CmpNone, "   Generating diff... %v/%v files", progress.processedFiles, progress.totalFiles)
+	} else {
+		err = win.SetRow(2, startColumn, CmpNone, "   Generating diff...")
+	}
+
+	if err != nil {
+		return
+	}
+
+	win.DrawBorder()
+
+	return win.SetTitle(CmpDiffviewTitle, "%v", diffView.title())
+}
+
 // RenderHelpBar does nothing
 func (diffView *DiffView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
 	diffView.lock.Lock()
@@ -276,10 +743,43 @@ func (diffView *DiffView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
 	lineIndex := diffView.viewPos.ActiveRowIndex()
 	line := diffLines.lines[lineIndex]
 
-	if line.lineType == dltDiffStatsFile {
+	line.determineDiffLineType()
+
+	switch {
+	case line.lineType == dltDiffStatsFile:
 		RenderKeyBindingHelp(diffView.ViewID(), lineBuilder, []ActionMessage{
 			{action: ActionSelect, message: "Jump to file diff"},
 		})
+	case line.isFileHeader():
+		message := "Collapse file"
+		if line.collapsed {
+			message = "Expand file"
+		}
+
+		RenderKeyBindingHelp(diffView.ViewID(), lineBuilder, []ActionMessage{
+			{action: ActionSelect, message: message},
+			{action: ActionCollapseAllFiles, message: "Collapse all"},
+			{action: ActionExpandAllFiles, message: "Expand all"},
+		})
+	case diffLines.oid != "" && (line.lineType == dltHunkStart || line.lineType == dltLineAdded || line.lineType == dltLineRemoved):
+		RenderKeyBindingHelp(diffView.ViewID(), lineBuilder, []ActionMessage{
+			{action: ActionLineHistory, message: "Line history"},
+			{action: ActionBlame, message: "Blame"},
+		})
+	}
+
+	return
+}
+
+// RenderStatusBar adds the position of the selected line to the status bar
+func (diffView *DiffView) RenderStatusBar(statusBarSegments *StatusBarSegments) (err error) {
+	diffView.lock.Lock()
+	defer diffView.lock.Unlock()
+
+	statusBarSegments.Add(SegmentMode, "Diff")
+
+	if diffLines, ok := diffView.diffs[diffView.activeDiff]; ok {
+		statusBarSegments.AddPosition(diffView.viewPos.ActiveRowIndex(), uint(len(diffLines.lines)))
 	}
 
 	return
@@ -299,70 +799,204 @@ func (diffView *DiffView) ViewID() ViewID {
 	return ViewDiff
 }
 
-// OnCommitSelected loads/fetches the diff for the selected commit and refreshes the display
+// Loading returns true if a diff is currently being generated
+func (diffView *DiffView) Loading() bool {
+	diffView.lock.Lock()
+	defer diffView.lock.Unlock()
+
+	return diffView.diffTask != nil
+}
+
+// OnCommitSelected loads/fetches the diff for the selected commit and refreshes the display.
+// Diffs are generated on a worker goroutine and any diff still being generated for a
+// previously selected commit is cancelled
 func (diffView *DiffView) OnCommitSelected(commit *Commit) (err error) {
 	log.Debugf("DiffView loading diff for selected commit %v", commit.commit.Id())
 
 	diffView.lock.Lock()
 	defer diffView.lock.Unlock()
 
-	diffID := diffID(commit.oid.String())
+	diffBase := diffView.diffBase
+	diffView.mergeParentCount = 0
+
+	if diffBase == DbParent && commit.commit.ParentCount() > 1 {
+		diffView.mergeParentCount = commit.commit.ParentCount()
+
+		if diffView.mergeParent > diffView.mergeParentCount {
+			diffView.mergeParent = 0
+		}
+	}
+
+	mergeParent := diffView.mergeParent
+	diffID := commitDiffID(commit, diffBase, mergeParent)
+	diffView.reloadDiff = func() {
+		diffView.OnCommitSelected(commit)
+	}
 
-	if diffLines, ok := diffView.diffs[diffID]; ok {
+	if diffLines, ok := diffView.diffs[diffID]; ok && diffBase == DbParent {
+		diffView.cancelDiffTask()
 		diffView.activeDiff = diffID
 		diffView.viewPos = diffLines.viewPos
 		diffView.channels.UpdateDisplay()
 		return
 	}
 
-	lines, err := diffView.generateDiffLinesForCommit(commit)
-	if err != nil {
+	if diffView.diffTask != nil && diffView.pendingDiff == diffID {
+		diffView.activeDiff = diffID
 		return
 	}
 
-	diffLines := &diffLines{
-		lines:   lines,
-		viewPos: NewViewPosition(),
-	}
+	diffView.cancelDiffTask()
 
+	diffTask := NewDiffTask(func(processedFiles, totalFiles int) {
+		diffView.onDiffProgress(diffID, processedFiles, totalFiles)
+	})
+
+	diffView.diffTask = diffTask
+	diffView.pendingDiff = diffID
+	diffView.diffProgress = diffProgress{}
 	diffView.activeDiff = diffID
-	diffView.diffs[diffID] = diffLines
-	diffView.viewPos = diffLines.viewPos
+	diffView.viewPos = NewViewPosition()
 	diffView.channels.UpdateDisplay()
 
+	go diffView.generateCommitDiff(commit, diffBase, mergeParent, diffID, diffTask)
+
 	return
 }
 
-// OnFileSelected loads/fetches the diff for the selected file and refreshes the display
-func (diffView *DiffView) OnFileSelected(statusType StatusType, path string) {
-	log.Debugf("DiffView loading diff for file %v", path)
+// commitDiffID returns the id of the diff for the commit against the provided base.
+// Diffs against the working tree or index are always regenerated as they change without the commit changing.
+// Merge commits diffed against their parents are identified by the parent number, or as combined when it is 0
+func commitDiffID(commit *Commit, diffBase DiffBase, mergeParent uint) diffID {
+	switch {
+	case diffBase != DbParent:
+		return diffID(fmt.Sprintf("%v %v", commit.oid, diffBase.Description()))
+	case commit.commit.ParentCount() <= 1:
+		return diffID(commit.oid.String())
+	case mergeParent == 0:
+		return diffID(fmt.Sprintf("%v (combined)", commit.oid))
+	}
+
+	return diffID(fmt.Sprintf("%v against parent %v", commit.oid, mergeParent))
+}
+
+func (diffView *DiffView) generateCommitDiff(commit *Commit, diffBase DiffBase, mergeParent uint, diffID diffID, diffTask *DiffTask) {
+	defer RecoverPanic()
+
+	lines, err := diffView.generateDiffLinesForCommit(commit, diffBase, mergeParent, diffTask)
 
 	diffView.lock.Lock()
 	defer diffView.lock.Unlock()
 
-	// Reload diff each time as staged or unstaged files diffs are liable
-	// to change frequently
-	diff, err := diffView.repoData.DiffFile(statusType, path)
-	if err != nil {
-		log.Errorf("Unable to load file diff: %v", err)
+	if diffTask.Cancelled() {
+		log.Debugf("Diff generation for %v was cancelled", diffID)
 		return
 	}
 
-	if err = diffView.storeDiff(diffID(path), diff); err != nil {
-		log.Errorf("Unable to store file diff: %v", err)
+	progress := diffView.diffProgress
+	diffView.diffTask = nil
+	diffView.pendingDiff = ""
+	diffView.diffProgress = diffProgress{}
+
+	if err != nil {
+		diffView.channels.ReportError(err)
 		return
 	}
 
-	diffView.channels.UpdateDisplay()
-}
+	diffLines := &diffLines{
+		lines:             lines,
+		viewPos:           diffView.viewPos,
+		jumpList:          NewJumpList(),
+		diffToolArgs:      commitDiffToolArgs(commit.oid.String(), diffBase, commit.commit.ParentCount(), mergeParent),
+		diffToolSupported: true,
+		regenerable:       true,
+	}
 
-// OnStageGroupSelected does nothing
-func (diffView *DiffView) OnStageGroupSelected(statusType StatusType) {
+	// Line history traces lines as they are in the commit and its first parent,
+	// which only holds for diffs of non-merge commits against their parent
+	if diffBase == DbParent && commit.commit.ParentCount() <= 1 {
+		diffLines.oid = commit.oid.String()
+	}
+
+	diffView.addDiffLines(diffID, diffLines)
+
+	if progress.totalFiles > 0 {
+		diffView.channels.ReportStatus("Generated diff for %v files", progress.totalFiles)
+	}
+
+	diffView.channels.UpdateDisplay()
+}
+
+func (diffView *DiffView) onDiffProgress(diffID diffID, processedFiles, totalFiles int) {
+	diffView.lock.Lock()
+	defer diffView.lock.Unlock()
+
+	if diffView.pendingDiff != diffID {
+		return
+	}
+
+	diffView.diffProgress = diffProgress{
+		processedFiles: processedFiles,
+		totalFiles:     totalFiles,
+	}
+
+	diffView.channels.ReportStatus("Generating diff for %v: %v/%v files", diffID, processedFiles, totalFiles)
+	diffView.channels.UpdateDisplay()
+}
+
+func (diffView *DiffView) cancelDiffTask() {
+	if diffView.diffTask == nil {
+		return
+	}
+
+	log.Debugf("Cancelling diff generation for %v", diffView.pendingDiff)
+
+	diffView.diffTask.Cancel()
+	diffView.diffTask = nil
+	diffView.pendingDiff = ""
+	diffView.diffProgress = diffProgress{}
+}
+
+// OnFileSelected loads/fetches the diff for the selected file and refreshes the display
+func (diffView *DiffView) OnFileSelected(statusType StatusType, path string) {
+	log.Debugf("DiffView loading diff for file %v", path)
+
+	diffView.lock.Lock()
+	defer diffView.lock.Unlock()
+
+	diffView.reloadDiff = func() {
+		diffView.OnFileSelected(statusType, path)
+	}
+	diffView.mergeParentCount = 0
+
+	// Reload diff each time as staged or unstaged files diffs are liable
+	// to change frequently
+	diff, err := diffView.repoData.DiffFile(statusType, path)
+	if err != nil {
+		log.Errorf("Unable to load file diff: %v", err)
+		return
+	}
+
+	if err = diffView.storeDiff(diffID(path), diff, statusType); err != nil {
+		log.Errorf("Unable to store file diff: %v", err)
+		return
+	}
+
+	diffView.channels.UpdateDisplay()
+}
+
+// OnStageGroupSelected does nothing
+func (diffView *DiffView) OnStageGroupSelected(statusType StatusType) {
 	log.Debugf("DiffView loading diff for stage %v", statusType)
 
 	diffView.lock.Lock()
 	defer diffView.lock.Unlock()
 
+	diffView.reloadDiff = func() {
+		diffView.OnStageGroupSelected(statusType)
+	}
+	diffView.mergeParentCount = 0
+
 	// Reload diff each time as staged or unstaged files diffs are liable
 	// to change frequently
 	diff, err := diffView.repoData.DiffStage(statusType)
@@ -372,7 +1006,7 @@ func (diffView *DiffView) OnStageGroupSelected(statusType StatusType) {
 	}
 
 	id := fmt.Sprintf("%v files", strings.ToLower(StatusTypeDisplayName(statusType)))
-	if err = diffView.storeDiff(diffID(id), diff); err != nil {
+	if err = diffView.storeDiff(diffID(id), diff, statusType); err != nil {
 		log.Errorf("Unable to store stage diff: %v", err)
 		return
 	}
@@ -387,29 +1021,222 @@ func (diffView *DiffView) OnNoEntrySelected() {
 	diffView.lock.Lock()
 	defer diffView.lock.Unlock()
 
+	diffView.cancelDiffTask()
 	diffView.activeDiff = diffID("")
+	diffView.reloadDiff = nil
+	diffView.mergeParentCount = 0
 	diffView.channels.UpdateDisplay()
 }
 
-func (diffView *DiffView) storeDiff(diffID diffID, diff *Diff) (err error) {
+func (diffView *DiffView) storeDiff(diffID diffID, diff *Diff, statusType StatusType) (err error) {
 	lines, err := diffView.generateDiffLinesForDiff(diff)
 	if err != nil {
 		return
 	}
 
+	diffToolArgs, diffToolSupported := stageDiffToolArgs(statusType)
+
 	diffLines := &diffLines{
-		lines:   lines,
-		viewPos: NewViewPosition(),
+		lines:             lines,
+		viewPos:           NewViewPosition(),
+		jumpList:          NewJumpList(),
+		diffToolArgs:      diffToolArgs,
+		diffToolSupported: diffToolSupported,
+		unstaged:          statusType == StUnstaged,
+		regenerable:       true,
 	}
 
+	diffView.cancelDiffTask()
+	diffView.addDiffLines(diffID, diffLines)
+	diffView.activeDiff = diffID
+	diffView.viewPos = diffLines.viewPos
+
+	return
+}
+
+// addDiffLines stores the diff lines with the file pattern filter applied
+func (diffView *DiffView) addDiffLines(diffID diffID, diffLines *diffLines) {
+	diffLines.setFilePattern(diffView.filePattern)
 	diffView.diffs[diffID] = diffLines
+}
+
+// DisplayText reads diff or log output from the provided reader and displays it
+// in the diff view. This allows the diff view to be used without a repository
+func (diffView *DiffView) DisplayText(name string, reader io.Reader) (err error) {
+	diffView.lock.Lock()
+	defer diffView.lock.Unlock()
+
+	lines, err := readLogLines(reader)
+	if err != nil {
+		return
+	}
+
+	diffLines := &diffLines{
+		lines:    lines,
+		viewPos:  NewViewPosition(),
+		jumpList: NewJumpList(),
+	}
+
+	diffID := diffID(name)
+	diffView.cancelDiffTask()
+	diffView.addDiffLines(diffID, diffLines)
 	diffView.activeDiff = diffID
 	diffView.viewPos = diffLines.viewPos
+	diffView.reloadDiff = nil
+	diffView.mergeParentCount = 0
+
+	return
+}
+
+// OnLineRangeSelected loads the history of the provided line range and displays it.
+// The history is loaded on a worker goroutine
+func (diffView *DiffView) OnLineRangeSelected(lineRange LineRange) {
+	log.Debugf("DiffView loading history for lines %v at %v", lineRange, lineRange.revision)
+
+	diffView.lock.Lock()
+	defer diffView.lock.Unlock()
+
+	diffID := diffID(fmt.Sprintf("line history of %v", lineRange))
+	diffView.cancelDiffTask()
+
+	diffTask := NewDiffTask(nil)
+	diffView.diffTask = diffTask
+	diffView.pendingDiff = diffID
+	diffView.diffProgress = diffProgress{}
+	diffView.activeDiff = diffID
+	diffView.viewPos = NewViewPosition()
+	diffView.reloadDiff = nil
+	diffView.mergeParentCount = 0
+	diffView.channels.UpdateDisplay()
+
+	go diffView.generateLineHistory(lineRange, diffID, diffTask)
+}
+
+func (diffView *DiffView) generateLineHistory(lineRange LineRange, diffID diffID, diffTask *DiffTask) {
+	defer RecoverPanic()
+
+	var lines []*diffLineData
+	output, err := diffView.repoData.LineHistory(lineRange)
+	if err == nil {
+		lines, err = readLogLines(bytes.NewReader(output))
+	}
+
+	diffView.lock.Lock()
+	defer diffView.lock.Unlock()
+
+	if diffTask.Cancelled() {
+		log.Debugf("Line history generation for %v was cancelled", diffID)
+		return
+	}
+
+	diffView.diffTask = nil
+	diffView.pendingDiff = ""
+
+	if err != nil {
+		diffView.channels.ReportError(err)
+		return
+	}
+
+	diffView.addDiffLines(diffID, &diffLines{
+		lines:    lines,
+		viewPos:  diffView.viewPos,
+		jumpList: NewJumpList(),
+	})
+
+	diffView.channels.UpdateDisplay()
+}
+
+// readLogLines reads git log output and determines the line type of commit header and message lines
+func readLogLines(reader io.Reader) (lines []*diffLineData, err error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(nil, pgMaxLineLength)
+
+	for scanner.Scan() {
+		lines = append(lines, &diffLineData{
+			line: scanner.Text(),
+		})
+	}
+
+	if err = scanner.Err(); err != nil {
+		return
+	}
+
+	determineLogLineTypes(lines)
 
 	return
 }
 
-func (diffView *DiffView) generateDiffLinesForCommit(commit *Commit) (lines []*diffLineData, err error) {
+// hunkHeaderMarker returns the run of @ characters which delimit the ranges of a hunk header.
+// Combined diff hunk headers use one more @ than the number of parents
+func hunkHeaderMarker(line string) string {
+	return line[:len(line)-len(strings.TrimLeft(line, "@"))]
+}
+
+// determineCombinedDiffLineTypes sets the line type of lines in combined diff hunks.
+// Each line has a column per parent and is considered added or removed if it was added or removed relative to any parent
+func determineCombinedDiffLineTypes(lines []*diffLineData) {
+	parents := 0
+
+	for _, diffLine := range lines {
+		line := diffLine.line
+
+		switch {
+		case strings.HasPrefix(line, dvCombinedDiffHeaderPrefix):
+			parents = 0
+		case strings.HasPrefix(line, "@@@"):
+			parents = len(hunkHeaderMarker(line)) - 1
+			diffLine.lineType = dltHunkStart
+		case parents == 0:
+		case len(line) < parents:
+			diffLine.lineType = dltNormal
+		case strings.Contains(line[:parents], "+"):
+			diffLine.lineType = dltLineAdded
+		case strings.Contains(line[:parents], "-"):
+			diffLine.lineType = dltLineRemoved
+		default:
+			diffLine.lineType = dltNormal
+		}
+	}
+}
+
+// determineLogLineTypes sets the line type of commit header and message lines
+// in git log output. All other lines are classified as diff lines when rendered
+func determineLogLineTypes(lines []*diffLineData) {
+	inCommitHeader := false
+
+	for _, diffLine := range lines {
+		line := diffLine.line
+
+		switch {
+		case strings.HasPrefix(line, "commit "):
+			inCommitHeader = true
+			diffLine.lineType = dltGitDiffHeader
+		case strings.HasPrefix(line, "diff --git"):
+			inCommitHeader = false
+		case !inCommitHeader:
+		case strings.HasPrefix(line, "Merge:"):
+			diffLine.lineType = dltGitDiffExtendedHeader
+		case strings.HasPrefix(line, "Author:"):
+			diffLine.lineType = dltDiffCommitAuthor
+		case strings.HasPrefix(line, "AuthorDate:") || strings.HasPrefix(line, "Date:"):
+			diffLine.lineType = dltDiffCommitAuthorDate
+		case strings.HasPrefix(line, "Commit:"):
+			diffLine.lineType = dltDiffCommitCommitter
+		case strings.HasPrefix(line, "CommitDate:"):
+			diffLine.lineType = dltDiffCommitCommitterDate
+		case strings.HasPrefix(line, "PullRequest:"):
+			diffLine.lineType = dltDiffCommitCommitter
+		case strings.HasPrefix(line, "    "):
+			diffLine.lineType = dltDiffCommitMessage
+		case line == "":
+			diffLine.lineType = dltNormal
+		default:
+			inCommitHeader = false
+		}
+	}
+}
+
+func (diffView *DiffView) generateDiffLinesForCommit(commit *Commit, diffBase DiffBase, mergeParent uint, diffTask *DiffTask) (lines []*diffLineData, err error) {
 	author := commit.commit.Author()
 	committer := commit.commit.Committer()
 
@@ -430,25 +1257,56 @@ func (diffView *DiffView) generateDiffLinesForCommit(commit *Commit) (lines []*d
 			line:     fmt.Sprintf("CommitterDate:\t%v", committer.When.Format(dvDateFormat)),
 			lineType: dltDiffCommitCommitterDate,
 		},
-		&diffLineData{
-			lineType: dltNormal,
-		},
 	)
 
+	if pullRequest, pullRequestErr := diffView.repoData.PullRequest(commit); pullRequestErr != nil {
+		log.Errorf("Unable to determine pull request for commit %v: %v", commit.oid, pullRequestErr)
+	} else if pullRequest != nil {
+		lines = append(lines, &diffLineData{
+			line:     fmt.Sprintf("PullRequest:\t%v", pullRequest),
+			lineType: dltDiffCommitCommitter,
+		})
+	}
+
+	lines = append(lines, &diffLineData{
+		lineType: dltNormal,
+	})
+
+	var messageLines []string
 	commitMessageScanner := bufio.NewScanner(strings.NewReader(commit.commit.Message()))
 
 	for commitMessageScanner.Scan() {
+		messageLines = append(messageLines, commitMessageScanner.Text())
+	}
+
+	trailers, trailerIndex := parseCommitTrailers(messageLines)
+
+	for _, messageLine := range messageLines[:trailerIndex] {
 		lines = append(lines, &diffLineData{
-			line:     commitMessageScanner.Text(),
+			line:     messageLine,
 			lineType: dltDiffCommitMessage,
 		})
 	}
 
+	for _, trailerLine := range formatCommitTrailers(trailers) {
+		lines = append(lines, &diffLineData{
+			line:     trailerLine,
+			lineType: dltDiffCommitTrailer,
+		})
+	}
+
 	lines = append(lines, &diffLineData{
 		lineType: dltNormal,
 	})
 
-	diff, err := diffView.repoData.DiffCommit(commit)
+	var diff *Diff
+	combined := diffBase == DbParent && commit.commit.ParentCount() > 1 && mergeParent == 0
+
+	if diffBase == DbParent && commit.commit.ParentCount() > 1 {
+		diff, err = diffView.repoData.DiffMergeCommit(commit, mergeParent, diffTask)
+	} else {
+		diff, err = diffView.repoData.DiffCommit(commit, diffBase, diffTask)
+	}
 	if err != nil {
 		return
 	}
@@ -458,6 +1316,10 @@ func (diffView *DiffView) generateDiffLinesForCommit(commit *Commit) (lines []*d
 		return
 	}
 
+	if combined {
+		determineCombinedDiffLineTypes(diffContent)
+	}
+
 	lines = append(lines, diffContent...)
 
 	return
@@ -496,6 +1358,46 @@ func (diffView *DiffView) generateDiffLinesForDiff(diff *Diff) (lines []*diffLin
 	return
 }
 
+// ExternalCommandContext returns the path of the file the cursor is in
+func (diffView *DiffView) ExternalCommandContext() map[string]string {
+	diffView.lock.Lock()
+	defer diffView.lock.Unlock()
+
+	context := map[string]string{}
+
+	diffLines, ok := diffView.diffs[diffView.activeDiff]
+	if !ok {
+		return context
+	}
+
+	if headerIndex, found := findFileHeaderIndex(diffLines.lines, diffView.viewPos.ActiveRowIndex()); found {
+		if path := diffHeaderPath(diffLines.lines[headerIndex].line); path != "" {
+			context[ecFile] = path
+		}
+	}
+
+	return context
+}
+
+// DiffToolCommand returns the git difftool command which opens the file the cursor is in,
+// or all files in the diff when the cursor is not in a file
+func (diffView *DiffView) DiffToolCommand() (command string, err error) {
+	diffView.lock.Lock()
+	defer diffView.lock.Unlock()
+
+	diffLines, ok := diffView.diffs[diffView.activeDiff]
+	if !ok || !diffLines.diffToolSupported {
+		return "", fmt.Errorf("The displayed diff cannot be opened in the difftool")
+	}
+
+	var path string
+	if headerIndex, found := findFileHeaderIndex(diffLines.lines, diffView.viewPos.ActiveRowIndex()); found {
+		path = diffHeaderPath(diffLines.lines[headerIndex].line)
+	}
+
+	return diffToolCommand(diffLines.diffToolArgs, path), nil
+}
+
 // HandleEvent does nothing
 func (diffView *DiffView) HandleEvent(event Event) (err error) {
 	return
@@ -518,9 +1420,16 @@ func (diffView *DiffView) OnSearchMatch(startPos ViewPos, matchLineIndex uint) {
 		return
 	}
 
+	diffView.recordJump()
 	viewPos.SetActiveRowIndex(matchLineIndex)
 }
 
+func (diffView *DiffView) recordJump() {
+	if diffLines, ok := diffView.diffs[diffView.activeDiff]; ok {
+		diffLines.jumpList.Record(JumpPosition{RowIndex: diffLines.viewPos.ActiveRowIndex()})
+	}
+}
+
 // HandleAction checks if the diff view supports the provided action and executes it if so
 func (diffView *DiffView) HandleAction(action Action) (err error) {
 	log.Debugf("DiffView handling action %v", action)
@@ -583,7 +1492,7 @@ func moveDownDiffLine(diffView *DiffView, action Action) (err error) {
 	lineNum := uint(len(diffLines.lines))
 	viewPos := diffView.viewPos
 
-	if viewPos.MoveLineDown(lineNum) {
+	if viewPos.MoveLinesDown(action.MoveCount(), lineNum) {
 		log.Debugf("Moving down one line in diff view")
 		diffView.channels.UpdateDisplay()
 	}
@@ -594,7 +1503,7 @@ func moveDownDiffLine(diffView *DiffView, action Action) (err error) {
 func moveUpDiffLine(diffView *DiffView, action Action) (err error) {
 	viewPos := diffView.viewPos
 
-	if viewPos.MoveLineUp() {
+	if viewPos.MoveLinesUp(action.MoveCount()) {
 		log.Debugf("Moving up one line in diff view")
 		diffView.channels.UpdateDisplay()
 	}
@@ -611,7 +1520,7 @@ func moveDownDiffPage(diffView *DiffView, action Action) (err error) {
 	lineNum := uint(len(diffLines.lines))
 	viewPos := diffView.viewPos
 
-	if viewPos.MovePageDown(diffView.viewDimension.rows-2, lineNum) {
+	if viewPos.MovePagesDown(action.MoveCount(), pageRows(diffView.viewDimension), lineNum) {
 		log.Debugf("Moving down one page in diff view")
 		diffView.channels.UpdateDisplay()
 	}
@@ -619,10 +1528,74 @@ func moveDownDiffPage(diffView *DiffView, action Action) (err error) {
 	return
 }
 
+func moveToNextDiffFile(diffView *DiffView, action Action) (err error) {
+	return diffView.moveToDiffLineOfType(dltGitDiffHeader, true, "file")
+}
+
+func moveToPrevDiffFile(diffView *DiffView, action Action) (err error) {
+	return diffView.moveToDiffLineOfType(dltGitDiffHeader, false, "file")
+}
+
+func moveToNextDiffHunk(diffView *DiffView, action Action) (err error) {
+	return diffView.moveToDiffLineOfType(dltHunkStart, true, "hunk")
+}
+
+func moveToPrevDiffHunk(diffView *DiffView, action Action) (err error) {
+	return diffView.moveToDiffLineOfType(dltHunkStart, false, "hunk")
+}
+
+// moveToDiffLineOfType moves the cursor to the next or previous line of the provided type
+func (diffView *DiffView) moveToDiffLineOfType(lineType diffLineType, forward bool, description string) (err error) {
+	diffLines, ok := diffView.diffs[diffView.activeDiff]
+	if !ok {
+		return
+	}
+
+	activeRowIndex := diffView.viewPos.ActiveRowIndex()
+
+	if lineIndex, found := findDiffLineOfType(diffLines.lines, activeRowIndex, lineType, forward); found {
+		log.Debugf("Moving to %v at line %v in diff view", description, lineIndex)
+		diffView.viewPos.SetActiveRowIndex(lineIndex)
+		diffView.channels.UpdateDisplay()
+	} else if forward {
+		diffView.channels.ReportStatus("No next %v", description)
+	} else {
+		diffView.channels.ReportStatus("No previous %v", description)
+	}
+
+	return
+}
+
+func findDiffLineOfType(lines []*diffLineData, startIndex uint, lineType diffLineType, forward bool) (lineIndex uint, found bool) {
+	lineNum := uint(len(lines))
+
+	for lineIndex = startIndex; ; {
+		if forward {
+			if lineIndex+1 >= lineNum {
+				return
+			}
+
+			lineIndex++
+		} else {
+			if lineIndex == 0 || lineIndex > lineNum {
+				return
+			}
+
+			lineIndex--
+		}
+
+		lines[lineIndex].determineDiffLineType()
+
+		if lines[lineIndex].lineType == lineType {
+			return lineIndex, true
+		}
+	}
+}
+
 func moveUpDiffPage(diffView *DiffView, action Action) (err error) {
 	viewPos := diffView.viewPos
 
-	if viewPos.MovePageUp(diffView.viewDimension.rows - 2) {
+	if viewPos.MovePagesUp(action.MoveCount(), pageRows(diffView.viewDimension)) {
 		log.Debugf("Moving up one page in diff view")
 		diffView.channels.UpdateDisplay()
 	}
@@ -639,8 +1612,8 @@ func moveDownDiffHalfPage(diffView *DiffView, action Action) (err error) {
 	lineNum := uint(len(diffLines.lines))
 	viewPos := diffView.viewPos
 
-	if viewPos.MovePageDown(diffView.viewDimension.rows/2-2, lineNum) {
-		log.Debugf("Moving down one page in diff view")
+	if viewPos.MoveHalfPagesDown(action.MoveCount(), pageRows(diffView.viewDimension), lineNum) {
+		log.Debugf("Moving down half a page in diff view")
 		diffView.channels.UpdateDisplay()
 	}
 
@@ -650,8 +1623,8 @@ func moveDownDiffHalfPage(diffView *DiffView, action Action) (err error) {
 func moveUpDiffHalfPage(diffView *DiffView, action Action) (err error) {
 	viewPos := diffView.viewPos
 
-	if viewPos.MovePageUp(diffView.viewDimension.rows/2 - 2) {
-		log.Debugf("Moving up one page in diff view")
+	if viewPos.MoveHalfPagesUp(action.MoveCount(), pageRows(diffView.viewDimension)) {
+		log.Debugf("Moving up half a page in diff view")
 		diffView.channels.UpdateDisplay()
 	}
 
@@ -678,8 +1651,244 @@ func scrollDiffViewLeft(diffView *DiffView, action Action) (err error) {
 	return
 }
 
+func toggleDiffViewWrap(diffView *DiffView, action Action) (err error) {
+	diffView.wrap = !diffView.wrap
+
+	if diffView.wrap {
+		diffView.channels.ReportStatus("Line wrapping enabled")
+	} else {
+		diffView.channels.ReportStatus("Line wrapping disabled")
+	}
+
+	diffView.channels.UpdateDisplay()
+
+	return
+}
+
+func toggleDiffViewLineNumbers(diffView *DiffView, action Action) (err error) {
+	diffView.lineNumbers = !diffView.lineNumbers
+	diffView.channels.UpdateDisplay()
+
+	return
+}
+
+func toggleDiffVisualSelect(diffView *DiffView, action Action) (err error) {
+	diffLines, ok := diffView.diffs[diffView.activeDiff]
+	if !ok || len(diffLines.lines) == 0 {
+		return
+	}
+
+	diffLines.visualActive = !diffLines.visualActive
+	diffLines.visualStart = diffView.viewPos.ActiveRowIndex()
+	diffView.channels.UpdateDisplay()
+
+	return
+}
+
+func yankDiffLines(diffView *DiffView, action Action) (err error) {
+	return diffView.yankSelectedLines(false)
+}
+
+func yankDiffLinesWithoutPrefix(diffView *DiffView, action Action) (err error) {
+	return diffView.yankSelectedLines(true)
+}
+
+// yankSelectedLines copies the lines in the visual selection, or the active line, to the clipboard and ends the selection
+func (diffView *DiffView) yankSelectedLines(stripPrefix bool) (err error) {
+	diffLines, ok := diffView.diffs[diffView.activeDiff]
+	if !ok || len(diffLines.lines) == 0 {
+		return
+	}
+
+	text, lineNum := diffLines.selectedText(diffView.viewPos.ActiveRowIndex(), stripPrefix)
+	diffLines.visualActive = false
+	diffView.channels.UpdateDisplay()
+
+	if err = CopyToClipboard(text, diffView.config); err != nil {
+		return
+	}
+
+	diffView.channels.ReportSuccess("Copied %v lines to the clipboard", lineNum)
+
+	return
+}
+
+// stageDiffLines stages the added and removed lines in the visual selection, or the active line,
+// by applying a patch containing only those lines to the index.
+// The diff is reloaded afterwards with the cursor kept on the same row
+func stageDiffLines(diffView *DiffView, action Action) (err error) {
+	diffLines, ok := diffView.diffs[diffView.activeDiff]
+	if !ok || len(diffLines.lines) == 0 {
+		return
+	}
+
+	if !diffLines.unstaged {
+		return fmt.Errorf("Lines can only be staged from the diff of unstaged changes")
+	}
+
+	if diffView.diffWhitespace() != DwNone {
+		return fmt.Errorf("Lines cannot be staged when whitespace changes are ignored")
+	}
+
+	activeRowIndex := diffView.viewPos.ActiveRowIndex()
+	startIndex, endIndex := diffLines.selectedLineRange(activeRowIndex)
+	endIndex = MinUint(endIndex, uint(len(diffLines.lines))-1)
+	selected := make(map[*diffLineData]bool)
+
+	for lineIndex := startIndex; lineIndex <= endIndex; lineIndex++ {
+		selected[diffLines.lines[lineIndex]] = true
+	}
+
+	patch, changes, err := partialPatch(diffLines.fullLines(), selected)
+	if err != nil {
+		return
+	}
+
+	if err = diffView.repoData.ApplyToIndex(patch); err != nil {
+		return
+	}
+
+	diffLines.visualActive = false
+	diffView.channels.ReportSuccess("Staged %v lines", changes)
+
+	if reloadDiff := diffView.reloadDiff; reloadDiff != nil {
+		go diffView.reloadDiffAtRow(reloadDiff, activeRowIndex)
+	}
+
+	return
+}
+
+// reloadDiffAtRow regenerates the displayed diff and moves the cursor to the provided row
+func (diffView *DiffView) reloadDiffAtRow(reloadDiff func(), rowIndex uint) {
+	defer RecoverPanic()
+
+	reloadDiff()
+
+	diffView.lock.Lock()
+	defer diffView.lock.Unlock()
+
+	if diffLines, ok := diffView.diffs[diffView.activeDiff]; ok && len(diffLines.lines) > 0 {
+		diffLines.viewPos.SetActiveRowIndex(MinUint(rowIndex, uint(len(diffLines.lines))-1))
+		diffView.channels.UpdateDisplay()
+	}
+}
+
+func openDiffLineIssue(diffView *DiffView, action Action) (err error) {
+	diffLines, ok := diffView.diffs[diffView.activeDiff]
+	if !ok || len(diffLines.lines) == 0 {
+		return
+	}
+
+	activeRowIndex := diffView.viewPos.ActiveRowIndex()
+	if activeRowIndex >= uint(len(diffLines.lines)) {
+		return
+	}
+
+	references := diffView.config.IssueReferences(diffLines.lines[activeRowIndex].line)
+	if len(references) == 0 {
+		return fmt.Errorf("No issue reference found on the selected line")
+	}
+
+	return openIssueReference(references[0], diffView.channels)
+}
+
+// openIssueReference opens the URL of the referenced issue in a browser
+func openIssueReference(reference *IssueReference, channels *Channels) (err error) {
+	if err = OpenInBrowser(reference.url); err != nil {
+		return
+	}
+
+	channels.ReportSuccess("Opened issue %v", reference.text)
+
+	return
+}
+
+func addDiffFileFilter(diffView *DiffView, action Action) (err error) {
+	if !(len(action.Args) > 0) {
+		return fmt.Errorf("Expected file pattern argument")
+	}
+
+	pattern, ok := action.Args[0].(string)
+	if !ok {
+		return fmt.Errorf("Expected file pattern argument to have type string")
+	}
+
+	pattern = strings.TrimSpace(pattern)
+	if err = validateFilePattern(pattern); err != nil {
+		return
+	}
+
+	diffView.setFilePattern(pattern)
+	diffView.channels.ReportStatus("Displaying files matching %v", pattern)
+
+	return
+}
+
+func removeDiffFileFilter(diffView *DiffView, action Action) (err error) {
+	if diffView.filePattern == "" {
+		diffView.channels.ReportStatus("No file filter applied to remove")
+		return
+	}
+
+	diffView.setFilePattern("")
+	diffView.channels.ReportStatus("Removed file filter")
+
+	return
+}
+
+// setFilePattern applies the file pattern to all loaded diffs
+func (diffView *DiffView) setFilePattern(pattern string) {
+	diffView.filePattern = pattern
+
+	for _, diffLines := range diffView.diffs {
+		diffLines.setFilePattern(pattern)
+	}
+
+	diffView.channels.UpdateDisplay()
+}
+
+func cycleDiffViewMergeParent(diffView *DiffView, action Action) (err error) {
+	if diffView.mergeParentCount == 0 {
+		diffView.channels.ReportStatus("Only the diffs of merge commits against their parents can be cycled")
+		return
+	}
+
+	diffView.mergeParent = (diffView.mergeParent + 1) % (diffView.mergeParentCount + 1)
+
+	if diffView.mergeParent == 0 {
+		diffView.channels.ReportStatus("Displaying combined diff against all parents")
+	} else {
+		diffView.channels.ReportStatus("Displaying diff against parent %v", diffView.mergeParent)
+	}
+
+	if reloadDiff := diffView.reloadDiff; reloadDiff != nil {
+		go func() {
+			defer RecoverPanic()
+			reloadDiff()
+		}()
+	}
+
+	return
+}
+
+func cycleDiffViewDiffBase(diffView *DiffView, action Action) (err error) {
+	diffView.diffBase = diffView.diffBase.Next()
+	diffView.channels.ReportStatus("Commits are diffed %v", diffView.diffBase.Description())
+
+	// The diff is regenerated asynchronously as reloading acquires the lock held while handling actions
+	if reloadDiff := diffView.reloadDiff; reloadDiff != nil {
+		go func() {
+			defer RecoverPanic()
+			reloadDiff()
+		}()
+	}
+
+	return
+}
+
 func moveToFirstDiffLine(diffView *DiffView, action Action) (err error) {
 	viewPos := diffView.viewPos
+	diffView.recordJump()
 
 	if viewPos.MoveToFirstLine() {
 		log.Debugf("Moving to first line in diff view")
@@ -697,6 +1906,7 @@ func moveToLastDiffLine(diffView *DiffView, action Action) (err error) {
 
 	lineNum := uint(len(diffLines.lines))
 	viewPos := diffView.viewPos
+	diffLines.jumpList.Record(JumpPosition{RowIndex: viewPos.ActiveRowIndex()})
 
 	if viewPos.MoveToLastLine(lineNum) {
 		log.Debugf("Moving to last line in diff view")
@@ -709,7 +1919,7 @@ func moveToLastDiffLine(diffView *DiffView, action Action) (err error) {
 func centerDiffView(diffView *DiffView, action Action) (err error) {
 	viewPos := diffView.viewPos
 
-	if viewPos.CenterActiveRow(diffView.viewDimension.rows - 2) {
+	if viewPos.CenterActiveRow(pageRows(diffView.viewDimension)) {
 		log.Debug("Centering DiffView")
 		diffView.channels.UpdateDisplay()
 	}
@@ -717,6 +1927,28 @@ func centerDiffView(diffView *DiffView, action Action) (err error) {
 	return
 }
 
+func scrollDiffViewCursorTop(diffView *DiffView, action Action) (err error) {
+	viewPos := diffView.viewPos
+
+	if viewPos.ScrollActiveRowTop() {
+		log.Debug("Scrolling DiffView so selected row is at the top")
+		diffView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func scrollDiffViewCursorBottom(diffView *DiffView, action Action) (err error) {
+	viewPos := diffView.viewPos
+
+	if viewPos.ScrollActiveRowBottom(pageRows(diffView.viewDimension)) {
+		log.Debug("Scrolling DiffView so selected row is at the bottom")
+		diffView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
 func selectDiffLine(diffView *DiffView, action Action) (err error) {
 	diffLines, ok := diffView.diffs[diffView.activeDiff]
 	if !ok {
@@ -726,33 +1958,201 @@ func selectDiffLine(diffView *DiffView, action Action) (err error) {
 	lineIndex := diffView.viewPos.ActiveRowIndex()
 	diffLine := diffLines.lines[lineIndex]
 
-	if diffLine.lineType != dltDiffStatsFile {
+	if diffLine.isFileHeader() {
+		return toggleDiffFileCollapse(diffView, action)
+	} else if diffLine.lineType != dltDiffStatsFile {
 		return
 	}
 
+	fileDiffIndex, err := findStatsFileDiffIndex(diffLines.lines, lineIndex)
+	if err != nil {
+		return
+	}
+
+	diffLines.jumpList.Record(JumpPosition{RowIndex: lineIndex})
+	diffView.jumpToDiffLine(diffLines, fileDiffIndex)
+
+	return
+}
+
+// findStatsFileDiffIndex returns the index of the git diff header of the file on the selected diff stats line.
+// Long paths are abbreviated in the diff stats with a leading "..." and renamed files are displayed as "old => new"
+func findStatsFileDiffIndex(lines []*diffLineData, lineIndex uint) (fileDiffIndex uint, err error) {
+	diffLine := lines[lineIndex]
 	sepIndex := strings.LastIndex(diffLine.line, "|")
 
 	if sepIndex == -1 || sepIndex >= len(diffLine.line)-1 {
-		return fmt.Errorf("Unable to determine file path from line: %v", diffLine.line)
+		return 0, fmt.Errorf("Unable to determine file path from line: %v", diffLine.line)
 	}
 
-	filePart := strings.TrimRight(diffLine.line[0:sepIndex], " ")
-	pattern := fmt.Sprintf("diff --git a/%v b/%v", filePart, filePart)
+	filePart := strings.TrimSpace(diffLine.line[0:sepIndex])
+	path := filePart
 
-	for lineIndex++; lineIndex < uint(len(diffLines.lines)); lineIndex++ {
-		diffLine = diffLines.lines[lineIndex]
+	if renameIndex := strings.LastIndex(path, dvStatsRenameSeparator); renameIndex != -1 {
+		path = path[renameIndex+len(dvStatsRenameSeparator):]
+	}
 
-		if strings.HasPrefix(diffLine.line, pattern) {
-			break
+	abbreviated := strings.HasPrefix(path, dvStatsAbbreviationPrefix)
+	path = strings.TrimPrefix(path, dvStatsAbbreviationPrefix)
+
+	for fileDiffIndex = lineIndex + 1; fileDiffIndex < uint(len(lines)); fileDiffIndex++ {
+		line := lines[fileDiffIndex].line
+
+		if !strings.HasPrefix(line, dvGitDiffHeaderPrefix) {
+			continue
+		}
+
+		newPathIndex := strings.LastIndex(line, " b/")
+		if newPathIndex == -1 {
+			continue
+		}
+
+		newPath := line[newPathIndex+len(" b/"):]
+
+		if newPath == path || (abbreviated && strings.HasSuffix(newPath, path)) {
+			return
 		}
 	}
 
-	if lineIndex >= uint(len(diffLines.lines)) {
-		return fmt.Errorf("Unable to find diff for file: %v", filePart)
+	return 0, fmt.Errorf("Unable to find diff for file: %v", filePart)
+}
+
+func toggleDiffFileCollapse(diffView *DiffView, action Action) (err error) {
+	diffLines, ok := diffView.diffs[diffView.activeDiff]
+	if !ok {
+		return
+	}
+
+	headerIndex, found := findFileHeaderIndex(diffLines.lines, diffView.viewPos.ActiveRowIndex())
+	if !found {
+		diffView.channels.ReportStatus("Select a file diff to collapse")
+		return
+	}
+
+	fileHeader := diffLines.lines[headerIndex]
+	fileHeader.collapsed = !fileHeader.collapsed
+	log.Debugf("Setting file %v to collapsed %v", fileHeader.line, fileHeader.collapsed)
+
+	diffLines.updateDisplayedLines()
+	diffView.channels.UpdateDisplay()
+
+	return
+}
+
+func collapseAllDiffFiles(diffView *DiffView, action Action) (err error) {
+	if diffLines, ok := diffView.diffs[diffView.activeDiff]; ok {
+		diffLines.setCollapsed(true)
+		diffView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func expandAllDiffFiles(diffView *DiffView, action Action) (err error) {
+	if diffLines, ok := diffView.diffs[diffView.activeDiff]; ok {
+		diffLines.setCollapsed(false)
+		diffView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func jumpBackDiffLine(diffView *DiffView, action Action) (err error) {
+	diffLines, ok := diffView.diffs[diffView.activeDiff]
+	if !ok {
+		return
+	}
+
+	if position, exists := diffLines.jumpList.Back(JumpPosition{RowIndex: diffLines.viewPos.ActiveRowIndex()}); exists {
+		diffView.jumpToDiffLine(diffLines, position.RowIndex)
+	}
+
+	return
+}
+
+func jumpForwardDiffLine(diffView *DiffView, action Action) (err error) {
+	diffLines, ok := diffView.diffs[diffView.activeDiff]
+	if !ok {
+		return
+	}
+
+	if position, exists := diffLines.jumpList.Forward(); exists {
+		diffView.jumpToDiffLine(diffLines, position.RowIndex)
+	}
+
+	return
+}
+
+func (diffView *DiffView) jumpToDiffLine(diffLines *diffLines, rowIndex uint) {
+	lineNum := uint(len(diffLines.lines))
+	if lineNum == 0 {
+		return
+	}
+
+	log.Debugf("Jumping to line %v in diff view", rowIndex)
+	diffLines.viewPos.SetActiveRowIndex(MinUint(rowIndex, lineNum-1))
+	diffLines.viewPos.CenterActiveRow(pageRows(diffView.viewDimension))
+	diffView.channels.UpdateDisplay()
+}
+
+func showDiffLineHistory(diffView *DiffView, action Action) (err error) {
+	diffLines, ok := diffView.diffs[diffView.activeDiff]
+	if !ok {
+		return
+	}
+
+	if diffLines.oid == "" {
+		return fmt.Errorf("Line history is only available for commit diffs")
 	}
 
-	diffView.viewPos.SetActiveRowIndex(lineIndex)
-	defer diffView.channels.UpdateDisplay()
+	lineRange, err := findDiffLineRange(diffLines.lines, diffView.viewPos.ActiveRowIndex(), diffLines.oid)
+	if err != nil {
+		return
+	}
 
-	return centerDiffView(diffView, action)
+	diffView.channels.DoAction(Action{
+		ActionType: ActionSplitView,
+		Args: []interface{}{
+			ActionSplitViewArgs{
+				CreateViewArgs: CreateViewArgs{
+					viewID:   ViewDiff,
+					viewArgs: []interface{}{lineRange},
+				},
+				orientation: CoDynamic,
+			},
+		},
+	})
+
+	return
+}
+
+func showDiffBlame(diffView *DiffView, action Action) (err error) {
+	diffLines, ok := diffView.diffs[diffView.activeDiff]
+	if !ok {
+		return
+	}
+
+	if diffLines.oid == "" {
+		return fmt.Errorf("Blame is only available for commit diffs")
+	}
+
+	lineRange, err := findDiffLineRange(diffLines.lines, diffView.viewPos.ActiveRowIndex(), diffLines.oid)
+	if err != nil {
+		return
+	}
+
+	diffView.channels.DoAction(Action{
+		ActionType: ActionSplitView,
+		Args: []interface{}{
+			ActionSplitViewArgs{
+				CreateViewArgs: CreateViewArgs{
+					viewID:   ViewBlame,
+					viewArgs: []interface{}{lineRange},
+				},
+				orientation: CoDynamic,
+			},
+		},
+	})
+
+	return
 }
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLineNumbersAreSetForLinesWithinHunks(t *testing.T) {
+	diffLines := &diffLines{}
+
+	for _, line := range []string{
+		"diff --git a/file.go b/file.go",
+		"--- a/file.go",
+		"+++ b/file.go",
+		"@@ -9,3 +9,4 @@ func main() {",
+		" context",
+		"-removed",
+		"+added",
+		"+added",
+		" context",
+		"\\ No newline at end of file",
+		"diff --git a/other.go b/other.go",
+		"@@ -100 +100 @@",
+		"-old",
+		"+new",
+	} {
+		diffLines.lines = append(diffLines.lines, &diffLineData{line: line})
+	}
+
+	expectedLineNumbers := [][]uint{
+		{0, 0}, {0, 0}, {0, 0}, {0, 0},
+		{9, 9}, {10, 0}, {0, 10}, {0, 11}, {11, 12}, {0, 0},
+		{0, 0}, {0, 0}, {100, 0}, {0, 100},
+	}
+
+	diffLines.setLineNumbers()
+
+	for lineIndex, diffLine := range diffLines.lines {
+		expected := expectedLineNumbers[lineIndex]
+
+		if diffLine.oldLineNumber != expected[0] || diffLine.newLineNumber != expected[1] {
+			t.Errorf("Line numbers for %q do not match expected value. Expected: %v, Actual: [%v %v]",
+				diffLine.line, expected, diffLine.oldLineNumber, diffLine.newLineNumber)
+		}
+	}
+
+	if diffLines.lineNumberWidth != 3 {
+		t.Errorf("Expected line number width 3 but found %v", diffLines.lineNumberWidth)
+	}
+
+	if gutter := diffLines.lineNumberGutter(diffLines.lines[6]); gutter != "     10 " {
+		t.Errorf("Line number gutter does not match expected value. Expected: %q, Actual: %q", "     10 ", gutter)
+	}
+}
+
+func TestStatsFileLinesAreMatchedToTheirFileDiff(t *testing.T) {
+	var lines []*diffLineData
+
+	for _, line := range []string{
+		"main.go | 2 +-",
+		".../very/long/path/util.go | 1 +",
+		"old.go => renamed.go | 0",
+		"3 files changed, 2 insertions(+), 1 deletion(-)",
+		"",
+		"diff --git a/main.go b/main.go",
+		"diff --git a/src/very/long/path/util.go b/src/very/long/path/util.go",
+		"diff --git a/old.go b/renamed.go",
+	} {
+		lines = append(lines, &diffLineData{line: line, lineType: dltDiffStatsFile})
+	}
+
+	for statsLineIndex, expectedIndex := range []uint{5, 6, 7} {
+		if fileDiffIndex, err := findStatsFileDiffIndex(lines, uint(statsLineIndex)); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		} else if fileDiffIndex != expectedIndex {
+			t.Errorf("File diff index does not match expected value for %q. Expected: %v, Actual: %v",
+				lines[statsLineIndex].line, expectedIndex, fileDiffIndex)
+		}
+	}
+
+	lines[0].line = "missing.go | 2 +-"
+
+	if _, err := findStatsFileDiffIndex(lines, 0); err == nil {
+		t.Errorf("Expected error for file with no diff")
+	}
+}
+
+func TestFindDiffLineOfType(t *testing.T) {
+	var lines []*diffLineData
+
+	for _, line := range []string{
+		"diff --git a/a.go b/a.go",
+		"@@ -1 +1 @@",
+		"-a",
+		"+b",
+		"diff --git a/b.go b/b.go",
+		"@@ -1 +1 @@",
+		" c",
+	} {
+		lines = append(lines, &diffLineData{line: line})
+	}
+
+	var findTests = []struct {
+		startIndex    uint
+		lineType      diffLineType
+		forward       bool
+		expectedIndex uint
+		expectedFound bool
+	}{
+		{startIndex: 0, lineType: dltGitDiffHeader, forward: true, expectedIndex: 4, expectedFound: true},
+		{startIndex: 4, lineType: dltGitDiffHeader, forward: true, expectedFound: false},
+		{startIndex: 6, lineType: dltGitDiffHeader, forward: false, expectedIndex: 4, expectedFound: true},
+		{startIndex: 2, lineType: dltHunkStart, forward: false, expectedIndex: 1, expectedFound: true},
+		{startIndex: 1, lineType: dltHunkStart, forward: true, expectedIndex: 5, expectedFound: true},
+		{startIndex: 0, lineType: dltHunkStart, forward: false, expectedFound: false},
+	}
+
+	for _, findTest := range findTests {
+		lineIndex, found := findDiffLineOfType(lines, findTest.startIndex, findTest.lineType, findTest.forward)
+
+		if found != findTest.expectedFound || (found && lineIndex != findTest.expectedIndex) {
+			t.Errorf("findDiffLineOfType result does not match expected value for start index %v. Expected: %v %v, Actual: %v %v",
+				findTest.startIndex, findTest.expectedIndex, findTest.expectedFound, lineIndex, found)
+		}
+	}
+}
+
+func TestCollapsedFilesAreHiddenAndCursorMovesToFileHeader(t *testing.T) {
+	diffLines := &diffLines{
+		viewPos: NewViewPosition(),
+	}
+
+	for _, line := range []string{
+		"diff --git a/a.go b/a.go",
+		"@@ -1 +1 @@",
+		"-a",
+		"+b",
+		"diff --git a/b.go b/b.go",
+		"@@ -1 +1 @@",
+		" c",
+	} {
+		diffLines.lines = append(diffLines.lines, &diffLineData{line: line})
+	}
+
+	diffLines.viewPos.SetActiveRowIndex(2)
+	diffLines.lines[0].collapsed = true
+	diffLines.updateDisplayedLines()
+
+	if lineNum := len(diffLines.lines); lineNum != 4 {
+		t.Fatalf("Expected 4 displayed lines but found %v", lineNum)
+	}
+
+	if activeRowIndex := diffLines.viewPos.ActiveRowIndex(); activeRowIndex != 0 {
+		t.Errorf("Expected cursor to move to collapsed file header but was on line %v", activeRowIndex)
+	}
+
+	if collapsedLines := diffLines.lines[0].collapsedLines; collapsedLines != 3 {
+		t.Errorf("Expected 3 collapsed lines but found %v", collapsedLines)
+	}
+
+	diffLines.viewPos.SetActiveRowIndex(3)
+	diffLines.setCollapsed(false)
+
+	if lineNum := len(diffLines.lines); lineNum != 7 {
+		t.Errorf("Expected 7 displayed lines but found %v", lineNum)
+	}
+
+	if activeRowIndex := diffLines.viewPos.ActiveRowIndex(); activeRowIndex != 6 {
+		t.Errorf("Expected cursor to remain on the same line but was on line %v", activeRowIndex)
+	}
+}
+
+func TestCombinedDiffLinesAreAddedOrRemovedRelativeToAnyParent(t *testing.T) {
+	var lines []*diffLineData
+
+	for _, line := range []string{
+		"diff --cc a.go",
+		"index 1234567,89abcde..0123456",
+		"--- a/a.go",
+		"+++ b/a.go",
+		"@@@ -1,2 -1,2 +1,3 @@@ func main() {",
+		"  unchanged",
+		"++both",
+		" +second",
+		"- first",
+		"\\ No newline at end of file",
+	} {
+		lines = append(lines, &diffLineData{line: line})
+	}
+
+	determineCombinedDiffLineTypes(lines)
+
+	expectedLineTypes := []diffLineType{
+		dltGitDiffHeader,
+		dltGitDiffExtendedHeader,
+		dltUnifiedDiffHeader,
+		dltUnifiedDiffHeader,
+		dltHunkStart,
+		dltNormal,
+		dltLineAdded,
+		dltLineAdded,
+		dltLineRemoved,
+		dltNormal,
+	}
+
+	for lineIndex, expectedLineType := range expectedLineTypes {
+		lines[lineIndex].determineDiffLineType()
+
+		if lineType := lines[lineIndex].lineType; lineType != expectedLineType {
+			t.Errorf("Line type does not match expected value for line %q. Expected: %v, Actual: %v", lines[lineIndex].line, expectedLineType, lineType)
+		}
+	}
+
+	if marker := hunkHeaderMarker(lines[4].line); marker != "@@@" {
+		t.Errorf("Hunk header marker does not match expected value. Expected: %q, Actual: %q", "@@@", marker)
+	}
+}
+
+func TestFilePatternHidesNonMatchingFilesAndUpdatesStatsSummary(t *testing.T) {
+	diffLines := &diffLines{
+		viewPos: NewViewPosition(),
+	}
+
+	for _, line := range []string{
+		"a.go     | 2 +-",
+		"README.md | 3 +++",
+		"2 files changed, 4 insertions(+), 1 deletion(-)",
+		"",
+		"diff --git a/a.go b/a.go",
+		"@@ -1 +1 @@",
+		"-a",
+		"+b",
+		"diff --git a/README.md b/README.md",
+		"@@ -0,0 +1,3 @@",
+		"+c",
+		"+d",
+		"+e",
+	} {
+		diffLine := &diffLineData{line: line}
+
+		if strings.Contains(line, "|") {
+			diffLine.lineType = dltDiffStatsFile
+		} else if strings.HasSuffix(line, "(-)") {
+			diffLine.lineType = dltNormal
+		}
+
+		diffLines.lines = append(diffLines.lines, diffLine)
+	}
+
+	diffLines.setFilePattern("*.go")
+
+	expectedLines := []string{
+		"a.go     | 2 +-",
+		"1 file changed, 1 insertion(+), 1 deletion(-)",
+		"",
+		"diff --git a/a.go b/a.go",
+		"@@ -1 +1 @@",
+		"-a",
+		"+b",
+	}
+
+	if len(diffLines.lines) != len(expectedLines) {
+		t.Fatalf("Expected %v displayed lines but found %v", len(expectedLines), len(diffLines.lines))
+	}
+
+	for lineIndex, expectedLine := range expectedLines {
+		if line := diffLines.lines[lineIndex].line; line != expectedLine {
+			t.Errorf("Line does not match expected value. Expected: %q, Actual: %q", expectedLine, line)
+		}
+	}
+
+	diffLines.setFilePattern("")
+
+	if lineNum := len(diffLines.lines); lineNum != 13 {
+		t.Errorf("Expected 13 displayed lines after removing the file pattern but found %v", lineNum)
+	}
+}
+
+func TestSelectedTextCanStripHunkLinePrefixes(t *testing.T) {
+	diffLines := &diffLines{}
+
+	for _, line := range []string{
+		"diff --git a/a.go b/a.go",
+		"@@ -1,2 +1,2 @@",
+		" context",
+		"-removed",
+		"+added",
+	} {
+		diffLines.lines = append(diffLines.lines, &diffLineData{line: line})
+	}
+
+	diffLines.visualActive = true
+	diffLines.visualStart = 4
+
+	if text, lineNum := diffLines.selectedText(1, false); text != "@@ -1,2 +1,2 @@\n context\n-removed\n+added\n" || lineNum != 4 {
+		t.Errorf("Selected text does not match expected value: %q (%v lines)", text, lineNum)
+	}
+
+	if text, _ := diffLines.selectedText(2, true); text != "context\nremoved\nadded\n" {
+		t.Errorf("Selected text without prefixes does not match expected value: %q", text)
+	}
+
+	diffLines.visualActive = false
+
+	if text, lineNum := diffLines.selectedText(0, true); text != "diff --git a/a.go b/a.go\n" || lineNum != 1 {
+		t.Errorf("Active line text does not match expected value: %q (%v lines)", text, lineNum)
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// DiffWhitespace describes which whitespace changes are ignored when generating diffs
+type DiffWhitespace int
+
+// The set of diff whitespace modes
+const (
+	DwNone DiffWhitespace = iota
+	DwAll
+	DwChange
+	DwTrailing
+	DwLeading
+)
+
+var diffWhitespaceNames = map[string]DiffWhitespace{
+	"none":     DwNone,
+	"all":      DwAll,
+	"change":   DwChange,
+	"trailing": DwTrailing,
+	"leading":  DwLeading,
+}
+
+var diffWhitespaceDescriptions = map[DiffWhitespace]string{
+	DwAll:      "ignoring all whitespace",
+	DwChange:   "ignoring whitespace changes",
+	DwTrailing: "ignoring trailing whitespace",
+	DwLeading:  "ignoring leading whitespace",
+}
+
+// ParseDiffWhitespace returns the diff whitespace mode with the provided name
+func ParseDiffWhitespace(name string) (diffWhitespace DiffWhitespace, err error) {
+	diffWhitespace, ok := diffWhitespaceNames[strings.ToLower(name)]
+	if !ok {
+		err = fmt.Errorf("Invalid diff whitespace mode %v. Valid values are none, all, change, trailing and leading", name)
+	}
+
+	return
+}
+
+// String returns the name of the diff whitespace mode
+func (diffWhitespace DiffWhitespace) String() string {
+	for name, value := range diffWhitespaceNames {
+		if value == diffWhitespace {
+			return name
+		}
+	}
+
+	return "none"
+}
+
+// Description returns a description of the whitespace changes ignored.
+// An empty string is returned when no whitespace changes are ignored
+func (diffWhitespace DiffWhitespace) Description() string {
+	return diffWhitespaceDescriptions[diffWhitespace]
+}
+
+// Next returns the mode following this one, wrapping back round to none
+func (diffWhitespace DiffWhitespace) Next() DiffWhitespace {
+	return (diffWhitespace + 1) % DiffWhitespace(len(diffWhitespaceNames))
+}
+
+// ignoreLeadingWhitespace removes changes to leading whitespace from a unified diff patch, as git is unable to ignore them.
+// A run of removed lines followed by the same number of added lines which only differ in leading whitespace
+// is replaced by the added lines as context, which leaves the line counts in the hunk header unchanged.
+// Hunks without any remaining changes are removed. The hunks of combined diffs are left unchanged
+func ignoreLeadingWhitespace(patch string) string {
+	lines := strings.SplitAfter(patch, "\n")
+	var buf bytes.Buffer
+
+	for lineIndex := 0; lineIndex < len(lines); {
+		if !strings.HasPrefix(lines[lineIndex], "@@ ") {
+			buf.WriteString(lines[lineIndex])
+			lineIndex++
+			continue
+		}
+
+		hunkEnd := lineIndex + 1
+		for hunkEnd < len(lines) && isHunkLine(lines[hunkEnd]) {
+			hunkEnd++
+		}
+
+		if hunkLines, changed := ignoreHunkLeadingWhitespace(lines[lineIndex+1 : hunkEnd]); changed {
+			buf.WriteString(lines[lineIndex])
+
+			for _, hunkLine := range hunkLines {
+				buf.WriteString(hunkLine)
+			}
+		}
+
+		lineIndex = hunkEnd
+	}
+
+	return buf.String()
+}
+
+func isHunkLine(line string) bool {
+	return line != "" && strings.ContainsAny(line[:1], " +-\\")
+}
+
+func ignoreHunkLeadingWhitespace(lines []string) (hunkLines []string, changed bool) {
+	for lineIndex := 0; lineIndex < len(lines); {
+		if !strings.HasPrefix(lines[lineIndex], "-") {
+			hunkLines = append(hunkLines, lines[lineIndex])
+			changed = changed || strings.HasPrefix(lines[lineIndex], "+")
+			lineIndex++
+			continue
+		}
+
+		addedStart := lineIndex
+		for addedStart < len(lines) && strings.HasPrefix(lines[addedStart], "-") {
+			addedStart++
+		}
+
+		addedEnd := addedStart
+		for addedEnd < len(lines) && strings.HasPrefix(lines[addedEnd], "+") {
+			addedEnd++
+		}
+
+		removed, added := lines[lineIndex:addedStart], lines[addedStart:addedEnd]
+
+		if onlyLeadingWhitespaceDiffers(removed, added) {
+			for _, line := range added {
+				hunkLines = append(hunkLines, " "+line[1:])
+			}
+		} else {
+			hunkLines = append(hunkLines, removed...)
+			hunkLines = append(hunkLines, added...)
+			changed = true
+		}
+
+		lineIndex = addedEnd
+	}
+
+	return
+}
+
+func onlyLeadingWhitespaceDiffers(removed, added []string) bool {
+	if len(removed) != len(added) {
+		return false
+	}
+
+	for lineIndex := range removed {
+		if strings.TrimLeft(removed[lineIndex][1:], " \t") != strings.TrimLeft(added[lineIndex][1:], " \t") {
+			return false
+		}
+	}
+
+	return true
+}
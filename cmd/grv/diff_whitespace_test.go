@@ -0,0 +1,83 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestDiffWhitespaceCyclesThroughAllModes(t *testing.T) {
+	expectedModes := []DiffWhitespace{DwAll, DwChange, DwTrailing, DwLeading, DwNone}
+	diffWhitespace := DwNone
+
+	for _, expectedMode := range expectedModes {
+		diffWhitespace = diffWhitespace.Next()
+
+		if diffWhitespace != expectedMode {
+			t.Errorf("Next diff whitespace mode does not match expected value. Expected: %v, Actual: %v", expectedMode, diffWhitespace)
+		}
+
+		if parsedMode, err := ParseDiffWhitespace(diffWhitespace.String()); err != nil || parsedMode != diffWhitespace {
+			t.Errorf("Unable to parse diff whitespace mode name %v: %v", diffWhitespace, err)
+		}
+	}
+}
+
+func TestInvalidDiffWhitespaceModeReturnsError(t *testing.T) {
+	if _, err := ParseDiffWhitespace("middle"); err == nil {
+		t.Errorf("Expected error for invalid diff whitespace mode")
+	}
+}
+
+func TestLeadingWhitespaceChangesAreIgnored(t *testing.T) {
+	patch := "diff --git a/main.go b/main.go\n" +
+		"--- a/main.go\n" +
+		"+++ b/main.go\n" +
+		"@@ -1,3 +1,3 @@\n" +
+		" func main() {\n" +
+		"-fmt.Println()\n" +
+		"+\tfmt.Println()\n" +
+		" }\n" +
+		"@@ -10,2 +10,2 @@\n" +
+		"-  return x \n" +
+		"+\treturn y\n" +
+		" }\n"
+
+	expected := "diff --git a/main.go b/main.go\n" +
+		"--- a/main.go\n" +
+		"+++ b/main.go\n" +
+		"@@ -10,2 +10,2 @@\n" +
+		"-  return x \n" +
+		"+\treturn y\n" +
+		" }\n"
+
+	if actual := ignoreLeadingWhitespace(patch); actual != expected {
+		t.Errorf("Patch does not match expected value. Expected: %q, Actual: %q", expected, actual)
+	}
+}
+
+func TestLeadingWhitespaceChangesAreReplacedByContext(t *testing.T) {
+	patch := "@@ -1,3 +1,4 @@\n" +
+		"-  a\n" +
+		"-b\n" +
+		"+a\n" +
+		"+\tb\n" +
+		" c\n" +
+		"+d\n" +
+		"@@ -5,1 +6,2 @@\n" +
+		"-e\n" +
+		"+ e\n" +
+		"+f\n"
+
+	expected := "@@ -1,3 +1,4 @@\n" +
+		" a\n" +
+		" \tb\n" +
+		" c\n" +
+		"+d\n" +
+		"@@ -5,1 +6,2 @@\n" +
+		"-e\n" +
+		"+ e\n" +
+		"+f\n"
+
+	if actual := ignoreLeadingWhitespace(patch); actual != expected {
+		t.Errorf("Patch does not match expected value. Expected: %q, Actual: %q", expected, actual)
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"time"
+)
+
+// DisplayScheduler coalesces display update requests so that at most one redraw
+// occurs per frame interval. A request received after the display has been idle
+// for at least a frame interval is drawn immediately
+type DisplayScheduler struct {
+	frameInterval time.Duration
+	lastDrawTime  time.Time
+	drawPending   bool
+	coalescedNum  uint
+}
+
+// NewDisplayScheduler creates a new scheduler which limits redraws to one per frame interval
+func NewDisplayScheduler(frameInterval time.Duration) *DisplayScheduler {
+	return &DisplayScheduler{
+		frameInterval: frameInterval,
+	}
+}
+
+// RequestDraw registers a display update request at the provided time.
+// If no draw is pending then true is returned along with the delay after which
+// the draw should occur. Requests received while a draw is pending are coalesced
+// into the pending draw and false is returned
+func (displayScheduler *DisplayScheduler) RequestDraw(now time.Time) (delay time.Duration, schedule bool) {
+	if displayScheduler.drawPending {
+		displayScheduler.coalescedNum++
+		return
+	}
+
+	displayScheduler.drawPending = true
+	schedule = true
+
+	if nextDrawTime := displayScheduler.lastDrawTime.Add(displayScheduler.frameInterval); nextDrawTime.After(now) {
+		delay = nextDrawTime.Sub(now)
+	}
+
+	return
+}
+
+// DrawStarted records that the pending draw is being performed at the provided time.
+// The number of requests which were coalesced into this draw is returned
+func (displayScheduler *DisplayScheduler) DrawStarted(now time.Time) (coalescedNum uint) {
+	coalescedNum = displayScheduler.coalescedNum
+
+	displayScheduler.drawPending = false
+	displayScheduler.coalescedNum = 0
+	displayScheduler.lastDrawTime = now
+
+	return
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDrawIsScheduledImmediatelyWhenDisplayIsIdle(t *testing.T) {
+	displayScheduler := NewDisplayScheduler(50 * time.Millisecond)
+	now := time.Now()
+
+	delay, schedule := displayScheduler.RequestDraw(now)
+
+	if !schedule {
+		t.Fatalf("Expected draw to be scheduled")
+	}
+
+	if delay != 0 {
+		t.Errorf("Draw delay does not match expected value. Expected: 0, Actual: %v", delay)
+	}
+}
+
+func TestRequestsAreCoalescedWhileDrawIsPending(t *testing.T) {
+	displayScheduler := NewDisplayScheduler(50 * time.Millisecond)
+	now := time.Now()
+
+	displayScheduler.RequestDraw(now)
+
+	for requestIndex := 0; requestIndex < 10; requestIndex++ {
+		if _, schedule := displayScheduler.RequestDraw(now); schedule {
+			t.Fatalf("Expected request to be coalesced into pending draw")
+		}
+	}
+
+	if coalescedNum := displayScheduler.DrawStarted(now); coalescedNum != 10 {
+		t.Errorf("Coalesced request number does not match expected value. Expected: 10, Actual: %v", coalescedNum)
+	}
+}
+
+func TestDrawIsDelayedUntilFrameIntervalHasElapsed(t *testing.T) {
+	frameInterval := 50 * time.Millisecond
+	displayScheduler := NewDisplayScheduler(frameInterval)
+	drawTime := time.Now()
+
+	displayScheduler.RequestDraw(drawTime)
+	displayScheduler.DrawStarted(drawTime)
+
+	delay, schedule := displayScheduler.RequestDraw(drawTime.Add(20 * time.Millisecond))
+
+	if !schedule {
+		t.Fatalf("Expected draw to be scheduled")
+	}
+
+	if expectedDelay := 30 * time.Millisecond; delay != expectedDelay {
+		t.Errorf("Draw delay does not match expected value. Expected: %v, Actual: %v", expectedDelay, delay)
+	}
+
+	displayScheduler.DrawStarted(drawTime.Add(frameInterval))
+
+	if delay, _ = displayScheduler.RequestDraw(drawTime.Add(3 * frameInterval)); delay != 0 {
+		t.Errorf("Draw delay does not match expected value. Expected: 0, Actual: %v", delay)
+	}
+}
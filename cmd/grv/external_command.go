@@ -0,0 +1,212 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	ecActionTypeStart = ActionType(1 << 17)
+	ecShell           = "/bin/sh"
+	ecCommit          = "commit"
+	ecBranch          = "branch"
+	ecFile            = "file"
+	ecRepo            = "repo"
+	ecEnvPrefix       = "GRV_"
+)
+
+var ecPlaceholderRegex = regexp.MustCompile(`%\(([a-zA-Z]+)\)`)
+
+var ecPlaceholders = map[string]bool{
+	ecCommit: true,
+	ecBranch: true,
+	ecFile:   true,
+	ecRepo:   true,
+}
+
+// ExternalCommandContextProvider is implemented by views which
+// can provide values for external command placeholders
+type ExternalCommandContextProvider interface {
+	ExternalCommandContext() map[string]string
+}
+
+// ExternalCommand is a shell command template which is run when
+// the key sequence it is bound to is entered
+type ExternalCommand struct {
+	template        string
+	captureOutput   bool
+	foreground      bool
+	pauseOnExit     bool
+	refreshInterval time.Duration
+}
+
+// ExternalCommandManager stores the external commands defined in config
+// Each external command is assigned its own action type.
+// Command views are stored by name
+type ExternalCommandManager struct {
+	keyBindings    KeyBindings
+	nextActionType ActionType
+	commands       map[ActionType]*ExternalCommand
+	views          map[string]*ExternalCommand
+	lock           sync.Mutex
+}
+
+// NewExternalCommandManager creates a new instance
+func NewExternalCommandManager(keyBindings KeyBindings) *ExternalCommandManager {
+	return &ExternalCommandManager{
+		keyBindings:    keyBindings,
+		nextActionType: ecActionTypeStart,
+		commands:       make(map[ActionType]*ExternalCommand),
+		views:          make(map[string]*ExternalCommand),
+	}
+}
+
+// RegisterCommand binds the provided keys to the external command template
+func (externalCommandManager *ExternalCommandManager) RegisterCommand(viewID ViewID, keys, template string, captureOutput bool) (err error) {
+	if err = validateExternalCommandTemplate(template); err != nil {
+		return
+	}
+
+	externalCommandManager.lock.Lock()
+	defer externalCommandManager.lock.Unlock()
+
+	actionType := externalCommandManager.nextActionType
+	externalCommandManager.nextActionType++
+
+	externalCommandManager.commands[actionType] = &ExternalCommand{
+		template:      template,
+		captureOutput: captureOutput,
+		pauseOnExit:   true,
+	}
+
+	externalCommandManager.keyBindings.SetActionBinding(viewID, keys, actionType)
+
+	log.Infof("Bound \"%v\" to external command \"%v\"", keys, template)
+
+	return
+}
+
+// Command returns the external command registered for the action type
+func (externalCommandManager *ExternalCommandManager) Command(actionType ActionType) (externalCommand *ExternalCommand, exists bool) {
+	externalCommandManager.lock.Lock()
+	defer externalCommandManager.lock.Unlock()
+
+	externalCommand, exists = externalCommandManager.commands[actionType]
+	return
+}
+
+// RegisterView defines a command view with the provided name which displays the output of the command template.
+// If refreshInterval is greater than 0 the command is rerun at that interval.
+// An existing command view with the same name is replaced
+func (externalCommandManager *ExternalCommandManager) RegisterView(name, template string, refreshInterval time.Duration) (err error) {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("Command view name cannot be empty")
+	}
+
+	if err = validateExternalCommandTemplate(template); err != nil {
+		return
+	}
+
+	externalCommandManager.lock.Lock()
+	defer externalCommandManager.lock.Unlock()
+
+	externalCommandManager.views[name] = &ExternalCommand{
+		template:        template,
+		captureOutput:   true,
+		refreshInterval: refreshInterval,
+	}
+
+	log.Infof("Registered command view \"%v\" for command \"%v\"", name, template)
+
+	return
+}
+
+// View returns the external command of the command view with the provided name
+func (externalCommandManager *ExternalCommandManager) View(name string) (externalCommand *ExternalCommand, exists bool) {
+	externalCommandManager.lock.Lock()
+	defer externalCommandManager.lock.Unlock()
+
+	externalCommand, exists = externalCommandManager.views[name]
+	return
+}
+
+func validateExternalCommandTemplate(template string) error {
+	if strings.TrimSpace(template) == "" {
+		return fmt.Errorf("Command cannot be empty")
+	}
+
+	for _, match := range ecPlaceholderRegex.FindAllStringSubmatch(template, -1) {
+		if !ecPlaceholders[match[1]] {
+			return fmt.Errorf("Invalid placeholder %v", match[0])
+		}
+	}
+
+	return nil
+}
+
+// Expand replaces the placeholders in the command template with
+// values from the provided context. Values are quoted for the shell
+func (externalCommand *ExternalCommand) Expand(context map[string]string) (command string, err error) {
+	command = ecPlaceholderRegex.ReplaceAllStringFunc(externalCommand.template, func(placeholder string) string {
+		name := ecPlaceholderRegex.FindStringSubmatch(placeholder)[1]
+
+		value, ok := context[name]
+		if !ok {
+			if err == nil {
+				err = fmt.Errorf("%v is not available in the current view", placeholder)
+			}
+
+			return placeholder
+		}
+
+		return shellQuote(value)
+	})
+
+	return
+}
+
+// Foreground returns true if the command must be run in the terminal GRV
+// is running in as GRV waits for it to complete
+func (externalCommand *ExternalCommand) Foreground() bool {
+	return externalCommand.foreground
+}
+
+// PauseOnExit returns true if GRV should wait for the user to press enter after the command
+// has exited, so its output can be read before the display is restored
+func (externalCommand *ExternalCommand) PauseOnExit() bool {
+	return externalCommand.pauseOnExit
+}
+
+// CaptureOutput returns true if the output of the command should
+// be displayed in a view rather than the terminal
+func (externalCommand *ExternalCommand) CaptureOutput() bool {
+	return externalCommand.captureOutput
+}
+
+// RefreshInterval returns the interval the command of a command view is rerun at.
+// The command is not rerun automatically if the interval is 0
+func (externalCommand *ExternalCommand) RefreshInterval() time.Duration {
+	return externalCommand.refreshInterval
+}
+
+// externalCommandEnvironment returns the values for external command placeholders as environment variables,
+// e.g. GRV_COMMIT, so they can be used by commands and the scripts they run
+func externalCommandEnvironment(context map[string]string) (env []string) {
+	for name, value := range context {
+		env = append(env, fmt.Sprintf("%v%v=%v", ecEnvPrefix, strings.ToUpper(name), value))
+	}
+
+	sort.Strings(env)
+
+	return
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.Replace(value, "'", `'\''`, -1) + "'"
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestExternalCommandPlaceholdersAreExpanded(t *testing.T) {
+	var expandTests = []struct {
+		template        string
+		context         map[string]string
+		expectedCommand string
+	}{
+		{
+			template:        "git show %(commit)",
+			context:         map[string]string{ecCommit: "4882ca9044661b49a26ae03ceb1be3a70d00c6a2"},
+			expectedCommand: "git show '4882ca9044661b49a26ae03ceb1be3a70d00c6a2'",
+		},
+		{
+			template:        "git log %(branch) -- %(file)",
+			context:         map[string]string{ecBranch: "master", ecFile: "cmd/grv/main.go"},
+			expectedCommand: "git log 'master' -- 'cmd/grv/main.go'",
+		},
+		{
+			template:        "cat %(file)",
+			context:         map[string]string{ecFile: "it's a file"},
+			expectedCommand: `cat 'it'\''s a file'`,
+		},
+		{
+			template:        "git status",
+			context:         map[string]string{},
+			expectedCommand: "git status",
+		},
+	}
+
+	for _, expandTest := range expandTests {
+		externalCommand := &ExternalCommand{template: expandTest.template}
+		command, err := externalCommand.Expand(expandTest.context)
+
+		if err != nil {
+			t.Errorf("Expand failed with error %v", err)
+		} else if command != expandTest.expectedCommand {
+			t.Errorf("Expanded command does not match expected value. Expected %v, Actual %v", expandTest.expectedCommand, command)
+		}
+	}
+}
+
+func TestErrorIsReturnedWhenPlaceholderValueIsUnavailable(t *testing.T) {
+	externalCommand := &ExternalCommand{template: "git show %(commit)"}
+
+	if _, err := externalCommand.Expand(map[string]string{ecFile: "main.go"}); err == nil {
+		t.Errorf("Expected Expand to return an error")
+	}
+}
+
+func TestInvalidExternalCommandTemplatesAreRejected(t *testing.T) {
+	var invalidTemplates = []string{
+		"",
+		"  ",
+		"git show %(sha)",
+	}
+
+	for _, invalidTemplate := range invalidTemplates {
+		if err := validateExternalCommandTemplate(invalidTemplate); err == nil {
+			t.Errorf("Expected template \"%v\" to be invalid", invalidTemplate)
+		}
+	}
+}
+
+func TestPlaceholderValuesAreProvidedAsEnvironmentVariables(t *testing.T) {
+	env := externalCommandEnvironment(map[string]string{
+		ecRepo:   "/home/user/src/grv",
+		ecCommit: "4882ca9044661b49a26ae03ceb1be3a70d00c6a2",
+		ecFile:   "it's a file",
+	})
+
+	expectedEnv := []string{
+		"GRV_COMMIT=4882ca9044661b49a26ae03ceb1be3a70d00c6a2",
+		"GRV_FILE=it's a file",
+		"GRV_REPO=/home/user/src/grv",
+	}
+
+	if !reflect.DeepEqual(env, expectedEnv) {
+		t.Errorf("Environment does not match expected value. Expected %v, Actual %v", expectedEnv, env)
+	}
+}
+
+func TestCommandViewsAreRegisteredByName(t *testing.T) {
+	externalCommandManager := NewExternalCommandManager(NewKeyBindingManager())
+
+	if err := externalCommandManager.RegisterView("ci-status", "ci-status %(commit)", 30*time.Second); err != nil {
+		t.Fatalf("RegisterView failed with error %v", err)
+	}
+
+	externalCommand, exists := externalCommandManager.View("ci-status")
+	if !exists {
+		t.Fatalf("Expected command view ci-status to be registered")
+	}
+
+	if refreshInterval := externalCommand.RefreshInterval(); refreshInterval != 30*time.Second {
+		t.Errorf("Refresh interval does not match expected value. Expected %v, Actual %v", 30*time.Second, refreshInterval)
+	}
+
+	command, err := externalCommand.Expand(map[string]string{ecCommit: "4882ca9"})
+	if err != nil {
+		t.Errorf("Expand failed with error %v", err)
+	} else if command != "ci-status '4882ca9'" {
+		t.Errorf("Expanded command does not match expected value. Expected %v, Actual %v", "ci-status '4882ca9'", command)
+	}
+
+	if _, exists = externalCommandManager.View("coverage"); exists {
+		t.Errorf("Expected command view coverage not to be registered")
+	}
+
+	if err = externalCommandManager.RegisterView("", "ci-status", 0); err == nil {
+		t.Errorf("Expected error for empty command view name")
+	}
+
+	if err = externalCommandManager.RegisterView("coverage", "coverage %(sha)", 0); err == nil {
+		t.Errorf("Expected error for invalid command template")
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+// FocusDirection is a direction focus can be moved in
+type FocusDirection int
+
+// The supported focus directions
+const (
+	FocusLeft FocusDirection = iota
+	FocusRight
+	FocusUp
+	FocusDown
+)
+
+// FocusRect is the area of the display a view occupies
+type FocusRect struct {
+	startRow uint
+	startCol uint
+	rows     uint
+	cols     uint
+}
+
+func (focusRect FocusRect) endRow() uint {
+	return focusRect.startRow + focusRect.rows
+}
+
+func (focusRect FocusRect) endCol() uint {
+	return focusRect.startCol + focusRect.cols
+}
+
+// FindFocusTarget determines which of the candidate areas focus should move to when moving
+// from the current area in the provided direction. The nearest candidate which lies entirely in
+// the direction of movement and overlaps the current area along the other axis is chosen.
+// Ties are broken by the size of the overlap. found is false if no candidate is suitable
+func FindFocusTarget(current FocusRect, candidates []FocusRect, direction FocusDirection) (targetIndex int, found bool) {
+	var bestDistance, bestOverlap uint
+
+	for candidateIndex, candidate := range candidates {
+		distance, overlap, valid := focusDistance(current, candidate, direction)
+		if !valid {
+			continue
+		}
+
+		if !found || distance < bestDistance || (distance == bestDistance && overlap > bestOverlap) {
+			targetIndex = candidateIndex
+			bestDistance = distance
+			bestOverlap = overlap
+			found = true
+		}
+	}
+
+	return
+}
+
+func focusDistance(current, candidate FocusRect, direction FocusDirection) (distance, overlap uint, valid bool) {
+	switch direction {
+	case FocusLeft:
+		if candidate.endCol() > current.startCol {
+			return
+		}
+
+		distance = current.startCol - candidate.endCol()
+		overlap = rangeOverlap(current.startRow, current.endRow(), candidate.startRow, candidate.endRow())
+	case FocusRight:
+		if candidate.startCol < current.endCol() {
+			return
+		}
+
+		distance = candidate.startCol - current.endCol()
+		overlap = rangeOverlap(current.startRow, current.endRow(), candidate.startRow, candidate.endRow())
+	case FocusUp:
+		if candidate.endRow() > current.startRow {
+			return
+		}
+
+		distance = current.startRow - candidate.endRow()
+		overlap = rangeOverlap(current.startCol, current.endCol(), candidate.startCol, candidate.endCol())
+	case FocusDown:
+		if candidate.startRow < current.endRow() {
+			return
+		}
+
+		distance = candidate.startRow - current.endRow()
+		overlap = rangeOverlap(current.startCol, current.endCol(), candidate.startCol, candidate.endCol())
+	default:
+		return
+	}
+
+	valid = overlap > 0 && candidate.rows > 0 && candidate.cols > 0
+
+	return
+}
+
+func rangeOverlap(start1, end1, start2, end2 uint) uint {
+	start := MaxUint(start1, start2)
+	end := MinUint(end1, end2)
+
+	if end <= start {
+		return 0
+	}
+
+	return end - start
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+)
+
+// Layout used by tests:
+//
+//	----------------------
+//	|      |      1      |
+//	|  0   |-------------|
+//	|      |      2      |
+//	----------------------
+var testFocusRects = []FocusRect{
+	{startRow: 0, startCol: 0, rows: 20, cols: 30},
+	{startRow: 0, startCol: 30, rows: 10, cols: 50},
+	{startRow: 10, startCol: 30, rows: 10, cols: 50},
+}
+
+func TestFocusMovesToAdjacentViewInDirection(t *testing.T) {
+	tests := []struct {
+		currentIndex   int
+		direction      FocusDirection
+		expectedIndex  int
+		expectedToMove bool
+	}{
+		{currentIndex: 1, direction: FocusDown, expectedIndex: 2, expectedToMove: true},
+		{currentIndex: 2, direction: FocusUp, expectedIndex: 1, expectedToMove: true},
+		{currentIndex: 1, direction: FocusLeft, expectedIndex: 0, expectedToMove: true},
+		{currentIndex: 2, direction: FocusLeft, expectedIndex: 0, expectedToMove: true},
+		{currentIndex: 0, direction: FocusLeft, expectedToMove: false},
+		{currentIndex: 0, direction: FocusUp, expectedToMove: false},
+		{currentIndex: 1, direction: FocusRight, expectedToMove: false},
+	}
+
+	for _, test := range tests {
+		targetIndex, found := FindFocusTarget(testFocusRects[test.currentIndex], testFocusRects, test.direction)
+
+		if found != test.expectedToMove {
+			t.Errorf("Expected focus move from %v in direction %v to be %v but was %v", test.currentIndex, test.direction, test.expectedToMove, found)
+		} else if found && targetIndex != test.expectedIndex {
+			t.Errorf("Focus target does not match expected value for move from %v in direction %v. Expected: %v, Actual: %v",
+				test.currentIndex, test.direction, test.expectedIndex, targetIndex)
+		}
+	}
+}
+
+func TestFocusMovesToViewWithLargestOverlap(t *testing.T) {
+	current := FocusRect{startRow: 0, startCol: 30, rows: 20, cols: 50}
+	candidates := []FocusRect{
+		{startRow: 0, startCol: 0, rows: 5, cols: 30},
+		{startRow: 5, startCol: 0, rows: 15, cols: 30},
+	}
+
+	targetIndex, found := FindFocusTarget(current, candidates, FocusLeft)
+
+	if !found || targetIndex != 1 {
+		t.Errorf("Focus target does not match expected value. Expected: 1, Actual: %v (found: %v)", targetIndex, found)
+	}
+}
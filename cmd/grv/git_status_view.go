@@ -60,6 +60,7 @@ type GitStatusViewListener interface {
 type GitStatusView struct {
 	repoData               RepoData
 	channels               *Channels
+	config                 Config
 	status                 *Status
 	renderedStatus         []*renderedStatusEntry
 	viewPos                ViewPos
@@ -72,24 +73,34 @@ type GitStatusView struct {
 }
 
 // NewGitStatusView created a new GitStatusView
-func NewGitStatusView(repoData RepoData, channels *Channels) *GitStatusView {
+func NewGitStatusView(repoData RepoData, channels *Channels, config Config) *GitStatusView {
 	gitStatusView := &GitStatusView{
 		repoData: repoData,
 		channels: channels,
+		config:   config,
 		viewPos:  NewViewPosition(),
 		handlers: map[ActionType]gitStatusViewHandler{
-			ActionPrevLine:     moveUpGitStatusEntry,
-			ActionNextLine:     moveDownGitStatusEntry,
-			ActionPrevPage:     moveUpGitStatusPage,
-			ActionNextPage:     moveDownGitStatusPage,
-			ActionPrevHalfPage: moveUpGitStatusHalfPage,
-			ActionNextHalfPage: moveDownGitStatusHalfPage,
-			ActionScrollRight:  scrollGitStatusViewRight,
-			ActionScrollLeft:   scrollGitStatusViewLeft,
-			ActionFirstLine:    moveToFirstGitStatusEntry,
-			ActionLastLine:     moveToLastGitStatusEntry,
-			ActionCenterView:   centerGitStatusView,
-			ActionSelect:       selectDiffEntry,
+			ActionPrevLine:               moveUpGitStatusEntry,
+			ActionNextLine:               moveDownGitStatusEntry,
+			ActionPrevPage:               moveUpGitStatusPage,
+			ActionNextPage:               moveDownGitStatusPage,
+			ActionPrevHalfPage:           moveUpGitStatusHalfPage,
+			ActionNextHalfPage:           moveDownGitStatusHalfPage,
+			ActionScrollRight:            scrollGitStatusViewRight,
+			ActionScrollLeft:             scrollGitStatusViewLeft,
+			ActionFirstLine:              moveToFirstGitStatusEntry,
+			ActionLastLine:               moveToLastGitStatusEntry,
+			ActionCenterView:             centerGitStatusView,
+			ActionScrollCursorTop:        scrollGitStatusViewCursorTop,
+			ActionScrollCursorBottom:     scrollGitStatusViewCursorBottom,
+			ActionSelect:                 selectDiffEntry,
+			ActionSaveStash:              saveGitStatusStash,
+			ActionAmendCommit:            amendCommit,
+			ActionAmendCommitEditMessage: amendCommitEditMessage,
+			ActionDiscardChanges:         discardGitStatusChanges,
+			ActionStageFile:              stageGitStatusFile,
+			ActionIgnoreFile:             ignoreGitStatusFile,
+			ActionIgnorePattern:          addGitStatusIgnorePattern,
 		},
 	}
 
@@ -119,7 +130,7 @@ func (gitStatusView *GitStatusView) Render(win RenderWindow) (err error) {
 	rows := win.Rows() - 2
 
 	viewPos := gitStatusView.ViewPos()
-	viewPos.DetermineViewStartRow(rows, renderedStatusNum)
+	viewPos.DetermineViewStartRow(rows, renderedStatusNum, uint(gitStatusView.config.GetInt(CfScrollOff)))
 	renderedStatusIndex := viewPos.ViewStartRowIndex()
 	startColumn := viewPos.ViewStartColumn()
 
@@ -232,6 +243,8 @@ func (gitStatusView *GitStatusView) notifyFileEntrySelected(renderedStatus *rend
 	log.Debugf("Notifying git status file selected listeners that file is selected")
 
 	go func() {
+		defer RecoverPanic()
+
 		for _, gitStatusViewListener := range gitStatusView.gitStatusViewListeners {
 			gitStatusViewListener.OnFileSelected(renderedStatus.statusType, renderedStatus.StatusEntry.diffDelta.NewFile.Path)
 		}
@@ -244,6 +257,8 @@ func (gitStatusView *GitStatusView) notifyStageGroupSelected(statusType StatusTy
 	log.Debugf("Notifying git status file selected listeners that a stage group is selected")
 
 	go func() {
+		defer RecoverPanic()
+
 		for _, gitStatusViewListener := range gitStatusView.gitStatusViewListeners {
 			gitStatusViewListener.OnStageGroupSelected(statusType)
 		}
@@ -256,6 +271,8 @@ func (gitStatusView *GitStatusView) notifyNoEntrySelected() {
 	log.Debugf("Notifying git status file selected listeners that no entry is selected")
 
 	go func() {
+		defer RecoverPanic()
+
 		for _, gitStatusViewListener := range gitStatusView.gitStatusViewListeners {
 			gitStatusViewListener.OnNoEntrySelected()
 		}
@@ -315,6 +332,25 @@ func (gitStatusView *GitStatusView) LineNumber() (lineNumber uint) {
 	return gitStatusView.lineNumber()
 }
 
+// ExternalCommandContext returns the path of the selected file
+func (gitStatusView *GitStatusView) ExternalCommandContext() map[string]string {
+	gitStatusView.lock.Lock()
+	defer gitStatusView.lock.Unlock()
+
+	context := map[string]string{}
+
+	activeRowIndex := gitStatusView.viewPos.ActiveRowIndex()
+	if activeRowIndex >= gitStatusView.lineNumber() {
+		return context
+	}
+
+	if statusEntry := gitStatusView.renderedStatus[activeRowIndex].StatusEntry; statusEntry != nil {
+		context[ecFile] = statusEntry.diffDelta.NewFile.Path
+	}
+
+	return context
+}
+
 // ViewPos returns the view position for this view
 func (gitStatusView *GitStatusView) ViewPos() ViewPos {
 	return gitStatusView.viewPos
@@ -474,13 +510,15 @@ func moveUpGitStatusEntry(gitStatusView *GitStatusView, action Action) (err erro
 	viewPos := gitStatusView.ViewPos()
 	renderedStatus := gitStatusView.renderedStatus
 
-	for viewPos.ActiveRowIndex() > 0 {
-		if !viewPos.MoveLineUp() {
-			return
-		}
+	for entryNum := action.MoveCount(); entryNum > 0 && viewPos.ActiveRowIndex() > 0; entryNum-- {
+		for viewPos.ActiveRowIndex() > 0 {
+			if !viewPos.MoveLineUp() {
+				return
+			}
 
-		if renderedStatus[viewPos.ActiveRowIndex()].text != "" {
-			break
+			if renderedStatus[viewPos.ActiveRowIndex()].text != "" {
+				break
+			}
 		}
 	}
 
@@ -502,13 +540,15 @@ func moveDownGitStatusEntry(gitStatusView *GitStatusView, action Action) (err er
 		return
 	}
 
-	for viewPos.ActiveRowIndex() < renderedStatusNum-1 {
-		if !viewPos.MoveLineDown(renderedStatusNum) {
-			return
-		}
+	for entryNum := action.MoveCount(); entryNum > 0 && viewPos.ActiveRowIndex() < renderedStatusNum-1; entryNum-- {
+		for viewPos.ActiveRowIndex() < renderedStatusNum-1 {
+			if !viewPos.MoveLineDown(renderedStatusNum) {
+				return
+			}
 
-		if renderedStatus[viewPos.ActiveRowIndex()].text != "" {
-			break
+			if renderedStatus[viewPos.ActiveRowIndex()].text != "" {
+				break
+			}
 		}
 	}
 
@@ -522,11 +562,11 @@ func moveDownGitStatusEntry(gitStatusView *GitStatusView, action Action) (err er
 }
 
 func moveUpGitStatusPage(gitStatusView *GitStatusView, action Action) (err error) {
-	pageSize := gitStatusView.viewDimension.rows - 2
+	pageSize := pageRows(gitStatusView.viewDimension) * action.MoveCount()
 	viewPos := gitStatusView.ViewPos()
 
 	for viewPos.ActiveRowIndex() > 0 && pageSize > 0 {
-		if err = moveUpGitStatusEntry(gitStatusView, action); err != nil {
+		if err = moveUpGitStatusEntry(gitStatusView, Action{ActionType: action.ActionType}); err != nil {
 			return
 		}
 
@@ -544,12 +584,12 @@ func moveUpGitStatusPage(gitStatusView *GitStatusView, action Action) (err error
 }
 
 func moveDownGitStatusPage(gitStatusView *GitStatusView, action Action) (err error) {
-	pageSize := gitStatusView.viewDimension.rows - 2
+	pageSize := pageRows(gitStatusView.viewDimension) * action.MoveCount()
 	viewPos := gitStatusView.ViewPos()
 	renderedStatusNum := gitStatusView.lineNumber()
 
 	for viewPos.ActiveRowIndex()+1 < renderedStatusNum && pageSize > 0 {
-		if err = moveDownGitStatusEntry(gitStatusView, action); err != nil {
+		if err = moveDownGitStatusEntry(gitStatusView, Action{ActionType: action.ActionType}); err != nil {
 			return
 		}
 
@@ -567,11 +607,11 @@ func moveDownGitStatusPage(gitStatusView *GitStatusView, action Action) (err err
 }
 
 func moveUpGitStatusHalfPage(gitStatusView *GitStatusView, action Action) (err error) {
-	halfPageSize := gitStatusView.viewDimension.rows/2 - 2
+	halfPageSize := halfPageRows(pageRows(gitStatusView.viewDimension)) * action.MoveCount()
 	viewPos := gitStatusView.ViewPos()
 
 	for viewPos.ActiveRowIndex() > 0 && halfPageSize > 0 {
-		if err = moveUpGitStatusEntry(gitStatusView, action); err != nil {
+		if err = moveUpGitStatusEntry(gitStatusView, Action{ActionType: action.ActionType}); err != nil {
 			return
 		}
 
@@ -589,12 +629,12 @@ func moveUpGitStatusHalfPage(gitStatusView *GitStatusView, action Action) (err e
 }
 
 func moveDownGitStatusHalfPage(gitStatusView *GitStatusView, action Action) (err error) {
-	halfPageSize := gitStatusView.viewDimension.rows/2 - 2
+	halfPageSize := halfPageRows(pageRows(gitStatusView.viewDimension)) * action.MoveCount()
 	viewPos := gitStatusView.ViewPos()
 	renderedStatusNum := gitStatusView.lineNumber()
 
 	for viewPos.ActiveRowIndex()+1 < renderedStatusNum && halfPageSize > 0 {
-		if err = moveDownGitStatusEntry(gitStatusView, action); err != nil {
+		if err = moveDownGitStatusEntry(gitStatusView, Action{ActionType: action.ActionType}); err != nil {
 			return
 		}
 
@@ -665,7 +705,7 @@ func moveToLastGitStatusEntry(gitStatusView *GitStatusView, action Action) (err
 func centerGitStatusView(gitStatusView *GitStatusView, action Action) (err error) {
 	viewPos := gitStatusView.ViewPos()
 
-	if viewPos.CenterActiveRow(gitStatusView.viewDimension.rows - 2) {
+	if viewPos.CenterActiveRow(pageRows(gitStatusView.viewDimension)) {
 		log.Debug("Centering GitStatusView")
 		gitStatusView.channels.UpdateDisplay()
 	}
@@ -673,6 +713,28 @@ func centerGitStatusView(gitStatusView *GitStatusView, action Action) (err error
 	return
 }
 
+func scrollGitStatusViewCursorTop(gitStatusView *GitStatusView, action Action) (err error) {
+	viewPos := gitStatusView.ViewPos()
+
+	if viewPos.ScrollActiveRowTop() {
+		log.Debug("Scrolling GitStatusView so selected row is at the top")
+		gitStatusView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func scrollGitStatusViewCursorBottom(gitStatusView *GitStatusView, action Action) (err error) {
+	viewPos := gitStatusView.ViewPos()
+
+	if viewPos.ScrollActiveRowBottom(pageRows(gitStatusView.viewDimension)) {
+		log.Debug("Scrolling GitStatusView so selected row is at the bottom")
+		gitStatusView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
 func selectDiffEntry(gitStatusView *GitStatusView, action Action) (err error) {
 	if len(gitStatusView.gitStatusViewListeners) == 0 {
 		gitStatusView.createGitStatusViewListener()
@@ -683,3 +745,242 @@ func selectDiffEntry(gitStatusView *GitStatusView, action Action) (err error) {
 
 	return
 }
+
+func saveGitStatusStash(gitStatusView *GitStatusView, action Action) error {
+	return saveStash(gitStatusView.repoData, gitStatusView.channels, action)
+}
+
+func amendCommit(gitStatusView *GitStatusView, action Action) error {
+	return gitStatusView.amendCommit(action, false)
+}
+
+func amendCommitEditMessage(gitStatusView *GitStatusView, action Action) error {
+	return gitStatusView.amendCommit(action, true)
+}
+
+// amendCommit requests confirmation before amending HEAD with the staged changes.
+// The message of HEAD is reused unless editMessage is set, in which case git commit
+// is run in the foreground so the message can be edited
+func (gitStatusView *GitStatusView) amendCommit(action Action, editMessage bool) (err error) {
+	if len(action.Args) == 0 {
+		question, pushed, err := gitStatusView.amendCommitQuestion(editMessage)
+		if err != nil {
+			return err
+		}
+
+		confirmedAction := Action{ActionType: action.ActionType, Args: []interface{}{true}}
+
+		if pushed {
+			gitStatusView.channels.ConfirmTyped(question, "amend", confirmedAction)
+		} else {
+			gitStatusView.channels.Confirm(question, confirmedAction)
+		}
+
+		return nil
+	}
+
+	if editMessage {
+		gitStatusView.channels.DoAction(Action{ActionType: ActionRunAmendCommit})
+		return
+	}
+
+	if err = gitStatusView.repoData.AmendCommit(); err != nil {
+		return
+	}
+
+	gitStatusView.channels.ReportSuccess("Amended HEAD with staged changes")
+
+	return
+}
+
+// amendCommitQuestion checks HEAD can be amended and generates the confirmation question.
+// A warning is included if HEAD is contained in a remote branch as amending it will require a force push
+func (gitStatusView *GitStatusView) amendCommitQuestion(editMessage bool) (question string, pushed bool, err error) {
+	if repositoryState := gitStatusView.repoData.RepositoryState(); repositoryState != "" {
+		err = fmt.Errorf("Unable to amend HEAD while a %v is in progress", repositoryState)
+		return
+	}
+
+	if !editMessage && (gitStatusView.status == nil || len(gitStatusView.status.Entries(StStaged)) == 0) {
+		err = fmt.Errorf("No staged changes to amend HEAD with")
+		return
+	}
+
+	head := gitStatusView.repoData.Head()
+	if head == nil {
+		err = fmt.Errorf("HEAD does not point to a commit to amend")
+		return
+	}
+
+	commit, err := gitStatusView.repoData.Commit(head.Oid())
+	if err != nil {
+		return
+	}
+
+	question = fmt.Sprintf("Amend commit %v \"%v\"", commit.oid.ShortID(), commit.commit.Summary())
+
+	var remoteBranch Branch
+	if remoteBranch, pushed = gitStatusView.repoData.RemoteBranchContaining(head.Oid()); pushed {
+		question = fmt.Sprintf("HEAD has been pushed to %v. %v and force push later", remoteBranch.Shorthand(), question)
+	}
+
+	return
+}
+
+// discardGitStatusChanges requests confirmation before discarding the changes to the selected file.
+// The confirmed action contains the status type and entry of the file to discard
+func discardGitStatusChanges(gitStatusView *GitStatusView, action Action) (err error) {
+	if len(action.Args) == 0 {
+		statusEntry, statusType, exists := gitStatusView.selectedStatusEntry()
+		if !exists {
+			return
+		}
+
+		question, err := discardChangesQuestion(statusEntry, statusType)
+		if err != nil {
+			return err
+		}
+
+		confirmedAction := Action{ActionType: action.ActionType, Args: []interface{}{statusType, statusEntry}}
+
+		if gitStatusView.config.GetBool(CfDiscardBackup) {
+			gitStatusView.channels.Confirm(question, confirmedAction)
+		} else {
+			gitStatusView.channels.ConfirmTyped(question, "discard", confirmedAction)
+		}
+
+		return nil
+	}
+
+	if len(action.Args) < 2 {
+		return fmt.Errorf("Expected status type and status entry arguments")
+	}
+
+	statusType, ok := action.Args[0].(StatusType)
+	if !ok {
+		return fmt.Errorf("Expected first argument to have type StatusType but got %T", action.Args[0])
+	}
+
+	statusEntry, ok := action.Args[1].(*StatusEntry)
+	if !ok {
+		return fmt.Errorf("Expected second argument to have type *StatusEntry but got %T", action.Args[1])
+	}
+
+	backup := gitStatusView.config.GetBool(CfDiscardBackup)
+	if err = gitStatusView.repoData.DiscardChanges(statusType, statusEntry, backup); err != nil {
+		return
+	}
+
+	path := statusEntry.diffDelta.NewFile.Path
+
+	if backup {
+		gitStatusView.channels.ReportSuccess("Discarded changes to %v. A backup was saved to the stash", path)
+	} else {
+		gitStatusView.channels.ReportSuccess("Discarded changes to %v", path)
+	}
+
+	return
+}
+
+// discardChangesQuestion checks the changes to the file of the status entry can be discarded and generates the confirmation question
+func discardChangesQuestion(statusEntry *StatusEntry, statusType StatusType) (question string, err error) {
+	path := statusEntry.diffDelta.NewFile.Path
+
+	switch statusType {
+	case StUnstaged:
+		question = fmt.Sprintf("Discard unstaged changes to %v", path)
+	case StStaged:
+		if statusEntry.statusEntryType == SetNew || statusEntry.statusEntryType == SetRenamed {
+			return "", fmt.Errorf("Unable to discard changes to %v as it does not exist in HEAD", path)
+		}
+
+		question = fmt.Sprintf("Discard staged and unstaged changes to %v", path)
+	case StUntracked:
+		return "", fmt.Errorf("Untracked files have no changes to discard")
+	case StConflicted:
+		return "", fmt.Errorf("Unable to discard changes to %v as it has conflicts", path)
+	}
+
+	return
+}
+
+// selectedStatusEntry returns the selected status entry and its status type.
+// False is returned if the selected line is not a file
+func (gitStatusView *GitStatusView) selectedStatusEntry() (statusEntry *StatusEntry, statusType StatusType, exists bool) {
+	activeRowIndex := gitStatusView.viewPos.ActiveRowIndex()
+	if activeRowIndex >= gitStatusView.lineNumber() {
+		return
+	}
+
+	renderedStatusEntry := gitStatusView.renderedStatus[activeRowIndex]
+	if renderedStatusEntry.StatusEntry == nil {
+		return
+	}
+
+	return renderedStatusEntry.StatusEntry, renderedStatusEntry.statusType, true
+}
+
+// stageGitStatusFile adds the selected untracked or unstaged file to the index
+func stageGitStatusFile(gitStatusView *GitStatusView, action Action) (err error) {
+	statusEntry, statusType, exists := gitStatusView.selectedStatusEntry()
+	if !exists {
+		return
+	}
+
+	path := statusEntry.diffDelta.NewFile.Path
+
+	switch statusType {
+	case StStaged:
+		return fmt.Errorf("%v is already staged", path)
+	case StConflicted:
+		return fmt.Errorf("Resolve the conflicts in %v before staging it", path)
+	}
+
+	if err = gitStatusView.repoData.StageFile(path); err != nil {
+		return
+	}
+
+	gitStatusView.channels.ReportSuccess("Staged %v", path)
+
+	return
+}
+
+// ignoreGitStatusFile requests confirmation before adding a pattern matching only the selected untracked file to .gitignore
+func ignoreGitStatusFile(gitStatusView *GitStatusView, action Action) (err error) {
+	statusEntry, statusType, exists := gitStatusView.selectedStatusEntry()
+	if !exists {
+		return
+	}
+
+	if statusType != StUntracked {
+		return fmt.Errorf("Only untracked files can be ignored")
+	}
+
+	pattern := ignorePattern(statusEntry.diffDelta.NewFile.Path)
+
+	gitStatusView.channels.Confirm(fmt.Sprintf("Add %v to %v", pattern, giFileName), Action{
+		ActionType: ActionIgnorePattern,
+		Args:       []interface{}{pattern},
+	})
+
+	return
+}
+
+func addGitStatusIgnorePattern(gitStatusView *GitStatusView, action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected ignore pattern argument")
+	}
+
+	pattern, ok := action.Args[0].(string)
+	if !ok {
+		return fmt.Errorf("Expected ignore pattern argument to have type string but got %T", action.Args[0])
+	}
+
+	if err = gitStatusView.repoData.AddIgnorePattern(pattern); err != nil {
+		return
+	}
+
+	gitStatusView.channels.ReportSuccess("Added %v to %v", pattern, giFileName)
+
+	return
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"strings"
+)
+
+const (
+	giFileName     = ".gitignore"
+	giSpecialChars = "\\*?["
+)
+
+// ignorePattern returns a gitignore pattern which only matches the file or directory at the provided path.
+// The pattern is anchored to the root of the repository and characters with a special meaning are escaped
+func ignorePattern(path string) string {
+	var pattern strings.Builder
+	pattern.WriteString("/")
+
+	for _, char := range path {
+		if strings.ContainsRune(giSpecialChars, char) {
+			pattern.WriteString("\\")
+		}
+
+		pattern.WriteRune(char)
+	}
+
+	// Trailing spaces are ignored unless they are escaped
+	if escapedPattern := pattern.String(); strings.HasSuffix(escapedPattern, " ") {
+		return strings.TrimSuffix(escapedPattern, " ") + "\\ "
+	}
+
+	return pattern.String()
+}
+
+// appendIgnorePattern returns the gitignore file content with the pattern added on its own line.
+// False is returned if the content already contains the pattern
+func appendIgnorePattern(content, pattern string) (updatedContent string, appended bool) {
+	for _, line := range strings.Split(content, "\n") {
+		if strings.TrimRight(line, "\r") == pattern {
+			return content, false
+		}
+	}
+
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+
+	return content + pattern + "\n", true
+}
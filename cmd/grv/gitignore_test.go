@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestIgnorePatternMatchesOnlyProvidedPath(t *testing.T) {
+	ignorePatternTests := []struct {
+		path            string
+		expectedPattern string
+	}{
+		{path: "build.log", expectedPattern: "/build.log"},
+		{path: "tmp/", expectedPattern: "/tmp/"},
+		{path: "docs/notes[1]*.txt", expectedPattern: "/docs/notes\\[1]\\*.txt"},
+		{path: "what?\\", expectedPattern: "/what\\?\\\\"},
+		{path: "#draft", expectedPattern: "/#draft"},
+		{path: "trailing ", expectedPattern: "/trailing\\ "},
+	}
+
+	for _, ignorePatternTest := range ignorePatternTests {
+		if pattern := ignorePattern(ignorePatternTest.path); pattern != ignorePatternTest.expectedPattern {
+			t.Errorf("Pattern does not match expected value for path %q. Expected: %q, Actual: %q",
+				ignorePatternTest.path, ignorePatternTest.expectedPattern, pattern)
+		}
+	}
+}
+
+func TestIgnorePatternIsAppendedOnItsOwnLine(t *testing.T) {
+	appendIgnorePatternTests := []struct {
+		content          string
+		expectedContent  string
+		expectedAppended bool
+	}{
+		{content: "", expectedContent: "/build.log\n", expectedAppended: true},
+		{content: "*.o\n", expectedContent: "*.o\n/build.log\n", expectedAppended: true},
+		{content: "*.o", expectedContent: "*.o\n/build.log\n", expectedAppended: true},
+		{content: "*.o\r\n/build.log\r\n", expectedContent: "*.o\r\n/build.log\r\n", expectedAppended: false},
+	}
+
+	for _, appendIgnorePatternTest := range appendIgnorePatternTests {
+		content, appended := appendIgnorePattern(appendIgnorePatternTest.content, "/build.log")
+
+		if content != appendIgnorePatternTest.expectedContent || appended != appendIgnorePatternTest.expectedAppended {
+			t.Errorf("Appending to %q did not produce expected result. Expected: %q %v, Actual: %q %v",
+				appendIgnorePatternTest.content, appendIgnorePatternTest.expectedContent,
+				appendIgnorePatternTest.expectedAppended, content, appended)
+		}
+	}
+}
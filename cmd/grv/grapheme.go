@@ -0,0 +1,107 @@
+package main
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	gcZeroWidthJoiner                  = '\u200D'
+	gcEmojiVariationSelector           = '\uFE0F'
+	gcRegionalIndicatorStart           = '\U0001F1E6'
+	gcRegionalIndicatorEnd             = '\U0001F1FF'
+	gcEmojiModifierStart               = '\U0001F3FB'
+	gcEmojiModifierEnd                 = '\U0001F3FF'
+	gcTagStart                         = '\U000E0020'
+	gcTagEnd                           = '\U000E007F'
+	gcEmojiPresentationWidth           = 2
+	gcVariationSelectorsStart          = '\uFE00'
+	gcVariationSelectorsEnd            = '\uFE0F'
+	gcVariationSelectorSupplementStart = '\U000E0100'
+	gcVariationSelectorSupplementEnd   = '\U000E01EF'
+)
+
+// NextGraphemeCluster splits off the first user perceived character of str.
+// A cluster is a base code point followed by any combining marks, variation selectors
+// and emoji modifiers. Emoji joined by zero width joiners and pairs of regional
+// indicators (flags) also form a single cluster
+func NextGraphemeCluster(str string) (cluster, rest string) {
+	if str == "" {
+		return
+	}
+
+	first, size := utf8.DecodeRuneInString(str)
+	end := size
+	regionalIndicators := 0
+
+	if isRegionalIndicator(first) {
+		regionalIndicators++
+	}
+
+	for end < len(str) {
+		codePoint, size := utf8.DecodeRuneInString(str[end:])
+
+		switch {
+		case codePoint == gcZeroWidthJoiner:
+			end += size
+
+			if end < len(str) {
+				if joined, joinedSize := utf8.DecodeRuneInString(str[end:]); !unicode.IsControl(joined) {
+					end += joinedSize
+				}
+			}
+		case isGraphemeExtender(codePoint):
+			end += size
+		case regionalIndicators == 1 && isRegionalIndicator(codePoint):
+			end += size
+			regionalIndicators++
+		default:
+			return str[:end], str[end:]
+		}
+	}
+
+	return str, ""
+}
+
+// GraphemeClusterWidth returns the number of columns the cluster is displayed in.
+// Clusters requesting emoji presentation and flags are displayed as wide characters
+func GraphemeClusterWidth(cluster string) uint {
+	first, size := utf8.DecodeRuneInString(cluster)
+
+	if IsNonPrintableCharacter(first) {
+		width := uint(0)
+		for _, codePoint := range cluster {
+			width += uint(RuneWidth(codePoint))
+		}
+
+		return width
+	}
+
+	width := uint(RuneWidth(first))
+
+	if size < len(cluster) && width > 0 {
+		if isRegionalIndicator(first) {
+			return gcEmojiPresentationWidth
+		}
+
+		for _, codePoint := range cluster[size:] {
+			if codePoint == gcEmojiVariationSelector {
+				return gcEmojiPresentationWidth
+			}
+		}
+	}
+
+	return width
+}
+
+func isGraphemeExtender(codePoint rune) bool {
+	return unicode.In(codePoint, unicode.Mn, unicode.Me, unicode.Mc) ||
+		(codePoint >= gcVariationSelectorsStart && codePoint <= gcVariationSelectorsEnd) ||
+		(codePoint >= gcVariationSelectorSupplementStart && codePoint <= gcVariationSelectorSupplementEnd) ||
+		(codePoint >= gcEmojiModifierStart && codePoint <= gcEmojiModifierEnd) ||
+		(codePoint >= gcTagStart && codePoint <= gcTagEnd)
+}
+
+func isRegionalIndicator(codePoint rune) bool {
+	return codePoint >= gcRegionalIndicatorStart && codePoint <= gcRegionalIndicatorEnd
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestNextGraphemeCluster(t *testing.T) {
+	var graphemeClusterTests = []struct {
+		arg              string
+		expectedClusters []string
+	}{
+		{
+			arg:              "",
+			expectedClusters: nil,
+		},
+		{
+			arg:              "abc",
+			expectedClusters: []string{"a", "b", "c"},
+		},
+		{
+			arg:              "éx",
+			expectedClusters: []string{"é", "x"},
+		},
+		{
+			arg:              "\U0001F468\u200D\U0001F469\u200D\U0001F467 fix",
+			expectedClusters: []string{"\U0001F468\u200D\U0001F469\u200D\U0001F467", " ", "f", "i", "x"},
+		},
+		{
+			arg:              "\U0001F1EC\U0001F1E7\U0001F1FA\U0001F1F8\U0001F1EB",
+			expectedClusters: []string{"\U0001F1EC\U0001F1E7", "\U0001F1FA\U0001F1F8", "\U0001F1EB"},
+		},
+		{
+			arg:              "\U0001F44D\U0001F3FD!",
+			expectedClusters: []string{"\U0001F44D\U0001F3FD", "!"},
+		},
+		{
+			arg:              "❤\uFE0F",
+			expectedClusters: []string{"❤\uFE0F"},
+		},
+	}
+
+	for _, graphemeClusterTest := range graphemeClusterTests {
+		var actualClusters []string
+
+		for cluster, rest := NextGraphemeCluster(graphemeClusterTest.arg); cluster != ""; cluster, rest = NextGraphemeCluster(rest) {
+			actualClusters = append(actualClusters, cluster)
+		}
+
+		if len(actualClusters) != len(graphemeClusterTest.expectedClusters) {
+			t.Errorf("Cluster count does not match expected value. Expected: %q, Actual: %q", graphemeClusterTest.expectedClusters, actualClusters)
+			continue
+		}
+
+		for index, expectedCluster := range graphemeClusterTest.expectedClusters {
+			if actualClusters[index] != expectedCluster {
+				t.Errorf("Cluster does not match expected value. Expected: %q, Actual: %q", expectedCluster, actualClusters[index])
+			}
+		}
+	}
+}
+
+func TestGraphemeClusterWidth(t *testing.T) {
+	var graphemeClusterWidthTests = []struct {
+		arg            string
+		expectedResult uint
+	}{
+		{
+			arg:            "a",
+			expectedResult: 1,
+		},
+		{
+			arg:            "é",
+			expectedResult: 1,
+		},
+		{
+			arg:            "\U0001F468\u200D\U0001F469\u200D\U0001F467",
+			expectedResult: 2,
+		},
+		{
+			arg:            "\U0001F1EC\U0001F1E7",
+			expectedResult: 2,
+		},
+		{
+			arg:            "\U0001F44D\U0001F3FD",
+			expectedResult: 2,
+		},
+		{
+			arg:            "❤\uFE0F",
+			expectedResult: 2,
+		},
+		{
+			arg:            "\t",
+			expectedResult: 2,
+		},
+	}
+
+	for _, graphemeClusterWidthTest := range graphemeClusterWidthTests {
+		actualResult := GraphemeClusterWidth(graphemeClusterWidthTest.arg)
+
+		if actualResult != graphemeClusterWidthTest.expectedResult {
+			t.Errorf("GraphemeClusterWidth return value does not match expected value for %q. Expected: %v, Actual: %v", graphemeClusterWidthTest.arg, graphemeClusterWidthTest.expectedResult, actualResult)
+		}
+	}
+}
@@ -2,8 +2,12 @@
 package main
 
 import (
+	"bufio"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
+	"os/exec"
 	"os/signal"
 	"strings"
 	"sync"
@@ -23,6 +27,10 @@ const (
 	grvMaxDrawFrequency      = time.Millisecond * 50
 	grvMinErrorDisplay       = time.Second * 2
 	grvMaxGitStatusFrequency = time.Millisecond * 500
+	grvPollDisabledInterval  = time.Second
+	grvMergeToolCommand      = "git mergetool -- %(file)"
+	grvRebaseTodoFilePrefix  = "grv-rebase-todo"
+	grvEditorCommand         = `set -- %(file) && eval "$(git var GIT_EDITOR) \"\$@\""`
 )
 
 type gRVChannels struct {
@@ -78,11 +86,19 @@ type GRV struct {
 	repoData       *RepositoryData
 	view           *View
 	ui             UI
+	layout         *LayoutManager
 	channels       gRVChannels
 	config         *Configuration
+	keyBindings    KeyBindings
 	inputBuffer    *InputBuffer
 	input          *InputKeyMapper
 	eventListeners []EventListener
+	plugins        *PluginManager
+	commands       *ExternalCommandManager
+	completion     *CommandCompletion
+	pager          bool
+	controlSocket  *ControlSocket
+	fsMonitor      FileSystemMonitorStatus
 }
 
 // UpdateDisplay sends a request to update the display
@@ -93,6 +109,23 @@ func (channels *Channels) UpdateDisplay() {
 	}
 }
 
+// ChannelQueueDepth contains the number of items queued on a channel
+type ChannelQueueDepth struct {
+	name     string
+	depth    int
+	capacity int
+}
+
+// QueueDepths returns the number of items currently queued on each channel
+func (channels *Channels) QueueDepths() []ChannelQueueDepth {
+	return []ChannelQueueDepth{
+		{name: "display", depth: len(channels.displayCh), capacity: cap(channels.displayCh)},
+		{name: "error", depth: len(channels.errorCh), capacity: cap(channels.errorCh)},
+		{name: "action", depth: len(channels.actionCh), capacity: cap(channels.actionCh)},
+		{name: "event", depth: len(channels.eventCh), capacity: cap(channels.eventCh)},
+	}
+}
+
 // Exit returns true if GRV is in the process of exiting
 func (channels *Channels) Exit() bool {
 	select {
@@ -137,16 +170,53 @@ func (channels *Channels) ReportEvent(event Event) {
 
 // ReportStatus updates the status bar with the provided status
 func (channels *Channels) ReportStatus(format string, args ...interface{}) {
+	channels.reportStatusMessage(SmlInfo, format, args...)
+}
+
+// ReportSuccess updates the status bar with a message confirming an action succeeded
+func (channels *Channels) ReportSuccess(format string, args ...interface{}) {
+	channels.reportStatusMessage(SmlSuccess, format, args...)
+}
+
+// ReportWarning updates the status bar with a warning
+func (channels *Channels) ReportWarning(format string, args ...interface{}) {
+	channels.reportStatusMessage(SmlWarning, format, args...)
+}
+
+// ReportFailure updates the status bar with a message stating an action failed.
+// Errors which should be listed in the error view are reported using ReportError
+func (channels *Channels) ReportFailure(format string, args ...interface{}) {
+	channels.reportStatusMessage(SmlError, format, args...)
+}
+
+func (channels *Channels) reportStatusMessage(level StatusMessageLevel, format string, args ...interface{}) {
 	status := fmt.Sprintf(format, args...)
 
 	if status != "" {
 		channels.DoAction(Action{
 			ActionType: ActionShowStatus,
-			Args:       []interface{}{status},
+			Args:       []interface{}{StatusMessage{level: level, text: status}},
 		})
 	}
 }
 
+// Confirm asks the user to confirm the action before it is performed
+func (channels *Channels) Confirm(question string, action Action) {
+	channels.DoAction(Action{
+		ActionType: ActionConfirmPrompt,
+		Args:       []interface{}{Confirmation{question: question, action: action}},
+	})
+}
+
+// ConfirmTyped asks the user to confirm the action by entering the provided response before it is performed.
+// This is used for actions whose effects cannot be undone
+func (channels *Channels) ConfirmTyped(question, typedResponse string, action Action) {
+	channels.DoAction(Action{
+		ActionType: ActionConfirmPrompt,
+		Args:       []interface{}{Confirmation{question: question, action: action, typedResponse: typedResponse}},
+	})
+}
+
 // NewGRV creates a new instace of GRV
 func NewGRV() *GRV {
 	grvChannels := gRVChannels{
@@ -163,38 +233,122 @@ func NewGRV() *GRV {
 	repoDataLoader := NewRepoDataLoader(channels)
 	repoData := NewRepositoryData(repoDataLoader, channels)
 	keyBindings := NewKeyBindingManager()
-	config := NewConfiguration(keyBindings, channels)
+	plugins := NewPluginManager(repoData, keyBindings, channels)
+	commands := NewExternalCommandManager(keyBindings)
+	completion := NewCommandCompletion(repoData)
+	config := NewConfiguration(keyBindings, channels, plugins, commands, completion)
+	repoData.SetConfig(config)
+	ListenForLogConfigChanges(config, channels)
 	ui := NewNCursesDisplay(config)
-	view := NewView(repoData, channels, config)
+	layout := NewLayoutManager(ui.ViewDimension)
+	layout.SetConfig(config)
+	view := NewView(repoData, channels, config, plugins, layout)
+	layout.AddResizeListener(view)
 
 	return &GRV{
 		repoData:       repoData,
 		view:           view,
 		ui:             ui,
+		layout:         layout,
 		channels:       grvChannels,
 		config:         config,
+		keyBindings:    keyBindings,
 		inputBuffer:    NewInputBuffer(keyBindings),
 		input:          NewInputKeyMapper(ui),
 		eventListeners: []EventListener{view, repoData},
+		plugins:        plugins,
+		commands:       commands,
+		completion:     completion,
 	}
 }
 
-// Initialise sets up all the components of GRV
-func (grv *GRV) Initialise(repoPath string) (err error) {
+// Initialise sets up all the components of GRV.
+// The state saved in the previous session is discarded if cleanSession is true.
+// If startRef is not empty then it is selected instead of the ref saved in the previous session
+func (grv *GRV) Initialise(repoPath, startRef string, cleanSession bool) (err error) {
 	log.Info("Initialising GRV")
 
 	if err = grv.repoData.Initialise(repoPath); err != nil {
 		return
 	}
 
+	if cleanSession {
+		log.Info("Starting with a clean session")
+		grv.repoData.Session().Clear()
+	}
+
+	if startRef != "" {
+		log.Infof("Starting with ref %v selected", startRef)
+		grv.repoData.Session().SetStartRef(startRef)
+	}
+
 	if err = grv.ui.Initialise(); err != nil {
 		return
 	}
 
+	SetPanicTerminalRestorer(grv.ui.RestoreTerminal)
+
+	// Config is loaded before views are initialised so that repository
+	// specific settings such as the default branch apply on start up
+	if configErrors := grv.config.Initialise(); configErrors != nil {
+		for _, configError := range configErrors {
+			grv.channels.errorCh <- configError
+		}
+	}
+
+	if configErrors := grv.config.LoadRepositoryConfig(grv.repoData.Path()); configErrors != nil {
+		for _, configError := range configErrors {
+			grv.channels.errorCh <- configError
+		}
+	}
+
 	if err = grv.view.Initialise(); err != nil {
 		return
 	}
 
+	channels := grv.channels.Channels()
+	InitReadLine(channels, grv.ui, grv.config, grv.completion)
+
+	return
+}
+
+// LoadCommandFile processes the configuration commands in the provided file.
+// It is loaded after the config files so the commands can override their settings.
+// Errors in individual commands are displayed once GRV is running
+func (grv *GRV) LoadCommandFile(commandFilePath string) (err error) {
+	fileInfo, err := os.Stat(commandFilePath)
+	if err != nil {
+		return fmt.Errorf("Unable to load command file: %v", err)
+	} else if fileInfo.IsDir() {
+		return fmt.Errorf("Unable to load command file: %v is a directory", commandFilePath)
+	}
+
+	log.Infof("Loading command file %v", commandFilePath)
+
+	for _, configError := range grv.config.LoadFile(commandFilePath) {
+		grv.channels.errorCh <- configError
+	}
+
+	return
+}
+
+// InitialisePager sets up GRV to display the provided text
+// instead of the contents of a repository
+func (grv *GRV) InitialisePager(reader io.Reader) (err error) {
+	log.Info("Initialising GRV in pager mode")
+
+	grv.pager = true
+
+	if err = grv.ui.Initialise(); err != nil {
+		return
+	}
+
+	SetPanicTerminalRestorer(grv.ui.RestoreTerminal)
+
+	if err = grv.view.InitialisePager(pgInputName, reader); err != nil {
+		return
+	}
+
 	if configErrors := grv.config.Initialise(); configErrors != nil {
 		for _, configError := range configErrors {
 			grv.channels.errorCh <- configError
@@ -202,7 +356,25 @@ func (grv *GRV) Initialise(repoPath string) (err error) {
 	}
 
 	channels := grv.channels.Channels()
-	InitReadLine(channels, grv.ui, grv.config)
+	InitReadLine(channels, grv.ui, grv.config, grv.completion)
+
+	return
+}
+
+// InitialiseControlSocket creates a unix socket at the provided path
+// which external tools can use to send requests to GRV
+func (grv *GRV) InitialiseControlSocket(path string) (err error) {
+	if grv.pager {
+		return fmt.Errorf("A control socket cannot be used in pager mode")
+	}
+
+	controlSocket := NewControlSocket(path, grv.repoData, grv.channels.Channels())
+
+	if err = controlSocket.Initialise(); err != nil {
+		return
+	}
+
+	grv.controlSocket = controlSocket
 
 	return
 }
@@ -217,16 +389,148 @@ func (grv *GRV) Free() {
 }
 
 // Suspend prepares GRV to be suspended and sends a SIGTSTP
-// to every process in the process group
+// to every process in the process group.
+// The default SIGTSTP action is restored so the signal stops GRV rather than being
+// caught by the signal handler loop, which handles SIGTSTP again once GRV is continued
 func (grv *GRV) Suspend() {
 	log.Info("Suspending GRV")
 
 	grv.ui.Suspend()
+	signal.Reset(syscall.SIGTSTP)
+
 	if err := syscall.Kill(0, syscall.SIGTSTP); err != nil {
 		log.Errorf("Kill syscall failed. Error when attempting to suspend GRV: %v", err)
 	}
 }
 
+// Refresh reloads HEAD, refs and the working tree status.
+// Commits are reloaded for any refs which have been updated
+func (grv *GRV) Refresh() {
+	log.Info("Refreshing repository data")
+
+	channels := grv.channels.Channels()
+	channels.ReportStatus("Refreshing")
+
+	if err := grv.repoData.LoadStatus(); err != nil {
+		channels.ReportError(err)
+	}
+
+	grv.repoData.LoadRefs(func(refs []Ref) error {
+		channels.ReportStatus("Refreshed %v refs", len(refs))
+		return nil
+	})
+}
+
+// runMergeTool runs the configured git merge tool on the selected conflicted file
+// and reloads the status afterwards to determine whether the conflict was resolved
+func (grv *GRV) runMergeTool() (err error) {
+	path := grv.externalCommandContext()[ecFile]
+
+	if err = grv.runExternalCommand(&ExternalCommand{template: grvMergeToolCommand, foreground: true}); err != nil {
+		return
+	}
+
+	if err = grv.repoData.LoadStatus(); err != nil {
+		return
+	}
+
+	conflicts, err := grv.repoData.Conflicts()
+	if err != nil {
+		return
+	}
+
+	for _, conflict := range conflicts {
+		if conflict.path == path {
+			grv.channels.Channels().ReportStatus("%v is still conflicted", path)
+			return
+		}
+	}
+
+	grv.channels.Channels().ReportStatus("Resolved conflicts in %v", path)
+
+	return
+}
+
+// runDiffTool opens the diff displayed in the active view in the configured git difftool
+func (grv *GRV) runDiffTool() (err error) {
+	for _, activeView := range grv.view.ActiveViewHierarchy() {
+		if commandProvider, ok := activeView.(DiffToolCommandProvider); ok {
+			var command string
+			if command, err = commandProvider.DiffToolCommand(); err != nil {
+				return
+			}
+
+			return grv.runExternalCommand(&ExternalCommand{template: command})
+		}
+	}
+
+	return fmt.Errorf("The difftool can only be run from the Diff View")
+}
+
+// runBinaryPreview runs the configured binary preview command on the file of the selected diff
+func (grv *GRV) runBinaryPreview() error {
+	template := grv.config.GetString(CfBinaryPreview)
+	if template == "" {
+		return fmt.Errorf("No preview command is configured. Set %v to a command such as \"feh %%(file)\"", CfBinaryPreview)
+	}
+
+	return grv.runExternalCommand(&ExternalCommand{template: template})
+}
+
+// runInteractiveRebase runs git rebase -i using the todo list generated from the commands assigned in the Commit View.
+// The todo list is installed by setting GIT_SEQUENCE_EDITOR to a command which copies it over the list git generates
+func (grv *GRV) runInteractiveRebase(action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected interactive rebase argument")
+	}
+
+	args, ok := action.Args[0].(ActionRunInteractiveRebaseArgs)
+	if !ok {
+		return fmt.Errorf("Expected first argument to have type ActionRunInteractiveRebaseArgs but found %T", action.Args[0])
+	}
+
+	todoFile, err := ioutil.TempFile("", grvRebaseTodoFilePrefix)
+	if err != nil {
+		return fmt.Errorf("Unable to create rebase todo list: %v", err)
+	}
+	defer os.Remove(todoFile.Name())
+
+	_, err = todoFile.WriteString(strings.Join(args.todo, "\n") + "\n")
+	if closeErr := todoFile.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return fmt.Errorf("Unable to write rebase todo list: %v", err)
+	}
+
+	base := "--root"
+	if args.base != "" {
+		base = args.base
+	}
+
+	command := fmt.Sprintf("GIT_SEQUENCE_EDITOR=%v git rebase -i %v", shellQuote("cp "+shellQuote(todoFile.Name())), base)
+
+	if err = grv.runExternalCommand(&ExternalCommand{template: command, foreground: true}); err != nil {
+		return
+	}
+
+	grv.Refresh()
+
+	return
+}
+
+// runAmendCommit amends HEAD with the staged changes allowing the commit message to be edited
+func (grv *GRV) runAmendCommit() (err error) {
+	if err = grv.runExternalCommand(&ExternalCommand{template: "git commit --amend", foreground: true}); err != nil {
+		return
+	}
+
+	grv.Refresh()
+
+	return
+}
+
 // Resume is called on receipt of a SIGCONT and reinitialises the UI
 func (grv *GRV) Resume() {
 	log.Info("Resuming GRV")
@@ -238,6 +542,196 @@ func (grv *GRV) Resume() {
 	grv.channels.displayCh <- true
 }
 
+// externalCommandContext returns the values for external command placeholders provided by the active views
+func (grv *GRV) externalCommandContext() map[string]string {
+	context := map[string]string{
+		ecRepo: grv.repoData.Workdir(),
+	}
+
+	for _, activeView := range grv.view.ActiveViewHierarchy() {
+		if contextProvider, ok := activeView.(ExternalCommandContextProvider); ok {
+			for name, value := range contextProvider.ExternalCommandContext() {
+				context[name] = value
+			}
+		}
+	}
+
+	return context
+}
+
+// runExternalCommand expands the command template using values provided by the active views.
+// The output of the command is either displayed in a new view, the command is run in a new tmux
+// pane or window or the command is run in the terminal with the UI suspended until it completes.
+// The placeholder values are also provided to commands which are not run in tmux as environment variables
+func (grv *GRV) runExternalCommand(externalCommand *ExternalCommand) (err error) {
+	if grv.pager {
+		return fmt.Errorf("External commands cannot be run in pager mode")
+	}
+
+	context := grv.externalCommandContext()
+
+	command, err := externalCommand.Expand(context)
+	if err != nil {
+		return
+	}
+
+	env := externalCommandEnvironment(context)
+
+	if externalCommand.CaptureOutput() {
+		log.Infof("Running command and capturing output: %v", command)
+
+		grv.channels.Channels().DoAction(Action{
+			ActionType: ActionSplitView,
+			Args: []interface{}{
+				ActionSplitViewArgs{
+					CreateViewArgs: CreateViewArgs{
+						viewID:   ViewCommandOutput,
+						viewArgs: []interface{}{command, env},
+					},
+					orientation: CoDynamic,
+				},
+			},
+		})
+
+		return
+	}
+
+	if tmuxCommandMode, _ := ParseTmuxCommandMode(grv.config.GetString(CfTmuxCommands)); tmuxCommandMode != TcmSuspend &&
+		insideTmux() && !externalCommand.Foreground() {
+		return grv.runExternalCommandInTmux(command, tmuxCommandMode)
+	}
+
+	log.Infof("Running command: %v", command)
+
+	grv.ui.Suspend()
+
+	cmd := exec.Command(ecShell, "-c", command)
+	cmd.Dir = grv.repoData.Workdir()
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err = cmd.Run(); err != nil {
+		err = fmt.Errorf("Command \"%v\" failed: %v", command, err)
+	}
+
+	if externalCommand.PauseOnExit() {
+		fmt.Print("\nPress Enter to return to GRV")
+		if _, readErr := bufio.NewReader(os.Stdin).ReadString('\n'); readErr != nil {
+			log.Errorf("Unable to read from stdin: %v", readErr)
+		}
+	}
+
+	grv.Resume()
+
+	return
+}
+
+// runShellCommand runs a command entered in the command prompt, e.g. :!git log, and displays its output in a new view
+func (grv *GRV) runShellCommand(action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected shell command argument")
+	}
+
+	command, ok := action.Args[0].(string)
+	if !ok {
+		return fmt.Errorf("Expected shell command argument of type string but got type %T", action.Args[0])
+	}
+
+	if err = validateExternalCommandTemplate(command); err != nil {
+		return
+	}
+
+	return grv.runExternalCommand(&ExternalCommand{template: command, captureOutput: true})
+}
+
+// evaluateConfigCommand evaluates a configuration command on behalf of another goroutine and
+// returns the errors it produced on the provided result channel
+func (grv *GRV) evaluateConfigCommand(action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected config command argument")
+	}
+
+	args, ok := action.Args[0].(ActionEvaluateConfigCommandArgs)
+	if !ok {
+		return fmt.Errorf("Expected first argument to have type ActionEvaluateConfigCommandArgs but found %T", action.Args[0])
+	}
+
+	args.resultCh <- grv.config.Evaluate(args.command)
+
+	return
+}
+
+// expandCommandViewArgs replaces the name of a command view being added or split with the command
+// it runs and the environment it is run with. The command template is expanded using values provided by
+// the active views when the command view is created, so reruns of the command use the same values
+func (grv *GRV) expandCommandViewArgs(action Action) (err error) {
+	if len(action.Args) == 0 {
+		return
+	}
+
+	switch args := action.Args[0].(type) {
+	case ActionAddViewArgs:
+		err = grv.expandCommandViewCreateArgs(&args.CreateViewArgs)
+		action.Args[0] = args
+	case ActionSplitViewArgs:
+		err = grv.expandCommandViewCreateArgs(&args.CreateViewArgs)
+		action.Args[0] = args
+	}
+
+	return
+}
+
+func (grv *GRV) expandCommandViewCreateArgs(createViewArgs *CreateViewArgs) (err error) {
+	if createViewArgs.viewID != ViewCommand {
+		return
+	}
+
+	if grv.pager {
+		return fmt.Errorf("Command views cannot be created in pager mode")
+	}
+
+	if len(createViewArgs.viewArgs) != 1 {
+		return fmt.Errorf("Expected command view name argument")
+	}
+
+	name, ok := createViewArgs.viewArgs[0].(string)
+	if !ok {
+		return fmt.Errorf("Expected command view name argument of type string but got type %T", createViewArgs.viewArgs[0])
+	}
+
+	externalCommand, exists := grv.commands.View(name)
+	if !exists {
+		return fmt.Errorf("No command view defined with name %v", name)
+	}
+
+	context := grv.externalCommandContext()
+
+	command, err := externalCommand.Expand(context)
+	if err != nil {
+		return
+	}
+
+	createViewArgs.viewArgs = []interface{}{name, command, externalCommandEnvironment(context), externalCommand.RefreshInterval()}
+
+	return
+}
+
+// runExternalCommandInTmux runs the command in a new tmux pane or window
+// GRV continues running and does not wait for the command to complete
+func (grv *GRV) runExternalCommandInTmux(command string, tmuxCommandMode TmuxCommandMode) (err error) {
+	args := tmuxCommandArgs(tmuxCommandMode, grv.repoData.Workdir(), command)
+
+	log.Infof("Running command in tmux: %v", args)
+
+	if output, cmdErr := exec.Command(args[0], args[1:]...).CombinedOutput(); cmdErr != nil {
+		err = fmt.Errorf("Unable to run command \"%v\" in tmux: %v %v", command, cmdErr, strings.TrimSpace(string(output)))
+	}
+
+	return
+}
+
 // End signals GRV to stop
 func (grv *GRV) End() {
 	log.Info("Stopping GRV")
@@ -263,17 +757,32 @@ func (grv *GRV) Run() {
 	go grv.runHandlerLoop(&waitGroup, channels.exitCh, channels.inputKeyCh, channels.actionCh, channels.errorCh, channels.eventCh)
 	waitGroup.Add(1)
 	go grv.runSignalHandlerLoop(&waitGroup, channels.exitCh)
-	waitGroup.Add(1)
-	go grv.runFileSystemMonitorLoop(&waitGroup, channels.exitCh)
+
+	if !grv.pager {
+		waitGroup.Add(1)
+		go grv.runFileSystemMonitorLoop(&waitGroup, channels.exitCh)
+		waitGroup.Add(1)
+		go grv.runPollingLoop(&waitGroup, channels.exitCh)
+	}
+
+	if grv.controlSocket != nil {
+		waitGroup.Add(1)
+		go grv.runControlSocketLoop(&waitGroup, channels.exitCh)
+	}
 
 	channels.displayCh <- true
 
 	log.Info("Waiting for loops to finish")
 	waitGroup.Wait()
 	log.Info("All loops finished")
+
+	if err := grv.repoData.Session().Save(); err != nil {
+		log.Errorf("Unable to save session: %v", err)
+	}
 }
 
 func (grv *GRV) runInputLoop(waitGroup *sync.WaitGroup, exitCh chan bool, inputKeyCh chan<- string, errorCh chan<- error) {
+	defer RecoverPanic()
 	defer waitGroup.Done()
 	defer log.Info("Input loop stopping")
 	log.Info("Starting input loop")
@@ -303,6 +812,7 @@ func (grv *GRV) runInputLoop(waitGroup *sync.WaitGroup, exitCh chan bool, inputK
 }
 
 func (grv *GRV) runDisplayLoop(waitGroup *sync.WaitGroup, exitCh <-chan bool, displayCh <-chan bool, errorCh chan error) {
+	defer RecoverPanic()
 	defer waitGroup.Done()
 	defer log.Info("Display loop stopping")
 	log.Info("Starting display loop")
@@ -311,21 +821,35 @@ func (grv *GRV) runDisplayLoop(waitGroup *sync.WaitGroup, exitCh <-chan bool, di
 	lastErrorReceivedTime := time.Now()
 	channels := &Channels{errorCh: errorCh}
 
+	displayScheduler := NewDisplayScheduler(grvMaxDrawFrequency)
 	timer := time.NewTimer(time.Hour)
 	timer.Stop()
-	timerActive := false
+
+	requestDraw := func() {
+		if delay, schedule := displayScheduler.RequestDraw(time.Now()); schedule {
+			timer.Reset(delay)
+		}
+	}
 
 	for {
 		select {
 		case <-displayCh:
 			log.Debug("Received display refresh request")
+			requestDraw()
 
-			if !timerActive {
-				timer.Reset(grvMaxDrawFrequency)
-				timerActive = true
+		DisplayLoop:
+			for {
+				select {
+				case <-displayCh:
+					requestDraw()
+				default:
+					break DisplayLoop
+				}
 			}
 		case <-timer.C:
-			timerActive = false
+			if coalescedNum := displayScheduler.DrawStarted(time.Now()); coalescedNum > 0 {
+				log.Debugf("Coalesced %v display refresh requests", coalescedNum)
+			}
 
 			if lastErrorReceivedTime.Before(time.Now().Add(-grvMinErrorDisplay)) {
 				errors = nil
@@ -335,7 +859,7 @@ func (grv *GRV) runDisplayLoop(waitGroup *sync.WaitGroup, exitCh <-chan bool, di
 
 			log.Debug("Refreshing display - Display refresh request received since last check")
 
-			viewDimension := grv.ui.ViewDimension()
+			viewDimension := grv.layout.Recalculate()
 
 			wins, err := grv.view.Render(viewDimension)
 			if err != nil {
@@ -363,11 +887,7 @@ func (grv *GRV) runDisplayLoop(waitGroup *sync.WaitGroup, exitCh <-chan bool, di
 			}
 
 			lastErrorReceivedTime = time.Now()
-
-			if !timerActive {
-				timer.Reset(grvMaxDrawFrequency)
-				timerActive = true
-			}
+			requestDraw()
 		case _, ok := <-exitCh:
 			if !ok {
 				return
@@ -377,37 +897,131 @@ func (grv *GRV) runDisplayLoop(waitGroup *sync.WaitGroup, exitCh <-chan bool, di
 }
 
 func (grv *GRV) runHandlerLoop(waitGroup *sync.WaitGroup, exitCh <-chan bool, inputKeyCh <-chan string, actionCh chan Action, errorCh chan<- error, eventCh <-chan Event) {
+	defer RecoverPanic()
 	defer waitGroup.Done()
 	defer log.Info("Handler loop stopping")
 	log.Info("Starting handler loop")
 
+	var keyTimeoutCh <-chan time.Time
+
+	processInput := func(flush bool) {
+		for {
+			viewHierarchy := grv.view.ActiveViewIDHierarchy()
+
+			var action Action
+			var keystring string
+
+			if flush {
+				action, keystring = grv.inputBuffer.Flush(viewHierarchy)
+				flush = false
+			} else {
+				action, keystring = grv.inputBuffer.Process(viewHierarchy)
+			}
+
+			if action.ActionType != ActionNone {
+				actionCh <- action
+			} else if keystring != "" {
+				log.Debugf("Dropping keystring: %v", keystring)
+			} else {
+				break
+			}
+		}
+
+		keyTimeoutCh = nil
+
+		// A partially entered key sequence is processed on its own if it is not completed before the timeout
+		if keyTimeout := grv.config.GetInt(CfKeyTimeout); keyTimeout > 0 && grv.inputBuffer.PendingKeys() != "" {
+			keyTimeoutCh = time.After(time.Duration(keyTimeout) * time.Millisecond)
+		}
+
+		grv.updateKeyHints()
+	}
+
 	for {
 		select {
 		case key := <-inputKeyCh:
 			grv.inputBuffer.Append(key)
-
-			for {
-				viewHierarchy := grv.view.ActiveViewIDHierarchy()
-				action, keystring := grv.inputBuffer.Process(viewHierarchy)
-
-				if action.ActionType != ActionNone {
-					actionCh <- action
-				} else if keystring != "" {
-					log.Debugf("Dropping keystring: %v", keystring)
-				} else {
-					break
-				}
-			}
+			processInput(false)
+		case <-keyTimeoutCh:
+			log.Debugf("Key sequence timed out with pending keys: %v", grv.inputBuffer.PendingKeys())
+			processInput(true)
 		case action := <-actionCh:
 			switch action.ActionType {
 			case ActionExit:
 				grv.End()
 			case ActionSuspend:
 				grv.Suspend()
-			default:
-				if err := grv.view.HandleAction(action); err != nil {
+			case ActionRefresh:
+				grv.Refresh()
+			case ActionMergeTool:
+				if err := grv.runMergeTool(); err != nil {
+					errorCh <- err
+				}
+			case ActionDiffTool:
+				if err := grv.runDiffTool(); err != nil {
+					errorCh <- err
+				}
+			case ActionPreviewBinary:
+				if err := grv.runBinaryPreview(); err != nil {
+					errorCh <- err
+				}
+			case ActionRunInteractiveRebase:
+				if err := grv.runInteractiveRebase(action); err != nil {
+					errorCh <- err
+				}
+			case ActionRunAmendCommit:
+				if err := grv.runAmendCommit(); err != nil {
+					errorCh <- err
+				}
+			case ActionOpenFile:
+				if err := grv.runExternalCommand(&ExternalCommand{template: grvEditorCommand}); err != nil {
+					errorCh <- err
+				}
+			case ActionRunShellCommand:
+				if err := grv.runShellCommand(action); err != nil {
+					errorCh <- err
+				}
+			case ActionEvaluateConfigCommand:
+				if err := grv.evaluateConfigCommand(action); err != nil {
 					errorCh <- err
 				}
+			case ActionAddView, ActionSplitView:
+				if err := grv.expandCommandViewArgs(action); err != nil {
+					errorCh <- err
+				} else if err := grv.view.HandleAction(action); err != nil {
+					errorCh <- err
+				}
+			default:
+				if grv.plugins.IsPluginAction(action.ActionType) {
+					if err := grv.plugins.HandleAction(action); err != nil {
+						errorCh <- err
+					}
+
+					break
+				}
+
+				if externalCommand, isExternalCommand := grv.commands.Command(action.ActionType); isExternalCommand {
+					if err := grv.runExternalCommand(externalCommand); err != nil {
+						errorCh <- err
+					}
+
+					break
+				}
+
+				if filterAction, isNamedFilter := grv.config.NamedFilterAction(action.ActionType); isNamedFilter {
+					if err := grv.view.HandleAction(filterAction); err != nil {
+						errorCh <- err
+					}
+
+					break
+				}
+
+				for repeat := uint(0); repeat < action.RepeatCount(); repeat++ {
+					if err := grv.view.HandleAction(action); err != nil {
+						errorCh <- err
+						break
+					}
+				}
 			}
 		case event := <-eventCh:
 			log.Infof("Received event: %v", event)
@@ -424,25 +1038,76 @@ func (grv *GRV) runHandlerLoop(waitGroup *sync.WaitGroup, exitCh <-chan bool, in
 	}
 }
 
+// updateKeyHints displays the key sequences which complete a partially entered key sequence
+// and the actions they are bound to, if key hints are enabled
+func (grv *GRV) updateKeyHints() {
+	pendingKeys := grv.inputBuffer.PendingKeys()
+	var keyHints []KeyHint
+
+	if pendingKeys != "" && grv.config.GetBool(CfKeyHints) {
+		completions := grv.keyBindings.Completions(grv.view.ActiveViewIDHierarchy(), pendingKeys)
+
+		for _, completion := range completions {
+			keyHints = append(keyHints, KeyHint{
+				keys:        completion.keystring,
+				description: grv.describeBinding(completion.binding),
+			})
+		}
+	}
+
+	if len(keyHints) > 0 || len(grv.view.KeyHints()) > 0 {
+		grv.view.SetKeyHints(pendingKeys, keyHints)
+		grv.channels.Channels().UpdateDisplay()
+	}
+}
+
+// describeBinding returns the name of the action the binding is for, e.g. first-line,
+// the command template of an external command, the name of a saved filter or the keys the binding maps to
+func (grv *GRV) describeBinding(binding Binding) string {
+	if binding.bindingType == BtKeystring {
+		return binding.keystring
+	}
+
+	if name, exists := grv.keyBindings.ActionName(binding.actionType); exists {
+		return strings.TrimSuffix(strings.TrimPrefix(name, cfActionNamePrefix), cfActionNameSuffix)
+	}
+
+	if externalCommand, isExternalCommand := grv.commands.Command(binding.actionType); isExternalCommand {
+		return "!" + externalCommand.template
+	}
+
+	if filterAction, isNamedFilter := grv.config.NamedFilterAction(binding.actionType); isNamedFilter && len(filterAction.Args) > 1 {
+		return fmt.Sprintf("filter %v", filterAction.Args[1])
+	}
+
+	return "unknown action"
+}
+
 func (grv *GRV) runSignalHandlerLoop(waitGroup *sync.WaitGroup, exitCh <-chan bool) {
+	defer RecoverPanic()
 	defer waitGroup.Done()
 	defer log.Info("Signal handler loop stopping")
 	log.Info("Signal handler loop starting")
 
 	signalCh := make(chan os.Signal, 1)
 
-	signal.Notify(signalCh, syscall.SIGTERM, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGHUP, syscall.SIGWINCH, syscall.SIGCONT)
+	signal.Notify(signalCh, syscall.SIGTERM, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGHUP, syscall.SIGWINCH, syscall.SIGCONT, syscall.SIGTSTP)
 
 	for {
 		select {
-		case signal := <-signalCh:
-			log.Debugf("Caught signal: %v", signal)
+		case caughtSignal := <-signalCh:
+			log.Debugf("Caught signal: %v", caughtSignal)
 
-			switch signal {
+			switch caughtSignal {
 			case syscall.SIGTERM, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGHUP:
 				grv.End()
 				return
+			case syscall.SIGTSTP:
+				// <C-z> is read as input while ncurses is active. SIGTSTP is received when the
+				// terminal sends it while a foreground command is running or from kill -TSTP
+				grv.Suspend()
 			case syscall.SIGCONT:
+				signal.Notify(signalCh, syscall.SIGTSTP)
 				grv.Resume()
 			case syscall.SIGWINCH:
 				if err := grv.ui.Resize(); err != nil {
@@ -459,71 +1124,130 @@ func (grv *GRV) runSignalHandlerLoop(waitGroup *sync.WaitGroup, exitCh <-chan bo
 	}
 }
 
+func (grv *GRV) runControlSocketLoop(waitGroup *sync.WaitGroup, exitCh <-chan bool) {
+	defer RecoverPanic()
+	defer waitGroup.Done()
+	defer log.Info("Control socket loop stopping")
+	log.Info("Control socket loop starting")
+
+	go grv.controlSocket.Serve()
+
+	<-exitCh
+
+	grv.controlSocket.Close()
+}
+
 func (grv *GRV) runFileSystemMonitorLoop(waitGroup *sync.WaitGroup, exitCh <-chan bool) {
+	defer RecoverPanic()
 	defer waitGroup.Done()
 	defer log.Info("FileSystem Monitor loop stopping")
 	log.Info("FileSystem loop starting")
 
-	channels := grv.channels.Channels()
 	eventCh := make(chan fs.EventInfo, 1)
-	repoGitDir := grv.repoData.Path()
-	repoFilePath := strings.TrimSuffix(repoGitDir, GitRepositoryDirectoryName+"/")
-	watchDir := repoFilePath + "..."
-
-	if err := fs.Watch(watchDir, eventCh, fs.All); err != nil {
-		log.Errorf("Unable to watch path for filesystem events %v: %v", watchDir, err)
-		return
-	}
+	watchPaths := NewRepositoryWatchPaths(grv.repoData.Path(), grv.repoData.Workdir())
 
 	defer fs.Stop(eventCh)
 
-	log.Infof("Watching filesystem events for path: %v", watchDir)
+	for _, watchDir := range watchPaths.WatchDirs() {
+		if err := fs.Watch(watchDir, eventCh, fs.All); err != nil {
+			log.Errorf("Unable to watch path for filesystem events %v: %v", watchDir, err)
+			return
+		}
+
+		log.Infof("Watching filesystem events for path: %v", watchDir)
+	}
+
+	grv.fsMonitor.SetWatching(true)
+	defer grv.fsMonitor.SetWatching(false)
 
 	timer := time.NewTimer(time.Hour)
 	timer.Stop()
 	timerActive := false
 
-	ignorePaths := map[string]bool{}
-
-	logFile := LogFile()
-	if logFile != "" {
-		ignorePaths[logFile] = true
-		log.Debugf("Ignoring filesystem events for log file %v", logFile)
-
-		if canonicalLogFile, err := CanonicalPath(logFile); err == nil {
-			ignorePaths[canonicalLogFile] = true
-			log.Debugf("Ignoring filesystem events for log file canonical path %v", canonicalLogFile)
-		}
-	}
-
-	gitDirModified := false
+	var pendingChange RepositoryChange
 
 	for {
 		select {
 		case event := <-eventCh:
-			if _, ignore := ignorePaths[event.Path()]; !ignore {
-				log.Debugf("FileSystem event: %v", event)
+			if IsLogFile(event.Path()) || !grv.config.GetBool(CfAutoRefresh) {
+				break
+			}
 
-				if !timerActive {
-					timer.Reset(grvMaxGitStatusFrequency)
-					timerActive = true
-				}
+			change := watchPaths.Classify(event.Path())
+			if !change.refsChanged && !change.statusChanged {
+				break
+			}
 
-				if !gitDirModified && strings.HasPrefix(event.Path(), repoGitDir) {
-					gitDirModified = true
-				}
+			log.Debugf("FileSystem event: %v", event)
+			grv.fsMonitor.RecordEvent()
+
+			pendingChange.refsChanged = pendingChange.refsChanged || change.refsChanged
+			pendingChange.statusChanged = pendingChange.statusChanged || change.statusChanged
+
+			if !timerActive {
+				timer.Reset(grvMaxGitStatusFrequency)
+				timerActive = true
 			}
 		case <-timer.C:
 			timerActive = false
-
-			if err := grv.repoData.LoadStatus(); err != nil {
-				channels.ReportError(err)
+			grv.processRepositoryChange(pendingChange)
+			pendingChange = RepositoryChange{}
+		case _, ok := <-exitCh:
+			if !ok {
+				return
 			}
+		}
+	}
+}
+
+// runPollingLoop periodically checks the modification times of refs and the index
+// for filesystems where filesystem events are not reliably delivered.
+// Changes are not processed if the filesystem monitor received events since the last poll
+// as it will already have processed them. The modification times are recorded again
+// whenever polling is enabled so that changes made while it was disabled are ignored
+func (grv *GRV) runPollingLoop(waitGroup *sync.WaitGroup, exitCh <-chan bool) {
+	defer RecoverPanic()
+	defer waitGroup.Done()
+	defer log.Info("Polling loop stopping")
+	log.Info("Polling loop starting")
+
+	watchPaths := NewRepositoryWatchPaths(grv.repoData.Path(), grv.repoData.Workdir())
+	modTimes := watchPaths.ModTimes()
+
+	pollInterval := func() time.Duration {
+		if interval := grv.config.GetInt(CfPollInterval); interval > 0 {
+			return time.Duration(interval) * time.Second
+		}
+
+		return grvPollDisabledInterval
+	}
+
+	timer := time.NewTimer(pollInterval())
+	defer timer.Stop()
 
-			if gitDirModified {
-				grv.repoData.LoadRefs(nil)
-				gitDirModified = false
+	for {
+		select {
+		case <-timer.C:
+			if grv.config.GetInt(CfPollInterval) <= 0 || !grv.config.GetBool(CfAutoRefresh) {
+				modTimes = nil
+			} else if modTimes == nil {
+				log.Debugf("Polling enabled. Recording modification times")
+				modTimes = watchPaths.ModTimes()
+				grv.fsMonitor.EventReceived()
+			} else {
+				currentModTimes := watchPaths.ModTimes()
+				change := currentModTimes.Changes(modTimes, watchPaths)
+				modTimes = currentModTimes
+
+				if grv.fsMonitor.EventReceived() {
+					log.Debugf("Filesystem monitor is receiving events. Not processing polled changes")
+				} else if change.refsChanged || change.statusChanged {
+					log.Debugf("Polling detected repository change: %v", change)
+					grv.processRepositoryChange(change)
+				}
 			}
+
+			timer.Reset(pollInterval())
 		case _, ok := <-exitCh:
 			if !ok {
 				return
@@ -531,3 +1255,16 @@ func (grv *GRV) runFileSystemMonitorLoop(waitGroup *sync.WaitGroup, exitCh <-cha
 		}
 	}
 }
+
+// processRepositoryChange reloads the repository data affected by a change
+func (grv *GRV) processRepositoryChange(change RepositoryChange) {
+	if change.statusChanged {
+		if err := grv.repoData.LoadStatus(); err != nil {
+			grv.channels.Channels().ReportError(err)
+		}
+	}
+
+	if change.refsChanged {
+		grv.repoData.LoadRefs(nil)
+	}
+}
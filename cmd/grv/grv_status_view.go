@@ -17,9 +17,9 @@ type GRVStatusView struct {
 }
 
 // NewGRVStatusView creates a new instance
-func NewGRVStatusView(helpRenderer HelpRenderer, repoData RepoData, channels *Channels, config ConfigSetter) *GRVStatusView {
+func NewGRVStatusView(helpRenderer HelpRenderer, statusBarRenderer StatusBarRenderer, repoData RepoData, channels *Channels, config ConfigSetter) *GRVStatusView {
 	return &GRVStatusView{
-		statusBarView: NewStatusBarView(repoData, channels, config),
+		statusBarView: NewStatusBarView(statusBarRenderer, repoData, channels, config),
 		helpBarView:   NewHelpBarView(helpRenderer),
 		statusBarWin:  NewWindow("statusBarView", config),
 		helpBarWin:    NewWindow("helpBarView", config),
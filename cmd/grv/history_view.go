@@ -4,15 +4,19 @@ const (
 	hvMaxRefViewWidth = uint(35)
 )
 
-// NewHistoryView creates a new instance of the history view
-func NewHistoryView(repoData RepoData, channels *Channels, config Config) *ContainerView {
-	refView := NewRefView(repoData, channels)
-	commitView := NewCommitView(repoData, channels)
-	diffView := NewDiffView(repoData, channels)
+// NewHistoryView creates a new instance of the history view.
+// The views displayed are rearranged whenever the layout manager reports a new layout
+func NewHistoryView(repoData RepoData, channels *Channels, config Config, layoutManager *LayoutManager) *ContainerView {
+	refView := NewRefView(repoData, channels, config)
+	commitView := NewCommitView(repoData, channels, config)
+	diffView := NewDiffView(repoData, channels, config)
 
 	refView.RegisterRefListener(commitView)
 	commitView.RegisterCommitViewListener(diffView)
 
+	refView.SetSessionStore(repoData.Session())
+	commitView.SetSessionStore(repoData.Session())
+
 	subContainer := NewContainerView(channels, config)
 	subContainer.SetOrientation(CoDynamic)
 	subContainer.AddChildViews(commitView, diffView)
@@ -24,9 +28,53 @@ func NewHistoryView(repoData RepoData, channels *Channels, config Config) *Conta
 	historyView.SetChildViewPositionCalculator(&historyViewPositionCalculator{historyView: historyView})
 	historyView.AddChildViews(refView, subContainer)
 
+	layoutManager.AddLayoutListener(&historyViewLayout{
+		historyView:  historyView,
+		subContainer: subContainer,
+		channels:     channels,
+		views: map[ViewID]AbstractView{
+			ViewRef:    refView,
+			ViewCommit: commitView,
+			ViewDiff:   diffView,
+		},
+	})
+
 	return historyView
 }
 
+type historyViewLayout struct {
+	historyView  *ContainerView
+	subContainer *ContainerView
+	views        map[ViewID]AbstractView
+	channels     *Channels
+}
+
+// OnLayoutChange arranges the history view child views as described by the provided layout.
+// The default layout is restored when no layout is provided
+func (historyViewLayout *historyViewLayout) OnLayoutChange(layout *Layout) {
+	historyView := historyViewLayout.historyView
+
+	if layout == nil {
+		historyView.SetOrientation(CoVertical)
+		historyView.SetChildViewPositionCalculator(&historyViewPositionCalculator{historyView: historyView})
+		historyView.SetChildViews(historyViewLayout.views[ViewRef], historyViewLayout.subContainer)
+	} else {
+		var childViews []AbstractView
+		for _, layoutView := range layout.views {
+			childViews = append(childViews, historyViewLayout.views[layoutView.viewID])
+		}
+
+		historyView.SetOrientation(layout.orientation)
+		historyView.SetChildViewPositionCalculator(&layoutPositionCalculator{
+			containerView: historyView,
+			layout:        layout,
+		})
+		historyView.SetChildViews(childViews...)
+	}
+
+	historyViewLayout.channels.UpdateDisplay()
+}
+
 type historyViewPositionCalculator struct {
 	historyView *ContainerView
 }
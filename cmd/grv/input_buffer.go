@@ -4,10 +4,15 @@ import (
 	"strings"
 )
 
+const (
+	ibMaxCount = 9999
+)
+
 // InputBuffer buffers input and maps it to configured actions or key sequences
 type InputBuffer struct {
 	buffer      []string
 	keyBindings KeyBindings
+	count       uint
 }
 
 // NewInputBuffer creates a new input buffer instance
@@ -38,10 +43,26 @@ func (inputBuffer *InputBuffer) hasInput() bool {
 	return len(inputBuffer.buffer) > 0
 }
 
+// PendingKeys returns the keys of a partially entered key sequence which is waiting for more input
+func (inputBuffer *InputBuffer) PendingKeys() string {
+	return strings.Join(inputBuffer.buffer, "")
+}
+
 // Process goes through the input in the buffer and attempts to map it to actions or key sequences
 // If no mapping is possible the key sequences on the buffer are returned.
 // If a prefix is matched then the buffer returns NOP so that more input can be appended to it
+// Unbound digits are treated as a count which is applied to the next repeatable action
 func (inputBuffer *InputBuffer) Process(viewHierarchy ViewHierarchy) (action Action, keystring string) {
+	return inputBuffer.process(viewHierarchy, false)
+}
+
+// Flush processes a partially entered key sequence as if no more input will follow it.
+// This is used once no further key has been entered within the key sequence timeout
+func (inputBuffer *InputBuffer) Flush(viewHierarchy ViewHierarchy) (action Action, keystring string) {
+	return inputBuffer.process(viewHierarchy, true)
+}
+
+func (inputBuffer *InputBuffer) process(viewHierarchy ViewHierarchy, flush bool) (action Action, keystring string) {
 	if !inputBuffer.hasInput() {
 		return
 	}
@@ -56,8 +77,8 @@ OuterLoop:
 		binding, prefix := keyBindings.Binding(viewHierarchy, strings.Join(keyBuffer, ""))
 
 		switch {
-		case prefix:
-			if len(inputBuffer.buffer) == 0 {
+		case prefix && (inputBuffer.hasInput() || !flush):
+			if !inputBuffer.hasInput() {
 				inputBuffer.prepend(keyBuffer)
 				return
 			}
@@ -66,11 +87,19 @@ OuterLoop:
 		case binding.bindingType == BtAction:
 			if binding.actionType != ActionNone {
 				action = Action{ActionType: binding.actionType}
+
+				if inputBuffer.count > 0 && action.IsRepeatable() {
+					action.Count = inputBuffer.count
+				}
 			} else if isPrefix {
 				inputBuffer.prepend(keyBuffer[1:])
 				keyBuffer = keyBuffer[0:1]
+			} else if len(keyBuffer) == 1 && inputBuffer.appendCountDigit(keyBuffer[0]) {
+				keyBuffer = keyBuffer[0:0]
+				continue
 			}
 
+			inputBuffer.count = 0
 			break OuterLoop
 		case binding.bindingType == BtKeystring:
 			inputBuffer.prepend(TokeniseKeys(binding.keystring))
@@ -83,3 +112,15 @@ OuterLoop:
 
 	return
 }
+
+func (inputBuffer *InputBuffer) appendCountDigit(key string) bool {
+	if len(key) != 1 || key[0] < '0' || key[0] > '9' || (key[0] == '0' && inputBuffer.count == 0) {
+		return false
+	}
+
+	if count := inputBuffer.count*10 + uint(key[0]-'0'); count <= ibMaxCount {
+		inputBuffer.count = count
+	}
+
+	return true
+}
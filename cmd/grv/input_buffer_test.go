@@ -16,6 +16,16 @@ func (keyBindings *MockKeyBindings) Binding(viewHierarchy ViewHierarchy, keystri
 	return args.Get(0).(Binding), args.Bool(1)
 }
 
+func (keyBindings *MockKeyBindings) Completions(viewHierarchy ViewHierarchy, prefix string) []KeyBindingCompletion {
+	args := keyBindings.Called(viewHierarchy, prefix)
+	return args.Get(0).([]KeyBindingCompletion)
+}
+
+func (keyBindings *MockKeyBindings) ActionName(actionType ActionType) (name string, exists bool) {
+	args := keyBindings.Called(actionType)
+	return args.String(0), args.Bool(1)
+}
+
 func (keyBindings *MockKeyBindings) SetActionBinding(viewID ViewID, keystring string, actionType ActionType) {
 	keyBindings.Called(viewID, keystring, actionType)
 }
@@ -115,3 +125,104 @@ func TestPotentialPrefixMatchIsReturnedAsSeparateKeysWhenFullInputDoesNotMatchBi
 	action, keyString = inputBuffer.Process(viewHierarchy)
 	checkProcessResult(Action{ActionType: ActionNone}, "b", action, keyString, t)
 }
+
+func TestCountPrefixIsAppliedToRepeatableAction(t *testing.T) {
+	keyBindings := &MockKeyBindings{}
+	inputBuffer := NewInputBuffer(keyBindings)
+
+	viewHierarchy := ViewHierarchy([]ViewID{ViewMain, ViewHistory, ViewCommit})
+
+	keyBindings.On("Binding", viewHierarchy, "1").Return(newActionBinding(ActionNone), false)
+	keyBindings.On("Binding", viewHierarchy, "0").Return(newActionBinding(ActionNone), false)
+	keyBindings.On("Binding", viewHierarchy, "j").Return(newActionBinding(ActionNextLine), false)
+
+	inputBuffer.Append("1")
+	action, keyString := inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionNone}, "", action, keyString, t)
+
+	inputBuffer.Append("0j")
+	action, keyString = inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionNextLine, Count: 10}, "j", action, keyString, t)
+
+	inputBuffer.Append("j")
+	action, keyString = inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionNextLine}, "j", action, keyString, t)
+}
+
+func TestCountPrefixIsNotAppliedToNonRepeatableAction(t *testing.T) {
+	keyBindings := &MockKeyBindings{}
+	inputBuffer := NewInputBuffer(keyBindings)
+
+	viewHierarchy := ViewHierarchy([]ViewID{ViewMain, ViewHistory, ViewCommit})
+
+	keyBindings.On("Binding", viewHierarchy, "5").Return(newActionBinding(ActionNone), false)
+	keyBindings.On("Binding", viewHierarchy, "G").Return(newActionBinding(ActionLastLine), false)
+
+	inputBuffer.Append("5G")
+	action, keyString := inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionLastLine}, "G", action, keyString, t)
+}
+
+func TestZeroWithoutCountIsNotTreatedAsCount(t *testing.T) {
+	keyBindings := &MockKeyBindings{}
+	inputBuffer := NewInputBuffer(keyBindings)
+
+	viewHierarchy := ViewHierarchy([]ViewID{ViewMain, ViewHistory, ViewCommit})
+
+	keyBindings.On("Binding", viewHierarchy, "0").Return(newActionBinding(ActionNone), false)
+
+	inputBuffer.Append("0")
+	action, keyString := inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionNone}, "0", action, keyString, t)
+}
+
+func TestPendingKeysAreReturnedWhilePrefixIsIncomplete(t *testing.T) {
+	keyBindings := &MockKeyBindings{}
+	inputBuffer := NewInputBuffer(keyBindings)
+
+	viewHierarchy := ViewHierarchy([]ViewID{ViewMain, ViewHistory, ViewCommit})
+
+	keyBindings.On("Binding", viewHierarchy, "g").Return(newActionBinding(ActionNone), true)
+	keyBindings.On("Binding", viewHierarchy, "gg").Return(newActionBinding(ActionFirstLine), false)
+
+	inputBuffer.Append("g")
+	action, keyString := inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionNone}, "", action, keyString, t)
+
+	if pendingKeys := inputBuffer.PendingKeys(); pendingKeys != "g" {
+		t.Errorf("Pending keys do not match expected value. Expected: %v, Actual: %v", "g", pendingKeys)
+	}
+
+	inputBuffer.Append("g")
+	action, keyString = inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionFirstLine}, "gg", action, keyString, t)
+
+	if pendingKeys := inputBuffer.PendingKeys(); pendingKeys != "" {
+		t.Errorf("Expected no pending keys but found: %v", pendingKeys)
+	}
+}
+
+func TestFlushProcessesIncompletePrefixAsSeparateKeys(t *testing.T) {
+	keyBindings := &MockKeyBindings{}
+	inputBuffer := NewInputBuffer(keyBindings)
+
+	viewHierarchy := ViewHierarchy([]ViewID{ViewMain, ViewHistory, ViewCommit})
+
+	keyBindings.On("Binding", viewHierarchy, "a").Return(newActionBinding(ActionNone), true)
+	keyBindings.On("Binding", viewHierarchy, "ab").Return(newActionBinding(ActionNone), true)
+	keyBindings.On("Binding", viewHierarchy, "b").Return(newActionBinding(ActionNextLine), false)
+
+	inputBuffer.Append("ab")
+	action, keyString := inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionNone}, "", action, keyString, t)
+
+	action, keyString = inputBuffer.Flush(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionNone}, "a", action, keyString, t)
+
+	action, keyString = inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionNextLine}, "b", action, keyString, t)
+
+	if pendingKeys := inputBuffer.PendingKeys(); pendingKeys != "" {
+		t.Errorf("Expected no pending keys but found: %v", pendingKeys)
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+	slice "github.com/bradfitz/slice"
+)
+
+// IssueReference is a reference to an issue found in a commit message
+type IssueReference struct {
+	text   string
+	url    string
+	offset int
+}
+
+type issuePattern struct {
+	regex       *regexp.Regexp
+	urlTemplate string
+}
+
+// IssuePatterns stores the patterns used to find issue references in commit messages
+// and the URL templates used to generate the URL of each referenced issue
+type IssuePatterns struct {
+	patterns []*issuePattern
+	lock     sync.Mutex
+}
+
+// NewIssuePatterns creates a new instance
+func NewIssuePatterns() *IssuePatterns {
+	return &IssuePatterns{}
+}
+
+// Add registers a pattern matching issue references. The URL template can refer to
+// capture groups of the pattern using $1, ${1} or ${name}
+func (issuePatterns *IssuePatterns) Add(pattern, urlTemplate string) (err error) {
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("Invalid issue pattern: %v", err)
+	}
+
+	if urlTemplate == "" {
+		return fmt.Errorf("Issue URL template cannot be empty")
+	}
+
+	issuePatterns.lock.Lock()
+	defer issuePatterns.lock.Unlock()
+
+	issuePatterns.patterns = append(issuePatterns.patterns, &issuePattern{
+		regex:       regex,
+		urlTemplate: urlTemplate,
+	})
+
+	log.Infof("Added issue pattern %v with URL template %v", pattern, urlTemplate)
+
+	return
+}
+
+// References returns the issue references in the text in the order they occur.
+// Each issue is only returned once
+func (issuePatterns *IssuePatterns) References(text string) (references []*IssueReference) {
+	issuePatterns.lock.Lock()
+	defer issuePatterns.lock.Unlock()
+
+	seen := make(map[string]bool)
+
+	for _, pattern := range issuePatterns.patterns {
+		for _, match := range pattern.regex.FindAllStringSubmatchIndex(text, -1) {
+			url := string(pattern.regex.ExpandString(nil, pattern.urlTemplate, text, match))
+			if seen[url] {
+				continue
+			}
+
+			seen[url] = true
+			references = append(references, &IssueReference{
+				text:   text[match[0]:match[1]],
+				url:    url,
+				offset: match[0],
+			})
+		}
+	}
+
+	slice.Sort(references, func(i, j int) bool {
+		return references[i].offset < references[j].offset
+	})
+
+	return
+}
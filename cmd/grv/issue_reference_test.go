@@ -0,0 +1,39 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestIssueReferencesAreFoundInOrder(t *testing.T) {
+	issuePatterns := NewIssuePatterns()
+
+	if err := issuePatterns.Add(`#([0-9]+)`, "https://github.com/owner/repo/issues/$1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := issuePatterns.Add(`\b[A-Z]+-[0-9]+\b`, "https://jira.example.com/browse/$0"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	references := issuePatterns.References("Fix JIRA-456 crash\n\nCloses #123 and #45. See also #123")
+	expectedURLs := []string{
+		"https://jira.example.com/browse/JIRA-456",
+		"https://github.com/owner/repo/issues/123",
+		"https://github.com/owner/repo/issues/45",
+	}
+
+	if len(references) != len(expectedURLs) {
+		t.Fatalf("Expected %v references but found %v", len(expectedURLs), len(references))
+	}
+
+	for index, reference := range references {
+		if reference.url != expectedURLs[index] {
+			t.Errorf("URL does not match expected value. Expected: %v, Actual: %v", expectedURLs[index], reference.url)
+		}
+	}
+}
+
+func TestInvalidIssuePatternIsRejected(t *testing.T) {
+	if err := NewIssuePatterns().Add(`#([0-9]+`, "https://example.com/$1"); err == nil {
+		t.Errorf("Expected error when adding invalid issue pattern")
+	}
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	jsRefs    = "refs"
+	jsCommits = "commits"
+)
+
+type jsonRef struct {
+	Name      string `json:"name"`
+	Shorthand string `json:"shorthand"`
+	Oid       string `json:"oid"`
+	Upstream  string `json:"upstream,omitempty"`
+	Ahead     uint   `json:"ahead,omitempty"`
+	Behind    uint   `json:"behind,omitempty"`
+}
+
+type jsonRefs struct {
+	Head           jsonRef   `json:"head"`
+	LocalBranches  []jsonRef `json:"localBranches"`
+	RemoteBranches []jsonRef `json:"remoteBranches"`
+	Tags           []jsonRef `json:"tags"`
+}
+
+type jsonSignature struct {
+	Name  string    `json:"name"`
+	Email string    `json:"email"`
+	Date  time.Time `json:"date"`
+}
+
+type jsonCommit struct {
+	Oid       string        `json:"oid"`
+	Parents   []string      `json:"parents"`
+	Author    jsonSignature `json:"author"`
+	Committer jsonSignature `json:"committer"`
+	Summary   string        `json:"summary"`
+	Message   string        `json:"message"`
+}
+
+// RenderJSON writes the data described by jsonSpec to the provided writer as JSON.
+// jsonSpec is either "refs" or "commits [REF]". If a filter query is provided
+// only commits matching the query are output
+func (batchRenderer *BatchRenderer) RenderJSON(jsonSpec, filterQuery string, writer io.Writer) (err error) {
+	fields := strings.Fields(jsonSpec)
+	if len(fields) == 0 {
+		return fmt.Errorf("No JSON output specified")
+	}
+
+	var data interface{}
+
+	switch {
+	case fields[0] == jsRefs && len(fields) == 1:
+		data = batchRenderer.jsonRefs()
+	case fields[0] == jsCommits && len(fields) <= 2:
+		var ref Ref
+
+		if len(fields) == 2 {
+			if ref, err = batchRenderer.windowViewFactory.getRef([]interface{}{fields[1]}); err != nil {
+				return
+			} else if ref == nil {
+				return fmt.Errorf("Invalid ref: %v", fields[1])
+			}
+		} else {
+			ref = batchRenderer.repoData.Head()
+		}
+
+		if data, err = batchRenderer.jsonCommits(ref, filterQuery); err != nil {
+			return
+		}
+	default:
+		return fmt.Errorf("Invalid JSON output: %v. Expected %v or %v [REF]", jsonSpec, jsRefs, jsCommits)
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	if err = encoder.Encode(data); err != nil {
+		return
+	}
+
+	batchRenderer.lock.Lock()
+	defer batchRenderer.lock.Unlock()
+
+	if len(batchRenderer.errors) > 0 {
+		err = batchRenderer.errors[0]
+	}
+
+	return
+}
+
+func (batchRenderer *BatchRenderer) jsonRefs() *jsonRefs {
+	repoData := batchRenderer.repoData
+
+	refs := &jsonRefs{
+		Head:           newJSONRef(repoData.Head()),
+		LocalBranches:  []jsonRef{},
+		RemoteBranches: []jsonRef{},
+		Tags:           []jsonRef{},
+	}
+
+	localBranches, remoteBranches, _ := repoData.Branches()
+
+	for _, localBranch := range localBranches {
+		refs.LocalBranches = append(refs.LocalBranches, newJSONRef(localBranch))
+	}
+
+	for _, remoteBranch := range remoteBranches {
+		refs.RemoteBranches = append(refs.RemoteBranches, newJSONRef(remoteBranch))
+	}
+
+	tags, _ := repoData.Tags()
+
+	for _, tag := range tags {
+		refs.Tags = append(refs.Tags, newJSONRef(tag))
+	}
+
+	return refs
+}
+
+func newJSONRef(ref Ref) jsonRef {
+	jsonRef := jsonRef{
+		Name:      ref.Name(),
+		Shorthand: ref.Shorthand(),
+		Oid:       ref.Oid().String(),
+	}
+
+	if localBranch, isLocalBranch := ref.(*LocalBranch); isLocalBranch {
+		jsonRef.Upstream = localBranch.remoteBranch
+		jsonRef.Ahead = localBranch.ahead
+		jsonRef.Behind = localBranch.behind
+	}
+
+	return jsonRef
+}
+
+func (batchRenderer *BatchRenderer) jsonCommits(ref Ref, filterQuery string) (commits []jsonCommit, err error) {
+	var commitFilter *CommitFilter
+
+	if filterQuery != "" {
+		var errors []error
+		if commitFilter, errors = CreateCommitFilter(filterQuery); len(errors) > 0 {
+			return nil, errors[0]
+		}
+	}
+
+	repoData := batchRenderer.repoData
+
+	if err = repoData.LoadCommits(ref); err != nil {
+		return
+	}
+
+	for repoData.CommitSetState(ref).loading {
+		time.Sleep(btPollInterval)
+	}
+
+	commitSetState := repoData.CommitSetState(ref)
+
+	commitCh, err := repoData.Commits(ref, 0, commitSetState.commitNum)
+	if err != nil {
+		return
+	}
+
+	commits = []jsonCommit{}
+
+	for commit := range commitCh {
+		if commitFilter == nil || commitFilter.MatchesFilter(commit) {
+			commits = append(commits, newJSONCommit(commit))
+		}
+	}
+
+	return
+}
+
+func newJSONCommit(commit *Commit) jsonCommit {
+	rawCommit := commit.commit
+	author := rawCommit.Author()
+	committer := rawCommit.Committer()
+
+	parents := []string{}
+	for parentIndex := uint(0); parentIndex < rawCommit.ParentCount(); parentIndex++ {
+		parents = append(parents, rawCommit.ParentId(parentIndex).String())
+	}
+
+	return jsonCommit{
+		Oid:     commit.oid.String(),
+		Parents: parents,
+		Author: jsonSignature{
+			Name:  author.Name,
+			Email: author.Email,
+			Date:  author.When,
+		},
+		Committer: jsonSignature{
+			Name:  committer.Name,
+			Email: committer.Email,
+			Date:  committer.When,
+		},
+		Summary: rawCommit.Summary(),
+		Message: rawCommit.Message(),
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+const (
+	jlMaxEntries = 100
+)
+
+// JumpPosition identifies a position recorded in a jump list.
+// Views with content that can change, such as the commit and ref views,
+// set the id of the selected item and resolve it to a row when jumping.
+// Views with static content only set the row index
+type JumpPosition struct {
+	ID       string
+	RowIndex uint
+}
+
+// JumpList records the positions a view was at before a jump
+// so that it is possible to navigate back and forward through them
+type JumpList struct {
+	entries []JumpPosition
+	index   int
+}
+
+// NewJumpList creates a new instance
+func NewJumpList() *JumpList {
+	return &JumpList{}
+}
+
+// Record stores the position the view is jumping from.
+// Any positions ahead of the current position in the jump list are discarded
+func (jumpList *JumpList) Record(position JumpPosition) {
+	jumpList.entries = jumpList.entries[:jumpList.index]
+
+	if entryNum := len(jumpList.entries); entryNum == 0 || jumpList.entries[entryNum-1] != position {
+		jumpList.entries = append(jumpList.entries, position)
+	}
+
+	if len(jumpList.entries) > jlMaxEntries {
+		jumpList.entries = jumpList.entries[len(jumpList.entries)-jlMaxEntries:]
+	}
+
+	jumpList.index = len(jumpList.entries)
+}
+
+// Back returns the previous position in the jump list.
+// The current position is stored so it can be returned to with Forward
+func (jumpList *JumpList) Back(position JumpPosition) (previousPosition JumpPosition, exists bool) {
+	if jumpList.index == 0 {
+		return
+	}
+
+	if jumpList.index == len(jumpList.entries) {
+		jumpList.entries = append(jumpList.entries, position)
+	}
+
+	jumpList.index--
+
+	return jumpList.entries[jumpList.index], true
+}
+
+// Forward returns the next position in the jump list
+func (jumpList *JumpList) Forward() (nextPosition JumpPosition, exists bool) {
+	if jumpList.index+1 >= len(jumpList.entries) {
+		return
+	}
+
+	jumpList.index++
+
+	return jumpList.entries[jumpList.index], true
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"testing"
+)
+
+func rowPosition(rowIndex uint) JumpPosition {
+	return JumpPosition{RowIndex: rowIndex}
+}
+
+func checkJumpResult(expectedRowIndex uint, expectedExists bool, actualPosition JumpPosition, actualExists bool, t *testing.T) {
+	if expectedExists != actualExists {
+		t.Errorf("Jump result existence does not match expected value. Expected: %v, Actual: %v", expectedExists, actualExists)
+	} else if expectedExists && expectedRowIndex != actualPosition.RowIndex {
+		t.Errorf("Jump result row index does not match expected value. Expected: %v, Actual: %v", expectedRowIndex, actualPosition.RowIndex)
+	}
+}
+
+func TestJumpListIsEmptyOnCreation(t *testing.T) {
+	jumpList := NewJumpList()
+
+	position, exists := jumpList.Back(rowPosition(5))
+	checkJumpResult(0, false, position, exists, t)
+
+	position, exists = jumpList.Forward()
+	checkJumpResult(0, false, position, exists, t)
+}
+
+func TestJumpListReturnsRecordedPositionsInReverseOrder(t *testing.T) {
+	jumpList := NewJumpList()
+	jumpList.Record(rowPosition(1))
+	jumpList.Record(rowPosition(10))
+
+	position, exists := jumpList.Back(rowPosition(20))
+	checkJumpResult(10, true, position, exists, t)
+
+	position, exists = jumpList.Back(rowPosition(10))
+	checkJumpResult(1, true, position, exists, t)
+
+	position, exists = jumpList.Back(rowPosition(1))
+	checkJumpResult(0, false, position, exists, t)
+}
+
+func TestJumpListForwardReturnsToPositionBeforeBack(t *testing.T) {
+	jumpList := NewJumpList()
+	jumpList.Record(rowPosition(1))
+	jumpList.Record(rowPosition(10))
+
+	jumpList.Back(rowPosition(20))
+	jumpList.Back(rowPosition(10))
+
+	position, exists := jumpList.Forward()
+	checkJumpResult(10, true, position, exists, t)
+
+	position, exists = jumpList.Forward()
+	checkJumpResult(20, true, position, exists, t)
+
+	position, exists = jumpList.Forward()
+	checkJumpResult(0, false, position, exists, t)
+}
+
+func TestJumpListRecordDiscardsForwardPositions(t *testing.T) {
+	jumpList := NewJumpList()
+	jumpList.Record(rowPosition(1))
+	jumpList.Record(rowPosition(10))
+
+	jumpList.Back(rowPosition(20))
+	jumpList.Back(rowPosition(10))
+	jumpList.Record(rowPosition(1))
+
+	position, exists := jumpList.Forward()
+	checkJumpResult(0, false, position, exists, t)
+
+	position, exists = jumpList.Back(rowPosition(5))
+	checkJumpResult(1, true, position, exists, t)
+}
+
+func TestJumpListIsLimitedToMaxEntries(t *testing.T) {
+	jumpList := NewJumpList()
+
+	for rowIndex := uint(0); rowIndex < jlMaxEntries+10; rowIndex++ {
+		jumpList.Record(rowPosition(rowIndex))
+	}
+
+	if len(jumpList.entries) != jlMaxEntries {
+		t.Errorf("Jump list size does not match expected value. Expected: %v, Actual: %v", jlMaxEntries, len(jumpList.entries))
+	}
+}
+
+func TestJumpListReturnsRecordedIDs(t *testing.T) {
+	jumpList := NewJumpList()
+	jumpList.Record(JumpPosition{ID: "abc", RowIndex: 1})
+	jumpList.Record(JumpPosition{ID: "def", RowIndex: 1})
+
+	position, exists := jumpList.Back(JumpPosition{ID: "ghi", RowIndex: 1})
+	if !exists || position.ID != "def" {
+		t.Errorf("Jump result id does not match expected value. Expected: %v, Actual: %v", "def", position.ID)
+	}
+
+	position, exists = jumpList.Back(position)
+	if !exists || position.ID != "abc" {
+		t.Errorf("Jump result id does not match expected value. Expected: %v, Actual: %v", "abc", position.ID)
+	}
+}
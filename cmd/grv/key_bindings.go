@@ -1,6 +1,10 @@
 package main
 
 import (
+	"sort"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
 	pt "github.com/tchap/go-patricia/patricia"
 )
 
@@ -12,15 +16,25 @@ const (
 	ActionNone ActionType = iota
 	ActionExit
 	ActionSuspend
+	ActionRefresh
 	ActionPrompt
 	ActionSearchPrompt
 	ActionReverseSearchPrompt
 	ActionFilterPrompt
+	ActionSetUpstreamPrompt
+	ActionPruneRemoteBranchesPrompt
+	ActionSelectCommitPrompt
+	ActionSetMarkPrompt
+	ActionJumpToMarkPrompt
+	ActionConfirmPrompt
+	ActionPickaxePrompt
+	ActionPickaxeRegexPrompt
 	ActionSearch
 	ActionReverseSearch
 	ActionSearchFindNext
 	ActionSearchFindPrev
 	ActionClearSearch
+	ActionClearSearchHighlight
 	ActionShowStatus
 	ActionNextLine
 	ActionPrevLine
@@ -35,11 +49,19 @@ const (
 	ActionSelect
 	ActionNextView
 	ActionPrevView
+	ActionFocusLeftView
+	ActionFocusRightView
+	ActionFocusUpView
+	ActionFocusDownView
 	ActionFullScreenView
 	ActionToggleViewLayout
+	ActionToggleViewVisibility
+	ActionToggleRefView
 	ActionAddFilter
 	ActionRemoveFilter
 	ActionCenterView
+	ActionScrollCursorTop
+	ActionScrollCursorBottom
 	ActionNextTab
 	ActionPrevTab
 	ActionNewTab
@@ -47,12 +69,141 @@ const (
 	ActionAddView
 	ActionSplitView
 	ActionRemoveView
+	ActionSetUpstream
+	ActionFindStaleRemoteBranches
+	ActionPruneRemoteBranches
+	ActionSelectCommit
+	ActionSelectParentCommit
+	ActionSelectMergeParentCommit
+	ActionSelectChildCommit
+	ActionJumpBack
+	ActionJumpForward
+	ActionSetMark
+	ActionJumpToMark
+	ActionRemoveMark
+	ActionSaveStash
+	ActionApplyStash
+	ActionPopStash
+	ActionDropStash
+	ActionResolveOurs
+	ActionResolveTheirs
+	ActionMergeTool
+	ActionBlame
+	ActionReblameParent
+	ActionLineHistory
+	ActionIncrementalSearch
+	ActionEndIncrementalSearch
+	ActionToggleWrap
+	ActionToggleLineNumbers
+	ActionCycleDiffWhitespace
+	ActionNextFile
+	ActionPrevFile
+	ActionNextHunk
+	ActionPrevHunk
+	ActionToggleFileCollapse
+	ActionCollapseAllFiles
+	ActionExpandAllFiles
+	ActionCycleDiffBase
+	ActionCycleMergeParent
+	ActionPreviewBinary
+	ActionDiffTool
+	ActionVisualSelect
+	ActionYank
+	ActionYankWithoutPrefix
+	ActionYankSummary
+	ActionYankMessage
+	ActionYankReference
+	ActionOpenPullRequest
+	ActionOpenIssue
+	ActionBisectStart
+	ActionBisectGood
+	ActionBisectBad
+	ActionBisectSkip
+	ActionBisectReset
+	ActionRebasePick
+	ActionRebaseSquash
+	ActionRebaseFixup
+	ActionRebaseDrop
+	ActionRebaseClear
+	ActionInteractiveRebase
+	ActionRunInteractiveRebase
+	ActionAmendCommit
+	ActionAmendCommitEditMessage
+	ActionRunAmendCommit
+	ActionStageLines
+	ActionDiscardChanges
+	ActionStageFile
+	ActionOpenFile
+	ActionIgnoreFile
+	ActionIgnorePatternPrompt
+	ActionIgnorePattern
+	ActionRunShellCommand
+	ActionEvaluateConfigCommand
 )
 
 // Action represents a type of actions and its arguments to be executed
+// Count is set when the key sequence for a repeatable action was prefixed with a number
 type Action struct {
 	ActionType ActionType
 	Args       []interface{}
+	Count      uint
+}
+
+var repeatableActions = map[ActionType]bool{
+	ActionNextLine:     true,
+	ActionPrevLine:     true,
+	ActionNextPage:     true,
+	ActionPrevPage:     true,
+	ActionNextHalfPage: true,
+	ActionPrevHalfPage: true,
+	ActionScrollRight:  true,
+	ActionScrollLeft:   true,
+
+	ActionSelectParentCommit: true,
+	ActionSelectChildCommit:  true,
+
+	ActionNextFile: true,
+	ActionPrevFile: true,
+	ActionNextHunk: true,
+	ActionPrevHunk: true,
+}
+
+// movementActions apply their count as a single movement rather than being performed repeatedly.
+// Views then only select the row the movement finishes on, so intermediate rows are not loaded
+var movementActions = map[ActionType]bool{
+	ActionNextLine:     true,
+	ActionPrevLine:     true,
+	ActionNextPage:     true,
+	ActionPrevPage:     true,
+	ActionNextHalfPage: true,
+	ActionPrevHalfPage: true,
+
+	ActionSelectParentCommit: true,
+	ActionSelectChildCommit:  true,
+}
+
+// IsRepeatable returns true if the action can be prefixed with a count
+func (action Action) IsRepeatable() bool {
+	return repeatableActions[action.ActionType]
+}
+
+// RepeatCount returns the number of times the action should be performed.
+// Movement actions are performed once as they apply their count themselves
+func (action Action) RepeatCount() uint {
+	if action.Count == 0 || movementActions[action.ActionType] {
+		return 1
+	}
+
+	return action.Count
+}
+
+// MoveCount returns the number of lines, pages or commits a movement action should move by
+func (action Action) MoveCount() uint {
+	if action.Count == 0 {
+		return 1
+	}
+
+	return action.Count
 }
 
 // CreateViewArgs contains the fields required to create and configure a view
@@ -73,45 +224,143 @@ type ActionSplitViewArgs struct {
 	orientation ContainerOrientation
 }
 
+// ActionRunInteractiveRebaseArgs contains arguments the ActionRunInteractiveRebase action requires
+type ActionRunInteractiveRebaseArgs struct {
+	base string
+	todo []string
+}
+
+// ActionEvaluateConfigCommandArgs contains arguments the ActionEvaluateConfigCommand action requires
+type ActionEvaluateConfigCommandArgs struct {
+	command  string
+	resultCh chan<- []error
+}
+
 var actionKeys = map[string]ActionType{
-	"<grv-nop>":                   ActionNone,
-	"<grv-exit>":                  ActionExit,
-	"<grv-suspend>":               ActionSuspend,
-	"<grv-prompt>":                ActionPrompt,
-	"<grv-search-prompt>":         ActionSearchPrompt,
-	"<grv-reverse-search-prompt>": ActionReverseSearchPrompt,
-	"<grv-filter-prompt>":         ActionFilterPrompt,
-	"<grv-search>":                ActionSearch,
-	"<grv-reverse-search>":        ActionReverseSearch,
-	"<grv-search-find-next>":      ActionSearchFindNext,
-	"<grv-search-find-prev>":      ActionSearchFindPrev,
-	"<grv-clear-search>":          ActionClearSearch,
-	"<grv-show-status>":           ActionShowStatus,
-	"<grv-next-line>":             ActionNextLine,
-	"<grv-prev-line>":             ActionPrevLine,
-	"<grv-next-page>":             ActionNextPage,
-	"<grv-prev-page>":             ActionPrevPage,
-	"<grv-next-half-page>":        ActionNextHalfPage,
-	"<grv-prev-half-page>":        ActionPrevHalfPage,
-	"<grv-scroll-right>":          ActionScrollRight,
-	"<grv-scroll-left>":           ActionScrollLeft,
-	"<grv-first-line>":            ActionFirstLine,
-	"<grv-last-line>":             ActionLastLine,
-	"<grv-select>":                ActionSelect,
-	"<grv-next-view>":             ActionNextView,
-	"<grv-prev-view>":             ActionPrevView,
-	"<grv-full-screen-view>":      ActionFullScreenView,
-	"<grv-toggle-view-layout>":    ActionToggleViewLayout,
-	"<grv-add-filter>":            ActionAddFilter,
-	"<grv-remove-filter>":         ActionRemoveFilter,
-	"<grv-center-view>":           ActionCenterView,
-	"<grv-next-tab>":              ActionNextTab,
-	"<grv-prev-tab>":              ActionPrevTab,
-	"<grv-add-tab>":               ActionNewTab,
-	"<grv-remove-tab>":            ActionRemoveTab,
-	"<grv-add-view>":              ActionAddView,
-	"<grv-split-view>":            ActionSplitView,
-	"<grv-remove-view>":           ActionRemoveView,
+	"<grv-nop>":                          ActionNone,
+	"<grv-exit>":                         ActionExit,
+	"<grv-suspend>":                      ActionSuspend,
+	"<grv-refresh>":                      ActionRefresh,
+	"<grv-prompt>":                       ActionPrompt,
+	"<grv-search-prompt>":                ActionSearchPrompt,
+	"<grv-reverse-search-prompt>":        ActionReverseSearchPrompt,
+	"<grv-filter-prompt>":                ActionFilterPrompt,
+	"<grv-pickaxe-prompt>":               ActionPickaxePrompt,
+	"<grv-pickaxe-regex-prompt>":         ActionPickaxeRegexPrompt,
+	"<grv-set-upstream-prompt>":          ActionSetUpstreamPrompt,
+	"<grv-prune-remote-branches-prompt>": ActionPruneRemoteBranchesPrompt,
+	"<grv-select-commit-prompt>":         ActionSelectCommitPrompt,
+	"<grv-set-mark-prompt>":              ActionSetMarkPrompt,
+	"<grv-jump-to-mark-prompt>":          ActionJumpToMarkPrompt,
+	"<grv-search>":                       ActionSearch,
+	"<grv-reverse-search>":               ActionReverseSearch,
+	"<grv-search-find-next>":             ActionSearchFindNext,
+	"<grv-search-find-prev>":             ActionSearchFindPrev,
+	"<grv-clear-search>":                 ActionClearSearch,
+	"<grv-clear-search-highlight>":       ActionClearSearchHighlight,
+	"<grv-show-status>":                  ActionShowStatus,
+	"<grv-next-line>":                    ActionNextLine,
+	"<grv-prev-line>":                    ActionPrevLine,
+	"<grv-next-page>":                    ActionNextPage,
+	"<grv-prev-page>":                    ActionPrevPage,
+	"<grv-next-half-page>":               ActionNextHalfPage,
+	"<grv-prev-half-page>":               ActionPrevHalfPage,
+	"<grv-scroll-right>":                 ActionScrollRight,
+	"<grv-scroll-left>":                  ActionScrollLeft,
+	"<grv-first-line>":                   ActionFirstLine,
+	"<grv-last-line>":                    ActionLastLine,
+	"<grv-select>":                       ActionSelect,
+	"<grv-next-view>":                    ActionNextView,
+	"<grv-prev-view>":                    ActionPrevView,
+	"<grv-full-screen-view>":             ActionFullScreenView,
+	"<grv-focus-left-view>":              ActionFocusLeftView,
+	"<grv-focus-right-view>":             ActionFocusRightView,
+	"<grv-focus-up-view>":                ActionFocusUpView,
+	"<grv-focus-down-view>":              ActionFocusDownView,
+	"<grv-toggle-view-layout>":           ActionToggleViewLayout,
+	"<grv-toggle-ref-view>":              ActionToggleRefView,
+	"<grv-add-filter>":                   ActionAddFilter,
+	"<grv-remove-filter>":                ActionRemoveFilter,
+	"<grv-center-view>":                  ActionCenterView,
+	"<grv-scroll-cursor-top>":            ActionScrollCursorTop,
+	"<grv-scroll-cursor-bottom>":         ActionScrollCursorBottom,
+	"<grv-next-tab>":                     ActionNextTab,
+	"<grv-prev-tab>":                     ActionPrevTab,
+	"<grv-add-tab>":                      ActionNewTab,
+	"<grv-remove-tab>":                   ActionRemoveTab,
+	"<grv-add-view>":                     ActionAddView,
+	"<grv-split-view>":                   ActionSplitView,
+	"<grv-remove-view>":                  ActionRemoveView,
+	"<grv-set-upstream>":                 ActionSetUpstream,
+	"<grv-find-stale-remote-branches>":   ActionFindStaleRemoteBranches,
+	"<grv-prune-remote-branches>":        ActionPruneRemoteBranches,
+	"<grv-select-commit>":                ActionSelectCommit,
+	"<grv-select-parent-commit>":         ActionSelectParentCommit,
+	"<grv-select-merge-parent-commit>":   ActionSelectMergeParentCommit,
+	"<grv-select-child-commit>":          ActionSelectChildCommit,
+	"<grv-jump-back>":                    ActionJumpBack,
+	"<grv-jump-forward>":                 ActionJumpForward,
+	"<grv-set-mark>":                     ActionSetMark,
+	"<grv-jump-to-mark>":                 ActionJumpToMark,
+	"<grv-remove-mark>":                  ActionRemoveMark,
+	"<grv-save-stash>":                   ActionSaveStash,
+	"<grv-apply-stash>":                  ActionApplyStash,
+	"<grv-pop-stash>":                    ActionPopStash,
+	"<grv-drop-stash>":                   ActionDropStash,
+	"<grv-resolve-ours>":                 ActionResolveOurs,
+	"<grv-resolve-theirs>":               ActionResolveTheirs,
+	"<grv-merge-tool>":                   ActionMergeTool,
+	"<grv-blame>":                        ActionBlame,
+	"<grv-reblame-parent>":               ActionReblameParent,
+	"<grv-line-history>":                 ActionLineHistory,
+	"<grv-incremental-search>":           ActionIncrementalSearch,
+	"<grv-end-incremental-search>":       ActionEndIncrementalSearch,
+	"<grv-toggle-wrap>":                  ActionToggleWrap,
+	"<grv-toggle-line-numbers>":          ActionToggleLineNumbers,
+	"<grv-cycle-diff-whitespace>":        ActionCycleDiffWhitespace,
+	"<grv-next-file>":                    ActionNextFile,
+	"<grv-prev-file>":                    ActionPrevFile,
+	"<grv-next-hunk>":                    ActionNextHunk,
+	"<grv-prev-hunk>":                    ActionPrevHunk,
+	"<grv-toggle-file-collapse>":         ActionToggleFileCollapse,
+	"<grv-collapse-all-files>":           ActionCollapseAllFiles,
+	"<grv-expand-all-files>":             ActionExpandAllFiles,
+	"<grv-cycle-diff-base>":              ActionCycleDiffBase,
+	"<grv-cycle-merge-parent>":           ActionCycleMergeParent,
+	"<grv-preview-binary>":               ActionPreviewBinary,
+	"<grv-diff-tool>":                    ActionDiffTool,
+	"<grv-visual-select>":                ActionVisualSelect,
+	"<grv-yank>":                         ActionYank,
+	"<grv-yank-without-prefix>":          ActionYankWithoutPrefix,
+	"<grv-yank-summary>":                 ActionYankSummary,
+	"<grv-yank-message>":                 ActionYankMessage,
+	"<grv-yank-reference>":               ActionYankReference,
+	"<grv-open-pull-request>":            ActionOpenPullRequest,
+	"<grv-open-issue>":                   ActionOpenIssue,
+	"<grv-bisect-start>":                 ActionBisectStart,
+	"<grv-bisect-good>":                  ActionBisectGood,
+	"<grv-bisect-bad>":                   ActionBisectBad,
+	"<grv-bisect-skip>":                  ActionBisectSkip,
+	"<grv-bisect-reset>":                 ActionBisectReset,
+	"<grv-rebase-pick>":                  ActionRebasePick,
+	"<grv-rebase-squash>":                ActionRebaseSquash,
+	"<grv-rebase-fixup>":                 ActionRebaseFixup,
+	"<grv-rebase-drop>":                  ActionRebaseDrop,
+	"<grv-rebase-clear>":                 ActionRebaseClear,
+	"<grv-interactive-rebase>":           ActionInteractiveRebase,
+	"<grv-run-interactive-rebase>":       ActionRunInteractiveRebase,
+	"<grv-amend-commit>":                 ActionAmendCommit,
+	"<grv-amend-commit-edit-message>":    ActionAmendCommitEditMessage,
+	"<grv-run-amend-commit>":             ActionRunAmendCommit,
+	"<grv-stage-lines>":                  ActionStageLines,
+	"<grv-discard-changes>":              ActionDiscardChanges,
+	"<grv-stage-file>":                   ActionStageFile,
+	"<grv-open-file>":                    ActionOpenFile,
+	"<grv-ignore-file>":                  ActionIgnoreFile,
+	"<grv-ignore-pattern-prompt>":        ActionIgnorePatternPrompt,
+	"<grv-ignore-pattern>":               ActionIgnorePattern,
+	"<grv-run-shell-command>":            ActionRunShellCommand,
+	"<grv-evaluate-config-command>":      ActionEvaluateConfigCommand,
 }
 
 var defaultKeyBindings = map[ActionType]map[ViewID][]string{
@@ -127,12 +376,18 @@ var defaultKeyBindings = map[ActionType]map[ViewID][]string{
 	ActionSuspend: {
 		ViewAll: {"<C-z>"},
 	},
+	ActionRefresh: {
+		ViewAll: {"<F5>"},
+	},
 	ActionSearchFindNext: {
 		ViewAll: {"n"},
 	},
 	ActionSearchFindPrev: {
 		ViewAll: {"N"},
 	},
+	ActionClearSearchHighlight: {
+		ViewAll: {"<C-l>"},
+	},
 	ActionNextLine: {
 		ViewAll: {"<Down>", "j"},
 	},
@@ -169,26 +424,55 @@ var defaultKeyBindings = map[ActionType]map[ViewID][]string{
 	ActionPrevView: {
 		ViewAll: {"<S-Tab>", "<C-w>W"},
 	},
+	ActionFocusLeftView: {
+		ViewAll: {"<C-w>h", "<C-w><Left>"},
+	},
+	ActionFocusRightView: {
+		ViewAll: {"<C-w>l", "<C-w><Right>"},
+	},
+	ActionFocusUpView: {
+		ViewAll: {"<C-w>k", "<C-w><Up>"},
+	},
+	ActionFocusDownView: {
+		ViewAll: {"<C-w>j", "<C-w><Down>"},
+	},
 	ActionFullScreenView: {
 		ViewAll: {"f", "<C-w>o", "<C-w><C-o>"},
 	},
 	ActionToggleViewLayout: {
 		ViewAll: {"<C-w>t"},
 	},
+	ActionToggleRefView: {
+		ViewAll: {"<C-w>r"},
+	},
 	ActionSelect: {
 		ViewAll: {"<Enter>"},
 	},
 	ActionFilterPrompt: {
 		ViewCommit: {"<C-q>"},
 		ViewRef:    {"<C-q>"},
+		ViewDiff:   {"<C-q>"},
 	},
 	ActionRemoveFilter: {
 		ViewCommit: {"<C-r>"},
 		ViewRef:    {"<C-r>"},
+		ViewDiff:   {"<C-r>"},
+	},
+	ActionPickaxePrompt: {
+		ViewCommit: {"S"},
+	},
+	ActionPickaxeRegexPrompt: {
+		ViewCommit: {"R"},
 	},
 	ActionCenterView: {
 		ViewAll: {"zz"},
 	},
+	ActionScrollCursorTop: {
+		ViewAll: {"zt"},
+	},
+	ActionScrollCursorBottom: {
+		ViewAll: {"zb"},
+	},
 	ActionNextTab: {
 		ViewAll: {"gt"},
 	},
@@ -198,6 +482,197 @@ var defaultKeyBindings = map[ActionType]map[ViewID][]string{
 	ActionRemoveView: {
 		ViewAll: {"q"},
 	},
+	ActionSetUpstreamPrompt: {
+		ViewRef: {"u"},
+	},
+	ActionFindStaleRemoteBranches: {
+		ViewRef: {"P"},
+	},
+	ActionSelectCommitPrompt: {
+		ViewCommit: {"gc"},
+	},
+	ActionSelectParentCommit: {
+		ViewCommit: {"p"},
+	},
+	ActionSelectMergeParentCommit: {
+		ViewCommit: {"P"},
+	},
+	ActionSelectChildCommit: {
+		ViewCommit: {"c"},
+	},
+	ActionJumpBack: {
+		ViewAll: {"<C-o>"},
+	},
+	// <C-i> cannot be distinguished from <Tab> in a terminal
+	ActionJumpForward: {
+		ViewAll: {"<C-n>"},
+	},
+	ActionSetMarkPrompt: {
+		ViewCommit: {"m"},
+	},
+	ActionJumpToMarkPrompt: {
+		ViewCommit: {"'"},
+	},
+	ActionRemoveMark: {
+		ViewMark: {"D"},
+	},
+	ActionSaveStash: {
+		ViewStash:     {"s"},
+		ViewGitStatus: {"S"},
+	},
+	ActionApplyStash: {
+		ViewStash: {"a"},
+	},
+	ActionPopStash: {
+		ViewStash: {"p"},
+	},
+	ActionDropStash: {
+		ViewStash: {"D"},
+	},
+	ActionResolveOurs: {
+		ViewConflict: {"o"},
+	},
+	ActionResolveTheirs: {
+		ViewConflict: {"t"},
+	},
+	ActionMergeTool: {
+		ViewConflict: {"M"},
+	},
+	ActionBlame: {
+		ViewDiff: {"B"},
+	},
+	ActionReblameParent: {
+		ViewBlame: {","},
+	},
+	ActionLineHistory: {
+		ViewDiff:  {"L"},
+		ViewBlame: {"L"},
+	},
+	ActionToggleWrap: {
+		ViewDiff: {"W"},
+	},
+	ActionToggleLineNumbers: {
+		ViewDiff: {"."},
+	},
+	ActionCycleDiffWhitespace: {
+		ViewDiff: {"w"},
+	},
+	ActionNextFile: {
+		ViewDiff: {"]f"},
+	},
+	ActionPrevFile: {
+		ViewDiff: {"[f"},
+	},
+	ActionNextHunk: {
+		ViewDiff: {"]c"},
+	},
+	ActionPrevHunk: {
+		ViewDiff: {"[c"},
+	},
+	ActionToggleFileCollapse: {
+		ViewDiff: {"za"},
+	},
+	ActionCollapseAllFiles: {
+		ViewDiff: {"zM"},
+	},
+	ActionExpandAllFiles: {
+		ViewDiff: {"zR"},
+	},
+	ActionCycleDiffBase: {
+		ViewDiff: {"b"},
+	},
+	ActionCycleMergeParent: {
+		ViewDiff: {"M"},
+	},
+	ActionPreviewBinary: {
+		ViewDiff: {"p"},
+	},
+	ActionDiffTool: {
+		ViewDiff: {"D"},
+	},
+	ActionVisualSelect: {
+		ViewDiff: {"V"},
+	},
+	ActionYank: {
+		ViewDiff:   {"y"},
+		ViewCommit: {"yy"},
+	},
+	ActionYankWithoutPrefix: {
+		ViewDiff: {"Y"},
+	},
+	ActionYankSummary: {
+		ViewCommit: {"ys"},
+	},
+	ActionYankMessage: {
+		ViewCommit: {"ym"},
+	},
+	ActionYankReference: {
+		ViewCommit: {"yr"},
+	},
+	ActionOpenPullRequest: {
+		ViewCommit: {"gp"},
+	},
+	ActionOpenIssue: {
+		ViewCommit: {"gi"},
+		ViewDiff:   {"gi"},
+	},
+	ActionBisectStart: {
+		ViewCommit: {"Bs"},
+	},
+	ActionBisectGood: {
+		ViewCommit: {"Bg"},
+	},
+	ActionBisectBad: {
+		ViewCommit: {"Bb"},
+	},
+	ActionBisectSkip: {
+		ViewCommit: {"Bk"},
+	},
+	ActionBisectReset: {
+		ViewCommit: {"Br"},
+	},
+	ActionRebasePick: {
+		ViewCommit: {"rp"},
+	},
+	ActionRebaseSquash: {
+		ViewCommit: {"rs"},
+	},
+	ActionRebaseFixup: {
+		ViewCommit: {"rf"},
+	},
+	ActionRebaseDrop: {
+		ViewCommit: {"rd"},
+	},
+	ActionRebaseClear: {
+		ViewCommit: {"rc"},
+	},
+	ActionInteractiveRebase: {
+		ViewCommit: {"ri"},
+	},
+	ActionAmendCommit: {
+		ViewGitStatus: {"ca"},
+	},
+	ActionAmendCommitEditMessage: {
+		ViewGitStatus: {"cA"},
+	},
+	ActionStageLines: {
+		ViewDiff: {"s"},
+	},
+	ActionDiscardChanges: {
+		ViewGitStatus: {"d"},
+	},
+	ActionStageFile: {
+		ViewGitStatus: {"a"},
+	},
+	ActionOpenFile: {
+		ViewGitStatus: {"o"},
+	},
+	ActionIgnoreFile: {
+		ViewGitStatus: {"i"},
+	},
+	ActionIgnorePatternPrompt: {
+		ViewGitStatus: {"I"},
+	},
 }
 
 // ViewHierarchy is a list of views parent to child
@@ -238,10 +713,18 @@ func newKeystringBinding(keystring string) Binding {
 // KeyBindings exposes key bindings that have been configured and allows new bindings to be set
 type KeyBindings interface {
 	Binding(viewHierarchy ViewHierarchy, keystring string) (binding Binding, isPrefix bool)
+	Completions(viewHierarchy ViewHierarchy, prefix string) []KeyBindingCompletion
+	ActionName(actionType ActionType) (name string, exists bool)
 	SetActionBinding(viewID ViewID, keystring string, actionType ActionType)
 	SetKeystringBinding(viewID ViewID, keystring, mappedKeystring string)
 }
 
+// KeyBindingCompletion is a bound key sequence which begins with a partially entered key sequence
+type KeyBindingCompletion struct {
+	keystring string
+	binding   Binding
+}
+
 // KeyBindingManager manages key bindings in grv
 type KeyBindingManager struct {
 	bindings map[ViewID]*pt.Trie
@@ -277,6 +760,68 @@ func (keyBindingManager *KeyBindingManager) Binding(viewHierarchy ViewHierarchy,
 	return newActionBinding(ActionNone), isPrefix
 }
 
+// Completions returns the bindings of the key sequences which begin with the provided prefix ordered by key sequence.
+// As with Binding, bindings for views earlier in the view hierarchy take priority
+func (keyBindingManager *KeyBindingManager) Completions(viewHierarchy ViewHierarchy, prefix string) (completions []KeyBindingCompletion) {
+	viewHierarchy = append(viewHierarchy, ViewAll)
+	visited := make(map[string]bool)
+
+	for _, viewID := range viewHierarchy {
+		viewBindings, ok := keyBindingManager.bindings[viewID]
+		if !ok {
+			continue
+		}
+
+		if err := viewBindings.VisitSubtree(pt.Prefix(prefix), func(keyPrefix pt.Prefix, item pt.Item) error {
+			keystring := string(keyPrefix)
+			if keystring == prefix || visited[keystring] || strings.HasPrefix(keystring, cfActionNamePrefix) {
+				return nil
+			}
+
+			visited[keystring] = true
+
+			if binding := item.(Binding); binding.bindingType != BtAction || binding.actionType != ActionNone {
+				completions = append(completions, KeyBindingCompletion{
+					keystring: keystring,
+					binding:   binding,
+				})
+			}
+
+			return nil
+		}); err != nil {
+			log.Errorf("Unable to determine completions for keys %v: %v", prefix, err)
+		}
+	}
+
+	sort.Slice(completions, func(i, j int) bool {
+		return completions[i].keystring < completions[j].keystring
+	})
+
+	return
+}
+
+// ActionName returns the name the action is referred to by in config, e.g. <grv-first-line>.
+// This includes actions registered by plugins
+func (keyBindingManager *KeyBindingManager) ActionName(actionType ActionType) (name string, exists bool) {
+	viewBindings, ok := keyBindingManager.bindings[ViewAll]
+	if !ok {
+		return
+	}
+
+	if err := viewBindings.VisitSubtree(pt.Prefix(cfActionNamePrefix), func(keyPrefix pt.Prefix, item pt.Item) error {
+		if binding := item.(Binding); !exists && binding.bindingType == BtAction && binding.actionType == actionType {
+			name = string(keyPrefix)
+			exists = true
+		}
+
+		return nil
+	}); err != nil {
+		log.Errorf("Unable to determine name of action %v: %v", actionType, err)
+	}
+
+	return
+}
+
 // SetActionBinding allows an action to be bound to the provided key sequence and view
 func (keyBindingManager *KeyBindingManager) SetActionBinding(viewID ViewID, keystring string, actionType ActionType) {
 	viewBindings := keyBindingManager.getOrCreateViewBindings(viewID)
@@ -315,8 +860,11 @@ func (keyBindingManager *KeyBindingManager) setDefaultKeyBindings() {
 }
 
 func isValidAction(action string) bool {
-	_, valid := actionKeys[action]
-	return valid
+	if _, valid := actionKeys[action]; valid {
+		return true
+	}
+
+	return strings.HasPrefix(action, plActionKeyPrefix) && strings.HasSuffix(action, plActionKeySuffix)
 }
 
 // DefaultKeyBindings returns the default key sequences that are bound to an action for the provided view
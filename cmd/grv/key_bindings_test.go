@@ -109,3 +109,73 @@ func TestDefaultKeyBindingsReturnsBindings(t *testing.T) {
 		}
 	}
 }
+
+func TestCompletionsOfPrefixAreReturnedInOrder(t *testing.T) {
+	keyBindings := NewKeyBindingManager()
+
+	keyBindings.SetActionBinding(ViewAll, "qb", ActionLastLine)
+	keyBindings.SetActionBinding(ViewAll, "qa", ActionFirstLine)
+	keyBindings.SetKeystringBinding(ViewRef, "qc", "gg")
+	keyBindings.SetActionBinding(ViewRef, "qa", ActionNextLine)
+	keyBindings.SetActionBinding(ViewCommit, "qd", ActionPrevLine)
+
+	completions := keyBindings.Completions(ViewHierarchy([]ViewID{ViewMain, ViewHistory, ViewRef}), "q")
+
+	expectedCompletions := []KeyBindingCompletion{
+		{keystring: "qa", binding: newActionBinding(ActionNextLine)},
+		{keystring: "qb", binding: newActionBinding(ActionLastLine)},
+		{keystring: "qc", binding: newKeystringBinding("gg")},
+	}
+
+	if !reflect.DeepEqual(expectedCompletions, completions) {
+		t.Errorf("Completions did not match expected result. Expected: %v, Actual: %v", expectedCompletions, completions)
+	}
+}
+
+func TestCompletionsExcludeActionNamesAndDisabledBindings(t *testing.T) {
+	keyBindings := NewKeyBindingManager()
+
+	keyBindings.SetActionBinding(ViewAll, "<grv-q>", ActionFirstLine)
+	keyBindings.SetActionBinding(ViewAll, "<gq", ActionNone)
+
+	if completions := keyBindings.Completions(ViewHierarchy([]ViewID{ViewMain, ViewHistory}), "<g"); len(completions) != 0 {
+		t.Errorf("Expected no completions but found: %v", completions)
+	}
+}
+
+func TestActionNameIsReturnedForAction(t *testing.T) {
+	keyBindings := NewKeyBindingManager()
+
+	if name, exists := keyBindings.ActionName(ActionFirstLine); !exists || name != "<grv-first-line>" {
+		t.Errorf("Action name did not match expected value. Expected: <grv-first-line>, Actual: %v", name)
+	}
+
+	if name, exists := keyBindings.ActionName(ActionType(-1)); exists {
+		t.Errorf("Expected no action name but found: %v", name)
+	}
+}
+
+func TestMovementActionsApplyTheirCountOnce(t *testing.T) {
+	countTests := []struct {
+		action              Action
+		expectedRepeatCount uint
+		expectedMoveCount   uint
+	}{
+		{action: Action{ActionType: ActionNextLine}, expectedRepeatCount: 1, expectedMoveCount: 1},
+		{action: Action{ActionType: ActionNextLine, Count: 50}, expectedRepeatCount: 1, expectedMoveCount: 50},
+		{action: Action{ActionType: ActionSelectParentCommit, Count: 3}, expectedRepeatCount: 1, expectedMoveCount: 3},
+		{action: Action{ActionType: ActionNextHunk, Count: 4}, expectedRepeatCount: 4, expectedMoveCount: 4},
+	}
+
+	for _, countTest := range countTests {
+		if repeatCount := countTest.action.RepeatCount(); repeatCount != countTest.expectedRepeatCount {
+			t.Errorf("RepeatCount did not match expected value for action %v. Expected: %v, Actual: %v",
+				countTest.action, countTest.expectedRepeatCount, repeatCount)
+		}
+
+		if moveCount := countTest.action.MoveCount(); moveCount != countTest.expectedMoveCount {
+			t.Errorf("MoveCount did not match expected value for action %v. Expected: %v, Actual: %v",
+				countTest.action, countTest.expectedMoveCount, moveCount)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"strings"
+)
+
+const (
+	khMaxRows       = 8
+	khColumnSpacing = 3
+)
+
+// KeyHint describes a key sequence which completes a partially entered key sequence
+type KeyHint struct {
+	keys        string
+	description string
+}
+
+// KeyHintView displays the key sequences which complete a partially entered
+// key sequence and the actions they are bound to
+type KeyHintView struct {
+	pendingKeys string
+	keyHints    []KeyHint
+}
+
+// NewKeyHintView creates a new instance of the key hint view
+func NewKeyHintView() *KeyHintView {
+	return &KeyHintView{}
+}
+
+// SetKeyHints sets the partially entered key sequence and the key hints for the key hint view to display
+func (keyHintView *KeyHintView) SetKeyHints(pendingKeys string, keyHints []KeyHint) {
+	keyHintView.pendingKeys = pendingKeys
+	keyHintView.keyHints = keyHints
+}
+
+// DisplayRowsRequired calculates the number of rows on the display required
+// to display the key hints in columns within the provided width
+func (keyHintView *KeyHintView) DisplayRowsRequired(cols uint) uint {
+	if len(keyHintView.keyHints) == 0 {
+		return 0
+	}
+
+	return keyHintLayout(keyHintView.keyHints, cols).rows + 2
+}
+
+type keyHintColumns struct {
+	keysWidth   uint
+	columnWidth uint
+	columns     uint
+	rows        uint
+}
+
+// keyHintLayout determines how the key hints are arranged in columns within the provided width.
+// The number of rows is limited so any key hints which do not fit are not displayed
+func keyHintLayout(keyHints []KeyHint, cols uint) (layout keyHintColumns) {
+	var descriptionWidth uint
+
+	for _, keyHint := range keyHints {
+		layout.keysWidth = MaxUint(layout.keysWidth, StringWidth(keyHint.keys))
+		descriptionWidth = MaxUint(descriptionWidth, StringWidth(keyHint.description))
+	}
+
+	layout.columnWidth = layout.keysWidth + 1 + descriptionWidth + khColumnSpacing
+
+	// Allow for the border and the leading space of each row
+	availableCols := cols - MinUint(cols, 3)
+	layout.columns = MaxUint(1, availableCols/layout.columnWidth)
+
+	keyHintNum := uint(len(keyHints))
+	layout.rows = MinUint(khMaxRows, (keyHintNum+layout.columns-1)/layout.columns)
+
+	return
+}
+
+// Render generates and writes the key hint view to the provided window
+func (keyHintView *KeyHintView) Render(win RenderWindow) (err error) {
+	keyHintNum := uint(len(keyHintView.keyHints))
+	layout := keyHintLayout(keyHintView.keyHints, win.Cols())
+	rows := MinUint(layout.rows, win.Rows()-MinUint(win.Rows(), 2))
+	var displayed uint
+
+	for rowIndex := uint(0); rowIndex < rows; rowIndex++ {
+		var lineBuilder *LineBuilder
+		if lineBuilder, err = win.LineBuilder(rowIndex+1, 1); err != nil {
+			return
+		}
+
+		lineBuilder.Append(" ")
+
+		// Key hints are ordered down each column
+		for column := uint(0); column < layout.columns; column++ {
+			keyHintIndex := column*rows + rowIndex
+			if keyHintIndex >= keyHintNum {
+				break
+			}
+
+			keyHint := keyHintView.keyHints[keyHintIndex]
+			keysPadding := layout.keysWidth - StringWidth(keyHint.keys) + 1
+			descriptionPadding := layout.columnWidth - layout.keysWidth - 1 - StringWidth(keyHint.description)
+
+			lineBuilder.
+				AppendWithStyle(CmpKeyHintViewKeys, "%v", keyHint.keys).
+				Append("%v%v%v", strings.Repeat(" ", int(keysPadding)), keyHint.description, strings.Repeat(" ", int(descriptionPadding)))
+
+			displayed++
+		}
+	}
+
+	win.DrawBorder()
+
+	if err = win.SetTitle(CmpKeyHintViewTitle, "%v", keyHintView.pendingKeys); err != nil {
+		return
+	}
+
+	if displayed < keyHintNum {
+		err = win.SetFooter(CmpKeyHintViewFooter, "%v more", keyHintNum-displayed)
+	}
+
+	return
+}
+
+// HandleEvent does nothing
+func (keyHintView *KeyHintView) HandleEvent(event Event) (err error) {
+	return
+}
+
+// HandleAction does nothing
+func (keyHintView *KeyHintView) HandleAction(Action) (err error) {
+	return
+}
+
+// OnActiveChange does nothing
+func (keyHintView *KeyHintView) OnActiveChange(bool) {
+
+}
+
+// ViewID returns the view ID of the key hint view
+func (keyHintView *KeyHintView) ViewID() ViewID {
+	return ViewKeyHint
+}
+
+// RenderHelpBar does nothing
+func (keyHintView *KeyHintView) RenderHelpBar(*LineBuilder) (err error) {
+	return
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func testKeyHints(keyHintNum int) (keyHints []KeyHint) {
+	for i := 0; i < keyHintNum; i++ {
+		keyHints = append(keyHints, KeyHint{keys: fmt.Sprintf("g%c", 'a'+i), description: "first-line"})
+	}
+
+	return
+}
+
+func TestKeyHintLayoutFitsHintsIntoAvailableColumns(t *testing.T) {
+	keyHintLayoutTests := []struct {
+		keyHintNum     int
+		cols           uint
+		expectedLayout keyHintColumns
+	}{
+		{keyHintNum: 3, cols: 80, expectedLayout: keyHintColumns{keysWidth: 2, columnWidth: 16, columns: 4, rows: 1}},
+		{keyHintNum: 10, cols: 80, expectedLayout: keyHintColumns{keysWidth: 2, columnWidth: 16, columns: 4, rows: 3}},
+		{keyHintNum: 10, cols: 20, expectedLayout: keyHintColumns{keysWidth: 2, columnWidth: 16, columns: 1, rows: khMaxRows}},
+		{keyHintNum: 2, cols: 5, expectedLayout: keyHintColumns{keysWidth: 2, columnWidth: 16, columns: 1, rows: 2}},
+	}
+
+	for _, keyHintLayoutTest := range keyHintLayoutTests {
+		if layout := keyHintLayout(testKeyHints(keyHintLayoutTest.keyHintNum), keyHintLayoutTest.cols); layout != keyHintLayoutTest.expectedLayout {
+			t.Errorf("Layout for %v key hints and %v columns does not match expected value. Expected: %+v, Actual: %+v",
+				keyHintLayoutTest.keyHintNum, keyHintLayoutTest.cols, keyHintLayoutTest.expectedLayout, layout)
+		}
+	}
+}
+
+func TestKeyHintViewRequiresRowsForHintsAndBorder(t *testing.T) {
+	keyHintView := NewKeyHintView()
+
+	if rows := keyHintView.DisplayRowsRequired(80); rows != 0 {
+		t.Errorf("Expected no rows to be required without key hints but found %v", rows)
+	}
+
+	keyHintView.SetKeyHints("g", testKeyHints(10))
+
+	if rows := keyHintView.DisplayRowsRequired(80); rows != 5 {
+		t.Errorf("Expected 5 rows to be required but found %v", rows)
+	}
+}
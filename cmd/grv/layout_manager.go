@@ -0,0 +1,293 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	lmVerticalOrientation   = "vertical"
+	lmHorizontalOrientation = "horizontal"
+	lmPercentageSuffix      = "%"
+	lmTotalPercentage       = 100
+)
+
+var layoutViewIDs = map[string]ViewID{
+	cfRefView:    ViewRef,
+	cfCommitView: ViewCommit,
+	cfDiffView:   ViewDiff,
+}
+
+// LayoutView is a view in a layout along with the percentage of the available space it occupies
+type LayoutView struct {
+	viewID     ViewID
+	percentage uint
+}
+
+// Layout describes the views to display, their orientation and their relative sizes
+type Layout struct {
+	orientation ContainerOrientation
+	views       []LayoutView
+}
+
+// ParseLayout parses a layout of the form "[vertical|horizontal] view:percentage% ...".
+// A nil layout is returned for an empty string
+func ParseLayout(layoutString string) (layout *Layout, err error) {
+	fields := strings.Fields(layoutString)
+	if len(fields) == 0 {
+		return
+	}
+
+	layout = &Layout{orientation: CoVertical}
+
+	switch strings.ToLower(fields[0]) {
+	case lmVerticalOrientation:
+		fields = fields[1:]
+	case lmHorizontalOrientation:
+		layout.orientation = CoHorizontal
+		fields = fields[1:]
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("Layout must contain at least one view")
+	}
+
+	usedViewIDs := make(map[ViewID]bool)
+	totalPercentage := uint(0)
+
+	for _, field := range fields {
+		var layoutView LayoutView
+		if layoutView, err = parseLayoutView(field); err != nil {
+			return nil, err
+		}
+
+		if usedViewIDs[layoutView.viewID] {
+			return nil, fmt.Errorf("View specified more than once in layout: %v", field)
+		}
+
+		usedViewIDs[layoutView.viewID] = true
+		totalPercentage += layoutView.percentage
+		layout.views = append(layout.views, layoutView)
+	}
+
+	if totalPercentage != lmTotalPercentage {
+		return nil, fmt.Errorf("Layout view percentages must sum to %v but sum to %v", lmTotalPercentage, totalPercentage)
+	}
+
+	return
+}
+
+func parseLayoutView(field string) (layoutView LayoutView, err error) {
+	parts := strings.Split(field, ":")
+	if len(parts) != 2 || !strings.HasSuffix(parts[1], lmPercentageSuffix) {
+		err = fmt.Errorf("Invalid layout view %v. Expected format view:percentage%%", field)
+		return
+	}
+
+	viewFound := false
+	for viewName, viewID := range layoutViewIDs {
+		if strings.EqualFold(viewName, parts[0]) {
+			layoutView.viewID = viewID
+			viewFound = true
+			break
+		}
+	}
+
+	if !viewFound {
+		err = fmt.Errorf("Unsupported layout view: %v", parts[0])
+		return
+	}
+
+	percentage, err := strconv.Atoi(strings.TrimSuffix(parts[1], lmPercentageSuffix))
+	if err != nil || percentage < 1 || percentage > lmTotalPercentage {
+		err = fmt.Errorf("Invalid layout percentage %v. Expected a value between 1 and %v", parts[1], lmTotalPercentage)
+		return
+	}
+
+	layoutView.percentage = uint(percentage)
+
+	return
+}
+
+// LayoutListener is notified when the configured layout changes
+type LayoutListener interface {
+	OnLayoutChange(layout *Layout)
+}
+
+// ResizeListener is notified when the dimensions of the display change
+type ResizeListener interface {
+	OnResize(viewDimension ViewDimension)
+}
+
+// ViewDimensionProvider returns the current dimensions of the display
+type ViewDimensionProvider func() ViewDimension
+
+// LayoutManager is the central source of the display dimensions all views are laid out within.
+// Listeners are notified whenever the dimensions change so that every view can be laid out again
+type LayoutManager struct {
+	dimensionProvider ViewDimensionProvider
+	viewDimension     ViewDimension
+	initialised       bool
+	listeners         []ResizeListener
+	layoutListeners   []LayoutListener
+	config            Config
+	lock              sync.Mutex
+}
+
+// NewLayoutManager creates a new instance which determines the display dimensions using the provided function
+func NewLayoutManager(dimensionProvider ViewDimensionProvider) *LayoutManager {
+	return &LayoutManager{
+		dimensionProvider: dimensionProvider,
+	}
+}
+
+// AddResizeListener registers a listener to be notified when the display dimensions change
+func (layoutManager *LayoutManager) AddResizeListener(listener ResizeListener) {
+	layoutManager.lock.Lock()
+	defer layoutManager.lock.Unlock()
+
+	layoutManager.listeners = append(layoutManager.listeners, listener)
+}
+
+// AddLayoutListener registers a listener to be notified when the configured layout changes
+func (layoutManager *LayoutManager) AddLayoutListener(listener LayoutListener) {
+	layoutManager.lock.Lock()
+	defer layoutManager.lock.Unlock()
+
+	layoutManager.layoutListeners = append(layoutManager.layoutListeners, listener)
+}
+
+// SetConfig sets the config the layout is read from and listens for changes to it
+func (layoutManager *LayoutManager) SetConfig(config Config) {
+	layoutManager.lock.Lock()
+	layoutManager.config = config
+	layoutManager.lock.Unlock()
+
+	config.AddOnChangeListener(CfLayout, layoutManager)
+}
+
+func (layoutManager *LayoutManager) onConfigVariableChange(configVariable ConfigVariable) {
+	layoutManager.lock.Lock()
+	config := layoutManager.config
+	listeners := append([]LayoutListener(nil), layoutManager.layoutListeners...)
+	layoutManager.lock.Unlock()
+
+	layout, err := ParseLayout(config.GetString(CfLayout))
+	if err != nil {
+		log.Errorf("Unable to parse layout: %v", err)
+		return
+	}
+
+	log.Infof("Applying layout: %v", config.GetString(CfLayout))
+
+	for _, listener := range listeners {
+		listener.OnLayoutChange(layout)
+	}
+}
+
+// ViewDimension returns the most recently calculated display dimensions
+func (layoutManager *LayoutManager) ViewDimension() ViewDimension {
+	layoutManager.lock.Lock()
+	defer layoutManager.lock.Unlock()
+
+	return layoutManager.viewDimension
+}
+
+// Recalculate determines the current display dimensions and returns them.
+// If the dimensions have changed since they were last calculated then all listeners are notified
+func (layoutManager *LayoutManager) Recalculate() ViewDimension {
+	viewDimension := layoutManager.dimensionProvider()
+
+	layoutManager.lock.Lock()
+	changed := layoutManager.initialised && viewDimension != layoutManager.viewDimension
+	layoutManager.viewDimension = viewDimension
+	layoutManager.initialised = true
+	listeners := append([]ResizeListener(nil), layoutManager.listeners...)
+	layoutManager.lock.Unlock()
+
+	if changed {
+		log.Infof("Display dimensions changed to %v", viewDimension)
+
+		for _, listener := range listeners {
+			listener.OnResize(viewDimension)
+		}
+	}
+
+	return viewDimension
+}
+
+// layoutPositionCalculator sizes child views using the percentages specified in a layout.
+// When only some of the layout views are displayed they share the available space in proportion
+// to their percentages. The container layout is used if a child view is not part of the layout
+type layoutPositionCalculator struct {
+	containerView *ContainerView
+	layout        *Layout
+}
+
+// CalculateChildViewPositions calculates the child layout data using the layout percentages
+func (calculator *layoutPositionCalculator) CalculateChildViewPositions(viewLayoutData *ViewLayoutData) (childPositions []*ChildViewPosition) {
+	percentages, totalPercentage, ok := calculator.childViewPercentages(viewLayoutData.childViewIDs)
+
+	if viewLayoutData.fullScreen || !ok || uint(len(percentages)) != viewLayoutData.childViewNum {
+		return calculator.containerView.CalculateChildViewPositions(viewLayoutData)
+	}
+
+	layoutViewNum := uint(len(percentages))
+
+	vertical := viewLayoutData.orientation == CoVertical
+	totalSize := viewLayoutData.viewDimension.rows
+	if vertical {
+		totalSize = viewLayoutData.viewDimension.cols
+	}
+
+	start := uint(0)
+
+	for viewIndex, percentage := range percentages {
+		size := totalSize * percentage / totalPercentage
+		if uint(viewIndex) == layoutViewNum-1 {
+			size = totalSize - start
+		}
+
+		childPosition := &ChildViewPosition{
+			viewDimension: viewLayoutData.viewDimension,
+		}
+
+		if vertical {
+			childPosition.viewDimension.cols = size
+			childPosition.startCol = start
+		} else {
+			childPosition.viewDimension.rows = size
+			childPosition.startRow = start
+		}
+
+		childPositions = append(childPositions, childPosition)
+		start += size
+	}
+
+	return
+}
+
+func (calculator *layoutPositionCalculator) childViewPercentages(childViewIDs []ViewID) (percentages []uint, totalPercentage uint, ok bool) {
+	for _, childViewID := range childViewIDs {
+		viewFound := false
+
+		for _, layoutView := range calculator.layout.views {
+			if layoutView.viewID == childViewID {
+				percentages = append(percentages, layoutView.percentage)
+				totalPercentage += layoutView.percentage
+				viewFound = true
+				break
+			}
+		}
+
+		if !viewFound {
+			return nil, 0, false
+		}
+	}
+
+	return percentages, totalPercentage, totalPercentage > 0
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"testing"
+)
+
+type mockResizeListener struct {
+	viewDimensions []ViewDimension
+}
+
+func (resizeListener *mockResizeListener) OnResize(viewDimension ViewDimension) {
+	resizeListener.viewDimensions = append(resizeListener.viewDimensions, viewDimension)
+}
+
+func TestListenersAreNotifiedOnlyWhenDimensionsChange(t *testing.T) {
+	viewDimension := ViewDimension{rows: 24, cols: 80}
+	layoutManager := NewLayoutManager(func() ViewDimension {
+		return viewDimension
+	})
+
+	resizeListener := &mockResizeListener{}
+	layoutManager.AddResizeListener(resizeListener)
+
+	layoutManager.Recalculate()
+	layoutManager.Recalculate()
+
+	if len(resizeListener.viewDimensions) != 0 {
+		t.Errorf("Expected no resize notifications but received %v", len(resizeListener.viewDimensions))
+	}
+
+	viewDimension = ViewDimension{rows: 50, cols: 120}
+
+	if recalculatedDimension := layoutManager.Recalculate(); recalculatedDimension != viewDimension {
+		t.Errorf("Recalculated dimension does not match expected value. Expected: %v, Actual: %v", viewDimension, recalculatedDimension)
+	}
+
+	if len(resizeListener.viewDimensions) != 1 || resizeListener.viewDimensions[0] != viewDimension {
+		t.Errorf("Resize notifications do not match expected value. Expected: [%v], Actual: %v", viewDimension, resizeListener.viewDimensions)
+	}
+
+	if layoutManager.ViewDimension() != viewDimension {
+		t.Errorf("ViewDimension does not match expected value. Expected: %v, Actual: %v", viewDimension, layoutManager.ViewDimension())
+	}
+}
+
+func TestLayoutIsParsed(t *testing.T) {
+	layout, err := ParseLayout("horizontal RefView:20% commitview:50% DIFFVIEW:30%")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedViews := []LayoutView{
+		{viewID: ViewRef, percentage: 20},
+		{viewID: ViewCommit, percentage: 50},
+		{viewID: ViewDiff, percentage: 30},
+	}
+
+	if layout.orientation != CoHorizontal {
+		t.Errorf("Layout orientation does not match expected value. Expected: %v, Actual: %v", CoHorizontal, layout.orientation)
+	}
+
+	if len(layout.views) != len(expectedViews) {
+		t.Fatalf("Layout view number does not match expected value. Expected: %v, Actual: %v", len(expectedViews), len(layout.views))
+	}
+
+	for viewIndex, expectedView := range expectedViews {
+		if layout.views[viewIndex] != expectedView {
+			t.Errorf("Layout view does not match expected value. Expected: %v, Actual: %v", expectedView, layout.views[viewIndex])
+		}
+	}
+}
+
+func TestLayoutOrientationDefaultsToVertical(t *testing.T) {
+	layout, err := ParseLayout("commitview:60% diffview:40%")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if layout.orientation != CoVertical {
+		t.Errorf("Layout orientation does not match expected value. Expected: %v, Actual: %v", CoVertical, layout.orientation)
+	}
+}
+
+func TestEmptyLayoutIsParsedAsNoLayout(t *testing.T) {
+	layout, err := ParseLayout("  ")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if layout != nil {
+		t.Errorf("Expected nil layout but got: %v", layout)
+	}
+}
+
+func TestInvalidLayoutsAreRejected(t *testing.T) {
+	invalidLayouts := []string{
+		"horizontal",
+		"refview:20% commitview:50%",
+		"refview:50% refview:50%",
+		"gitstatusview:100%",
+		"commitview:100",
+		"commitview:0% diffview:100%",
+		"commitview:abc%",
+	}
+
+	for _, invalidLayout := range invalidLayouts {
+		if _, err := ParseLayout(invalidLayout); err == nil {
+			t.Errorf("Expected error for layout %v", invalidLayout)
+		}
+	}
+}
+
+func TestChildViewsAreSizedUsingLayoutPercentages(t *testing.T) {
+	layout, err := ParseLayout("refview:20% commitview:50% diffview:30%")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	calculator := &layoutPositionCalculator{layout: layout}
+	childPositions := calculator.CalculateChildViewPositions(&ViewLayoutData{
+		viewDimension: ViewDimension{rows: 24, cols: 99},
+		orientation:   CoVertical,
+		childViewNum:  3,
+		childViewIDs:  []ViewID{ViewRef, ViewCommit, ViewDiff},
+	})
+
+	expectedPositions := []ChildViewPosition{
+		{viewDimension: ViewDimension{rows: 24, cols: 19}, startCol: 0},
+		{viewDimension: ViewDimension{rows: 24, cols: 49}, startCol: 19},
+		{viewDimension: ViewDimension{rows: 24, cols: 31}, startCol: 68},
+	}
+
+	if len(childPositions) != len(expectedPositions) {
+		t.Fatalf("Child position number does not match expected value. Expected: %v, Actual: %v", len(expectedPositions), len(childPositions))
+	}
+
+	for positionIndex, expectedPosition := range expectedPositions {
+		if *childPositions[positionIndex] != expectedPosition {
+			t.Errorf("Child position does not match expected value. Expected: %+v, Actual: %+v", expectedPosition, *childPositions[positionIndex])
+		}
+	}
+}
+
+func TestDisplayedViewsShareSpaceInProportionToLayoutPercentages(t *testing.T) {
+	layout, err := ParseLayout("horizontal refview:20% commitview:50% diffview:30%")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	calculator := &layoutPositionCalculator{layout: layout}
+	childPositions := calculator.CalculateChildViewPositions(&ViewLayoutData{
+		viewDimension: ViewDimension{rows: 40, cols: 80},
+		orientation:   CoHorizontal,
+		childViewNum:  2,
+		childViewIDs:  []ViewID{ViewCommit, ViewDiff},
+	})
+
+	expectedPositions := []ChildViewPosition{
+		{viewDimension: ViewDimension{rows: 25, cols: 80}, startRow: 0},
+		{viewDimension: ViewDimension{rows: 15, cols: 80}, startRow: 25},
+	}
+
+	if len(childPositions) != len(expectedPositions) {
+		t.Fatalf("Child position number does not match expected value. Expected: %v, Actual: %v", len(expectedPositions), len(childPositions))
+	}
+
+	for positionIndex, expectedPosition := range expectedPositions {
+		if *childPositions[positionIndex] != expectedPosition {
+			t.Errorf("Child position does not match expected value. Expected: %+v, Actual: %+v", expectedPosition, *childPositions[positionIndex])
+		}
+	}
+}
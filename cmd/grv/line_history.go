@@ -0,0 +1,173 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	lhDevNull      = "/dev/null"
+	lhOldPathStart = "--- "
+	lhNewPathStart = "+++ "
+	lhNoNewline    = "\\"
+)
+
+var lhHunkHeaderRegex = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// LineRange is a range of lines in a file at a revision.
+// Line numbers start at 1 and the range includes the end line
+type LineRange struct {
+	path     string
+	start    int
+	end      int
+	revision string
+}
+
+// String returns a description of the line range
+func (lineRange LineRange) String() string {
+	if lineRange.start == lineRange.end {
+		return fmt.Sprintf("%v:%v", lineRange.path, lineRange.start)
+	}
+
+	return fmt.Sprintf("%v:%v-%v", lineRange.path, lineRange.start, lineRange.end)
+}
+
+type hunkHeader struct {
+	oldStart int
+	oldLines int
+	newStart int
+	newLines int
+}
+
+func parseHunkHeader(line string) (header hunkHeader, err error) {
+	matches := lhHunkHeaderRegex.FindStringSubmatch(line)
+	if matches == nil {
+		err = fmt.Errorf("Invalid hunk header: %v", line)
+		return
+	}
+
+	values := []*int{&header.oldStart, &header.oldLines, &header.newStart, &header.newLines}
+
+	for valueIndex, value := range values {
+		match := matches[valueIndex+1]
+
+		if match == "" {
+			*value = 1
+		} else if *value, err = strconv.Atoi(match); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// findDiffLineRange determines the line range selected in the diff of the commit with the provided oid.
+// When a hunk header is selected the lines of the hunk are used, otherwise the selected line is used.
+// Removed lines only exist in the parent commit, so their range refers to the parent revision
+func findDiffLineRange(lines []*diffLineData, lineIndex uint, oid string) (lineRange LineRange, err error) {
+	if lineIndex >= uint(len(lines)) {
+		err = fmt.Errorf("Invalid line index: %v", lineIndex)
+		return
+	}
+
+	hunkIndex := -1
+	for index := int(lineIndex); index >= 0; index-- {
+		lines[index].determineDiffLineType()
+
+		if lineType := lines[index].lineType; lineType == dltHunkStart {
+			hunkIndex = index
+			break
+		} else if lineType != dltNormal && lineType != dltLineAdded && lineType != dltLineRemoved {
+			break
+		}
+	}
+
+	if hunkIndex == -1 {
+		err = fmt.Errorf("Select a hunk or a line within a hunk to view its history")
+		return
+	}
+
+	header, err := parseHunkHeader(lines[hunkIndex].line)
+	if err != nil {
+		return
+	}
+
+	oldPath, newPath := findDiffPaths(lines, hunkIndex)
+	oldRange := LineRange{path: oldPath, revision: oid + "^"}
+	newRange := LineRange{path: newPath, revision: oid}
+
+	if uint(hunkIndex) == lineIndex {
+		if header.newLines > 0 {
+			lineRange = newRange
+			lineRange.start = header.newStart
+			lineRange.end = header.newStart + header.newLines - 1
+		} else {
+			lineRange = oldRange
+			lineRange.start = header.oldStart
+			lineRange.end = header.oldStart + header.oldLines - 1
+		}
+	} else {
+		oldLine, newLine := header.oldStart, header.newStart
+
+		for index := uint(hunkIndex + 1); index < lineIndex; index++ {
+			switch line := lines[index].line; {
+			case strings.HasPrefix(line, "+"):
+				newLine++
+			case strings.HasPrefix(line, "-"):
+				oldLine++
+			case strings.HasPrefix(line, lhNoNewline):
+			default:
+				oldLine++
+				newLine++
+			}
+		}
+
+		if lines[lineIndex].lineType == dltLineRemoved {
+			lineRange = oldRange
+			lineRange.start = oldLine
+		} else {
+			lineRange = newRange
+			lineRange.start = newLine
+		}
+
+		lineRange.end = lineRange.start
+	}
+
+	if lineRange.path == "" {
+		err = fmt.Errorf("Unable to determine file path for hunk: %v", lines[hunkIndex].line)
+	} else if lineRange.start < 1 || lineRange.end < lineRange.start {
+		err = fmt.Errorf("No lines to view the history of")
+	}
+
+	return
+}
+
+// findDiffPaths returns the old and new paths of the file the hunk at hunkIndex belongs to.
+// A path is empty if the file does not exist on that side of the diff
+func findDiffPaths(lines []*diffLineData, hunkIndex int) (oldPath, newPath string) {
+	for index := hunkIndex - 1; index >= 0; index-- {
+		line := lines[index].line
+
+		switch {
+		case strings.HasPrefix(line, lhNewPathStart):
+			newPath = diffPath(strings.TrimPrefix(line, lhNewPathStart), "b/")
+		case strings.HasPrefix(line, lhOldPathStart):
+			oldPath = diffPath(strings.TrimPrefix(line, lhOldPathStart), "a/")
+			return
+		}
+	}
+
+	return
+}
+
+func diffPath(path, prefix string) string {
+	path = strings.TrimRight(path, "\t")
+
+	if path == lhDevNull {
+		return ""
+	}
+
+	return strings.TrimPrefix(path, prefix)
+}
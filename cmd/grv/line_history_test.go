@@ -0,0 +1,96 @@
+package main
+
+import (
+	"testing"
+)
+
+func testDiffLines(lines ...string) (diffLines []*diffLineData) {
+	for _, line := range lines {
+		diffLines = append(diffLines, &diffLineData{line: line})
+	}
+
+	return
+}
+
+func TestHunkHeaderLineCountsDefaultToOne(t *testing.T) {
+	header, err := parseHunkHeader("@@ -3 +4,0 @@ func main() {")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := hunkHeader{oldStart: 3, oldLines: 1, newStart: 4, newLines: 0}
+	if header != expected {
+		t.Errorf("Hunk header does not match expected value. Expected: %v, Actual: %v", expected, header)
+	}
+
+	if _, err = parseHunkHeader("not a hunk header"); err == nil {
+		t.Errorf("Expected error for invalid hunk header")
+	}
+}
+
+func TestLineRangeIsDeterminedForSelectedDiffLine(t *testing.T) {
+	lines := testDiffLines(
+		"diff --git a/old.go b/new.go",
+		"index 1234567..89abcde 100644",
+		"--- a/old.go",
+		"+++ b/new.go",
+		"@@ -10,4 +10,5 @@ func main() {",
+		" context",
+		"-removed",
+		"+added one",
+		"+added two",
+		" context",
+	)
+
+	lineRangeTests := []struct {
+		lineIndex uint
+		expected  LineRange
+	}{
+		{lineIndex: 4, expected: LineRange{path: "new.go", start: 10, end: 14, revision: "abc"}},
+		{lineIndex: 5, expected: LineRange{path: "new.go", start: 10, end: 10, revision: "abc"}},
+		{lineIndex: 6, expected: LineRange{path: "old.go", start: 11, end: 11, revision: "abc^"}},
+		{lineIndex: 8, expected: LineRange{path: "new.go", start: 12, end: 12, revision: "abc"}},
+		{lineIndex: 9, expected: LineRange{path: "new.go", start: 13, end: 13, revision: "abc"}},
+	}
+
+	for _, lineRangeTest := range lineRangeTests {
+		lineRange, err := findDiffLineRange(lines, lineRangeTest.lineIndex, "abc")
+		if err != nil {
+			t.Errorf("Unexpected error for line %v: %v", lineRangeTest.lineIndex, err)
+		} else if lineRange != lineRangeTest.expected {
+			t.Errorf("Line range does not match expected value. Expected: %v, Actual: %v", lineRangeTest.expected, lineRange)
+		}
+	}
+}
+
+func TestLineRangeOfDeletedFileUsesParentRevision(t *testing.T) {
+	lines := testDiffLines(
+		"diff --git a/file.go b/file.go",
+		"deleted file mode 100644",
+		"--- a/file.go",
+		"+++ /dev/null",
+		"@@ -1,2 +0,0 @@",
+		"-first",
+		"-second",
+	)
+
+	expected := LineRange{path: "file.go", start: 1, end: 2, revision: "abc^"}
+
+	if lineRange, err := findDiffLineRange(lines, 4, "abc"); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if lineRange != expected {
+		t.Errorf("Line range does not match expected value. Expected: %v, Actual: %v", expected, lineRange)
+	}
+}
+
+func TestLineRangeOutsideHunkReturnsError(t *testing.T) {
+	lines := testDiffLines(
+		"diff --git a/file.go b/file.go",
+		"--- a/file.go",
+		"+++ b/file.go",
+	)
+
+	if _, err := findDiffLineRange(lines, 1, "abc"); err == nil {
+		t.Errorf("Expected error when no hunk is selected")
+	}
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+type partialHunk struct {
+	header   hunkHeader
+	section  string
+	lines    []string
+	oldLines int
+	newLines int
+	changes  uint
+}
+
+type partialFilePatch struct {
+	path        string
+	headerLines []string
+	hunks       []*partialHunk
+	selected    bool
+	renamed     bool
+}
+
+// lines returns the lines of the file patch with hunk headers regenerated to match the selected lines.
+// The new start line of each hunk is offset by the lines added and removed by the preceding hunks.
+// As in the hunk headers git generates, the start of an empty range is the line before it
+func (filePatch *partialFilePatch) lines() (lines []string) {
+	lines = append(lines, filePatch.headerLines...)
+	offset := 0
+
+	for _, hunk := range filePatch.hunks {
+		firstLine := hunk.header.oldStart + offset
+		if hunk.oldLines == 0 {
+			firstLine++
+		}
+
+		newStart := firstLine
+		if hunk.newLines == 0 {
+			newStart--
+		}
+
+		lines = append(lines, fmt.Sprintf("@@ -%v,%v +%v,%v @@%v",
+			hunk.header.oldStart, hunk.oldLines, newStart, hunk.newLines, hunk.section))
+		lines = append(lines, hunk.lines...)
+		offset += hunk.newLines - hunk.oldLines
+	}
+
+	return
+}
+
+// partialPatch generates a patch from the diff lines which only contains the selected added and removed lines.
+// Selecting a hunk header selects all lines in the hunk and selecting a file header selects all lines in the file.
+// Unselected removed lines are converted to context lines and unselected added lines are omitted.
+// The number of added and removed lines in the patch is returned along with it
+func partialPatch(lines []*diffLineData, selected map[*diffLineData]bool) (patch string, changes uint, err error) {
+	var patchLines []string
+	var filePatch *partialFilePatch
+	var hunk *partialHunk
+	var oldRemaining, newRemaining int
+	var hunkSelected, prevLineOmitted bool
+
+	endHunk := func() {
+		if hunk != nil && hunk.changes > 0 {
+			filePatch.hunks = append(filePatch.hunks, hunk)
+		}
+
+		hunk = nil
+	}
+
+	endFile := func() error {
+		endHunk()
+
+		if filePatch == nil || len(filePatch.hunks) == 0 {
+			return nil
+		}
+
+		if filePatch.renamed {
+			return fmt.Errorf("Unable to stage lines of renamed or copied file %v", filePatch.path)
+		}
+
+		patchLines = append(patchLines, filePatch.lines()...)
+
+		for _, stagedHunk := range filePatch.hunks {
+			changes += stagedHunk.changes
+		}
+
+		return nil
+	}
+
+	for _, diffLine := range lines {
+		line := diffLine.line
+
+		switch {
+		case diffLine.isFileHeader():
+			if err = endFile(); err != nil {
+				return
+			}
+
+			filePatch = &partialFilePatch{
+				path:        diffHeaderPath(line),
+				headerLines: []string{line},
+				selected:    selected[diffLine],
+			}
+		case filePatch == nil:
+		case strings.HasPrefix(line, "@@"):
+			endHunk()
+
+			var header hunkHeader
+			if header, err = parseHunkHeader(line); err != nil {
+				return
+			}
+
+			hunk = &partialHunk{
+				header:  header,
+				section: line[len(lhHunkHeaderRegex.FindString(line)):],
+			}
+			oldRemaining, newRemaining = header.oldLines, header.newLines
+			hunkSelected = filePatch.selected || selected[diffLine]
+			prevLineOmitted = false
+		case hunk == nil:
+			filePatch.headerLines = append(filePatch.headerLines, line)
+			filePatch.selected = filePatch.selected || selected[diffLine]
+			filePatch.renamed = filePatch.renamed ||
+				strings.HasPrefix(line, dvRenamedPrefix) || strings.HasPrefix(line, dvCopiedPrefix)
+		case strings.HasPrefix(line, lhNoNewline):
+			if !prevLineOmitted {
+				hunk.lines = append(hunk.lines, line)
+			}
+		case oldRemaining <= 0 && newRemaining <= 0:
+		case strings.HasPrefix(line, "+"):
+			newRemaining--
+			prevLineOmitted = !hunkSelected && !selected[diffLine]
+
+			if !prevLineOmitted {
+				hunk.lines = append(hunk.lines, line)
+				hunk.newLines++
+				hunk.changes++
+			}
+		case strings.HasPrefix(line, "-"):
+			oldRemaining--
+			prevLineOmitted = false
+			hunk.oldLines++
+
+			if hunkSelected || selected[diffLine] {
+				hunk.lines = append(hunk.lines, line)
+				hunk.changes++
+			} else {
+				hunk.lines = append(hunk.lines, " "+line[1:])
+				hunk.newLines++
+			}
+		default:
+			oldRemaining--
+			newRemaining--
+			prevLineOmitted = false
+			hunk.lines = append(hunk.lines, line)
+			hunk.oldLines++
+			hunk.newLines++
+		}
+	}
+
+	if err = endFile(); err != nil {
+		return
+	}
+
+	if len(patchLines) == 0 {
+		return "", 0, fmt.Errorf("No added or removed lines selected")
+	}
+
+	return strings.Join(patchLines, "\n") + "\n", changes, nil
+}
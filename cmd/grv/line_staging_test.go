@@ -0,0 +1,149 @@
+package main
+
+import (
+	"testing"
+)
+
+func testPartialPatchLines() []*diffLineData {
+	return testDiffLines(
+		" main.go | 4 +++-",
+		"",
+		"diff --git a/main.go b/main.go",
+		"index 1234567..89abcde 100644",
+		"--- a/main.go",
+		"+++ b/main.go",
+		"@@ -1,4 +1,5 @@ package main",
+		" one",
+		"-two",
+		"+TWO",
+		"+three",
+		" four",
+		" five",
+		"@@ -10,2 +11,3 @@ func main() {",
+		" ten",
+		"+eleven",
+		" twelve",
+	)
+}
+
+func testSelectedLines(lines []*diffLineData, lineIndexes ...int) map[*diffLineData]bool {
+	selected := make(map[*diffLineData]bool)
+
+	for _, lineIndex := range lineIndexes {
+		selected[lines[lineIndex]] = true
+	}
+
+	return selected
+}
+
+func TestPartialPatchContainsOnlySelectedLines(t *testing.T) {
+	lines := testPartialPatchLines()
+
+	patch, changes, err := partialPatch(lines, testSelectedLines(lines, 10))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedPatch := "diff --git a/main.go b/main.go\n" +
+		"index 1234567..89abcde 100644\n" +
+		"--- a/main.go\n" +
+		"+++ b/main.go\n" +
+		"@@ -1,4 +1,5 @@ package main\n" +
+		" one\n" +
+		" two\n" +
+		"+three\n" +
+		" four\n" +
+		" five\n"
+
+	if patch != expectedPatch {
+		t.Errorf("Patch does not match expected value. Expected: %q, Actual: %q", expectedPatch, patch)
+	}
+
+	if changes != 1 {
+		t.Errorf("Expected 1 change but found %v", changes)
+	}
+}
+
+func TestPartialPatchOffsetsLaterHunks(t *testing.T) {
+	lines := testPartialPatchLines()
+
+	patch, changes, err := partialPatch(lines, testSelectedLines(lines, 8, 15))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedPatch := "diff --git a/main.go b/main.go\n" +
+		"index 1234567..89abcde 100644\n" +
+		"--- a/main.go\n" +
+		"+++ b/main.go\n" +
+		"@@ -1,4 +1,3 @@ package main\n" +
+		" one\n" +
+		"-two\n" +
+		" four\n" +
+		" five\n" +
+		"@@ -10,2 +9,3 @@ func main() {\n" +
+		" ten\n" +
+		"+eleven\n" +
+		" twelve\n"
+
+	if patch != expectedPatch {
+		t.Errorf("Patch does not match expected value. Expected: %q, Actual: %q", expectedPatch, patch)
+	}
+
+	if changes != 2 {
+		t.Errorf("Expected 2 changes but found %v", changes)
+	}
+}
+
+func TestPartialPatchSelectsWholeHunkAndFile(t *testing.T) {
+	lines := testPartialPatchLines()
+
+	if _, changes, err := partialPatch(lines, testSelectedLines(lines, 6)); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if changes != 3 {
+		t.Errorf("Expected 3 changes when selecting hunk header but found %v", changes)
+	}
+
+	if _, changes, err := partialPatch(lines, testSelectedLines(lines, 2)); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if changes != 4 {
+		t.Errorf("Expected 4 changes when selecting file header but found %v", changes)
+	}
+}
+
+func TestPartialPatchOmitsNoNewlineMarkerOfOmittedLine(t *testing.T) {
+	lines := testDiffLines(
+		"diff --git a/file b/file",
+		"--- a/file",
+		"+++ b/file",
+		"@@ -1 +1 @@",
+		"-old",
+		"\\ No newline at end of file",
+		"+new",
+		"\\ No newline at end of file",
+	)
+
+	patch, _, err := partialPatch(lines, testSelectedLines(lines, 4))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedPatch := "diff --git a/file b/file\n" +
+		"--- a/file\n" +
+		"+++ b/file\n" +
+		"@@ -1,1 +0,0 @@\n" +
+		"-old\n" +
+		"\\ No newline at end of file\n"
+
+	if patch != expectedPatch {
+		t.Errorf("Patch does not match expected value. Expected: %q, Actual: %q", expectedPatch, patch)
+	}
+}
+
+func TestPartialPatchReturnsErrorWhenNoChangesSelected(t *testing.T) {
+	lines := testPartialPatchLines()
+
+	if _, _, err := partialPatch(lines, testSelectedLines(lines, 0, 7)); err == nil {
+		t.Errorf("Expected error when no added or removed lines are selected")
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+// LinePart is a section of a line displayed using a single style
+type LinePart struct {
+	themeComponentID ThemeComponentID
+	text             string
+}
+
+// WrapLineParts splits the provided line into rows which each fit within width columns.
+// Each row is assumed to be drawn from startColumn, which determines the width of tabs.
+// Grapheme clusters are never split across rows
+func WrapLineParts(lineParts []LinePart, startColumn, width uint, config Config) (rows [][]LinePart) {
+	var row []LinePart
+	rowWidth := uint(0)
+
+	for _, linePart := range lineParts {
+		text := linePart.text
+
+		for cluster, rest := NextGraphemeCluster(text); cluster != ""; cluster, rest = NextGraphemeCluster(rest) {
+			clusterWidth := renderedClusterWidth(cluster, startColumn+rowWidth, config)
+
+			if rowWidth > 0 && rowWidth+clusterWidth > width {
+				rows = append(rows, row)
+				row = nil
+				rowWidth = 0
+				clusterWidth = renderedClusterWidth(cluster, startColumn, config)
+			}
+
+			if partNum := len(row); partNum > 0 && row[partNum-1].themeComponentID == linePart.themeComponentID {
+				row[partNum-1].text += cluster
+			} else {
+				row = append(row, LinePart{themeComponentID: linePart.themeComponentID, text: cluster})
+			}
+
+			rowWidth += clusterWidth
+		}
+	}
+
+	return append(rows, row)
+}
+
+func renderedClusterWidth(cluster string, column uint, config Config) (width uint) {
+	for _, renderedCodePoint := range DetermineRenderedGraphemeCluster(cluster, column, config) {
+		width += renderedCodePoint.width
+	}
+
+	return
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLinePartsAreWrappedToWidth(t *testing.T) {
+	lineParts := []LinePart{
+		{themeComponentID: CmpDiffviewDifflineHunkStart, text: "@@ -1 +1 @@"},
+		{themeComponentID: CmpDiffviewDifflineHunkHeader, text: " func"},
+	}
+
+	expectedRows := [][]LinePart{
+		{
+			{themeComponentID: CmpDiffviewDifflineHunkStart, text: "@@ -1 +"},
+		},
+		{
+			{themeComponentID: CmpDiffviewDifflineHunkStart, text: "1 @@"},
+			{themeComponentID: CmpDiffviewDifflineHunkHeader, text: " fu"},
+		},
+		{
+			{themeComponentID: CmpDiffviewDifflineHunkHeader, text: "nc"},
+		},
+	}
+
+	if rows := WrapLineParts(lineParts, 2, 7, nil); !reflect.DeepEqual(rows, expectedRows) {
+		t.Errorf("Wrapped rows do not match expected value. Expected: %v, Actual: %v", expectedRows, rows)
+	}
+}
+
+func TestWideCharactersAreNotSplitAcrossRows(t *testing.T) {
+	lineParts := []LinePart{
+		{themeComponentID: CmpNone, text: "ab世界"},
+	}
+
+	expectedRows := [][]LinePart{
+		{
+			{themeComponentID: CmpNone, text: "ab"},
+		},
+		{
+			{themeComponentID: CmpNone, text: "世"},
+		},
+		{
+			{themeComponentID: CmpNone, text: "界"},
+		},
+	}
+
+	if rows := WrapLineParts(lineParts, 1, 3, nil); !reflect.DeepEqual(rows, expectedRows) {
+		t.Errorf("Wrapped rows do not match expected value. Expected: %v, Actual: %v", expectedRows, rows)
+	}
+}
+
+func TestEmptyLineIsWrappedToSingleRow(t *testing.T) {
+	rows := WrapLineParts([]LinePart{{themeComponentID: CmpNone}}, 1, 10, nil)
+
+	if len(rows) != 1 {
+		t.Errorf("Expected a single row but found %v", len(rows))
+	}
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+type listViewHandler func(listView *ListView, action Action, rowNum uint) (moved bool)
+
+var listViewHandlers = map[ActionType]listViewHandler{
+	ActionPrevLine:           moveUpListRow,
+	ActionNextLine:           moveDownListRow,
+	ActionPrevPage:           moveUpListPage,
+	ActionNextPage:           moveDownListPage,
+	ActionPrevHalfPage:       moveUpListHalfPage,
+	ActionNextHalfPage:       moveDownListHalfPage,
+	ActionScrollRight:        scrollListViewRight,
+	ActionScrollLeft:         scrollListViewLeft,
+	ActionFirstLine:          moveToFirstListRow,
+	ActionLastLine:           moveToLastListRow,
+	ActionCenterView:         centerListView,
+	ActionScrollCursorTop:    scrollListViewCursorTop,
+	ActionScrollCursorBottom: scrollListViewCursorBottom,
+}
+
+// ListView contains the state and the movement and search handling
+// shared by views which display a list of rows.
+// Views embed a ListView and use its lock to guard their own state
+type ListView struct {
+	channels      *Channels
+	viewPos       ViewPos
+	viewDimension ViewDimension
+	viewSearch    *ViewSearch
+	lock          sync.Mutex
+}
+
+// NewListView creates a new instance.
+// The embedding view is responsible for creating the view search
+// as it provides the lines to search
+func NewListView(channels *Channels) *ListView {
+	return &ListView{
+		channels: channels,
+		viewPos:  NewViewPosition(),
+	}
+}
+
+// ViewPos returns the view position for this view
+func (listView *ListView) ViewPos() ViewPos {
+	return listView.viewPos
+}
+
+// OnSearchMatch selects the row which matched the search pattern
+func (listView *ListView) OnSearchMatch(startPos ViewPos, matchLineIndex uint) {
+	listView.lock.Lock()
+	defer listView.lock.Unlock()
+
+	if listView.viewPos != startPos {
+		log.Debugf("Selected row has changed since search started")
+		return
+	}
+
+	listView.viewPos.SetActiveRowIndex(matchLineIndex)
+}
+
+// HandleListAction handles movement and search actions for a list containing rowNum rows.
+// The lock must be held by the caller
+func (listView *ListView) HandleListAction(action Action, rowNum uint) (handled bool, err error) {
+	handler, ok := listViewHandlers[action.ActionType]
+	if !ok {
+		return listView.viewSearch.HandleAction(action)
+	}
+
+	if handler(listView, action, rowNum) {
+		viewPos := listView.viewPos
+		log.Debugf("Handled action %v. Active row: %v, view start column: %v",
+			action.ActionType, viewPos.ActiveRowIndex(), viewPos.ViewStartColumn())
+		listView.channels.UpdateDisplay()
+	}
+
+	return true, nil
+}
+
+// RenderSearchHighlight highlights matches of the most recent search
+func (listView *ListView) RenderSearchHighlight(win RenderWindow) (err error) {
+	if searchActive, searchPattern, lastSearchFoundMatch := listView.viewSearch.SearchActive(); searchActive && lastSearchFoundMatch {
+		err = win.Highlight(searchPattern, CmpAllviewSearchMatch)
+	}
+
+	return
+}
+
+func (listView *ListView) pageRows() uint {
+	return pageRows(listView.viewDimension)
+}
+
+func moveUpListRow(listView *ListView, action Action, rowNum uint) bool {
+	return listView.viewPos.MoveLinesUp(action.MoveCount())
+}
+
+func moveDownListRow(listView *ListView, action Action, rowNum uint) bool {
+	return listView.viewPos.MoveLinesDown(action.MoveCount(), rowNum)
+}
+
+func moveUpListPage(listView *ListView, action Action, rowNum uint) bool {
+	return listView.viewPos.MovePagesUp(action.MoveCount(), listView.pageRows())
+}
+
+func moveDownListPage(listView *ListView, action Action, rowNum uint) bool {
+	return listView.viewPos.MovePagesDown(action.MoveCount(), listView.pageRows(), rowNum)
+}
+
+func moveUpListHalfPage(listView *ListView, action Action, rowNum uint) bool {
+	return listView.viewPos.MoveHalfPagesUp(action.MoveCount(), listView.pageRows())
+}
+
+func moveDownListHalfPage(listView *ListView, action Action, rowNum uint) bool {
+	return listView.viewPos.MoveHalfPagesDown(action.MoveCount(), listView.pageRows(), rowNum)
+}
+
+func scrollListViewRight(listView *ListView, action Action, rowNum uint) bool {
+	listView.viewPos.MovePageRight(listView.viewDimension.cols)
+	return true
+}
+
+func scrollListViewLeft(listView *ListView, action Action, rowNum uint) bool {
+	return listView.viewPos.MovePageLeft(listView.viewDimension.cols)
+}
+
+func moveToFirstListRow(listView *ListView, action Action, rowNum uint) bool {
+	return listView.viewPos.MoveToFirstLine()
+}
+
+func moveToLastListRow(listView *ListView, action Action, rowNum uint) bool {
+	return listView.viewPos.MoveToLastLine(rowNum)
+}
+
+func centerListView(listView *ListView, action Action, rowNum uint) bool {
+	return listView.viewPos.CenterActiveRow(listView.pageRows())
+}
+
+func scrollListViewCursorTop(listView *ListView, action Action, rowNum uint) bool {
+	return listView.viewPos.ScrollActiveRowTop()
+}
+
+func scrollListViewCursorBottom(listView *ListView, action Action, rowNum uint) bool {
+	return listView.viewPos.ScrollActiveRowBottom(listView.pageRows())
+}
@@ -8,6 +8,7 @@ import (
 	"path"
 	"runtime"
 	"strings"
+	"sync"
 
 	log "github.com/Sirupsen/logrus"
 )
@@ -17,7 +18,22 @@ const (
 	logFileDateFormat = "2006-01-02 15:04:05.000-0700"
 )
 
-var logFile string
+var (
+	logLevel             = strings.ToLower(MnLogLevelDefault)
+	logFilePath          string
+	logFile              *os.File
+	logFileCanonicalPath string
+	logLock              sync.Mutex
+)
+
+var logLevels = map[string]log.Level{
+	"PANIC": log.PanicLevel,
+	"FATAL": log.FatalLevel,
+	"ERROR": log.ErrorLevel,
+	"WARN":  log.WarnLevel,
+	"INFO":  log.InfoLevel,
+	"DEBUG": log.DebugLevel,
+}
 
 type fileHook struct{}
 
@@ -77,45 +93,140 @@ func (formatter logFormatter) formatBracketEntry(buffer *bytes.Buffer, value str
 	buffer.WriteString("] ")
 }
 
-// LogFile returns the path of the file GRV is logging to
-func LogFile() string {
-	return logFile
+// ParseLogLevel returns the logrus level with the provided name.
+// Logging to a file is disabled for the level NONE, in which case entries at the info level and above are
+// still captured for the LogView
+func ParseLogLevel(name string) (level log.Level, fileLogging bool, err error) {
+	upperName := strings.ToUpper(name)
+
+	if upperName == MnLogLevelDefault {
+		return log.InfoLevel, false, nil
+	}
+
+	level, ok := logLevels[upperName]
+	if !ok {
+		err = fmt.Errorf("Invalid log level %v. Valid values are none, panic, fatal, error, warn, info and debug", name)
+	}
+
+	return level, ok, err
+}
+
+// LogLevel returns the name of the configured log level
+func LogLevel() string {
+	logLock.Lock()
+	defer logLock.Unlock()
+
+	return logLevel
 }
 
-// InitialiseLogging sets up logging
+// LogFilePath returns the path of the configured log file
+func LogFilePath() string {
+	logLock.Lock()
+	defer logLock.Unlock()
+
+	return logFilePath
+}
+
+// IsLogFile returns true if the path refers to the file GRV is currently logging to
+func IsLogFile(filePath string) bool {
+	logLock.Lock()
+	defer logLock.Unlock()
+
+	return logFile != nil && (filePath == logFile.Name() || filePath == logFileCanonicalPath)
+}
+
+// InitialiseLogging sets up logging. The log file is truncated if logging to it is enabled
 func InitialiseLogging(logLevel, logFilePath string) {
-	if logLevel == MnLogLevelDefault {
-		log.SetOutput(ioutil.Discard)
+	log.SetOutput(ioutil.Discard)
+	log.SetFormatter(logFormatter{})
+	log.AddHook(fileHook{})
+	log.AddHook(sessionLog)
+
+	if err := configureLogging(logLevel, logFilePath, os.O_TRUNC); err != nil {
+		fmt.Fprintf(os.Stderr, "FATAL: %v\n", err)
+		os.Exit(1)
+	}
+
+	log.Info("Logging initialised")
+}
+
+// ConfigureLogging sets the log level and the file logged to.
+// Logging to a file is disabled if the level is NONE or the file path is empty.
+// Entries are appended to the log file if it already exists
+func ConfigureLogging(logLevel, logFilePath string) error {
+	return configureLogging(logLevel, logFilePath, os.O_APPEND)
+}
+
+func configureLogging(level, filePath string, openFlags int) (err error) {
+	logrusLevel, fileLogging, err := ParseLogLevel(level)
+	if err != nil {
 		return
 	}
 
-	logFile = logFilePath
+	logLock.Lock()
+	defer logLock.Unlock()
 
-	logLevels := map[string]log.Level{
-		"PANIC": log.PanicLevel,
-		"FATAL": log.FatalLevel,
-		"ERROR": log.ErrorLevel,
-		"WARN":  log.WarnLevel,
-		"INFO":  log.InfoLevel,
-		"DEBUG": log.DebugLevel,
+	activeFilePath := ""
+	if logFile != nil {
+		activeFilePath = logFile.Name()
 	}
 
-	if level, ok := logLevels[logLevel]; ok {
-		log.SetLevel(level)
-	} else {
-		log.Fatalf("Invalid logLevel: %v", logLevel)
+	if !fileLogging || filePath == "" {
+		log.SetOutput(ioutil.Discard)
+		closeLogFile()
+	} else if filePath != activeFilePath {
+		var file *os.File
+		if file, err = os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|openFlags, 0644); err != nil {
+			return fmt.Errorf("Unable to open log file %v for writing: %v", filePath, err)
+		}
+
+		log.SetOutput(file)
+		closeLogFile()
+
+		logFile = file
+		logFileCanonicalPath, _ = CanonicalPath(filePath)
 	}
 
-	file, err := os.OpenFile(logFilePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
-	if err != nil {
-		log.Fatalf("Unable to open log file %v for writing: %v", logFilePath, err)
+	log.SetLevel(logrusLevel)
+	logLevel = strings.ToLower(level)
+	logFilePath = filePath
+
+	return
+}
+
+func closeLogFile() {
+	if logFile != nil {
+		logFile.Close()
+		logFile = nil
+		logFileCanonicalPath = ""
 	}
+}
 
-	log.SetOutput(file)
+// logConfigListener applies changes to the logging config variables
+type logConfigListener struct {
+	config   Config
+	channels *Channels
+}
 
-	log.SetFormatter(logFormatter{})
+// ListenForLogConfigChanges reconfigures logging when the loglevel or logfile config variables are set
+func ListenForLogConfigChanges(config Config, channels *Channels) {
+	listener := &logConfigListener{
+		config:   config,
+		channels: channels,
+	}
 
-	log.AddHook(fileHook{})
+	config.AddOnChangeListener(CfLogLevel, listener)
+	config.AddOnChangeListener(CfLogFile, listener)
+}
 
-	log.Info("Logging initialised")
+func (listener *logConfigListener) onConfigVariableChange(configVariable ConfigVariable) {
+	level := listener.config.GetString(CfLogLevel)
+	filePath := listener.config.GetString(CfLogFile)
+
+	if err := ConfigureLogging(level, filePath); err != nil {
+		listener.channels.ReportError(err)
+		return
+	}
+
+	log.Infof("Log level set to %v and log file set to \"%v\"", level, filePath)
 }
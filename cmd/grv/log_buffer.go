@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	lbMaxEntries       = 2000
+	lbEntryTimeFormat  = "15:04:05.000"
+	lbLevelColumnWidth = 7
+)
+
+var sessionLog = NewLogBuffer(lbMaxEntries)
+
+// LogEntry is a log message captured during the current session
+type LogEntry struct {
+	time    time.Time
+	level   log.Level
+	file    string
+	message string
+}
+
+// String returns a single line representation of the log entry
+func (logEntry LogEntry) String() string {
+	var buffer strings.Builder
+	fmt.Fprintf(&buffer, "%v %-*v ", logEntry.time.Format(lbEntryTimeFormat), lbLevelColumnWidth, strings.ToUpper(logEntry.level.String()))
+
+	if logEntry.file != "" {
+		fmt.Fprintf(&buffer, "%v ", logEntry.file)
+	}
+
+	buffer.WriteString("- ")
+	buffer.WriteString(strings.Replace(logEntry.message, "\n", "\\n", -1))
+
+	return buffer.String()
+}
+
+// LogBuffer retains the most recent log entries so they can be inspected without opening the log file.
+// It is registered as a logrus hook and receives every entry logged at the configured log level
+type LogBuffer struct {
+	entries    []LogEntry
+	start      int
+	maxEntries int
+	lock       sync.Mutex
+}
+
+// NewLogBuffer creates a new instance which retains at most maxEntries entries
+func NewLogBuffer(maxEntries int) *LogBuffer {
+	return &LogBuffer{
+		maxEntries: maxEntries,
+	}
+}
+
+// Add stores the entry, replacing the oldest entry if the buffer is full
+func (logBuffer *LogBuffer) Add(logEntry LogEntry) {
+	logBuffer.lock.Lock()
+	defer logBuffer.lock.Unlock()
+
+	if len(logBuffer.entries) < logBuffer.maxEntries {
+		logBuffer.entries = append(logBuffer.entries, logEntry)
+		return
+	}
+
+	logBuffer.entries[logBuffer.start] = logEntry
+	logBuffer.start = (logBuffer.start + 1) % len(logBuffer.entries)
+}
+
+// Entries returns the retained entries ordered from oldest to newest
+func (logBuffer *LogBuffer) Entries() []LogEntry {
+	logBuffer.lock.Lock()
+	defer logBuffer.lock.Unlock()
+
+	entries := make([]LogEntry, 0, len(logBuffer.entries))
+	entries = append(entries, logBuffer.entries[logBuffer.start:]...)
+	entries = append(entries, logBuffer.entries[:logBuffer.start]...)
+
+	return entries
+}
+
+// Fire stores the logrus entry
+func (logBuffer *LogBuffer) Fire(entry *log.Entry) (err error) {
+	file, _ := entry.Data["file"].(string)
+
+	logBuffer.Add(LogEntry{
+		time:    entry.Time,
+		level:   entry.Level,
+		file:    file,
+		message: entry.Message,
+	})
+
+	return
+}
+
+// Levels returns the log levels the buffer receives entries for
+func (logBuffer *LogBuffer) Levels() []log.Level {
+	return log.AllLevels
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+func TestLogBufferRetainsMostRecentEntries(t *testing.T) {
+	logBuffer := NewLogBuffer(3)
+
+	for _, message := range []string{"one", "two", "three", "four", "five"} {
+		logBuffer.Add(LogEntry{message: message})
+	}
+
+	entries := logBuffer.Entries()
+	expectedMessages := []string{"three", "four", "five"}
+
+	if len(entries) != len(expectedMessages) {
+		t.Fatalf("Expected %v entries but found %v", len(expectedMessages), len(entries))
+	}
+
+	for entryIndex, entry := range entries {
+		if entry.message != expectedMessages[entryIndex] {
+			t.Errorf("Entry %v does not match expected value. Expected: %v, Actual: %v",
+				entryIndex, expectedMessages[entryIndex], entry.message)
+		}
+	}
+}
+
+func TestLogBufferCapturesLogrusEntries(t *testing.T) {
+	logBuffer := NewLogBuffer(10)
+	entryTime := time.Date(2018, 3, 4, 15, 4, 5, 0, time.UTC)
+
+	err := logBuffer.Fire(&log.Entry{
+		Time:    entryTime,
+		Level:   log.ErrorLevel,
+		Message: "Unable to load ref\nmaster",
+		Data:    log.Fields{"file": "repo_data.go:42"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	entries := logBuffer.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry but found %v", len(entries))
+	}
+
+	expectedLine := "15:04:05.000 ERROR   repo_data.go:42 - Unable to load ref\\nmaster"
+	if line := entries[0].String(); line != expectedLine {
+		t.Errorf("Entry line does not match expected value. Expected: %q, Actual: %q", expectedLine, line)
+	}
+}
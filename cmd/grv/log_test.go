@@ -0,0 +1,76 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+func TestLogLevelIsParsedIgnoringCase(t *testing.T) {
+	logLevelTests := []struct {
+		name                string
+		expectedLevel       log.Level
+		expectedFileLogging bool
+	}{
+		{name: "none", expectedLevel: log.InfoLevel, expectedFileLogging: false},
+		{name: "NONE", expectedLevel: log.InfoLevel, expectedFileLogging: false},
+		{name: "debug", expectedLevel: log.DebugLevel, expectedFileLogging: true},
+		{name: "Warn", expectedLevel: log.WarnLevel, expectedFileLogging: true},
+	}
+
+	for _, logLevelTest := range logLevelTests {
+		level, fileLogging, err := ParseLogLevel(logLevelTest.name)
+
+		switch {
+		case err != nil:
+			t.Errorf("Unexpected error for log level %v: %v", logLevelTest.name, err)
+		case level != logLevelTest.expectedLevel || fileLogging != logLevelTest.expectedFileLogging:
+			t.Errorf("Log level %v does not match expected value. Expected: %v %v, Actual: %v %v", logLevelTest.name,
+				logLevelTest.expectedLevel, logLevelTest.expectedFileLogging, level, fileLogging)
+		}
+	}
+
+	if _, _, err := ParseLogLevel("verbose"); err == nil {
+		t.Errorf("Expected error for invalid log level")
+	}
+}
+
+func TestLogFileIsOpenedOnlyWhenFileLoggingIsEnabled(t *testing.T) {
+	dir, err := ioutil.TempDir("", "grv-log")
+	if err != nil {
+		t.Fatalf("Unable to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	defer ConfigureLogging(MnLogLevelDefault, "")
+
+	logFilePath := filepath.Join(dir, "grv.log")
+
+	if err = ConfigureLogging("none", logFilePath); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	} else if IsLogFile(logFilePath) {
+		t.Errorf("Expected file logging to be disabled for log level none")
+	}
+
+	if err = ConfigureLogging("debug", logFilePath); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	} else if !IsLogFile(logFilePath) {
+		t.Errorf("Expected %v to be logged to", logFilePath)
+	}
+
+	if LogLevel() != "debug" || LogFilePath() != logFilePath {
+		t.Errorf("Unexpected logging configuration: %v %v", LogLevel(), LogFilePath())
+	}
+
+	if err = ConfigureLogging("debug", ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	} else if IsLogFile(logFilePath) {
+		t.Errorf("Expected file logging to be disabled for empty log file path")
+	}
+
+	if err = ConfigureLogging("debug", dir); err == nil {
+		t.Errorf("Expected error when log file path is a directory")
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+const (
+	lvTitle = "Log"
+)
+
+// LogView displays the log entries and errors captured during the current session.
+// New entries are loaded each time the view is rendered
+type LogView struct {
+	*PluginView
+	logBuffer *LogBuffer
+}
+
+// NewLogView creates a new instance
+func NewLogView(logBuffer *LogBuffer, channels *Channels, config Config) *LogView {
+	logView := &LogView{
+		logBuffer: logBuffer,
+	}
+
+	logView.PluginView = NewPluginView(lvTitle, logView.generateLines, channels, config)
+
+	return logView
+}
+
+// Render loads any new log entries and writes them to the provided window.
+// If the last entry is selected then the selection moves to the newest entry
+func (logView *LogView) Render(win RenderWindow) (err error) {
+	pluginView := logView.PluginView
+
+	pluginView.lock.Lock()
+	lineNumber := uint(len(pluginView.lines))
+	followNewest := lineNumber == 0 || pluginView.viewPos.ActiveRowIndex()+1 >= lineNumber
+
+	pluginView.loadLines()
+
+	if lineNumber = uint(len(pluginView.lines)); followNewest && lineNumber > 0 {
+		pluginView.viewPos.SetActiveRowIndex(lineNumber - 1)
+	}
+	pluginView.lock.Unlock()
+
+	return pluginView.Render(win)
+}
+
+// ViewID returns the ViewID for the log view
+func (logView *LogView) ViewID() ViewID {
+	return ViewLog
+}
+
+func (logView *LogView) generateLines() (lines []string) {
+	for _, logEntry := range logView.logBuffer.Entries() {
+		lines = append(lines, logEntry.String())
+	}
+
+	return
+}
@@ -3,7 +3,9 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	log "github.com/Sirupsen/logrus"
 )
@@ -23,12 +25,22 @@ var (
 
 type grvArgs struct {
 	repoFilePath string
+	startRef     string
 	logLevel     string
 	logFilePath  string
 	version      bool
+	batch        string
+	json         string
+	filter       string
+	socket       string
+	commandFile  string
+	cleanSession bool
+	stdin        bool
 }
 
 func main() {
+	defer RecoverPanic()
+
 	args := parseArgs()
 	if args.version {
 		printVersion()
@@ -37,10 +49,34 @@ func main() {
 
 	InitialiseLogging(args.logLevel, args.logFilePath)
 
+	if args.batch != "" || args.json != "" {
+		runBatch(args)
+		return
+	}
+
 	log.Debugf("Creating GRV instance")
 	grv := NewGRV()
 
-	if err := grv.Initialise(args.repoFilePath); err != nil {
+	var err error
+
+	if UsePagerMode(args.stdin, args.repoFilePath) {
+		var input io.Reader
+		if input, err = ReadPagerInput(); err == nil {
+			err = grv.InitialisePager(input)
+		}
+	} else {
+		err = grv.Initialise(args.repoFilePath, args.startRef, args.cleanSession)
+	}
+
+	if err == nil && args.socket != "" {
+		err = grv.InitialiseControlSocket(args.socket)
+	}
+
+	if err == nil && args.commandFile != "" {
+		err = grv.LoadCommandFile(args.commandFile)
+	}
+
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "FATAL: Unable to initialise grv: %v\n", err)
 		grv.Free()
 		log.Fatal(err)
@@ -54,19 +90,72 @@ func main() {
 }
 
 func parseArgs() *grvArgs {
-	repoFilePathPtr := flag.String("repoFilePath", mnRepoFilePathDefault, "Repository file path")
-	logLevelPtr := flag.String("logLevel", MnLogLevelDefault, "Logging level [NONE|PANIC|FATAL|ERROR|WARN|INFO|DEBUG]")
-	logFilePathPtr := flag.String("logFile", mnLogFilePathDefault, "Log file path")
+	var repoFilePath, logLevel, logFilePath, commandFile string
+
+	flag.StringVar(&repoFilePath, "repoFilePath", mnRepoFilePathDefault, "Repository file path")
+	flag.StringVar(&repoFilePath, "repo", mnRepoFilePathDefault, "Repository file path (alias of -repoFilePath)")
+	flag.StringVar(&logLevel, "logLevel", MnLogLevelDefault, "Logging level [NONE|PANIC|FATAL|ERROR|WARN|INFO|DEBUG]")
+	flag.StringVar(&logLevel, "log-level", MnLogLevelDefault, "Logging level (alias of -logLevel)")
+	flag.StringVar(&logFilePath, "logFile", mnLogFilePathDefault, "Log file path")
+	flag.StringVar(&logFilePath, "log-file", mnLogFilePathDefault, "Log file path (alias of -logFile)")
+	startRefPtr := flag.String("ref", "", "Branch or tag to select on start up")
 	versionPtr := flag.Bool("version", false, "Print version")
+	batchPtr := flag.String("batch", "", "Print the lines of a view to stdout and exit (e.g. \"CommitView master\")")
+	jsonPtr := flag.String("json", "", "Print refs or commits as JSON to stdout and exit [refs|commits REF]")
+	filterPtr := flag.String("filter", "", "Filter query applied to commits printed by -json")
+	socketPtr := flag.String("controlSocket", "", "Path of a unix socket to create which accepts requests from external tools")
+	flag.StringVar(&commandFile, "commandFile", "", "Path of a file containing commands to run on start up")
+	flag.StringVar(&commandFile, "command-file", "", "Path of a file containing commands to run on start up (alias of -commandFile)")
+	cleanSessionPtr := flag.Bool("cleanSession", false, "Start without restoring the state saved when grv last exited")
 
 	flag.Parse()
 
 	return &grvArgs{
-		repoFilePath: *repoFilePathPtr,
-		logLevel:     *logLevelPtr,
-		logFilePath:  *logFilePathPtr,
+		repoFilePath: repoFilePath,
+		startRef:     *startRefPtr,
+		logLevel:     strings.ToUpper(logLevel),
+		logFilePath:  logFilePath,
 		version:      *versionPtr,
+		batch:        *batchPtr,
+		json:         *jsonPtr,
+		filter:       *filterPtr,
+		socket:       *socketPtr,
+		commandFile:  commandFile,
+		cleanSession: *cleanSessionPtr,
+		stdin:        StdinRequested(flag.Args()),
+	}
+}
+
+func runBatch(args *grvArgs) {
+	log.Debugf("Creating BatchRenderer instance")
+	batchRenderer := NewBatchRenderer()
+
+	if err := batchRenderer.Initialise(args.repoFilePath); err != nil {
+		fmt.Fprintf(os.Stderr, "FATAL: Unable to initialise grv: %v\n", err)
+		batchRenderer.Free()
+		log.Fatal(err)
+	}
+
+	for _, configError := range batchRenderer.LoadConfig() {
+		fmt.Fprintf(os.Stderr, "grv: %v\n", configError)
+	}
+
+	var err error
+
+	if args.json != "" {
+		err = batchRenderer.RenderJSON(args.json, args.filter, os.Stdout)
+	} else {
+		err = batchRenderer.Render(args.batch, os.Stdout)
 	}
+
+	batchRenderer.Free()
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "grv: %v\n", err)
+		log.Fatal(err)
+	}
+
+	log.Info("Exiting normally")
 }
 
 func printVersion() {
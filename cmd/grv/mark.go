@@ -0,0 +1,175 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	mkMarksFile = "grv_marks"
+)
+
+var markNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9]$`)
+
+// Mark is a named reference to a commit
+type Mark struct {
+	name string
+	oid  string
+}
+
+// MarkStore stores the marks set on commits in a repository.
+// Marks are persisted in the repository directory so they are
+// available across sessions
+type MarkStore struct {
+	filePath string
+	marks    map[string]string
+	lock     sync.Mutex
+}
+
+// NewMarkStore creates a new instance
+func NewMarkStore() *MarkStore {
+	return &MarkStore{
+		marks: make(map[string]string),
+	}
+}
+
+// Load reads any marks previously stored for the repository at the provided path
+func (markStore *MarkStore) Load(repoPath string) (err error) {
+	markStore.lock.Lock()
+	defer markStore.lock.Unlock()
+
+	markStore.filePath = filepath.Join(repoPath, mkMarksFile)
+
+	file, err := os.Open(markStore.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+
+		return
+	}
+	defer file.Close()
+
+	marks, err := parseMarks(file)
+	if err != nil {
+		return
+	}
+
+	markStore.marks = marks
+
+	return
+}
+
+func parseMarks(reader io.Reader) (marks map[string]string, err error) {
+	marks = make(map[string]string)
+	scanner := bufio.NewScanner(reader)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		if len(fields) != 2 || !markNameRegexp.MatchString(fields[0]) || !hexRegexp.MatchString(fields[1]) {
+			return nil, fmt.Errorf("Invalid mark on line %v", lineNumber)
+		}
+
+		marks[fields[0]] = fields[1]
+	}
+
+	err = scanner.Err()
+
+	return
+}
+
+// SetMark stores a mark with the provided name for the commit
+func (markStore *MarkStore) SetMark(name, oid string) (err error) {
+	if !markNameRegexp.MatchString(name) {
+		return fmt.Errorf("Invalid mark name: %v. Mark names must be a single letter or digit", name)
+	}
+
+	markStore.lock.Lock()
+	defer markStore.lock.Unlock()
+
+	markStore.marks[name] = oid
+
+	return markStore.save()
+}
+
+// RemoveMark removes the mark with the provided name
+func (markStore *MarkStore) RemoveMark(name string) (err error) {
+	markStore.lock.Lock()
+	defer markStore.lock.Unlock()
+
+	if _, exists := markStore.marks[name]; !exists {
+		return fmt.Errorf("No mark set with name %v", name)
+	}
+
+	delete(markStore.marks, name)
+
+	return markStore.save()
+}
+
+// Mark returns the oid of the commit the mark with the provided name is set on
+func (markStore *MarkStore) Mark(name string) (oid string, exists bool) {
+	markStore.lock.Lock()
+	defer markStore.lock.Unlock()
+
+	oid, exists = markStore.marks[name]
+	return
+}
+
+// Marks returns all marks ordered by name
+func (markStore *MarkStore) Marks() (marks []Mark) {
+	markStore.lock.Lock()
+	defer markStore.lock.Unlock()
+
+	for _, name := range markStore.names() {
+		marks = append(marks, Mark{
+			name: name,
+			oid:  markStore.marks[name],
+		})
+	}
+
+	return
+}
+
+func (markStore *MarkStore) names() (names []string) {
+	for name := range markStore.marks {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return
+}
+
+func (markStore *MarkStore) save() (err error) {
+	if markStore.filePath == "" {
+		return
+	}
+
+	var buffer bytes.Buffer
+
+	for _, name := range markStore.names() {
+		fmt.Fprintf(&buffer, "%v %v\n", name, markStore.marks[name])
+	}
+
+	if err = ioutil.WriteFile(markStore.filePath, buffer.Bytes(), 0644); err != nil {
+		err = fmt.Errorf("Unable to save marks: %v", err)
+	}
+
+	return
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseMarksReadsNameAndOidFromEachLine(t *testing.T) {
+	input := "a 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n\nB 7a3f2c1\n"
+
+	expected := map[string]string{
+		"a": "4b825dc642cb6eb9a060e54bf8d69288fbee4904",
+		"B": "7a3f2c1",
+	}
+
+	actual, err := parseMarks(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Marks do not match expected value. Expected: %v, Actual: %v", expected, actual)
+	}
+}
+
+func TestParseMarksReturnsErrorForInvalidLines(t *testing.T) {
+	invalidInputs := []string{
+		"a",
+		"ab 7a3f2c1",
+		"a 7a3f2c1 extra",
+		"a notanoid",
+	}
+
+	for _, input := range invalidInputs {
+		if _, err := parseMarks(strings.NewReader(input)); err == nil {
+			t.Errorf("Expected error for input %v", input)
+		}
+	}
+}
+
+func TestSetMarkRejectsInvalidNames(t *testing.T) {
+	markStore := NewMarkStore()
+
+	for _, name := range []string{"", "ab", "-", " "} {
+		if err := markStore.SetMark(name, "7a3f2c1"); err == nil {
+			t.Errorf("Expected error for mark name \"%v\"", name)
+		}
+	}
+}
+
+func TestMarksAreReturnedOrderedByName(t *testing.T) {
+	markStore := NewMarkStore()
+	markStore.SetMark("c", "3333333")
+	markStore.SetMark("a", "1111111")
+	markStore.SetMark("b", "2222222")
+
+	expected := []Mark{
+		{name: "a", oid: "1111111"},
+		{name: "b", oid: "2222222"},
+		{name: "c", oid: "3333333"},
+	}
+
+	if actual := markStore.Marks(); !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Marks do not match expected value. Expected: %v, Actual: %v", expected, actual)
+	}
+}
+
+func TestMarksArePersistedAcrossMarkStoreInstances(t *testing.T) {
+	repoPath, err := ioutil.TempDir("", "grv-marks")
+	if err != nil {
+		t.Fatalf("Unable to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(repoPath)
+
+	markStore := NewMarkStore()
+	if err = markStore.Load(repoPath); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	markStore.SetMark("a", "1111111")
+	markStore.SetMark("b", "2222222")
+	markStore.RemoveMark("a")
+
+	loadedMarkStore := NewMarkStore()
+	if err = loadedMarkStore.Load(repoPath); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, exists := loadedMarkStore.Mark("a"); exists {
+		t.Errorf("Expected mark a to have been removed")
+	}
+
+	if oid, exists := loadedMarkStore.Mark("b"); !exists || oid != "2222222" {
+		t.Errorf("Expected mark b to be set on 2222222 but found: %v", oid)
+	}
+}
+
+func TestMarksCanBeSetAfterFailingToLoadInvalidMarks(t *testing.T) {
+	repoPath, err := ioutil.TempDir("", "grv-marks")
+	if err != nil {
+		t.Fatalf("Unable to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(repoPath)
+
+	if err = ioutil.WriteFile(filepath.Join(repoPath, mkMarksFile), []byte("invalid mark line\n"), 0644); err != nil {
+		t.Fatalf("Unable to write marks file: %v", err)
+	}
+
+	markStore := NewMarkStore()
+	if err = markStore.Load(repoPath); err == nil {
+		t.Errorf("Expected error loading invalid marks")
+	}
+
+	if err = markStore.SetMark("a", "1111111"); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	if oid, exists := markStore.Mark("a"); !exists || oid != "1111111" {
+		t.Errorf("Expected mark a to be set on 1111111 but found: %v", oid)
+	}
+}
@@ -0,0 +1,275 @@
+package main
+
+import (
+	"fmt"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	mvColumnNum     = 3
+	mvSummaryColumn = 2
+)
+
+type markViewHandler func(*MarkView, Action) error
+
+// MarkView displays the marks set on commits in the repository
+type MarkView struct {
+	*ListView
+	repoData       RepoData
+	config         Config
+	marks          []Mark
+	active         bool
+	tableFormatter *TableFormatter
+	handlers       map[ActionType]markViewHandler
+}
+
+// NewMarkView creates a new instance
+func NewMarkView(repoData RepoData, channels *Channels, config Config) *MarkView {
+	markView := &MarkView{
+		ListView:       NewListView(channels),
+		repoData:       repoData,
+		config:         config,
+		tableFormatter: NewTableFormatter(mvColumnNum),
+		handlers: map[ActionType]markViewHandler{
+			ActionSelect:     selectMark,
+			ActionRemoveMark: removeMark,
+		},
+	}
+
+	markView.viewSearch = NewViewSearch(markView, channels)
+
+	return markView
+}
+
+// Initialise loads the marks set in the repository
+func (markView *MarkView) Initialise() (err error) {
+	log.Info("Initialising MarkView")
+
+	markView.lock.Lock()
+	defer markView.lock.Unlock()
+
+	markView.loadMarks()
+
+	return
+}
+
+func (markView *MarkView) loadMarks() {
+	markView.marks = markView.repoData.Marks().Marks()
+
+	markNum := uint(len(markView.marks))
+	viewPos := markView.viewPos
+
+	if markNum == 0 {
+		viewPos.SetActiveRowIndex(0)
+	} else if viewPos.ActiveRowIndex() >= markNum {
+		viewPos.SetActiveRowIndex(markNum - 1)
+	}
+}
+
+// Render generates and writes the mark view to the provided window
+func (markView *MarkView) Render(win RenderWindow) (err error) {
+	markView.lock.Lock()
+	defer markView.lock.Unlock()
+
+	log.Debug("Rendering MarkView")
+
+	markView.viewDimension = win.ViewDimensions()
+	markView.loadMarks()
+
+	markNum := uint(len(markView.marks))
+	rows := win.Rows() - 2
+
+	viewPos := markView.viewPos
+	viewPos.DetermineViewStartRow(rows, markNum, uint(markView.config.GetInt(CfScrollOff)))
+	markIndex := viewPos.ViewStartRowIndex()
+
+	tableFormatter := markView.tableFormatter
+	tableFormatter.Resize(MinUint(rows, markNum-markIndex))
+	tableFormatter.Clear()
+	tableFormatter.SetColWidthLimit(mvSummaryColumn, uint(markView.config.GetInt(CfMarkSummaryWidth)))
+
+	for rowIndex := uint(0); rowIndex < rows && markIndex < markNum; rowIndex++ {
+		if err = markView.renderMark(tableFormatter, rowIndex, markView.marks[markIndex]); err != nil {
+			return
+		}
+
+		markIndex++
+	}
+
+	if err = tableFormatter.Render(win, viewPos.ViewStartColumn(), true); err != nil {
+		return
+	}
+
+	if markNum == 0 {
+		if err = win.SetRow(2, 1, CmpNone, "   No marks set"); err != nil {
+			return
+		}
+	} else if err = win.SetSelectedRow(viewPos.SelectedRowIndex()+1, markView.active); err != nil {
+		return
+	}
+
+	win.DrawBorder()
+
+	if err = win.SetTitle(CmpMarkviewTitle, "Marks"); err != nil {
+		return
+	}
+
+	if markNum > 0 {
+		if err = win.SetFooter(CmpMarkviewFooter, "Mark %v of %v", viewPos.ActiveRowIndex()+1, markNum); err != nil {
+			return
+		}
+	}
+
+	err = markView.RenderSearchHighlight(win)
+
+	return
+}
+
+func (markView *MarkView) renderMark(tableFormatter *TableFormatter, rowIndex uint, mark Mark) (err error) {
+	shortID, summary := markView.markCommitDetails(mark)
+
+	if err = tableFormatter.SetCellWithStyle(rowIndex, 0, CmpMarkviewName, "%v", mark.name); err != nil {
+		return
+	}
+
+	if err = tableFormatter.SetCellWithStyle(rowIndex, 1, CmpMarkviewShortOid, "%v", shortID); err != nil {
+		return
+	}
+
+	return tableFormatter.SetCellWithStyle(rowIndex, mvSummaryColumn, CmpMarkviewSummary, "%v", summary)
+}
+
+func (markView *MarkView) markCommitDetails(mark Mark) (shortID, summary string) {
+	commit, err := markView.repoData.CommitByOid(mark.oid)
+	if err != nil {
+		log.Debugf("Unable to load commit %v for mark %v: %v", mark.oid, mark.name, err)
+		return mark.oid, "Commit not found"
+	}
+
+	return commit.oid.ShortID(), commit.commit.Summary()
+}
+
+// RenderHelpBar renders key binding help for the mark view
+func (markView *MarkView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
+	RenderKeyBindingHelp(markView.ViewID(), lineBuilder, []ActionMessage{
+		{action: ActionSelect, message: "Show Commit"},
+		{action: ActionRemoveMark, message: "Remove Mark"},
+	})
+
+	return
+}
+
+// HandleEvent does nothing
+func (markView *MarkView) HandleEvent(event Event) (err error) {
+	return
+}
+
+// OnActiveChange updates whether this view is currently active
+func (markView *MarkView) OnActiveChange(active bool) {
+	log.Debugf("MarkView active: %v", active)
+	markView.lock.Lock()
+	defer markView.lock.Unlock()
+
+	markView.active = active
+}
+
+// ViewID returns the ViewID for the mark view
+func (markView *MarkView) ViewID() ViewID {
+	return ViewMark
+}
+
+// Line returns the rendered line at the specified index
+func (markView *MarkView) Line(lineIndex uint) (line string) {
+	markView.lock.Lock()
+	defer markView.lock.Unlock()
+
+	markNum := uint(len(markView.marks))
+	if lineIndex >= markNum {
+		log.Errorf("Invalid lineIndex: %v >= %v", lineIndex, markNum)
+		return
+	}
+
+	mark := markView.marks[lineIndex]
+	shortID, summary := markView.markCommitDetails(mark)
+
+	return fmt.Sprintf("%v %v %v", mark.name, shortID, summary)
+}
+
+// LineNumber returns the number of marks in the view
+func (markView *MarkView) LineNumber() (lineNumber uint) {
+	markView.lock.Lock()
+	defer markView.lock.Unlock()
+
+	return uint(len(markView.marks))
+}
+
+// HandleAction checks if the mark view supports this action and if it does executes it
+func (markView *MarkView) HandleAction(action Action) (err error) {
+	markView.lock.Lock()
+	defer markView.lock.Unlock()
+
+	if handler, ok := markView.handlers[action.ActionType]; ok {
+		log.Debugf("MarkView handling action %v", action)
+		err = handler(markView, action)
+	} else {
+		_, err = markView.HandleListAction(action, uint(len(markView.marks)))
+	}
+
+	return
+}
+
+func (markView *MarkView) selectedMark() (mark Mark, exists bool) {
+	activeRowIndex := markView.viewPos.ActiveRowIndex()
+
+	if activeRowIndex >= uint(len(markView.marks)) {
+		return
+	}
+
+	return markView.marks[activeRowIndex], true
+}
+
+func selectMark(markView *MarkView, action Action) (err error) {
+	mark, exists := markView.selectedMark()
+	if !exists {
+		return
+	}
+
+	log.Debugf("Showing commit %v for mark %v", mark.oid, mark.name)
+
+	markView.channels.DoAction(Action{
+		ActionType: ActionNewTab,
+		Args:       []interface{}{fmt.Sprintf("Mark %v", mark.name)},
+	})
+
+	markView.channels.DoAction(Action{
+		ActionType: ActionAddView,
+		Args: []interface{}{
+			ActionAddViewArgs{
+				CreateViewArgs: CreateViewArgs{
+					viewID:   ViewCommit,
+					viewArgs: []interface{}{mark.oid},
+				},
+			},
+		},
+	})
+
+	return
+}
+
+func removeMark(markView *MarkView, action Action) (err error) {
+	mark, exists := markView.selectedMark()
+	if !exists {
+		return
+	}
+
+	if err = markView.repoData.Marks().RemoveMark(mark.name); err != nil {
+		return
+	}
+
+	markView.loadMarks()
+	markView.channels.ReportSuccess("Removed mark %v", mark.name)
+	markView.channels.UpdateDisplay()
+
+	return
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	nfActionTypeStart = ActionType(1 << 18)
+)
+
+// NamedFilters stores commit filter queries saved under a name.
+// Each saved filter bound to a key sequence is assigned its own action type
+type NamedFilters struct {
+	keyBindings    KeyBindings
+	nextActionType ActionType
+	queries        map[string]string
+	actionFilters  map[ActionType]string
+	lock           sync.Mutex
+}
+
+// NewNamedFilters creates a new instance
+func NewNamedFilters(keyBindings KeyBindings) *NamedFilters {
+	return &NamedFilters{
+		keyBindings:    keyBindings,
+		nextActionType: nfActionTypeStart,
+		queries:        make(map[string]string),
+		actionFilters:  make(map[ActionType]string),
+	}
+}
+
+// Save stores the query under the provided name, replacing any query previously saved with that name
+func (namedFilters *NamedFilters) Save(name, query string) {
+	namedFilters.lock.Lock()
+	defer namedFilters.lock.Unlock()
+
+	namedFilters.queries[name] = query
+	log.Infof("Saved filter %v with query: %v", name, query)
+}
+
+// Bind binds the provided keys in the Commit View to the filter with the provided name
+func (namedFilters *NamedFilters) Bind(name, keys string) {
+	namedFilters.lock.Lock()
+	defer namedFilters.lock.Unlock()
+
+	actionType := namedFilters.nextActionType
+	namedFilters.nextActionType++
+
+	namedFilters.actionFilters[actionType] = name
+	namedFilters.keyBindings.SetActionBinding(ViewCommit, keys, actionType)
+
+	log.Infof("Bound \"%v\" to filter %v", keys, name)
+}
+
+// ApplyAction returns the action which applies the filter with the provided name
+func (namedFilters *NamedFilters) ApplyAction(name string) (action Action, exists bool) {
+	namedFilters.lock.Lock()
+	defer namedFilters.lock.Unlock()
+
+	return namedFilters.applyAction(name)
+}
+
+// BoundApplyAction returns the action which applies the filter bound to the provided action type
+func (namedFilters *NamedFilters) BoundApplyAction(actionType ActionType) (action Action, exists bool) {
+	namedFilters.lock.Lock()
+	defer namedFilters.lock.Unlock()
+
+	name, exists := namedFilters.actionFilters[actionType]
+	if !exists {
+		return
+	}
+
+	return namedFilters.applyAction(name)
+}
+
+func (namedFilters *NamedFilters) applyAction(name string) (action Action, exists bool) {
+	query, exists := namedFilters.queries[name]
+	if exists {
+		action = Action{
+			ActionType: ActionAddFilter,
+			Args:       []interface{}{query, name},
+		}
+	}
+
+	return
+}
+
+// Names returns the names of all saved filters
+func (namedFilters *NamedFilters) Names() (names []string) {
+	namedFilters.lock.Lock()
+	defer namedFilters.lock.Unlock()
+
+	for name := range namedFilters.queries {
+		names = append(names, name)
+	}
+
+	return
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	prPackedRefsFile     = "packed-refs"
+	prFullyPeeledTrait   = "fully-peeled"
+	prPeeledLinePrefix   = "^"
+	prCommentLinePrefix  = "#"
+	prHeaderTraitsPrefix = "# pack-refs with:"
+)
+
+// peeledTag contains the object a packed tag ref points to and the commit it peels to
+type peeledTag struct {
+	oid       string
+	peeledOid string
+}
+
+// loadPeeledTags reads the packed-refs file in the provided repository directory and
+// returns the commit each packed tag points to. This avoids loading tag objects
+// from the object database to determine the commits annotated tags point to
+func loadPeeledTags(repoPath string) (peeledTags map[string]peeledTag, err error) {
+	file, err := os.Open(filepath.Join(repoPath, prPackedRefsFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+
+		return
+	}
+	defer file.Close()
+
+	return parsePeeledTags(file)
+}
+
+// parsePeeledTags parses the content of a packed-refs file and returns the peeled tags keyed by ref name.
+// When the file is not marked as fully peeled only annotated tags with a peeled entry can be resolved
+func parsePeeledTags(reader io.Reader) (peeledTags map[string]peeledTag, err error) {
+	peeledTags = make(map[string]peeledTag)
+	scanner := bufio.NewScanner(reader)
+	fullyPeeled := false
+	lastRefName := ""
+	lastOid := ""
+	lineNum := 0
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		lineNum++
+
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, prHeaderTraitsPrefix):
+			for _, trait := range strings.Fields(strings.TrimPrefix(line, prHeaderTraitsPrefix)) {
+				if trait == prFullyPeeledTrait {
+					fullyPeeled = true
+				}
+			}
+		case strings.HasPrefix(line, prCommentLinePrefix):
+			continue
+		case strings.HasPrefix(line, prPeeledLinePrefix):
+			if lastRefName == "" {
+				return nil, fmt.Errorf("Invalid peeled entry on line %v of packed-refs", lineNum)
+			}
+
+			if strings.HasPrefix(lastRefName, rdlTagRefPrefix) {
+				peeledTags[lastRefName] = peeledTag{
+					oid:       lastOid,
+					peeledOid: strings.TrimPrefix(line, prPeeledLinePrefix),
+				}
+			}
+
+			lastRefName = ""
+		default:
+			fields := strings.Fields(line)
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("Invalid entry on line %v of packed-refs: %v", lineNum, line)
+			}
+
+			lastOid = fields[0]
+			lastRefName = fields[1]
+
+			if fullyPeeled && strings.HasPrefix(lastRefName, rdlTagRefPrefix) {
+				peeledTags[lastRefName] = peeledTag{
+					oid:       lastOid,
+					peeledOid: lastOid,
+				}
+			}
+		}
+	}
+
+	err = scanner.Err()
+
+	return
+}
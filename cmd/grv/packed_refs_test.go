@@ -0,0 +1,80 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPeeledEntriesAreReturnedForAnnotatedTags(t *testing.T) {
+	packedRefs := `# pack-refs with: peeled sorted 
+1111111111111111111111111111111111111111 refs/heads/master
+2222222222222222222222222222222222222222 refs/tags/v1.0
+^3333333333333333333333333333333333333333
+4444444444444444444444444444444444444444 refs/tags/v1.1
+`
+	peeledTags, err := parsePeeledTags(strings.NewReader(packedRefs))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(peeledTags) != 1 {
+		t.Fatalf("Peeled tag number does not match expected value. Expected: 1, Actual: %v", len(peeledTags))
+	}
+
+	expectedPeeledTag := peeledTag{
+		oid:       "2222222222222222222222222222222222222222",
+		peeledOid: "3333333333333333333333333333333333333333",
+	}
+
+	if peeledTags["refs/tags/v1.0"] != expectedPeeledTag {
+		t.Errorf("Peeled tag does not match expected value. Expected: %v, Actual: %v", expectedPeeledTag, peeledTags["refs/tags/v1.0"])
+	}
+}
+
+func TestAllTagsArePeeledWhenFileIsFullyPeeled(t *testing.T) {
+	packedRefs := `# pack-refs with: peeled fully-peeled sorted 
+1111111111111111111111111111111111111111 refs/heads/master
+2222222222222222222222222222222222222222 refs/tags/v1.0
+^3333333333333333333333333333333333333333
+4444444444444444444444444444444444444444 refs/tags/v1.1
+`
+	peeledTags, err := parsePeeledTags(strings.NewReader(packedRefs))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedPeeledTags := map[string]peeledTag{
+		"refs/tags/v1.0": {
+			oid:       "2222222222222222222222222222222222222222",
+			peeledOid: "3333333333333333333333333333333333333333",
+		},
+		"refs/tags/v1.1": {
+			oid:       "4444444444444444444444444444444444444444",
+			peeledOid: "4444444444444444444444444444444444444444",
+		},
+	}
+
+	if len(peeledTags) != len(expectedPeeledTags) {
+		t.Fatalf("Peeled tag number does not match expected value. Expected: %v, Actual: %v", len(expectedPeeledTags), len(peeledTags))
+	}
+
+	for refName, expectedPeeledTag := range expectedPeeledTags {
+		if peeledTags[refName] != expectedPeeledTag {
+			t.Errorf("Peeled tag for %v does not match expected value. Expected: %v, Actual: %v", refName, expectedPeeledTag, peeledTags[refName])
+		}
+	}
+}
+
+func TestErrorIsReturnedForInvalidPackedRefsEntry(t *testing.T) {
+	packedRefs := `# pack-refs with: peeled fully-peeled sorted 
+^3333333333333333333333333333333333333333
+`
+	if _, err := parsePeeledTags(strings.NewReader(packedRefs)); err == nil {
+		t.Errorf("Expected error for peeled entry without a preceding ref")
+	}
+
+	packedRefs = "1111111111111111111111111111111111111111\n"
+	if _, err := parsePeeledTags(strings.NewReader(packedRefs)); err == nil {
+		t.Errorf("Expected error for entry without a ref name")
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"syscall"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	pgTerminalPath  = "/dev/tty"
+	pgInputName     = "stdin"
+	pgStdinArg      = "-"
+	pgMaxLineLength = 1024 * 1024
+)
+
+// StdinIsPiped returns true if stdin is a pipe or a regular file.
+// Character devices such as a terminal or /dev/null are not considered piped input
+func StdinIsPiped() bool {
+	fileInfo, err := os.Stdin.Stat()
+	if err != nil {
+		log.Errorf("Unable to stat stdin: %v", err)
+		return false
+	}
+
+	mode := fileInfo.Mode()
+
+	return mode&os.ModeNamedPipe != 0 || mode.IsRegular()
+}
+
+// StdinRequested returns true if the - argument, which requests input is read from stdin, was provided
+func StdinRequested(positionalArgs []string) bool {
+	for _, positionalArg := range positionalArgs {
+		if positionalArg == pgStdinArg {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UsePagerMode returns true if grv should display stdin instead of a repository.
+// This is the case when stdin was explicitly requested using the - argument or
+// when input is piped to grv and no repository can be found at the provided path.
+// A pipe alone is not sufficient as grv may be launched from a script or IDE with a pipe as stdin
+func UsePagerMode(stdinRequested bool, repoPath string) bool {
+	if stdinRequested {
+		return true
+	} else if !StdinIsPiped() {
+		return false
+	}
+
+	if _, err := DiscoverRepository(repoPath); err != nil {
+		log.Infof("Input is piped and no repository was found: %v", err)
+		return true
+	}
+
+	return false
+}
+
+// ReadPagerInput reads all input from stdin and then replaces stdin with
+// the controlling terminal so keyboard input can be received by the UI
+func ReadPagerInput() (input io.Reader, err error) {
+	data, err := ioutil.ReadAll(os.Stdin)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read stdin: %v", err)
+	}
+
+	log.Infof("Read %v bytes from stdin", len(data))
+
+	terminal, err := os.Open(pgTerminalPath)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to open terminal: %v", err)
+	}
+
+	defer terminal.Close()
+
+	if err = syscall.Dup2(int(terminal.Fd()), syscall.Stdin); err != nil {
+		return nil, fmt.Errorf("Unable to replace stdin with terminal: %v", err)
+	}
+
+	return bytes.NewBuffer(data), nil
+}
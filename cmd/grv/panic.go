@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	pnReportFilePattern = "grv-panic-*.txt"
+	pnReportTimeFormat  = "2006-01-02 15:04:05.000-0700"
+	pnReportLogEntryNum = 200
+	pnPanicExitStatus   = 2
+)
+
+var panicHandler struct {
+	lock            sync.Mutex
+	restoreTerminal func()
+}
+
+// SetPanicTerminalRestorer registers the function used to restore the terminal before a panic is reported
+func SetPanicTerminalRestorer(restoreTerminal func()) {
+	panicHandler.lock.Lock()
+	defer panicHandler.lock.Unlock()
+
+	panicHandler.restoreTerminal = restoreTerminal
+}
+
+// RecoverPanic restores the terminal, writes a diagnostic report and exits if the calling goroutine is panicking.
+// A panic cannot be recovered from in a different goroutine, so RecoverPanic is deferred at the start of every goroutine
+func RecoverPanic() {
+	if value := recover(); value != nil {
+		handlePanic(value, debug.Stack())
+	}
+}
+
+func handlePanic(value interface{}, stack []byte) {
+	// The lock is never released so that only the first panic is reported.
+	// Any other goroutine which panics blocks until GRV has exited
+	panicHandler.lock.Lock()
+
+	if panicHandler.restoreTerminal != nil {
+		panicHandler.restoreTerminal()
+	}
+
+	log.Errorf("Panic: %v\n%s", value, stack)
+
+	fmt.Fprintf(os.Stderr, "GRV encountered an unexpected error and has exited: %v\n", value)
+
+	if reportPath, err := writePanicReportFile(value, stack); err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to write diagnostic report: %v\n\n%s", err, stack)
+	} else {
+		fmt.Fprintf(os.Stderr, "A diagnostic report was written to %v\n", reportPath)
+	}
+
+	os.Exit(pnPanicExitStatus)
+}
+
+func writePanicReportFile(value interface{}, stack []byte) (reportPath string, err error) {
+	file, err := ioutil.TempFile("", pnReportFilePattern)
+	if err != nil {
+		return
+	}
+
+	reportPath = file.Name()
+
+	if err = writePanicReport(file, time.Now(), value, stack, sessionLog.Entries()); err != nil {
+		file.Close()
+		return
+	}
+
+	err = file.Close()
+
+	return
+}
+
+// writePanicReport writes the details of a panic along with the most recent log entries
+func writePanicReport(writer io.Writer, panicTime time.Time, value interface{}, stack []byte, logEntries []LogEntry) error {
+	bufferedWriter := bufio.NewWriter(writer)
+
+	fmt.Fprintf(bufferedWriter, "GRV panic report\n\n")
+	fmt.Fprintf(bufferedWriter, "Time:       %v\n", panicTime.Format(pnReportTimeFormat))
+	fmt.Fprintf(bufferedWriter, "Version:    %v\n", version)
+	fmt.Fprintf(bufferedWriter, "Commit:     %v\n", headOid)
+	fmt.Fprintf(bufferedWriter, "Build date: %v\n", buildDateTime)
+	fmt.Fprintf(bufferedWriter, "Go:         %v %v/%v\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(bufferedWriter, "Log level:  %v\n", LogLevel())
+	fmt.Fprintf(bufferedWriter, "Log file:   %v\n", LogFilePath())
+	fmt.Fprintf(bufferedWriter, "\nPanic: %v\n\n%s\n", value, stack)
+
+	if len(logEntries) > pnReportLogEntryNum {
+		logEntries = logEntries[len(logEntries)-pnReportLogEntryNum:]
+	}
+
+	fmt.Fprintf(bufferedWriter, "Recent log entries (%v):\n", len(logEntries))
+
+	for _, logEntry := range logEntries {
+		fmt.Fprintf(bufferedWriter, "%v\n", logEntry)
+	}
+
+	return bufferedWriter.Flush()
+}
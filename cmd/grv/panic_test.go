@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPanicReportContainsPanicAndRecentLogEntries(t *testing.T) {
+	var logEntries []LogEntry
+	for entryIndex := 0; entryIndex < pnReportLogEntryNum+10; entryIndex++ {
+		logEntries = append(logEntries, LogEntry{message: fmt.Sprintf("Entry %v", entryIndex)})
+	}
+
+	var buffer bytes.Buffer
+	panicTime := time.Date(2018, 3, 4, 15, 4, 5, 0, time.UTC)
+	stack := []byte("goroutine 1 [running]:\nmain.main()\n")
+
+	if err := writePanicReport(&buffer, panicTime, "index out of range", stack, logEntries); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	report := buffer.String()
+
+	expectedContents := []string{
+		"Time:       2018-03-04 15:04:05.000+0000\n",
+		"\nPanic: index out of range\n\ngoroutine 1 [running]:\nmain.main()\n",
+		fmt.Sprintf("Recent log entries (%v):\n", pnReportLogEntryNum),
+		fmt.Sprintf("- Entry %v\n", pnReportLogEntryNum+9),
+	}
+
+	for _, expectedContent := range expectedContents {
+		if !strings.Contains(report, expectedContent) {
+			t.Errorf("Expected report to contain %q. Report: %v", expectedContent, report)
+		}
+	}
+
+	if strings.Contains(report, "- Entry 9\n") {
+		t.Errorf("Expected only the most recent %v log entries to be included", pnReportLogEntryNum)
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	pxStringOption = "-S"
+	pxRegexOption  = "-G"
+)
+
+// Pickaxe matches commits by the content of their diff in the same way as git log -S and -G.
+// A string pickaxe matches diffs which change the number of occurrences of the string in a file.
+// A regex pickaxe matches diffs which add or remove a line matching the regex
+type Pickaxe struct {
+	pattern string
+	regex   *regexp.Regexp
+}
+
+// NewPickaxe creates a new instance which matches the provided string or regex
+func NewPickaxe(pattern string, isRegex bool) (pickaxe *Pickaxe, err error) {
+	if pattern == "" {
+		return nil, fmt.Errorf("Pickaxe pattern cannot be empty")
+	}
+
+	pickaxe = &Pickaxe{
+		pattern: pattern,
+	}
+
+	if isRegex {
+		if pickaxe.regex, err = regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("Invalid pickaxe regex %v: %v", pattern, err)
+		}
+	}
+
+	return
+}
+
+// ParsePickaxeQuery creates a pickaxe from a filter query of the form "-S <string>" or "-G <regex>".
+// isPickaxeQuery is false if the query is not a pickaxe query
+func ParsePickaxeQuery(query string) (pickaxe *Pickaxe, isPickaxeQuery bool, err error) {
+	query = strings.TrimLeft(query, " ")
+
+	for _, option := range []string{pxStringOption, pxRegexOption} {
+		if strings.HasPrefix(query, option) {
+			pattern := strings.TrimLeft(strings.TrimPrefix(query, option), " ")
+			pickaxe, err = NewPickaxe(pattern, option == pxRegexOption)
+			return pickaxe, true, err
+		}
+	}
+
+	return
+}
+
+// Query returns the filter query which creates this pickaxe
+func (pickaxe *Pickaxe) Query() string {
+	if pickaxe.regex != nil {
+		return fmt.Sprintf("%v %v", pxRegexOption, pickaxe.pattern)
+	}
+
+	return fmt.Sprintf("%v %v", pxStringOption, pickaxe.pattern)
+}
+
+// MatchesFileLines returns true if the lines added to and removed from a file match the pickaxe
+func (pickaxe *Pickaxe) MatchesFileLines(addedLines, removedLines []string) bool {
+	if pickaxe.regex != nil {
+		for _, lines := range [][]string{addedLines, removedLines} {
+			for _, line := range lines {
+				if pickaxe.regex.MatchString(line) {
+					return true
+				}
+			}
+		}
+
+		return false
+	}
+
+	occurrences := 0
+
+	for _, line := range addedLines {
+		occurrences += strings.Count(line, pickaxe.pattern)
+	}
+
+	for _, line := range removedLines {
+		occurrences -= strings.Count(line, pickaxe.pattern)
+	}
+
+	return occurrences != 0
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestPickaxeQueryIsParsed(t *testing.T) {
+	queryTests := []struct {
+		query          string
+		isPickaxeQuery bool
+		expectedQuery  string
+		isRegex        bool
+	}{
+		{query: "-S foo bar", isPickaxeQuery: true, expectedQuery: "-S foo bar"},
+		{query: " -G  ^func\\s", isPickaxeQuery: true, expectedQuery: "-G ^func\\s", isRegex: true},
+		{query: "authorname = \"-S\"", isPickaxeQuery: false},
+	}
+
+	for _, queryTest := range queryTests {
+		pickaxe, isPickaxeQuery, err := ParsePickaxeQuery(queryTest.query)
+		if err != nil {
+			t.Errorf("Unexpected error for query %v: %v", queryTest.query, err)
+			continue
+		}
+
+		if isPickaxeQuery != queryTest.isPickaxeQuery {
+			t.Errorf("Pickaxe query detection does not match expected value for query %v. Expected: %v, Actual: %v",
+				queryTest.query, queryTest.isPickaxeQuery, isPickaxeQuery)
+		} else if isPickaxeQuery {
+			if pickaxe.Query() != queryTest.expectedQuery {
+				t.Errorf("Pickaxe query does not match expected value. Expected: %v, Actual: %v", queryTest.expectedQuery, pickaxe.Query())
+			}
+
+			if (pickaxe.regex != nil) != queryTest.isRegex {
+				t.Errorf("Pickaxe regex does not match expected value for query %v", queryTest.query)
+			}
+		}
+	}
+}
+
+func TestInvalidPickaxeQueriesReturnErrors(t *testing.T) {
+	for _, query := range []string{"-S", "-G ", "-G [a-"} {
+		if _, _, err := ParsePickaxeQuery(query); err == nil {
+			t.Errorf("Expected error for query %v", query)
+		}
+	}
+}
+
+func TestStringPickaxeMatchesChangeInOccurrences(t *testing.T) {
+	pickaxe, _ := NewPickaxe("foo", false)
+
+	matchTests := []struct {
+		addedLines   []string
+		removedLines []string
+		expected     bool
+	}{
+		{addedLines: []string{"call foo()"}, expected: true},
+		{removedLines: []string{"foo foo"}, addedLines: []string{"foo"}, expected: true},
+		{removedLines: []string{"x := foo(1)"}, addedLines: []string{"x := foo(2)"}, expected: false},
+		{addedLines: []string{"bar"}, expected: false},
+	}
+
+	for _, matchTest := range matchTests {
+		if actual := pickaxe.MatchesFileLines(matchTest.addedLines, matchTest.removedLines); actual != matchTest.expected {
+			t.Errorf("Match does not match expected value for added lines %v and removed lines %v. Expected: %v, Actual: %v",
+				matchTest.addedLines, matchTest.removedLines, matchTest.expected, actual)
+		}
+	}
+}
+
+func TestRegexPickaxeMatchesAnyChangedLine(t *testing.T) {
+	pickaxe, _ := NewPickaxe("fo+\\(", true)
+
+	if !pickaxe.MatchesFileLines(nil, []string{"x := foo(1)"}) {
+		t.Errorf("Expected removed line to match")
+	}
+
+	if !pickaxe.MatchesFileLines([]string{"x := foo(2)"}, []string{"x := foo(1)"}) {
+		t.Errorf("Expected modified line to match")
+	}
+
+	if pickaxe.MatchesFileLines([]string{"bar"}, []string{"baz"}) {
+		t.Errorf("Expected unrelated lines not to match")
+	}
+}
@@ -0,0 +1,324 @@
+package main
+
+import (
+	"fmt"
+	"plugin"
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	plRegisterSymbol  = "GRVPluginRegister"
+	plActionKeyPrefix = "<grv-plugin-"
+	plActionKeySuffix = ">"
+	plActionTypeStart = ActionType(1 << 16)
+	plRepoPathArg     = "repo"
+)
+
+// PluginActionHandler is invoked when a plugin action is executed
+// The returned string (if not empty) is displayed in the status bar
+type PluginActionHandler func(args map[string]string) (string, error)
+
+// PluginViewLineProvider provides the lines a plugin view displays
+type PluginViewLineProvider func() []string
+
+type pluginRegisterFunc func(
+	func(string, func(map[string]string) (string, error)) error,
+	func(string, string, string) error,
+	func(string, func() []string) error,
+) error
+
+type pluginAction struct {
+	name    string
+	handler PluginActionHandler
+}
+
+type pluginKeyBinding struct {
+	view       string
+	viewID     ViewID
+	keys       string
+	actionName string
+}
+
+// pluginRegistration stages the actions, key bindings and views registered by a plugin.
+// They are only added to the PluginManager once the plugin has registered successfully,
+// so a plugin which fails to register leaves no partial registrations behind
+type pluginRegistration struct {
+	pluginManager *PluginManager
+	actions       []pluginAction
+	keyBindings   []pluginKeyBinding
+	views         map[string]PluginViewLineProvider
+}
+
+// PluginManager loads plugins and dispatches the actions they register
+//
+// A plugin is a go plugin (built with -buildmode=plugin) which exports a function with the signature:
+//
+//	func GRVPluginRegister(
+//		registerAction func(name string, handler func(args map[string]string) (string, error)) error,
+//		bindKey func(view, keys, actionName string) error,
+//		registerView func(name string, lines func() []string) error,
+//	) error
+//
+// Only standard library types are used in the signature so plugins do not need to import grv
+type PluginManager struct {
+	repoData       RepoData
+	keyBindings    KeyBindings
+	channels       *Channels
+	nextActionType ActionType
+	actions        map[ActionType]PluginActionHandler
+	actionNames    map[string]ActionType
+	views          map[string]PluginViewLineProvider
+	loaded         map[string]bool
+	lock           sync.Mutex
+}
+
+// NewPluginManager creates a new instance
+func NewPluginManager(repoData RepoData, keyBindings KeyBindings, channels *Channels) *PluginManager {
+	return &PluginManager{
+		repoData:       repoData,
+		keyBindings:    keyBindings,
+		channels:       channels,
+		nextActionType: plActionTypeStart,
+		actions:        make(map[ActionType]PluginActionHandler),
+		actionNames:    make(map[string]ActionType),
+		views:          make(map[string]PluginViewLineProvider),
+		loaded:         make(map[string]bool),
+	}
+}
+
+// Load opens the plugin at the provided path and calls its register function
+func (pluginManager *PluginManager) Load(path string) (err error) {
+	pluginManager.lock.Lock()
+	loaded := pluginManager.loaded[path]
+	pluginManager.lock.Unlock()
+
+	if loaded {
+		log.Debugf("Plugin %v already loaded", path)
+		return
+	}
+
+	log.Infof("Loading plugin %v", path)
+
+	goPlugin, err := plugin.Open(path)
+	if err != nil {
+		return fmt.Errorf("Unable to load plugin %v: %v", path, err)
+	}
+
+	symbol, err := goPlugin.Lookup(plRegisterSymbol)
+	if err != nil {
+		return fmt.Errorf("Plugin %v does not export %v: %v", path, plRegisterSymbol, err)
+	}
+
+	register, ok := symbol.(func(
+		func(string, func(map[string]string) (string, error)) error,
+		func(string, string, string) error,
+		func(string, func() []string) error,
+	) error)
+	if !ok {
+		return fmt.Errorf("Plugin %v exports %v with unexpected type %T", path, plRegisterSymbol, symbol)
+	}
+
+	return pluginManager.register(path, register)
+}
+
+func (pluginManager *PluginManager) register(path string, register pluginRegisterFunc) (err error) {
+	registration := &pluginRegistration{
+		pluginManager: pluginManager,
+		views:         make(map[string]PluginViewLineProvider),
+	}
+
+	if err = register(registration.registerAction, registration.bindKey, registration.registerView); err != nil {
+		return fmt.Errorf("Plugin %v failed to register: %v", path, err)
+	}
+
+	pluginManager.lock.Lock()
+	defer pluginManager.lock.Unlock()
+
+	if err = registration.commit(); err != nil {
+		return fmt.Errorf("Plugin %v failed to register: %v", path, err)
+	}
+
+	// The plugin is only marked as loaded once registration has succeeded so loading can be retried
+	pluginManager.loaded[path] = true
+
+	log.Infof("Loaded plugin %v", path)
+
+	return
+}
+
+func pluginActionKey(name string) string {
+	return plActionKeyPrefix + name + plActionKeySuffix
+}
+
+func (registration *pluginRegistration) actionRegistered(name string) bool {
+	for _, action := range registration.actions {
+		if action.name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (registration *pluginRegistration) registerAction(name string, handler func(map[string]string) (string, error)) (err error) {
+	if name == "" || handler == nil {
+		return fmt.Errorf("Plugin actions require a name and a handler")
+	}
+
+	pluginManager := registration.pluginManager
+	pluginManager.lock.Lock()
+	_, exists := pluginManager.actionNames[name]
+	pluginManager.lock.Unlock()
+
+	if exists || registration.actionRegistered(name) {
+		return fmt.Errorf("Plugin action %v has already been registered", name)
+	}
+
+	registration.actions = append(registration.actions, pluginAction{
+		name:    name,
+		handler: handler,
+	})
+
+	return
+}
+
+func (registration *pluginRegistration) bindKey(view, keys, actionName string) (err error) {
+	viewID, ok := viewIDNames[view]
+	if !ok {
+		return fmt.Errorf("Invalid view: %v", view)
+	}
+
+	if keys == "" {
+		return fmt.Errorf("Keys cannot be empty")
+	}
+
+	pluginManager := registration.pluginManager
+	pluginManager.lock.Lock()
+	_, exists := pluginManager.actionNames[actionName]
+	pluginManager.lock.Unlock()
+
+	if !exists && !registration.actionRegistered(actionName) {
+		return fmt.Errorf("No plugin action registered with name %v", actionName)
+	}
+
+	registration.keyBindings = append(registration.keyBindings, pluginKeyBinding{
+		view:       view,
+		viewID:     viewID,
+		keys:       keys,
+		actionName: actionName,
+	})
+
+	return
+}
+
+func (registration *pluginRegistration) registerView(name string, lines func() []string) (err error) {
+	if name == "" || lines == nil {
+		return fmt.Errorf("Plugin views require a name and a line provider")
+	}
+
+	pluginManager := registration.pluginManager
+	pluginManager.lock.Lock()
+	_, exists := pluginManager.views[name]
+	pluginManager.lock.Unlock()
+
+	if _, staged := registration.views[name]; exists || staged {
+		return fmt.Errorf("Plugin view %v has already been registered", name)
+	}
+
+	registration.views[name] = lines
+
+	return
+}
+
+// commit adds the staged registrations to the PluginManager.
+// The PluginManager lock must be held when calling this method
+func (registration *pluginRegistration) commit() (err error) {
+	pluginManager := registration.pluginManager
+
+	// Another plugin may have registered the same names while this plugin was registering
+	for _, action := range registration.actions {
+		if _, exists := pluginManager.actionNames[action.name]; exists {
+			return fmt.Errorf("Plugin action %v has already been registered", action.name)
+		}
+	}
+
+	for name := range registration.views {
+		if _, exists := pluginManager.views[name]; exists {
+			return fmt.Errorf("Plugin view %v has already been registered", name)
+		}
+	}
+
+	for _, action := range registration.actions {
+		actionType := pluginManager.nextActionType
+		pluginManager.nextActionType++
+
+		pluginManager.actions[actionType] = action.handler
+		pluginManager.actionNames[action.name] = actionType
+		pluginManager.keyBindings.SetActionBinding(ViewAll, pluginActionKey(action.name), actionType)
+
+		log.Infof("Registered plugin action %v", pluginActionKey(action.name))
+	}
+
+	for _, keyBinding := range registration.keyBindings {
+		pluginManager.keyBindings.SetActionBinding(keyBinding.viewID, keyBinding.keys, pluginManager.actionNames[keyBinding.actionName])
+		log.Infof("Bound \"%v\" to plugin action %v for view %v", keyBinding.keys, keyBinding.actionName, keyBinding.view)
+	}
+
+	for name, lines := range registration.views {
+		pluginManager.views[name] = lines
+		log.Infof("Registered plugin view %v", name)
+	}
+
+	return
+}
+
+// IsPluginAction returns true if the action type was registered by a plugin
+func (pluginManager *PluginManager) IsPluginAction(actionType ActionType) bool {
+	pluginManager.lock.Lock()
+	defer pluginManager.lock.Unlock()
+
+	_, exists := pluginManager.actions[actionType]
+	return exists
+}
+
+// HandleAction executes the plugin handler registered for the action
+// Handlers are run on a separate goroutine so slow plugins do not block input
+func (pluginManager *PluginManager) HandleAction(action Action) (err error) {
+	pluginManager.lock.Lock()
+	handler, exists := pluginManager.actions[action.ActionType]
+	pluginManager.lock.Unlock()
+
+	if !exists {
+		return fmt.Errorf("No plugin action registered for action type %v", action.ActionType)
+	}
+
+	args := map[string]string{
+		plRepoPathArg: pluginManager.repoData.Path(),
+	}
+
+	go func() {
+		defer RecoverPanic()
+
+		status, err := handler(args)
+		if err != nil {
+			pluginManager.channels.ReportError(err)
+		} else if status != "" {
+			pluginManager.channels.ReportStatus("%v", status)
+		}
+
+		pluginManager.channels.UpdateDisplay()
+	}()
+
+	return
+}
+
+// ViewLineProvider returns the line provider for the plugin view with the provided name
+func (pluginManager *PluginManager) ViewLineProvider(name string) (lineProvider PluginViewLineProvider, exists bool) {
+	pluginManager.lock.Lock()
+	defer pluginManager.lock.Unlock()
+
+	lineProvider, exists = pluginManager.views[name]
+	return
+}
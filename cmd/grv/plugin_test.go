@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPluginLoadCanBeRetriedAfterFailure(t *testing.T) {
+	pluginManager := NewPluginManager(nil, nil, nil)
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		if err := pluginManager.Load("/does/not/exist.so"); err == nil {
+			t.Errorf("Expected error when loading missing plugin on attempt %v", attempt)
+		}
+	}
+}
+
+func TestPluginRegistrationsAreDiscardedWhenRegistrationFails(t *testing.T) {
+	pluginManager := NewPluginManager(nil, NewKeyBindingManager(), nil)
+	failRegistration := true
+
+	register := func(
+		registerAction func(string, func(map[string]string) (string, error)) error,
+		bindKey func(string, string, string) error,
+		registerView func(string, func() []string) error,
+	) error {
+		handler := func(map[string]string) (string, error) { return "", nil }
+
+		if err := registerAction("test-action", handler); err != nil {
+			return err
+		}
+
+		if err := bindKey(cfCommitView, "T", "test-action"); err != nil {
+			return err
+		}
+
+		if err := registerView("test-view", func() []string { return nil }); err != nil {
+			return err
+		}
+
+		if failRegistration {
+			return fmt.Errorf("Registration failed")
+		}
+
+		return nil
+	}
+
+	if err := pluginManager.register("test.so", register); err == nil {
+		t.Fatalf("Expected registration to fail")
+	}
+
+	if _, exists := pluginManager.ViewLineProvider("test-view"); exists {
+		t.Errorf("Expected view registered by failed plugin to be discarded")
+	}
+
+	failRegistration = false
+
+	if err := pluginManager.register("test.so", register); err != nil {
+		t.Fatalf("Expected retried registration to succeed but got error: %v", err)
+	}
+
+	actionType, exists := pluginManager.actionNames["test-action"]
+	if !exists || !pluginManager.IsPluginAction(actionType) {
+		t.Errorf("Expected action test-action to be registered")
+	}
+
+	if _, exists := pluginManager.ViewLineProvider("test-view"); !exists {
+		t.Errorf("Expected view test-view to be registered")
+	}
+
+	if !pluginManager.loaded["test.so"] {
+		t.Errorf("Expected plugin to be marked as loaded")
+	}
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	log "github.com/Sirupsen/logrus"
+)
+
+type pluginViewHandler func(*PluginView, Action) error
+
+// PluginView displays lines provided by a plugin
+type PluginView struct {
+	*ListView
+	config       Config
+	name         string
+	lineProvider PluginViewLineProvider
+	lines        []string
+	active       bool
+	handlers     map[ActionType]pluginViewHandler
+}
+
+// NewPluginView creates a new instance
+func NewPluginView(name string, lineProvider PluginViewLineProvider, channels *Channels, config Config) *PluginView {
+	pluginView := &PluginView{
+		ListView:     NewListView(channels),
+		config:       config,
+		name:         name,
+		lineProvider: lineProvider,
+		handlers: map[ActionType]pluginViewHandler{
+			ActionSelect: reloadPluginView,
+		},
+	}
+
+	pluginView.viewSearch = NewViewSearch(pluginView, channels)
+
+	return pluginView
+}
+
+// Initialise loads the lines provided by the plugin
+func (pluginView *PluginView) Initialise() (err error) {
+	log.Debugf("Initialising PluginView %v", pluginView.name)
+
+	pluginView.lock.Lock()
+	defer pluginView.lock.Unlock()
+
+	pluginView.loadLines()
+
+	return
+}
+
+func (pluginView *PluginView) loadLines() {
+	pluginView.lines = pluginView.lineProvider()
+
+	lineNumber := uint(len(pluginView.lines))
+	viewPos := pluginView.viewPos
+
+	if lineNumber == 0 {
+		viewPos.SetActiveRowIndex(0)
+	} else if viewPos.ActiveRowIndex() >= lineNumber {
+		viewPos.SetActiveRowIndex(lineNumber - 1)
+	}
+}
+
+// Render generates and writes the plugin view to the provided window
+func (pluginView *PluginView) Render(win RenderWindow) (err error) {
+	pluginView.lock.Lock()
+	defer pluginView.lock.Unlock()
+
+	log.Debugf("Rendering PluginView %v", pluginView.name)
+
+	pluginView.viewDimension = win.ViewDimensions()
+
+	lineNumber := uint(len(pluginView.lines))
+	rows := win.Rows() - 2
+
+	viewPos := pluginView.viewPos
+	viewPos.DetermineViewStartRow(rows, lineNumber, uint(pluginView.config.GetInt(CfScrollOff)))
+	lineIndex := viewPos.ViewStartRowIndex()
+	startColumn := viewPos.ViewStartColumn()
+
+	for rowIndex := uint(0); rowIndex < rows && lineIndex < lineNumber; rowIndex++ {
+		if err = win.SetRow(rowIndex+1, startColumn, CmpNone, " %v", pluginView.lines[lineIndex]); err != nil {
+			return
+		}
+
+		lineIndex++
+	}
+
+	if lineNumber > 0 {
+		if err = win.SetSelectedRow(viewPos.SelectedRowIndex()+1, pluginView.active); err != nil {
+			return
+		}
+	}
+
+	win.DrawBorder()
+
+	if err = win.SetTitle(CmpPluginviewTitle, "%v", pluginView.name); err != nil {
+		return
+	}
+
+	if lineNumber > 0 {
+		if err = win.SetFooter(CmpPluginviewFooter, "Line %v of %v", viewPos.ActiveRowIndex()+1, lineNumber); err != nil {
+			return
+		}
+	}
+
+	err = pluginView.RenderSearchHighlight(win)
+
+	return
+}
+
+// RenderHelpBar renders key binding help for the plugin view
+func (pluginView *PluginView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
+	RenderKeyBindingHelp(pluginView.ViewID(), lineBuilder, []ActionMessage{
+		{action: ActionSelect, message: "Reload"},
+	})
+
+	return
+}
+
+// HandleEvent does nothing
+func (pluginView *PluginView) HandleEvent(event Event) (err error) {
+	return
+}
+
+// OnActiveChange updates whether this view is currently active
+func (pluginView *PluginView) OnActiveChange(active bool) {
+	log.Debugf("PluginView %v active: %v", pluginView.name, active)
+	pluginView.lock.Lock()
+	defer pluginView.lock.Unlock()
+
+	pluginView.active = active
+}
+
+// ViewID returns the ViewID for the plugin view
+func (pluginView *PluginView) ViewID() ViewID {
+	return ViewPlugin
+}
+
+// Line returns the line at the specified index
+func (pluginView *PluginView) Line(lineIndex uint) (line string) {
+	pluginView.lock.Lock()
+	defer pluginView.lock.Unlock()
+
+	lineNumber := uint(len(pluginView.lines))
+	if lineIndex >= lineNumber {
+		log.Errorf("Invalid lineIndex: %v >= %v", lineIndex, lineNumber)
+		return
+	}
+
+	return pluginView.lines[lineIndex]
+}
+
+// LineNumber returns the number of lines in the view
+func (pluginView *PluginView) LineNumber() (lineNumber uint) {
+	pluginView.lock.Lock()
+	defer pluginView.lock.Unlock()
+
+	return uint(len(pluginView.lines))
+}
+
+// HandleAction checks if the plugin view supports this action and if it does executes it
+func (pluginView *PluginView) HandleAction(action Action) (err error) {
+	pluginView.lock.Lock()
+	defer pluginView.lock.Unlock()
+
+	if handler, ok := pluginView.handlers[action.ActionType]; ok {
+		log.Debugf("PluginView handling action %v", action)
+		err = handler(pluginView, action)
+	} else {
+		_, err = pluginView.HandleListAction(action, uint(len(pluginView.lines)))
+	}
+
+	return
+}
+
+func reloadPluginView(pluginView *PluginView, action Action) (err error) {
+	log.Debugf("Reloading PluginView %v", pluginView.name)
+	pluginView.loadLines()
+	pluginView.channels.ReportSuccess("Reloaded %v", pluginView.name)
+	pluginView.channels.UpdateDisplay()
+
+	return
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	prGitHubAPIURL      = "https://api.github.com"
+	prGitHubURL         = "https://github.com"
+	prGitHubTokenEnv    = "GITHUB_TOKEN"
+	prRemoteName        = "origin"
+	prAPIRequestTimeout = 10 * time.Second
+	prAPIErrorBackoff   = time.Minute
+)
+
+// PullRequestLookup determines how the pull request associated with a commit is found
+type PullRequestLookup int
+
+// The set of pull request lookup methods
+const (
+	PrlOff PullRequestLookup = iota
+	PrlMessage
+	PrlAPI
+)
+
+var pullRequestLookupNames = map[string]PullRequestLookup{
+	"off":     PrlOff,
+	"message": PrlMessage,
+	"api":     PrlAPI,
+}
+
+var prMergeMessageRegex = regexp.MustCompile(`^Merge pull request #([0-9]+) from `)
+var prSquashSummaryRegex = regexp.MustCompile(`^(.*?)\s*\(#([0-9]+)\)$`)
+var prGitHubRemoteRegex = regexp.MustCompile(`github\.com[:/]([^/]+)/([^/]+?)(\.git)?/?$`)
+
+// ParsePullRequestLookup returns the pull request lookup method with the provided name
+func ParsePullRequestLookup(name string) (pullRequestLookup PullRequestLookup, err error) {
+	pullRequestLookup, ok := pullRequestLookupNames[strings.ToLower(name)]
+	if !ok {
+		err = fmt.Errorf("Invalid pull request lookup %v. Valid values are off, message and api", name)
+	}
+
+	return
+}
+
+// PullRequest is a GitHub pull request associated with a commit
+type PullRequest struct {
+	number int
+	title  string
+	url    string
+}
+
+// String returns the pull request number and title
+func (pullRequest *PullRequest) String() string {
+	return fmt.Sprintf("#%v %v", pullRequest.number, pullRequest.title)
+}
+
+// pullRequestFromMessage determines the pull request a commit was merged by from the commit message.
+// Both GitHub merge commits and squash merged commits are recognised
+func pullRequestFromMessage(message string) (pullRequest *PullRequest, found bool) {
+	lines := strings.Split(strings.TrimSpace(message), "\n")
+	summary := strings.TrimSpace(lines[0])
+
+	if matches := prMergeMessageRegex.FindStringSubmatch(summary); matches != nil {
+		number, _ := strconv.Atoi(matches[1])
+		pullRequest = &PullRequest{number: number}
+
+		for _, line := range lines[1:] {
+			if line = strings.TrimSpace(line); line != "" {
+				pullRequest.title = line
+				break
+			}
+		}
+
+		return pullRequest, true
+	}
+
+	if matches := prSquashSummaryRegex.FindStringSubmatch(summary); matches != nil {
+		number, _ := strconv.Atoi(matches[2])
+		return &PullRequest{number: number, title: matches[1]}, true
+	}
+
+	return
+}
+
+// parseGitHubRemoteURL returns the owner and name of the GitHub repository the remote URL refers to
+func parseGitHubRemoteURL(remoteURL string) (owner, repo string, ok bool) {
+	matches := prGitHubRemoteRegex.FindStringSubmatch(strings.TrimSpace(remoteURL))
+	if matches == nil {
+		return
+	}
+
+	return matches[1], matches[2], true
+}
+
+type gitHubPullRequest struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	HTMLURL string `json:"html_url"`
+}
+
+// PullRequestCache determines the pull requests commits are associated with and caches the results.
+// After a failed API query no further queries are made until prAPIErrorBackoff has elapsed
+type PullRequestCache struct {
+	remoteURL    func(remoteName string) (string, error)
+	apiURL       string
+	client       *http.Client
+	pullRequests map[string]*PullRequest
+	apiErr       error
+	apiRetryTime time.Time
+	lock         sync.Mutex
+}
+
+// NewPullRequestCache creates a new instance which uses the provided function to determine the URL of the origin remote
+func NewPullRequestCache(remoteURL func(remoteName string) (string, error)) *PullRequestCache {
+	return &PullRequestCache{
+		remoteURL:    remoteURL,
+		apiURL:       prGitHubAPIURL,
+		client:       &http.Client{Timeout: prAPIRequestTimeout},
+		pullRequests: make(map[string]*PullRequest),
+	}
+}
+
+// PullRequest returns the pull request associated with the commit with the provided id and message.
+// The commit message is checked first and the GitHub API is queried if lookup is PrlAPI.
+// A nil pull request is returned if the commit is not associated with one
+func (pullRequestCache *PullRequestCache) PullRequest(oid, message string, lookup PullRequestLookup) (pullRequest *PullRequest, err error) {
+	if lookup == PrlOff {
+		return
+	}
+
+	pullRequestCache.lock.Lock()
+	pullRequest, cached := pullRequestCache.pullRequests[oid]
+	pullRequestCache.lock.Unlock()
+
+	if cached {
+		return
+	}
+
+	owner, repo, isGitHubRepo := pullRequestCache.gitHubRepository()
+
+	pullRequest, found := pullRequestFromMessage(message)
+	if found {
+		if isGitHubRepo {
+			pullRequest.url = fmt.Sprintf("%v/%v/%v/pull/%v", prGitHubURL, owner, repo, pullRequest.number)
+		}
+	} else if lookup == PrlAPI && isGitHubRepo {
+		if pullRequest, err = pullRequestCache.queryPullRequestWithBackoff(owner, repo, oid); err != nil {
+			return
+		}
+	}
+
+	pullRequestCache.lock.Lock()
+	pullRequestCache.pullRequests[oid] = pullRequest
+	pullRequestCache.lock.Unlock()
+
+	return
+}
+
+func (pullRequestCache *PullRequestCache) gitHubRepository() (owner, repo string, ok bool) {
+	remoteURL, err := pullRequestCache.remoteURL(prRemoteName)
+	if err != nil {
+		log.Debugf("Unable to determine URL of remote %v: %v", prRemoteName, err)
+		return
+	}
+
+	return parseGitHubRemoteURL(remoteURL)
+}
+
+// queryPullRequestWithBackoff queries the GitHub API unless a previous query failed within prAPIErrorBackoff,
+// in which case the previous error is returned. This prevents every commit displayed from waiting on an
+// API which is unavailable or rate limiting requests
+func (pullRequestCache *PullRequestCache) queryPullRequestWithBackoff(owner, repo, oid string) (pullRequest *PullRequest, err error) {
+	pullRequestCache.lock.Lock()
+	apiErr, apiRetryTime := pullRequestCache.apiErr, pullRequestCache.apiRetryTime
+	pullRequestCache.lock.Unlock()
+
+	if apiErr != nil && time.Now().Before(apiRetryTime) {
+		return nil, apiErr
+	}
+
+	if pullRequest, err = pullRequestCache.queryPullRequest(owner, repo, oid); err != nil {
+		log.Infof("Pull request API query failed. Retrying in %v: %v", prAPIErrorBackoff, err)
+
+		pullRequestCache.lock.Lock()
+		pullRequestCache.apiErr = err
+		pullRequestCache.apiRetryTime = time.Now().Add(prAPIErrorBackoff)
+		pullRequestCache.lock.Unlock()
+	}
+
+	return
+}
+
+func (pullRequestCache *PullRequestCache) queryPullRequest(owner, repo, oid string) (pullRequest *PullRequest, err error) {
+	url := fmt.Sprintf("%v/repos/%v/%v/commits/%v/pulls", pullRequestCache.apiURL, owner, repo, oid)
+	log.Debugf("Querying pull requests for commit %v: %v", oid, url)
+
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return
+	}
+
+	request.Header.Set("Accept", "application/vnd.github+json")
+	if token := os.Getenv(prGitHubTokenEnv); token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	response, err := pullRequestCache.client.Do(request)
+	if err != nil {
+		err = fmt.Errorf("Unable to query pull requests for commit %v: %v", oid, err)
+		return
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		err = fmt.Errorf("Unable to query pull requests for commit %v: %v", oid, response.Status)
+		return
+	}
+
+	var gitHubPullRequests []gitHubPullRequest
+	if err = json.NewDecoder(response.Body).Decode(&gitHubPullRequests); err != nil {
+		err = fmt.Errorf("Unable to parse pull requests for commit %v: %v", oid, err)
+		return
+	}
+
+	if len(gitHubPullRequests) > 0 {
+		pullRequest = &PullRequest{
+			number: gitHubPullRequests[0].Number,
+			title:  gitHubPullRequests[0].Title,
+			url:    gitHubPullRequests[0].HTMLURL,
+		}
+	}
+
+	return
+}
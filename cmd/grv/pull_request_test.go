@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPullRequestIsDeterminedFromCommitMessage(t *testing.T) {
+	tests := []struct {
+		message        string
+		expectedNumber int
+		expectedTitle  string
+	}{
+		{message: "Merge pull request #42 from user/branch\n\nFix the thing\n", expectedNumber: 42, expectedTitle: "Fix the thing"},
+		{message: "Fix the thing (#123)\n\nLonger description\n", expectedNumber: 123, expectedTitle: "Fix the thing"},
+	}
+
+	for _, test := range tests {
+		pullRequest, found := pullRequestFromMessage(test.message)
+		if !found {
+			t.Errorf("Expected pull request to be found in message %q", test.message)
+		} else if pullRequest.number != test.expectedNumber || pullRequest.title != test.expectedTitle {
+			t.Errorf("Pull request does not match expected value. Expected: #%v %v, Actual: %v", test.expectedNumber, test.expectedTitle, pullRequest)
+		}
+	}
+}
+
+func TestPullRequestIsNotFoundInUnrelatedCommitMessage(t *testing.T) {
+	if _, found := pullRequestFromMessage("Fix issue #123 in parser\n"); found {
+		t.Errorf("Expected no pull request to be found")
+	}
+}
+
+func TestGitHubRemoteURLsAreParsed(t *testing.T) {
+	remoteURLs := []string{
+		"git@github.com:owner/repo.git",
+		"https://github.com/owner/repo",
+		"https://github.com/owner/repo.git",
+		"ssh://git@github.com/owner/repo.git",
+	}
+
+	for _, remoteURL := range remoteURLs {
+		if owner, repo, ok := parseGitHubRemoteURL(remoteURL); !ok || owner != "owner" || repo != "repo" {
+			t.Errorf("Remote URL %v was not parsed correctly. Actual: %v/%v", remoteURL, owner, repo)
+		}
+	}
+
+	if _, _, ok := parseGitHubRemoteURL("https://gitlab.com/owner/repo.git"); ok {
+		t.Errorf("Expected non GitHub remote URL to be rejected")
+	}
+}
+
+func TestPullRequestIsQueriedFromAPI(t *testing.T) {
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests++
+
+		if request.URL.Path != "/repos/owner/repo/commits/abc123/pulls" {
+			http.NotFound(writer, request)
+			return
+		}
+
+		fmt.Fprint(writer, `[{"number": 7, "title": "Add feature", "html_url": "https://github.com/owner/repo/pull/7"}]`)
+	}))
+	defer server.Close()
+
+	pullRequestCache := NewPullRequestCache(func(remoteName string) (string, error) {
+		return "git@github.com:owner/repo.git", nil
+	})
+	pullRequestCache.apiURL = server.URL
+
+	for i := 0; i < 2; i++ {
+		pullRequest, err := pullRequestCache.PullRequest("abc123", "Add feature\n", PrlAPI)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		} else if pullRequest == nil || pullRequest.number != 7 || pullRequest.url != "https://github.com/owner/repo/pull/7" {
+			t.Errorf("Pull request does not match expected value. Actual: %v", pullRequest)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("Expected pull request to be cached. Requests made: %v", requests)
+	}
+}
+
+func TestPullRequestAPIIsNotQueriedAgainAfterAnError(t *testing.T) {
+	requests := 0
+	failRequests := true
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests++
+
+		if failRequests {
+			http.Error(writer, "rate limit exceeded", http.StatusForbidden)
+			return
+		}
+
+		fmt.Fprint(writer, `[{"number": 7, "title": "Add feature", "html_url": "https://github.com/owner/repo/pull/7"}]`)
+	}))
+	defer server.Close()
+
+	pullRequestCache := NewPullRequestCache(func(remoteName string) (string, error) {
+		return "git@github.com:owner/repo.git", nil
+	})
+	pullRequestCache.apiURL = server.URL
+
+	for _, oid := range []string{"abc123", "def456"} {
+		if _, err := pullRequestCache.PullRequest(oid, "Add feature\n", PrlAPI); err == nil {
+			t.Errorf("Expected error for commit %v", oid)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("Expected API not to be queried again after an error. Requests made: %v", requests)
+	}
+
+	failRequests = false
+	pullRequestCache.apiRetryTime = time.Now()
+
+	if pullRequest, err := pullRequestCache.PullRequest("abc123", "Add feature\n", PrlAPI); err != nil {
+		t.Errorf("Unexpected error once backoff has elapsed: %v", err)
+	} else if pullRequest == nil || pullRequest.number != 7 {
+		t.Errorf("Pull request does not match expected value. Actual: %v", pullRequest)
+	}
+}
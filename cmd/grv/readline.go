@@ -8,10 +8,41 @@ package main
 //
 // #include <stdio.h>
 // #include <stdlib.h>
+// #include <string.h>
 // #include <readline/readline.h>
 // #include <readline/history.h>
 //
 // extern void grvReadlineUpdateDisplay(void);
+// extern char *grvReadlineCompletionGenerator(char *text, int state);
+//
+// static char *grv_completion_generator(const char *text, int state) {
+//	return grvReadlineCompletionGenerator((char *) text, state);
+// }
+//
+// static char **grv_complete(const char *text, int start, int end) {
+//	char **matches;
+//
+//	rl_attempted_completion_over = 1;
+//	matches = rl_completion_matches(text, grv_completion_generator);
+//
+//	if (matches != NULL && matches[1] == NULL) {
+//		size_t length = strlen(matches[0]);
+//		rl_completion_append_character = (length > 0 && matches[0][length - 1] == '/') ? '\0' : ' ';
+//	}
+//
+//	return matches;
+// }
+//
+// static int grv_readline_cancel(int count, int key) {
+//	rl_replace_line("", 0);
+//	rl_done = 1;
+//	return 0;
+// }
+//
+// static int grv_is_last_history_entry(const char *line) {
+//	HIST_ENTRY *entry = history_get(history_base + history_length - 1);
+//	return entry != NULL && strcmp(entry->line, line) == 0;
+// }
 //
 // static void grv_init_readline(void) {
 // 	rl_redisplay_function = grvReadlineUpdateDisplay;
@@ -20,7 +51,17 @@ package main
 //#if RL_READLINE_VERSION >= 0x0603
 //	rl_change_environment = 0;
 //#endif
-//	rl_bind_key('\t', NULL);
+//	rl_bind_key('\t', rl_complete);
+//	rl_attempted_completion_function = grv_complete;
+//	rl_completer_word_break_characters = " \t";
+//	rl_bind_key(CTRL('g'), grv_readline_cancel);
+//#if RL_READLINE_VERSION >= 0x0603
+//	rl_bind_keyseq("\\e", grv_readline_cancel);
+//#endif
+//	rl_bind_keyseq("\\e[A", rl_history_search_backward);
+//	rl_bind_keyseq("\\e[B", rl_history_search_forward);
+//	rl_bind_keyseq("\\eOA", rl_history_search_backward);
+//	rl_bind_keyseq("\\eOB", rl_history_search_forward);
 //
 //	history_write_timestamps = 1;
 //	history_comment_char = '#';
@@ -40,13 +81,22 @@ const (
 	rlCommandHistoryFile = "/command_history"
 	rlSearchHistoryFile  = "/search_history"
 	rlFilterHistoryFile  = "/filter_history"
+	rlBranchHistoryFile  = "/branch_history"
+	rlCommitHistoryFile  = "/commit_history"
+	rlPickaxeHistoryFile = "/pickaxe_history"
 )
 
+// Each prompt type has its own history which is persisted to a file in the config directory.
+// Prompts which accept the same type of input share a history
 var historyFilePrompts = map[string]string{
 	PromptText:              rlCommandHistoryFile,
 	SearchPromptText:        rlSearchHistoryFile,
 	ReverseSearchPromptText: rlSearchHistoryFile,
 	FilterPromptText:        rlFilterHistoryFile,
+	SetUpstreamPromptText:   rlBranchHistoryFile,
+	SelectCommitPromptText:  rlCommitHistoryFile,
+	PickaxePromptText:       rlPickaxeHistoryFile,
+	PickaxeRegexPromptText:  rlPickaxeHistoryFile,
 }
 
 var readLine ReadLine
@@ -61,15 +111,22 @@ type ReadLine struct {
 	promptPoint    int
 	active         bool
 	lastPromptText string
+	inputListener  PromptInputListener
+	completion     *CommandCompletion
+	candidates     []string
 	lock           sync.Mutex
 }
 
+// PromptInputListener is notified each time the prompt input changes
+type PromptInputListener func(input string)
+
 // InitReadLine initialises the readline library
-func InitReadLine(channels *Channels, ui InputUI, config Config) {
+func InitReadLine(channels *Channels, ui InputUI, config Config, completion *CommandCompletion) {
 	readLine = ReadLine{
-		channels: channels,
-		config:   config,
-		ui:       ui,
+		channels:   channels,
+		config:     config,
+		ui:         ui,
+		completion: completion,
 	}
 
 	C.grv_init_readline()
@@ -120,14 +177,22 @@ func writeHistoryFile(file string) {
 }
 
 // Prompt shows a readline prompt using prompt text provided
-// User input is returned
+// User input is returned. Escape or <C-g> cancel the prompt and return no input
 func Prompt(prompt string) string {
+	return PromptWithInputListener(prompt, nil)
+}
+
+// PromptWithInputListener shows a readline prompt and notifies the provided listener
+// each time the input changes. The listener is called on the goroutine which displayed the prompt
+func PromptWithInputListener(prompt string, inputListener PromptInputListener) string {
 	cPrompt := C.CString(prompt)
 
 	readLineSetupPromptHistory(prompt)
+	readLineSetInputListener(inputListener)
 	readLineSetActive(true)
 	cInput := C.readline(cPrompt)
 	readLineSetActive(false)
+	readLineSetInputListener(nil)
 
 	C.free(unsafe.Pointer(cPrompt))
 	readLineAddPromptHistory(prompt, cInput)
@@ -160,6 +225,14 @@ func readLineSetActive(active bool) {
 	readLine.active = active
 }
 
+func readLineSetInputListener(inputListener PromptInputListener) {
+	readLine.lock.Lock()
+	defer readLine.lock.Unlock()
+
+	readLine.inputListener = inputListener
+	readLine.promptInput = ""
+}
+
 func readLineSetupPromptHistory(prompt string) {
 	readLine.lock.Lock()
 	defer readLine.lock.Unlock()
@@ -191,22 +264,43 @@ func readLineAddPromptHistory(prompt string, cInput *C.char) {
 		return
 	}
 
-	_, hasHistoryFile := historyFilePrompts[readLine.lastPromptText]
+	historyFile, hasHistoryFile := historyFilePrompts[readLine.lastPromptText]
+	if !hasHistoryFile {
+		return
+	}
 
-	if hasHistoryFile {
+	if C.grv_is_last_history_entry(cInput) == 0 {
 		C.add_history(cInput)
 	}
+
+	if historySize := readLine.config.GetInt(CfHistorySize); historySize > 0 {
+		C.stifle_history(C.int(historySize))
+	} else {
+		C.unstifle_history()
+	}
+
+	writeHistoryFile(historyFile)
 }
 
 //export grvReadlineUpdateDisplay
 func grvReadlineUpdateDisplay() {
 	readLine.lock.Lock()
-	defer readLine.lock.Unlock()
 
 	displayPrompt := C.GoString(C.rl_display_prompt)
 	lineBuffer := C.GoString(C.rl_line_buffer)
 	point := int(C.rl_point)
 
+	inputListener := readLine.inputListener
+	inputChanged := lineBuffer != readLine.promptInput
+
+	defer func() {
+		readLine.lock.Unlock()
+
+		if inputListener != nil && inputChanged {
+			inputListener(lineBuffer)
+		}
+	}()
+
 	readLine.promptText = displayPrompt
 	readLine.promptInput = lineBuffer
 	readLine.promptPoint = point
@@ -216,3 +310,42 @@ func grvReadlineUpdateDisplay() {
 
 	readLine.channels.UpdateDisplay()
 }
+
+// grvReadlineCompletionGenerator is called repeatedly by readline to generate completion candidates.
+// Candidates are generated when state is 0 and returned one at a time. NULL is returned when none remain.
+// The command prompt and the set upstream prompt support completion
+//
+//export grvReadlineCompletionGenerator
+func grvReadlineCompletionGenerator(text *C.char, state C.int) *C.char {
+	readLine.lock.Lock()
+	defer readLine.lock.Unlock()
+
+	if state == 0 {
+		readLine.candidates = nil
+
+		if readLine.completion != nil {
+			input := C.GoString(C.rl_line_buffer)
+			if point := int(C.rl_point); point < len(input) {
+				input = input[:point]
+			}
+
+			switch C.GoString(C.rl_prompt) {
+			case PromptText:
+				readLine.candidates = readLine.completion.Complete(input)
+			case SetUpstreamPromptText:
+				readLine.candidates = readLine.completion.CompleteRemoteBranches(input)
+			}
+
+			log.Debugf("Completion candidates for input \"%v\": %v", input, readLine.candidates)
+		}
+	}
+
+	if len(readLine.candidates) == 0 {
+		return nil
+	}
+
+	candidate := readLine.candidates[0]
+	readLine.candidates = readLine.candidates[1:]
+
+	return C.CString(candidate)
+}
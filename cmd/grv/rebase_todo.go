@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+)
+
+// RebaseCommand is the action an interactive rebase performs on a commit
+type RebaseCommand int
+
+// The set of supported rebase commands
+const (
+	RcPick RebaseCommand = iota
+	RcSquash
+	RcFixup
+	RcDrop
+)
+
+var rebaseCommandNames = map[RebaseCommand]string{
+	RcPick:   "pick",
+	RcSquash: "squash",
+	RcFixup:  "fixup",
+	RcDrop:   "drop",
+}
+
+// String returns the todo list name of the rebase command
+func (rebaseCommand RebaseCommand) String() string {
+	return rebaseCommandNames[rebaseCommand]
+}
+
+// combinesWithParent returns true if the command folds the commit into the commit before it
+func (rebaseCommand RebaseCommand) combinesWithParent() bool {
+	return rebaseCommand == RcSquash || rebaseCommand == RcFixup
+}
+
+// RebaseTodoCommit contains the details of a commit required to generate a rebase todo list
+type RebaseTodoCommit struct {
+	oid         string
+	parentOid   string
+	summary     string
+	parentCount uint
+}
+
+// RebaseTodo stores the rebase commands assigned to commits from which an interactive rebase todo list is generated
+type RebaseTodo struct {
+	commands map[string]RebaseCommand
+}
+
+// NewRebaseTodo creates a new instance with no commands assigned
+func NewRebaseTodo() *RebaseTodo {
+	return &RebaseTodo{
+		commands: make(map[string]RebaseCommand),
+	}
+}
+
+// Set assigns the rebase command to the commit with the provided oid
+func (rebaseTodo *RebaseTodo) Set(oid string, rebaseCommand RebaseCommand) {
+	rebaseTodo.commands[oid] = rebaseCommand
+}
+
+// Command returns the rebase command assigned to the commit with the provided oid
+func (rebaseTodo *RebaseTodo) Command(oid string) (rebaseCommand RebaseCommand, assigned bool) {
+	rebaseCommand, assigned = rebaseTodo.commands[oid]
+	return
+}
+
+// Clear removes all assigned rebase commands
+func (rebaseTodo *RebaseTodo) Clear() {
+	rebaseTodo.commands = make(map[string]RebaseCommand)
+}
+
+// Empty returns true if no rebase commands have been assigned
+func (rebaseTodo *RebaseTodo) Empty() bool {
+	return len(rebaseTodo.commands) == 0
+}
+
+// Generate creates the todo list for an interactive rebase of the commits provided by commitAt.
+// Commits are provided newest first starting from HEAD. The rebase starts from the parent of the
+// oldest commit with an assigned command, or earlier if that commit is squashed or fixed up into its
+// parent. Commits without an assigned command are picked. Each commit must be the parent of the
+// commit before it, as a commit missing from the todo list would be dropped by the rebase.
+// The todo list is ordered oldest first as git expects and base is empty if the rebase includes
+// the root commit
+func (rebaseTodo *RebaseTodo) Generate(commitAt func(index uint) (RebaseTodoCommit, bool)) (base string, lines []string, err error) {
+	if rebaseTodo.Empty() {
+		err = fmt.Errorf("No commits have been assigned a rebase command")
+		return
+	}
+
+	var commits []RebaseTodoCommit
+	remaining := len(rebaseTodo.commands)
+	combineWithParent := false
+
+	for index := uint(0); remaining > 0 || combineWithParent; index++ {
+		commit, exists := commitAt(index)
+		if !exists {
+			if remaining > 0 {
+				err = fmt.Errorf("Commits assigned a rebase command must be ancestors of HEAD")
+			} else {
+				err = fmt.Errorf("Unable to squash or fixup the root commit")
+			}
+
+			return
+		}
+
+		if commit.parentCount > 1 {
+			err = fmt.Errorf("Unable to rebase across merge commit %v", abbreviateOid(commit.oid))
+			return
+		}
+
+		if len(commits) > 0 && commits[len(commits)-1].parentOid != commit.oid {
+			err = fmt.Errorf("Unable to rebase as commit %v is not the parent of %v. Clear any commit filters and retry",
+				abbreviateOid(commit.oid), abbreviateOid(commits[len(commits)-1].oid))
+			return
+		}
+
+		rebaseCommand, assigned := rebaseTodo.commands[commit.oid]
+		if assigned {
+			remaining--
+		}
+
+		combineWithParent = rebaseCommand.combinesWithParent()
+		commits = append(commits, commit)
+	}
+
+	base = commits[len(commits)-1].parentOid
+
+	for index := len(commits) - 1; index >= 0; index-- {
+		commit := commits[index]
+		rebaseCommand := rebaseTodo.commands[commit.oid]
+		lines = append(lines, fmt.Sprintf("%v %v %v", rebaseCommand, commit.oid, commit.summary))
+	}
+
+	return
+}
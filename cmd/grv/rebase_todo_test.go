@@ -0,0 +1,144 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newTestRebaseTodoCommits(commits []RebaseTodoCommit) func(index uint) (RebaseTodoCommit, bool) {
+	return func(index uint) (commit RebaseTodoCommit, exists bool) {
+		if index >= uint(len(commits)) {
+			return
+		}
+
+		return commits[index], true
+	}
+}
+
+var rebaseTodoTestCommits = []RebaseTodoCommit{
+	{oid: "e", parentOid: "d", summary: "Fix typo", parentCount: 1},
+	{oid: "d", parentOid: "c", summary: "Add tests", parentCount: 1},
+	{oid: "c", parentOid: "b", summary: "Add feature", parentCount: 1},
+	{oid: "b", parentOid: "a", summary: "Refactor", parentCount: 1},
+	{oid: "a", summary: "Initial commit"},
+}
+
+func TestRebaseTodoStartsFromParentOfOldestAssignedCommit(t *testing.T) {
+	rebaseTodo := NewRebaseTodo()
+	rebaseTodo.Set("c", RcPick)
+	rebaseTodo.Set("e", RcFixup)
+
+	base, lines, err := rebaseTodo.Generate(newTestRebaseTodoCommits(rebaseTodoTestCommits))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedLines := []string{
+		"pick c Add feature",
+		"pick d Add tests",
+		"fixup e Fix typo",
+	}
+
+	if base != "b" {
+		t.Errorf("Expected base b but got %v", base)
+	}
+
+	if !reflect.DeepEqual(expectedLines, lines) {
+		t.Errorf("Todo list does not match expected value. Expected: %v, Actual: %v", expectedLines, lines)
+	}
+}
+
+func TestRebaseTodoIncludesParentOfSquashedCommit(t *testing.T) {
+	rebaseTodo := NewRebaseTodo()
+	rebaseTodo.Set("d", RcSquash)
+	rebaseTodo.Set("e", RcDrop)
+
+	base, lines, err := rebaseTodo.Generate(newTestRebaseTodoCommits(rebaseTodoTestCommits))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedLines := []string{
+		"pick c Add feature",
+		"squash d Add tests",
+		"drop e Fix typo",
+	}
+
+	if base != "b" {
+		t.Errorf("Expected base b but got %v", base)
+	}
+
+	if !reflect.DeepEqual(expectedLines, lines) {
+		t.Errorf("Todo list does not match expected value. Expected: %v, Actual: %v", expectedLines, lines)
+	}
+}
+
+func TestRebaseTodoIncludingRootCommitHasNoBase(t *testing.T) {
+	rebaseTodo := NewRebaseTodo()
+	rebaseTodo.Set("a", RcPick)
+
+	base, lines, err := rebaseTodo.Generate(newTestRebaseTodoCommits(rebaseTodoTestCommits))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if base != "" {
+		t.Errorf("Expected empty base but got %v", base)
+	}
+
+	if len(lines) != len(rebaseTodoTestCommits) {
+		t.Errorf("Expected %v todo lines but got %v", len(rebaseTodoTestCommits), len(lines))
+	}
+}
+
+func TestRebaseTodoReturnsErrors(t *testing.T) {
+	mergeCommits := []RebaseTodoCommit{
+		{oid: "c", parentOid: "b", summary: "Add feature", parentCount: 1},
+		{oid: "b", parentOid: "a", summary: "Merge branch", parentCount: 2},
+		{oid: "a", summary: "Initial commit"},
+	}
+
+	filteredCommits := []RebaseTodoCommit{
+		{oid: "e", parentOid: "d", summary: "Fix typo", parentCount: 1},
+		{oid: "c", parentOid: "b", summary: "Add feature", parentCount: 1},
+		{oid: "b", parentOid: "a", summary: "Refactor", parentCount: 1},
+		{oid: "a", summary: "Initial commit"},
+	}
+
+	tests := []struct {
+		commands map[string]RebaseCommand
+		commits  []RebaseTodoCommit
+	}{
+		{
+			commands: map[string]RebaseCommand{},
+			commits:  rebaseTodoTestCommits,
+		},
+		{
+			commands: map[string]RebaseCommand{"a": RcSquash},
+			commits:  rebaseTodoTestCommits,
+		},
+		{
+			commands: map[string]RebaseCommand{"z": RcDrop},
+			commits:  rebaseTodoTestCommits,
+		},
+		{
+			commands: map[string]RebaseCommand{"a": RcDrop},
+			commits:  mergeCommits,
+		},
+		{
+			commands: map[string]RebaseCommand{"c": RcDrop},
+			commits:  filteredCommits,
+		},
+	}
+
+	for _, test := range tests {
+		rebaseTodo := NewRebaseTodo()
+		for oid, rebaseCommand := range test.commands {
+			rebaseTodo.Set(oid, rebaseCommand)
+		}
+
+		if _, _, err := rebaseTodo.Generate(newTestRebaseTodoCommits(test.commits)); err == nil {
+			t.Errorf("Expected error for commands %v", test.commands)
+		}
+	}
+}
@@ -156,17 +156,23 @@ func (renderedRefList *renderedRefList) Children() (children uint) {
 
 // RefView manages the display of references
 type RefView struct {
-	channels      *Channels
-	repoData      RepoData
-	refLists      []*refList
-	refListeners  []RefListener
-	active        bool
-	renderedRefs  renderedRefSet
-	viewPos       ViewPos
-	viewDimension ViewDimension
-	handlers      map[ActionType]refViewHandler
-	viewSearch    *ViewSearch
-	lock          sync.Mutex
+	channels         *Channels
+	config           Config
+	repoData         RepoData
+	refLists         []*refList
+	refListeners     []RefListener
+	active           bool
+	renderedRefs     renderedRefSet
+	refFilterQueries []string
+	tagFilter        *TagFilter
+	tagSort          TagSort
+	viewPos          ViewPos
+	jumpList         *JumpList
+	viewDimension    ViewDimension
+	handlers         map[ActionType]refViewHandler
+	viewSearch       *ViewSearch
+	sessionStore     *SessionStore
+	lock             sync.Mutex
 }
 
 // RefListener is notified when a reference is selected
@@ -175,11 +181,13 @@ type RefListener interface {
 }
 
 // NewRefView creates a new instance
-func NewRefView(repoData RepoData, channels *Channels) *RefView {
+func NewRefView(repoData RepoData, channels *Channels, config Config) *RefView {
 	refView := &RefView{
 		channels:     channels,
+		config:       config,
 		repoData:     repoData,
 		viewPos:      NewViewPosition(),
+		jumpList:     NewJumpList(),
 		renderedRefs: newRenderedRefList(),
 		refLists: []*refList{
 			{
@@ -200,29 +208,74 @@ func NewRefView(repoData RepoData, channels *Channels) *RefView {
 			},
 		},
 		handlers: map[ActionType]refViewHandler{
-			ActionPrevLine:     moveUpRef,
-			ActionNextLine:     moveDownRef,
-			ActionPrevPage:     moveUpRefPage,
-			ActionNextPage:     moveDownRefPage,
-			ActionPrevHalfPage: moveUpRefHalfPage,
-			ActionNextHalfPage: moveDownRefHalfPage,
-			ActionScrollRight:  scrollRefViewRight,
-			ActionScrollLeft:   scrollRefViewLeft,
-			ActionFirstLine:    moveToFirstRef,
-			ActionLastLine:     moveToLastRef,
-			ActionSelect:       selectRef,
-			ActionAddFilter:    addRefFilter,
-			ActionRemoveFilter: removeRefFilter,
-			ActionCenterView:   centerRefView,
+			ActionPrevLine:                moveUpRef,
+			ActionNextLine:                moveDownRef,
+			ActionPrevPage:                moveUpRefPage,
+			ActionNextPage:                moveDownRefPage,
+			ActionPrevHalfPage:            moveUpRefHalfPage,
+			ActionNextHalfPage:            moveDownRefHalfPage,
+			ActionScrollRight:             scrollRefViewRight,
+			ActionScrollLeft:              scrollRefViewLeft,
+			ActionFirstLine:               moveToFirstRef,
+			ActionLastLine:                moveToLastRef,
+			ActionSelect:                  selectRef,
+			ActionAddFilter:               addRefFilter,
+			ActionRemoveFilter:            removeRefFilter,
+			ActionCenterView:              centerRefView,
+			ActionScrollCursorTop:         scrollRefViewCursorTop,
+			ActionScrollCursorBottom:      scrollRefViewCursorBottom,
+			ActionJumpBack:                jumpBackRef,
+			ActionJumpForward:             jumpForwardRef,
+			ActionSetUpstream:             setUpstream,
+			ActionFindStaleRemoteBranches: findStaleRemoteBranches,
+			ActionPruneRemoteBranches:     pruneRemoteBranches,
 		},
 	}
 
 	refView.viewSearch = NewViewSearch(refView, channels)
+	refView.tagFilter, _ = ParseTagFilter(config.GetString(CfTagFilter))
+	refView.tagSort, _ = ParseTagSort(config.GetString(CfTagSort))
+	config.AddOnChangeListener(CfTagFilter, refView)
+	config.AddOnChangeListener(CfTagSort, refView)
 
 	return refView
 }
 
-// Initialise loads the HEAD reference along with branches and tags
+func (refView *RefView) onConfigVariableChange(configVariable ConfigVariable) {
+	switch configVariable {
+	case CfTagFilter:
+		tagFilter, err := ParseTagFilter(refView.config.GetString(CfTagFilter))
+		if err != nil {
+			log.Errorf("Unable to parse tag filter: %v", err)
+			return
+		}
+
+		refView.lock.Lock()
+		defer refView.lock.Unlock()
+
+		refView.tagFilter = tagFilter
+	case CfTagSort:
+		tagSort, err := ParseTagSort(refView.config.GetString(CfTagSort))
+		if err != nil {
+			log.Errorf("Unable to parse tag sort: %v", err)
+			return
+		}
+
+		refView.lock.Lock()
+		defer refView.lock.Unlock()
+
+		refView.tagSort = tagSort
+	default:
+		return
+	}
+
+	refView.generateRenderedRefs()
+	refView.channels.UpdateDisplay()
+}
+
+// Initialise loads the HEAD reference along with branches and tags.
+// Any ref groups, filters and selected ref saved in the previous session are restored.
+// If no ref was saved then the configured default branch is selected instead of HEAD
 func (refView *RefView) Initialise() (err error) {
 	log.Info("Initialising RefView")
 
@@ -230,6 +283,23 @@ func (refView *RefView) Initialise() (err error) {
 		return
 	}
 
+	sessionState := NewSessionState()
+	if refView.sessionStore != nil {
+		sessionState = refView.sessionStore.State()
+		refView.restoreSessionState(sessionState)
+		refView.sessionStore.RegisterSessionStateProvider(refView)
+	}
+
+	head := refView.repoData.Head()
+	startRefName := sessionState.refName
+	if startRefName == "" {
+		startRefName = refView.config.GetString(CfDefaultBranch)
+	}
+
+	if isRefNamed(head, startRefName) {
+		startRefName = ""
+	}
+
 	refView.repoData.LoadRefs(func(refs []Ref) (err error) {
 		log.Debug("Refs loaded")
 		refView.lock.Lock()
@@ -247,6 +317,19 @@ func (refView *RefView) Initialise() (err error) {
 			}
 		}
 
+		if startRefName != "" {
+			if renderedRefIndex, found := refView.findRenderedRef(startRefName); found {
+				activeRowIndex = renderedRefIndex
+				startRef := renderedRefs[renderedRefIndex].ref
+
+				log.Debugf("Selecting start ref %v", startRef.Name())
+				err = refView.notifyRefListeners(startRef)
+			} else {
+				log.Warnf("Unable to find start ref %v. Selecting HEAD", startRefName)
+				err = refView.notifyRefListeners(refView.repoData.Head())
+			}
+		}
+
 		refView.viewPos.SetActiveRowIndex(activeRowIndex)
 		refView.channels.UpdateDisplay()
 
@@ -256,15 +339,81 @@ func (refView *RefView) Initialise() (err error) {
 	})
 
 	refView.generateRenderedRefs()
-	head := refView.repoData.Head()
 
-	err = refView.notifyRefListeners(head)
+	if startRefName == "" {
+		err = refView.notifyRefListeners(head)
+	}
 
 	return
 }
 
+// SetSessionStore sets the store the state of this view is restored from and saved to
+func (refView *RefView) SetSessionStore(sessionStore *SessionStore) {
+	refView.sessionStore = sessionStore
+}
+
+func (refView *RefView) restoreSessionState(sessionState *SessionState) {
+	refView.lock.Lock()
+	defer refView.lock.Unlock()
+
+	for _, refList := range refView.refLists {
+		if expanded, exists := sessionState.RefGroupExpanded(refList.name); exists {
+			refList.expanded = expanded
+		}
+	}
+
+	for _, query := range sessionState.refFilters {
+		refFilter, errors := CreateRefFilter(query)
+		if len(errors) > 0 {
+			log.Warnf("Unable to restore ref filter %v: %v", query, errors)
+			continue
+		}
+
+		refView.renderedRefs.AddChild(newFilteredRenderedRefList(refFilter))
+		refView.refFilterQueries = append(refView.refFilterQueries, query)
+	}
+}
+
+// SaveSessionState records the expanded ref groups and applied ref filters
+func (refView *RefView) SaveSessionState(sessionState *SessionState) {
+	refView.lock.Lock()
+	defer refView.lock.Unlock()
+
+	for _, refList := range refView.refLists {
+		sessionState.refGroups[refList.name] = refList.expanded
+	}
+
+	sessionState.refFilters = append(sessionState.refFilters, refView.refFilterQueries...)
+}
+
+func (refView *RefView) findRenderedRef(refName string) (renderedRefIndex uint, found bool) {
+	for index, renderedRef := range refView.renderedRefs.RenderedRefs() {
+		if isRefNamed(renderedRef.ref, refName) {
+			return uint(index), true
+		}
+	}
+
+	return
+}
+
+func (refView *RefView) selectedRenderedRef() *RenderedRef {
+	renderedRefs := refView.renderedRefs.RenderedRefs()
+	activeRowIndex := refView.viewPos.ActiveRowIndex()
+
+	if activeRowIndex >= uint(len(renderedRefs)) {
+		return nil
+	}
+
+	return renderedRefs[activeRowIndex]
+}
+
+// isRefNamed returns true if the full name or shorthand of the ref matches the provided name
+func isRefNamed(ref Ref, refName string) bool {
+	return ref != nil && refName != "" && (ref.Name() == refName || ref.Shorthand() == refName)
+}
+
 func getDetachedHeadDisplayValue(oid *Oid) string {
-	return fmt.Sprintf("HEAD detached at %s", oid.String()[0:7])
+	return fmt.Sprintf("HEAD detached at %s", oid.ShortID())
 }
 
 func isSelectableRenderedRef(renderedRefType RenderedRefType) bool {
@@ -289,6 +438,8 @@ func (refView *RefView) notifyRefListeners(ref Ref) (err error) {
 	refListeners := append([]RefListener(nil), refView.refListeners...)
 
 	go func() {
+		defer RecoverPanic()
+
 		log.Debugf("Notifying RefListeners of selected ref %v", ref.Name())
 
 		for _, refListener := range refListeners {
@@ -355,7 +506,7 @@ func (refView *RefView) Render(win RenderWindow) (err error) {
 	renderedRefNum := uint(len(renderedRefs))
 	rows := win.Rows() - 2
 	viewPos := refView.viewPos
-	viewPos.DetermineViewStartRow(rows, renderedRefNum)
+	viewPos.DetermineViewStartRow(rows, renderedRefNum, uint(refView.config.GetInt(CfScrollOff)))
 	refIndex := viewPos.ViewStartRowIndex()
 	startColumn := viewPos.ViewStartColumn()
 
@@ -416,11 +567,29 @@ func (refView *RefView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
 		{action: ActionSelect, message: "Select"},
 		{action: ActionFilterPrompt, message: "Add Filter"},
 		{action: ActionRemoveFilter, message: "Remove Filter"},
+		{action: ActionSetUpstreamPrompt, message: "Set Upstream"},
+		{action: ActionFindStaleRemoteBranches, message: "Prune"},
 	})
 
 	return
 }
 
+// RenderStatusBar adds the number of applied filters and the position of the selected ref to the status bar
+func (refView *RefView) RenderStatusBar(statusBarSegments *StatusBarSegments) (err error) {
+	refView.lock.Lock()
+	defer refView.lock.Unlock()
+
+	statusBarSegments.Add(SegmentMode, "Refs")
+
+	if filters := refView.renderedRefs.Children(); filters > 0 {
+		statusBarSegments.Add(SegmentFilter, "%v", filterSegmentText(filters))
+	}
+
+	statusBarSegments.AddPosition(refView.viewPos.ActiveRowIndex(), uint(len(refView.renderedRefs.RenderedRefs())))
+
+	return
+}
+
 func (refView *RefView) renderFooter(win RenderWindow, selectedRenderedRef *RenderedRef) (err error) {
 	var footer string
 
@@ -458,12 +627,18 @@ func (refView *RefView) renderFooter(win RenderWindow, selectedRenderedRef *Rend
 		case RvTagGroup:
 			if tags, loading := refView.repoData.Tags(); loading && len(tags) == 0 {
 				footer = "Tags: Loading"
+			} else if refView.tagFilter != nil {
+				footer = fmt.Sprintf("Tags: %v of %v matching %v", len(refView.tagFilter.FilterTags(tags)), len(tags), refView.tagFilter.pattern)
 			} else {
 				footer = fmt.Sprintf("Tags: %v", len(tags))
 			}
 		case RvTag:
 			tags, _ := refView.repoData.Tags()
-			footer = fmt.Sprintf("Tag %v of %v", selectedRenderedRef.refNum, len(tags))
+			footer = fmt.Sprintf("Tag %v of %v", selectedRenderedRef.refNum, len(refView.tagFilter.FilterTags(tags)))
+
+			if tag, isTag := selectedRenderedRef.ref.(*Tag); isTag {
+				footer += refView.tagDetails(tag)
+			}
 		}
 	}
 
@@ -476,6 +651,12 @@ func (refView *RefView) renderFooter(win RenderWindow, selectedRenderedRef *Rend
 
 func (refView *RefView) generateRenderedRefs() {
 	log.Debug("Generating Rendered Refs")
+
+	var selectedRefName string
+	if renderedRef := refView.selectedRenderedRef(); renderedRef != nil && renderedRef.ref != nil {
+		selectedRefName = renderedRef.ref.Name()
+	}
+
 	refView.renderedRefs.Clear()
 	renderedRefs := refView.renderedRefs
 
@@ -506,7 +687,10 @@ func (refView *RefView) generateRenderedRefs() {
 	viewPos := refView.viewPos
 	renderedRefNum := uint(len(renderedRefs.RenderedRefs()))
 
-	if viewPos.ActiveRowIndex() >= renderedRefNum {
+	// Keep the selected ref selected when refs have been added or removed above it
+	if renderedRefIndex, found := refView.findRenderedRef(selectedRefName); found {
+		viewPos.SetActiveRowIndex(renderedRefIndex)
+	} else if viewPos.ActiveRowIndex() >= renderedRefNum {
 		viewPos.SetActiveRowIndex(renderedRefNum - 1)
 	} else {
 		renderedRef := renderedRefs.RenderedRefs()[viewPos.ActiveRowIndex()]
@@ -578,6 +762,21 @@ func generateBranches(refView *RefView, refList *refList, renderedRefs renderedR
 	}
 }
 
+// tagDetails returns the tagger and date of annotated tags and the date of lightweight tags
+func (refView *RefView) tagDetails(tag *Tag) string {
+	if tag.date.IsZero() {
+		return ""
+	}
+
+	date := NewDateFormatter(refView.config).Format(tag.date)
+
+	if tag.annotated && tag.tagger != "" {
+		return fmt.Sprintf(" - tagged by %v on %v", tag.tagger, date)
+	}
+
+	return fmt.Sprintf(" - %v", date)
+}
+
 func generateTags(refView *RefView, refList *refList, renderedRefs renderedRefSet) {
 	tags, loading := refView.repoData.Tags()
 
@@ -590,7 +789,7 @@ func generateTags(refView *RefView, refList *refList, renderedRefs renderedRefSe
 		return
 	}
 
-	for tagIndex, tag := range tags {
+	for tagIndex, tag := range refView.tagSort.SortTags(refView.tagFilter.FilterTags(tags)) {
 		renderedRefs.Add(&RenderedRef{
 			value:           fmt.Sprintf("   %s", tag.Shorthand()),
 			ref:             tag,
@@ -644,6 +843,44 @@ func (refView *RefView) ViewID() ViewID {
 	return ViewRef
 }
 
+// Loading returns true if refs are still being loaded
+func (refView *RefView) Loading() bool {
+	refView.lock.Lock()
+	defer refView.lock.Unlock()
+
+	for _, renderedRef := range refView.renderedRefs.RenderedRefs() {
+		if renderedRef.renderedRefType == RvLoading {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ExternalCommandContext returns the branch and commit of the selected ref
+func (refView *RefView) ExternalCommandContext() map[string]string {
+	refView.lock.Lock()
+	defer refView.lock.Unlock()
+
+	context := map[string]string{}
+
+	renderedRefs := refView.renderedRefs.RenderedRefs()
+	activeRowIndex := refView.viewPos.ActiveRowIndex()
+
+	if activeRowIndex >= uint(len(renderedRefs)) || renderedRefs[activeRowIndex].ref == nil {
+		return context
+	}
+
+	ref := renderedRefs[activeRowIndex].ref
+	context[ecCommit] = ref.Oid().String()
+
+	if _, isBranch := ref.(Branch); isBranch {
+		context[ecBranch] = ref.Shorthand()
+	}
+
+	return context
+}
+
 // ViewPos returns the current cursor position in the view
 func (refView *RefView) ViewPos() ViewPos {
 	return refView.viewPos
@@ -658,6 +895,7 @@ func (refView *RefView) OnSearchMatch(startPos ViewPos, matchLineIndex uint) {
 	renderedRef := renderedRefs[matchLineIndex]
 
 	if isSelectableRenderedRef(renderedRef.renderedRefType) {
+		refView.jumpList.Record(refView.jumpPosition())
 		refView.viewPos.SetActiveRowIndex(matchLineIndex)
 	} else {
 		log.Debugf("Unable to select search match at index %v as it is not a selectable type", matchLineIndex)
@@ -740,6 +978,35 @@ func (refView *RefView) HandleAction(action Action) (err error) {
 }
 
 func moveUpRef(refView *RefView, action Action) (err error) {
+	moved := false
+
+	for refNum := action.MoveCount(); refNum > 0 && refView.moveToPrevRef(); refNum-- {
+		moved = true
+	}
+
+	if moved {
+		refView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveDownRef(refView *RefView, action Action) (err error) {
+	moved := false
+
+	for refNum := action.MoveCount(); refNum > 0 && refView.moveToNextRef(); refNum-- {
+		moved = true
+	}
+
+	if moved {
+		refView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+// moveToPrevRef moves the cursor to the previous selectable ref and returns true if it moved
+func (refView *RefView) moveToPrevRef() (moved bool) {
 	viewPos := refView.viewPos
 
 	if viewPos.ActiveRowIndex() == 0 {
@@ -765,7 +1032,7 @@ func moveUpRef(refView *RefView, action Action) (err error) {
 	renderedRef := renderedRefs[activeRowIndex]
 	if isSelectableRenderedRef(renderedRef.renderedRefType) {
 		viewPos.SetActiveRowIndex(activeRowIndex)
-		refView.channels.UpdateDisplay()
+		moved = true
 	} else {
 		log.Debug("No valid ref entry to move to")
 	}
@@ -773,7 +1040,8 @@ func moveUpRef(refView *RefView, action Action) (err error) {
 	return
 }
 
-func moveDownRef(refView *RefView, action Action) (err error) {
+// moveToNextRef moves the cursor to the next selectable ref and returns true if it moved
+func (refView *RefView) moveToNextRef() (moved bool) {
 	renderedRefs := refView.renderedRefs.RenderedRefs()
 	renderedRefNum := uint(len(renderedRefs))
 	viewPos := refView.viewPos
@@ -800,7 +1068,7 @@ func moveDownRef(refView *RefView, action Action) (err error) {
 	renderedRef := renderedRefs[activeRowIndex]
 	if isSelectableRenderedRef(renderedRef.renderedRefType) {
 		viewPos.SetActiveRowIndex(activeRowIndex)
-		refView.channels.UpdateDisplay()
+		moved = true
 	} else {
 		log.Debug("No valid ref entry to move to")
 	}
@@ -809,11 +1077,11 @@ func moveDownRef(refView *RefView, action Action) (err error) {
 }
 
 func moveUpRefPage(refView *RefView, action Action) (err error) {
-	pageSize := refView.viewDimension.rows - 2
+	pageSize := pageRows(refView.viewDimension) * action.MoveCount()
 	viewPos := refView.viewPos
 
 	for viewPos.ActiveRowIndex() > 0 && pageSize > 0 {
-		if err = moveUpRef(refView, action); err != nil {
+		if err = moveUpRef(refView, Action{ActionType: action.ActionType}); err != nil {
 			break
 		} else {
 			pageSize--
@@ -826,11 +1094,11 @@ func moveUpRefPage(refView *RefView, action Action) (err error) {
 func moveDownRefPage(refView *RefView, action Action) (err error) {
 	renderedRefs := refView.renderedRefs.RenderedRefs()
 	renderedRefNum := uint(len(renderedRefs))
-	pageSize := refView.viewDimension.rows - 2
+	pageSize := pageRows(refView.viewDimension) * action.MoveCount()
 	viewPos := refView.viewPos
 
 	for viewPos.ActiveRowIndex()+1 < renderedRefNum && pageSize > 0 {
-		if err = moveDownRef(refView, action); err != nil {
+		if err = moveDownRef(refView, Action{ActionType: action.ActionType}); err != nil {
 			break
 		} else {
 			pageSize--
@@ -841,11 +1109,11 @@ func moveDownRefPage(refView *RefView, action Action) (err error) {
 }
 
 func moveUpRefHalfPage(refView *RefView, action Action) (err error) {
-	halfPageSize := refView.viewDimension.rows/2 - 2
+	halfPageSize := halfPageRows(pageRows(refView.viewDimension)) * action.MoveCount()
 	viewPos := refView.viewPos
 
 	for viewPos.ActiveRowIndex() > 0 && halfPageSize > 0 {
-		if err = moveUpRef(refView, action); err != nil {
+		if err = moveUpRef(refView, Action{ActionType: action.ActionType}); err != nil {
 			break
 		} else {
 			halfPageSize--
@@ -858,11 +1126,11 @@ func moveUpRefHalfPage(refView *RefView, action Action) (err error) {
 func moveDownRefHalfPage(refView *RefView, action Action) (err error) {
 	renderedRefs := refView.renderedRefs.RenderedRefs()
 	renderedRefNum := uint(len(renderedRefs))
-	halfPageSize := refView.viewDimension.rows/2 - 2
+	halfPageSize := halfPageRows(pageRows(refView.viewDimension)) * action.MoveCount()
 	viewPos := refView.viewPos
 
 	for viewPos.ActiveRowIndex()+1 < renderedRefNum && halfPageSize > 0 {
-		if err = moveDownRef(refView, action); err != nil {
+		if err = moveDownRef(refView, Action{ActionType: action.ActionType}); err != nil {
 			break
 		} else {
 			halfPageSize--
@@ -894,6 +1162,7 @@ func scrollRefViewLeft(refView *RefView, action Action) (err error) {
 
 func moveToFirstRef(refView *RefView, action Action) (err error) {
 	viewPos := refView.viewPos
+	refView.jumpList.Record(refView.jumpPosition())
 
 	if viewPos.MoveToFirstLine() {
 		log.Debugf("Moving to first ref")
@@ -907,6 +1176,7 @@ func moveToLastRef(refView *RefView, action Action) (err error) {
 	viewPos := refView.viewPos
 	renderedRefs := refView.renderedRefs.RenderedRefs()
 	renderedRefNum := uint(len(renderedRefs))
+	refView.jumpList.Record(refView.jumpPosition())
 
 	if viewPos.MoveToLastLine(renderedRefNum) {
 		log.Debugf("Moving to last ref")
@@ -962,6 +1232,7 @@ func addRefFilter(refView *RefView, action Action) (err error) {
 
 	beforeRenderedRefNum := len(refView.renderedRefs.RenderedRefs())
 	refView.renderedRefs.AddChild(newFilteredRenderedRefList(refFilter))
+	refView.refFilterQueries = append(refView.refFilterQueries, query)
 	afterRenderedRefNum := len(refView.renderedRefs.RenderedRefs())
 
 	if afterRenderedRefNum < beforeRenderedRefNum {
@@ -975,6 +1246,7 @@ func addRefFilter(refView *RefView, action Action) (err error) {
 
 func removeRefFilter(refView *RefView, action Action) (err error) {
 	if refView.renderedRefs.RemoveChild() {
+		refView.refFilterQueries = refView.refFilterQueries[:len(refView.refFilterQueries)-1]
 		refView.channels.ReportStatus("Removed ref filter")
 	} else {
 		refView.channels.ReportStatus("No ref filter applied to remove")
@@ -986,10 +1258,161 @@ func removeRefFilter(refView *RefView, action Action) (err error) {
 func centerRefView(refView *RefView, action Action) (err error) {
 	viewPos := refView.viewPos
 
-	if viewPos.CenterActiveRow(refView.viewDimension.rows - 2) {
+	if viewPos.CenterActiveRow(pageRows(refView.viewDimension)) {
 		log.Debug("Centering RefView")
 		refView.channels.UpdateDisplay()
 	}
 
 	return
 }
+
+func scrollRefViewCursorTop(refView *RefView, action Action) (err error) {
+	viewPos := refView.viewPos
+
+	if viewPos.ScrollActiveRowTop() {
+		log.Debug("Scrolling RefView so selected row is at the top")
+		refView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func scrollRefViewCursorBottom(refView *RefView, action Action) (err error) {
+	viewPos := refView.viewPos
+
+	if viewPos.ScrollActiveRowBottom(pageRows(refView.viewDimension)) {
+		log.Debug("Scrolling RefView so selected row is at the bottom")
+		refView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func setUpstream(refView *RefView, action Action) (err error) {
+	if !(len(action.Args) > 0) {
+		return fmt.Errorf("Expected remote branch argument")
+	}
+
+	remoteBranchName, ok := action.Args[0].(string)
+	if !ok {
+		return fmt.Errorf("Expected remote branch argument to have type string")
+	}
+
+	// Refs may have been reloaded while the upstream was being entered
+	renderedRef := refView.selectedRenderedRef()
+	if renderedRef == nil {
+		refView.channels.ReportWarning("No branch selected")
+		return
+	}
+
+	localBranch, isLocalBranch := renderedRef.ref.(*LocalBranch)
+	if !isLocalBranch {
+		refView.channels.ReportWarning("Upstream can only be set for a local branch")
+		return
+	}
+
+	if err = refView.repoData.SetUpstream(localBranch, remoteBranchName); err != nil {
+		return
+	}
+
+	refView.channels.ReportSuccess("Branch %v set up to track %v", localBranch.Shorthand(), remoteBranchName)
+
+	return
+}
+
+func findStaleRemoteBranches(refView *RefView, action Action) (err error) {
+	refView.channels.ReportStatus("Checking remotes for stale branches...")
+
+	go func() {
+		defer RecoverPanic()
+
+		staleBranches, err := refView.repoData.StaleRemoteBranches()
+		if err != nil {
+			refView.channels.ReportError(err)
+			return
+		}
+
+		if len(staleBranches) == 0 {
+			refView.channels.ReportStatus("No stale remote branches found")
+			return
+		}
+
+		refView.channels.DoAction(Action{
+			ActionType: ActionPruneRemoteBranchesPrompt,
+			Args:       []interface{}{staleBranches},
+		})
+	}()
+
+	return
+}
+
+func pruneRemoteBranches(refView *RefView, action Action) (err error) {
+	if !(len(action.Args) > 0) {
+		return fmt.Errorf("Expected stale remote branches argument")
+	}
+
+	staleBranches, ok := action.Args[0].([]Branch)
+	if !ok {
+		return fmt.Errorf("Expected stale remote branches argument to have type []Branch")
+	}
+
+	if err = refView.repoData.PruneRemoteBranches(staleBranches); err != nil {
+		return
+	}
+
+	refView.channels.ReportSuccess("Pruned %v stale remote branches", len(staleBranches))
+
+	return
+}
+
+func jumpBackRef(refView *RefView, action Action) (err error) {
+	if position, exists := refView.jumpList.Back(refView.jumpPosition()); exists {
+		refView.jumpToRef(position)
+	}
+
+	return
+}
+
+func jumpForwardRef(refView *RefView, action Action) (err error) {
+	if position, exists := refView.jumpList.Forward(); exists {
+		refView.jumpToRef(position)
+	}
+
+	return
+}
+
+// jumpPosition identifies the selected ref by its name so that jumping
+// returns to the same ref if refs have been added or removed
+func (refView *RefView) jumpPosition() JumpPosition {
+	position := JumpPosition{RowIndex: refView.viewPos.ActiveRowIndex()}
+
+	if renderedRef := refView.selectedRenderedRef(); renderedRef != nil && renderedRef.ref != nil {
+		position.ID = renderedRef.ref.Name()
+	}
+
+	return position
+}
+
+func (refView *RefView) jumpToRef(position JumpPosition) {
+	renderedRefNum := uint(len(refView.renderedRefs.RenderedRefs()))
+	if renderedRefNum == 0 {
+		return
+	}
+
+	rowIndex := MinUint(position.RowIndex, renderedRefNum-1)
+
+	if position.ID != "" {
+		renderedRefIndex, found := refView.findRenderedRef(position.ID)
+		if !found {
+			refView.channels.ReportStatus("Ref %v no longer exists", position.ID)
+			return
+		}
+
+		rowIndex = renderedRefIndex
+	}
+
+	log.Debugf("Jumping to ref at index %v", rowIndex)
+	refView.viewPos.SetActiveRowIndex(rowIndex)
+	refView.viewPos.CenterActiveRow(pageRows(refView.viewDimension))
+	refView.channels.UpdateDisplay()
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// renderStats records the render times of all window views
+var renderStats = NewRenderStats()
+
+// ViewRenderStats contains render time information for a view
+type ViewRenderStats struct {
+	viewID        ViewID
+	renderNum     uint
+	lastDuration  time.Duration
+	maxDuration   time.Duration
+	totalDuration time.Duration
+}
+
+// AverageDuration returns the mean render time of the view
+func (viewRenderStats ViewRenderStats) AverageDuration() time.Duration {
+	if viewRenderStats.renderNum == 0 {
+		return 0
+	}
+
+	return viewRenderStats.totalDuration / time.Duration(viewRenderStats.renderNum)
+}
+
+// RenderStats records how long each view takes to render
+type RenderStats struct {
+	stats map[ViewID]*ViewRenderStats
+	lock  sync.Mutex
+}
+
+// NewRenderStats creates a new instance
+func NewRenderStats() *RenderStats {
+	return &RenderStats{
+		stats: make(map[ViewID]*ViewRenderStats),
+	}
+}
+
+// Record adds the render time of a view
+func (renderStats *RenderStats) Record(viewID ViewID, duration time.Duration) {
+	renderStats.lock.Lock()
+	defer renderStats.lock.Unlock()
+
+	viewRenderStats, ok := renderStats.stats[viewID]
+	if !ok {
+		viewRenderStats = &ViewRenderStats{viewID: viewID}
+		renderStats.stats[viewID] = viewRenderStats
+	}
+
+	viewRenderStats.renderNum++
+	viewRenderStats.lastDuration = duration
+	viewRenderStats.totalDuration += duration
+
+	if duration > viewRenderStats.maxDuration {
+		viewRenderStats.maxDuration = duration
+	}
+}
+
+// Stats returns a copy of the recorded render times ordered by view id
+func (renderStats *RenderStats) Stats() (stats []ViewRenderStats) {
+	renderStats.lock.Lock()
+	defer renderStats.lock.Unlock()
+
+	for _, viewRenderStats := range renderStats.stats {
+		stats = append(stats, *viewRenderStats)
+	}
+
+	sort.Sort(viewRenderStatsByViewID(stats))
+
+	return
+}
+
+type viewRenderStatsByViewID []ViewRenderStats
+
+func (stats viewRenderStatsByViewID) Len() int {
+	return len(stats)
+}
+
+func (stats viewRenderStatsByViewID) Less(i, j int) bool {
+	return stats[i].viewID < stats[j].viewID
+}
+
+func (stats viewRenderStatsByViewID) Swap(i, j int) {
+	stats[i], stats[j] = stats[j], stats[i]
+}
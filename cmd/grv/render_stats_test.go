@@ -0,0 +1,47 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRenderTimesAreAggregatedPerView(t *testing.T) {
+	renderStats := NewRenderStats()
+
+	renderStats.Record(ViewDiff, 30*time.Millisecond)
+	renderStats.Record(ViewCommit, 10*time.Millisecond)
+	renderStats.Record(ViewCommit, 20*time.Millisecond)
+	renderStats.Record(ViewCommit, 6*time.Millisecond)
+
+	stats := renderStats.Stats()
+
+	if len(stats) != 2 {
+		t.Fatalf("View number does not match expected value. Expected: 2, Actual: %v", len(stats))
+	}
+
+	expectedCommitViewStats := ViewRenderStats{
+		viewID:        ViewCommit,
+		renderNum:     3,
+		lastDuration:  6 * time.Millisecond,
+		maxDuration:   20 * time.Millisecond,
+		totalDuration: 36 * time.Millisecond,
+	}
+
+	if stats[0] != expectedCommitViewStats {
+		t.Errorf("CommitView stats do not match expected value. Expected: %+v, Actual: %+v", expectedCommitViewStats, stats[0])
+	}
+
+	if averageDuration := stats[0].AverageDuration(); averageDuration != 12*time.Millisecond {
+		t.Errorf("Average duration does not match expected value. Expected: 12ms, Actual: %v", averageDuration)
+	}
+
+	if stats[1].viewID != ViewDiff {
+		t.Errorf("Expected DiffView stats to be ordered after CommitView stats")
+	}
+}
+
+func TestAverageDurationIsZeroWhenNoRendersRecorded(t *testing.T) {
+	if averageDuration := (ViewRenderStats{}).AverageDuration(); averageDuration != 0 {
+		t.Errorf("Average duration does not match expected value. Expected: 0, Actual: %v", averageDuration)
+	}
+}
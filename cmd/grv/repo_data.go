@@ -2,10 +2,10 @@ package main
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
 	"reflect"
+	"runtime"
 	"sync"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 	slice "github.com/bradfitz/slice"
@@ -15,6 +15,8 @@ const (
 	// GitRepositoryDirectoryName is the name of the git directory in a git repository
 	GitRepositoryDirectoryName = ".git"
 	updatedRefChannelSize      = 256
+	rdFilterRefreshInterval    = 500 * time.Millisecond
+	rdFilterWaitInterval       = 50 * time.Millisecond
 )
 
 // OnRefsLoaded is called when all refs have been loaded and processed
@@ -54,6 +56,7 @@ type RefStateListener interface {
 type RepoData interface {
 	EventListener
 	Path() string
+	Workdir() string
 	LoadHead() error
 	LoadRefs(OnRefsLoaded)
 	LoadCommits(Ref) error
@@ -69,7 +72,33 @@ type RepoData interface {
 	CommitByOid(oidStr string) (*Commit, error)
 	AddCommitFilter(Ref, *CommitFilter) error
 	RemoveCommitFilter(Ref) error
-	DiffCommit(commit *Commit) (*Diff, error)
+	SetUpstream(localBranch *LocalBranch, remoteBranchName string) error
+	StaleRemoteBranches() ([]Branch, error)
+	PruneRemoteBranches([]Branch) error
+	Stashes() ([]*StashEntry, error)
+	SaveStash(message string) error
+	ApplyStash(stashEntry *StashEntry, pop bool) error
+	DropStash(stashEntry *StashEntry) error
+	RepositoryState() string
+	AmendCommit() error
+	ApplyToIndex(patch string) error
+	DiscardChanges(statusType StatusType, statusEntry *StatusEntry, backup bool) error
+	StageFile(path string) error
+	AddIgnorePattern(pattern string) error
+	RemoteBranchContaining(oid *Oid) (Branch, bool)
+	StartBisect() error
+	MarkBisectCommit(commit *Commit, bisectTerm BisectTerm) (*BisectStatus, error)
+	ResetBisect() error
+	BisectStatus() *BisectStatus
+	Conflicts() ([]*Conflict, error)
+	ConflictContent(*Conflict) ([]ConflictLine, error)
+	ResolveConflict(*Conflict, ConflictResolution) error
+	Blame(revision, path string) ([]*BlameLine, error)
+	LineHistory(LineRange) ([]byte, error)
+	CommitRange(commitRange string) (<-chan *Commit, error)
+	PickaxeMatches(*Commit, *Pickaxe) (bool, error)
+	DiffCommit(commit *Commit, diffBase DiffBase, diffTask *DiffTask) (*Diff, error)
+	DiffMergeCommit(commit *Commit, parentNumber uint, diffTask *DiffTask) (*Diff, error)
 	DiffFile(statusType StatusType, path string) (*Diff, error)
 	DiffStage(statusType StatusType) (*Diff, error)
 	LoadStatus() (err error)
@@ -77,6 +106,12 @@ type RepoData interface {
 	RegisterStatusListener(StatusListener)
 	RegisterRefStateListener(RefStateListener)
 	RegisterCommitSetListener(CommitSetListener)
+	Marks() *MarkStore
+	Session() *SessionStore
+	CommitMetadata(commit *Commit) (*CommitMetadata, bool)
+	LoadCommitMetadata(commits []*Commit)
+	CommitSetStates() map[string]CommitSetState
+	PullRequest(commit *Commit) (*PullRequest, error)
 }
 
 type commitSet interface {
@@ -86,40 +121,105 @@ type commitSet interface {
 	SetLoading(loading bool)
 	CommitSetState() CommitSetState
 	Update([]*Commit)
+	SetCommitLimit(limit uint)
 	Clone() commitSet
 }
 
 type filteredCommitSet struct {
-	commits      []*Commit
+	commits      *commitList
 	loading      bool
 	child        commitSet
 	commitFilter *CommitFilter
+	initialising bool
+	stopped      bool
 	lock         sync.Mutex
 }
 
-func newBaseFilteredCommitSet() *filteredCommitSet {
-	return newFilteredCommitSet(nil, nil)
+func newBaseFilteredCommitSet(commits *commitList) *filteredCommitSet {
+	return newFilteredCommitSet(nil, nil, commits)
 }
 
-func newFilteredCommitSet(child commitSet, commitFilter *CommitFilter) *filteredCommitSet {
+func newFilteredCommitSet(child commitSet, commitFilter *CommitFilter, commits *commitList) *filteredCommitSet {
 	return &filteredCommitSet{
-		commits:      make([]*Commit, 0),
+		commits:      commits,
 		child:        child,
 		commitFilter: commitFilter,
 	}
 }
 
+// initialiseFromCommitSet adds the commits of the child which match the filter.
+// The filter is evaluated without holding the lock so that matching commits can be
+// displayed while slow filters are still being applied. Commits added to the child
+// during initialisation are filtered here rather than by AddCommit to preserve their order
 func (filteredCommitSet *filteredCommitSet) initialiseFromCommitSet() {
 	filteredCommitSet.lock.Lock()
-	defer filteredCommitSet.lock.Unlock()
+	if !filteredCommitSet.hasChild() {
+		filteredCommitSet.lock.Unlock()
+		return
+	}
 
-	if filteredCommitSet.hasChild() {
-		for commit := range filteredCommitSet.child.CommitStream() {
-			filteredCommitSet.addCommitIfFilterMatches(commit)
+	child := filteredCommitSet.child
+	filteredCommitSet.initialising = true
+	filteredCommitSet.lock.Unlock()
+
+	var index uint
+
+	for !filteredCommitSet.isStopped() {
+		commit := child.Commit(index)
+
+		if commit == nil && isInitialisingCommitSet(child) {
+			time.Sleep(rdFilterWaitInterval)
+			continue
 		}
+
+		if commit == nil {
+			filteredCommitSet.lock.Lock()
+			if commit = child.Commit(index); commit == nil {
+				filteredCommitSet.initialising = false
+				filteredCommitSet.lock.Unlock()
+				return
+			}
+			filteredCommitSet.lock.Unlock()
+		}
+
+		if filteredCommitSet.commitFilter.MatchesFilter(commit) {
+			filteredCommitSet.lock.Lock()
+			filteredCommitSet.commits.Append(commit)
+			filteredCommitSet.lock.Unlock()
+		}
+
+		index++
 	}
 }
 
+func (filteredCommitSet *filteredCommitSet) isInitialising() bool {
+	filteredCommitSet.lock.Lock()
+	defer filteredCommitSet.lock.Unlock()
+
+	return filteredCommitSet.initialising
+}
+
+// isInitialisingCommitSet returns true if the commit set is a filter which is still being applied
+func isInitialisingCommitSet(commitSet commitSet) bool {
+	filteredCommitSet, ok := commitSet.(*filteredCommitSet)
+	return ok && filteredCommitSet.isInitialising()
+}
+
+// stop ends initialisation of a filter which has been removed
+func (filteredCommitSet *filteredCommitSet) stop() {
+	filteredCommitSet.lock.Lock()
+	defer filteredCommitSet.lock.Unlock()
+
+	filteredCommitSet.stopped = true
+}
+
+func (filteredCommitSet *filteredCommitSet) isStopped() bool {
+	filteredCommitSet.lock.Lock()
+	defer filteredCommitSet.lock.Unlock()
+
+	return filteredCommitSet.stopped
+}
+
 // CommitSet returns the child commit set of this filter
 func (filteredCommitSet *filteredCommitSet) Child() commitSet {
 	filteredCommitSet.lock.Lock()
@@ -150,9 +250,11 @@ func (filteredCommitSet *filteredCommitSet) AddCommit(commit *Commit) (err error
 			return
 		}
 
-		filteredCommitSet.addCommitIfFilterMatches(commit)
+		if !filteredCommitSet.initialising {
+			filteredCommitSet.addCommitIfFilterMatches(commit)
+		}
 	} else if filteredCommitSet.loading {
-		filteredCommitSet.commits = append(filteredCommitSet.commits, commit)
+		filteredCommitSet.commits.Append(commit)
 	} else {
 		err = fmt.Errorf("Cannot add commit when CommitSet is not in loading state")
 	}
@@ -162,7 +264,7 @@ func (filteredCommitSet *filteredCommitSet) AddCommit(commit *Commit) (err error
 
 func (filteredCommitSet *filteredCommitSet) addCommitIfFilterMatches(commit *Commit) {
 	if filteredCommitSet.commitFilter.MatchesFilter(commit) {
-		filteredCommitSet.commits = append(filteredCommitSet.commits, commit)
+		filteredCommitSet.commits.Append(commit)
 	}
 }
 
@@ -171,11 +273,7 @@ func (filteredCommitSet *filteredCommitSet) Commit(index uint) (commit *Commit)
 	filteredCommitSet.lock.Lock()
 	defer filteredCommitSet.lock.Unlock()
 
-	if index < uint(len(filteredCommitSet.commits)) {
-		commit = filteredCommitSet.commits[index]
-	}
-
-	return
+	return filteredCommitSet.commits.Commit(index)
 }
 
 // CommitStream returns a channel through which all the commits in this set can be read
@@ -183,17 +281,15 @@ func (filteredCommitSet *filteredCommitSet) CommitStream() <-chan *Commit {
 	ch := make(chan *Commit)
 
 	go func() {
+		defer RecoverPanic()
 		defer close(ch)
 		var commit *Commit
-		index := 0
+		var index uint
 
 		for {
 			filteredCommitSet.lock.Lock()
 
-			length := len(filteredCommitSet.commits)
-			if index < length {
-				commit = filteredCommitSet.commits[index]
-			}
+			commit = filteredCommitSet.commits.Commit(index)
 
 			filteredCommitSet.lock.Unlock()
 
@@ -236,7 +332,7 @@ func (filteredCommitSet *filteredCommitSet) CommitSetState() CommitSetState {
 			}
 		}
 
-		commitSetState.commitNum = uint(len(filteredCommitSet.commits))
+		commitSetState.commitNum = filteredCommitSet.commits.Len()
 		commitSetState.filterState.filtersApplied++
 
 		return commitSetState
@@ -244,7 +340,7 @@ func (filteredCommitSet *filteredCommitSet) CommitSetState() CommitSetState {
 
 	return CommitSetState{
 		loading:   filteredCommitSet.loading,
-		commitNum: uint(len(filteredCommitSet.commits)),
+		commitNum: filteredCommitSet.commits.Len(),
 	}
 }
 
@@ -257,10 +353,22 @@ func (filteredCommitSet *filteredCommitSet) Update(commits []*Commit) {
 		return
 	}
 
-	filteredCommitSet.commits = commits
+	filteredCommitSet.commits.Set(commits)
 	filteredCommitSet.loading = false
 }
 
+// SetCommitLimit sets the maximum number of commits held in memory by this set and its child
+func (filteredCommitSet *filteredCommitSet) SetCommitLimit(limit uint) {
+	filteredCommitSet.lock.Lock()
+	defer filteredCommitSet.lock.Unlock()
+
+	if filteredCommitSet.hasChild() {
+		filteredCommitSet.child.SetCommitLimit(limit)
+	}
+
+	filteredCommitSet.commits.SetLimit(limit)
+}
+
 func (filteredCommitSet *filteredCommitSet) Clone() commitSet {
 	filteredCommitSet.lock.Lock()
 	defer filteredCommitSet.lock.Unlock()
@@ -270,10 +378,9 @@ func (filteredCommitSet *filteredCommitSet) Clone() commitSet {
 		child = filteredCommitSet.child.Clone()
 	}
 
-	clone := newBaseFilteredCommitSet()
+	clone := newBaseFilteredCommitSet(filteredCommitSet.commits.Clone())
 	clone.child = child
 	clone.commitFilter = filteredCommitSet.commitFilter
-	clone.commits = append([]*Commit(nil), filteredCommitSet.commits...)
 	clone.loading = filteredCommitSet.loading
 
 	return clone
@@ -597,6 +704,8 @@ func (refSet *refSet) notifyRefStateListenersRefsChanged(addedRefs, removedRefs
 	refStateListeners := append([]RefStateListener(nil), refSet.refStateListeners...)
 
 	go func() {
+		defer RecoverPanic()
+
 		log.Debugf("Notifying RefStateListeners Refs Changed - new: %v, removed: %v, updated: %v",
 			len(addedRefs), len(removedRefs), len(updatedRefs))
 
@@ -610,6 +719,8 @@ func (refSet *refSet) notifyRefStateListenersHeadChanged(oldHead, newHead Ref) {
 	refStateListeners := append([]RefStateListener(nil), refSet.refStateListeners...)
 
 	go func() {
+		defer RecoverPanic()
+
 		log.Debugf("Notifying RefStateListeners HEAD changed %v:%v -> %v:%v",
 			oldHead.Name(), oldHead.Oid(), newHead.Name(), newHead.Oid())
 
@@ -623,6 +734,8 @@ func (refSet *refSet) notifyRefStateListenersTrackingBranchesUpdated(trackingBra
 	refStateListeners := append([]RefStateListener(nil), refSet.refStateListeners...)
 
 	go func() {
+		defer RecoverPanic()
+
 		log.Debugf("Notifying RefStateListeners %v tracking branches have changed", len(trackingBranches))
 
 		for _, refStateListener := range refStateListeners {
@@ -660,6 +773,7 @@ type CommitRefs struct {
 
 type commitRefSet struct {
 	commitRefs map[*Oid]*CommitRefs
+	generation uint
 	lock       sync.Mutex
 }
 
@@ -669,21 +783,31 @@ func newCommitRefSet() *commitRefSet {
 	return commitRefSet
 }
 
-func (commitRefSet *commitRefSet) clear() {
+func (commitRefSet *commitRefSet) clear() (generation uint) {
 	commitRefSet.lock.Lock()
 	defer commitRefSet.lock.Unlock()
 
 	commitRefSet.commitRefs = make(map[*Oid]*CommitRefs)
+	commitRefSet.generation++
+
+	return commitRefSet.generation
 }
 
-func (commitRefSet *commitRefSet) addTagForCommit(commit *Commit, newTag *Tag) {
+func (commitRefSet *commitRefSet) isCurrentGeneration(generation uint) bool {
 	commitRefSet.lock.Lock()
 	defer commitRefSet.lock.Unlock()
 
-	commitRefs, ok := commitRefSet.commitRefs[commit.oid]
+	return commitRefSet.generation == generation
+}
+
+func (commitRefSet *commitRefSet) addTagForOid(oid *Oid, newTag *Tag) {
+	commitRefSet.lock.Lock()
+	defer commitRefSet.lock.Unlock()
+
+	commitRefs, ok := commitRefSet.commitRefs[oid]
 	if !ok {
 		commitRefs = &CommitRefs{}
-		commitRefSet.commitRefs[commit.oid] = commitRefs
+		commitRefSet.commitRefs[oid] = commitRefs
 	}
 
 	for _, tag := range commitRefs.tags {
@@ -732,14 +856,40 @@ func (commitRefSet *commitRefSet) refsForCommit(commit *Commit) (commitRefsCopy
 type refCommitSets struct {
 	commits            map[string]commitSet
 	commitSetListeners []CommitSetListener
+	commitLimit        uint
+	commitLoader       CommitLoader
 	channels           *Channels
 	lock               sync.Mutex
 }
 
-func newRefCommitSets(channels *Channels) *refCommitSets {
+func newRefCommitSets(channels *Channels, commitLoader CommitLoader) *refCommitSets {
 	return &refCommitSets{
-		commits:  make(map[string]commitSet),
-		channels: channels,
+		commits:      make(map[string]commitSet),
+		commitLoader: commitLoader,
+		channels:     channels,
+	}
+}
+
+func (refCommitSets *refCommitSets) newCommitList() *commitList {
+	refCommitSets.lock.Lock()
+	defer refCommitSets.lock.Unlock()
+
+	return newCommitList(refCommitSets.commitLimit, refCommitSets.commitLoader)
+}
+
+func (refCommitSets *refCommitSets) setCommitLimit(limit uint) {
+	refCommitSets.lock.Lock()
+	defer refCommitSets.lock.Unlock()
+
+	if limit == refCommitSets.commitLimit {
+		return
+	}
+
+	log.Infof("Setting commit limit to %v", limit)
+	refCommitSets.commitLimit = limit
+
+	for _, commitSet := range refCommitSets.commits {
+		commitSet.SetCommitLimit(limit)
 	}
 }
 
@@ -751,6 +901,19 @@ func (refCommitSets *refCommitSets) commitSet(ref Ref) (commitSet commitSet, exi
 	return
 }
 
+func (refCommitSets *refCommitSets) commitSetStates() (commitSetStates map[string]CommitSetState) {
+	refCommitSets.lock.Lock()
+	defer refCommitSets.lock.Unlock()
+
+	commitSetStates = make(map[string]CommitSetState)
+
+	for refName, commitSet := range refCommitSets.commits {
+		commitSetStates[refName] = commitSet.CommitSetState()
+	}
+
+	return
+}
+
 func (refCommitSets *refCommitSets) setCommitSet(ref Ref, commitSet commitSet) {
 	refCommitSets.lock.Lock()
 	defer refCommitSets.lock.Unlock()
@@ -767,13 +930,38 @@ func (refCommitSets *refCommitSets) addCommitFilter(ref Ref, commitFilter *Commi
 		return fmt.Errorf("No CommitSet exists for ref: %v", ref.Name())
 	}
 
-	filteredCommitSet := newFilteredCommitSet(commitSet, commitFilter)
+	filteredCommitSet := newFilteredCommitSet(commitSet, commitFilter, newCommitList(refCommitSets.commitLimit, refCommitSets.commitLoader))
 	refCommitSets.commits[ref.Name()] = filteredCommitSet
 
 	go func() {
+		defer RecoverPanic()
+
 		beforeState := commitSet.CommitSetState()
+
+		ticker := time.NewTicker(rdFilterRefreshInterval)
+		defer ticker.Stop()
+		done := make(chan bool)
+		defer close(done)
+
+		go func() {
+			defer RecoverPanic()
+
+			for {
+				select {
+				case <-ticker.C:
+					refCommitSets.channels.UpdateDisplay()
+				case <-done:
+					return
+				}
+			}
+		}()
+
 		filteredCommitSet.initialiseFromCommitSet()
 
+		if filteredCommitSet.isStopped() {
+			return
+		}
+
 		if !beforeState.loading {
 			afterState := filteredCommitSet.CommitSetState()
 
@@ -812,6 +1000,7 @@ func (refCommitSets *refCommitSets) removeCommitFilter(ref Ref) (err error) {
 		return
 	}
 
+	filteredCommitSet.stop()
 	refCommitSets.commits[ref.Name()] = filteredCommitSet.Child()
 	refCommitSets.channels.ReportStatus("Removed commit filter")
 
@@ -856,6 +1045,8 @@ func (refCommitSets *refCommitSets) notifyCommitSetListenersCommitSetLoaded(ref
 	commitSetListeners := append([]CommitSetListener(nil), refCommitSets.commitSetListeners...)
 
 	go func() {
+		defer RecoverPanic()
+
 		log.Debugf("Notifying CommitSetListeners commits for ref %v have loaded", ref.Name())
 
 		for _, listener := range commitSetListeners {
@@ -871,6 +1062,8 @@ func (refCommitSets *refCommitSets) notifyCommitSetListenersCommitSetUpdated(ref
 	commitSetListeners := append([]CommitSetListener(nil), refCommitSets.commitSetListeners...)
 
 	go func() {
+		defer RecoverPanic()
+
 		log.Debugf("Notifying CommitSetListeners commits for ref %v have updated", ref.Name())
 
 		for _, listener := range commitSetListeners {
@@ -960,7 +1153,15 @@ type RepositoryData struct {
 	commitRefSet   *commitRefSet
 	refCommitSets  *refCommitSets
 	statusManager  *statusManager
+	marks          *MarkStore
+	session        *SessionStore
+	workerPool     *WorkerPool
+	commitMetadata *CommitMetadataCache
+	pullRequests   *PullRequestCache
+	config         Config
 	refUpdateCh    chan *UpdatedRef
+	bisectStatus   *BisectStatus
+	bisectLock     sync.Mutex
 }
 
 // NewRepositoryData creates a new instance
@@ -969,11 +1170,17 @@ func NewRepositoryData(repoDataLoader *RepoDataLoader, channels *Channels) *Repo
 		channels:       channels,
 		repoDataLoader: repoDataLoader,
 		commitRefSet:   newCommitRefSet(),
-		refCommitSets:  newRefCommitSets(channels),
+		refCommitSets:  newRefCommitSets(channels, repoDataLoader.Commit),
 		statusManager:  newStatusManager(repoDataLoader),
+		marks:          NewMarkStore(),
+		session:        NewSessionStore(),
+		workerPool:     NewWorkerPool(runtime.NumCPU()),
 		refUpdateCh:    make(chan *UpdatedRef, updatedRefChannelSize),
 	}
 
+	repoData.commitMetadata = NewCommitMetadataCache(repoDataLoader, repoData.workerPool, channels)
+	repoData.pullRequests = NewPullRequestCache(repoDataLoader.RemoteURL)
+
 	repoData.refSet = newRefSet(repoData)
 
 	return repoData
@@ -982,6 +1189,7 @@ func NewRepositoryData(repoDataLoader *RepoDataLoader, channels *Channels) *Repo
 // Free free's any underlying resources
 func (repoData *RepositoryData) Free() {
 	close(repoData.refUpdateCh)
+	repoData.workerPool.Stop()
 	repoData.repoDataLoader.Free()
 }
 
@@ -996,6 +1204,16 @@ func (repoData *RepositoryData) Initialise(repoPath string) (err error) {
 		return
 	}
 
+	if markErr := repoData.marks.Load(repoData.Path()); markErr != nil {
+		repoData.channels.ReportError(fmt.Errorf("Unable to load marks: %v", markErr))
+	}
+
+	if sessionErr := repoData.session.Load(repoData.Path()); sessionErr != nil {
+		repoData.channels.ReportError(fmt.Errorf("Unable to load session: %v", sessionErr))
+	}
+
+	repoData.workerPool.Start()
+
 	go repoData.processUpdatedRefs()
 	repoData.RegisterRefStateListener(repoData)
 
@@ -1003,30 +1221,8 @@ func (repoData *RepositoryData) Initialise(repoPath string) (err error) {
 }
 
 func (repoData *RepositoryData) processPath(repoPath string) (processedPath string, err error) {
-	path, err := CanonicalPath(repoPath)
-	if err != nil {
-		return
-	}
-
-	for {
-		gitDirPath := filepath.Join(path, GitRepositoryDirectoryName)
-		log.Debugf("gitDirPath: %v", gitDirPath)
-
-		if _, err = os.Stat(gitDirPath); err != nil {
-			if !os.IsNotExist(err) {
-				break
-			}
-		} else {
-			processedPath = gitDirPath
-			break
-		}
-
-		if path == "/" {
-			err = fmt.Errorf("Unable to find a git repository in %v or any of its parent directories", repoPath)
-			break
-		}
-
-		path = filepath.Dir(path)
+	if processedPath, err = DiscoverRepository(repoPath); err == nil {
+		log.Debugf("gitDirPath: %v", processedPath)
 	}
 
 	return
@@ -1037,6 +1233,11 @@ func (repoData *RepositoryData) Path() string {
 	return repoData.repoDataLoader.Path()
 }
 
+// Workdir returns the working directory of the repository
+func (repoData *RepositoryData) Workdir() string {
+	return repoData.repoDataLoader.Workdir()
+}
+
 // LoadHead attempts to load the HEAD reference
 func (repoData *RepositoryData) LoadHead() (err error) {
 	head, err := repoData.repoDataLoader.Head()
@@ -1061,6 +1262,7 @@ func (repoData *RepositoryData) LoadRefs(onRefsLoaded OnRefsLoaded) {
 	}
 
 	go func() {
+		defer RecoverPanic()
 		defer refSet.endRefUpdate()
 
 		if err := repoData.LoadHead(); err != nil {
@@ -1098,26 +1300,71 @@ func (repoData *RepositoryData) mapRefsToCommits(refs []Ref) {
 	log.Debug("Mapping refs to commits")
 
 	commitRefSet := repoData.commitRefSet
-	commitRefSet.clear()
+	generation := commitRefSet.clear()
+	var unpeeledTags []*Tag
 
 	for _, ref := range refs {
-		commit, err := repoData.repoDataLoader.Commit(ref.Oid())
-		if err != nil {
-			log.Errorf("Error when loading ref %v:%v - %v", ref.Name(), ref.Oid(), err)
-			continue
-		}
-
 		switch refInstance := ref.(type) {
 		case Branch:
+			commit, err := repoData.repoDataLoader.Commit(ref.Oid())
+			if err != nil {
+				log.Errorf("Error when loading ref %v:%v - %v", ref.Name(), ref.Oid(), err)
+				continue
+			}
+
 			commitRefSet.addBranchForCommit(commit, refInstance)
 		case *Tag:
-			commitRefSet.addTagForCommit(commit, refInstance)
+			if refInstance.peeledOid != nil {
+				commitRefSet.addTagForOid(refInstance.peeledOid, refInstance)
+			} else {
+				unpeeledTags = append(unpeeledTags, refInstance)
+			}
 		}
 	}
 
+	if len(unpeeledTags) > 0 {
+		go repoData.peelTags(unpeeledTags, generation)
+	}
+
 	return
 }
 
+// peelTags resolves the commits the provided tags point to in the background.
+// Peeling stops if the refs are remapped before all tags have been processed
+func (repoData *RepositoryData) peelTags(tags []*Tag, generation uint) {
+	defer RecoverPanic()
+
+	log.Debugf("Peeling %v tags", len(tags))
+
+	for _, tag := range tags {
+		select {
+		case <-repoData.channels.exitCh:
+			return
+		default:
+		}
+
+		if !repoData.commitRefSet.isCurrentGeneration(generation) {
+			log.Debug("Refs were remapped. Stopping tag peeling")
+			return
+		}
+
+		repoData.peelTag(tag)
+	}
+
+	log.Debug("Finished peeling tags")
+	repoData.channels.UpdateDisplay()
+}
+
+func (repoData *RepositoryData) peelTag(tag *Tag) {
+	commit, err := repoData.repoDataLoader.PeelTag(tag)
+	if err != nil {
+		log.Errorf("Error when loading ref %v:%v - %v", tag.Name(), tag.Oid(), err)
+		return
+	}
+
+	repoData.commitRefSet.addTagForOid(commit.oid, tag)
+}
+
 // LoadCommits attempts to load all commits for the provided oid
 func (repoData *RepositoryData) LoadCommits(ref Ref) (err error) {
 	if _, ok := repoData.refCommitSets.commitSet(ref); ok {
@@ -1125,16 +1372,22 @@ func (repoData *RepositoryData) LoadCommits(ref Ref) (err error) {
 		return
 	}
 
+	if tag, isTag := ref.(*Tag); isTag && tag.peeledOid == nil {
+		repoData.peelTag(tag)
+	}
+
 	commitCh, err := repoData.repoDataLoader.Commits(ref.Oid())
 	if err != nil {
 		return
 	}
 
-	commitSet := newBaseFilteredCommitSet()
+	commitSet := newBaseFilteredCommitSet(repoData.refCommitSets.newCommitList())
 	commitSet.SetLoading(true)
 	repoData.refCommitSets.setCommitSet(ref, commitSet)
 
 	go func() {
+		defer RecoverPanic()
+
 		log.Debugf("Receiving commits from RepoDataLoader for ref %v at %v", ref.Name(), ref.Oid())
 
 		for commit := range commitCh {
@@ -1207,6 +1460,11 @@ func (repoData *RepositoryData) CommitSetState(ref Ref) CommitSetState {
 	}
 }
 
+// CommitRange returns a channel from which the commits in a range of the form rev..rev can be read
+func (repoData *RepositoryData) CommitRange(commitRange string) (<-chan *Commit, error) {
+	return repoData.repoDataLoader.CommitRange(commitRange)
+}
+
 // Commits returns a channel from which the commit range specified can be read
 func (repoData *RepositoryData) Commits(ref Ref, startIndex, count uint) (<-chan *Commit, error) {
 	commitSet, ok := repoData.refCommitSets.commitSet(ref)
@@ -1217,6 +1475,7 @@ func (repoData *RepositoryData) Commits(ref Ref, startIndex, count uint) (<-chan
 	commitCh := make(chan *Commit)
 
 	go func() {
+		defer RecoverPanic()
 		defer close(commitCh)
 		var commit *Commit
 		index := startIndex
@@ -1273,10 +1532,351 @@ func (repoData *RepositoryData) RemoveCommitFilter(ref Ref) error {
 	return repoData.refCommitSets.removeCommitFilter(ref)
 }
 
-// DiffCommit loads a diff between the commit with the specified oid and its parent
+// SetUpstream sets the upstream of the local branch to the specified remote branch and reloads refs
+func (repoData *RepositoryData) SetUpstream(localBranch *LocalBranch, remoteBranchName string) (err error) {
+	_, remoteBranches, _ := repoData.refSet.branches()
+	remoteBranchExists := false
+
+	for _, remoteBranch := range remoteBranches {
+		if remoteBranch.Shorthand() == remoteBranchName {
+			remoteBranchExists = true
+			break
+		}
+	}
+
+	if !remoteBranchExists {
+		return fmt.Errorf("Remote branch %v does not exist", remoteBranchName)
+	}
+
+	if err = repoData.repoDataLoader.SetUpstream(localBranch, remoteBranchName); err != nil {
+		return
+	}
+
+	repoData.LoadRefs(nil)
+
+	return
+}
+
+// StaleRemoteBranches returns the remote-tracking branches which no longer exist on their remote
+func (repoData *RepositoryData) StaleRemoteBranches() (staleBranches []Branch, err error) {
+	staleBranchNames, err := repoData.repoDataLoader.StaleRemoteBranches()
+	if err != nil {
+		return
+	}
+
+	_, remoteBranches, _ := repoData.refSet.branches()
+
+	for _, staleBranchName := range staleBranchNames {
+		for _, remoteBranch := range remoteBranches {
+			if remoteBranch.Name() == staleBranchName {
+				staleBranches = append(staleBranches, remoteBranch)
+				break
+			}
+		}
+	}
+
+	return
+}
+
+// PruneRemoteBranches deletes the provided remote-tracking branches and reloads refs
+func (repoData *RepositoryData) PruneRemoteBranches(remoteBranches []Branch) (err error) {
+	for _, remoteBranch := range remoteBranches {
+		if !remoteBranch.IsRemote() {
+			return fmt.Errorf("Branch %v is not a remote branch", remoteBranch.Name())
+		}
+
+		if err = repoData.repoDataLoader.DeleteRef(remoteBranch.Name()); err != nil {
+			return
+		}
+	}
+
+	repoData.LoadRefs(nil)
+
+	return
+}
+
+// Stashes returns the stash entries of the repository, most recent first
+func (repoData *RepositoryData) Stashes() ([]*StashEntry, error) {
+	return repoData.repoDataLoader.LoadStashes()
+}
+
+// SaveStash stashes the changes in the working tree and index and reloads the status
+func (repoData *RepositoryData) SaveStash(message string) (err error) {
+	if status := repoData.Status(); status != nil && !status.HasUncommittedChanges() {
+		return fmt.Errorf("No local changes to stash")
+	}
+
+	if err = repoData.repoDataLoader.SaveStash(message); err != nil {
+		return
+	}
+
+	repoData.reloadStatus()
+
+	return
+}
+
+// ApplyStash applies the provided stash entry to the working tree and reloads the status.
+// The stash entry is removed if pop is true
+func (repoData *RepositoryData) ApplyStash(stashEntry *StashEntry, pop bool) (err error) {
+	if err = repoData.checkStashEntry(stashEntry); err != nil {
+		return
+	}
+
+	if err = repoData.repoDataLoader.ApplyStash(stashEntry.index, pop); err != nil {
+		return
+	}
+
+	repoData.reloadStatus()
+
+	return
+}
+
+// reloadStatus loads the status in the background so that status
+// listeners are not notified on the goroutine which modified the working tree
+func (repoData *RepositoryData) reloadStatus() {
+	go func() {
+		defer RecoverPanic()
+
+		if err := repoData.LoadStatus(); err != nil {
+			repoData.channels.ReportError(err)
+		}
+	}()
+}
+
+// DropStash removes the provided stash entry
+func (repoData *RepositoryData) DropStash(stashEntry *StashEntry) (err error) {
+	if err = repoData.checkStashEntry(stashEntry); err != nil {
+		return
+	}
+
+	return repoData.repoDataLoader.DropStash(stashEntry.index)
+}
+
+// checkStashEntry ensures the stash entry still has the same index,
+// as entries are addressed by index and the stash may have been modified outside of grv
+func (repoData *RepositoryData) checkStashEntry(stashEntry *StashEntry) (err error) {
+	stashes, err := repoData.Stashes()
+	if err != nil {
+		return
+	}
+
+	if stashEntry.index >= len(stashes) || !stashes[stashEntry.index].oid.Equal(stashEntry.oid) {
+		return fmt.Errorf("Stash entry %v has changed. Please try again", stashEntry.Name())
+	}
+
+	return
+}
+
+// RepositoryState returns the name of the operation in progress (e.g. merge or rebase)
+func (repoData *RepositoryData) RepositoryState() string {
+	return repoData.repoDataLoader.RepositoryState()
+}
+
+// AmendCommit amends HEAD with the staged changes and reloads the status and refs
+func (repoData *RepositoryData) AmendCommit() (err error) {
+	if status := repoData.Status(); status != nil && len(status.Entries(StStaged)) == 0 {
+		return fmt.Errorf("No staged changes to amend HEAD with")
+	}
+
+	if err = repoData.repoDataLoader.AmendCommit(); err != nil {
+		return
+	}
+
+	repoData.reloadStatus()
+	repoData.LoadRefs(nil)
+
+	return
+}
+
+// ApplyToIndex applies the patch to the index and reloads the status
+func (repoData *RepositoryData) ApplyToIndex(patch string) (err error) {
+	if err = repoData.repoDataLoader.ApplyToIndex(patch); err != nil {
+		return
+	}
+
+	repoData.reloadStatus()
+
+	return
+}
+
+// DiscardChanges discards the changes to the file of the status entry and reloads the status.
+// Staged entries have both their staged and unstaged changes discarded.
+// If backup is true the local changes are added to the stash before they are discarded
+func (repoData *RepositoryData) DiscardChanges(statusType StatusType, statusEntry *StatusEntry, backup bool) (err error) {
+	path := statusEntry.diffDelta.NewFile.Path
+
+	if backup {
+		if err = repoData.repoDataLoader.BackupStash(fmt.Sprintf("Backup before discarding changes to %v", path)); err != nil {
+			return
+		}
+	}
+
+	if err = repoData.repoDataLoader.DiscardChanges(path, statusType == StStaged); err != nil {
+		return
+	}
+
+	repoData.reloadStatus()
+
+	return
+}
+
+// StageFile adds the file to the index and reloads the status
+func (repoData *RepositoryData) StageFile(path string) (err error) {
+	if err = repoData.repoDataLoader.StageFile(path); err != nil {
+		return
+	}
+
+	repoData.reloadStatus()
+
+	return
+}
+
+// AddIgnorePattern adds the pattern to the .gitignore file of the repository and reloads the status
+func (repoData *RepositoryData) AddIgnorePattern(pattern string) (err error) {
+	if err = repoData.repoDataLoader.AddIgnorePattern(pattern); err != nil {
+		return
+	}
+
+	repoData.reloadStatus()
+
+	return
+}
+
+// RemoteBranchContaining returns a remote branch which contains the commit with the provided oid.
+// Preference is given to the upstream branch of HEAD if it contains the commit
+func (repoData *RepositoryData) RemoteBranchContaining(oid *Oid) (remoteBranch Branch, found bool) {
+	_, remoteBranches, _ := repoData.Branches()
+
+	contains := func(branch Branch) bool {
+		if branch.Oid().Equal(oid) {
+			return true
+		}
+
+		ahead, _, err := repoData.repoDataLoader.AheadBehind(oid, branch.Oid())
+		return err == nil && ahead == 0
+	}
+
+	if localBranch, isLocalBranch := repoData.Head().(*LocalBranch); isLocalBranch && localBranch.IsTrackingBranch() {
+		for _, branch := range remoteBranches {
+			if branch.Name() == localBranch.remoteBranch && contains(branch) {
+				return branch, true
+			}
+		}
+	}
+
+	for _, branch := range remoteBranches {
+		if contains(branch) {
+			return branch, true
+		}
+	}
+
+	return
+}
+
+// StartBisect starts a bisect session
+func (repoData *RepositoryData) StartBisect() (err error) {
+	if _, err = repoData.repoDataLoader.Bisect("start"); err != nil {
+		return
+	}
+
+	repoData.setBisectStatus(&BisectStatus{})
+
+	return
+}
+
+// MarkBisectCommit marks the commit as good, bad or skipped and returns the progress of the bisect.
+// git bisect checks out the next commit to test
+func (repoData *RepositoryData) MarkBisectCommit(commit *Commit, bisectTerm BisectTerm) (bisectStatus *BisectStatus, err error) {
+	output, err := repoData.repoDataLoader.Bisect(bisectTerm.String(), commit.oid.String())
+	if err != nil {
+		return
+	}
+
+	bisectStatus = parseBisectOutput(output)
+	repoData.setBisectStatus(bisectStatus)
+
+	return
+}
+
+// ResetBisect ends the bisect session and checks out the branch HEAD pointed to when it was started
+func (repoData *RepositoryData) ResetBisect() (err error) {
+	if _, err = repoData.repoDataLoader.Bisect("reset"); err != nil {
+		return
+	}
+
+	repoData.setBisectStatus(nil)
+
+	return
+}
+
+// BisectStatus returns the progress of the bisect in progress or nil if the repository is not being bisected
+func (repoData *RepositoryData) BisectStatus() *BisectStatus {
+	if repoData.RepositoryState() != "bisect" {
+		return nil
+	}
+
+	repoData.bisectLock.Lock()
+	defer repoData.bisectLock.Unlock()
+
+	if repoData.bisectStatus == nil {
+		return &BisectStatus{}
+	}
+
+	return repoData.bisectStatus
+}
+
+func (repoData *RepositoryData) setBisectStatus(bisectStatus *BisectStatus) {
+	repoData.bisectLock.Lock()
+	defer repoData.bisectLock.Unlock()
+
+	repoData.bisectStatus = bisectStatus
+}
+
+// Conflicts returns the files which have conflicting changes in the index
+func (repoData *RepositoryData) Conflicts() ([]*Conflict, error) {
+	return repoData.repoDataLoader.LoadConflicts()
+}
+
+// ConflictContent returns the three-way merged content of the provided conflicted file
+func (repoData *RepositoryData) ConflictContent(conflict *Conflict) ([]ConflictLine, error) {
+	return repoData.repoDataLoader.ConflictContent(conflict)
+}
+
+// ResolveConflict resolves the provided conflicted file using the chosen side and reloads the status
+func (repoData *RepositoryData) ResolveConflict(conflict *Conflict, resolution ConflictResolution) (err error) {
+	if err = repoData.repoDataLoader.ResolveConflict(conflict.path, resolution); err != nil {
+		return
+	}
+
+	repoData.reloadStatus()
+
+	return
+}
+
+// Blame returns the lines of the file at the provided revision and the commits which last modified them
+func (repoData *RepositoryData) Blame(revision, path string) ([]*BlameLine, error) {
+	return repoData.repoDataLoader.Blame(revision, path)
+}
+
+// LineHistory returns the commits and diff hunks which modified the provided line range
+func (repoData *RepositoryData) LineHistory(lineRange LineRange) ([]byte, error) {
+	return repoData.repoDataLoader.LineHistory(lineRange)
+}
+
+// PickaxeMatches returns true if the diff of the provided commit matches the pickaxe
+func (repoData *RepositoryData) PickaxeMatches(commit *Commit, pickaxe *Pickaxe) (bool, error) {
+	return repoData.repoDataLoader.PickaxeMatches(commit, pickaxe)
+}
+
+// DiffMergeCommit loads a diff between the merge commit with the specified oid and one or all of its parents
+func (repoData *RepositoryData) DiffMergeCommit(commit *Commit, parentNumber uint, diffTask *DiffTask) (*Diff, error) {
+	return repoData.repoDataLoader.DiffMergeCommit(commit, parentNumber, diffTask)
+}
+
+// DiffCommit loads a diff between the commit with the specified oid and the provided base
 // If the commit has more than one parent no diff is returned
-func (repoData *RepositoryData) DiffCommit(commit *Commit) (*Diff, error) {
-	return repoData.repoDataLoader.DiffCommit(commit)
+func (repoData *RepositoryData) DiffCommit(commit *Commit, diffBase DiffBase, diffTask *DiffTask) (*Diff, error) {
+	return repoData.repoDataLoader.DiffCommit(commit, diffBase, diffTask)
 }
 
 // DiffFile Generates a diff for the provided file
@@ -1311,6 +1911,62 @@ func (repoData *RepositoryData) RegisterRefStateListener(refStateListener RefSta
 	repoData.refSet.registerRefStateListener(refStateListener)
 }
 
+// CommitMetadata returns the metadata for the provided commit if it has been computed
+func (repoData *RepositoryData) CommitMetadata(commit *Commit) (*CommitMetadata, bool) {
+	return repoData.commitMetadata.Metadata(commit)
+}
+
+// LoadCommitMetadata computes the metadata for the provided commits in the background
+func (repoData *RepositoryData) LoadCommitMetadata(commits []*Commit) {
+	repoData.commitMetadata.Load(commits)
+}
+
+// CommitSetStates returns the state of the commit set loaded for each ref
+func (repoData *RepositoryData) CommitSetStates() map[string]CommitSetState {
+	return repoData.refCommitSets.commitSetStates()
+}
+
+// SetConfig applies the config variables used by the repository data and listens for changes to them
+func (repoData *RepositoryData) SetConfig(config Config) {
+	repoData.config = config
+	config.AddOnChangeListener(CfCommitLimit, repoData)
+	config.AddOnChangeListener(CfAbbrev, repoData)
+	config.AddOnChangeListener(CfDiffWhitespace, repoData)
+	config.AddOnChangeListener(CfRenameThreshold, repoData)
+	config.AddOnChangeListener(CfDiffAlgorithm, repoData)
+	repoData.onConfigVariableChange(CfCommitLimit)
+	repoData.onConfigVariableChange(CfAbbrev)
+	repoData.onConfigVariableChange(CfDiffWhitespace)
+	repoData.onConfigVariableChange(CfRenameThreshold)
+	repoData.onConfigVariableChange(CfDiffAlgorithm)
+}
+
+func (repoData *RepositoryData) onConfigVariableChange(configVariable ConfigVariable) {
+	switch configVariable {
+	case CfCommitLimit:
+		commitLimit := uint(repoData.config.GetInt(CfCommitLimit))
+		repoData.repoDataLoader.SetCommitLimit(commitLimit)
+		repoData.refCommitSets.setCommitLimit(commitLimit)
+	case CfAbbrev:
+		repoData.repoDataLoader.SetShortOidLength(uint(repoData.config.GetInt(CfAbbrev)))
+	case CfDiffWhitespace:
+		diffWhitespace, _ := ParseDiffWhitespace(repoData.config.GetString(CfDiffWhitespace))
+		repoData.repoDataLoader.SetDiffWhitespace(diffWhitespace)
+	case CfRenameThreshold:
+		repoData.repoDataLoader.SetRenameThreshold(uint(repoData.config.GetInt(CfRenameThreshold)))
+	case CfDiffAlgorithm:
+		diffAlgorithm, _ := ParseDiffAlgorithm(repoData.config.GetString(CfDiffAlgorithm))
+		repoData.repoDataLoader.SetDiffAlgorithm(diffAlgorithm)
+	}
+}
+
+// PullRequest returns the GitHub pull request the commit is associated with.
+// A nil pull request is returned if there is no associated pull request or the lookup is disabled
+func (repoData *RepositoryData) PullRequest(commit *Commit) (*PullRequest, error) {
+	pullRequestLookup, _ := ParsePullRequestLookup(repoData.config.GetString(CfPullRequests))
+	return repoData.pullRequests.PullRequest(commit.oid.String(), commit.commit.Message(), pullRequestLookup)
+}
+
 // RegisterCommitSetListener registers a listener to be notified when a commitSet event occurs
 func (repoData *RepositoryData) RegisterCommitSetListener(commitSetListener CommitSetListener) {
 	repoData.refCommitSets.registerCommitSetListener(commitSetListener)
@@ -1342,6 +1998,8 @@ func (repoData *RepositoryData) addUpdatedRefsToProcessingQueue(updatedRefs []*U
 }
 
 func (repoData *RepositoryData) processUpdatedRefs() {
+	defer RecoverPanic()
+
 	log.Info("Starting UpdatedRef processor")
 
 	for updatedRef := range repoData.refUpdateCh {
@@ -1427,3 +2085,13 @@ func (repoData *RepositoryData) handleViewRemovedEvent(event Event) {
 		}
 	}
 }
+
+// Marks returns the marks set on commits in the repository
+func (repoData *RepositoryData) Marks() *MarkStore {
+	return repoData.marks
+}
+
+// Session returns the session state saved for the repository
+func (repoData *RepositoryData) Session() *SessionStore {
+	return repoData.session
+}
@@ -2,10 +2,19 @@ package main
 
 import (
 	"bytes"
+	"container/list"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"reflect"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 	slice "github.com/bradfitz/slice"
@@ -18,20 +27,39 @@ const (
 	rdlCommitBufferSize = 100
 	rdlDiffStatsCols    = 80
 	rdlShortOidLen      = 7
+	rdlMinShortOidLen   = 4
+	rdlMaxShortOidLen   = 40
+	rdlMaxSimilarity    = 100
+	rdlRemoteRefPrefix  = "refs/remotes/"
+	rdlLocalRefPrefix   = "refs/heads/"
+	rdlTagRefPrefix     = "refs/tags/"
 )
 
+var errPickaxeMatched = errors.New("Pickaxe matched")
+
+var shortOidLength uint32 = rdlShortOidLen
+
 type instanceCache struct {
-	oids       map[string]*Oid
-	commits    map[string]*Commit
-	oidLock    sync.Mutex
-	commitLock sync.Mutex
+	oids          map[string]*Oid
+	commits       map[string]*Commit
+	commitLimit   uint
+	commitsByUse  *list.List
+	commitElement map[string]*list.Element
+	oidLock       sync.Mutex
+	commitLock    sync.Mutex
 }
 
 // RepoDataLoader handles loading data from the repository
 type RepoDataLoader struct {
-	repo     *git.Repository
-	cache    *instanceCache
-	channels *Channels
+	repo            *git.Repository
+	cache           *instanceCache
+	commitCache     *CommitCache
+	commitGraph     *CommitGraph
+	channels        *Channels
+	shortOidLength  uint
+	diffWhitespace  int32
+	renameThreshold int32
+	diffAlgorithm   int32
 }
 
 // Oid is reference to a git object
@@ -56,14 +84,24 @@ func (oid Oid) String() string {
 // ShortID returns a shortened oid hash
 func (oid Oid) ShortID() (shortID string) {
 	id := oid.String()
+	length := int(atomic.LoadUint32(&shortOidLength))
 
-	if len(id) >= rdlShortOidLen {
-		shortID = id[0:rdlShortOidLen]
+	if len(id) >= length {
+		shortID = id[0:length]
 	}
 
 	return
 }
 
+// abbreviateOid shortens the oid hash to the configured short oid length
+func abbreviateOid(oid string) string {
+	if length := int(atomic.LoadUint32(&shortOidLength)); len(oid) > length {
+		return oid[:length]
+	}
+
+	return oid
+}
+
 // Ref is a named pointer to a commit
 type Ref interface {
 	Oid() *Oid
@@ -227,9 +265,13 @@ func (remoteBranch *RemoteBranch) Equal(other Ref) bool {
 // Tag contains data for a tag reference
 type Tag struct {
 	oid       *Oid
+	peeledOid *Oid
 	name      string
 	shorthand string
 	isRemote  bool
+	annotated bool
+	tagger    string
+	date      time.Time
 }
 
 // Oid pointed to by this tag
@@ -309,7 +351,7 @@ func (head *HEAD) Equal(other Ref) bool {
 // Commit contains data for a commit
 type Commit struct {
 	oid    *Oid
-	commit *git.Commit
+	commit RawCommit
 }
 
 // Diff contains data for a generated diff
@@ -368,6 +410,18 @@ func newStatusEntry(gitStatus git.Status, statusType StatusType, rawStatusEntry
 	}
 }
 
+// StashEntry is an entry in the stash list
+type StashEntry struct {
+	index   int
+	message string
+	oid     *Oid
+}
+
+// Name returns the stash entry name in the form stash@{index}
+func (stashEntry *StashEntry) Name() string {
+	return fmt.Sprintf("stash@{%v}", stashEntry.index)
+}
+
 // StatusType describes the different stages a status entry can be in
 type StatusType int
 
@@ -443,6 +497,18 @@ func (status *Status) IsEmpty() bool {
 	return entryNum == 0
 }
 
+// HasUncommittedChanges returns true if there are staged, unstaged or conflicted entries.
+// Untracked files are not considered uncommitted changes
+func (status *Status) HasUncommittedChanges() bool {
+	for _, statusType := range []StatusType{StStaged, StUnstaged, StConflicted} {
+		if len(status.Entries(statusType)) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (status *Status) addEntry(rawStatusEntry git.StatusEntry) {
 	for rawStatus, statusType := range statusTypeMap {
 		processedRawStatus := rawStatusEntry.Status & rawStatus
@@ -497,8 +563,10 @@ func statusEntriesEqual(entries, otherEntries []*StatusEntry) bool {
 
 func newInstanceCache() *instanceCache {
 	return &instanceCache{
-		oids:    make(map[string]*Oid),
-		commits: make(map[string]*Commit),
+		oids:          make(map[string]*Oid),
+		commits:       make(map[string]*Commit),
+		commitsByUse:  list.New(),
+		commitElement: make(map[string]*list.Element),
 	}
 }
 
@@ -518,13 +586,14 @@ func (cache *instanceCache) getOid(rawOid *git.Oid) *Oid {
 	return oid
 }
 
-func (cache *instanceCache) getCommit(rawCommit *git.Commit) *Commit {
+func (cache *instanceCache) getCommit(rawCommit RawCommit) *Commit {
 	cache.commitLock.Lock()
 	defer cache.commitLock.Unlock()
 
 	oidStr := rawCommit.Id().String()
 
 	if commit, ok := cache.commits[oidStr]; ok {
+		cache.markCommitUsed(oidStr)
 		return commit
 	}
 
@@ -533,6 +602,8 @@ func (cache *instanceCache) getCommit(rawCommit *git.Commit) *Commit {
 		commit: rawCommit,
 	}
 	cache.commits[oidStr] = commit
+	cache.markCommitUsed(oidStr)
+	cache.evictCommits()
 
 	return commit
 }
@@ -541,11 +612,61 @@ func (cache *instanceCache) getCachedCommit(oid *Oid) (commit *Commit, exists bo
 	cache.commitLock.Lock()
 	defer cache.commitLock.Unlock()
 
-	commit, exists = cache.commits[oid.String()]
+	oidStr := oid.String()
+
+	if commit, exists = cache.commits[oidStr]; exists {
+		cache.markCommitUsed(oidStr)
+	}
 
 	return
 }
 
+// setCommitLimit sets the maximum number of commit instances retained by the cache.
+// A limit of 0 means commit instances are never evicted
+func (cache *instanceCache) setCommitLimit(limit uint) {
+	cache.commitLock.Lock()
+	defer cache.commitLock.Unlock()
+
+	cache.commitLimit = limit
+	cache.commitsByUse.Init()
+	cache.commitElement = make(map[string]*list.Element)
+
+	if limit > 0 {
+		for oidStr := range cache.commits {
+			cache.markCommitUsed(oidStr)
+		}
+
+		cache.evictCommits()
+	}
+}
+
+func (cache *instanceCache) markCommitUsed(oidStr string) {
+	if cache.commitLimit == 0 {
+		return
+	}
+
+	if element, exists := cache.commitElement[oidStr]; exists {
+		cache.commitsByUse.MoveToFront(element)
+	} else {
+		cache.commitElement[oidStr] = cache.commitsByUse.PushFront(oidStr)
+	}
+}
+
+func (cache *instanceCache) evictCommits() {
+	if cache.commitLimit == 0 {
+		return
+	}
+
+	for uint(cache.commitsByUse.Len()) > cache.commitLimit {
+		element := cache.commitsByUse.Back()
+		oidStr := element.Value.(string)
+
+		cache.commitsByUse.Remove(element)
+		delete(cache.commitElement, oidStr)
+		delete(cache.commits, oidStr)
+	}
+}
+
 func (cache *instanceCache) getCachedOid(oidStr string) (oid *Oid, exists bool) {
 	cache.commitLock.Lock()
 	defer cache.commitLock.Unlock()
@@ -567,11 +688,133 @@ func NewRepoDataLoader(channels *Channels) *RepoDataLoader {
 func (repoDataLoader *RepoDataLoader) Free() {
 	log.Info("Freeing RepoDataLoader")
 
+	if repoDataLoader.commitCache != nil {
+		if err := repoDataLoader.commitCache.Save(); err != nil {
+			log.Errorf("Unable to save commit cache: %v", err)
+		}
+	}
+
 	if repoDataLoader.repo != nil {
 		repoDataLoader.repo.Free()
 	}
 }
 
+// SetCommitLimit sets the maximum number of commit instances the loader retains
+func (repoDataLoader *RepoDataLoader) SetCommitLimit(limit uint) {
+	repoDataLoader.cache.setCommitLimit(limit)
+}
+
+// SetShortOidLength sets the length of abbreviated oids.
+// When length is 0 the value of core.abbrev in the repository git config is used
+func (repoDataLoader *RepoDataLoader) SetShortOidLength(length uint) {
+	repoDataLoader.shortOidLength = length
+	repoDataLoader.applyShortOidLength()
+}
+
+func (repoDataLoader *RepoDataLoader) applyShortOidLength() {
+	length := repoDataLoader.shortOidLength
+	if length == 0 {
+		length = repoDataLoader.coreAbbrevLength()
+	}
+
+	log.Debugf("Setting short oid length to %v", length)
+	atomic.StoreUint32(&shortOidLength, uint32(length))
+}
+
+func (repoDataLoader *RepoDataLoader) coreAbbrevLength() uint {
+	if repoDataLoader.repo == nil {
+		return rdlShortOidLen
+	}
+
+	config, err := repoDataLoader.repo.Config()
+	if err != nil {
+		log.Errorf("Unable to load git config: %v", err)
+		return rdlShortOidLen
+	}
+	defer config.Free()
+
+	value, err := config.LookupString("core.abbrev")
+	if err != nil {
+		return rdlShortOidLen
+	}
+
+	if length, err := strconv.Atoi(value); err == nil && length >= rdlMinShortOidLen && length <= rdlMaxShortOidLen {
+		return uint(length)
+	}
+
+	return rdlShortOidLen
+}
+
+// SetDiffWhitespace sets which whitespace changes are ignored when generating diffs
+func (repoDataLoader *RepoDataLoader) SetDiffWhitespace(diffWhitespace DiffWhitespace) {
+	atomic.StoreInt32(&repoDataLoader.diffWhitespace, int32(diffWhitespace))
+}
+
+// applyDiffWhitespace removes leading whitespace changes from the patch when they are ignored.
+// The other whitespace modes are handled when the diff is generated
+func (repoDataLoader *RepoDataLoader) applyDiffWhitespace(patch string) string {
+	if DiffWhitespace(atomic.LoadInt32(&repoDataLoader.diffWhitespace)) == DwLeading {
+		return ignoreLeadingWhitespace(patch)
+	}
+
+	return patch
+}
+
+// SetDiffAlgorithm sets the algorithm used to generate diffs
+func (repoDataLoader *RepoDataLoader) SetDiffAlgorithm(diffAlgorithm DiffAlgorithm) {
+	atomic.StoreInt32(&repoDataLoader.diffAlgorithm, int32(diffAlgorithm))
+}
+
+// SetRenameThreshold sets the similarity percentage at which files are considered renamed or copied.
+// Rename and copy detection is disabled when the threshold is 0
+func (repoDataLoader *RepoDataLoader) SetRenameThreshold(threshold uint) {
+	atomic.StoreInt32(&repoDataLoader.renameThreshold, int32(threshold))
+}
+
+// findSimilar updates the diff to record files which were renamed or copied as such, instead of as deleted and added files
+func (repoDataLoader *RepoDataLoader) findSimilar(rawDiff *git.Diff) (err error) {
+	threshold := uint16(atomic.LoadInt32(&repoDataLoader.renameThreshold))
+	if threshold == 0 {
+		return
+	}
+
+	options, err := git.DefaultDiffFindOptions()
+	if err != nil {
+		return
+	}
+
+	options.Flags |= git.DiffFindRenames | git.DiffFindCopies
+	options.RenameThreshold = threshold
+	options.CopyThreshold = threshold
+
+	return rawDiff.FindSimilar(&options)
+}
+
+func (repoDataLoader *RepoDataLoader) diffOptions() (options git.DiffOptions, err error) {
+	if options, err = git.DefaultDiffOptions(); err != nil {
+		return
+	}
+
+	switch DiffWhitespace(atomic.LoadInt32(&repoDataLoader.diffWhitespace)) {
+	case DwAll:
+		options.Flags |= git.DiffIgnoreWhitespace
+	case DwChange:
+		options.Flags |= git.DiffIgnoreWhitespaceChange
+	case DwTrailing:
+		options.Flags |= git.DiffIgnoreWitespaceEol
+	}
+
+	// libgit2 doesn't implement the histogram algorithm, so the patience algorithm it extends is used instead
+	switch DiffAlgorithm(atomic.LoadInt32(&repoDataLoader.diffAlgorithm)) {
+	case DaPatience, DaHistogram:
+		options.Flags |= git.DiffPatience
+	case DaMinimal:
+		options.Flags |= git.DiffMinimal
+	}
+
+	return
+}
+
 // Initialise attempts to access the repository
 func (repoDataLoader *RepoDataLoader) Initialise(repoPath string) error {
 	log.Infof("Opening repository at %v", repoPath)
@@ -583,6 +826,18 @@ func (repoDataLoader *RepoDataLoader) Initialise(repoPath string) error {
 	}
 
 	repoDataLoader.repo = repo
+	repoDataLoader.commitCache = NewCommitCache(repo.Path())
+	repoDataLoader.applyShortOidLength()
+
+	if err = repoDataLoader.commitCache.Load(); err != nil {
+		log.Errorf("Unable to load commit cache: %v", err)
+	}
+
+	if repoDataLoader.commitGraph, err = LoadCommitGraph(repo.Path()); err != nil {
+		log.Errorf("Unable to load commit-graph: %v", err)
+	} else if repoDataLoader.commitGraph != nil {
+		log.Infof("Loaded commit-graph containing %v commits", repoDataLoader.commitGraph.CommitNum())
+	}
 
 	return nil
 }
@@ -592,6 +847,11 @@ func (repoDataLoader *RepoDataLoader) Path() string {
 	return repoDataLoader.repo.Path()
 }
 
+// Workdir returns the working directory of the repository
+func (repoDataLoader *RepoDataLoader) Workdir() string {
+	return repoDataLoader.repo.Workdir()
+}
+
 // Head loads the current HEAD ref
 func (repoDataLoader *RepoDataLoader) Head() (ref Ref, err error) {
 	log.Debug("Loading HEAD")
@@ -707,6 +967,11 @@ func (repoDataLoader *RepoDataLoader) loadBranches() (branches []Branch, err err
 func (repoDataLoader *RepoDataLoader) loadTags() (tags []*Tag, err error) {
 	log.Debug("Loading local tags")
 
+	peeledTags, peeledTagsErr := loadPeeledTags(repoDataLoader.repo.Path())
+	if peeledTagsErr != nil {
+		log.Errorf("Unable to read peeled tags: %v", peeledTagsErr)
+	}
+
 	refIter, err := repoDataLoader.repo.NewReferenceIterator()
 	if err != nil {
 		return
@@ -727,6 +992,15 @@ func (repoDataLoader *RepoDataLoader) loadTags() (tags []*Tag, err error) {
 				name:      ref.Name(),
 				shorthand: ref.Shorthand(),
 			}
+
+			if peeledTag, exists := peeledTags[ref.Name()]; exists && peeledTag.oid == oid.String() {
+				if rawPeeledOid, err := git.NewOid(peeledTag.peeledOid); err == nil {
+					newTag.peeledOid = repoDataLoader.cache.getOid(rawPeeledOid)
+				}
+			}
+
+			repoDataLoader.loadTagDetails(newTag)
+
 			tags = append(tags, newTag)
 
 			log.Debugf("Loaded tag %v", newTag)
@@ -736,8 +1010,57 @@ func (repoDataLoader *RepoDataLoader) loadTags() (tags []*Tag, err error) {
 	return
 }
 
+// loadTagDetails sets the tagger and date of annotated tags.
+// Lightweight tags are dated using the committer date of the commit they point to
+func (repoDataLoader *RepoDataLoader) loadTagDetails(tag *Tag) {
+	object, err := repoDataLoader.repo.Lookup(tag.oid.oid)
+	if err != nil {
+		log.Debugf("Error when attempting to lookup object with ID %v for tag %v", tag.oid, tag.name)
+		return
+	}
+
+	switch object.Type() {
+	case git.ObjectTag:
+		rawTag, err := object.AsTag()
+		if err != nil {
+			log.Debugf("Error when attempting convert object with ID %v to tag", tag.oid)
+			return
+		}
+
+		tag.annotated = true
+
+		if tagger := rawTag.Tagger(); tagger != nil {
+			tag.tagger = fmt.Sprintf("%v <%v>", tagger.Name, tagger.Email)
+			tag.date = tagger.When
+		}
+	case git.ObjectCommit:
+		rawCommit, err := object.AsCommit()
+		if err != nil {
+			log.Debugf("Error when attempting convert object with ID %v to commit", tag.oid)
+			return
+		}
+
+		tag.date = rawCommit.Committer().When
+	}
+}
+
+// PeelTag loads the commit the provided tag points to
+func (repoDataLoader *RepoDataLoader) PeelTag(tag *Tag) (commit *Commit, err error) {
+	return repoDataLoader.Commit(tag.Oid())
+}
+
 // Commits loads all commits for the provided ref and returns a channel from which the loaded commits can be read
+// Commits are read from the commit cache where possible. If only an ancestor of the provided oid
+// has been cached and the provided oid fast-forwards the ancestor then only the commits not reachable
+// from the ancestor are walked
 func (repoDataLoader *RepoDataLoader) Commits(oid *Oid) (<-chan *Commit, error) {
+	tip := oid.String()
+
+	if records, exists := repoDataLoader.commitCache.Walk(tip); exists {
+		log.Debugf("Loading %v commits for oid %v from commit cache", len(records), oid)
+		return repoDataLoader.loadCommits(nil, records, ""), nil
+	}
+
 	revWalk, err := repoDataLoader.repo.Walk()
 	if err != nil {
 		return nil, err
@@ -747,9 +1070,85 @@ func (repoDataLoader *RepoDataLoader) Commits(oid *Oid) (<-chan *Commit, error)
 		return nil, err
 	}
 
+	var records []*commitRecord
+
+	if ancestorOid, ancestorRecords, exists := repoDataLoader.cachedAncestorWalk(oid); exists {
+		if err := revWalk.Hide(ancestorOid); err != nil {
+			log.Errorf("Unable to hide commits reachable from %v: %v", ancestorOid, err)
+		} else {
+			log.Debugf("Using %v cached commits reachable from ancestor %v", len(ancestorRecords), ancestorOid)
+			records = ancestorRecords
+		}
+	}
+
 	log.Debugf("Loading commits for oid %v", oid)
 
-	return repoDataLoader.loadCommits(revWalk), nil
+	return repoDataLoader.loadCommits(revWalk, records, tip), nil
+}
+
+// cachedAncestorWalk returns the cached walk of the most recently used cached tip which the provided oid fast-forwards.
+// Only ancestors connected to the provided oid by a chain of single parent commits are used as the walked commits
+// then precede the cached commits in the same order a full walk would produce. If a merge commit was walked
+// then commits it merged could be ordered amongst the cached commits
+func (repoDataLoader *RepoDataLoader) cachedAncestorWalk(oid *Oid) (ancestorOid *git.Oid, records []*commitRecord, exists bool) {
+	for _, tip := range repoDataLoader.commitCache.Tips() {
+		tipOid, err := git.NewOid(tip)
+		if err != nil {
+			continue
+		}
+
+		if !repoDataLoader.isDescendant(oid.oid, tipOid) || !repoDataLoader.isFastForward(oid.oid, tipOid) {
+			continue
+		}
+
+		if records, exists = repoDataLoader.commitCache.Walk(tip); exists {
+			ancestorOid = tipOid
+			return
+		}
+	}
+
+	return
+}
+
+// isFastForward returns true if ancestor can be reached from commit by only following commits with a single parent
+func (repoDataLoader *RepoDataLoader) isFastForward(commit, ancestor *git.Oid) bool {
+	for oid := commit; !oid.Equal(ancestor); {
+		rawCommit, err := repoDataLoader.repo.LookupCommit(oid)
+		if err != nil {
+			log.Debugf("Unable to load commit %v: %v", oid, err)
+			return false
+		}
+
+		parentCount := rawCommit.ParentCount()
+		if parentCount != 1 {
+			log.Debugf("Commit %v has %v parents and does not fast-forward %v", oid, parentCount, ancestor)
+			rawCommit.Free()
+			return false
+		}
+
+		oid = rawCommit.ParentId(0)
+		rawCommit.Free()
+	}
+
+	return true
+}
+
+// isDescendant returns true if commit is a descendant of ancestor.
+// The commit-graph is used when it contains both commits
+func (repoDataLoader *RepoDataLoader) isDescendant(commit, ancestor *git.Oid) bool {
+	if repoDataLoader.commitGraph != nil {
+		if isDescendant, found := repoDataLoader.commitGraph.IsDescendant(commit.String(), ancestor.String()); found {
+			return isDescendant
+		}
+	}
+
+	isDescendant, err := repoDataLoader.repo.DescendantOf(commit, ancestor)
+	if err != nil {
+		log.Debugf("Unable to determine if %v is a descendant of %v: %v", commit, ancestor, err)
+		return false
+	}
+
+	return isDescendant
 }
 
 // CommitRange accepts a range of the form rev..rev and returns a stream of commits in this range
@@ -765,32 +1164,73 @@ func (repoDataLoader *RepoDataLoader) CommitRange(commitRange string) (<-chan *C
 
 	log.Debugf("Loading commits for range %v", commitRange)
 
-	return repoDataLoader.loadCommits(revWalk), nil
+	return repoDataLoader.loadCommits(revWalk, nil, ""), nil
 }
 
-func (repoDataLoader *RepoDataLoader) loadCommits(revWalk *git.RevWalk) <-chan *Commit {
+// loadCommits streams the commits produced by the rev walk followed by the provided cached commits.
+// If a tip is provided and all commits were loaded then they are stored in the commit cache for that tip.
+// The commit cache is written to disk when the loader is freed
+func (repoDataLoader *RepoDataLoader) loadCommits(revWalk *git.RevWalk, records []*commitRecord, tip string) <-chan *Commit {
 	commitCh := make(chan *Commit, rdlCommitBufferSize)
 
 	go func() {
+		defer RecoverPanic()
 		defer close(commitCh)
-		defer revWalk.Free()
 
+		var rawCommits []RawCommit
 		commitNum := 0
+		complete := true
+
+		sendCommit := func(rawCommit RawCommit) {
+			commit := repoDataLoader.cache.getCommit(rawCommit)
+			commitNum++
+
+			if tip != "" {
+				rawCommits = append(rawCommits, commit.commit)
+			}
+
+			commitCh <- commit
+		}
+
+		if revWalk != nil {
+			defer revWalk.Free()
+
+			if err := revWalk.Iterate(func(commit *git.Commit) bool {
+				if repoDataLoader.channels.Exit() {
+					complete = false
+					return false
+				}
+
+				sendCommit(commit)
 
-		if err := revWalk.Iterate(func(commit *git.Commit) bool {
+				return true
+			}); err != nil {
+				log.Errorf("Error when iterating over commits: %v", err)
+				complete = false
+			}
+		}
+
+		for _, record := range records {
 			if repoDataLoader.channels.Exit() {
-				return false
+				complete = false
+				break
 			}
 
-			commitNum++
-			commitCh <- repoDataLoader.cache.getCommit(commit)
+			rawCommit, err := newCachedCommit(record, repoDataLoader.repo)
+			if err != nil {
+				log.Errorf("Invalid commit in commit cache: %v", err)
+				complete = false
+				break
+			}
 
-			return true
-		}); err != nil {
-			log.Errorf("Error when iterating over commits: %v", err)
+			sendCommit(rawCommit)
 		}
 
 		log.Debugf("Loaded %v commits", commitNum)
+
+		if tip != "" && complete {
+			repoDataLoader.commitCache.AddWalk(tip, rawCommits)
+		}
 	}()
 
 	return commitCh
@@ -874,76 +1314,782 @@ func (repoDataLoader *RepoDataLoader) MergeBase(oid1, oid2 *Oid) (commonAncestor
 
 // AheadBehind returns the number of unique commits between two branches
 func (repoDataLoader *RepoDataLoader) AheadBehind(local, upstream *Oid) (ahead, behind int, err error) {
+	if repoDataLoader.commitGraph != nil {
+		var found bool
+		if ahead, behind, found = repoDataLoader.commitGraph.AheadBehind(local.String(), upstream.String()); found {
+			return
+		}
+	}
+
 	return repoDataLoader.repo.AheadBehind(local.oid, upstream.oid)
 }
 
-// DiffCommit loads a diff between the commit with the specified oid and its parent
-// If the commit has more than one parent no diff is returned
-func (repoDataLoader *RepoDataLoader) DiffCommit(commit *Commit) (diff *Diff, err error) {
-	diff = &Diff{}
+// SetUpstream sets the upstream of the provided local branch to the remote branch with the provided name
+func (repoDataLoader *RepoDataLoader) SetUpstream(localBranch *LocalBranch, remoteBranchName string) (err error) {
+	rawBranch, err := repoDataLoader.repo.LookupBranch(localBranch.Shorthand(), git.BranchLocal)
+	if err != nil {
+		return
+	}
+	defer rawBranch.Free()
 
-	if commit.commit.ParentCount() > 1 {
+	log.Debugf("Setting upstream of branch %v to %v", localBranch.Shorthand(), remoteBranchName)
+
+	return rawBranch.SetUpstream(remoteBranchName)
+}
+
+// StaleRemoteBranches returns the names of remote-tracking branches which no longer exist on their remote
+func (repoDataLoader *RepoDataLoader) StaleRemoteBranches() (staleBranches []string, err error) {
+	remoteNames, err := repoDataLoader.repo.Remotes.List()
+	if err != nil {
 		return
 	}
 
-	var commitTree, parentTree *git.Tree
-	if commitTree, err = commit.commit.Tree(); err != nil {
+	branches, err := repoDataLoader.loadBranches()
+	if err != nil {
 		return
 	}
-	defer commitTree.Free()
 
-	if commit.commit.ParentCount() > 0 {
-		if parentTree, err = commit.commit.Parent(0).Tree(); err != nil {
+	for _, remoteName := range remoteNames {
+		var remoteRefs map[string]bool
+		if remoteRefs, err = repoDataLoader.remoteRefs(remoteName); err != nil {
 			return
 		}
-		defer parentTree.Free()
+
+		remoteBranchPrefix := rdlRemoteRefPrefix + remoteName + "/"
+
+		for _, branch := range branches {
+			if !branch.IsRemote() || !strings.HasPrefix(branch.Name(), remoteBranchPrefix) {
+				continue
+			}
+
+			branchName := strings.TrimPrefix(branch.Name(), remoteBranchPrefix)
+			if branchName == RdlHeadRef {
+				continue
+			}
+
+			if _, exists := remoteRefs[rdlLocalRefPrefix+branchName]; !exists {
+				log.Debugf("Remote branch %v no longer exists on remote %v", branch.Name(), remoteName)
+				staleBranches = append(staleBranches, branch.Name())
+			}
+		}
 	}
 
-	options, err := git.DefaultDiffOptions()
+	return
+}
+
+// RemoteURL returns the URL of the remote with the provided name
+func (repoDataLoader *RepoDataLoader) RemoteURL(remoteName string) (url string, err error) {
+	remote, err := repoDataLoader.repo.Remotes.Lookup(remoteName)
 	if err != nil {
 		return
 	}
+	defer remote.Free()
+
+	return remote.Url(), nil
+}
+
+func (repoDataLoader *RepoDataLoader) remoteRefs(remoteName string) (remoteRefs map[string]bool, err error) {
+	log.Debugf("Listing refs for remote %v", remoteName)
+
+	// git is used to list the refs so the credential helpers, ssh agent and ssh configuration of the user apply.
+	// Prompting for credentials on the terminal is disabled as the terminal is in use by grv
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", "--git-dir", repoDataLoader.repo.Path(), "ls-remote", "--heads", remoteName)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	cmd.Stderr = &stderr
 
-	commitDiff, err := repoDataLoader.repo.DiffTreeToTree(parentTree, commitTree, &options)
+	output, err := cmd.Output()
 	if err != nil {
+		err = fmt.Errorf("Unable to list refs of remote %v: %v", remoteName, strings.TrimSpace(stderr.String()))
 		return
 	}
-	defer commitDiff.Free()
 
-	return repoDataLoader.generateDiff(commitDiff)
-}
+	remoteRefs = make(map[string]bool)
+	for _, line := range strings.Split(string(output), "\n") {
+		// Each line has the form: <oid>\t<ref>
+		if fields := strings.Split(line, "\t"); len(fields) == 2 {
+			remoteRefs[fields[1]] = true
+		}
+	}
 
-// DiffStage returns a diff for all files in the provided stage
-func (repoDataLoader *RepoDataLoader) DiffStage(statusType StatusType) (diff *Diff, err error) {
-	diff = &Diff{}
+	return
+}
 
-	rawDiff, err := repoDataLoader.generateRawDiff(statusType)
-	if err != nil || rawDiff == nil {
+// DeleteRef deletes the ref with the provided name
+func (repoDataLoader *RepoDataLoader) DeleteRef(refName string) (err error) {
+	rawRef, err := repoDataLoader.repo.References.Lookup(refName)
+	if err != nil {
 		return
 	}
-	defer rawDiff.Free()
+	defer rawRef.Free()
+
+	log.Debugf("Deleting ref %v", refName)
 
-	return repoDataLoader.generateDiff(rawDiff)
+	return rawRef.Delete()
 }
 
-// DiffFile Generates a diff for the provided file
-// If statusType is StStaged then the diff is between HEAD and the index
-// If statusType is StUnstaged then the diff is between index and the working directory
-func (repoDataLoader *RepoDataLoader) DiffFile(statusType StatusType, path string) (diff *Diff, err error) {
-	diff = &Diff{}
+// LoadStashes loads the stash entries of the repository, most recent first
+func (repoDataLoader *RepoDataLoader) LoadStashes() (stashes []*StashEntry, err error) {
+	log.Debug("Loading stashes")
 
-	rawDiff, err := repoDataLoader.generateRawDiff(statusType)
-	if err != nil || rawDiff == nil {
-		return
-	}
-	defer rawDiff.Free()
+	err = repoDataLoader.repo.Stashes.Foreach(func(index int, message string, rawOid *git.Oid) error {
+		stashes = append(stashes, &StashEntry{
+			index:   index,
+			message: message,
+			oid:     &Oid{oid: rawOid},
+		})
 
-	numDeltas, err := rawDiff.NumDeltas()
+		return nil
+	})
+
+	return
+}
+
+// SaveStash stashes the changes in the working tree and index
+func (repoDataLoader *RepoDataLoader) SaveStash(message string) (err error) {
+	signature, err := repoDataLoader.repo.DefaultSignature()
 	if err != nil {
 		return
 	}
 
-	var diffDelta git.DiffDelta
+	log.Debugf("Saving stash with message: %v", message)
+
+	_, err = repoDataLoader.repo.Stashes.Save(signature, message, git.StashDefault)
+
+	return
+}
+
+// ApplyStash applies the changes of the stash entry with the provided index to the working tree.
+// The stash entry is removed if pop is true and the changes were applied successfully
+func (repoDataLoader *RepoDataLoader) ApplyStash(index int, pop bool) (err error) {
+	options, err := git.DefaultStashApplyOptions()
+	if err != nil {
+		return
+	}
+
+	log.Debugf("Applying stash@{%v}. Pop: %v", index, pop)
+
+	if pop {
+		return repoDataLoader.repo.Stashes.Pop(index, options)
+	}
+
+	return repoDataLoader.repo.Stashes.Apply(index, options)
+}
+
+// DropStash removes the stash entry with the provided index
+func (repoDataLoader *RepoDataLoader) DropStash(index int) error {
+	log.Debugf("Dropping stash@{%v}", index)
+	return repoDataLoader.repo.Stashes.Drop(index)
+}
+
+var repositoryStateNames = map[git.RepositoryState]string{
+	git.RepositoryStateMerge:                "merge",
+	git.RepositoryStateRevert:               "revert",
+	git.RepositoryStateCherrypick:           "cherry-pick",
+	git.RepositoryStateBisect:               "bisect",
+	git.RepositoryStateRebase:               "rebase",
+	git.RepositoryStateRebaseInteractive:    "rebase",
+	git.RepositoryStateRebaseMerge:          "rebase",
+	git.RepositoryStateApplyMailbox:         "am",
+	git.RepositoryStateApplyMailboxOrRebase: "am",
+}
+
+// RepositoryState returns the name of the operation in progress (e.g. merge or rebase)
+// or an empty string if no operation is in progress
+func (repoDataLoader *RepoDataLoader) RepositoryState() string {
+	return repositoryStateNames[repoDataLoader.repo.State()]
+}
+
+// Bisect runs git bisect with the provided arguments in the working directory and returns its output
+func (repoDataLoader *RepoDataLoader) Bisect(args ...string) (output string, err error) {
+	log.Debugf("Running git bisect %v", strings.Join(args, " "))
+	return repoDataLoader.runGitCommand(append([]string{"bisect"}, args...)...)
+}
+
+// AmendCommit amends HEAD with the changes staged in the index, reusing the existing commit message
+func (repoDataLoader *RepoDataLoader) AmendCommit() (err error) {
+	log.Debug("Amending HEAD with staged changes")
+	_, err = repoDataLoader.runGitCommand("commit", "--amend", "--no-edit")
+	return
+}
+
+// DiscardChanges restores the file at the provided path from the index, discarding its unstaged changes.
+// When includeStaged is true the file is restored from HEAD, discarding its staged changes as well
+func (repoDataLoader *RepoDataLoader) DiscardChanges(path string, includeStaged bool) (err error) {
+	log.Debugf("Discarding changes to %v. Include staged: %v", path, includeStaged)
+
+	args := []string{"checkout"}
+	if includeStaged {
+		args = append(args, "HEAD")
+	}
+
+	_, err = repoDataLoader.runGitCommand(append(args, "--", path)...)
+
+	return
+}
+
+// StageFile adds the current content of the file at the provided path to the index
+func (repoDataLoader *RepoDataLoader) StageFile(path string) (err error) {
+	log.Debugf("Staging %v", path)
+	_, err = repoDataLoader.runGitCommand("add", "--", path)
+	return
+}
+
+// AddIgnorePattern appends the pattern to the .gitignore file in the root of the working directory.
+// The file is created if it doesn't exist
+func (repoDataLoader *RepoDataLoader) AddIgnorePattern(pattern string) (err error) {
+	gitignorePath := filepath.Join(repoDataLoader.Workdir(), giFileName)
+
+	content, err := ioutil.ReadFile(gitignorePath)
+	if err != nil && !os.IsNotExist(err) {
+		return
+	}
+
+	updatedContent, appended := appendIgnorePattern(string(content), pattern)
+	if !appended {
+		return fmt.Errorf("%v already contains %v", giFileName, pattern)
+	}
+
+	log.Debugf("Adding pattern %v to %v", pattern, gitignorePath)
+
+	return ioutil.WriteFile(gitignorePath, []byte(updatedContent), 0644)
+}
+
+// BackupStash adds the current state of the index and working tree to the stash
+// without modifying them. Nothing is stored when there are no local changes
+func (repoDataLoader *RepoDataLoader) BackupStash(message string) (err error) {
+	output, err := repoDataLoader.runGitCommand("stash", "create", message)
+	if err != nil {
+		return
+	}
+
+	if oid := strings.TrimSpace(output); oid != "" {
+		log.Debugf("Storing backup stash %v with message: %v", oid, message)
+		_, err = repoDataLoader.runGitCommand("stash", "store", "-m", message, oid)
+	}
+
+	return
+}
+
+// runGitCommand runs git with the provided arguments in the working directory and returns its output.
+// The C locale is used so output which is parsed, e.g. by git bisect, is not translated
+func (repoDataLoader *RepoDataLoader) runGitCommand(args ...string) (output string, err error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoDataLoader.Workdir()
+	cmd.Env = append(os.Environ(), "LC_ALL=C")
+
+	rawOutput, err := cmd.CombinedOutput()
+	output = string(rawOutput)
+
+	if err != nil {
+		err = fmt.Errorf("git %v failed: %v", args[0], strings.TrimSpace(output))
+	}
+
+	return
+}
+
+// ApplyToIndex applies the provided patch to the index without modifying the working directory
+func (repoDataLoader *RepoDataLoader) ApplyToIndex(patch string) (err error) {
+	log.Debugf("Applying patch to index:\n%v", patch)
+
+	cmd := exec.Command("git", "apply", "--cached", "-")
+	cmd.Dir = repoDataLoader.Workdir()
+	cmd.Stdin = strings.NewReader(patch)
+
+	if output, cmdErr := cmd.CombinedOutput(); cmdErr != nil {
+		err = fmt.Errorf("git apply --cached failed: %v", strings.TrimSpace(string(output)))
+	}
+
+	return
+}
+
+// LoadConflicts loads the files which have conflicting changes in the index
+func (repoDataLoader *RepoDataLoader) LoadConflicts() (conflicts []*Conflict, err error) {
+	log.Debug("Loading conflicts")
+
+	index, err := repoDataLoader.repo.Index()
+	if err != nil {
+		return
+	}
+	defer index.Free()
+
+	if !index.HasConflicts() {
+		return
+	}
+
+	iterator, err := index.ConflictIterator()
+	if err != nil {
+		return
+	}
+	defer iterator.Free()
+
+	for {
+		indexConflict, iterErr := iterator.Next()
+		if git.IsErrorCode(iterErr, git.ErrIterOver) {
+			break
+		} else if iterErr != nil {
+			return nil, iterErr
+		}
+
+		conflict := &Conflict{}
+
+		for _, stage := range []struct {
+			entry *git.IndexEntry
+			oid   **Oid
+		}{
+			{entry: indexConflict.Ancestor, oid: &conflict.ancestorOid},
+			{entry: indexConflict.Our, oid: &conflict.ourOid},
+			{entry: indexConflict.Their, oid: &conflict.theirOid},
+		} {
+			if stage.entry != nil {
+				conflict.path = stage.entry.Path
+				*stage.oid = &Oid{oid: stage.entry.Id}
+			}
+		}
+
+		conflicts = append(conflicts, conflict)
+	}
+
+	return
+}
+
+// ConflictContent generates the three-way merged content of a conflicted file with diff3 style conflict markers
+func (repoDataLoader *RepoDataLoader) ConflictContent(conflict *Conflict) (conflictLines []ConflictLine, err error) {
+	log.Debugf("Generating conflict content for %v", conflict.path)
+
+	var inputs [3]git.MergeFileInput
+
+	for inputIndex, oid := range []*Oid{conflict.ancestorOid, conflict.ourOid, conflict.theirOid} {
+		inputs[inputIndex].Path = conflict.path
+
+		if oid == nil {
+			continue
+		}
+
+		var blob *git.Blob
+		if blob, err = repoDataLoader.repo.LookupBlob(oid.oid); err != nil {
+			return
+		}
+
+		inputs[inputIndex].Contents = blob.Contents()
+		blob.Free()
+	}
+
+	result, err := git.MergeFile(inputs[0], inputs[1], inputs[2], &git.MergeFileOptions{
+		AncestorLabel: "base",
+		OurLabel:      "ours",
+		TheirLabel:    "theirs",
+		Flags:         git.MergeFileStyleDiff3,
+	})
+	if err != nil {
+		return
+	}
+	defer result.Free()
+
+	conflictLines = ParseConflictContent(string(result.Contents))
+
+	return
+}
+
+// ResolveConflict resolves a conflicted file by checking out the version from the chosen side
+// of the conflict and staging it. If the file was deleted on the chosen side it is removed
+func (repoDataLoader *RepoDataLoader) ResolveConflict(path string, resolution ConflictResolution) (err error) {
+	log.Debugf("Resolving conflict for %v using %v", path, resolution)
+
+	workdir := repoDataLoader.repo.Workdir()
+	if workdir == "" {
+		return fmt.Errorf("Unable to resolve conflicts in a bare repository")
+	}
+
+	index, err := repoDataLoader.repo.Index()
+	if err != nil {
+		return
+	}
+	defer index.Free()
+
+	indexConflict, err := index.Conflict(path)
+	if err != nil {
+		return
+	}
+
+	entry := indexConflict.Our
+	if resolution == CrTheirs {
+		entry = indexConflict.Their
+	}
+
+	filePath := filepath.Join(workdir, path)
+
+	if entry == nil {
+		if err = index.RemoveConflict(path); err != nil {
+			return
+		}
+
+		if err = os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			return
+		}
+	} else {
+		if err = repoDataLoader.checkoutIndexEntry(entry, filePath); err != nil {
+			return
+		}
+
+		if err = index.AddByPath(path); err != nil {
+			return
+		}
+	}
+
+	return index.Write()
+}
+
+// Blame runs git blame to determine the commit which last modified each line of the file at the provided revision
+func (repoDataLoader *RepoDataLoader) Blame(revision, path string) (blameLines []*BlameLine, err error) {
+	log.Debugf("Loading blame for %v at %v", path, revision)
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", "--git-dir", repoDataLoader.repo.Path(), "blame", "--line-porcelain", revision, "--", path)
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("Unable to blame %v at %v: %v", path, revision, strings.TrimSpace(stderr.String()))
+	}
+
+	return parseBlame(output)
+}
+
+func (repoDataLoader *RepoDataLoader) checkoutIndexEntry(entry *git.IndexEntry, filePath string) (err error) {
+	blob, err := repoDataLoader.repo.LookupBlob(entry.Id)
+	if err != nil {
+		return
+	}
+	defer blob.Free()
+
+	if err = os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return
+	}
+
+	if err = os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		return
+	}
+
+	switch entry.Mode {
+	case git.FilemodeLink:
+		return os.Symlink(string(blob.Contents()), filePath)
+	case git.FilemodeBlobExecutable:
+		return ioutil.WriteFile(filePath, blob.Contents(), 0755)
+	}
+
+	return ioutil.WriteFile(filePath, blob.Contents(), 0644)
+}
+
+// DiffCommit loads a diff between the commit with the specified oid and the provided base.
+// If the base is the parent of the commit and the commit has more than one parent no diff is returned.
+// Generation stops with an error if the provided task is cancelled
+func (repoDataLoader *RepoDataLoader) DiffCommit(commit *Commit, diffBase DiffBase, diffTask *DiffTask) (diff *Diff, err error) {
+	diff = &Diff{}
+
+	if diffBase == DbParent && commit.commit.ParentCount() > 1 {
+		return
+	}
+
+	return repoDataLoader.generateCommitDiff(diffTask, func(options *git.DiffOptions) (*git.Diff, error) {
+		return repoDataLoader.diffCommitTreeToBase(commit, diffBase, options)
+	})
+}
+
+// DiffMergeCommit loads a diff between the merge commit with the specified oid and one of its parents.
+// Parents are numbered from 1 and a parent number of 0 loads the combined diff of the commit against all of its parents.
+// Generation stops with an error if the provided task is cancelled
+func (repoDataLoader *RepoDataLoader) DiffMergeCommit(commit *Commit, parentNumber uint, diffTask *DiffTask) (diff *Diff, err error) {
+	if parentNumber == 0 {
+		return repoDataLoader.combinedDiff(commit, diffTask)
+	}
+
+	if parentNumber > commit.commit.ParentCount() {
+		return nil, fmt.Errorf("Commit %v has no parent %v", commit.oid.ShortID(), parentNumber)
+	}
+
+	return repoDataLoader.generateCommitDiff(diffTask, func(options *git.DiffOptions) (*git.Diff, error) {
+		return repoDataLoader.diffCommitTreeToParent(commit, parentNumber-1, options)
+	})
+}
+
+// generateCommitDiff generates a diff from the git diff created by the provided function.
+// The git diff is created with options that stop diff generation when the task is cancelled
+func (repoDataLoader *RepoDataLoader) generateCommitDiff(diffTask *DiffTask, createDiff func(*git.DiffOptions) (*git.Diff, error)) (diff *Diff, err error) {
+	diff = &Diff{}
+
+	options, err := repoDataLoader.diffOptions()
+	if err != nil {
+		return
+	}
+
+	options.NotifyCallback = func(diffSoFar *git.Diff, delta git.DiffDelta, matchedPathspec string) error {
+		if diffTask.Cancelled() {
+			return errDiffCancelled
+		}
+
+		return nil
+	}
+
+	commitDiff, err := createDiff(&options)
+	if err != nil {
+		if diffTask.Cancelled() {
+			err = errDiffCancelled
+		}
+
+		return
+	}
+	defer commitDiff.Free()
+
+	return repoDataLoader.generateDiff(commitDiff, diffTask)
+}
+
+// combinedDiff runs git show --cc to generate the combined diff of a merge commit as libgit2 is unable to produce one
+func (repoDataLoader *RepoDataLoader) combinedDiff(commit *Commit, diffTask *DiffTask) (diff *Diff, err error) {
+	diff = &Diff{}
+
+	args := []string{"--git-dir", repoDataLoader.repo.Path(), "show", "--cc", "--format=", "--no-color", "--no-ext-diff"}
+
+	switch DiffWhitespace(atomic.LoadInt32(&repoDataLoader.diffWhitespace)) {
+	case DwAll:
+		args = append(args, "--ignore-all-space")
+	case DwChange:
+		args = append(args, "--ignore-space-change")
+	case DwTrailing:
+		args = append(args, "--ignore-space-at-eol")
+	}
+
+	args = append(args, "--diff-algorithm="+DiffAlgorithm(atomic.LoadInt32(&repoDataLoader.diffAlgorithm)).String())
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", append(args, commit.oid.String())...)
+	cmd.Stdout = &diff.diffText
+	cmd.Stderr = &stderr
+
+	if err = cmd.Run(); err != nil {
+		err = fmt.Errorf("Unable to generate combined diff for %v: %v", commit.oid.ShortID(), strings.TrimSpace(stderr.String()))
+	} else if diffTask.Cancelled() {
+		err = errDiffCancelled
+	}
+
+	return
+}
+
+// CommitStats returns the number of files and lines changed by the commit relative to its first parent.
+// Merge commits have no stats
+func (repoDataLoader *RepoDataLoader) CommitStats(commit *Commit) (commitStats CommitStats, err error) {
+	if commit.commit.ParentCount() > 1 {
+		return
+	}
+
+	options, err := git.DefaultDiffOptions()
+	if err != nil {
+		return
+	}
+
+	commitDiff, err := repoDataLoader.diffCommitTree(commit, &options)
+	if err != nil {
+		return
+	}
+	defer commitDiff.Free()
+
+	if err = repoDataLoader.findSimilar(commitDiff); err != nil {
+		return
+	}
+
+	stats, err := commitDiff.Stats()
+	if err != nil {
+		return
+	}
+	defer stats.Free()
+
+	commitStats = CommitStats{
+		filesChanged: stats.FilesChanged(),
+		insertions:   stats.Insertions(),
+		deletions:    stats.Deletions(),
+	}
+
+	return
+}
+
+// CommitSignatureStatus checks whether the commit is signed and if so verifies the signature using git
+func (repoDataLoader *RepoDataLoader) CommitSignatureStatus(commit *Commit) (signatureStatus SignatureStatus, err error) {
+	odb, err := repoDataLoader.repo.Odb()
+	if err != nil {
+		return
+	}
+	defer odb.Free()
+
+	object, err := odb.Read(commit.oid.oid)
+	if err != nil {
+		return
+	}
+	defer object.Free()
+
+	if !isSignedCommitObject(object.Data()) {
+		return SignatureNone, nil
+	}
+
+	output, _ := exec.Command("git", "--git-dir", repoDataLoader.repo.Path(), "verify-commit", "--raw", commit.oid.String()).CombinedOutput()
+
+	return parseVerifyCommitOutput(string(output)), nil
+}
+
+// LineHistory runs git log -L to generate the commits and diff hunks which modified the provided line range
+func (repoDataLoader *RepoDataLoader) LineHistory(lineRange LineRange) (output []byte, err error) {
+	log.Debugf("Loading history for lines %v at %v", lineRange, lineRange.revision)
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", "--git-dir", repoDataLoader.repo.Path(), "log",
+		fmt.Sprintf("-L%v,%v:%v", lineRange.start, lineRange.end, lineRange.path), lineRange.revision, "--")
+	cmd.Stderr = &stderr
+
+	if output, err = cmd.Output(); err != nil {
+		err = fmt.Errorf("Unable to load history for lines %v: %v", lineRange, strings.TrimSpace(stderr.String()))
+	}
+
+	return
+}
+
+// PickaxeMatches returns true if the diff between the commit and its parent matches the provided pickaxe.
+// As with git log -S and -G, merge commits are not matched
+func (repoDataLoader *RepoDataLoader) PickaxeMatches(commit *Commit, pickaxe *Pickaxe) (matches bool, err error) {
+	if commit.commit.ParentCount() > 1 {
+		return
+	}
+
+	options, err := git.DefaultDiffOptions()
+	if err != nil {
+		return
+	}
+
+	commitDiff, err := repoDataLoader.diffCommitTree(commit, &options)
+	if err != nil {
+		return
+	}
+	defer commitDiff.Free()
+
+	var addedLines, removedLines []string
+
+	checkFile := func() {
+		matches = matches || pickaxe.MatchesFileLines(addedLines, removedLines)
+		addedLines, removedLines = nil, nil
+	}
+
+	err = commitDiff.ForEach(func(delta git.DiffDelta, progress float64) (git.DiffForEachHunkCallback, error) {
+		if checkFile(); matches {
+			return nil, errPickaxeMatched
+		}
+
+		return func(hunk git.DiffHunk) (git.DiffForEachLineCallback, error) {
+			return func(line git.DiffLine) error {
+				switch line.Origin {
+				case git.DiffLineAddition:
+					addedLines = append(addedLines, line.Content)
+				case git.DiffLineDeletion:
+					removedLines = append(removedLines, line.Content)
+				}
+
+				return nil
+			}, nil
+		}, nil
+	}, git.DiffDetailLines)
+
+	if err == errPickaxeMatched {
+		return true, nil
+	} else if err != nil {
+		return
+	}
+
+	checkFile()
+
+	return
+}
+
+func (repoDataLoader *RepoDataLoader) diffCommitTree(commit *Commit, options *git.DiffOptions) (commitDiff *git.Diff, err error) {
+	return repoDataLoader.diffCommitTreeToParent(commit, 0, options)
+}
+
+// diffCommitTreeToParent diffs the tree of the commit against the tree of the parent with the provided index.
+// Commits without the parent are diffed against an empty tree
+func (repoDataLoader *RepoDataLoader) diffCommitTreeToParent(commit *Commit, parentIndex uint, options *git.DiffOptions) (commitDiff *git.Diff, err error) {
+	var commitTree, parentTree *git.Tree
+	if commitTree, err = commit.commit.Tree(); err != nil {
+		return
+	}
+	defer commitTree.Free()
+
+	if commit.commit.ParentCount() > parentIndex {
+		if parentTree, err = commit.commit.Parent(parentIndex).Tree(); err != nil {
+			return
+		}
+		defer parentTree.Free()
+	}
+
+	return repoDataLoader.repo.DiffTreeToTree(parentTree, commitTree, options)
+}
+
+// diffCommitTreeToBase diffs the tree of the commit against the provided base.
+// Diffs against the working tree include staged changes
+func (repoDataLoader *RepoDataLoader) diffCommitTreeToBase(commit *Commit, diffBase DiffBase, options *git.DiffOptions) (commitDiff *git.Diff, err error) {
+	if diffBase == DbParent {
+		return repoDataLoader.diffCommitTree(commit, options)
+	}
+
+	commitTree, err := commit.commit.Tree()
+	if err != nil {
+		return
+	}
+	defer commitTree.Free()
+
+	if diffBase == DbWorkingTree {
+		return repoDataLoader.repo.DiffTreeToWorkdirWithIndex(commitTree, options)
+	}
+
+	index, err := repoDataLoader.repo.Index()
+	if err != nil {
+		return
+	}
+	defer index.Free()
+
+	return repoDataLoader.repo.DiffTreeToIndex(commitTree, index, options)
+}
+
+// DiffStage returns a diff for all files in the provided stage
+func (repoDataLoader *RepoDataLoader) DiffStage(statusType StatusType) (diff *Diff, err error) {
+	diff = &Diff{}
+
+	rawDiff, err := repoDataLoader.generateRawDiff(statusType)
+	if err != nil || rawDiff == nil {
+		return
+	}
+	defer rawDiff.Free()
+
+	return repoDataLoader.generateDiff(rawDiff, nil)
+}
+
+// DiffFile Generates a diff for the provided file
+// If statusType is StStaged then the diff is between HEAD and the index
+// If statusType is StUnstaged then the diff is between index and the working directory
+func (repoDataLoader *RepoDataLoader) DiffFile(statusType StatusType, path string) (diff *Diff, err error) {
+	diff = &Diff{}
+
+	rawDiff, err := repoDataLoader.generateRawDiff(statusType)
+	if err != nil || rawDiff == nil {
+		return
+	}
+	defer rawDiff.Free()
+
+	if err = repoDataLoader.findSimilar(rawDiff); err != nil {
+		return
+	}
+
+	numDeltas, err := rawDiff.NumDeltas()
+	if err != nil {
+		return
+	}
+
+	var diffDelta git.DiffDelta
 	var patch *git.Patch
 	var patchString string
 
@@ -961,7 +2107,7 @@ func (repoDataLoader *RepoDataLoader) DiffFile(statusType StatusType, path strin
 				return
 			}
 
-			diff.diffText.WriteString(patchString)
+			diff.diffText.WriteString(repoDataLoader.applyDiffWhitespace(summariseRenameLines(patchString)))
 
 			if err := patch.Free(); err != nil {
 				log.Errorf("Error when freeing patch: %v", err)
@@ -1000,7 +2146,7 @@ func (repoDataLoader *RepoDataLoader) generateRawDiff(statusType StatusType) (ra
 			return
 		}
 
-		if options, err = git.DefaultDiffOptions(); err != nil {
+		if options, err = repoDataLoader.diffOptions(); err != nil {
 			return
 		}
 
@@ -1012,7 +2158,7 @@ func (repoDataLoader *RepoDataLoader) generateRawDiff(statusType StatusType) (ra
 			return
 		}
 
-		if options, err = git.DefaultDiffOptions(); err != nil {
+		if options, err = repoDataLoader.diffOptions(); err != nil {
 			return
 		}
 
@@ -1024,9 +2170,13 @@ func (repoDataLoader *RepoDataLoader) generateRawDiff(statusType StatusType) (ra
 	return
 }
 
-func (repoDataLoader *RepoDataLoader) generateDiff(rawDiff *git.Diff) (diff *Diff, err error) {
+func (repoDataLoader *RepoDataLoader) generateDiff(rawDiff *git.Diff, diffTask *DiffTask) (diff *Diff, err error) {
 	diff = &Diff{}
 
+	if err = repoDataLoader.findSimilar(rawDiff); err != nil {
+		return
+	}
+
 	stats, err := rawDiff.Stats()
 	if err != nil {
 		return
@@ -1048,6 +2198,10 @@ func (repoDataLoader *RepoDataLoader) generateDiff(rawDiff *git.Diff) (diff *Dif
 	var patchString string
 
 	for i := 0; i < numDeltas; i++ {
+		if diffTask.Cancelled() {
+			return nil, errDiffCancelled
+		}
+
 		if patch, err = rawDiff.Patch(i); err != nil {
 			return
 		}
@@ -1056,11 +2210,26 @@ func (repoDataLoader *RepoDataLoader) generateDiff(rawDiff *git.Diff) (diff *Dif
 			return
 		}
 
+		// Binary detection happens when the patch is generated, so the delta is retrieved afterwards
+		var delta git.DiffDelta
+		if delta, err = rawDiff.GetDelta(i); err != nil {
+			return
+		}
+
+		if delta.Flags&git.DiffFlagBinary != 0 {
+			summary := binaryDiffSummary(delta.OldFile.Size, delta.NewFile.Size, delta.Status == git.DeltaAdded, delta.Status == git.DeltaDeleted)
+			patchString = replaceBinaryFilesLine(patchString, summary)
+		}
+
+		patchString = repoDataLoader.applyDiffWhitespace(summariseRenameLines(patchString))
+
 		diff.diffText.WriteString(patchString)
 
 		if err := patch.Free(); err != nil {
 			log.Errorf("Error when freeing patch: %v", err)
 		}
+
+		diffTask.reportProgress(i+1, numDeltas)
 	}
 
 	return
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	log "github.com/Sirupsen/logrus"
+	git "gopkg.in/libgit2/git2go.v25"
+)
+
+// DiscoverRepository finds the git directory of the repository containing the provided path.
+// The provided path and each of its parents are checked in turn by libgit2, so .git files
+// (as created for worktrees and submodules) and bare repositories are supported
+func DiscoverRepository(repoPath string) (gitDirPath string, err error) {
+	path, err := CanonicalPath(repoPath)
+	if err != nil {
+		return
+	}
+
+	log.Debugf("Discovering repository containing: %v", path)
+
+	if gitDirPath, err = git.Discover(path, false, nil); err != nil {
+		return "", fmt.Errorf("Unable to find a git repository in %v or any of its parent directories: %v", repoPath, err)
+	}
+
+	// libgit2 returns the git directory with a trailing separator
+	gitDirPath = filepath.Clean(gitDirPath)
+
+	log.Debugf("Found git directory %v", gitDirPath)
+
+	return
+}
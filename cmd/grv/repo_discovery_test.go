@@ -0,0 +1,113 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	git "gopkg.in/libgit2/git2go.v25"
+)
+
+func createTestDirs(t *testing.T, root string, dirs ...string) {
+	for _, dir := range dirs {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatalf("Unable to create directory %v: %v", dir, err)
+		}
+	}
+}
+
+func createTestFile(t *testing.T, root, file, content string) {
+	if err := ioutil.WriteFile(filepath.Join(root, file), []byte(content), 0644); err != nil {
+		t.Fatalf("Unable to create file %v: %v", file, err)
+	}
+}
+
+func createTestRepository(t *testing.T, root, path string, bare bool) {
+	repo, err := git.InitRepository(filepath.Join(root, path), bare)
+	if err != nil {
+		t.Fatalf("Unable to create repository %v: %v", path, err)
+	}
+
+	repo.Free()
+}
+
+func createTestRoot(t *testing.T) string {
+	root, err := ioutil.TempDir("", "grv-discovery")
+	if err != nil {
+		t.Fatalf("Unable to create temporary directory: %v", err)
+	}
+
+	if root, err = CanonicalPath(root); err != nil {
+		t.Fatalf("Unable to determine canonical path: %v", err)
+	}
+
+	return root
+}
+
+func TestRepositoryIsDiscoveredFromSubdirectory(t *testing.T) {
+	root := createTestRoot(t)
+	defer os.RemoveAll(root)
+
+	createTestRepository(t, root, "repo", false)
+	createTestDirs(t, root, "repo/src/pkg")
+
+	gitDirPath, err := DiscoverRepository(filepath.Join(root, "repo/src/pkg"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if expected := filepath.Join(root, "repo/.git"); gitDirPath != expected {
+		t.Errorf("Git directory does not match expected value. Expected: %v, Actual: %v", expected, gitDirPath)
+	}
+}
+
+func TestGitDirFileIsFollowedForSubmodules(t *testing.T) {
+	root := createTestRoot(t)
+	defer os.RemoveAll(root)
+
+	createTestRepository(t, root, "repo", false)
+	createTestRepository(t, root, "repo/.git/modules/lib", true)
+	createTestDirs(t, root, "repo/lib/src")
+	createTestFile(t, root, "repo/lib/.git", "gitdir: ../.git/modules/lib\n")
+
+	gitDirPath, err := DiscoverRepository(filepath.Join(root, "repo/lib/src"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if expected := filepath.Join(root, "repo/.git/modules/lib"); gitDirPath != expected {
+		t.Errorf("Git directory does not match expected value. Expected: %v, Actual: %v", expected, gitDirPath)
+	}
+}
+
+func TestInvalidGitDirFileReturnsError(t *testing.T) {
+	root := createTestRoot(t)
+	defer os.RemoveAll(root)
+
+	createTestDirs(t, root, "invalid", "missing")
+	createTestFile(t, root, "invalid/.git", "not a gitdir file\n")
+	createTestFile(t, root, "missing/.git", "gitdir: ../does-not-exist\n")
+
+	for _, path := range []string{"invalid", "missing"} {
+		if _, err := DiscoverRepository(filepath.Join(root, path)); err == nil {
+			t.Errorf("Expected error for path %v", path)
+		}
+	}
+}
+
+func TestBareRepositoryIsDiscovered(t *testing.T) {
+	root := createTestRoot(t)
+	defer os.RemoveAll(root)
+
+	createTestRepository(t, root, "bare.git", true)
+
+	gitDirPath, err := DiscoverRepository(filepath.Join(root, "bare.git/refs/heads"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if expected := filepath.Join(root, "bare.git"); gitDirPath != expected {
+		t.Errorf("Git directory does not match expected value. Expected: %v, Actual: %v", expected, gitDirPath)
+	}
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	rwCommonDirFile   = "commondir"
+	rwPackedRefsFile  = "packed-refs"
+	rwIndexFile       = "index"
+	rwRefsDir         = "refs"
+	rwLockFileSuffix  = ".lock"
+	rwRecursiveSuffix = "/..."
+)
+
+// RepositoryChange describes the repository data affected by a filesystem event
+type RepositoryChange struct {
+	refsChanged   bool
+	statusChanged bool
+}
+
+// RepositoryWatchPaths contains the directories of a repository which are
+// monitored for changes and determines which data a change affects
+type RepositoryWatchPaths struct {
+	gitDir    string
+	commonDir string
+	workdir   string
+}
+
+// NewRepositoryWatchPaths creates a new instance for the repository with the provided git directory and working directory.
+// The common directory of a worktree, which contains the refs shared by all worktrees, is read from the git directory
+func NewRepositoryWatchPaths(gitDir, workdir string) *RepositoryWatchPaths {
+	watchPaths := &RepositoryWatchPaths{
+		gitDir:    filepath.Clean(gitDir),
+		commonDir: filepath.Clean(gitDir),
+	}
+
+	if workdir != "" {
+		watchPaths.workdir = filepath.Clean(workdir)
+	}
+
+	if content, err := ioutil.ReadFile(filepath.Join(gitDir, rwCommonDirFile)); err == nil {
+		commonDir := strings.TrimSpace(string(content))
+
+		if !filepath.IsAbs(commonDir) {
+			commonDir = filepath.Join(gitDir, commonDir)
+		}
+
+		watchPaths.commonDir = filepath.Clean(commonDir)
+		log.Debugf("Using common directory %v", watchPaths.commonDir)
+	}
+
+	return watchPaths
+}
+
+// WatchDirs returns the recursive watch paths required to monitor the repository
+func (watchPaths *RepositoryWatchPaths) WatchDirs() (watchDirs []string) {
+	var dirs []string
+
+	for _, dir := range []string{watchPaths.workdir, watchPaths.gitDir, watchPaths.commonDir} {
+		if dir == "" {
+			continue
+		}
+
+		contained := false
+		for _, existingDir := range dirs {
+			if isWithinDir(dir, existingDir) {
+				contained = true
+				break
+			}
+		}
+
+		if !contained {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	for _, dir := range dirs {
+		watchDirs = append(watchDirs, dir+rwRecursiveSuffix)
+	}
+
+	return
+}
+
+// Classify determines which repository data is affected by a change to the provided path.
+// Changes to HEAD, packed-refs or refs affect refs. Changes to the index or working
+// directory affect the status. Lock files and other git directory content are ignored
+func (watchPaths *RepositoryWatchPaths) Classify(path string) (change RepositoryChange) {
+	path = filepath.Clean(path)
+
+	if strings.HasSuffix(path, rwLockFileSuffix) {
+		return
+	}
+
+	for _, gitDir := range []string{watchPaths.gitDir, watchPaths.commonDir} {
+		if !isWithinDir(path, gitDir) {
+			continue
+		}
+
+		relPath, err := filepath.Rel(gitDir, path)
+		if err != nil {
+			return
+		}
+
+		switch {
+		case relPath == RdlHeadRef:
+			change.refsChanged = true
+			change.statusChanged = true
+		case relPath == rwPackedRefsFile || isWithinDir(relPath, rwRefsDir):
+			change.refsChanged = true
+		case relPath == rwIndexFile:
+			change.statusChanged = true
+		}
+
+		if change.refsChanged || change.statusChanged {
+			return
+		}
+	}
+
+	if isWithinDir(path, watchPaths.gitDir) || isWithinDir(path, watchPaths.commonDir) {
+		return
+	}
+
+	if watchPaths.workdir != "" && isWithinDir(path, watchPaths.workdir) {
+		change.statusChanged = true
+	}
+
+	return
+}
+
+// RepositoryModTimes contains the modification times of the files which determine the refs and status of a repository
+type RepositoryModTimes map[string]time.Time
+
+// ModTimes returns the modification times of HEAD, packed-refs, the index and all loose refs.
+// This allows changes to be detected by polling when filesystem events are unreliable
+func (watchPaths *RepositoryWatchPaths) ModTimes() RepositoryModTimes {
+	modTimes := make(RepositoryModTimes)
+
+	addModTime := func(path string, fileInfo os.FileInfo) {
+		if !fileInfo.IsDir() && !strings.HasSuffix(path, rwLockFileSuffix) {
+			modTimes[path] = fileInfo.ModTime()
+		}
+	}
+
+	files := []string{
+		filepath.Join(watchPaths.gitDir, RdlHeadRef),
+		filepath.Join(watchPaths.gitDir, rwIndexFile),
+		filepath.Join(watchPaths.commonDir, rwPackedRefsFile),
+	}
+
+	for _, file := range files {
+		if fileInfo, err := os.Stat(file); err == nil {
+			addModTime(file, fileInfo)
+		}
+	}
+
+	refsDir := filepath.Join(watchPaths.commonDir, rwRefsDir)
+	if err := filepath.Walk(refsDir, func(path string, fileInfo os.FileInfo, err error) error {
+		if err == nil {
+			addModTime(path, fileInfo)
+		}
+
+		return nil
+	}); err != nil {
+		log.Debugf("Unable to read refs directory %v: %v", refsDir, err)
+	}
+
+	return modTimes
+}
+
+// Changes compares the modification times with those previously recorded and
+// returns the repository data affected by any files which were added, modified or removed
+func (modTimes RepositoryModTimes) Changes(previousModTimes RepositoryModTimes, watchPaths *RepositoryWatchPaths) (change RepositoryChange) {
+	addChange := func(path string) {
+		pathChange := watchPaths.Classify(path)
+		change.refsChanged = change.refsChanged || pathChange.refsChanged
+		change.statusChanged = change.statusChanged || pathChange.statusChanged
+	}
+
+	for path, modTime := range modTimes {
+		if previousModTime, exists := previousModTimes[path]; !exists || !previousModTime.Equal(modTime) {
+			addChange(path)
+		}
+	}
+
+	for path := range previousModTimes {
+		if _, exists := modTimes[path]; !exists {
+			addChange(path)
+		}
+	}
+
+	return
+}
+
+func isWithinDir(path, dir string) bool {
+	return path == dir || strings.HasPrefix(path, dir+string(filepath.Separator))
+}
+
+// FileSystemMonitorStatus records whether the filesystem monitor is watching the repository
+// and receiving events. This allows polling to skip changes the filesystem monitor has already processed
+type FileSystemMonitorStatus struct {
+	watching      bool
+	eventReceived bool
+	lock          sync.Mutex
+}
+
+// SetWatching records whether the filesystem monitor is watching the repository
+func (status *FileSystemMonitorStatus) SetWatching(watching bool) {
+	status.lock.Lock()
+	defer status.lock.Unlock()
+
+	status.watching = watching
+	status.eventReceived = false
+}
+
+// RecordEvent records that the filesystem monitor received an event for a repository change
+func (status *FileSystemMonitorStatus) RecordEvent() {
+	status.lock.Lock()
+	defer status.lock.Unlock()
+
+	status.eventReceived = status.watching
+}
+
+// EventReceived returns true if the filesystem monitor is watching the repository
+// and has received an event since EventReceived was last called
+func (status *FileSystemMonitorStatus) EventReceived() (eventReceived bool) {
+	status.lock.Lock()
+	defer status.lock.Unlock()
+
+	eventReceived = status.watching && status.eventReceived
+	status.eventReceived = false
+
+	return
+}
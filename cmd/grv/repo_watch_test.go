@@ -0,0 +1,135 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRepositoryChangesAreClassifiedByPath(t *testing.T) {
+	watchPaths := &RepositoryWatchPaths{
+		gitDir:    "/repo/.git",
+		commonDir: "/repo/.git",
+		workdir:   "/repo",
+	}
+
+	changeTests := []struct {
+		path     string
+		expected RepositoryChange
+	}{
+		{path: "/repo/.git/HEAD", expected: RepositoryChange{refsChanged: true, statusChanged: true}},
+		{path: "/repo/.git/packed-refs", expected: RepositoryChange{refsChanged: true}},
+		{path: "/repo/.git/refs/heads/master", expected: RepositoryChange{refsChanged: true}},
+		{path: "/repo/.git/refs/remotes/origin/master", expected: RepositoryChange{refsChanged: true}},
+		{path: "/repo/.git/index", expected: RepositoryChange{statusChanged: true}},
+		{path: "/repo/.git/index.lock", expected: RepositoryChange{}},
+		{path: "/repo/.git/refs/heads/master.lock", expected: RepositoryChange{}},
+		{path: "/repo/.git/objects/4b/825dc642cb6eb9a060e54bf8d69288fbee4904", expected: RepositoryChange{}},
+		{path: "/repo/.git/logs/HEAD", expected: RepositoryChange{}},
+		{path: "/repo/src/main.go", expected: RepositoryChange{statusChanged: true}},
+		{path: "/other/main.go", expected: RepositoryChange{}},
+	}
+
+	for _, changeTest := range changeTests {
+		if actual := watchPaths.Classify(changeTest.path); actual != changeTest.expected {
+			t.Errorf("Change does not match expected value for path %v. Expected: %v, Actual: %v", changeTest.path, changeTest.expected, actual)
+		}
+	}
+}
+
+func TestWorktreeCommonDirectoryIsWatched(t *testing.T) {
+	root := createTestRoot(t)
+	defer os.RemoveAll(root)
+
+	createTestDirs(t, root, "repo/.git/worktrees/feature", "feature")
+	createTestFile(t, root, "repo/.git/worktrees/feature/commondir", "../..\n")
+
+	watchPaths := NewRepositoryWatchPaths(filepath.Join(root, "repo/.git/worktrees/feature")+"/", filepath.Join(root, "feature")+"/")
+
+	expectedWatchDirs := []string{
+		filepath.Join(root, "feature") + "/...",
+		filepath.Join(root, "repo/.git/worktrees/feature") + "/...",
+		filepath.Join(root, "repo/.git") + "/...",
+	}
+
+	if actual := watchPaths.WatchDirs(); !reflect.DeepEqual(expectedWatchDirs, actual) {
+		t.Errorf("Watch directories do not match expected value. Expected: %v, Actual: %v", expectedWatchDirs, actual)
+	}
+
+	if change := watchPaths.Classify(filepath.Join(root, "repo/.git/refs/heads/feature")); !change.refsChanged {
+		t.Errorf("Expected change to a ref in the common directory to change refs")
+	}
+
+	if change := watchPaths.Classify(filepath.Join(root, "repo/.git/worktrees/feature/HEAD")); !change.refsChanged || !change.statusChanged {
+		t.Errorf("Expected change to the worktree HEAD to change refs and status")
+	}
+}
+
+func TestModTimeChangesAreClassified(t *testing.T) {
+	root := createTestRoot(t)
+	defer os.RemoveAll(root)
+
+	createTestDirs(t, root, ".git/refs/heads", ".git/refs/tags")
+	createTestFile(t, root, ".git/HEAD", "ref: refs/heads/master\n")
+	createTestFile(t, root, ".git/index", "")
+	createTestFile(t, root, ".git/refs/heads/master", "")
+	createTestFile(t, root, ".git/refs/tags/v1.0", "")
+
+	watchPaths := NewRepositoryWatchPaths(filepath.Join(root, ".git"), root)
+	modTimes := watchPaths.ModTimes()
+
+	if change := watchPaths.ModTimes().Changes(modTimes, watchPaths); change.refsChanged || change.statusChanged {
+		t.Errorf("Expected no change when no files were modified but found: %v", change)
+	}
+
+	modTime := time.Now().Add(time.Hour)
+	if err := os.Chtimes(filepath.Join(root, ".git/index"), modTime, modTime); err != nil {
+		t.Fatalf("Unable to update modification time: %v", err)
+	}
+
+	currentModTimes := watchPaths.ModTimes()
+	if change := currentModTimes.Changes(modTimes, watchPaths); change.refsChanged || !change.statusChanged {
+		t.Errorf("Expected only status to change when index was modified but found: %v", change)
+	}
+
+	modTimes = currentModTimes
+
+	if err := os.Remove(filepath.Join(root, ".git/refs/tags/v1.0")); err != nil {
+		t.Fatalf("Unable to remove ref: %v", err)
+	}
+
+	createTestFile(t, root, ".git/refs/heads/feature.lock", "")
+
+	if change := watchPaths.ModTimes().Changes(modTimes, watchPaths); !change.refsChanged || change.statusChanged {
+		t.Errorf("Expected only refs to change when ref was removed but found: %v", change)
+	}
+}
+
+func TestFileSystemMonitorEventsAreOnlyReportedWhileWatching(t *testing.T) {
+	var status FileSystemMonitorStatus
+
+	status.RecordEvent()
+	if status.EventReceived() {
+		t.Errorf("Expected event not to be reported before the filesystem monitor is watching")
+	}
+
+	status.SetWatching(true)
+	status.RecordEvent()
+
+	if !status.EventReceived() {
+		t.Errorf("Expected event to be reported while the filesystem monitor is watching")
+	}
+
+	if status.EventReceived() {
+		t.Errorf("Expected event to only be reported once")
+	}
+
+	status.RecordEvent()
+	status.SetWatching(false)
+
+	if status.EventReceived() {
+		t.Errorf("Expected event not to be reported after the filesystem monitor stopped watching")
+	}
+}
@@ -1,15 +1,86 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
 	"regexp"
 	"runtime"
+	"strings"
+	"unicode"
 )
 
 const (
 	searchMaxIterationsBeforeYeild = 1000
+	searchIgnoreCaseFlag           = "(?i)"
+	searchIgnoreCaseEscape         = 'c'
+	searchMatchCaseEscape          = 'C'
 )
 
+// SearchCase describes how the case of letters in a search pattern is matched
+type SearchCase int
+
+// The set of search case modes
+const (
+	ScSensitive SearchCase = iota
+	ScIgnore
+	ScSmart
+)
+
+var searchCaseNames = map[string]SearchCase{
+	"sensitive": ScSensitive,
+	"ignore":    ScIgnore,
+	"smart":     ScSmart,
+}
+
+// ParseSearchCase returns the search case mode with the provided name
+func ParseSearchCase(name string) (searchCase SearchCase, err error) {
+	searchCase, ok := searchCaseNames[strings.ToLower(name)]
+	if !ok {
+		err = fmt.Errorf("Invalid search case %v. Valid values are sensitive, ignore and smart", name)
+	}
+
+	return
+}
+
+// ApplySearchCase returns a regex pattern which matches case as specified by the provided mode.
+// Smart case ignores case unless the pattern contains an upper case letter.
+// The mode can be overridden for a single search by including \c (ignore case) or \C (match case) in the pattern
+func ApplySearchCase(pattern string, searchCase SearchCase) string {
+	var processedPattern bytes.Buffer
+	hasUpperCase := false
+	escaped := false
+
+	for _, char := range pattern {
+		switch {
+		case escaped && char == searchIgnoreCaseEscape:
+			searchCase = ScIgnore
+		case escaped && char == searchMatchCaseEscape:
+			searchCase = ScSensitive
+		case escaped:
+			processedPattern.WriteRune('\\')
+			processedPattern.WriteRune(char)
+		case char == '\\':
+			escaped = true
+			continue
+		default:
+			hasUpperCase = hasUpperCase || unicode.IsUpper(char)
+			processedPattern.WriteRune(char)
+		}
+
+		escaped = false
+	}
+
+	if escaped {
+		processedPattern.WriteRune('\\')
+	}
+
+	if searchCase == ScIgnore || (searchCase == ScSmart && !hasUpperCase) {
+		return searchIgnoreCaseFlag + processedPattern.String()
+	}
+
+	return processedPattern.String()
+}
+
 // SearchDirection describes the direction the search should be performed in
 type SearchDirection int
 
@@ -231,3 +231,35 @@ func TestSearchFindAll(t *testing.T) {
 		t.Errorf("FindAll did not return expected matches. Expected: %v. Actual %v", expectedMatches, actualMatches)
 	}
 }
+
+func TestSearchCaseIsAppliedToPattern(t *testing.T) {
+	searchCaseTests := []struct {
+		pattern    string
+		searchCase SearchCase
+		expected   string
+	}{
+		{pattern: "test", searchCase: ScSensitive, expected: "test"},
+		{pattern: "Test", searchCase: ScIgnore, expected: "(?i)Test"},
+		{pattern: "test\\s", searchCase: ScSmart, expected: "(?i)test\\s"},
+		{pattern: "Test\\S", searchCase: ScSmart, expected: "Test\\S"},
+		{pattern: "test\\S", searchCase: ScSmart, expected: "(?i)test\\S"},
+		{pattern: "test\\c", searchCase: ScSensitive, expected: "(?i)test"},
+		{pattern: "\\Ctest", searchCase: ScIgnore, expected: "test"},
+		{pattern: "test\\\\c", searchCase: ScSensitive, expected: "test\\\\c"},
+	}
+
+	for _, searchCaseTest := range searchCaseTests {
+		if actual := ApplySearchCase(searchCaseTest.pattern, searchCaseTest.searchCase); actual != searchCaseTest.expected {
+			t.Errorf("Pattern does not match expected value for input %v. Expected: %v, Actual: %v",
+				searchCaseTest.pattern, searchCaseTest.expected, actual)
+		}
+	}
+}
+
+func TestSearchIgnoringCaseFindsMatch(t *testing.T) {
+	search := createSearch(SdForward, ApplySearchCase("TST", ScIgnore), t)
+
+	lineIndex, found := search.FindNext(0)
+
+	checkResult(3, true, lineIndex, found, t)
+}
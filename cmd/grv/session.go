@@ -0,0 +1,239 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	ssSessionFile = "grv_session"
+
+	ssRefKey          = "ref"
+	ssCommitKey       = "commit"
+	ssExpandedKey     = "expanded"
+	ssCollapsedKey    = "collapsed"
+	ssRefFilterKey    = "reffilter"
+	ssCommitFilterKey = "commitfilter"
+)
+
+// SessionState contains the view state saved when grv exits and restored
+// the next time grv is launched for the same repository
+type SessionState struct {
+	refName       string
+	commitID      string
+	refGroups     map[string]bool
+	refFilters    []string
+	commitFilters []string
+}
+
+// NewSessionState creates a new empty instance
+func NewSessionState() *SessionState {
+	return &SessionState{
+		refGroups: make(map[string]bool),
+	}
+}
+
+// RefGroupExpanded returns the saved expanded state of the ref group with the provided name
+func (sessionState *SessionState) RefGroupExpanded(name string) (expanded, exists bool) {
+	expanded, exists = sessionState.refGroups[name]
+	return
+}
+
+// SessionStateProvider is implemented by views which contribute to the saved session state
+type SessionStateProvider interface {
+	SaveSessionState(*SessionState)
+}
+
+// SessionStore loads the session state for a repository and saves
+// the state of registered views when grv exits.
+// The session state is persisted in the repository directory
+type SessionStore struct {
+	filePath  string
+	state     *SessionState
+	providers []SessionStateProvider
+	lock      sync.Mutex
+}
+
+// NewSessionStore creates a new instance
+func NewSessionStore() *SessionStore {
+	return &SessionStore{
+		state: NewSessionState(),
+	}
+}
+
+// Load reads any session state previously saved for the repository at the provided path
+func (sessionStore *SessionStore) Load(repoPath string) (err error) {
+	sessionStore.lock.Lock()
+	defer sessionStore.lock.Unlock()
+
+	sessionStore.filePath = filepath.Join(repoPath, ssSessionFile)
+
+	file, err := os.Open(sessionStore.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+
+		return
+	}
+	defer file.Close()
+
+	state, err := parseSessionState(file)
+	if err != nil {
+		return
+	}
+
+	sessionStore.state = state
+
+	return
+}
+
+// Clear discards the loaded session state so grv starts with the default view state
+func (sessionStore *SessionStore) Clear() {
+	sessionStore.lock.Lock()
+	defer sessionStore.lock.Unlock()
+
+	sessionStore.state = NewSessionState()
+}
+
+// SetStartRef replaces the ref saved in the previous session with the provided ref.
+// The commit and commit filters saved for the previous ref are discarded
+func (sessionStore *SessionStore) SetStartRef(refName string) {
+	sessionStore.lock.Lock()
+	defer sessionStore.lock.Unlock()
+
+	sessionStore.state.refName = refName
+	sessionStore.state.commitID = ""
+	sessionStore.state.commitFilters = nil
+}
+
+// State returns the session state loaded at startup
+func (sessionStore *SessionStore) State() *SessionState {
+	sessionStore.lock.Lock()
+	defer sessionStore.lock.Unlock()
+
+	return sessionStore.state
+}
+
+// RegisterSessionStateProvider adds a provider whose state will be saved when grv exits
+func (sessionStore *SessionStore) RegisterSessionStateProvider(provider SessionStateProvider) {
+	sessionStore.lock.Lock()
+	defer sessionStore.lock.Unlock()
+
+	sessionStore.providers = append(sessionStore.providers, provider)
+}
+
+// Save collects the current state from all providers and writes it to the repository directory
+func (sessionStore *SessionStore) Save() (err error) {
+	sessionStore.lock.Lock()
+	filePath := sessionStore.filePath
+	providers := append([]SessionStateProvider(nil), sessionStore.providers...)
+	sessionStore.lock.Unlock()
+
+	if filePath == "" {
+		return
+	}
+
+	state := NewSessionState()
+	for _, provider := range providers {
+		provider.SaveSessionState(state)
+	}
+
+	var buffer bytes.Buffer
+	writeSessionState(&buffer, state)
+
+	if err = ioutil.WriteFile(filePath, buffer.Bytes(), 0644); err != nil {
+		err = fmt.Errorf("Unable to save session: %v", err)
+	}
+
+	return
+}
+
+func parseSessionState(reader io.Reader) (state *SessionState, err error) {
+	state = NewSessionState()
+	scanner := bufio.NewScanner(reader)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		fields := strings.SplitN(line, " ", 2)
+		if len(fields) != 2 || strings.TrimSpace(fields[1]) == "" {
+			return nil, fmt.Errorf("Invalid session entry on line %v", lineNumber)
+		}
+
+		key, value := fields[0], strings.TrimSpace(fields[1])
+
+		switch key {
+		case ssRefKey:
+			state.refName = value
+		case ssCommitKey:
+			if !hexRegexp.MatchString(value) {
+				return nil, fmt.Errorf("Invalid commit id on line %v", lineNumber)
+			}
+
+			state.commitID = value
+		case ssExpandedKey:
+			state.refGroups[value] = true
+		case ssCollapsedKey:
+			state.refGroups[value] = false
+		case ssRefFilterKey:
+			state.refFilters = append(state.refFilters, value)
+		case ssCommitFilterKey:
+			state.commitFilters = append(state.commitFilters, value)
+		default:
+			return nil, fmt.Errorf("Invalid session entry %v on line %v", key, lineNumber)
+		}
+	}
+
+	err = scanner.Err()
+
+	return
+}
+
+func writeSessionState(writer io.Writer, state *SessionState) {
+	if state.refName != "" {
+		fmt.Fprintf(writer, "%v %v\n", ssRefKey, state.refName)
+	}
+
+	if state.commitID != "" {
+		fmt.Fprintf(writer, "%v %v\n", ssCommitKey, state.commitID)
+	}
+
+	var groupNames []string
+	for groupName := range state.refGroups {
+		groupNames = append(groupNames, groupName)
+	}
+
+	sort.Strings(groupNames)
+
+	for _, groupName := range groupNames {
+		key := ssCollapsedKey
+		if state.refGroups[groupName] {
+			key = ssExpandedKey
+		}
+
+		fmt.Fprintf(writer, "%v %v\n", key, groupName)
+	}
+
+	for _, refFilter := range state.refFilters {
+		fmt.Fprintf(writer, "%v %v\n", ssRefFilterKey, refFilter)
+	}
+
+	for _, commitFilter := range state.commitFilters {
+		fmt.Fprintf(writer, "%v %v\n", ssCommitFilterKey, commitFilter)
+	}
+}
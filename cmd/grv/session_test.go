@@ -0,0 +1,121 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testSessionStateProvider struct {
+	save func(*SessionState)
+}
+
+func (provider *testSessionStateProvider) SaveSessionState(sessionState *SessionState) {
+	provider.save(sessionState)
+}
+
+func TestParseSessionStateReadsEachEntry(t *testing.T) {
+	input := "ref refs/heads/master\n" +
+		"commit 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+		"\n" +
+		"expanded Remote Branches\n" +
+		"collapsed Tags\n" +
+		"reffilter name GLOB \"feature*\"\n" +
+		"commitfilter authorname = \"John Smith\"\n" +
+		"commitfilter summary CONTAINS fix\n"
+
+	expected := &SessionState{
+		refName:  "refs/heads/master",
+		commitID: "4b825dc642cb6eb9a060e54bf8d69288fbee4904",
+		refGroups: map[string]bool{
+			"Remote Branches": true,
+			"Tags":            false,
+		},
+		refFilters:    []string{"name GLOB \"feature*\""},
+		commitFilters: []string{"authorname = \"John Smith\"", "summary CONTAINS fix"},
+	}
+
+	actual, err := parseSessionState(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Session state does not match expected value. Expected: %v, Actual: %v", expected, actual)
+	}
+}
+
+func TestParseSessionStateReturnsErrorForInvalidLines(t *testing.T) {
+	invalidInputs := []string{
+		"ref",
+		"commit notanoid",
+		"unknown value",
+		"expanded ",
+	}
+
+	for _, input := range invalidInputs {
+		if _, err := parseSessionState(strings.NewReader(input)); err == nil {
+			t.Errorf("Expected error for input %v", input)
+		}
+	}
+}
+
+func TestSessionStateIsPersistedAcrossSessionStoreInstances(t *testing.T) {
+	repoPath, err := ioutil.TempDir("", "grv-session")
+	if err != nil {
+		t.Fatalf("Unable to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(repoPath)
+
+	sessionStore := NewSessionStore()
+	if err = sessionStore.Load(repoPath); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	sessionStore.RegisterSessionStateProvider(&testSessionStateProvider{
+		save: func(sessionState *SessionState) {
+			sessionState.refGroups["Branches"] = true
+			sessionState.refGroups["Tags"] = false
+			sessionState.refFilters = append(sessionState.refFilters, "name = master")
+		},
+	})
+	sessionStore.RegisterSessionStateProvider(&testSessionStateProvider{
+		save: func(sessionState *SessionState) {
+			sessionState.refName = "refs/heads/master"
+			sessionState.commitID = "1111111"
+			sessionState.commitFilters = append(sessionState.commitFilters, "summary CONTAINS fix")
+		},
+	})
+
+	if err = sessionStore.Save(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	loadedSessionStore := NewSessionStore()
+	if err = loadedSessionStore.Load(repoPath); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := &SessionState{
+		refName:  "refs/heads/master",
+		commitID: "1111111",
+		refGroups: map[string]bool{
+			"Branches": true,
+			"Tags":     false,
+		},
+		refFilters:    []string{"name = master"},
+		commitFilters: []string{"summary CONTAINS fix"},
+	}
+
+	if actual := loadedSessionStore.State(); !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Session state does not match expected value. Expected: %v, Actual: %v", expected, actual)
+	}
+
+	loadedSessionStore.Clear()
+
+	if actual := loadedSessionStore.State(); actual.refName != "" || len(actual.refGroups) != 0 {
+		t.Errorf("Expected session state to be empty after clear but found: %v", actual)
+	}
+}
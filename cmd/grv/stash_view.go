@@ -0,0 +1,334 @@
+package main
+
+import (
+	"fmt"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	svColumnNum     = 2
+	svMessageColumn = 1
+)
+
+type stashViewHandler func(*StashView, Action) error
+
+// StashView displays the stash entries of the repository and allows them to be applied, popped or dropped
+type StashView struct {
+	*ListView
+	repoData       RepoData
+	config         Config
+	stashes        []*StashEntry
+	active         bool
+	tableFormatter *TableFormatter
+	handlers       map[ActionType]stashViewHandler
+}
+
+// NewStashView creates a new instance
+func NewStashView(repoData RepoData, channels *Channels, config Config) *StashView {
+	stashView := &StashView{
+		ListView:       NewListView(channels),
+		repoData:       repoData,
+		config:         config,
+		tableFormatter: NewTableFormatter(svColumnNum),
+		handlers: map[ActionType]stashViewHandler{
+			ActionSaveStash:  saveStashEntry,
+			ActionApplyStash: applyStashEntry,
+			ActionPopStash:   popStashEntry,
+			ActionDropStash:  dropStashEntry,
+		},
+	}
+
+	stashView.viewSearch = NewViewSearch(stashView, channels)
+	repoData.RegisterStatusListener(stashView)
+
+	return stashView
+}
+
+// Initialise loads the stash entries of the repository
+func (stashView *StashView) Initialise() (err error) {
+	log.Info("Initialising StashView")
+
+	stashView.lock.Lock()
+	defer stashView.lock.Unlock()
+
+	return stashView.loadStashes()
+}
+
+func (stashView *StashView) loadStashes() (err error) {
+	stashes, err := stashView.repoData.Stashes()
+	if err != nil {
+		return
+	}
+
+	stashView.stashes = stashes
+
+	stashNum := uint(len(stashView.stashes))
+	viewPos := stashView.viewPos
+
+	if stashNum == 0 {
+		viewPos.SetActiveRowIndex(0)
+	} else if viewPos.ActiveRowIndex() >= stashNum {
+		viewPos.SetActiveRowIndex(stashNum - 1)
+	}
+
+	return
+}
+
+// OnStatusChanged reloads the stash entries as the stash is usually modified along with the working tree
+func (stashView *StashView) OnStatusChanged(status *Status) {
+	stashView.lock.Lock()
+	defer stashView.lock.Unlock()
+
+	if err := stashView.loadStashes(); err != nil {
+		stashView.channels.ReportError(err)
+		return
+	}
+
+	stashView.channels.UpdateDisplay()
+}
+
+// Render generates and writes the stash view to the provided window
+func (stashView *StashView) Render(win RenderWindow) (err error) {
+	stashView.lock.Lock()
+	defer stashView.lock.Unlock()
+
+	log.Debug("Rendering StashView")
+
+	stashView.viewDimension = win.ViewDimensions()
+
+	stashNum := uint(len(stashView.stashes))
+	rows := win.Rows() - 2
+
+	viewPos := stashView.viewPos
+	viewPos.DetermineViewStartRow(rows, stashNum, uint(stashView.config.GetInt(CfScrollOff)))
+	stashIndex := viewPos.ViewStartRowIndex()
+
+	tableFormatter := stashView.tableFormatter
+	tableFormatter.Resize(MinUint(rows, stashNum-stashIndex))
+	tableFormatter.Clear()
+	tableFormatter.SetColWidthLimit(svMessageColumn, uint(stashView.config.GetInt(CfStashMessageWidth)))
+
+	for rowIndex := uint(0); rowIndex < rows && stashIndex < stashNum; rowIndex++ {
+		if err = stashView.renderStash(tableFormatter, rowIndex, stashView.stashes[stashIndex]); err != nil {
+			return
+		}
+
+		stashIndex++
+	}
+
+	if err = tableFormatter.Render(win, viewPos.ViewStartColumn(), true); err != nil {
+		return
+	}
+
+	if stashNum == 0 {
+		if err = win.SetRow(2, 1, CmpNone, "   No stash entries"); err != nil {
+			return
+		}
+	} else if err = win.SetSelectedRow(viewPos.SelectedRowIndex()+1, stashView.active); err != nil {
+		return
+	}
+
+	win.DrawBorder()
+
+	if err = win.SetTitle(CmpStashviewTitle, "Stashes"); err != nil {
+		return
+	}
+
+	if stashNum > 0 {
+		if err = win.SetFooter(CmpStashviewFooter, "Stash %v of %v", viewPos.ActiveRowIndex()+1, stashNum); err != nil {
+			return
+		}
+	}
+
+	err = stashView.RenderSearchHighlight(win)
+
+	return
+}
+
+func (stashView *StashView) renderStash(tableFormatter *TableFormatter, rowIndex uint, stashEntry *StashEntry) (err error) {
+	if err = tableFormatter.SetCellWithStyle(rowIndex, 0, CmpStashviewName, "%v", stashEntry.Name()); err != nil {
+		return
+	}
+
+	return tableFormatter.SetCellWithStyle(rowIndex, svMessageColumn, CmpStashviewMessage, "%v", stashEntry.message)
+}
+
+// RenderHelpBar renders key binding help for the stash view
+func (stashView *StashView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
+	RenderKeyBindingHelp(stashView.ViewID(), lineBuilder, []ActionMessage{
+		{action: ActionSaveStash, message: "Stash Changes"},
+		{action: ActionApplyStash, message: "Apply"},
+		{action: ActionPopStash, message: "Pop"},
+		{action: ActionDropStash, message: "Drop"},
+	})
+
+	return
+}
+
+// HandleEvent does nothing
+func (stashView *StashView) HandleEvent(event Event) (err error) {
+	return
+}
+
+// OnActiveChange updates whether this view is currently active
+func (stashView *StashView) OnActiveChange(active bool) {
+	log.Debugf("StashView active: %v", active)
+	stashView.lock.Lock()
+	defer stashView.lock.Unlock()
+
+	stashView.active = active
+}
+
+// ViewID returns the ViewID for the stash view
+func (stashView *StashView) ViewID() ViewID {
+	return ViewStash
+}
+
+// Line returns the rendered line at the specified index
+func (stashView *StashView) Line(lineIndex uint) (line string) {
+	stashView.lock.Lock()
+	defer stashView.lock.Unlock()
+
+	stashNum := uint(len(stashView.stashes))
+	if lineIndex >= stashNum {
+		log.Errorf("Invalid lineIndex: %v >= %v", lineIndex, stashNum)
+		return
+	}
+
+	stashEntry := stashView.stashes[lineIndex]
+
+	return fmt.Sprintf("%v %v", stashEntry.Name(), stashEntry.message)
+}
+
+// LineNumber returns the number of stash entries in the view
+func (stashView *StashView) LineNumber() (lineNumber uint) {
+	stashView.lock.Lock()
+	defer stashView.lock.Unlock()
+
+	return uint(len(stashView.stashes))
+}
+
+// HandleAction checks if the stash view supports this action and if it does executes it
+func (stashView *StashView) HandleAction(action Action) (err error) {
+	stashView.lock.Lock()
+	defer stashView.lock.Unlock()
+
+	if handler, ok := stashView.handlers[action.ActionType]; ok {
+		log.Debugf("StashView handling action %v", action)
+		err = handler(stashView, action)
+	} else {
+		_, err = stashView.HandleListAction(action, uint(len(stashView.stashes)))
+	}
+
+	return
+}
+
+func (stashView *StashView) selectedStash() (stashEntry *StashEntry, exists bool) {
+	activeRowIndex := stashView.viewPos.ActiveRowIndex()
+
+	if activeRowIndex >= uint(len(stashView.stashes)) {
+		return
+	}
+
+	return stashView.stashes[activeRowIndex], true
+}
+
+func saveStashEntry(stashView *StashView, action Action) (err error) {
+	if err = saveStash(stashView.repoData, stashView.channels, action); err != nil {
+		return
+	}
+
+	return stashView.loadStashes()
+}
+
+func applyStashEntry(stashView *StashView, action Action) error {
+	return stashView.modifyStash(action, "Apply", func(stashEntry *StashEntry) (err error) {
+		if err = stashView.repoData.ApplyStash(stashEntry, false); err == nil {
+			stashView.channels.ReportSuccess("Applied %v", stashEntry.Name())
+		}
+
+		return
+	})
+}
+
+func popStashEntry(stashView *StashView, action Action) error {
+	return stashView.modifyStash(action, "Pop", func(stashEntry *StashEntry) (err error) {
+		if err = stashView.repoData.ApplyStash(stashEntry, true); err == nil {
+			stashView.channels.ReportSuccess("Popped %v", stashEntry.Name())
+		}
+
+		return
+	})
+}
+
+func dropStashEntry(stashView *StashView, action Action) error {
+	return stashView.modifyStash(action, "Drop", func(stashEntry *StashEntry) (err error) {
+		if err = stashView.repoData.DropStash(stashEntry); err == nil {
+			stashView.channels.ReportSuccess("Dropped %v", stashEntry.Name())
+		}
+
+		return
+	})
+}
+
+// modifyStash requests confirmation before performing an operation on the selected stash entry.
+// The confirmed action contains the stash entry to operate on as its argument
+func (stashView *StashView) modifyStash(action Action, operation string, modify func(*StashEntry) error) (err error) {
+	if len(action.Args) == 0 {
+		stashEntry, exists := stashView.selectedStash()
+		if !exists {
+			return
+		}
+
+		stashView.channels.Confirm(fmt.Sprintf("%v %v: %v", operation, stashEntry.Name(), stashEntry.message), Action{
+			ActionType: action.ActionType,
+			Args:       []interface{}{stashEntry},
+		})
+
+		return
+	}
+
+	stashEntry, ok := action.Args[0].(*StashEntry)
+	if !ok {
+		return fmt.Errorf("Expected stash entry argument to have type *StashEntry but got %T", action.Args[0])
+	}
+
+	if err = modify(stashEntry); err != nil {
+		return
+	}
+
+	if err = stashView.loadStashes(); err != nil {
+		return
+	}
+
+	stashView.channels.UpdateDisplay()
+
+	return
+}
+
+// saveStash requests confirmation before stashing the working tree changes.
+// The confirmed action contains the stash message as its argument
+func saveStash(repoData RepoData, channels *Channels, action Action) (err error) {
+	if len(action.Args) == 0 {
+		channels.Confirm("Stash working tree changes", Action{
+			ActionType: ActionSaveStash,
+			Args:       []interface{}{""},
+		})
+
+		return
+	}
+
+	message, ok := action.Args[0].(string)
+	if !ok {
+		return fmt.Errorf("Expected stash message argument to have type string but got %T", action.Args[0])
+	}
+
+	if err = repoData.SaveStash(message); err != nil {
+		return
+	}
+
+	channels.ReportSuccess("Saved working tree changes to the stash")
+
+	return
+}
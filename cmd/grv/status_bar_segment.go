@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+)
+
+// StatusBarSegmentType identifies the information a status bar segment displays
+type StatusBarSegmentType int
+
+// The set of status bar segment types
+const (
+	SegmentMode StatusBarSegmentType = iota
+	SegmentFilter
+	SegmentPosition
+	SegmentDirty
+	SegmentBisect
+)
+
+var statusBarSegmentThemeComponents = map[StatusBarSegmentType]ThemeComponentID{
+	SegmentMode:     CmpStatusbarviewMode,
+	SegmentFilter:   CmpStatusbarviewFilter,
+	SegmentPosition: CmpStatusbarviewPosition,
+	SegmentDirty:    CmpStatusbarviewDirty,
+	SegmentBisect:   CmpStatusbarviewBisect,
+}
+
+// StatusBarSegment is a single item of information displayed in the status bar
+type StatusBarSegment struct {
+	segmentType StatusBarSegmentType
+	text        string
+}
+
+// ThemeComponentID returns the theme component the segment is drawn with
+func (segment StatusBarSegment) ThemeComponentID() ThemeComponentID {
+	if themeComponentID, ok := statusBarSegmentThemeComponents[segment.segmentType]; ok {
+		return themeComponentID
+	}
+
+	return CmpStatusbarviewNormal
+}
+
+// Width returns the number of columns required to display the segment
+func (segment StatusBarSegment) Width() uint {
+	return StringWidth(segment.text) + 2
+}
+
+// StatusBarSegments collects the segments views contribute to the status bar
+type StatusBarSegments struct {
+	segments []StatusBarSegment
+}
+
+// Add appends a segment of the provided type
+func (statusBarSegments *StatusBarSegments) Add(segmentType StatusBarSegmentType, format string, args ...interface{}) {
+	statusBarSegments.segments = append(statusBarSegments.segments, StatusBarSegment{
+		segmentType: segmentType,
+		text:        fmt.Sprintf(format, args...),
+	})
+}
+
+// AddPosition appends a segment containing the active row and how far through the view it is
+func (statusBarSegments *StatusBarSegments) AddPosition(activeRowIndex, rowNum uint) {
+	if rowNum == 0 {
+		return
+	}
+
+	statusBarSegments.Add(SegmentPosition, "%v/%v %v%%", activeRowIndex+1, rowNum, PositionPercentage(activeRowIndex, rowNum))
+}
+
+// Segments returns the collected segments
+func (statusBarSegments *StatusBarSegments) Segments() []StatusBarSegment {
+	return statusBarSegments.segments
+}
+
+// Width returns the number of columns required to display all segments
+func (statusBarSegments *StatusBarSegments) Width() (width uint) {
+	for _, segment := range statusBarSegments.segments {
+		width += segment.Width()
+	}
+
+	return
+}
+
+// StatusBarRenderer is implemented by views which contribute segments to the status bar
+type StatusBarRenderer interface {
+	RenderStatusBar(*StatusBarSegments) error
+}
+
+// RenderChildStatusBar passes the segments on to the provided view if it contributes to the status bar
+func RenderChildStatusBar(view AbstractView, statusBarSegments *StatusBarSegments) (err error) {
+	if statusBarRenderer, ok := view.(StatusBarRenderer); ok {
+		err = statusBarRenderer.RenderStatusBar(statusBarSegments)
+	}
+
+	return
+}
+
+func filterSegmentText(filters uint) string {
+	plural := ""
+	if filters > 1 {
+		plural = "s"
+	}
+
+	return fmt.Sprintf("%v filter%v", filters, plural)
+}
+
+// PositionPercentage returns how far through rowNum rows the active row is as a percentage
+func PositionPercentage(activeRowIndex, rowNum uint) uint {
+	if rowNum == 0 {
+		return 0
+	}
+
+	return MinUint(activeRowIndex+1, rowNum) * 100 / rowNum
+}
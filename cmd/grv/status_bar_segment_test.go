@@ -0,0 +1,92 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestPositionPercentage(t *testing.T) {
+	var positionPercentageTests = []struct {
+		activeRowIndex uint
+		rowNum         uint
+		expectedResult uint
+	}{
+		{
+			activeRowIndex: 0,
+			rowNum:         0,
+			expectedResult: 0,
+		},
+		{
+			activeRowIndex: 0,
+			rowNum:         1,
+			expectedResult: 100,
+		},
+		{
+			activeRowIndex: 0,
+			rowNum:         4,
+			expectedResult: 25,
+		},
+		{
+			activeRowIndex: 2,
+			rowNum:         3,
+			expectedResult: 100,
+		},
+		{
+			activeRowIndex: 10,
+			rowNum:         5,
+			expectedResult: 100,
+		},
+	}
+
+	for _, positionPercentageTest := range positionPercentageTests {
+		actualResult := PositionPercentage(positionPercentageTest.activeRowIndex, positionPercentageTest.rowNum)
+
+		if actualResult != positionPercentageTest.expectedResult {
+			t.Errorf("PositionPercentage return value does not match expected value. Expected: %v, Actual: %v", positionPercentageTest.expectedResult, actualResult)
+		}
+	}
+}
+
+func TestStatusBarSegmentsAreRecordedInOrderWithThemeComponents(t *testing.T) {
+	statusBarSegments := &StatusBarSegments{}
+	statusBarSegments.Add(SegmentMode, "Commits")
+	statusBarSegments.Add(SegmentFilter, "%v", filterSegmentText(2))
+	statusBarSegments.AddPosition(4, 10)
+
+	expectedSegments := []struct {
+		text             string
+		themeComponentID ThemeComponentID
+	}{
+		{text: "Commits", themeComponentID: CmpStatusbarviewMode},
+		{text: "2 filters", themeComponentID: CmpStatusbarviewFilter},
+		{text: "5/10 50%", themeComponentID: CmpStatusbarviewPosition},
+	}
+
+	segments := statusBarSegments.Segments()
+	if len(segments) != len(expectedSegments) {
+		t.Fatalf("Segment number does not match expected value. Expected: %v, Actual: %v", len(expectedSegments), len(segments))
+	}
+
+	for index, expectedSegment := range expectedSegments {
+		if segments[index].text != expectedSegment.text {
+			t.Errorf("Segment text does not match expected value. Expected: %v, Actual: %v", expectedSegment.text, segments[index].text)
+		}
+
+		if segments[index].ThemeComponentID() != expectedSegment.themeComponentID {
+			t.Errorf("Segment theme component does not match expected value. Expected: %v, Actual: %v", expectedSegment.themeComponentID, segments[index].ThemeComponentID())
+		}
+	}
+
+	expectedWidth := uint(len("Commits") + len("2 filters") + len("5/10 50%") + 6)
+	if width := statusBarSegments.Width(); width != expectedWidth {
+		t.Errorf("Segments width does not match expected value. Expected: %v, Actual: %v", expectedWidth, width)
+	}
+}
+
+func TestNoPositionSegmentIsAddedForEmptyView(t *testing.T) {
+	statusBarSegments := &StatusBarSegments{}
+	statusBarSegments.AddPosition(0, 0)
+
+	if len(statusBarSegments.Segments()) != 0 {
+		t.Errorf("Expected no segments to be added for an empty view")
+	}
+}
@@ -2,7 +2,9 @@ package main
 
 import (
 	"fmt"
+	"strings"
 	"sync"
+	"time"
 	"unicode/utf8"
 
 	log "github.com/Sirupsen/logrus"
@@ -14,8 +16,18 @@ const (
 	SearchPromptText        = "/"
 	ReverseSearchPromptText = "?"
 	FilterPromptText        = "query: "
+	SetUpstreamPromptText   = "upstream: "
+	SelectCommitPromptText  = "commit: "
+	SetMarkPromptText       = "mark: "
+	JumpToMarkPromptText    = "jump to mark: "
+	PickaxePromptText       = "pickaxe string: "
+	PickaxeRegexPromptText  = "pickaxe regex: "
+	IgnorePatternPromptText = "ignore pattern: "
 )
 
+// ShellCommandPrefix identifies input to the command prompt which is run as a shell command
+const ShellCommandPrefix = "!"
+
 type promptType int
 
 const (
@@ -23,26 +35,46 @@ const (
 	ptCommand
 	ptSearch
 	ptFilter
+	ptSetUpstream
+	ptSelectCommit
+	ptMark
+	ptConfirm
+	ptPickaxe
+	ptIgnorePattern
 )
 
 // StatusBarView manages the display of the status bar
 type StatusBarView struct {
-	repoData      RepoData
-	channels      *Channels
-	config        ConfigSetter
-	active        bool
-	promptType    promptType
-	pendingStatus string
-	lock          sync.Mutex
+	statusBarRenderer StatusBarRenderer
+	repoData          RepoData
+	channels          *Channels
+	config            ConfigSetter
+	active            bool
+	promptType        promptType
+	typedResponse     string
+	statusMessage     StatusMessage
+	statusMessageID   uint
+	dismissTimer      *time.Timer
+	dirty             bool
+	lock              sync.Mutex
 }
 
 // NewStatusBarView creates a new instance
-func NewStatusBarView(repoData RepoData, channels *Channels, config ConfigSetter) *StatusBarView {
-	return &StatusBarView{
-		repoData: repoData,
-		channels: channels,
-		config:   config,
+func NewStatusBarView(statusBarRenderer StatusBarRenderer, repoData RepoData, channels *Channels, config ConfigSetter) *StatusBarView {
+	statusBarView := &StatusBarView{
+		statusBarRenderer: statusBarRenderer,
+		repoData:          repoData,
+		channels:          channels,
+		config:            config,
+	}
+
+	if status := repoData.Status(); status != nil {
+		statusBarView.dirty = status.HasUncommittedChanges()
 	}
+
+	repoData.RegisterStatusListener(statusBarView)
+
+	return statusBarView
 }
 
 // Initialise does nothing
@@ -61,50 +93,202 @@ func (statusBarView *StatusBarView) HandleAction(action Action) (err error) {
 	case ActionPrompt:
 		statusBarView.showCommandPrompt()
 	case ActionSearchPrompt:
-		statusBarView.showSearchPrompt(SearchPromptText, ActionSearch)
+		statusBarView.showSearchPrompt(SearchPromptText, ActionSearch, action)
 	case ActionReverseSearchPrompt:
-		statusBarView.showSearchPrompt(ReverseSearchPromptText, ActionReverseSearch)
+		statusBarView.showSearchPrompt(ReverseSearchPromptText, ActionReverseSearch, action)
 	case ActionFilterPrompt:
 		statusBarView.showFilterPrompt()
+	case ActionSetUpstreamPrompt:
+		statusBarView.showSetUpstreamPrompt()
+	case ActionSelectCommitPrompt:
+		statusBarView.showSelectCommitPrompt()
+	case ActionSetMarkPrompt:
+		statusBarView.showMarkPrompt(SetMarkPromptText, ActionSetMark)
+	case ActionJumpToMarkPrompt:
+		statusBarView.showMarkPrompt(JumpToMarkPromptText, ActionJumpToMark)
+	case ActionPruneRemoteBranchesPrompt:
+		err = statusBarView.showPruneRemoteBranchesPrompt(action)
+	case ActionConfirmPrompt:
+		err = statusBarView.showConfirmPrompt(action)
+	case ActionPickaxePrompt:
+		statusBarView.showPickaxePrompt(PickaxePromptText, pxStringOption)
+	case ActionPickaxeRegexPrompt:
+		statusBarView.showPickaxePrompt(PickaxeRegexPromptText, pxRegexOption)
+	case ActionIgnorePatternPrompt:
+		statusBarView.showIgnorePatternPrompt()
 	case ActionShowStatus:
-		statusBarView.lock.Lock()
-		defer statusBarView.lock.Unlock()
-
-		if len(action.Args) > 0 {
-			status, ok := action.Args[0].(string)
-			if ok {
-				statusBarView.pendingStatus = status
-				log.Infof("Received status: %v", status)
-				statusBarView.channels.UpdateDisplay()
-				return
-			}
-		}
+		err = statusBarView.showStatus(action)
+	}
+
+	return
+}
+
+func (statusBarView *StatusBarView) showStatus(action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected status argument but received: %v", action.Args)
+	}
+
+	var statusMessage StatusMessage
+
+	switch status := action.Args[0].(type) {
+	case StatusMessage:
+		statusMessage = status
+	case string:
+		statusMessage = StatusMessage{level: SmlInfo, text: status}
+	default:
+		return fmt.Errorf("Expected status argument but received: %v", action.Args)
+	}
+
+	statusBarView.lock.Lock()
+	defer statusBarView.lock.Unlock()
+
+	log.Infof("Received status: %v", statusMessage.text)
+	statusBarView.statusMessage = statusMessage
+	statusBarView.statusMessageID++
 
-		err = fmt.Errorf("Expected status argument but received: %v", action.Args)
+	if statusBarView.dismissTimer != nil {
+		statusBarView.dismissTimer.Stop()
+		statusBarView.dismissTimer = nil
 	}
 
+	timeout := time.Duration(statusBarView.config.GetInt(CfStatusTimeout)) * time.Second
+	if displayDuration := statusMessage.DisplayDuration(timeout); displayDuration > 0 {
+		statusMessageID := statusBarView.statusMessageID
+		statusBarView.dismissTimer = time.AfterFunc(displayDuration, func() {
+			statusBarView.dismissStatus(statusMessageID)
+		})
+	}
+
+	statusBarView.channels.UpdateDisplay()
+
 	return
 }
 
+// dismissStatus clears the status message if it has not been replaced since it was displayed
+func (statusBarView *StatusBarView) dismissStatus(statusMessageID uint) {
+	statusBarView.lock.Lock()
+	defer statusBarView.lock.Unlock()
+
+	if statusMessageID != statusBarView.statusMessageID {
+		return
+	}
+
+	log.Debugf("Dismissing status: %v", statusBarView.statusMessage.text)
+	statusBarView.statusMessage = StatusMessage{}
+	statusBarView.dismissTimer = nil
+	statusBarView.channels.UpdateDisplay()
+}
+
 func (statusBarView *StatusBarView) showCommandPrompt() {
 	statusBarView.promptType = ptCommand
 	input := Prompt(PromptText)
-	errors := statusBarView.config.Evaluate(input)
-	statusBarView.channels.ReportErrors(errors)
+
+	if strings.HasPrefix(input, ShellCommandPrefix) {
+		statusBarView.channels.DoAction(Action{
+			ActionType: ActionRunShellCommand,
+			Args:       []interface{}{strings.TrimPrefix(input, ShellCommandPrefix)},
+		})
+	} else {
+		errors := statusBarView.config.Evaluate(input)
+		statusBarView.channels.ReportErrors(errors)
+	}
+
 	statusBarView.promptType = ptNone
 }
 
-func (statusBarView *StatusBarView) showSearchPrompt(prompt string, actionType ActionType) {
+func (statusBarView *StatusBarView) showSearchPrompt(prompt string, actionType ActionType, action Action) {
 	statusBarView.promptType = ptSearch
+	defer func() { statusBarView.promptType = ptNone }()
+
+	searchCase, err := ParseSearchCase(statusBarView.config.GetString(CfSearchCase))
+	if err != nil {
+		log.Errorf("Invalid search case: %v", err)
+	}
+
+	var incrementalSearchHandler IncrementalSearchHandler
+	if len(action.Args) > 0 {
+		incrementalSearchHandler, _ = action.Args[len(action.Args)-1].(IncrementalSearchHandler)
+	}
+
+	if incrementalSearchHandler == nil {
+		input := Prompt(prompt)
+
+		if input == "" {
+			statusBarView.channels.DoAction(Action{
+				ActionType: ActionClearSearch,
+			})
+		} else {
+			statusBarView.channels.DoAction(Action{
+				ActionType: actionType,
+				Args:       []interface{}{ApplySearchCase(input, searchCase)},
+			})
+		}
+
+		return
+	}
+
+	input := PromptWithInputListener(prompt, func(input string) {
+		if input != "" {
+			input = ApplySearchCase(input, searchCase)
+		}
+
+		err := incrementalSearchHandler(Action{
+			ActionType: ActionIncrementalSearch,
+			Args: []interface{}{Action{
+				ActionType: actionType,
+				Args:       []interface{}{input},
+			}},
+		})
+
+		if err != nil {
+			log.Debugf("Incremental search failed: %v", err)
+		}
+	})
+
+	err = incrementalSearchHandler(Action{
+		ActionType: ActionEndIncrementalSearch,
+		Args:       []interface{}{input != ""},
+	})
+
+	statusBarView.channels.ReportError(err)
+}
+
+func (statusBarView *StatusBarView) showFilterPrompt() {
+	statusBarView.promptType = ptFilter
+	input := Prompt(FilterPromptText)
+
+	if input != "" {
+		statusBarView.channels.DoAction(Action{
+			ActionType: ActionAddFilter,
+			Args:       []interface{}{input},
+		})
+	}
+
+	statusBarView.promptType = ptNone
+}
+
+// showPickaxePrompt adds a pickaxe filter using the provided option for the entered pattern
+func (statusBarView *StatusBarView) showPickaxePrompt(prompt, option string) {
+	statusBarView.promptType = ptPickaxe
 	input := Prompt(prompt)
 
-	if input == "" {
+	if input != "" {
 		statusBarView.channels.DoAction(Action{
-			ActionType: ActionClearSearch,
+			ActionType: ActionAddFilter,
+			Args:       []interface{}{fmt.Sprintf("%v %v", option, input)},
 		})
-	} else {
+	}
+
+	statusBarView.promptType = ptNone
+}
+
+func (statusBarView *StatusBarView) showIgnorePatternPrompt() {
+	statusBarView.promptType = ptIgnorePattern
+	input := strings.TrimSpace(Prompt(IgnorePatternPromptText))
+
+	if input != "" {
 		statusBarView.channels.DoAction(Action{
-			ActionType: actionType,
+			ActionType: ActionIgnorePattern,
 			Args:       []interface{}{input},
 		})
 	}
@@ -112,13 +296,27 @@ func (statusBarView *StatusBarView) showSearchPrompt(prompt string, actionType A
 	statusBarView.promptType = ptNone
 }
 
-func (statusBarView *StatusBarView) showFilterPrompt() {
-	statusBarView.promptType = ptFilter
-	input := Prompt(FilterPromptText)
+func (statusBarView *StatusBarView) showSetUpstreamPrompt() {
+	statusBarView.promptType = ptSetUpstream
+	input := Prompt(SetUpstreamPromptText)
 
 	if input != "" {
 		statusBarView.channels.DoAction(Action{
-			ActionType: ActionAddFilter,
+			ActionType: ActionSetUpstream,
+			Args:       []interface{}{input},
+		})
+	}
+
+	statusBarView.promptType = ptNone
+}
+
+func (statusBarView *StatusBarView) showSelectCommitPrompt() {
+	statusBarView.promptType = ptSelectCommit
+	input := Prompt(SelectCommitPromptText)
+
+	if input != "" {
+		statusBarView.channels.DoAction(Action{
+			ActionType: ActionSelectCommit,
 			Args:       []interface{}{input},
 		})
 	}
@@ -126,6 +324,95 @@ func (statusBarView *StatusBarView) showFilterPrompt() {
 	statusBarView.promptType = ptNone
 }
 
+func (statusBarView *StatusBarView) showMarkPrompt(prompt string, actionType ActionType) {
+	statusBarView.promptType = ptMark
+	input := strings.TrimSpace(Prompt(prompt))
+
+	if input != "" {
+		statusBarView.channels.DoAction(Action{
+			ActionType: actionType,
+			Args:       []interface{}{input},
+		})
+	}
+
+	statusBarView.promptType = ptNone
+}
+
+func (statusBarView *StatusBarView) showPruneRemoteBranchesPrompt(action Action) (err error) {
+	if !(len(action.Args) > 0) {
+		return fmt.Errorf("Expected stale remote branches argument")
+	}
+
+	staleBranches, ok := action.Args[0].([]Branch)
+	if !ok {
+		return fmt.Errorf("Expected stale remote branches argument to have type []Branch but got %T", action.Args[0])
+	}
+
+	var branchNames []string
+	for _, staleBranch := range staleBranches {
+		branchNames = append(branchNames, staleBranch.Shorthand())
+	}
+
+	statusBarView.confirm(Confirmation{
+		question: fmt.Sprintf("Prune stale remote branches %v", strings.Join(branchNames, ", ")),
+		action: Action{
+			ActionType: ActionPruneRemoteBranches,
+			Args:       []interface{}{staleBranches},
+		},
+	})
+
+	return
+}
+
+// showConfirmPrompt asks the question of the provided confirmation and performs its action if the user confirms
+func (statusBarView *StatusBarView) showConfirmPrompt(action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected confirmation argument")
+	}
+
+	confirmation, ok := action.Args[0].(Confirmation)
+	if !ok {
+		return fmt.Errorf("Expected confirmation argument to have type Confirmation but got %T", action.Args[0])
+	}
+
+	statusBarView.confirm(confirmation)
+
+	return
+}
+
+// confirm performs the action of the confirmation if the user confirms it.
+// No prompt is displayed if the action is configured not to require confirmation
+func (statusBarView *StatusBarView) confirm(confirmation Confirmation) {
+	if noConfirmActions, err := ParseNoConfirmActions(statusBarView.config.GetString(CfNoConfirm)); err == nil &&
+		noConfirmActions[confirmation.action.ActionType] {
+		statusBarView.channels.DoAction(confirmation.action)
+		return
+	}
+
+	statusBarView.promptType = ptConfirm
+	statusBarView.typedResponse = confirmation.typedResponse
+	input := Prompt(confirmation.Prompt())
+
+	if confirmation.IsConfirmedBy(input) {
+		statusBarView.channels.DoAction(confirmation.action)
+	}
+
+	statusBarView.promptType = ptNone
+	statusBarView.typedResponse = ""
+}
+
+// OnStatusChanged updates whether the working tree has uncommitted changes
+func (statusBarView *StatusBarView) OnStatusChanged(status *Status) {
+	statusBarView.lock.Lock()
+	defer statusBarView.lock.Unlock()
+
+	if dirty := status.HasUncommittedChanges(); dirty != statusBarView.dirty {
+		log.Debugf("Working tree has uncommitted changes: %v", dirty)
+		statusBarView.dirty = dirty
+		statusBarView.channels.UpdateDisplay()
+	}
+}
+
 // OnActiveChange updates the active state of this view
 func (statusBarView *StatusBarView) OnActiveChange(active bool) {
 	statusBarView.lock.Lock()
@@ -141,7 +428,8 @@ func (statusBarView *StatusBarView) ViewID() ViewID {
 }
 
 // Render generates and draws the status view to the provided window
-// If the readline prompt is active then this is drawn
+// If the readline prompt is active then this is drawn.
+// Otherwise the last status is drawn along with the segments contributed by the active view
 func (statusBarView *StatusBarView) Render(win RenderWindow) (err error) {
 	statusBarView.lock.Lock()
 	defer statusBarView.lock.Unlock()
@@ -155,7 +443,7 @@ func (statusBarView *StatusBarView) Render(win RenderWindow) (err error) {
 		promptText, promptInput, promptPoint := PromptState()
 		lineBuilder.Append("%v%v", promptText, promptInput)
 		bytes := 0
-		characters := len(promptText)
+		characters := int(StringWidth(promptText))
 
 		for _, char := range promptInput {
 			bytes += utf8.RuneLen(char)
@@ -169,8 +457,40 @@ func (statusBarView *StatusBarView) Render(win RenderWindow) (err error) {
 
 		err = win.SetCursor(0, uint(characters))
 	} else {
-		lineBuilder.Append(" %v", statusBarView.pendingStatus)
 		win.ApplyStyle(CmpStatusbarviewNormal)
+		status := fmt.Sprintf(" %v", statusBarView.statusMessage.text)
+		lineBuilder.AppendWithStyle(statusBarView.statusMessage.ThemeComponentID(), "%v", status)
+		err = statusBarView.renderSegments(lineBuilder, win.Cols(), StringWidth(status))
+	}
+
+	return
+}
+
+// renderSegments right aligns the segments contributed by the active view.
+// A segment is included when the working tree has uncommitted changes.
+// Segments are not drawn if there is insufficient space to display them after the status
+func (statusBarView *StatusBarView) renderSegments(lineBuilder *LineBuilder, cols, statusWidth uint) (err error) {
+	statusBarSegments := &StatusBarSegments{}
+
+	if statusBarView.dirty {
+		statusBarSegments.Add(SegmentDirty, "Uncommitted changes")
+	}
+
+	if statusBarView.statusBarRenderer != nil {
+		if err = statusBarView.statusBarRenderer.RenderStatusBar(statusBarSegments); err != nil {
+			return
+		}
+	}
+
+	segmentsWidth := statusBarSegments.Width()
+	if segmentsWidth == 0 || statusWidth+segmentsWidth >= cols {
+		return
+	}
+
+	lineBuilder.AppendWithStyle(CmpStatusbarviewNormal, "%v", strings.Repeat(" ", int(cols-statusWidth-segmentsWidth)))
+
+	for _, segment := range statusBarSegments.Segments() {
+		lineBuilder.AppendWithStyle(segment.ThemeComponentID(), " %v ", segment.text)
 	}
 
 	return
@@ -182,11 +502,27 @@ func (statusBarView *StatusBarView) RenderHelpBar(lineBuilder *LineBuilder) (err
 
 	switch statusBarView.promptType {
 	case ptCommand:
-		message = "Enter a command"
+		message = "Enter a command (prefix with ! to run a shell command)"
 	case ptSearch:
-		message = "Enter a regex pattern"
+		message = "Enter a regex pattern (\\c to ignore case, \\C to match case)"
 	case ptFilter:
 		message = "Enter a filter query"
+	case ptSetUpstream:
+		message = "Enter a remote branch"
+	case ptSelectCommit:
+		message = "Enter a full or abbreviated commit id"
+	case ptMark:
+		message = "Enter a mark name (a single letter or digit)"
+	case ptConfirm:
+		if statusBarView.typedResponse != "" {
+			message = fmt.Sprintf("Enter %v to confirm", statusBarView.typedResponse)
+		} else {
+			message = "Enter y to confirm"
+		}
+	case ptPickaxe:
+		message = "Enter a pattern to find commits which add or remove it"
+	case ptIgnorePattern:
+		message = "Enter a pattern to add to .gitignore, e.g. *.log"
 	}
 
 	if message != "" {
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"time"
+)
+
+// StatusMessageLevel indicates the kind of feedback a status message provides
+type StatusMessageLevel int
+
+// The set of status message levels
+const (
+	SmlInfo StatusMessageLevel = iota
+	SmlSuccess
+	SmlWarning
+	SmlError
+)
+
+var statusMessageThemeComponents = map[StatusMessageLevel]ThemeComponentID{
+	SmlInfo:    CmpStatusbarviewNormal,
+	SmlSuccess: CmpStatusbarviewSuccess,
+	SmlWarning: CmpStatusbarviewWarning,
+	SmlError:   CmpStatusbarviewError,
+}
+
+// StatusMessage is transient feedback displayed in the status bar
+type StatusMessage struct {
+	level StatusMessageLevel
+	text  string
+}
+
+// ThemeComponentID returns the theme component the message is drawn with
+func (statusMessage StatusMessage) ThemeComponentID() ThemeComponentID {
+	if themeComponentID, ok := statusMessageThemeComponents[statusMessage.level]; ok {
+		return themeComponentID
+	}
+
+	return CmpStatusbarviewNormal
+}
+
+// DisplayDuration returns how long the message is displayed for before it is dismissed.
+// Warnings and errors are displayed for twice the configured timeout so they are not missed.
+// A duration of 0 means the message is displayed until it is replaced
+func (statusMessage StatusMessage) DisplayDuration(timeout time.Duration) time.Duration {
+	if timeout <= 0 {
+		return 0
+	}
+
+	switch statusMessage.level {
+	case SmlWarning, SmlError:
+		return timeout * 2
+	}
+
+	return timeout
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStatusMessageIsDrawnWithComponentForItsLevel(t *testing.T) {
+	themeComponentTests := []struct {
+		level                    StatusMessageLevel
+		expectedThemeComponentID ThemeComponentID
+	}{
+		{level: SmlInfo, expectedThemeComponentID: CmpStatusbarviewNormal},
+		{level: SmlSuccess, expectedThemeComponentID: CmpStatusbarviewSuccess},
+		{level: SmlWarning, expectedThemeComponentID: CmpStatusbarviewWarning},
+		{level: SmlError, expectedThemeComponentID: CmpStatusbarviewError},
+		{level: StatusMessageLevel(-1), expectedThemeComponentID: CmpStatusbarviewNormal},
+	}
+
+	for _, themeComponentTest := range themeComponentTests {
+		statusMessage := StatusMessage{level: themeComponentTest.level, text: "Fetched origin"}
+
+		if themeComponentID := statusMessage.ThemeComponentID(); themeComponentID != themeComponentTest.expectedThemeComponentID {
+			t.Errorf("Theme component does not match expected value for level %v. Expected: %v, Actual: %v",
+				themeComponentTest.level, themeComponentTest.expectedThemeComponentID, themeComponentID)
+		}
+	}
+}
+
+func TestStatusMessageDisplayDurationDependsOnLevel(t *testing.T) {
+	displayDurationTests := []struct {
+		level                   StatusMessageLevel
+		timeout                 time.Duration
+		expectedDisplayDuration time.Duration
+	}{
+		{level: SmlInfo, timeout: 5 * time.Second, expectedDisplayDuration: 5 * time.Second},
+		{level: SmlSuccess, timeout: 5 * time.Second, expectedDisplayDuration: 5 * time.Second},
+		{level: SmlWarning, timeout: 5 * time.Second, expectedDisplayDuration: 10 * time.Second},
+		{level: SmlError, timeout: 5 * time.Second, expectedDisplayDuration: 10 * time.Second},
+		{level: SmlError, timeout: 0, expectedDisplayDuration: 0},
+	}
+
+	for _, displayDurationTest := range displayDurationTests {
+		statusMessage := StatusMessage{level: displayDurationTest.level}
+
+		if displayDuration := statusMessage.DisplayDuration(displayDurationTest.timeout); displayDuration != displayDurationTest.expectedDisplayDuration {
+			t.Errorf("Display duration does not match expected value for level %v. Expected: %v, Actual: %v",
+				displayDurationTest.level, displayDurationTest.expectedDisplayDuration, displayDuration)
+		}
+	}
+}
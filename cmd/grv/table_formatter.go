@@ -8,6 +8,7 @@ import (
 
 const (
 	tfSeparator = " "
+	tfEllipsis  = '…'
 )
 
 type tableCellText struct {
@@ -21,15 +22,25 @@ type tableCell struct {
 
 // TableFormatter renders provided data in a tabular layout
 type TableFormatter struct {
-	config       Config
-	maxColWidths []uint
-	cells        [][]tableCell
+	config         Config
+	maxColWidths   []uint
+	colWidthLimits []uint
+	cells          [][]tableCell
 }
 
 // NewTableFormatter creates a new instance of the table formatter supporting the specified number of columns
 func NewTableFormatter(cols uint) *TableFormatter {
 	return &TableFormatter{
-		maxColWidths: make([]uint, cols),
+		maxColWidths:   make([]uint, cols),
+		colWidthLimits: make([]uint, cols),
+	}
+}
+
+// SetColWidthLimit limits the width of a column. Text in the column wider than the limit
+// is truncated and ends with an ellipsis. A limit of 0 allows the column to be any width
+func (tableFormatter *TableFormatter) SetColWidthLimit(colIndex, limit uint) {
+	if colIndex < uint(len(tableFormatter.colWidthLimits)) {
+		tableFormatter.colWidthLimits[colIndex] = limit
 	}
 }
 
@@ -216,7 +227,16 @@ func (tableFormatter *TableFormatter) determineMaxColWidths(border bool) {
 			column += uint(len(tfSeparator))
 		}
 
+		limit := tableFormatter.colWidthLimits[colIndex]
+		if limit > 0 && tableFormatter.maxColWidths[colIndex] > limit {
+			tableFormatter.maxColWidths[colIndex] = limit
+		}
+
 		for rowIndex := range tableFormatter.cells {
+			if limit > 0 {
+				tableFormatter.truncateCell(rowIndex, colIndex, column, limit)
+			}
+
 			width := tableFormatter.textWidth(rowIndex, colIndex, column)
 
 			if width > tableFormatter.maxColWidths[colIndex] {
@@ -227,12 +247,46 @@ func (tableFormatter *TableFormatter) determineMaxColWidths(border bool) {
 
 }
 
+// truncateCell removes text from the end of the cell so that it fits within the width limit.
+// The last character displayed is replaced with an ellipsis when text is removed
+func (tableFormatter *TableFormatter) truncateCell(rowIndex, colIndex int, column, limit uint) {
+	if tableFormatter.textWidth(rowIndex, colIndex, column) <= limit {
+		return
+	}
+
+	tableCell := &tableFormatter.cells[rowIndex][colIndex]
+	width := uint(0)
+
+	for entryIndex := range tableCell.textEntries {
+		textEntry := &tableCell.textEntries[entryIndex]
+		var text bytes.Buffer
+
+		for cluster, rest := NextGraphemeCluster(textEntry.text); cluster != ""; cluster, rest = NextGraphemeCluster(rest) {
+			clusterWidth := uint(0)
+
+			for _, renderedCodePoint := range DetermineRenderedGraphemeCluster(cluster, column+width, tableFormatter.config) {
+				clusterWidth += renderedCodePoint.width
+			}
+
+			if width+clusterWidth >= limit {
+				text.WriteRune(tfEllipsis)
+				textEntry.text = text.String()
+				tableCell.textEntries = tableCell.textEntries[:entryIndex+1]
+				return
+			}
+
+			text.WriteString(cluster)
+			width += clusterWidth
+		}
+	}
+}
+
 func (tableFormatter *TableFormatter) textWidth(rowIndex, colIndex int, column uint) (width uint) {
 	textEntries := tableFormatter.cells[rowIndex][colIndex].textEntries
 
 	for _, textEntry := range textEntries {
-		for _, codePoint := range textEntry.text {
-			renderedCodePoints := DetermineRenderedCodePoint(codePoint, column, tableFormatter.config)
+		for cluster, rest := NextGraphemeCluster(textEntry.text); cluster != ""; cluster, rest = NextGraphemeCluster(rest) {
+			renderedCodePoints := DetermineRenderedGraphemeCluster(cluster, column, tableFormatter.config)
 
 			for _, renderedCodePoint := range renderedCodePoints {
 				width += renderedCodePoint.width
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestCellsWiderThanColumnLimitAreTruncatedWithEllipsis(t *testing.T) {
+	tableFormatter := NewTableFormatter(2)
+	tableFormatter.SetColWidthLimit(0, 6)
+	tableFormatter.Resize(3)
+
+	cellValues := [][]string{
+		{"short", "a"},
+		{"exactly", "b"},
+		{"much longer value", "c"},
+	}
+
+	for rowIndex, rowValues := range cellValues {
+		for colIndex, value := range rowValues {
+			if err := tableFormatter.SetCell(uint(rowIndex), uint(colIndex), "%v", value); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+	}
+
+	if err := tableFormatter.PadCells(false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedRows := []string{
+		"short  a ",
+		"exact… b ",
+		"much … c ",
+	}
+
+	for rowIndex, expectedRow := range expectedRows {
+		if row, err := tableFormatter.RowString(uint(rowIndex)); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		} else if row != expectedRow {
+			t.Errorf("Row does not match expected value. Expected: %q, Actual: %q", expectedRow, row)
+		}
+	}
+}
+
+func TestTruncationRetainsStyleOfTruncatedText(t *testing.T) {
+	tableFormatter := NewTableFormatter(1)
+	tableFormatter.SetColWidthLimit(0, 4)
+	tableFormatter.Resize(1)
+
+	tableFormatter.SetCellWithStyle(0, 0, CmpCommitviewTag, "%v", "<v1>")
+	tableFormatter.AppendToCellWithStyle(0, 0, CmpCommitviewSummary, "%v", " summary")
+
+	if err := tableFormatter.PadCells(false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	textEntries := tableFormatter.cells[0][0].textEntries
+	if len(textEntries) != 1 || textEntries[0].text != "<v1…" || textEntries[0].themeComponentID != CmpCommitviewTag {
+		t.Errorf("Truncated text entries do not match expected value: %v", textEntries)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	glob "github.com/gobwas/glob"
+)
+
+// TagFilter determines which tags are displayed in the Ref View
+type TagFilter struct {
+	pattern string
+	matches func(tagName string) bool
+}
+
+// ParseTagFilter creates a tag filter from the provided pattern. Patterns of the form /regex/
+// are treated as regular expressions and all other patterns as globs. A nil filter is returned
+// for an empty pattern
+func ParseTagFilter(pattern string) (tagFilter *TagFilter, err error) {
+	if pattern == "" {
+		return
+	}
+
+	if len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		var regex *regexp.Regexp
+		if regex, err = regexp.Compile(pattern[1 : len(pattern)-1]); err != nil {
+			err = fmt.Errorf("Invalid tag filter regex %v: %v", pattern, err)
+			return
+		}
+
+		return &TagFilter{pattern: pattern, matches: regex.MatchString}, nil
+	}
+
+	tagGlob, err := glob.Compile(pattern)
+	if err != nil {
+		err = fmt.Errorf("Invalid tag filter glob %v: %v", pattern, err)
+		return
+	}
+
+	return &TagFilter{pattern: pattern, matches: tagGlob.Match}, nil
+}
+
+// FilterTags returns the tags whose names match the filter
+func (tagFilter *TagFilter) FilterTags(tags []*Tag) (filteredTags []*Tag) {
+	if tagFilter == nil {
+		return tags
+	}
+
+	for _, tag := range tags {
+		if tagFilter.matches(tag.Shorthand()) {
+			filteredTags = append(filteredTags, tag)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestTagFilterMatchesTagNames(t *testing.T) {
+	tests := []struct {
+		pattern         string
+		matchingTags    []string
+		nonMatchingTags []string
+	}{
+		{
+			pattern:         "v*",
+			matchingTags:    []string{"v1.0.0", "v2.1"},
+			nonMatchingTags: []string{"ci-build-1234", "release-1.0"},
+		},
+		{
+			pattern:         `/^v[0-9]+\.[0-9]+\.[0-9]+$/`,
+			matchingTags:    []string{"v1.0.0", "v10.2.3"},
+			nonMatchingTags: []string{"v1.0.0-rc1", "ci-build-1234"},
+		},
+	}
+
+	for _, test := range tests {
+		tagFilter, err := ParseTagFilter(test.pattern)
+		if err != nil {
+			t.Errorf("Unexpected error parsing tag filter %v: %v", test.pattern, err)
+			continue
+		}
+
+		for _, tagName := range test.matchingTags {
+			if !tagFilter.matches(tagName) {
+				t.Errorf("Expected tag %v to match tag filter %v", tagName, test.pattern)
+			}
+		}
+
+		for _, tagName := range test.nonMatchingTags {
+			if tagFilter.matches(tagName) {
+				t.Errorf("Expected tag %v not to match tag filter %v", tagName, test.pattern)
+			}
+		}
+	}
+}
+
+func TestEmptyTagFilterPatternReturnsNoFilter(t *testing.T) {
+	if tagFilter, err := ParseTagFilter(""); tagFilter != nil || err != nil {
+		t.Errorf("Expected no tag filter and no error. Actual: %v, %v", tagFilter, err)
+	}
+}
+
+func TestInvalidTagFilterRegexIsRejected(t *testing.T) {
+	if _, err := ParseTagFilter("/v[0-9/"); err == nil {
+		t.Errorf("Expected error for invalid tag filter regex")
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	slice "github.com/bradfitz/slice"
+)
+
+// TagSort determines the order tags are displayed in the Ref View
+type TagSort int
+
+// The set of tag sort orders
+const (
+	TsName TagSort = iota
+	TsDate
+)
+
+var tagSortNames = map[string]TagSort{
+	"name": TsName,
+	"date": TsDate,
+}
+
+// ParseTagSort returns the tag sort order with the provided name
+func ParseTagSort(name string) (tagSort TagSort, err error) {
+	tagSort, ok := tagSortNames[strings.ToLower(name)]
+	if !ok {
+		err = fmt.Errorf("Invalid tag sort %v. Valid values are name and date", name)
+	}
+
+	return
+}
+
+// SortTags returns the provided tags in the specified order. Tags are provided sorted by name
+// so the input is returned unmodified for TsName. For TsDate the newest tags are returned first
+// and tags with the same date are ordered by name
+func (tagSort TagSort) SortTags(tags []*Tag) []*Tag {
+	if tagSort != TsDate {
+		return tags
+	}
+
+	sortedTags := make([]*Tag, len(tags))
+	copy(sortedTags, tags)
+
+	slice.Sort(sortedTags, func(i, j int) bool {
+		if !sortedTags[i].date.Equal(sortedTags[j].date) {
+			return sortedTags[i].date.After(sortedTags[j].date)
+		}
+
+		return sortedTags[i].name < sortedTags[j].name
+	})
+
+	return sortedTags
+}
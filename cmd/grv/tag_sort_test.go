@@ -0,0 +1,57 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTagsAreSortedByDateNewestFirst(t *testing.T) {
+	tags := []*Tag{
+		{name: "refs/tags/v1.0.0", date: time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "refs/tags/v1.1.0", date: time.Date(2017, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "refs/tags/a-release", date: time.Date(2017, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "refs/tags/v0.9.0", date: time.Date(2016, 12, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	expectedNames := []string{"refs/tags/a-release", "refs/tags/v1.1.0", "refs/tags/v1.0.0", "refs/tags/v0.9.0"}
+
+	sortedTags := TsDate.SortTags(tags)
+
+	if len(sortedTags) != len(expectedNames) {
+		t.Fatalf("Expected %v tags but got %v", len(expectedNames), len(sortedTags))
+	}
+
+	for index, expectedName := range expectedNames {
+		if sortedTags[index].name != expectedName {
+			t.Errorf("Expected tag %v at index %v but got %v", expectedName, index, sortedTags[index].name)
+		}
+	}
+
+	if tags[0].name != "refs/tags/v1.0.0" {
+		t.Errorf("Expected input tags to be unmodified")
+	}
+}
+
+func TestTagsAreUnmodifiedWhenSortedByName(t *testing.T) {
+	tags := []*Tag{
+		{name: "refs/tags/v1.0.0"},
+		{name: "refs/tags/v1.1.0"},
+	}
+
+	sortedTags := TsName.SortTags(tags)
+
+	for index, tag := range tags {
+		if sortedTags[index] != tag {
+			t.Errorf("Expected tag %v at index %v but got %v", tag.name, index, sortedTags[index].name)
+		}
+	}
+}
+
+func TestParseTagSortReturnsErrorForInvalidValue(t *testing.T) {
+	if _, err := ParseTagSort("size"); err == nil {
+		t.Errorf("Expected error for invalid tag sort")
+	}
+
+	if tagSort, err := ParseTagSort("Date"); err != nil || tagSort != TsDate {
+		t.Errorf("Expected TsDate but got %v, error: %v", tagSort, err)
+	}
+}
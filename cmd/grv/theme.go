@@ -31,7 +31,13 @@ const (
 	CmpCommitviewShortOid
 	CmpCommitviewDate
 	CmpCommitviewAuthor
+	CmpCommitviewStats
+	CmpCommitviewSignature
 	CmpCommitviewSummary
+	CmpCommitviewCommitType
+	CmpCommitviewFeatCommitType
+	CmpCommitviewFixCommitType
+	CmpCommitviewRebaseCommand
 	CmpCommitviewTag
 	CmpCommitviewLocalBranch
 	CmpCommitviewRemoteBranch
@@ -44,6 +50,7 @@ const (
 	CmpDiffviewDifflineDiffCommitCommitter
 	CmpDiffviewDifflineDiffCommitCommitterDate
 	CmpDiffviewDifflineDiffCommitMessage
+	CmpDiffviewDifflineDiffCommitTrailer
 	CmpDiffviewDifflineDiffStatsFile
 	CmpDiffviewDifflineGitDiffHeader
 	CmpDiffviewDifflineGitDiffExtendedHeader
@@ -52,6 +59,7 @@ const (
 	CmpDiffviewDifflineHunkHeader
 	CmpDiffviewDifflineLineAdded
 	CmpDiffviewDifflineLineRemoved
+	CmpDiffviewDifflineLineNumber
 
 	CmpGitStatusStagedTitle
 	CmpGitStatusUnstagedTitle
@@ -63,6 +71,14 @@ const (
 	CmpGitStatusConflictedFile
 
 	CmpStatusbarviewNormal
+	CmpStatusbarviewMode
+	CmpStatusbarviewFilter
+	CmpStatusbarviewPosition
+	CmpStatusbarviewDirty
+	CmpStatusbarviewBisect
+	CmpStatusbarviewSuccess
+	CmpStatusbarviewWarning
+	CmpStatusbarviewError
 
 	CmpHelpbarviewSpecial
 	CmpHelpbarviewNormal
@@ -71,6 +87,46 @@ const (
 	CmpErrorViewFooter
 	CmpErrorViewErrors
 
+	CmpKeyHintViewTitle
+	CmpKeyHintViewFooter
+	CmpKeyHintViewKeys
+
+	CmpPluginviewTitle
+	CmpPluginviewFooter
+
+	CmpCommandOutputViewTitle
+	CmpCommandOutputViewFooter
+	CmpCommandOutputViewChangedLine
+
+	CmpMarkviewTitle
+	CmpMarkviewFooter
+	CmpMarkviewName
+	CmpMarkviewShortOid
+	CmpMarkviewSummary
+
+	CmpStashviewTitle
+	CmpStashviewFooter
+	CmpStashviewName
+	CmpStashviewMessage
+
+	CmpConflictviewTitle
+	CmpConflictviewFooter
+	CmpConflictviewFile
+	CmpConflictviewMarker
+	CmpConflictviewOurs
+	CmpConflictviewBase
+	CmpConflictviewTheirs
+	CmpBlameviewTitle
+	CmpBlameviewFooter
+	CmpBlameviewShortOid
+	CmpBlameviewDate
+	CmpBlameviewAuthor
+	CmpBlameviewLineNumber
+
+	CmpChangelogviewTitle
+	CmpChangelogviewFooter
+	CmpChangelogviewHeading
+
 	CmpCount
 )
 
@@ -48,10 +48,34 @@ func NewClassicTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorGreen),
 			},
+			CmpCommitviewStats: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
+			CmpCommitviewSignature: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorMagenta),
+			},
 			CmpCommitviewSummary: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorNone),
 			},
+			CmpCommitviewCommitType: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorMagenta),
+			},
+			CmpCommitviewFeatCommitType: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorGreen),
+			},
+			CmpCommitviewFixCommitType: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorRed),
+			},
+			CmpCommitviewRebaseCommand: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
 			CmpCommitviewTag: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorRed),
@@ -96,6 +120,10 @@ func NewClassicTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorNone),
 			},
+			CmpDiffviewDifflineDiffCommitTrailer: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
 			CmpDiffviewDifflineDiffStatsFile: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorBlue),
@@ -128,6 +156,10 @@ func NewClassicTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorRed),
 			},
+			CmpDiffviewDifflineLineNumber: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
 			CmpRefviewTitle: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorCyan),
@@ -168,6 +200,38 @@ func NewClassicTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorBlue),
 				fgcolor: NewSystemColor(ColorYellow),
 			},
+			CmpStatusbarviewMode: {
+				bgcolor: NewSystemColor(ColorYellow),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpStatusbarviewFilter: {
+				bgcolor: NewSystemColor(ColorBlue),
+				fgcolor: NewSystemColor(ColorWhite),
+			},
+			CmpStatusbarviewPosition: {
+				bgcolor: NewSystemColor(ColorBlue),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpStatusbarviewDirty: {
+				bgcolor: NewSystemColor(ColorBlue),
+				fgcolor: NewSystemColor(ColorRed),
+			},
+			CmpStatusbarviewBisect: {
+				bgcolor: NewSystemColor(ColorBlue),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
+			CmpStatusbarviewSuccess: {
+				bgcolor: NewSystemColor(ColorBlue),
+				fgcolor: NewSystemColor(ColorGreen),
+			},
+			CmpStatusbarviewWarning: {
+				bgcolor: NewSystemColor(ColorBlue),
+				fgcolor: NewSystemColor(ColorMagenta),
+			},
+			CmpStatusbarviewError: {
+				bgcolor: NewSystemColor(ColorBlue),
+				fgcolor: NewSystemColor(ColorRed),
+			},
 			CmpHelpbarviewSpecial: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorMagenta),
@@ -188,6 +252,138 @@ func NewClassicTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorRed),
 				fgcolor: NewSystemColor(ColorWhite),
 			},
+			CmpKeyHintViewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpKeyHintViewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpKeyHintViewKeys: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
+			CmpPluginviewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpPluginviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpCommandOutputViewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpCommandOutputViewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpCommandOutputViewChangedLine: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
+			CmpMarkviewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpMarkviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpMarkviewName: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorGreen),
+			},
+			CmpMarkviewShortOid: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
+			CmpMarkviewSummary: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpStashviewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpStashviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpStashviewName: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorGreen),
+			},
+			CmpStashviewMessage: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpConflictviewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpConflictviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpConflictviewFile: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
+			CmpConflictviewMarker: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorMagenta),
+			},
+			CmpConflictviewOurs: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorGreen),
+			},
+			CmpConflictviewBase: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpConflictviewTheirs: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorRed),
+			},
+			CmpBlameviewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpBlameviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpBlameviewShortOid: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
+			CmpBlameviewDate: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpBlameviewAuthor: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorGreen),
+			},
+			CmpBlameviewLineNumber: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
+			CmpChangelogviewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpChangelogviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpChangelogviewHeading: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
 			CmpGitStatusStagedTitle: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorMagenta),
@@ -272,10 +468,34 @@ func NewColdTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorCyan),
 			},
+			CmpCommitviewStats: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
+			CmpCommitviewSignature: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorMagenta),
+			},
 			CmpCommitviewSummary: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorNone),
 			},
+			CmpCommitviewCommitType: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpCommitviewFeatCommitType: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpCommitviewFixCommitType: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorMagenta),
+			},
+			CmpCommitviewRebaseCommand: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
 			CmpCommitviewTag: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorMagenta),
@@ -320,6 +540,10 @@ func NewColdTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorMagenta),
 			},
+			CmpDiffviewDifflineDiffCommitTrailer: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
 			CmpDiffviewDifflineDiffStatsFile: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorNone),
@@ -352,6 +576,10 @@ func NewColdTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorRed),
 			},
+			CmpDiffviewDifflineLineNumber: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
 			CmpRefviewTitle: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorBlue),
@@ -392,6 +620,38 @@ func NewColdTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorCyan),
 				fgcolor: NewSystemColor(ColorWhite),
 			},
+			CmpStatusbarviewMode: {
+				bgcolor: NewSystemColor(ColorWhite),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpStatusbarviewFilter: {
+				bgcolor: NewSystemColor(ColorCyan),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
+			CmpStatusbarviewPosition: {
+				bgcolor: NewSystemColor(ColorCyan),
+				fgcolor: NewSystemColor(ColorBlack),
+			},
+			CmpStatusbarviewDirty: {
+				bgcolor: NewSystemColor(ColorCyan),
+				fgcolor: NewSystemColor(ColorRed),
+			},
+			CmpStatusbarviewBisect: {
+				bgcolor: NewSystemColor(ColorCyan),
+				fgcolor: NewSystemColor(ColorMagenta),
+			},
+			CmpStatusbarviewSuccess: {
+				bgcolor: NewSystemColor(ColorCyan),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpStatusbarviewWarning: {
+				bgcolor: NewSystemColor(ColorCyan),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
+			CmpStatusbarviewError: {
+				bgcolor: NewSystemColor(ColorCyan),
+				fgcolor: NewSystemColor(ColorRed),
+			},
 			CmpHelpbarviewSpecial: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorCyan),
@@ -412,6 +672,138 @@ func NewColdTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorRed),
 				fgcolor: NewSystemColor(ColorWhite),
 			},
+			CmpKeyHintViewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpKeyHintViewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpKeyHintViewKeys: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
+			CmpPluginviewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpPluginviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpCommandOutputViewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpCommandOutputViewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpCommandOutputViewChangedLine: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
+			CmpMarkviewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpMarkviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpMarkviewName: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpMarkviewShortOid: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorMagenta),
+			},
+			CmpMarkviewSummary: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpStashviewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpStashviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpStashviewName: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpStashviewMessage: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpConflictviewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpConflictviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpConflictviewFile: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpConflictviewMarker: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorMagenta),
+			},
+			CmpConflictviewOurs: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorGreen),
+			},
+			CmpConflictviewBase: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpConflictviewTheirs: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorRed),
+			},
+			CmpBlameviewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpBlameviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpBlameviewShortOid: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorMagenta),
+			},
+			CmpBlameviewDate: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpBlameviewAuthor: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpBlameviewLineNumber: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpChangelogviewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpChangelogviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpChangelogviewHeading: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
 			CmpGitStatusStagedTitle: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorCyan),
@@ -497,10 +889,34 @@ func NewSolarizedTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewColorNumber(64),
 			},
+			CmpCommitviewStats: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(136),
+			},
+			CmpCommitviewSignature: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(125),
+			},
 			CmpCommitviewSummary: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorNone),
 			},
+			CmpCommitviewCommitType: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(61),
+			},
+			CmpCommitviewFeatCommitType: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(64),
+			},
+			CmpCommitviewFixCommitType: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(160),
+			},
+			CmpCommitviewRebaseCommand: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(136),
+			},
 			CmpCommitviewTag: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewColorNumber(160),
@@ -545,6 +961,10 @@ func NewSolarizedTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorNone),
 			},
+			CmpDiffviewDifflineDiffCommitTrailer: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(37),
+			},
 			CmpDiffviewDifflineDiffStatsFile: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewColorNumber(33),
@@ -577,6 +997,10 @@ func NewSolarizedTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewColorNumber(160),
 			},
+			CmpDiffviewDifflineLineNumber: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(245),
+			},
 			CmpRefviewTitle: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewColorNumber(37),
@@ -617,6 +1041,38 @@ func NewSolarizedTheme() MutableTheme {
 				bgcolor: NewColorNumber(235),
 				fgcolor: NewColorNumber(136),
 			},
+			CmpStatusbarviewMode: {
+				bgcolor: NewColorNumber(136),
+				fgcolor: NewColorNumber(235),
+			},
+			CmpStatusbarviewFilter: {
+				bgcolor: NewColorNumber(235),
+				fgcolor: NewColorNumber(125),
+			},
+			CmpStatusbarviewPosition: {
+				bgcolor: NewColorNumber(235),
+				fgcolor: NewColorNumber(37),
+			},
+			CmpStatusbarviewDirty: {
+				bgcolor: NewColorNumber(235),
+				fgcolor: NewColorNumber(160),
+			},
+			CmpStatusbarviewBisect: {
+				bgcolor: NewColorNumber(235),
+				fgcolor: NewColorNumber(136),
+			},
+			CmpStatusbarviewSuccess: {
+				bgcolor: NewColorNumber(235),
+				fgcolor: NewColorNumber(64),
+			},
+			CmpStatusbarviewWarning: {
+				bgcolor: NewColorNumber(235),
+				fgcolor: NewColorNumber(166),
+			},
+			CmpStatusbarviewError: {
+				bgcolor: NewColorNumber(235),
+				fgcolor: NewColorNumber(160),
+			},
 			CmpHelpbarviewSpecial: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewColorNumber(125),
@@ -637,6 +1093,138 @@ func NewSolarizedTheme() MutableTheme {
 				bgcolor: NewColorNumber(160),
 				fgcolor: NewColorNumber(245),
 			},
+			CmpKeyHintViewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(37),
+			},
+			CmpKeyHintViewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(37),
+			},
+			CmpKeyHintViewKeys: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(136),
+			},
+			CmpPluginviewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(37),
+			},
+			CmpPluginviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(37),
+			},
+			CmpCommandOutputViewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(37),
+			},
+			CmpCommandOutputViewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(37),
+			},
+			CmpCommandOutputViewChangedLine: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(136),
+			},
+			CmpMarkviewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(37),
+			},
+			CmpMarkviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(37),
+			},
+			CmpMarkviewName: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(64),
+			},
+			CmpMarkviewShortOid: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(136),
+			},
+			CmpMarkviewSummary: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpStashviewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(37),
+			},
+			CmpStashviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(37),
+			},
+			CmpStashviewName: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(64),
+			},
+			CmpStashviewMessage: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpConflictviewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(37),
+			},
+			CmpConflictviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(37),
+			},
+			CmpConflictviewFile: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(136),
+			},
+			CmpConflictviewMarker: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(125),
+			},
+			CmpConflictviewOurs: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(64),
+			},
+			CmpConflictviewBase: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(61),
+			},
+			CmpConflictviewTheirs: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(160),
+			},
+			CmpBlameviewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(37),
+			},
+			CmpBlameviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(37),
+			},
+			CmpBlameviewShortOid: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(136),
+			},
+			CmpBlameviewDate: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(33),
+			},
+			CmpBlameviewAuthor: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(64),
+			},
+			CmpBlameviewLineNumber: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(245),
+			},
+			CmpChangelogviewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(37),
+			},
+			CmpChangelogviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(37),
+			},
+			CmpChangelogviewHeading: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(136),
+			},
 			CmpGitStatusStagedTitle: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewColorNumber(61),
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// TmuxCommandMode determines how external commands are run when GRV is running inside tmux
+type TmuxCommandMode int
+
+// The set of tmux command modes
+const (
+	TcmSuspend TmuxCommandMode = iota
+	TcmPane
+	TcmWindow
+)
+
+var tmuxCommandModeNames = map[string]TmuxCommandMode{
+	"suspend": TcmSuspend,
+	"pane":    TcmPane,
+	"window":  TcmWindow,
+}
+
+// ParseTmuxCommandMode returns the tmux command mode with the provided name
+func ParseTmuxCommandMode(name string) (tmuxCommandMode TmuxCommandMode, err error) {
+	tmuxCommandMode, ok := tmuxCommandModeNames[strings.ToLower(name)]
+	if !ok {
+		err = fmt.Errorf("Invalid tmux command mode %v. Valid values are suspend, pane and window", name)
+	}
+
+	return
+}
+
+// insideTmux returns true if GRV is running inside a tmux session
+func insideTmux() bool {
+	return os.Getenv("TMUX") != ""
+}
+
+// tmuxCommandArgs returns the tmux command which runs the shell command in a new pane or window.
+// The pane or window remains open after the command completes until enter is pressed
+func tmuxCommandArgs(tmuxCommandMode TmuxCommandMode, dir, command string) []string {
+	tmuxCommand := "new-window"
+	if tmuxCommandMode == TcmPane {
+		tmuxCommand = "split-window"
+	}
+
+	command = fmt.Sprintf(`%v; printf '\nPress Enter to close'; read line`, command)
+
+	return []string{"tmux", tmuxCommand, "-c", dir, command}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTmuxCommandArgsRunCommandInPaneOrWindow(t *testing.T) {
+	tests := []struct {
+		tmuxCommandMode TmuxCommandMode
+		expectedArgs    []string
+	}{
+		{
+			tmuxCommandMode: TcmPane,
+			expectedArgs:    []string{"tmux", "split-window", "-c", "/repo", `git difftool; printf '\nPress Enter to close'; read line`},
+		},
+		{
+			tmuxCommandMode: TcmWindow,
+			expectedArgs:    []string{"tmux", "new-window", "-c", "/repo", `git difftool; printf '\nPress Enter to close'; read line`},
+		},
+	}
+
+	for _, test := range tests {
+		if args := tmuxCommandArgs(test.tmuxCommandMode, "/repo", "git difftool"); !reflect.DeepEqual(args, test.expectedArgs) {
+			t.Errorf("Args do not match expected value. Expected: %v, Actual: %v", test.expectedArgs, args)
+		}
+	}
+}
+
+func TestParseTmuxCommandModeRejectsUnknownMode(t *testing.T) {
+	if _, err := ParseTmuxCommandMode("split"); err == nil {
+		t.Errorf("Expected error when parsing unknown tmux command mode")
+	}
+}
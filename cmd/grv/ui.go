@@ -99,6 +99,7 @@ type UI interface {
 	Update([]*Window) error
 	Suspend()
 	Resume() error
+	RestoreTerminal()
 	Free()
 }
 
@@ -134,7 +135,7 @@ type NCursesUI struct {
 	pipe          signalPipe
 	maxColors     int
 	maxColorPairs int
-	suspended     bool
+	suspendCount  uint
 }
 
 // NewNCursesDisplay creates a new NCursesUI instance
@@ -153,6 +154,12 @@ func (ui *NCursesUI) Free() {
 	ui.free()
 }
 
+// RestoreTerminal ends ncurses mode so the terminal is usable after GRV exits following a panic.
+// The UI lock is not acquired as it may be held by the goroutine which panicked
+func (ui *NCursesUI) RestoreTerminal() {
+	gc.End()
+}
+
 func (ui *NCursesUI) free() {
 	log.Info("Deleting NCurses windows")
 
@@ -239,23 +246,38 @@ func (ui *NCursesUI) initialiseNCurses() (err error) {
 }
 
 // Suspend ends ncurses to leave the terminal in the correct state when
-// GRV is suspended
+// GRV is suspended. Calls can be nested, e.g. GRV can be stopped while an
+// external command is running, in which case ncurses is only ended once
 func (ui *NCursesUI) Suspend() {
 	ui.lock.Lock()
 	defer ui.lock.Unlock()
 
+	ui.suspendCount++
+	if ui.suspendCount > 1 {
+		log.Debugf("Display already suspended. Suspend count: %v", ui.suspendCount)
+		return
+	}
+
 	gc.End()
-	ui.suspended = true
 	ui.cancelGetInput()
 }
 
-// Resume reinitialises ncurses
+// Resume reinitialises ncurses and clears the screen so the next update redraws every view.
+// The display remains suspended until Resume has been called for each call to Suspend
 func (ui *NCursesUI) Resume() (err error) {
 	ui.lock.Lock()
 	defer ui.lock.Unlock()
 
+	if ui.suspendCount > 0 {
+		ui.suspendCount--
+	}
+
+	if ui.suspendCount > 0 {
+		log.Debugf("Display remains suspended. Suspend count: %v", ui.suspendCount)
+		return
+	}
+
 	ui.stdscr.Refresh()
-	ui.suspended = false
 	return ui.resize()
 }
 
@@ -284,7 +306,17 @@ func (ui *NCursesUI) resize() (err error) {
 		return fmt.Errorf("NCurses ResizeTerm failed: %v", err)
 	}
 
-	return ui.initialiseNCurses()
+	if err = ui.initialiseNCurses(); err != nil {
+		return
+	}
+
+	if err = ui.stdscr.Clear(); err != nil {
+		return fmt.Errorf("NCurses Clear failed: %v", err)
+	}
+
+	ui.stdscr.NoutRefresh()
+
+	return
 }
 
 // ViewDimension returns the dimensions of the terminal
@@ -307,6 +339,11 @@ func (ui *NCursesUI) Update(wins []*Window) (err error) {
 
 	log.Debug("Updating display")
 
+	if ui.suspendCount > 0 {
+		log.Debug("Display is suspended")
+		return
+	}
+
 	if err = ui.createAndUpdateWindows(wins); err != nil {
 		return
 	}
@@ -452,7 +489,12 @@ func drawWindow(win *Window, nwin *nCursesWindow) {
 func (ui *NCursesUI) GetInput(force bool) (key Key, err error) {
 	key = UINoKey
 
-	if ui.suspended {
+	ui.lock.Lock()
+	suspended := ui.suspendCount > 0
+	ui.lock.Unlock()
+
+	if suspended {
+		time.Sleep(inputNoWinSleep)
 		return
 	}
 
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
 	"path/filepath"
 
@@ -16,6 +17,15 @@ func MinUint(x, y uint) uint {
 	return y
 }
 
+// MaxUint returns the largest values of the supplied arguments
+func MaxUint(x, y uint) uint {
+	if x > y {
+		return x
+	}
+
+	return y
+}
+
 // MaxInt returns the largest values of the supplied arguments
 func MaxInt(x, y int) int {
 	if x > y {
@@ -34,6 +44,23 @@ func Abs(x int) uint {
 	return uint(x)
 }
 
+// FormatNumber returns a string representation of the provided number
+// with a comma separating each group of three digits
+func FormatNumber(number uint) string {
+	digits := fmt.Sprintf("%v", number)
+	var buffer bytes.Buffer
+
+	for index, digit := range digits {
+		if index > 0 && (len(digits)-index)%3 == 0 {
+			buffer.WriteRune(',')
+		}
+
+		buffer.WriteRune(digit)
+	}
+
+	return buffer.String()
+}
+
 // IsNonPrintableCharacter returns true if the provided character is a non-printable ASCII character
 func IsNonPrintableCharacter(codePoint rune) bool {
 	return (codePoint >= 0 && codePoint < 32) || codePoint == 127
@@ -49,6 +76,15 @@ func RuneWidth(codePoint rune) int {
 	return rw.RuneWidth(codePoint)
 }
 
+// StringWidth returns the number of columns required to display the provided string
+func StringWidth(str string) (width uint) {
+	for cluster, rest := NextGraphemeCluster(str); cluster != ""; cluster, rest = NextGraphemeCluster(rest) {
+		width += GraphemeClusterWidth(cluster)
+	}
+
+	return
+}
+
 // NonPrintableCharString converts a control character into a string representation
 func NonPrintableCharString(codePoint rune) string {
 	if IsNonPrintableCharacter(codePoint) {
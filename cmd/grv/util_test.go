@@ -36,6 +36,38 @@ func TestMinUint(t *testing.T) {
 	}
 }
 
+func TestMaxUint(t *testing.T) {
+	var maxTests = []struct {
+		arg1           uint
+		arg2           uint
+		expectedResult uint
+	}{
+		{
+			arg1:           1,
+			arg2:           2,
+			expectedResult: 2,
+		},
+		{
+			arg1:           5,
+			arg2:           4,
+			expectedResult: 5,
+		},
+		{
+			arg1:           5,
+			arg2:           5,
+			expectedResult: 5,
+		},
+	}
+
+	for _, maxTest := range maxTests {
+		actualResult := MaxUint(maxTest.arg1, maxTest.arg2)
+
+		if actualResult != maxTest.expectedResult {
+			t.Errorf("Max return arg does not match expected arg. Expected: %v, Actual: %v", maxTest.expectedResult, actualResult)
+		}
+	}
+}
+
 func TestMaxInt(t *testing.T) {
 	var maxTests = []struct {
 		arg1           int
@@ -101,6 +133,38 @@ func TestAbs(t *testing.T) {
 	}
 }
 
+func TestFormatNumber(t *testing.T) {
+	var formatNumberTests = []struct {
+		arg            uint
+		expectedResult string
+	}{
+		{
+			arg:            0,
+			expectedResult: "0",
+		},
+		{
+			arg:            999,
+			expectedResult: "999",
+		},
+		{
+			arg:            12340,
+			expectedResult: "12,340",
+		},
+		{
+			arg:            1234567,
+			expectedResult: "1,234,567",
+		},
+	}
+
+	for _, formatNumberTest := range formatNumberTests {
+		actualResult := FormatNumber(formatNumberTest.arg)
+
+		if actualResult != formatNumberTest.expectedResult {
+			t.Errorf("FormatNumber return value does not match expected value. Expected: %v, Actual: %v", formatNumberTest.expectedResult, actualResult)
+		}
+	}
+}
+
 func TestIsNonPrintableCharacter(t *testing.T) {
 	var isPrintableTests = []struct {
 		arg            rune
@@ -161,6 +225,34 @@ func TestRuneWidth(t *testing.T) {
 	}
 }
 
+func TestStringWidth(t *testing.T) {
+	var stringWidthTests = []struct {
+		arg            string
+		expectedResult uint
+	}{
+		{
+			arg:            "",
+			expectedResult: 0,
+		},
+		{
+			arg:            "abc",
+			expectedResult: 3,
+		},
+		{
+			arg:            "a世b",
+			expectedResult: 4,
+		},
+	}
+
+	for _, stringWidthTest := range stringWidthTests {
+		actualResult := StringWidth(stringWidthTest.arg)
+
+		if actualResult != stringWidthTest.expectedResult {
+			t.Errorf("StringWidth return value does not match expected value. Expected: %v, Actual: %v", stringWidthTest.expectedResult, actualResult)
+		}
+	}
+}
+
 func TestNonPrintableCharString(t *testing.T) {
 	var printableCharTests = []struct {
 		arg            rune
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"sync"
 
 	log "github.com/Sirupsen/logrus"
@@ -29,6 +30,17 @@ const (
 	ViewHelpBar
 	ViewError
 	ViewGitStatus
+	ViewPlugin
+	ViewCommandOutput
+	ViewMark
+	ViewDebug
+	ViewStash
+	ViewConflict
+	ViewBlame
+	ViewChangelog
+	ViewLog
+	ViewCommand
+	ViewKeyHint
 )
 
 // HelpRenderer renders help information
@@ -71,6 +83,12 @@ func (viewDimension ViewDimension) String() string {
 	return fmt.Sprintf("rows:%v,cols:%v", viewDimension.rows, viewDimension.cols)
 }
 
+// pageRows returns the number of rows available to display content in a view
+// of the provided dimensions once its border has been drawn
+func pageRows(viewDimension ViewDimension) uint {
+	return MaxUint(viewDimension.rows, 2) - 2
+}
+
 // RegisterViewListener is a function which registers an observer on a view
 type RegisterViewListener func(observer interface{}) error
 
@@ -81,10 +99,14 @@ type View struct {
 	activeViewPos     uint
 	grvStatusView     WindowViewCollection
 	channels          *Channels
-	config            Config
+	config            ConfigSetter
 	promptActive      bool
 	errorView         *ErrorView
 	errorViewWin      *Window
+	keyHintView       *KeyHintView
+	keyHintViewWin    *Window
+	pendingKeys       string
+	keyHints          []KeyHint
 	activeViewWin     *Window
 	errors            []error
 	windowViewFactory *WindowViewFactory
@@ -92,20 +114,22 @@ type View struct {
 }
 
 // NewView creates a new instance
-func NewView(repoData RepoData, channels *Channels, config ConfigSetter) (view *View) {
+func NewView(repoData RepoData, channels *Channels, config ConfigSetter, plugins *PluginManager, layoutManager *LayoutManager) (view *View) {
 	view = &View{
 		views: []WindowViewCollection{
-			NewHistoryView(repoData, channels, config),
+			NewHistoryView(repoData, channels, config, layoutManager),
 			NewStatusView(repoData, channels, config),
 		},
 		channels:          channels,
 		config:            config,
-		windowViewFactory: NewWindowViewFactory(repoData, channels, config),
+		windowViewFactory: NewWindowViewFactory(repoData, channels, config, plugins),
 	}
 
-	view.grvStatusView = NewGRVStatusView(view, repoData, channels, config)
+	view.grvStatusView = NewGRVStatusView(view, view, repoData, channels, config)
 	view.errorView = NewErrorView()
 	view.errorViewWin = NewWindow("errorView", config)
+	view.keyHintView = NewKeyHintView()
+	view.keyHintViewWin = NewWindow("keyHintView", config)
 	view.activeViewWin = NewWindow("activeView", config)
 
 	return
@@ -124,6 +148,60 @@ func (view *View) Initialise() (err error) {
 	return
 }
 
+// InitialisePager replaces the default tabs with a single tab
+// containing a diff view which displays the provided text
+func (view *View) InitialisePager(name string, reader io.Reader) (err error) {
+	diffView := NewDiffView(nil, view.channels, view.config)
+
+	if err = diffView.DisplayText(name, reader); err != nil {
+		return
+	}
+
+	pagerView := NewContainerView(view.channels, view.config)
+	pagerView.SetTitle("Pager")
+	pagerView.AddChildViews(diffView)
+
+	if err = pagerView.Initialise(); err != nil {
+		return
+	}
+
+	view.lock.Lock()
+	view.views = []WindowViewCollection{pagerView}
+	view.activeViewPos = 0
+	view.lock.Unlock()
+
+	view.OnActiveChange(true)
+
+	return
+}
+
+// OnResize lays out the tabs which are not currently displayed using the new dimensions.
+// This ensures no view retains a layout based on the previous dimensions. The displayed tab
+// is laid out when the display is next rendered
+func (view *View) OnResize(viewDimension ViewDimension) {
+	if viewDimension.rows < 4 {
+		return
+	}
+
+	view.lock.Lock()
+	childViews := append([]WindowViewCollection(nil), view.views...)
+	activeViewPos := view.activeViewPos
+	view.lock.Unlock()
+
+	activeViewDim := viewDimension
+	activeViewDim.rows -= 3
+
+	for childViewIndex, childView := range childViews {
+		if uint(childViewIndex) == activeViewPos {
+			continue
+		}
+
+		if _, err := childView.Render(activeViewDim); err != nil {
+			log.Errorf("Error when laying out tab %v: %v", childView.Title(), err)
+		}
+	}
+}
+
 // Render generates all windows to be drawn to the UI
 func (view *View) Render(viewDimension ViewDimension) (wins []*Window, err error) {
 	log.Debug("Rendering View")
@@ -146,6 +224,10 @@ func (view *View) Render(viewDimension ViewDimension) (wins []*Window, err error
 		view.determineErrorViewDimensions(&errorViewDim, &activeViewDim)
 	}
 
+	keyHintViewDim := viewDimension
+	keyHintViewDim.rows = 0
+	view.determineKeyHintViewDimensions(&keyHintViewDim, &activeViewDim)
+
 	view.lock.Lock()
 	childView := view.views[view.activeViewPos]
 	view.lock.Unlock()
@@ -179,6 +261,15 @@ func (view *View) Render(viewDimension ViewDimension) (wins []*Window, err error
 		startRow += errorViewDim.rows
 	}
 
+	if keyHintViewDim.rows > 0 {
+		if wins, err = view.renderKeyHintView(wins, keyHintViewDim); err != nil {
+			return
+		}
+
+		view.keyHintViewWin.SetPosition(startRow, 0)
+		startRow += keyHintViewDim.rows
+	}
+
 	statusViewWins, err := view.grvStatusView.Render(statusViewDim)
 	if err != nil {
 		return
@@ -227,6 +318,37 @@ func (view *View) renderErrorView(wins []*Window, errorViewDim, activeViewDim Vi
 	return
 }
 
+func (view *View) determineKeyHintViewDimensions(keyHintViewDim, activeViewDim *ViewDimension) {
+	view.lock.Lock()
+	view.keyHintView.SetKeyHints(view.pendingKeys, view.keyHints)
+	view.lock.Unlock()
+
+	keyHintRowsRequired := view.keyHintView.DisplayRowsRequired(keyHintViewDim.cols)
+	if keyHintRowsRequired == 0 {
+		return
+	}
+
+	if activeViewDim.rows > keyHintRowsRequired+viewMinActiveViewRows {
+		keyHintViewDim.rows = keyHintRowsRequired
+		activeViewDim.rows -= keyHintRowsRequired
+	} else {
+		log.Debugf("Unable to display key hints, not enough space")
+	}
+}
+
+func (view *View) renderKeyHintView(wins []*Window, keyHintViewDim ViewDimension) (allWins []*Window, err error) {
+	view.keyHintViewWin.Resize(keyHintViewDim)
+	view.keyHintViewWin.Clear()
+
+	if err = view.keyHintView.Render(view.keyHintViewWin); err != nil {
+		return
+	}
+
+	allWins = append(wins, view.keyHintViewWin)
+
+	return
+}
+
 func (view *View) renderActiveView(availableCols uint) (err error) {
 	viewTitles := make([]string, len(view.views))
 	cols := uint(0)
@@ -291,6 +413,15 @@ func (view *View) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
 	return
 }
 
+// RenderStatusBar is proxied to the active tab
+func (view *View) RenderStatusBar(statusBarSegments *StatusBarSegments) (err error) {
+	view.lock.Lock()
+	childView := view.views[view.activeViewPos]
+	view.lock.Unlock()
+
+	return RenderChildStatusBar(childView, statusBarSegments)
+}
+
 // HandleEvent passes the event on to all child views
 func (view *View) HandleEvent(event Event) (err error) {
 	view.lock.Lock()
@@ -311,9 +442,14 @@ func (view *View) HandleAction(action Action) (err error) {
 	log.Debugf("View handling action %v", action)
 
 	switch action.ActionType {
-	case ActionPrompt, ActionSearchPrompt, ActionReverseSearchPrompt, ActionFilterPrompt:
+	case ActionPrompt, ActionSearchPrompt, ActionReverseSearchPrompt, ActionFilterPrompt, ActionSetUpstreamPrompt,
+		ActionPruneRemoteBranchesPrompt, ActionSelectCommitPrompt, ActionSetMarkPrompt, ActionJumpToMarkPrompt,
+		ActionConfirmPrompt, ActionPickaxePrompt, ActionPickaxeRegexPrompt, ActionIgnorePatternPrompt:
 		err = view.prompt(action)
 		return
+	case ActionCycleDiffWhitespace:
+		err = view.cycleDiffWhitespace()
+		return
 	case ActionShowStatus:
 		view.lock.Lock()
 		defer view.lock.Unlock()
@@ -442,6 +578,24 @@ func (view *View) SetErrors(errors []error) {
 	view.errors = errors
 }
 
+// SetKeyHints sets the key hints to be displayed for a partially entered key sequence.
+// The key hint view is hidden when no key hints are provided
+func (view *View) SetKeyHints(pendingKeys string, keyHints []KeyHint) {
+	view.lock.Lock()
+	defer view.lock.Unlock()
+
+	view.pendingKeys = pendingKeys
+	view.keyHints = keyHints
+}
+
+// KeyHints returns the key hints currently displayed
+func (view *View) KeyHints() []KeyHint {
+	view.lock.Lock()
+	defer view.lock.Unlock()
+
+	return view.keyHints
+}
+
 // Title returns the title of this view
 func (view *View) Title() string {
 	return "Main View"
@@ -454,6 +608,10 @@ func (view *View) prompt(action Action) (err error) {
 	view.promptActive = true
 	view.lock.Unlock()
 
+	if action.ActionType == ActionSearchPrompt || action.ActionType == ActionReverseSearchPrompt {
+		action.Args = append(action.Args, IncrementalSearchHandler(view.HandleAction))
+	}
+
 	err = view.grvStatusView.HandleAction(action)
 
 	view.lock.Lock()
@@ -467,6 +625,24 @@ func (view *View) prompt(action Action) (err error) {
 	return
 }
 
+// cycleDiffWhitespace sets the diffwhitespace config variable to the next mode
+func (view *View) cycleDiffWhitespace() (err error) {
+	diffWhitespace, _ := ParseDiffWhitespace(view.config.GetString(CfDiffWhitespace))
+	diffWhitespace = diffWhitespace.Next()
+
+	if errors := view.config.Evaluate(fmt.Sprintf("set %v %v", CfDiffWhitespace, diffWhitespace)); len(errors) > 0 {
+		return errors[0]
+	}
+
+	if description := diffWhitespace.Description(); description != "" {
+		view.channels.ReportStatus("Diffs are generated %v", description)
+	} else {
+		view.channels.ReportStatus("Diffs include all whitespace changes")
+	}
+
+	return
+}
+
 func (view *View) nextTab() {
 	view.activeViewPos++
 	view.activeViewPos %= uint(len(view.views))
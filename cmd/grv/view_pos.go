@@ -5,18 +5,29 @@ type ViewPos interface {
 	ActiveRowIndex() uint
 	SetActiveRowIndex(activeRowIndex uint)
 	ViewStartRowIndex() uint
+	SetViewStartRowIndex(viewStartRowIndex uint)
 	ViewStartColumn() uint
 	SelectedRowIndex() uint
-	DetermineViewStartRow(viewRows, rows uint)
+	DetermineViewStartRow(viewRows, rows, scrollOff uint)
 	MoveLineDown(rows uint) (changed bool)
+	MoveLinesDown(lineNum, rows uint) (changed bool)
 	MoveLineUp() (changed bool)
+	MoveLinesUp(lineNum uint) (changed bool)
 	MovePageDown(pageRows, rows uint) (changed bool)
+	MovePagesDown(pageNum, pageRows, rows uint) (changed bool)
 	MovePageUp(pageRows uint) (changed bool)
+	MovePagesUp(pageNum, pageRows uint) (changed bool)
+	MoveHalfPageDown(pageRows, rows uint) (changed bool)
+	MoveHalfPagesDown(halfPageNum, pageRows, rows uint) (changed bool)
+	MoveHalfPageUp(pageRows uint) (changed bool)
+	MoveHalfPagesUp(halfPageNum, pageRows uint) (changed bool)
 	MovePageRight(cols uint)
 	MovePageLeft(cols uint) (changed bool)
 	MoveToFirstLine() (changed bool)
 	MoveToLastLine(rows uint) (changed bool)
 	CenterActiveRow(pageRows uint) (changed bool)
+	ScrollActiveRowTop() (changed bool)
+	ScrollActiveRowBottom(pageRows uint) (changed bool)
 }
 
 // ViewPosition implements the ViewPos interface
@@ -50,6 +61,11 @@ func (viewPos *ViewPosition) ViewStartRowIndex() uint {
 	return viewPos.viewStartRowIndex
 }
 
+// SetViewStartRowIndex sets the row index the view should be drawn from
+func (viewPos *ViewPosition) SetViewStartRowIndex(viewStartRowIndex uint) {
+	viewPos.viewStartRowIndex = viewStartRowIndex
+}
+
 // ViewStartColumn returns the column the display should be drawn from
 func (viewPos *ViewPosition) ViewStartColumn() uint {
 	return viewPos.viewStartColumn
@@ -61,14 +77,28 @@ func (viewPos *ViewPosition) SelectedRowIndex() uint {
 }
 
 // DetermineViewStartRow determines the row the view should start displaying from based on the current cursor position
-func (viewPos *ViewPosition) DetermineViewStartRow(viewRows, rows uint) {
+// Where possible scrollOff rows are kept visible above and below the cursor
+func (viewPos *ViewPosition) DetermineViewStartRow(viewRows, rows, scrollOff uint) {
 	if rows > 0 && viewPos.activeRowIndex >= rows {
 		viewPos.activeRowIndex = rows - 1
 	}
 
-	if viewPos.viewStartRowIndex > viewPos.activeRowIndex {
-		viewPos.viewStartRowIndex = viewPos.activeRowIndex
-	} else if rowDiff := viewPos.activeRowIndex - viewPos.viewStartRowIndex; rowDiff >= viewRows {
+	if viewRows > 0 {
+		scrollOff = MinUint(scrollOff, (viewRows-1)/2)
+	} else {
+		scrollOff = 0
+	}
+
+	topMargin := MinUint(scrollOff, viewPos.activeRowIndex)
+	bottomMargin := uint(0)
+
+	if rows > viewPos.activeRowIndex+1 {
+		bottomMargin = MinUint(scrollOff, rows-(viewPos.activeRowIndex+1))
+	}
+
+	if viewPos.viewStartRowIndex+topMargin > viewPos.activeRowIndex {
+		viewPos.viewStartRowIndex = viewPos.activeRowIndex - topMargin
+	} else if rowDiff := viewPos.activeRowIndex + bottomMargin - viewPos.viewStartRowIndex; rowDiff >= viewRows {
 		viewPos.viewStartRowIndex += (rowDiff - viewRows) + 1
 	} else if visibleRows := rows - (viewPos.viewStartRowIndex + 1); visibleRows < viewRows && viewPos.viewStartRowIndex > 0 {
 		viewPos.viewStartRowIndex -= MinUint(viewPos.viewStartRowIndex, (viewRows-visibleRows)-1)
@@ -77,8 +107,13 @@ func (viewPos *ViewPosition) DetermineViewStartRow(viewRows, rows uint) {
 
 // MoveLineDown moves the cursor down one line
 func (viewPos *ViewPosition) MoveLineDown(rows uint) (changed bool) {
-	if viewPos.activeRowIndex+1 < rows {
-		viewPos.activeRowIndex++
+	return viewPos.MoveLinesDown(1, rows)
+}
+
+// MoveLinesDown moves the cursor down the provided number of lines, stopping at the last line
+func (viewPos *ViewPosition) MoveLinesDown(lineNum, rows uint) (changed bool) {
+	if viewPos.activeRowIndex+1 < rows && lineNum > 0 {
+		viewPos.activeRowIndex += MinUint(lineNum, rows-(viewPos.activeRowIndex+1))
 		changed = true
 	}
 
@@ -87,14 +122,39 @@ func (viewPos *ViewPosition) MoveLineDown(rows uint) (changed bool) {
 
 // MoveLineUp moves the cursor up one line
 func (viewPos *ViewPosition) MoveLineUp() (changed bool) {
-	if viewPos.activeRowIndex > 0 {
-		viewPos.activeRowIndex--
+	return viewPos.MoveLinesUp(1)
+}
+
+// MoveLinesUp moves the cursor up the provided number of lines, stopping at the first line
+func (viewPos *ViewPosition) MoveLinesUp(lineNum uint) (changed bool) {
+	if viewPos.activeRowIndex > 0 && lineNum > 0 {
+		viewPos.activeRowIndex -= MinUint(lineNum, viewPos.activeRowIndex)
 		changed = true
 	}
 
 	return
 }
 
+// MovePagesDown moves the cursor and display down the provided number of pages
+func (viewPos *ViewPosition) MovePagesDown(pageNum, pageRows, rows uint) (changed bool) {
+	return viewPos.MovePageDown(pageNum*pageRows, rows)
+}
+
+// MovePagesUp moves the cursor and display up the provided number of pages
+func (viewPos *ViewPosition) MovePagesUp(pageNum, pageRows uint) (changed bool) {
+	return viewPos.MovePageUp(pageNum * pageRows)
+}
+
+// MoveHalfPagesDown moves the cursor and display down the provided number of half pages
+func (viewPos *ViewPosition) MoveHalfPagesDown(halfPageNum, pageRows, rows uint) (changed bool) {
+	return viewPos.MovePageDown(halfPageNum*halfPageRows(pageRows), rows)
+}
+
+// MoveHalfPagesUp moves the cursor and display up the provided number of half pages
+func (viewPos *ViewPosition) MoveHalfPagesUp(halfPageNum, pageRows uint) (changed bool) {
+	return viewPos.MovePageUp(halfPageNum * halfPageRows(pageRows))
+}
+
 // MovePageDown moves the cursor and display down a page
 func (viewPos *ViewPosition) MovePageDown(pageRows, rows uint) (changed bool) {
 	if viewPos.activeRowIndex+1 < rows {
@@ -117,6 +177,20 @@ func (viewPos *ViewPosition) MovePageUp(pageRows uint) (changed bool) {
 	return
 }
 
+// MoveHalfPageDown moves the cursor and display down half a page
+func (viewPos *ViewPosition) MoveHalfPageDown(pageRows, rows uint) (changed bool) {
+	return viewPos.MovePageDown(halfPageRows(pageRows), rows)
+}
+
+// MoveHalfPageUp moves the cursor and display up half a page
+func (viewPos *ViewPosition) MoveHalfPageUp(pageRows uint) (changed bool) {
+	return viewPos.MovePageUp(halfPageRows(pageRows))
+}
+
+func halfPageRows(pageRows uint) uint {
+	return MaxUint(pageRows/2, 1)
+}
+
 // MovePageRight scrolls the view right a page (half the available view width)
 func (viewPos *ViewPosition) MovePageRight(cols uint) {
 	halfPage := cols / 2
@@ -175,3 +249,29 @@ func (viewPos *ViewPosition) CenterActiveRow(pageRows uint) (changed bool) {
 
 	return
 }
+
+// ScrollActiveRowTop scrolls the display so the active row is at the top of the view
+func (viewPos *ViewPosition) ScrollActiveRowTop() (changed bool) {
+	if viewPos.viewStartRowIndex != viewPos.activeRowIndex {
+		viewPos.viewStartRowIndex = viewPos.activeRowIndex
+		changed = true
+	}
+
+	return
+}
+
+// ScrollActiveRowBottom scrolls the display so the active row is at the bottom of the view
+func (viewPos *ViewPosition) ScrollActiveRowBottom(pageRows uint) (changed bool) {
+	var viewStartRowIndex uint
+
+	if viewPos.activeRowIndex+1 > pageRows {
+		viewStartRowIndex = viewPos.activeRowIndex + 1 - pageRows
+	}
+
+	if viewPos.viewStartRowIndex != viewStartRowIndex {
+		viewPos.viewStartRowIndex = viewStartRowIndex
+		changed = true
+	}
+
+	return
+}
@@ -137,6 +137,86 @@ func TestMovePageUpDoesNotUpdateActiveRowIndexAndViewStartRowIndexIfNoRowsLeft(t
 	checkViewPosResult(false, result, t)
 }
 
+func TestMoveHalfPageDownUpdatesActiveRowIndexAndViewStartRowIndex(t *testing.T) {
+	expected := newViewPos(7, 7, 1)
+
+	actual := newViewPos(2, 1, 1)
+	result := actual.MoveHalfPageDown(10, 20)
+
+	checkViewPos(expected, actual, t)
+	checkViewPosResult(true, result, t)
+}
+
+func TestMoveHalfPageDownMovesAtLeastOneRow(t *testing.T) {
+	expected := newViewPos(3, 3, 1)
+
+	actual := newViewPos(2, 1, 1)
+	result := actual.MoveHalfPageDown(1, 20)
+
+	checkViewPos(expected, actual, t)
+	checkViewPosResult(true, result, t)
+}
+
+func TestMoveHalfPageUpUpdatesActiveRowIndexAndViewStartRowIndex(t *testing.T) {
+	expected := newViewPos(2, 2, 1)
+
+	actual := newViewPos(7, 5, 1)
+	result := actual.MoveHalfPageUp(10)
+
+	checkViewPos(expected, actual, t)
+	checkViewPosResult(true, result, t)
+}
+
+func TestMoveHalfPageUpDoesNotUpdateActiveRowIndexAndViewStartRowIndexIfNoRowsLeft(t *testing.T) {
+	expected := newViewPos(0, 0, 1)
+
+	actual := newViewPos(0, 0, 1)
+	result := actual.MoveHalfPageUp(10)
+
+	checkViewPos(expected, actual, t)
+	checkViewPosResult(false, result, t)
+}
+
+func TestMoveLinesDownStopsAtLastRow(t *testing.T) {
+	expected := newViewPos(4, 0, 1)
+
+	actual := newViewPos(1, 0, 1)
+	result := actual.MoveLinesDown(10, 5)
+
+	checkViewPos(expected, actual, t)
+	checkViewPosResult(true, result, t)
+}
+
+func TestMoveLinesUpStopsAtFirstRow(t *testing.T) {
+	expected := newViewPos(0, 0, 1)
+
+	actual := newViewPos(3, 0, 1)
+	result := actual.MoveLinesUp(10)
+
+	checkViewPos(expected, actual, t)
+	checkViewPosResult(true, result, t)
+}
+
+func TestMovePagesDownMovesByMultiplePages(t *testing.T) {
+	expected := newViewPos(16, 16, 1)
+
+	actual := newViewPos(1, 0, 1)
+	result := actual.MovePagesDown(3, 5, 20)
+
+	checkViewPos(expected, actual, t)
+	checkViewPosResult(true, result, t)
+}
+
+func TestMoveHalfPagesUpMovesByMultipleHalfPages(t *testing.T) {
+	expected := newViewPos(5, 5, 1)
+
+	actual := newViewPos(15, 10, 1)
+	result := actual.MoveHalfPagesUp(2, 10)
+
+	checkViewPos(expected, actual, t)
+	checkViewPosResult(true, result, t)
+}
+
 func TestMovePageRightIncreasesViewStartColumnByHalfPageSize(t *testing.T) {
 	expected := newViewPos(0, 0, 6)
 
@@ -246,11 +326,51 @@ func TestCenterActiveRowDoesNothingIfViewAlreadyCentered(t *testing.T) {
 	checkViewPosResult(false, result, t)
 }
 
+func TestScrollActiveRowTopSetsViewStartRowIndexToActiveRowIndex(t *testing.T) {
+	expected := newViewPos(7, 7, 1)
+
+	actual := newViewPos(7, 3, 1)
+	result := actual.ScrollActiveRowTop()
+
+	checkViewPos(expected, actual, t)
+	checkViewPosResult(true, result, t)
+}
+
+func TestScrollActiveRowTopDoesNothingIfActiveRowIsAlreadyAtTop(t *testing.T) {
+	expected := newViewPos(7, 7, 1)
+
+	actual := newViewPos(7, 7, 1)
+	result := actual.ScrollActiveRowTop()
+
+	checkViewPos(expected, actual, t)
+	checkViewPosResult(false, result, t)
+}
+
+func TestScrollActiveRowBottomSetsViewStartRowIndexSoActiveRowIsOnLastRow(t *testing.T) {
+	expected := newViewPos(12, 3, 1)
+
+	actual := newViewPos(12, 10, 1)
+	result := actual.ScrollActiveRowBottom(10)
+
+	checkViewPos(expected, actual, t)
+	checkViewPosResult(true, result, t)
+}
+
+func TestScrollActiveRowBottomSetsViewStartRowIndexToZeroIfActiveRowIsOnFirstPage(t *testing.T) {
+	expected := newViewPos(4, 0, 1)
+
+	actual := newViewPos(4, 2, 1)
+	result := actual.ScrollActiveRowBottom(10)
+
+	checkViewPos(expected, actual, t)
+	checkViewPosResult(true, result, t)
+}
+
 func TestDetermineViewStartRowSetsViewStartRowIndexToActiveRowIndexIfGreater(t *testing.T) {
 	expected := newViewPos(5, 5, 1)
 
 	actual := newViewPos(5, 9, 1)
-	actual.DetermineViewStartRow(10, 20)
+	actual.DetermineViewStartRow(10, 20, 0)
 
 	checkViewPos(expected, actual, t)
 }
@@ -259,7 +379,7 @@ func TestDetermineViewStartRowIncreasesViewStartRowIndexSoThatActiveRowIndexIsVi
 	expected := newViewPos(15, 6, 1)
 
 	actual := newViewPos(15, 2, 1)
-	actual.DetermineViewStartRow(10, 20)
+	actual.DetermineViewStartRow(10, 20, 0)
 
 	checkViewPos(expected, actual, t)
 }
@@ -268,7 +388,7 @@ func TestDetermineViewStartRowDecreasesViewStartRowIndexSoThatAsManyRowsAreVisib
 	expected := newViewPos(15, 10, 1)
 
 	actual := newViewPos(15, 13, 1)
-	actual.DetermineViewStartRow(10, 20)
+	actual.DetermineViewStartRow(10, 20, 0)
 
 	checkViewPos(expected, actual, t)
 }
@@ -277,7 +397,43 @@ func TestDetermineViewStartRowDecreasesActiveRowIndexIfItIsGreaterThanTheTotalNu
 	expected := newViewPos(19, 10, 1)
 
 	actual := newViewPos(20, 10, 1)
-	actual.DetermineViewStartRow(10, 20)
+	actual.DetermineViewStartRow(10, 20, 0)
+
+	checkViewPos(expected, actual, t)
+}
+
+func TestDetermineViewStartRowDecreasesViewStartRowIndexToKeepScrollOffRowsAboveActiveRow(t *testing.T) {
+	expected := newViewPos(20, 17, 1)
+
+	actual := newViewPos(20, 18, 1)
+	actual.DetermineViewStartRow(10, 100, 3)
+
+	checkViewPos(expected, actual, t)
+}
+
+func TestDetermineViewStartRowIncreasesViewStartRowIndexToKeepScrollOffRowsBelowActiveRow(t *testing.T) {
+	expected := newViewPos(30, 24, 1)
+
+	actual := newViewPos(30, 18, 1)
+	actual.DetermineViewStartRow(10, 100, 3)
+
+	checkViewPos(expected, actual, t)
+}
+
+func TestDetermineViewStartRowLimitsScrollOffToHalfTheViewRows(t *testing.T) {
+	expected := newViewPos(30, 26, 1)
+
+	actual := newViewPos(30, 30, 1)
+	actual.DetermineViewStartRow(10, 100, 20)
+
+	checkViewPos(expected, actual, t)
+}
+
+func TestDetermineViewStartRowIgnoresScrollOffAtStartOfRows(t *testing.T) {
+	expected := newViewPos(1, 0, 1)
+
+	actual := newViewPos(1, 1, 1)
+	actual.DetermineViewStartRow(10, 100, 3)
 
 	checkViewPos(expected, actual, t)
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"sync"
 
 	log "github.com/Sirupsen/logrus"
@@ -13,13 +14,26 @@ type SearchableView interface {
 	OnSearchMatch(startPos ViewPos, matchLineIndex uint)
 }
 
+// IncrementalSearchHandler is provided to the search prompt to
+// update the search of the active view while the pattern is being typed
+type IncrementalSearchHandler func(Action) error
+
 // ViewSearch manages search functionality for a view
 type ViewSearch struct {
-	search               *Search
-	searchableView       SearchableView
-	channels             *Channels
-	lastSearchFoundMatch bool
-	lock                 sync.Mutex
+	search                *Search
+	searchableView        SearchableView
+	channels              *Channels
+	lastSearchFoundMatch  bool
+	highlightCleared      bool
+	incrementalSearch     *incrementalSearch
+	incrementalGeneration uint
+	lock                  sync.Mutex
+}
+
+type incrementalSearch struct {
+	startPos      ViewPos
+	startRowIndex uint
+	pending       bool
 }
 
 // NewViewSearch creates a new instance
@@ -30,12 +44,16 @@ func NewViewSearch(searchableView SearchableView, channels *Channels) *ViewSearc
 	}
 }
 
-// SearchActive returns the state of the most recent search (if one has been performed)
-func (viewSearch *ViewSearch) SearchActive() (active bool, pattern string, lastSearchFoundMatch bool) {
+// SearchActive returns the state of the most recent search (if one has been performed).
+// highlightMatches is true if the last search found a match and highlighting has not been cleared
+func (viewSearch *ViewSearch) SearchActive() (active bool, pattern string, highlightMatches bool) {
 	viewSearch.lock.Lock()
 	defer viewSearch.lock.Unlock()
 
-	return viewSearch.searchActive()
+	active, pattern, lastSearchFoundMatch := viewSearch.searchActive()
+	highlightMatches = lastSearchFoundMatch && !viewSearch.highlightCleared
+
+	return
 }
 
 func (viewSearch *ViewSearch) searchActive() (active bool, pattern string, lastSearchFoundMatch bool) {
@@ -64,6 +82,12 @@ func (viewSearch *ViewSearch) HandleAction(action Action) (handled bool, err err
 		err = viewSearch.findPrevMatch()
 	case ActionClearSearch:
 		err = viewSearch.clearSearch()
+	case ActionClearSearchHighlight:
+		err = viewSearch.clearSearchHighlight()
+	case ActionIncrementalSearch:
+		err = viewSearch.updateIncrementalSearch(action)
+	case ActionEndIncrementalSearch:
+		err = viewSearch.endIncrementalSearch(action)
 	default:
 		handled = false
 	}
@@ -89,12 +113,15 @@ func (viewSearch *ViewSearch) findNextMatch() (err error) {
 	}
 
 	viewPos := viewSearch.searchableView.ViewPos()
+	viewSearch.highlightCleared = false
 
 	viewSearch.channels.ReportStatus("Searching...")
 	log.Debugf("Searching for next occurrence of pattern %v starting from row index :%v",
 		pattern, viewPos.ActiveRowIndex())
 
 	go func() {
+		defer RecoverPanic()
+
 		matchLineIndex, found := viewSearch.search.FindNext(viewPos.ActiveRowIndex())
 
 		viewSearch.lock.Lock()
@@ -119,12 +146,15 @@ func (viewSearch *ViewSearch) findPrevMatch() (err error) {
 	}
 
 	viewPos := viewSearch.searchableView.ViewPos()
+	viewSearch.highlightCleared = false
 
 	viewSearch.channels.ReportStatus("Searching...")
 	log.Debugf("Searching for previous occurrence of pattern %v starting from row index :%v",
 		pattern, viewPos.ActiveRowIndex())
 
 	go func() {
+		defer RecoverPanic()
+
 		matchLineIndex, found := viewSearch.search.FindPrev(viewPos.ActiveRowIndex())
 
 		viewSearch.lock.Lock()
@@ -153,3 +183,143 @@ func (viewSearch *ViewSearch) clearSearch() (err error) {
 
 	return
 }
+
+// clearSearchHighlight stops highlighting matches until the search is next performed.
+// Unlike clearSearch, the search pattern is retained so further matches can still be found
+func (viewSearch *ViewSearch) clearSearchHighlight() (err error) {
+	if active, _, _ := viewSearch.searchActive(); active && !viewSearch.highlightCleared {
+		viewSearch.highlightCleared = true
+		viewSearch.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+// updateIncrementalSearch moves to the first match of the pattern being typed, searching from the
+// position the view was at when the search started. The view returns to that position if there is no match.
+// Patterns which are not yet valid regular expressions are ignored
+func (viewSearch *ViewSearch) updateIncrementalSearch(action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected search action argument")
+	}
+
+	searchAction, ok := action.Args[0].(Action)
+	if !ok {
+		return fmt.Errorf("Expected search action argument but found %T", action.Args[0])
+	}
+
+	if viewSearch.incrementalSearch == nil {
+		viewPos := viewSearch.searchableView.ViewPos()
+		viewSearch.incrementalSearch = &incrementalSearch{
+			startPos:      viewPos,
+			startRowIndex: viewPos.ActiveRowIndex(),
+		}
+	}
+
+	incremental := viewSearch.incrementalSearch
+	viewSearch.incrementalGeneration++
+	generation := viewSearch.incrementalGeneration
+	viewSearch.highlightCleared = false
+
+	search, err := CreateSearchFromAction(searchAction, viewSearch.searchableView)
+	if err != nil {
+		log.Debugf("Ignoring incomplete search pattern: %v", err)
+		return nil
+	} else if search.pattern == "" {
+		viewSearch.search = nil
+		viewSearch.lastSearchFoundMatch = false
+		incremental.pending = false
+		startPos, startRowIndex := incremental.startPos, incremental.startRowIndex
+
+		go func() {
+			defer RecoverPanic()
+			viewSearch.searchableView.OnSearchMatch(startPos, startRowIndex)
+		}()
+
+		return nil
+	}
+
+	viewSearch.search = search
+	incremental.pending = true
+
+	log.Debugf("Incrementally searching for pattern %v starting from row index :%v",
+		search.pattern, incremental.startRowIndex)
+
+	go func() {
+		defer RecoverPanic()
+
+		matchLineIndex, found := search.FindNext(incremental.startRowIndex)
+
+		viewSearch.lock.Lock()
+		if generation != viewSearch.incrementalGeneration {
+			viewSearch.lock.Unlock()
+			return
+		}
+
+		viewSearch.lastSearchFoundMatch = found
+		incremental.pending = false
+		reportStatus := viewSearch.incrementalSearch != incremental
+		viewSearch.lock.Unlock()
+
+		if !found {
+			matchLineIndex = incremental.startRowIndex
+		}
+
+		viewSearch.searchableView.OnSearchMatch(incremental.startPos, matchLineIndex)
+		viewSearch.channels.UpdateDisplay()
+
+		if reportStatus {
+			viewSearch.reportMatchStatus(found)
+		}
+	}()
+
+	return
+}
+
+// endIncrementalSearch retains the current search and position if the search was accepted.
+// Otherwise the search is cleared and the view returns to the position it was at when the search started
+func (viewSearch *ViewSearch) endIncrementalSearch(action Action) (err error) {
+	incremental := viewSearch.incrementalSearch
+	viewSearch.incrementalSearch = nil
+
+	if incremental == nil {
+		return
+	}
+
+	accepted, ok := false, len(action.Args) > 0
+	if ok {
+		accepted, ok = action.Args[0].(bool)
+	}
+
+	if !ok {
+		return fmt.Errorf("Expected search accepted argument but found: %v", action.Args)
+	}
+
+	if accepted && viewSearch.search != nil {
+		if !incremental.pending {
+			viewSearch.reportMatchStatus(viewSearch.lastSearchFoundMatch)
+		}
+
+		return
+	}
+
+	viewSearch.incrementalGeneration++
+	viewSearch.search = nil
+	viewSearch.lastSearchFoundMatch = false
+	startPos, startRowIndex := incremental.startPos, incremental.startRowIndex
+
+	go func() {
+		defer RecoverPanic()
+		viewSearch.searchableView.OnSearchMatch(startPos, startRowIndex)
+	}()
+
+	return
+}
+
+func (viewSearch *ViewSearch) reportMatchStatus(found bool) {
+	if found {
+		viewSearch.channels.ReportStatus("Match found")
+	} else {
+		viewSearch.channels.ReportStatus("No matches found")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"unicode"
+	"unicode/utf8"
 
 	log "github.com/Sirupsen/logrus"
 	rw "github.com/mattn/go-runewidth"
@@ -67,23 +68,29 @@ type RenderWindow interface {
 	LineBuilder(rowIndex, startColumn uint) (*LineBuilder, error)
 }
 
-// RenderedCodePoint contains the display values for a codepoint
+// RenderedCodePoint contains the display values for a codepoint.
+// extend contains any code points which are combined with the codepoint
+// into a single grapheme cluster and are drawn in the same cell
 type RenderedCodePoint struct {
 	width     uint
 	codePoint rune
+	extend    string
 }
 
 type line struct {
 	cells []*cell
 }
 
-// LineBuilder provides a way of drawing a single line to a window
+// LineBuilder provides a way of drawing a single line to a window.
+// Text is positioned by display column, so wide characters occupy multiple cells
+// and combining characters are drawn in the same cell as the character they modify
 type LineBuilder struct {
-	line        *line
-	cellIndex   uint
-	column      uint
-	startColumn uint
-	config      Config
+	line            *line
+	cellIndex       uint
+	column          uint
+	startColumn     uint
+	config          Config
+	lastCellVisible bool
 }
 
 type cellStyle struct {
@@ -161,20 +168,17 @@ func (lineBuilder *LineBuilder) AppendWithStyle(themeComponentID ThemeComponentI
 	str := fmt.Sprintf(format, args...)
 	line := lineBuilder.line
 
-	for _, codePoint := range str {
-		renderedCodePoints := DetermineRenderedCodePoint(codePoint, lineBuilder.column, lineBuilder.config)
+	for cluster, rest := NextGraphemeCluster(str); cluster != ""; cluster, rest = NextGraphemeCluster(rest) {
+		renderedCodePoints := DetermineRenderedGraphemeCluster(cluster, lineBuilder.column, lineBuilder.config)
 
 		for _, renderedCodePoint := range renderedCodePoints {
-			if lineBuilder.cellIndex > uint(len(line.cells)) {
-				break
+			if lineBuilder.cellIndex >= uint(len(line.cells)) {
+				return lineBuilder
 			}
 
-			if renderedCodePoint.width > 1 {
-				lineBuilder.setCellAndAdvanceIndex(renderedCodePoint.codePoint, renderedCodePoint.width, themeComponentID)
-				lineBuilder.Clear(renderedCodePoint.width - 1)
-			} else if renderedCodePoint.width > 0 {
-				lineBuilder.setCellAndAdvanceIndex(renderedCodePoint.codePoint, renderedCodePoint.width, themeComponentID)
-			} else {
+			if renderedCodePoint.width > 0 {
+				lineBuilder.setCellAndAdvanceIndex(renderedCodePoint, themeComponentID)
+			} else if lineBuilder.lastCellVisible {
 				lineBuilder.appendToPreviousCell(renderedCodePoint.codePoint)
 			}
 		}
@@ -197,26 +201,55 @@ func (lineBuilder *LineBuilder) AppendACSChar(acsChar AcsChar, themeComponentID
 		}
 
 		lineBuilder.column++
+		lineBuilder.lastCellVisible = false
 	}
 
 	return lineBuilder
 }
 
-func (lineBuilder *LineBuilder) setCellAndAdvanceIndex(codePoint rune, width uint, themeComponentID ThemeComponentID) {
+// setCellAndAdvanceIndex draws the code point at the current column. The cells following a wide
+// character are left empty as the terminal draws the character across them. If only part of a wide
+// character is visible, because it is scrolled past the start of the line or does not fit at the end,
+// then spaces are drawn in the visible cells instead
+func (lineBuilder *LineBuilder) setCellAndAdvanceIndex(renderedCodePoint RenderedCodePoint, themeComponentID ThemeComponentID) {
 	line := lineBuilder.line
+	cells := uint(len(line.cells))
+	width := renderedCodePoint.width
 
-	if lineBuilder.cellIndex < uint(len(line.cells)) {
-		if lineBuilder.column >= lineBuilder.startColumn {
-			cell := line.cells[lineBuilder.cellIndex]
-			cell.codePoints.Reset()
-			cell.codePoints.WriteRune(codePoint)
-			cell.style.themeComponentID = themeComponentID
-			cell.style.acsChar = 0
-			lineBuilder.cellIndex++
+	if lineBuilder.cellIndex >= cells {
+		return
+	}
+
+	endColumn := lineBuilder.column + width
+
+	switch {
+	case endColumn <= lineBuilder.startColumn:
+		lineBuilder.lastCellVisible = false
+	case lineBuilder.column < lineBuilder.startColumn || lineBuilder.cellIndex+width > cells:
+		visibleCells := endColumn - MaxUint(lineBuilder.column, lineBuilder.startColumn)
+
+		for i := uint(0); i < visibleCells && lineBuilder.cellIndex < cells; i++ {
+			lineBuilder.setCell(RenderedCodePoint{width: 1, codePoint: ' '}, themeComponentID)
 		}
 
-		lineBuilder.column += width
+		lineBuilder.lastCellVisible = false
+	default:
+		lineBuilder.setCell(renderedCodePoint, themeComponentID)
+		lineBuilder.Clear(width - 1)
+		lineBuilder.lastCellVisible = true
 	}
+
+	lineBuilder.column = endColumn
+}
+
+func (lineBuilder *LineBuilder) setCell(renderedCodePoint RenderedCodePoint, themeComponentID ThemeComponentID) {
+	cell := lineBuilder.line.cells[lineBuilder.cellIndex]
+	cell.codePoints.Reset()
+	cell.codePoints.WriteRune(renderedCodePoint.codePoint)
+	cell.codePoints.WriteString(renderedCodePoint.extend)
+	cell.style.themeComponentID = themeComponentID
+	cell.style.acsChar = 0
+	lineBuilder.cellIndex++
 }
 
 // Clear resets the next cellNum cells in the line
@@ -235,10 +268,16 @@ func (lineBuilder *LineBuilder) ToLineStart() {
 	lineBuilder.startColumn = 1
 }
 
+// appendToPreviousCell adds a zero width code point, such as a combining accent,
+// to the cell containing the most recently drawn character
 func (lineBuilder *LineBuilder) appendToPreviousCell(codePoint rune) {
-	if lineBuilder.cellIndex > 0 {
-		cell := lineBuilder.line.cells[lineBuilder.cellIndex-1]
-		cell.codePoints.WriteRune(codePoint)
+	cellIndex := int(lineBuilder.cellIndex) - 1
+
+	for ; cellIndex >= 0; cellIndex-- {
+		if lineBuilder.line.cells[cellIndex].codePoints.Len() > 0 {
+			lineBuilder.line.cells[cellIndex].codePoints.WriteRune(codePoint)
+			return
+		}
 	}
 }
 
@@ -433,14 +472,12 @@ func (win *Window) setHeader(rowIndex uint, rightJustified bool, themeComponentI
 	format = " " + format + " "
 
 	if rightJustified {
-		// Assume only ascii alphanumeric characters and space character
-		// present in footer text
-		formattedLen := uint(len([]rune(fmt.Sprintf(format, args...))))
-		if formattedLen > win.cols+2 {
+		formattedWidth := StringWidth(fmt.Sprintf(format, args...))
+		if formattedWidth+2 > win.cols {
 			return
 		}
 
-		lineBuilder.cellIndex = win.cols - (2 + formattedLen)
+		lineBuilder.cellIndex = win.cols - (2 + formattedWidth)
 	} else {
 		lineBuilder.cellIndex = 2
 	}
@@ -526,6 +563,39 @@ func (win *Window) ApplyStyle(themeComponentID ThemeComponentID) {
 	}
 }
 
+// DetermineRenderedGraphemeCluster converts a grapheme cluster into its rendered representation.
+// A cluster of printable code points is drawn as a single character
+func DetermineRenderedGraphemeCluster(cluster string, column uint, config Config) (renderedCodePoints []RenderedCodePoint) {
+	first, size := utf8.DecodeRuneInString(cluster)
+	renderedCodePoints = DetermineRenderedCodePoint(first, column, config)
+
+	if size == len(cluster) {
+		return
+	}
+
+	if len(renderedCodePoints) == 1 && renderedCodePoints[0].width > 0 && unicode.IsPrint(first) {
+		renderedCodePoints[0].width = GraphemeClusterWidth(cluster)
+		renderedCodePoints[0].extend = cluster[size:]
+		return
+	}
+
+	for _, renderedCodePoint := range renderedCodePoints {
+		column += renderedCodePoint.width
+	}
+
+	for _, codePoint := range cluster[size:] {
+		extendCodePoints := DetermineRenderedCodePoint(codePoint, column, config)
+
+		for _, renderedCodePoint := range extendCodePoints {
+			column += renderedCodePoint.width
+		}
+
+		renderedCodePoints = append(renderedCodePoints, extendCodePoints...)
+	}
+
+	return
+}
+
 // DetermineRenderedCodePoint converts a code point into its rendered representation
 func DetermineRenderedCodePoint(codePoint rune, column uint, config Config) (renderedCodePoints []RenderedCodePoint) {
 	if !unicode.IsPrint(codePoint) {
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"regexp"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 )
@@ -13,16 +14,18 @@ type WindowViewFactory struct {
 	repoData RepoData
 	channels *Channels
 	config   Config
+	plugins  *PluginManager
 }
 
 var hexRegexp = regexp.MustCompile(`^[[:xdigit:]]+$`)
 
 // NewWindowViewFactory creates a new instance
-func NewWindowViewFactory(repoData RepoData, channels *Channels, config Config) *WindowViewFactory {
+func NewWindowViewFactory(repoData RepoData, channels *Channels, config Config, plugins *PluginManager) *WindowViewFactory {
 	return &WindowViewFactory{
 		repoData: repoData,
 		channels: channels,
 		config:   config,
+		plugins:  plugins,
 	}
 }
 
@@ -42,6 +45,26 @@ func (windowViewFactory *WindowViewFactory) CreateWindowViewWithArgs(viewID View
 		windowView, err = windowViewFactory.createDiffView(args)
 	case ViewGitStatus:
 		windowView = windowViewFactory.createGitStatusView()
+	case ViewPlugin:
+		windowView, err = windowViewFactory.createPluginView(args)
+	case ViewCommandOutput:
+		windowView, err = windowViewFactory.createCommandOutputView(args)
+	case ViewMark:
+		windowView = windowViewFactory.createMarkView()
+	case ViewDebug:
+		windowView = windowViewFactory.createDebugView()
+	case ViewStash:
+		windowView = windowViewFactory.createStashView()
+	case ViewConflict:
+		windowView = windowViewFactory.createConflictView()
+	case ViewBlame:
+		windowView, err = windowViewFactory.createBlameView(args)
+	case ViewChangelog:
+		windowView, err = windowViewFactory.createChangelogView(args)
+	case ViewLog:
+		windowView = windowViewFactory.createLogView()
+	case ViewCommand:
+		windowView, err = windowViewFactory.createCommandView(args)
 	default:
 		err = fmt.Errorf("Unsupported view type: %v", viewID)
 	}
@@ -51,7 +74,7 @@ func (windowViewFactory *WindowViewFactory) CreateWindowViewWithArgs(viewID View
 
 func (windowViewFactory *WindowViewFactory) createRefView() *RefView {
 	log.Info("Created RefView instance")
-	return NewRefView(windowViewFactory.repoData, windowViewFactory.channels)
+	return NewRefView(windowViewFactory.repoData, windowViewFactory.channels, windowViewFactory.config)
 }
 
 func (windowViewFactory *WindowViewFactory) createCommitView(args []interface{}) (commitView *CommitView, err error) {
@@ -60,7 +83,7 @@ func (windowViewFactory *WindowViewFactory) createCommitView(args []interface{})
 		return
 	}
 
-	commitView = NewCommitView(windowViewFactory.repoData, windowViewFactory.channels)
+	commitView = NewCommitView(windowViewFactory.repoData, windowViewFactory.channels, windowViewFactory.config)
 
 	log.Info("Created CommitView instance")
 
@@ -73,12 +96,21 @@ func (windowViewFactory *WindowViewFactory) createCommitView(args []interface{})
 }
 
 func (windowViewFactory *WindowViewFactory) createDiffView(args []interface{}) (diffView *DiffView, err error) {
+	if len(args) > 0 {
+		if lineRange, ok := args[0].(LineRange); ok {
+			diffView = NewDiffView(windowViewFactory.repoData, windowViewFactory.channels, windowViewFactory.config)
+			log.Infof("Created DiffView instance for history of lines %v", lineRange)
+			diffView.OnLineRangeSelected(lineRange)
+			return
+		}
+	}
+
 	ref, err := windowViewFactory.getRef(args)
 	if err != nil {
 		return
 	}
 
-	diffView = NewDiffView(windowViewFactory.repoData, windowViewFactory.channels)
+	diffView = NewDiffView(windowViewFactory.repoData, windowViewFactory.channels, windowViewFactory.config)
 
 	log.Info("Created DiffView instance")
 
@@ -96,7 +128,7 @@ func (windowViewFactory *WindowViewFactory) createDiffView(args []interface{}) (
 }
 
 func (windowViewFactory *WindowViewFactory) createGitStatusView() *GitStatusView {
-	gitStatusView := NewGitStatusView(windowViewFactory.repoData, windowViewFactory.channels)
+	gitStatusView := NewGitStatusView(windowViewFactory.repoData, windowViewFactory.channels, windowViewFactory.config)
 
 	status := windowViewFactory.repoData.Status()
 	gitStatusView.OnStatusChanged(status)
@@ -106,6 +138,183 @@ func (windowViewFactory *WindowViewFactory) createGitStatusView() *GitStatusView
 	return gitStatusView
 }
 
+func (windowViewFactory *WindowViewFactory) createPluginView(args []interface{}) (pluginView *PluginView, err error) {
+	if len(args) == 0 {
+		err = fmt.Errorf("Expected plugin view name argument")
+		return
+	}
+
+	name, ok := args[0].(string)
+	if !ok {
+		err = fmt.Errorf("Expected plugin view name argument of type string but got type %T", args[0])
+		return
+	}
+
+	lineProvider, exists := windowViewFactory.plugins.ViewLineProvider(name)
+	if !exists {
+		err = fmt.Errorf("No plugin view registered with name %v", name)
+		return
+	}
+
+	pluginView = NewPluginView(name, lineProvider, windowViewFactory.channels, windowViewFactory.config)
+
+	log.Infof("Created PluginView instance for %v", name)
+
+	return
+}
+
+func (windowViewFactory *WindowViewFactory) createCommandOutputView(args []interface{}) (commandOutputView *CommandOutputView, err error) {
+	if len(args) == 0 {
+		err = fmt.Errorf("Expected command argument")
+		return
+	}
+
+	command, ok := args[0].(string)
+	if !ok {
+		err = fmt.Errorf("Expected command argument of type string but got type %T", args[0])
+		return
+	}
+
+	var env []string
+	if len(args) > 1 {
+		if env, ok = args[1].([]string); !ok {
+			err = fmt.Errorf("Expected environment argument of type []string but got type %T", args[1])
+			return
+		}
+	}
+
+	commandOutputView = NewCommandOutputView(command, windowViewFactory.repoData.Workdir(), env,
+		windowViewFactory.channels, windowViewFactory.config)
+
+	log.Infof("Created CommandOutputView instance for command: %v", command)
+
+	return
+}
+
+func (windowViewFactory *WindowViewFactory) createCommandView(args []interface{}) (commandView *CommandOutputView, err error) {
+	if len(args) < 4 {
+		err = fmt.Errorf("Expected command view name, command, environment and refresh interval arguments")
+		return
+	}
+
+	name, ok := args[0].(string)
+	if !ok {
+		err = fmt.Errorf("Expected command view name argument of type string but got type %T", args[0])
+		return
+	}
+
+	command, ok := args[1].(string)
+	if !ok {
+		err = fmt.Errorf("Expected command argument of type string but got type %T", args[1])
+		return
+	}
+
+	env, ok := args[2].([]string)
+	if !ok {
+		err = fmt.Errorf("Expected environment argument of type []string but got type %T", args[2])
+		return
+	}
+
+	refreshInterval, ok := args[3].(time.Duration)
+	if !ok {
+		err = fmt.Errorf("Expected refresh interval argument of type time.Duration but got type %T", args[3])
+		return
+	}
+
+	commandView = NewCommandView(name, command, windowViewFactory.repoData.Workdir(), env, refreshInterval,
+		windowViewFactory.channels, windowViewFactory.config)
+
+	log.Infof("Created command view instance %v for command: %v", name, command)
+
+	return
+}
+
+func (windowViewFactory *WindowViewFactory) createMarkView() *MarkView {
+	log.Info("Created MarkView instance")
+	return NewMarkView(windowViewFactory.repoData, windowViewFactory.channels, windowViewFactory.config)
+}
+
+func (windowViewFactory *WindowViewFactory) createDebugView() *DebugView {
+	log.Info("Created DebugView instance")
+	return NewDebugView(windowViewFactory.repoData, windowViewFactory.channels, windowViewFactory.config)
+}
+
+func (windowViewFactory *WindowViewFactory) createLogView() *LogView {
+	log.Info("Created LogView instance")
+	return NewLogView(sessionLog, windowViewFactory.channels, windowViewFactory.config)
+}
+
+func (windowViewFactory *WindowViewFactory) createStashView() *StashView {
+	log.Info("Created StashView instance")
+	return NewStashView(windowViewFactory.repoData, windowViewFactory.channels, windowViewFactory.config)
+}
+
+func (windowViewFactory *WindowViewFactory) createConflictView() *ConflictView {
+	log.Info("Created ConflictView instance")
+	return NewConflictView(windowViewFactory.repoData, windowViewFactory.channels, windowViewFactory.config)
+}
+
+func (windowViewFactory *WindowViewFactory) createBlameView(args []interface{}) (blameView *BlameView, err error) {
+	if len(args) > 0 {
+		if lineRange, ok := args[0].(LineRange); ok {
+			blameView = NewBlameView(lineRange.revision, lineRange.path, lineRange.start,
+				windowViewFactory.repoData, windowViewFactory.channels, windowViewFactory.config)
+			log.Infof("Created BlameView instance for %v", lineRange)
+			return
+		}
+	}
+
+	if len(args) < 1 {
+		err = fmt.Errorf("Expected path argument")
+		return
+	}
+
+	// An optional revision argument follows the path and defaults to HEAD
+	stringArgs := [2]string{"", "HEAD"}
+	for argIndex := 0; argIndex < len(args) && argIndex < len(stringArgs); argIndex++ {
+		arg, ok := args[argIndex].(string)
+		if !ok {
+			err = fmt.Errorf("Expected blame argument of type string but got type %T", args[argIndex])
+			return
+		}
+
+		stringArgs[argIndex] = arg
+	}
+
+	blameView = NewBlameView(stringArgs[1], stringArgs[0], 0,
+		windowViewFactory.repoData, windowViewFactory.channels, windowViewFactory.config)
+
+	log.Infof("Created BlameView instance for %v at %v", stringArgs[0], stringArgs[1])
+
+	return
+}
+
+func (windowViewFactory *WindowViewFactory) createChangelogView(args []interface{}) (changelogView *ChangelogView, err error) {
+	if len(args) < 2 {
+		err = fmt.Errorf("Expected from and to ref arguments")
+		return
+	}
+
+	// Optional trailer key and output file arguments follow the from and to refs
+	var stringArgs [4]string
+	for argIndex := 0; argIndex < len(args) && argIndex < len(stringArgs); argIndex++ {
+		arg, ok := args[argIndex].(string)
+		if !ok {
+			err = fmt.Errorf("Expected changelog argument of type string but got type %T", args[argIndex])
+			return
+		}
+
+		stringArgs[argIndex] = arg
+	}
+
+	changelogView = NewChangelogView(stringArgs[0], stringArgs[1], stringArgs[2], stringArgs[3],
+		windowViewFactory.repoData, windowViewFactory.channels, windowViewFactory.config)
+
+	log.Infof("Created ChangelogView instance for range %v..%v", stringArgs[0], stringArgs[1])
+
+	return
+}
+
 func (windowViewFactory *WindowViewFactory) getRef(args []interface{}) (ref Ref, err error) {
 	if len(args) == 0 {
 		return
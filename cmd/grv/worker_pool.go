@@ -0,0 +1,133 @@
+package main
+
+import (
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	wpJobQueueSize = 256
+)
+
+// WorkerJob is a unit of work executed by a worker pool
+type WorkerJob func()
+
+type workerPoolJob struct {
+	key string
+	job WorkerJob
+}
+
+// WorkerPool executes jobs concurrently on a fixed number of worker goroutines.
+// Each job is identified by a key and a job is not queued again while a job
+// with the same key is pending
+type WorkerPool struct {
+	workerNum int
+	jobCh     chan workerPoolJob
+	pending   map[string]bool
+	started   bool
+	stopped   bool
+	waitGroup sync.WaitGroup
+	lock      sync.Mutex
+}
+
+// NewWorkerPool creates a new worker pool which will use the specified number of workers
+func NewWorkerPool(workerNum int) *WorkerPool {
+	if workerNum < 1 {
+		workerNum = 1
+	}
+
+	return &WorkerPool{
+		workerNum: workerNum,
+		jobCh:     make(chan workerPoolJob, wpJobQueueSize),
+		pending:   make(map[string]bool),
+	}
+}
+
+// Start launches the worker goroutines
+func (workerPool *WorkerPool) Start() {
+	workerPool.lock.Lock()
+	defer workerPool.lock.Unlock()
+
+	if workerPool.started || workerPool.stopped {
+		return
+	}
+
+	workerPool.started = true
+	log.Infof("Starting worker pool with %v workers", workerPool.workerNum)
+
+	for workerIndex := 0; workerIndex < workerPool.workerNum; workerIndex++ {
+		workerPool.waitGroup.Add(1)
+		go workerPool.worker()
+	}
+}
+
+// Stop waits for running jobs to complete and stops all workers.
+// Jobs which have not yet started are discarded
+func (workerPool *WorkerPool) Stop() {
+	workerPool.lock.Lock()
+
+	if workerPool.stopped {
+		workerPool.lock.Unlock()
+		return
+	}
+
+	workerPool.stopped = true
+	close(workerPool.jobCh)
+	workerPool.lock.Unlock()
+
+	workerPool.waitGroup.Wait()
+	log.Info("Stopped worker pool")
+}
+
+// Submit queues the job for execution. false is returned if a job with the same key
+// is already pending, the job queue is full or the pool has been stopped
+func (workerPool *WorkerPool) Submit(key string, job WorkerJob) bool {
+	workerPool.lock.Lock()
+	defer workerPool.lock.Unlock()
+
+	if workerPool.stopped || workerPool.pending[key] {
+		return false
+	}
+
+	select {
+	case workerPool.jobCh <- workerPoolJob{key: key, job: job}:
+		workerPool.pending[key] = true
+		return true
+	default:
+		log.Debugf("Worker pool queue is full. Unable to submit job %v", key)
+		return false
+	}
+}
+
+// Pending returns true if a job with the provided key is queued or running
+func (workerPool *WorkerPool) Pending(key string) bool {
+	workerPool.lock.Lock()
+	defer workerPool.lock.Unlock()
+
+	return workerPool.pending[key]
+}
+
+func (workerPool *WorkerPool) worker() {
+	defer RecoverPanic()
+	defer workerPool.waitGroup.Done()
+
+	for poolJob := range workerPool.jobCh {
+		if workerPool.isStopped() {
+			continue
+		}
+
+		poolJob.job()
+
+		workerPool.lock.Lock()
+		delete(workerPool.pending, poolJob.key)
+		workerPool.lock.Unlock()
+	}
+}
+
+func (workerPool *WorkerPool) isStopped() bool {
+	workerPool.lock.Lock()
+	defer workerPool.lock.Unlock()
+
+	return workerPool.stopped
+}
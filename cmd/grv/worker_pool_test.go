@@ -0,0 +1,59 @@
+package main
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestWorkerPoolExecutesSubmittedJobs(t *testing.T) {
+	workerPool := NewWorkerPool(4)
+	workerPool.Start()
+
+	var waitGroup sync.WaitGroup
+	var lock sync.Mutex
+	executed := make(map[string]bool)
+
+	for _, key := range []string{"a", "b", "c", "d", "e"} {
+		key := key
+		waitGroup.Add(1)
+
+		if !workerPool.Submit(key, func() {
+			defer waitGroup.Done()
+			lock.Lock()
+			executed[key] = true
+			lock.Unlock()
+		}) {
+			t.Fatalf("Expected job %v to be submitted", key)
+		}
+	}
+
+	waitGroup.Wait()
+	workerPool.Stop()
+
+	if len(executed) != 5 {
+		t.Errorf("Executed job number does not match expected value. Expected: 5, Actual: %v", len(executed))
+	}
+}
+
+func TestWorkerPoolDoesNotQueuePendingJobAgain(t *testing.T) {
+	workerPool := NewWorkerPool(1)
+
+	if !workerPool.Submit("a", func() {}) {
+		t.Fatalf("Expected job to be submitted")
+	}
+
+	if workerPool.Submit("a", func() {}) {
+		t.Errorf("Expected pending job to not be submitted again")
+	}
+
+	if !workerPool.Pending("a") {
+		t.Errorf("Expected job to be pending")
+	}
+
+	workerPool.Start()
+	workerPool.Stop()
+
+	if workerPool.Submit("b", func() {}) {
+		t.Errorf("Expected job to not be submitted to stopped worker pool")
+	}
+}